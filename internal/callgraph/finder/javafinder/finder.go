@@ -14,6 +14,8 @@ func (f JavaFinder) FindRoots(files []string) ([]string, error) {
 	pomFiles := finder.FilterFiles(files, "pom.xml")
 	ps := PomService{}
 	rootFiles := ps.GetRootPomFiles(pomFiles)
+	gs := GradleService{}
+	rootFiles = append(rootFiles, gs.GetRootGradleFiles(files)...)
 
 	return rootFiles, nil
 }
@@ -17,6 +17,19 @@ func TestFindMavenRoots(t *testing.T) {
 	assert.Len(t, roots, 0)
 }
 
+func TestFindGradleRoots(t *testing.T) {
+	files := []string{
+		filepath.Join("testdata", "pom.xml"),
+		filepath.Join("gradle", "settings.gradle"),
+		filepath.Join("gradle", "app", "build.gradle"),
+	}
+	f := JavaFinder{}
+	roots, err := f.FindRoots(files)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{filepath.Join("testdata", "pom.xml"), filepath.Join("gradle", "settings.gradle")}, roots)
+}
+
 func TestFindDependencyDirs(t *testing.T) {
 	files := []string{"test/asd/pom.xml", "test2/basd/qwe/asd.class", "test2/test/asd", "test3/tes.jar"}
 	f := JavaFinder{}
@@ -0,0 +1,61 @@
+package javafinder
+
+import (
+	"path/filepath"
+	"sort"
+
+	"github.com/debricked/cli/internal/callgraph/finder"
+)
+
+const (
+	gradleSettingsPattern = `^settings\.gradle(\.kts)?$`
+	gradleBuildPattern    = `^build\.gradle(\.kts)?$`
+)
+
+type IGradleService interface {
+	GetRootGradleFiles(files []string) []string
+}
+
+type GradleService struct{}
+
+// GetRootGradleFiles returns the settings files of Gradle builds, along with the build files of single project builds
+// without settings. Build files and settings below the settings of another build are part of that build.
+func (g GradleService) GetRootGradleFiles(files []string) []string {
+	settingsDirs := map[string]string{}
+	for _, file := range finder.FilterFiles(files, gradleSettingsPattern) {
+		settingsDirs[filepath.Dir(file)] = file
+	}
+	buildDirs := map[string]string{}
+	for _, file := range finder.FilterFiles(files, gradleBuildPattern) {
+		buildDirs[filepath.Dir(file)] = file
+	}
+
+	roots := []string{}
+	for dir, file := range settingsDirs {
+		if !hasAncestor(filepath.Dir(dir), settingsDirs) {
+			roots = append(roots, file)
+		}
+	}
+	for dir, file := range buildDirs {
+		if !hasAncestor(dir, settingsDirs) {
+			roots = append(roots, file)
+		}
+	}
+	sort.Strings(roots)
+
+	return roots
+}
+
+// hasAncestor reports whether dir, or any of its parent directories, is in dirs
+func hasAncestor(dir string, dirs map[string]string) bool {
+	for {
+		if _, ok := dirs[dir]; ok {
+			return true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return false
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,34 @@
+package javafinder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRootGradleFiles(t *testing.T) {
+	multiProject := filepath.Join("multi", "settings.gradle.kts")
+	singleProject := filepath.Join("single", "build.gradle")
+	files := []string{
+		multiProject,
+		filepath.Join("multi", "build.gradle.kts"),
+		filepath.Join("multi", "app", "build.gradle.kts"),
+		filepath.Join("multi", "buildSrc", "settings.gradle.kts"),
+		singleProject,
+		filepath.Join("single", "gradle.properties"),
+		filepath.Join("other", "build.gradle.bak"),
+	}
+
+	g := GradleService{}
+	roots := g.GetRootGradleFiles(files)
+
+	assert.Equal(t, []string{multiProject, singleProject}, roots)
+}
+
+func TestGetRootGradleFilesNone(t *testing.T) {
+	g := GradleService{}
+	roots := g.GetRootGradleFiles([]string{filepath.Join("testdata", "pom.xml")})
+
+	assert.Empty(t, roots)
+}
@@ -0,0 +1,95 @@
+package javascriptfinder
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/debricked/cli/internal/file"
+)
+
+const (
+	packageJSON = "package.json"
+	nodeModules = "node_modules"
+)
+
+var sourceExtensions = map[string]bool{
+	".js": true, ".jsx": true, ".mjs": true, ".cjs": true, ".ts": true, ".tsx": true, ".mts": true, ".cts": true,
+}
+
+type JavaScriptFinder struct{}
+
+// FindRoots finds the package.json files of the application, each being the root of a project
+func (f JavaScriptFinder) FindRoots(files []string) ([]string, error) {
+	var roots []string
+	for _, file := range files {
+		if filepath.Base(file) == packageJSON && !inNodeModules(file) {
+			roots = append(roots, file)
+		}
+	}
+
+	return roots, nil
+}
+
+// Not needed for JavaScript, dependencies are resolved from node_modules when called
+func (f JavaScriptFinder) FindDependencyDirs(files []string, findJars bool) ([]string, error) {
+	return []string{}, nil
+}
+
+// FindFiles finds the package.json files and JavaScript and TypeScript sources, leaving out node_modules and
+// declaration files
+func (f JavaScriptFinder) FindFiles(roots []string, exclusions []string, inclusions []string) ([]string, error) {
+	files := make(map[string]bool)
+	var err error = nil
+
+	for _, root := range roots {
+		err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			excluded := file.Excluded(exclusions, inclusions, path)
+
+			if info.IsDir() && (excluded || info.Name() == nodeModules) {
+				return filepath.SkipDir
+			}
+
+			if !info.IsDir() && !excluded && (info.Name() == packageJSON || IsSource(path)) {
+				files[path] = true
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fileList := make([]string, 0, len(files))
+	for k := range files {
+		fileList = append(fileList, k)
+	}
+
+	return fileList, err
+}
+
+// IsSource reports whether a file is a JavaScript or TypeScript source, excluding declaration files
+func IsSource(path string) bool {
+	name := filepath.Base(path)
+	if strings.HasSuffix(name, ".d.ts") || strings.HasSuffix(name, ".d.mts") || strings.HasSuffix(name, ".d.cts") {
+		return false
+	}
+
+	return sourceExtensions[filepath.Ext(name)]
+}
+
+func inNodeModules(path string) bool {
+	for _, part := range strings.Split(filepath.ToSlash(path), "/") {
+		if part == nodeModules {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,71 @@
+package javascriptfinder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindRoots(t *testing.T) {
+	f := JavaScriptFinder{}
+	files := []string{
+		filepath.Join("testdata", "app", "package.json"),
+		filepath.Join("testdata", "app", "index.js"),
+		filepath.Join("testdata", "app", "nested", "package.json"),
+		filepath.Join("testdata", "app", "node_modules", "lib", "package.json"),
+	}
+	roots, err := f.FindRoots(files)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{files[0], files[2]}, roots)
+}
+
+func TestFindFiles(t *testing.T) {
+	f := JavaScriptFinder{}
+	files, err := f.FindFiles([]string{"testdata"}, nil, nil)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join("testdata", "app", "package.json"),
+		filepath.Join("testdata", "app", "index.js"),
+		filepath.Join("testdata", "app", "src", "run.ts"),
+		filepath.Join("testdata", "app", "nested", "package.json"),
+		filepath.Join("testdata", "app", "nested", "index.mjs"),
+	}, files)
+}
+
+func TestFindFilesExclusions(t *testing.T) {
+	f := JavaScriptFinder{}
+	files, err := f.FindFiles([]string{"testdata"}, []string{"**/nested/**"}, nil)
+	assert.Nil(t, err)
+	assert.NotContains(t, files, filepath.Join("testdata", "app", "nested", "index.mjs"))
+	assert.Contains(t, files, filepath.Join("testdata", "app", "index.js"))
+}
+
+func TestFindFilesError(t *testing.T) {
+	f := JavaScriptFinder{}
+	_, err := f.FindFiles([]string{"nonexistent"}, nil, nil)
+	assert.NotNil(t, err)
+}
+
+func TestFindDependencyDirs(t *testing.T) {
+	f := JavaScriptFinder{}
+	dirs, err := f.FindDependencyDirs([]string{filepath.Join("testdata", "app", "index.js")}, false)
+	assert.Nil(t, err)
+	assert.Empty(t, dirs)
+}
+
+func TestIsSource(t *testing.T) {
+	cases := map[string]bool{
+		"index.js":   true,
+		"app.tsx":    true,
+		"lib.mjs":    true,
+		"types.d.ts": false,
+		"data.json":  false,
+		"README.md":  false,
+	}
+	for name, expected := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, expected, IsSource(name))
+		})
+	}
+}
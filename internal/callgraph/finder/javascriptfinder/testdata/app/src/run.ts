@@ -0,0 +1 @@
+export const run = (): void => {}
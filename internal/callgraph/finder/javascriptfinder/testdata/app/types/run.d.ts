@@ -0,0 +1 @@
+export declare function run(): void
@@ -0,0 +1,98 @@
+package finder
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Project is a directory with one or more root files, such as package.json or requirements.txt
+type Project struct {
+	Dir       string
+	RootFiles []string
+	// Sources are the source files of the project, relative to Dir, leaving out those of projects nested in it
+	Sources []string
+}
+
+// FindProjects finds the projects in paths, with the files isSource reports as source files. Projects without
+// sources are left out.
+func FindProjects(
+	finder IFinder,
+	paths []string,
+	exclusions []string,
+	inclusions []string,
+	isSource func(file string) bool,
+) ([]Project, error) {
+	files, err := finder.FindFiles(paths, exclusions, inclusions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find files: %w", err)
+	}
+	roots, err := finder.FindRoots(files)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find roots: %w", err)
+	}
+
+	// A project often has several roots, such as requirements.txt and setup.py
+	var rootDirs []string
+	rootFiles := map[string][]string{}
+	for _, root := range roots {
+		rootDir := filepath.Dir(root)
+		if _, ok := rootFiles[rootDir]; !ok {
+			rootDirs = append(rootDirs, rootDir)
+		}
+		rootFiles[rootDir] = append(rootFiles[rootDir], root)
+	}
+
+	var projects []Project
+	for _, rootDir := range rootDirs {
+		sources := projectSources(rootDir, rootDirs, files, isSource)
+		if len(sources) > 0 {
+			projects = append(projects, Project{Dir: rootDir, RootFiles: rootFiles[rootDir], Sources: sources})
+		}
+	}
+
+	return projects, nil
+}
+
+func projectSources(rootDir string, rootDirs []string, files []string, isSource func(string) bool) []string {
+	var sources []string
+	for _, file := range files {
+		if !isSource(file) || projectDir(file, rootDirs) != rootDir {
+			continue
+		}
+		source, err := filepath.Rel(rootDir, file)
+		if err != nil {
+			continue
+		}
+		sources = append(sources, source)
+	}
+
+	return sources
+}
+
+// projectDir returns the innermost of rootDirs that file is in
+func projectDir(file string, rootDirs []string) string {
+	dir := ""
+	for _, rootDir := range rootDirs {
+		if isIn(file, rootDir) && len(rootDir) > len(dir) {
+			dir = rootDir
+		}
+	}
+
+	return dir
+}
+
+func isIn(file string, dir string) bool {
+	relative, err := filepath.Rel(dir, file)
+
+	return err == nil && relative != ".." && !strings.HasPrefix(relative, ".."+string(filepath.Separator))
+}
+
+// StrategyWarning logs a warning of a call graph strategy
+func StrategyWarning(errMsg string) {
+	warningColor := color.New(color.FgYellow, color.Bold).SprintFunc()
+	log.Println(warningColor("Warning: ") + errMsg)
+}
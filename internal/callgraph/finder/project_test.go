@@ -0,0 +1,67 @@
+package finder
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/debricked/cli/internal/callgraph/finder/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func isPythonSource(file string) bool {
+	return strings.HasSuffix(file, ".py")
+}
+
+func TestFindProjects(t *testing.T) {
+	finder := testdata.NewEmptyFinderMock()
+	finder.FindFilesNames = []string{
+		filepath.Join("app", "requirements.txt"),
+		filepath.Join("app", "setup.py"),
+		filepath.Join("app", "pkg", "views.py"),
+		filepath.Join("app", "nested", "pyproject.toml"),
+		filepath.Join("app", "nested", "tool.py"),
+		filepath.Join("empty", "requirements.txt"),
+		filepath.Join("other", "script.py"),
+	}
+	finder.FindRootsNames = []string{
+		filepath.Join("app", "requirements.txt"),
+		filepath.Join("app", "setup.py"),
+		filepath.Join("app", "nested", "pyproject.toml"),
+		filepath.Join("empty", "requirements.txt"),
+	}
+
+	projects, err := FindProjects(finder, []string{"."}, nil, nil, isPythonSource)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Project{
+		{
+			Dir:       "app",
+			RootFiles: []string{filepath.Join("app", "requirements.txt"), filepath.Join("app", "setup.py")},
+			Sources:   []string{"setup.py", filepath.Join("pkg", "views.py")},
+		},
+		{
+			Dir:       filepath.Join("app", "nested"),
+			RootFiles: []string{filepath.Join("app", "nested", "pyproject.toml")},
+			Sources:   []string{"tool.py"},
+		},
+	}, projects)
+}
+
+func TestFindProjectsErr(t *testing.T) {
+	finder := testdata.NewEmptyFinderMock()
+	finder.FindRootsErr = assert.AnError
+
+	_, err := FindProjects(finder, []string{"."}, nil, nil, isPythonSource)
+	assert.ErrorContains(t, err, "failed to find roots")
+
+	finder.FindFilesErr = assert.AnError
+	_, err = FindProjects(finder, []string{"."}, nil, nil, isPythonSource)
+	assert.ErrorContains(t, err, "failed to find files")
+}
+
+func TestIsIn(t *testing.T) {
+	assert.True(t, isIn(filepath.Join("a", "b.py"), "a"))
+	assert.False(t, isIn(filepath.Join("ab", "b.py"), "a"))
+	assert.False(t, isIn(filepath.Join("..", "b.py"), "."))
+}
@@ -0,0 +1,173 @@
+package pythonfinder
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/debricked/cli/internal/file"
+)
+
+const (
+	pyvenvCfg  = "pyvenv.cfg"
+	pycache    = "__pycache__"
+	virtualEnv = "VIRTUAL_ENV"
+)
+
+// manifests are the files marking the root of a project
+var manifests = []string{"pyproject.toml", "setup.py", "setup.cfg", "Pipfile"}
+
+// venvNames are the names of the virtual environments looked for next to a root, besides the <file>.venv
+// environments created by the pip resolver
+var venvNames = []string{".venv", "venv", "env"}
+
+type PythonFinder struct{}
+
+// FindRoots finds the requirements files and manifests of the application, the directory of each being the root of
+// a project
+func (f PythonFinder) FindRoots(files []string) ([]string, error) {
+	var roots []string
+	for _, file := range files {
+		if IsRoot(file) && !inVirtualEnv(file) {
+			roots = append(roots, file)
+		}
+	}
+
+	return roots, nil
+}
+
+// FindDependencyDirs finds the site-packages directories of the virtual environments next to the roots in files, and
+// of the active virtual environment
+func (f PythonFinder) FindDependencyDirs(files []string, findJars bool) ([]string, error) {
+	dirs := []string{}
+	added := map[string]bool{}
+	add := func(venv string) {
+		for _, dir := range sitePackages(venv) {
+			if !added[dir] {
+				added[dir] = true
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	for _, file := range files {
+		dir := filepath.Dir(file)
+		add(filepath.Join(dir, filepath.Base(file)+".venv"))
+		for _, name := range venvNames {
+			add(filepath.Join(dir, name))
+		}
+	}
+	if venv := os.Getenv(virtualEnv); venv != "" {
+		add(venv)
+	}
+
+	return dirs, nil
+}
+
+// FindFiles finds the requirements files, manifests and Python sources, leaving out virtual environments
+func (f PythonFinder) FindFiles(roots []string, exclusions []string, inclusions []string) ([]string, error) {
+	files := make(map[string]bool)
+	var err error = nil
+
+	for _, root := range roots {
+		err = filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+
+			excluded := file.Excluded(exclusions, inclusions, path)
+
+			if info.IsDir() && (excluded || info.Name() == pycache || isVirtualEnv(path)) {
+				return filepath.SkipDir
+			}
+
+			if !info.IsDir() && !excluded && (IsRoot(path) || IsSource(path)) {
+				files[path] = true
+			}
+
+			return nil
+		})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fileList := make([]string, 0, len(files))
+	for k := range files {
+		fileList = append(fileList, k)
+	}
+
+	return fileList, err
+}
+
+// IsSource reports whether a file is a Python source
+func IsSource(path string) bool {
+	return filepath.Ext(path) == ".py"
+}
+
+// IsRoot reports whether a file is a requirements file or a manifest of a project
+func IsRoot(path string) bool {
+	name := filepath.Base(path)
+	if strings.HasPrefix(name, "requirements") && filepath.Ext(name) == ".txt" {
+		return true
+	}
+	for _, manifest := range manifests {
+		if name == manifest {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sitePackages finds the site-packages directories of the virtual environment in venv, on Unix and on Windows
+func sitePackages(venv string) []string {
+	if !isVirtualEnv(venv) {
+		return nil
+	}
+	var dirs []string
+	for _, pattern := range []string{
+		filepath.Join(venv, "lib", "python*", "site-packages"),
+		filepath.Join(venv, "lib64", "python*", "site-packages"),
+		filepath.Join(venv, "Lib", "site-packages"),
+	} {
+		matches, _ := filepath.Glob(pattern)
+		for _, match := range matches {
+			// lib64 is usually a link to lib
+			if resolved, err := filepath.EvalSymlinks(match); err == nil && resolved != match && contains(dirs, resolved) {
+				continue
+			}
+			dirs = append(dirs, match)
+		}
+	}
+
+	return dirs
+}
+
+func isVirtualEnv(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, pyvenvCfg))
+
+	return err == nil && !info.IsDir()
+}
+
+// inVirtualEnv reports whether a file is inside a virtual environment, the directory of which holds a pyvenv.cfg
+func inVirtualEnv(path string) bool {
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		if isVirtualEnv(dir) {
+			return true
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			return false
+		}
+	}
+}
+
+func contains(list []string, value string) bool {
+	for _, element := range list {
+		if element == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,92 @@
+package pythonfinder
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var venv = filepath.Join("testdata", "app", "requirements.txt.venv")
+
+func TestFindRoots(t *testing.T) {
+	f := PythonFinder{}
+	files := []string{
+		filepath.Join("testdata", "app", "requirements.txt"),
+		filepath.Join("testdata", "app", "main.py"),
+		filepath.Join("testdata", "app", "nested", "pyproject.toml"),
+		filepath.Join(venv, "lib", "python3.11", "site-packages", "dep", "setup.py"),
+	}
+	roots, err := f.FindRoots(files)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{files[0], files[2]}, roots)
+}
+
+func TestFindFiles(t *testing.T) {
+	f := PythonFinder{}
+	files, err := f.FindFiles([]string{"testdata"}, nil, nil)
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join("testdata", "app", "requirements.txt"),
+		filepath.Join("testdata", "app", "main.py"),
+		filepath.Join("testdata", "app", "pkg", "__init__.py"),
+		filepath.Join("testdata", "app", "nested", "pyproject.toml"),
+		filepath.Join("testdata", "app", "nested", "tool.py"),
+	}, files)
+}
+
+func TestFindFilesExclusions(t *testing.T) {
+	f := PythonFinder{}
+	files, err := f.FindFiles([]string{"testdata"}, []string{"**/nested/**"}, nil)
+	assert.Nil(t, err)
+	assert.NotContains(t, files, filepath.Join("testdata", "app", "nested", "tool.py"))
+	assert.Contains(t, files, filepath.Join("testdata", "app", "main.py"))
+}
+
+func TestFindFilesError(t *testing.T) {
+	f := PythonFinder{}
+	_, err := f.FindFiles([]string{"nonexistent"}, nil, nil)
+	assert.NotNil(t, err)
+}
+
+func TestFindDependencyDirs(t *testing.T) {
+	t.Setenv(virtualEnv, "")
+	f := PythonFinder{}
+	dirs, err := f.FindDependencyDirs([]string{filepath.Join("testdata", "app", "requirements.txt")}, false)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(venv, "lib", "python3.11", "site-packages"),
+		filepath.Join("testdata", "app", ".venv", "Lib", "site-packages"),
+	}, dirs)
+}
+
+func TestFindDependencyDirsActiveVirtualEnv(t *testing.T) {
+	t.Setenv(virtualEnv, venv)
+	f := PythonFinder{}
+	dirs, err := f.FindDependencyDirs([]string{filepath.Join("testdata", "app", "nested", "pyproject.toml")}, false)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{filepath.Join(venv, "lib", "python3.11", "site-packages")}, dirs)
+}
+
+func TestIsRoot(t *testing.T) {
+	cases := map[string]bool{
+		"requirements.txt":     true,
+		"requirements-dev.txt": true,
+		"pyproject.toml":       true,
+		"setup.py":             true,
+		"Pipfile":              true,
+		"requirements.in":      false,
+		"main.py":              false,
+	}
+	for name, expected := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, expected, IsRoot(name))
+		})
+	}
+}
+
+func TestIsSource(t *testing.T) {
+	assert.True(t, IsSource("main.py"))
+	assert.False(t, IsSource("main.pyc"))
+	assert.False(t, IsSource("requirements.txt"))
+}
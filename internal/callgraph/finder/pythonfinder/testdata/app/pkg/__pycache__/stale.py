@@ -0,0 +1 @@
+cached = True
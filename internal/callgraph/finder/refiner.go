@@ -36,7 +36,7 @@ func ConvertPathsToAbsPaths(paths []string) ([]string, error) {
 	return absPaths, nil
 }
 
-// Matches class directories to closest root pom file and creates a map
+// Matches class directories to closest root pom file, or Gradle build file, and creates a map
 // with each root pom file pointing at a list of its related class directories
 func MapFilesToDir(rootPomFiles []string, classDirs []string) map[string][]string {
 	pomFileToClassDirsMap := make(map[string][]string)
@@ -66,7 +66,7 @@ func findPomFileMatch(classDir string, pomFiles []string) (string, error) {
 	matched := false
 
 	for _, pomFile := range pomFiles {
-		pomFilePath := strings.TrimSuffix(pomFile, "pom.xml")
+		pomFilePath := strings.TrimSuffix(pomFile, filepath.Base(pomFile))
 		if strings.Contains(classDir, pomFilePath) {
 			numberSeparators := strings.Count(pomFilePath, string(os.PathSeparator))
 			if numberSeparators > longestSeperatorMatch {
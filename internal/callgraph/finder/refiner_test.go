@@ -37,6 +37,22 @@ func TestMapFilesToDir(t *testing.T) {
 	assert.Len(t, mapFiles[filepath.Join("foo/bar/tree/asd/fast/pom.xml")], 1)
 }
 
+func TestMapFilesToDirGradle(t *testing.T) {
+	dirs := []string{
+		filepath.Join("foo/app/build/classes/java/main") + string(os.PathSeparator),
+		filepath.Join("foo/lib/build/classes/java/main") + string(os.PathSeparator),
+		filepath.Join("bar/target/classes") + string(os.PathSeparator),
+	}
+	files := []string{
+		filepath.Join("foo/settings.gradle.kts"),
+		filepath.Join("bar/pom.xml"),
+	}
+	mapFiles := MapFilesToDir(files, dirs)
+
+	assert.Len(t, mapFiles[filepath.Join("foo/settings.gradle.kts")], 2)
+	assert.Len(t, mapFiles[filepath.Join("bar/pom.xml")], 1)
+}
+
 func TestFindPomFileMatch(t *testing.T) {
 
 	classDir := filepath.Join("foo/bar/tree/asd/hej/target/classfiles") + string(os.PathSeparator)
@@ -35,14 +35,17 @@ type CallgraphBuilder struct {
 	cgModel          *model.CallGraph
 	includeTests     bool
 	algorithm        string
+	entryPoints      []string
 }
 
+// NewCallgraphBuilder creates a builder for mainFile, which is either a main file or a package pattern such as ./...
 func NewCallgraphBuilder(
 	workingDirectory string,
 	mainFile string,
 	outputName string,
 	filesystem ioFs.IFileSystem,
 	ctx cgexec.IContext,
+	options Options,
 ) CallgraphBuilder {
 	return CallgraphBuilder{
 		workingDirectory: workingDirectory,
@@ -51,8 +54,9 @@ func NewCallgraphBuilder(
 		filesystem:       filesystem,
 		ctx:              ctx,
 		cgModel:          model.NewCallGraph(),
-		includeTests:     true,
-		algorithm:        "cha",
+		includeTests:     options.IncludeTests,
+		algorithm:        options.Algorithm,
+		entryPoints:      options.EntryPoints,
 	}
 }
 
@@ -137,7 +141,10 @@ func (cg *CallgraphBuilder) constructInternalCallGraph(prog *ssa.Program, pkgs [
 		}
 
 	case AlgorithmVTA:
-		icg = vta.CallGraph(ssautil.AllFunctions(prog), cha.CallGraph(prog))
+		icg, err = cg.constructVTACallGraph(prog)
+		if err != nil {
+			return nil, err
+		}
 
 	default:
 		return nil, fmt.Errorf("unknown algorithm: %s", cg.algorithm)
@@ -149,19 +156,59 @@ func (cg *CallgraphBuilder) constructInternalCallGraph(prog *ssa.Program, pkgs [
 }
 
 func (cg *CallgraphBuilder) constructRTACallGraph(prog *ssa.Program, pkgs []*ssa.Package) (*callgraph.Graph, error) {
-	mains, err := mainPackages(pkgs)
+	roots, err := cg.rootFunctions(prog, pkgs)
 	if err != nil {
 		return nil, err
 	}
-	var roots []*ssa.Function
-	for _, main := range mains {
-		roots = append(roots, main.Func("init"), main.Func("main"))
-	}
 	rtares := rta.Analyze(roots, true)
 
 	return rtares.CallGraph, nil
 }
 
+// constructVTACallGraph refines the CHA call graph of all functions,
+// or of the functions reachable from the entry points if such are given
+func (cg *CallgraphBuilder) constructVTACallGraph(prog *ssa.Program) (*callgraph.Graph, error) {
+	chaGraph := cha.CallGraph(prog)
+	if len(cg.entryPoints) == 0 {
+		return vta.CallGraph(ssautil.AllFunctions(prog), chaGraph), nil
+	}
+
+	roots, err := entryPointFunctions(prog, cg.entryPoints)
+	if err != nil {
+		return nil, err
+	}
+
+	return vta.CallGraph(reachableFunctions(chaGraph, roots), chaGraph), nil
+}
+
+// rootFunctions returns the functions that RTA starts from. These are the entry points if given,
+// otherwise the main functions. Libraries without main packages are rooted in their exported API,
+// and in their tests if included.
+func (cg *CallgraphBuilder) rootFunctions(prog *ssa.Program, pkgs []*ssa.Package) ([]*ssa.Function, error) {
+	if len(cg.entryPoints) > 0 {
+		return entryPointFunctions(prog, cg.entryPoints)
+	}
+
+	mains := mainPackages(pkgs)
+	roots := packageFunctions(mains...)
+	for _, main := range mains {
+		if !isTestMain(main) {
+			return roots, nil
+		}
+	}
+
+	for _, pkg := range pkgs {
+		if pkg != nil && pkg.Pkg.Name() != "main" {
+			roots = append(roots, exportedFunctions(pkg)...)
+		}
+	}
+	if len(roots) == 0 {
+		return nil, fmt.Errorf("no main packages or exported functions")
+	}
+
+	return roots, nil
+}
+
 func IsApplicationNode(filename string, pwd string) bool {
 	if len(filename) > len(pwd) && filename[:len(pwd)] == pwd {
 		return true
@@ -291,18 +338,15 @@ func (cg *CallgraphBuilder) RunCallGraph() (string, error) {
 
 // mainPackages returns the main packages to analyze.
 // Each resulting package is named "main" and has a main function.
-func mainPackages(pkgs []*ssa.Package) ([]*ssa.Package, error) {
+func mainPackages(pkgs []*ssa.Package) []*ssa.Package {
 	var mains []*ssa.Package
 	for _, p := range pkgs {
 		if p != nil && p.Pkg.Name() == "main" && p.Func("main") != nil {
 			mains = append(mains, p)
 		}
 	}
-	if len(mains) == 0 {
-		return nil, fmt.Errorf("no main packages")
-	}
 
-	return mains, nil
+	return mains
 }
 
 type Edge struct {
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	ctxTestdata "github.com/debricked/cli/internal/callgraph/cgexec/testdata"
+	"github.com/debricked/cli/internal/callgraph/model"
 	"github.com/debricked/cli/internal/io"
 	"github.com/stretchr/testify/assert"
 )
@@ -80,14 +81,16 @@ func TestCallGraphGeneration(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			options := DefaultOptions()
+			options.Algorithm = tt.algorithm
 			cg := NewCallgraphBuilder(
 				rootFileDir,
 				"app.go",
 				outputName,
 				io.FileSystem{},
 				ctx,
+				options,
 			)
-			cg.algorithm = tt.algorithm
 			outputPath, err := cg.RunCallGraph()
 			assert.Nil(t, err)
 			assert.NotEmpty(t, outputPath)
@@ -103,6 +106,105 @@ func TestCallGraphGeneration(t *testing.T) {
 	}
 }
 
+func parentSymbols(node *model.Node) []string {
+	var symbols []string
+	for _, edge := range node.Parents {
+		symbols = append(symbols, edge.Parent.Symbol)
+	}
+
+	return symbols
+}
+
+func TestLibraryCallGraphGeneration(t *testing.T) {
+	ctx, _ := ctxTestdata.NewContextMock()
+
+	tests := []struct {
+		name      string
+		options   Options
+		parents   map[string][]string
+		notCalled []string
+	}{
+		{
+			name:    "Test with rta algorithm rooted in exported API",
+			options: Options{Algorithm: AlgorithmRTA, IncludeTests: true},
+			parents: map[string][]string{
+				"example.com/library.greeting":            {"example.com/library.Greeter.Welcome"},
+				"example.com/library.Greeter.Welcome":     {"example.com/library.Greet"},
+				"example.com/library/internal/text.Upper": {"example.com/library.Greeter.Welcome"},
+			},
+			notCalled: []string{"example.com/library.unused"},
+		},
+		{
+			name:    "Test with rta algorithm without tests",
+			options: Options{Algorithm: AlgorithmRTA, IncludeTests: false},
+			parents: map[string][]string{
+				"example.com/library.greeting": {"example.com/library.Greeter.Welcome"},
+			},
+			notCalled: []string{"example.com/library.unused"},
+		},
+		{
+			name:    "Test with rta algorithm and method entry point",
+			options: Options{Algorithm: AlgorithmRTA, EntryPoints: []string{"example.com/library.Greeter.Welcome"}},
+			parents: map[string][]string{
+				"example.com/library.greeting": {"example.com/library.Greeter.Welcome"},
+			},
+			notCalled: []string{"example.com/library.Greet", "example.com/library.NewGreeter"},
+		},
+		{
+			name:    "Test with vta algorithm and function entry point",
+			options: Options{Algorithm: AlgorithmVTA, EntryPoints: []string{"example.com/library.Greet"}},
+			parents: map[string][]string{
+				"example.com/library.NewGreeter":      {"example.com/library.Greet"},
+				"example.com/library.Greeter.Welcome": {"example.com/library.Greet"},
+			},
+			notCalled: []string{"example.com/library.unused"},
+		},
+		{
+			name:    "Test with cha algorithm",
+			options: Options{Algorithm: AlgorithmCHA},
+			parents: map[string][]string{
+				"example.com/library.greeting": {"example.com/library.Greeter.Welcome", "example.com/library.unused"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cg := NewCallgraphBuilder(filepath.Join("testdata", "library"), modulePackages, outputName, io.FileSystem{}, ctx, tt.options)
+			err := cg.constructCallGraph()
+			assert.NoError(t, err)
+			for symbol, parents := range tt.parents {
+				node := cg.cgModel.GetNode(symbol)
+				if assert.NotNil(t, node, symbol) {
+					assert.ElementsMatch(t, parents, parentSymbols(node), symbol)
+				}
+			}
+			for _, symbol := range tt.notCalled {
+				assert.Nil(t, cg.cgModel.GetNode(symbol), symbol)
+			}
+		})
+	}
+}
+
+func TestLibraryCallGraphEntryPointNotFound(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmRTA, AlgorithmVTA} {
+		options := Options{Algorithm: algorithm, EntryPoints: []string{"example.com/library.Missing"}}
+		cg := NewCallgraphBuilder(filepath.Join("testdata", "library"), modulePackages, outputName, io.FileSystem{}, nil, options)
+		err := cg.constructCallGraph()
+		assert.ErrorContains(t, err, "entry point example.com/library.Missing not found")
+	}
+}
+
+func TestSplitMember(t *testing.T) {
+	pkg, name, ok := splitMember("github.com/org/repo/pkg.Client.Do")
+	assert.True(t, ok)
+	assert.Equal(t, "github.com/org/repo/pkg.Client", pkg)
+	assert.Equal(t, "Do", name)
+
+	_, _, ok = splitMember("github.com/org/repo.v2/pkg")
+	assert.False(t, ok)
+}
+
 func TestIsApplicationNode(t *testing.T) {
 	tests := []struct {
 		name string
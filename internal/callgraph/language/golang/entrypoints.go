@@ -0,0 +1,162 @@
+package golang
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/callgraph"
+	"golang.org/x/tools/go/ssa"
+)
+
+// isTestMain reports whether pkg is the main package generated by go test
+func isTestMain(pkg *ssa.Package) bool {
+	return strings.HasSuffix(pkg.Pkg.Path(), ".test")
+}
+
+// packageFunctions returns init and main of main packages and the exported API of other packages
+func packageFunctions(pkgs ...*ssa.Package) []*ssa.Function {
+	var fns []*ssa.Function
+	for _, pkg := range pkgs {
+		if pkg.Pkg.Name() == "main" {
+			fns = appendFunction(fns, pkg.Func("init"))
+			fns = appendFunction(fns, pkg.Func("main"))
+		} else {
+			fns = append(fns, exportedFunctions(pkg)...)
+		}
+	}
+
+	return fns
+}
+
+// exportedFunctions returns init and the exported functions and methods of pkg
+func exportedFunctions(pkg *ssa.Package) []*ssa.Function {
+	fns := appendFunction(nil, pkg.Func("init"))
+	for name, member := range pkg.Members {
+		if !token.IsExported(name) {
+			continue
+		}
+
+		switch m := member.(type) {
+		case *ssa.Function:
+			if m.TypeParams().Len() == 0 {
+				fns = append(fns, m)
+			}
+		case *ssa.Type:
+			fns = append(fns, exportedMethods(pkg.Prog, m.Type())...)
+		}
+	}
+
+	return fns
+}
+
+func exportedMethods(prog *ssa.Program, t types.Type) []*ssa.Function {
+	if types.IsInterface(t) {
+		return nil
+	}
+
+	var fns []*ssa.Function
+	methodSet := prog.MethodSets.MethodSet(types.NewPointer(t))
+	for i := 0; i < methodSet.Len(); i++ {
+		if selection := methodSet.At(i); selection.Obj().Exported() {
+			fns = appendFunction(fns, prog.MethodValue(selection))
+		}
+	}
+
+	return fns
+}
+
+// entryPointFunctions resolves entry points, given as packages, functions or methods, to functions
+func entryPointFunctions(prog *ssa.Program, entryPoints []string) ([]*ssa.Function, error) {
+	packagesByPath := map[string][]*ssa.Package{}
+	for _, pkg := range prog.AllPackages() {
+		packagesByPath[pkg.Pkg.Path()] = append(packagesByPath[pkg.Pkg.Path()], pkg)
+	}
+
+	var roots []*ssa.Function
+	for _, entryPoint := range entryPoints {
+		fns := entryPointFunction(prog, packagesByPath, entryPoint)
+		if len(fns) == 0 {
+			return nil, fmt.Errorf("entry point %s not found", entryPoint)
+		}
+		roots = append(roots, fns...)
+	}
+
+	return roots, nil
+}
+
+func entryPointFunction(prog *ssa.Program, packagesByPath map[string][]*ssa.Package, entryPoint string) []*ssa.Function {
+	if pkgs, ok := packagesByPath[entryPoint]; ok {
+		return packageFunctions(pkgs...)
+	}
+
+	pkgPath, name, ok := splitMember(entryPoint)
+	if !ok {
+		return nil
+	}
+
+	var fns []*ssa.Function
+	for _, pkg := range packagesByPath[pkgPath] {
+		fns = appendFunction(fns, pkg.Func(name))
+	}
+	if len(fns) > 0 {
+		return fns
+	}
+
+	typePath, typeName, ok := splitMember(pkgPath)
+	if !ok {
+		return nil
+	}
+
+	for _, pkg := range packagesByPath[typePath] {
+		if t := pkg.Type(typeName); t != nil && !types.IsInterface(t.Type()) {
+			selection := prog.MethodSets.MethodSet(types.NewPointer(t.Type())).Lookup(pkg.Pkg, name)
+			if selection != nil {
+				fns = appendFunction(fns, prog.MethodValue(selection))
+			}
+		}
+	}
+
+	return fns
+}
+
+// splitMember splits github.com/org/repo/pkg.Member into its package path and member name
+func splitMember(symbol string) (string, string, bool) {
+	i := strings.LastIndex(symbol, ".")
+	if i <= strings.LastIndex(symbol, "/") {
+		return "", "", false
+	}
+
+	return symbol[:i], symbol[i+1:], true
+}
+
+// reachableFunctions returns the functions reachable from roots in icg
+func reachableFunctions(icg *callgraph.Graph, roots []*ssa.Function) map[*ssa.Function]bool {
+	reachable := map[*ssa.Function]bool{}
+	queue := append([]*ssa.Function{}, roots...)
+	for len(queue) > 0 {
+		fn := queue[0]
+		queue = queue[1:]
+		if reachable[fn] {
+			continue
+		}
+		reachable[fn] = true
+
+		if node := icg.Nodes[fn]; node != nil {
+			for _, edge := range node.Out {
+				queue = append(queue, edge.Callee.Func)
+			}
+		}
+	}
+
+	return reachable
+}
+
+func appendFunction(fns []*ssa.Function, fn *ssa.Function) []*ssa.Function {
+	if fn == nil {
+		return fns
+	}
+
+	return append(fns, fn)
+}
@@ -13,6 +13,8 @@ import (
 
 const (
 	outputName = "debricked-call-graph.golang"
+	// modulePackages is the package pattern used instead of a main file to analyse all packages of a module
+	modulePackages = "./..."
 )
 
 type Job struct {
@@ -37,12 +39,23 @@ func NewJob(dir string, mainFile string, writer ioFs.IFileWriter, archive io.IAr
 
 func (j *Job) Run() {
 	workingDirectory := j.GetDir()
+	var kwargs map[string]string
+	if j.config != nil {
+		kwargs = j.config.Kwargs()
+	}
+	options, err := NewOptions(kwargs)
+	if err != nil {
+		j.Errors().Critical(err)
+
+		return
+	}
 	callgraph := NewCallgraphBuilder(
 		workingDirectory,
 		j.mainFile,
 		outputName,
 		j.fs,
 		j.ctx,
+		options,
 	)
 	j.SendStatus("generating call graph")
 	j.runCallGraph(&callgraph)
@@ -187,3 +187,15 @@ func TestRunWithErrorsIsNotExistTrue(t *testing.T) {
 
 	assert.True(t, j.Errors().HasError())
 }
+
+func TestRunInvalidOptions(t *testing.T) {
+	config := conf.NewConfig("golang", nil, map[string]string{TestsKwarg: "maybe"}, true, "go", "")
+	ctx, _ := ctxTestdata.NewContextMock()
+
+	j := NewJob(dir, "main.go", io.FileWriter{}, ioTestData.ArchiveMock{}, config, ctx, io.FileSystem{})
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.True(t, j.Errors().HasError())
+	assert.ErrorContains(t, j.Errors().GetAll()[0], "invalid value for tests")
+}
@@ -0,0 +1,79 @@
+package golang
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	AlgorithmKwarg   = "algorithm"
+	TestsKwarg       = "tests"
+	EntryPointsKwarg = "entryPoints"
+)
+
+// Algorithms lists the supported call graph algorithms, ordered from least to most precise
+var Algorithms = []string{AlgorithmStatic, AlgorithmCHA, AlgorithmRTA, AlgorithmVTA}
+
+type Options struct {
+	Algorithm    string
+	IncludeTests bool
+	// EntryPoints are packages, functions or methods, such as
+	// github.com/org/repo/pkg, github.com/org/repo/pkg.Run or github.com/org/repo/pkg.Client.Do
+	EntryPoints []string
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Algorithm:    AlgorithmCHA,
+		IncludeTests: true,
+	}
+}
+
+// NewOptions reads the options from the config kwargs, falling back to the defaults for missing kwargs
+func NewOptions(kwargs map[string]string) (Options, error) {
+	options := DefaultOptions()
+
+	if algorithm, ok := kwargs[AlgorithmKwarg]; ok && algorithm != "" {
+		if err := ValidateAlgorithm(algorithm); err != nil {
+			return options, err
+		}
+		options.Algorithm = algorithm
+	}
+
+	if tests, ok := kwargs[TestsKwarg]; ok && tests != "" {
+		includeTests, err := strconv.ParseBool(tests)
+		if err != nil {
+			return options, fmt.Errorf("invalid value for %s: %s", TestsKwarg, tests)
+		}
+		options.IncludeTests = includeTests
+	}
+
+	for _, entryPoint := range strings.Split(kwargs[EntryPointsKwarg], ",") {
+		entryPoint = strings.TrimSpace(entryPoint)
+		if entryPoint != "" {
+			options.EntryPoints = append(options.EntryPoints, entryPoint)
+		}
+	}
+
+	return options, nil
+}
+
+// Kwargs returns the options as config kwargs
+func (o Options) Kwargs() map[string]string {
+	return map[string]string{
+		AlgorithmKwarg:   o.Algorithm,
+		TestsKwarg:       strconv.FormatBool(o.IncludeTests),
+		EntryPointsKwarg: strings.Join(o.EntryPoints, ","),
+	}
+}
+
+func ValidateAlgorithm(algorithm string) error {
+	for _, supported := range Algorithms {
+		if algorithm == supported {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s is not a supported algorithm, use one of: %s", algorithm, strings.Join(Algorithms, ", "))
+}
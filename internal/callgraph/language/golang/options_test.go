@@ -0,0 +1,45 @@
+package golang
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOptionsDefaults(t *testing.T) {
+	options, err := NewOptions(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultOptions(), options)
+	assert.Equal(t, AlgorithmCHA, options.Algorithm)
+	assert.True(t, options.IncludeTests)
+	assert.Empty(t, options.EntryPoints)
+}
+
+func TestNewOptions(t *testing.T) {
+	options, err := NewOptions(map[string]string{
+		AlgorithmKwarg:   AlgorithmRTA,
+		TestsKwarg:       "false",
+		EntryPointsKwarg: "example.com/a, example.com/b.Run,",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, Options{Algorithm: AlgorithmRTA, IncludeTests: false, EntryPoints: []string{"example.com/a", "example.com/b.Run"}}, options)
+
+	roundTrip, err := NewOptions(options.Kwargs())
+	assert.NoError(t, err)
+	assert.Equal(t, options, roundTrip)
+}
+
+func TestNewOptionsErrors(t *testing.T) {
+	_, err := NewOptions(map[string]string{AlgorithmKwarg: "pointer"})
+	assert.ErrorContains(t, err, "pointer is not a supported algorithm, use one of: static, cha, rta, vta")
+
+	_, err = NewOptions(map[string]string{TestsKwarg: "maybe"})
+	assert.ErrorContains(t, err, "invalid value for tests: maybe")
+}
+
+func TestValidateAlgorithm(t *testing.T) {
+	for _, algorithm := range Algorithms {
+		assert.NoError(t, ValidateAlgorithm(algorithm))
+	}
+	assert.Error(t, ValidateAlgorithm("CHA"))
+}
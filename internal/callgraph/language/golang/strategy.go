@@ -1,16 +1,15 @@
 package golang
 
 import (
-	"fmt"
-	"log"
+	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/debricked/cli/internal/callgraph/cgexec"
 	conf "github.com/debricked/cli/internal/callgraph/config"
 	"github.com/debricked/cli/internal/callgraph/finder"
 	"github.com/debricked/cli/internal/callgraph/job"
 	"github.com/debricked/cli/internal/io"
-	"github.com/fatih/color"
 )
 
 type Strategy struct {
@@ -26,57 +25,85 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 	var jobs []job.IJob
 
 	if s.config == nil {
-		strategyWarning("No config is setup")
+		finder.StrategyWarning("No config is setup")
 
 		return jobs, nil
 	}
 
+	options, err := NewOptions(s.config.Kwargs())
+	if err != nil {
+		finder.StrategyWarning("Invalid options: " + err.Error())
+
+		return jobs, err
+	}
+
 	for _, path := range s.paths {
 		files, err := s.finder.FindFiles([]string{path}, s.exclusions, s.inclusions)
 		if err != nil {
-			strategyWarning("Error while finding files: " + err.Error())
+			finder.StrategyWarning("Error while finding files: " + err.Error())
 
 			return jobs, err
 		}
 
-		roots, err := s.finder.FindRoots(files)
-		if err != nil {
-			strategyWarning("Error while finding roots: " + err.Error())
+		var roots []string
+		if len(options.EntryPoints) == 0 {
+			roots, err = s.finder.FindRoots(files)
+			if err != nil {
+				finder.StrategyWarning("Error while finding roots: " + err.Error())
 
-			return jobs, err
+				return jobs, err
+			}
 		}
 
-		if len(roots) == 0 {
-			return jobs, nil
+		for _, rootFilePath := range roots {
+			jobs = append(jobs, s.newJob(filepath.Dir(rootFilePath), filepath.Base(rootFilePath)))
 		}
 
-		for _, rootFilePath := range roots {
-			rootFileDir := filepath.Dir(rootFilePath)
-			rootFile := filepath.Base(rootFilePath)
-			jobs = append(jobs, NewJob(
-				rootFileDir,
-				rootFile,
-				io.FileWriter{},
-				io.NewArchive("."),
-				s.config,
-				s.ctx,
-				io.FileSystem{},
-			),
-			)
+		// Without main packages, or with explicit entry points, all packages of each module are analysed
+		if len(roots) == 0 {
+			for _, moduleDir := range moduleDirs(files) {
+				jobs = append(jobs, s.newJob(moduleDir, modulePackages))
+			}
 		}
 	}
 
 	return jobs, nil
 }
 
-func NewStrategy(config conf.IConfig, paths []string, exclusions []string, inclusions []string, finder finder.IFinder, ctx cgexec.IContext) Strategy {
-	return Strategy{config, paths, exclusions, inclusions, finder, ctx}
+func (s Strategy) newJob(dir string, mainFile string) *Job {
+	return NewJob(
+		dir,
+		mainFile,
+		io.FileWriter{},
+		io.NewArchive("."),
+		s.config,
+		s.ctx,
+		io.FileSystem{},
+	)
 }
 
-func strategyWarning(errMsg string) {
-	err := fmt.Errorf("%s", errMsg)
-	warningColor := color.New(color.FgYellow, color.Bold).SprintFunc()
-	defaultOutputWriter := log.Writer()
-	log.Println(warningColor("Warning: ") + err.Error())
-	log.SetOutput(defaultOutputWriter)
+// moduleDirs returns the directories of the go.mod files closest to files
+func moduleDirs(files []string) []string {
+	var dirs []string
+	seen := map[string]bool{}
+	for _, file := range files {
+		for dir := filepath.Dir(file); !seen[dir]; dir = filepath.Dir(dir) {
+			seen[dir] = true
+			if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+				dirs = append(dirs, dir)
+
+				break
+			}
+			if filepath.Dir(dir) == dir {
+				break
+			}
+		}
+	}
+	sort.Strings(dirs)
+
+	return dirs
+}
+
+func NewStrategy(config conf.IConfig, paths []string, exclusions []string, inclusions []string, finder finder.IFinder, ctx cgexec.IContext) Strategy {
+	return Strategy{config, paths, exclusions, inclusions, finder, ctx}
 }
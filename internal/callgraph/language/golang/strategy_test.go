@@ -1,6 +1,7 @@
 package golang
 
 import (
+	"path/filepath"
 	"testing"
 
 	ctxTestdata "github.com/debricked/cli/internal/callgraph/cgexec/testdata"
@@ -92,3 +93,52 @@ func TestInvokeNoRoots(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Empty(t, jobs)
 }
+
+func TestInvokeLibrary(t *testing.T) {
+	conf := config.NewConfig("golang", nil, map[string]string{}, true, "go", "")
+	finder := testdata.NewEmptyFinderMock()
+	finder.FindFilesNames = []string{
+		filepath.Join("testdata", "library", "library.go"),
+		filepath.Join("testdata", "library", "internal", "text", "text.go"),
+		filepath.Join("testdata", "fixture", "app.go"),
+	}
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, filepath.Join("testdata", "library"), jobs[0].GetDir())
+	assert.Equal(t, []string{modulePackages}, jobs[0].GetFiles())
+}
+
+func TestInvokeEntryPoints(t *testing.T) {
+	conf := config.NewConfig("golang", nil, map[string]string{EntryPointsKwarg: "example.com/library.Greet"}, true, "go", "")
+	finder := testdata.NewEmptyFinderMock()
+	finder.FindFilesNames = []string{filepath.Join("testdata", "library", "library.go")}
+	finder.FindRootsNames = []string{"main.go"}
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 1)
+	assert.Equal(t, []string{modulePackages}, jobs[0].GetFiles())
+}
+
+func TestInvokeInvalidOptions(t *testing.T) {
+	conf := config.NewConfig("golang", nil, map[string]string{AlgorithmKwarg: "pointer"}, true, "go", "")
+	finder := testdata.NewEmptyFinderMock()
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	jobs, err := s.Invoke()
+	assert.ErrorContains(t, err, "pointer is not a supported algorithm")
+	assert.Empty(t, jobs)
+}
+
+func TestModuleDirs(t *testing.T) {
+	dirs := moduleDirs([]string{
+		filepath.Join("testdata", "library", "internal", "text", "text.go"),
+		filepath.Join("testdata", "library", "library.go"),
+		filepath.Join("testdata", "fixture", "app.go"),
+	})
+	assert.Equal(t, []string{filepath.Join("testdata", "library")}, dirs)
+}
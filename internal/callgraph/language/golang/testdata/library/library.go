@@ -0,0 +1,27 @@
+package library
+
+import "example.com/library/internal/text"
+
+type Greeter struct {
+	name string
+}
+
+func NewGreeter(name string) *Greeter {
+	return &Greeter{name: name}
+}
+
+func (g *Greeter) Welcome() string {
+	return text.Upper(greeting(g.name))
+}
+
+func Greet(name string) string {
+	return NewGreeter(name).Welcome()
+}
+
+func greeting(name string) string {
+	return "Hello, " + name
+}
+
+func unused() string {
+	return greeting("nobody")
+}
@@ -1,9 +1,13 @@
 package java
 
 import (
+	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
 
 	"github.com/debricked/cli/internal/callgraph/cgexec"
+	internalOs "github.com/debricked/cli/internal/runtime/os"
 )
 
 type ICmdFactory interface {
@@ -11,6 +15,8 @@ type ICmdFactory interface {
 	MakeCallGraphGenerationCmd(callgraphJarPath string, workingDirectory string, targetClasses []string, dependencyClasses string, outputName string, ctx cgexec.IContext) (*exec.Cmd, error)
 	MakeBuildMavenCmd(workingDirectory string, ctx cgexec.IContext) (*exec.Cmd, error)
 	MakeJavaVersionCmd(workingDirectory string, ctx cgexec.IContext) (*exec.Cmd, error)
+	MakeBuildGradleCmd(workingDirectory string, ctx cgexec.IContext) (*exec.Cmd, error)
+	MakeGradleCopyDependenciesCmd(workingDirectory string, targetDir string, initScript string, ctx cgexec.IContext) (*exec.Cmd, error)
 }
 
 type CmdFactory struct{}
@@ -82,3 +88,51 @@ func (_ CmdFactory) MakeJavaVersionCmd(workingDirectory string, ctx cgexec.ICont
 
 	return cgexec.MakeCommand(workingDirectory, path, args, ctx), err
 }
+
+func (_ CmdFactory) MakeBuildGradleCmd(workingDirectory string, ctx cgexec.IContext) (*exec.Cmd, error) {
+	gradle := gradleExecutable(workingDirectory)
+	path, err := exec.LookPath(gradle)
+	args := []string{
+		gradle,
+		"classes",
+		"-q",
+	}
+
+	return cgexec.MakeCommand(workingDirectory, path, args, ctx), err
+}
+
+func (_ CmdFactory) MakeGradleCopyDependenciesCmd(
+	workingDirectory string,
+	targetDir string,
+	initScript string,
+	ctx cgexec.IContext,
+) (*exec.Cmd, error) {
+	gradle := gradleExecutable(workingDirectory)
+	path, err := exec.LookPath(gradle)
+	args := []string{
+		gradle,
+		"-q",
+		"--init-script",
+		initScript,
+		"-PdebrickedTargetDir=" + targetDir,
+		"debrickedCopyDependencies",
+	}
+
+	return cgexec.MakeCommand(workingDirectory, path, args, ctx), err
+}
+
+// gradleExecutable returns the Gradle wrapper of the project in workingDirectory, or else gradle
+func gradleExecutable(workingDirectory string) string {
+	gradlew := "gradlew"
+	if runtime.GOOS == internalOs.Windows {
+		gradlew = "gradlew.bat"
+	}
+	wrapper, err := filepath.Abs(filepath.Join(workingDirectory, gradlew))
+	if err == nil {
+		if info, statErr := os.Stat(wrapper); statErr == nil && !info.IsDir() {
+			return wrapper
+		}
+	}
+
+	return "gradle"
+}
@@ -1,9 +1,13 @@
 package java
 
 import (
+	"os"
+	"path/filepath"
+	"runtime"
 	"testing"
 
 	ctxTestdata "github.com/debricked/cli/internal/callgraph/cgexec/testdata"
+	internalOs "github.com/debricked/cli/internal/runtime/os"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -63,3 +67,35 @@ func TestMakeJavaVersionCmd(t *testing.T) {
 	assert.Contains(t, args, "java")
 	assert.Contains(t, args, "--version")
 }
+
+func TestMakeBuildGradleCmd(t *testing.T) {
+	ctx, _ := ctxTestdata.NewContextMock()
+	cmd, _ := CmdFactory{}.MakeBuildGradleCmd(t.TempDir(), ctx)
+
+	assert.NotNil(t, cmd)
+	args := cmd.Args
+	assert.Equal(t, "gradle", args[0])
+	assert.Contains(t, args, "classes")
+	assert.Contains(t, args, "-q")
+}
+
+func TestMakeGradleCopyDependenciesCmdWrapper(t *testing.T) {
+	workingDirectory := t.TempDir()
+	gradlew := "gradlew"
+	if runtime.GOOS == internalOs.Windows {
+		gradlew = "gradlew.bat"
+	}
+	wrapper := filepath.Join(workingDirectory, gradlew)
+	assert.NoError(t, os.WriteFile(wrapper, []byte("#!/bin/sh\n"), 0700)) // #nosec G306
+	ctx, _ := ctxTestdata.NewContextMock()
+	cmd, err := CmdFactory{}.MakeGradleCopyDependenciesCmd(workingDirectory, "target", "init.groovy", ctx)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, cmd)
+	args := cmd.Args
+	assert.Equal(t, wrapper, args[0])
+	assert.Contains(t, args, "--init-script")
+	assert.Contains(t, args, "init.groovy")
+	assert.Contains(t, args, "-PdebrickedTargetDir=target")
+	assert.Contains(t, args, "debrickedCopyDependencies")
+}
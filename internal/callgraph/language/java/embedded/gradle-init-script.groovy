@@ -0,0 +1,17 @@
+// Copies the runtime classpath of every Java project into the directory set by -PdebrickedTargetDir, after compiling
+// its classes, and prints the class output directories for the call graph to be generated from
+allprojects {
+    plugins.withType(JavaPlugin) {
+        def debrickedProject = project
+        tasks.register('debrickedCopyDependencies', Copy) {
+            dependsOn 'classes'
+            into debrickedProject.findProperty('debrickedTargetDir') ?: '.debrickedTmpFolder'
+            from debrickedProject.sourceSets.main.runtimeClasspath.filter { it.isFile() }
+            doLast {
+                debrickedProject.sourceSets.main.output.classesDirs.files.each { dir ->
+                    println 'debricked-classes:' + dir.absolutePath
+                }
+            }
+        }
+    }
+}
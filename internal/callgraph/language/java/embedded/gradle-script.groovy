@@ -1,8 +0,0 @@
-
-
-allprojects{
-    task debrickedCopyDependencies(type: Copy) {
-        into ".debrickedTmpDir"
-        from configurations.default
-    }
-}
\ No newline at end of file
@@ -1,9 +1,11 @@
 package java
 
 import (
+	"embed"
 	"os"
 	"os/exec"
 	"path"
+	"strings"
 	"syscall"
 
 	"github.com/debricked/cli/internal/callgraph/cgexec"
@@ -18,8 +20,14 @@ const (
 	gradle        = "gradle"
 	dependencyDir = ".debrickedTmpFolder"
 	outputName    = "debricked-call-graph.java"
+
+	gradleInitScriptFileName = ".gradle-callgraph-init-script.debricked.groovy"
+	gradleClassesPrefix      = "debricked-classes:"
 )
 
+//go:embed embedded/gradle-init-script.groovy
+var gradleInitScript embed.FS
+
 type Job struct {
 	job.BaseJob
 	cmdFactory  ICmdFactory
@@ -54,7 +62,6 @@ func NewJob(
 
 func (j *Job) Run() {
 	workingDirectory := j.GetDir()
-	pmConfig := j.config.PackageManager()
 	targetDir := path.Join(workingDirectory, dependencyDir)
 	targetClasses := []string{workingDirectory}
 	if len(j.GetFiles()) > 0 {
@@ -63,18 +70,7 @@ func (j *Job) Run() {
 
 	// If folder doesn't exist, copy dependencies
 	if _, err := j.fs.Stat(targetDir); j.fs.IsNotExist(err) {
-		var osCmd *exec.Cmd
-		if pmConfig == maven {
-			osCmd, err = j.cmdFactory.MakeMvnCopyDependenciesCmd(workingDirectory, targetDir, j.ctx)
-			j.SendStatus("copying external dep jars to target folder" + targetDir)
-		}
-		if err != nil {
-			j.Errors().Critical(err)
-
-			return
-		}
-
-		j.runCopyDependencies(osCmd)
+		classDirs := j.copyDependencies(workingDirectory, targetDir)
 		if j.Errors().HasError() {
 			// If error during copy to .debricked_call_graph, remove the folder
 
@@ -82,6 +78,9 @@ func (j *Job) Run() {
 
 			return
 		}
+		if len(classDirs) > 0 {
+			targetClasses = classDirs
+		}
 	}
 	callgraph := NewCallgraph(
 		j.cmdFactory,
@@ -104,12 +103,73 @@ func (j *Job) Run() {
 	j.runPostProcess()
 }
 
-func (j *Job) runCopyDependencies(osCmd *exec.Cmd) {
+// copyDependencies copies the dependency jars of the project to targetDir. The class output directories reported by
+// Gradle are returned, while Maven projects use the class directories found.
+func (j *Job) copyDependencies(workingDirectory string, targetDir string) []string {
+	var osCmd *exec.Cmd
+	var err error
+	if j.config.PackageManager() == gradle {
+		initScript := path.Join(workingDirectory, gradleInitScriptFileName)
+		err = j.writeGradleInitScript(initScript)
+		if err == nil {
+			defer j.fs.Remove(initScript) //nolint:errcheck
+			osCmd, err = j.cmdFactory.MakeGradleCopyDependenciesCmd(workingDirectory, targetDir, initScript, j.ctx)
+		}
+	} else {
+		osCmd, err = j.cmdFactory.MakeMvnCopyDependenciesCmd(workingDirectory, targetDir, j.ctx)
+	}
+	j.SendStatus("copying external dep jars to target folder" + targetDir)
+	if err != nil {
+		j.Errors().Critical(err)
+
+		return nil
+	}
+
+	output := j.runCopyDependencies(osCmd)
+
+	return j.parseClassDirs(output)
+}
+
+func (j *Job) writeGradleInitScript(initScript string) error {
+	file, err := j.fs.FsOpenEmbed(gradleInitScript, "embedded/gradle-init-script.groovy")
+	if err != nil {
+		return err
+	}
+	defer j.fs.FsCloseFile(file)
+	content, err := j.fs.FsReadAll(file)
+	if err != nil {
+		return err
+	}
+
+	return j.fs.FsWriteFile(initScript, content, 0600)
+}
+
+// parseClassDirs returns the existing class output directories printed by the Gradle init script
+func (j *Job) parseClassDirs(output string) []string {
+	classDirs := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		classDir, found := strings.CutPrefix(strings.TrimSpace(line), gradleClassesPrefix)
+		if !found {
+			continue
+		}
+		if _, err := j.fs.Stat(classDir); err == nil {
+			classDirs = append(classDirs, classDir)
+		}
+	}
+
+	return classDirs
+}
+
+func (j *Job) runCopyDependencies(osCmd *exec.Cmd) string {
 	cmd := cgexec.NewCommand(osCmd)
 	err := cgexec.RunCommand(*cmd, j.ctx)
 	if err != nil {
 		j.Errors().Critical(err)
+
+		return ""
 	}
+
+	return cmd.GetStdOut().String()
 }
 
 func (j *Job) runCallGraph(callgraph ICallgraph) {
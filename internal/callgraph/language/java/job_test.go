@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"path/filepath"
 	"syscall"
 	"testing"
 
@@ -66,6 +68,57 @@ func TestRunMakeMavenCopyDependenciesCmdErr(t *testing.T) {
 	assert.Contains(t, j.Errors().GetAll(), cmdErr)
 }
 
+func TestRunGradle(t *testing.T) {
+	workingDirectory := t.TempDir()
+	classDir := filepath.Join(workingDirectory, "build", "classes", "java", "main")
+	assert.NoError(t, os.MkdirAll(classDir, 0755))
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.GradleCopyDepCmd = exec.Command("echo", gradleClassesPrefix+classDir)
+	config := conf.NewConfig("java", nil, map[string]string{"pm": gradle}, true, gradle, "")
+	ctx, _ := ctxTestdata.NewContextMock()
+
+	fsMock := ioTestData.FileSystemMock{}
+	zip := ioTestData.ZipMock{}
+	archiveMock := io.NewArchiveWithStructs(workingDirectory, fsMock, zip)
+
+	j := NewJob(workingDirectory, files, cmdFactoryMock, &ioTestData.FileWriterMock{}, archiveMock, config, ctx, io.FileSystem{}, testdata.MockSootHandler{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	_, err := os.Stat(filepath.Join(workingDirectory, gradleInitScriptFileName))
+	assert.True(t, os.IsNotExist(err), "init script should be removed once dependencies are copied")
+}
+
+func TestRunMakeGradleCopyDependenciesCmdErr(t *testing.T) {
+	cmdErr := errors.New("cmd-error")
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.GradleCopyDepErr = cmdErr
+	config := conf.NewConfig("java", nil, map[string]string{"pm": gradle}, true, gradle, "")
+	ctx, _ := ctxTestdata.NewContextMock()
+	archiveMock := io.NewArchiveWithStructs("dir", ioTestData.FileSystemMock{}, ioTestData.ZipMock{})
+
+	j := NewJob(t.TempDir(), files, cmdFactoryMock, &ioTestData.FileWriterMock{}, archiveMock, config, ctx, io.FileSystem{}, testdata.MockSootHandler{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Len(t, j.Errors().GetAll(), 1)
+	assert.Contains(t, j.Errors().GetAll(), cmdErr)
+}
+
+func TestParseClassDirs(t *testing.T) {
+	classDir := t.TempDir()
+	j := NewJob(dir, files, nil, nil, nil, conf.Config{}, nil, io.FileSystem{}, nil)
+	output := "Starting a Gradle Daemon\n" +
+		gradleClassesPrefix + classDir + "\n" +
+		gradleClassesPrefix + filepath.Join(classDir, "missing") + "\n"
+
+	assert.Equal(t, []string{classDir}, j.parseClassDirs(output))
+	assert.Empty(t, j.parseClassDirs(""))
+}
+
 func TestRun(t *testing.T) {
 	fileWriterMock := &ioTestData.FileWriterMock{}
 	cmdFactoryMock := testdata.NewEchoCmdFactory()
@@ -2,7 +2,7 @@ package java
 
 import (
 	"fmt"
-	"log"
+	"os/exec"
 	"path/filepath"
 	"strings"
 
@@ -12,7 +12,6 @@ import (
 	"github.com/debricked/cli/internal/callgraph/job"
 	"github.com/debricked/cli/internal/io"
 	"github.com/debricked/cli/internal/tui"
-	"github.com/fatih/color"
 )
 
 type Strategy struct {
@@ -30,7 +29,7 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 	// Filter relevant files
 
 	if s.config == nil {
-		strategyWarning("No config is setup")
+		finder.StrategyWarning("No config is setup")
 
 		return jobs, nil
 	}
@@ -39,23 +38,15 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 	var err error
 	files, err := s.finder.FindFiles(s.paths, s.exclusions, s.inclusions)
 	if err != nil {
-		strategyWarning("Error while finding files: " + err.Error())
+		finder.StrategyWarning("Error while finding files: " + err.Error())
 
 		return jobs, err
 	}
 
-	// NOTE: Removed to meet cyclic complexity limit of 10.
-	// pmConfig := s.config.PackageManager()
-	// switch pmConfig {
-	// case maven:
-	// 	roots, err = s.finder.FindMavenRoots(s.files)
-	// default:
-	// 	roots, err = s.finder.FindMavenRoots(s.files)
-	// }
-
+	// Roots are root pom files and Gradle settings or build files, see packageManager
 	roots, err = s.finder.FindRoots(files)
 	if err != nil {
-		strategyWarning("Error while finding roots: " + err.Error())
+		finder.StrategyWarning("Error while finding roots: " + err.Error())
 
 		return jobs, err
 	}
@@ -83,7 +74,7 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 		}
 	}
 	if foundRootsWoClasses > 0 {
-		strategyWarning("Found " + fmt.Sprint(foundRootsWoClasses) + " roots without related classes, make sure to build your project before running.")
+		finder.StrategyWarning("Found " + fmt.Sprint(foundRootsWoClasses) + " roots without related classes, make sure to build your project before running.")
 	}
 	for rootFile, classDirs := range rootClassMapping {
 		// For each class paths dir within the root, find GCDPath as entrypoint
@@ -95,7 +86,7 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 			s.cmdFactory,
 			io.FileWriter{},
 			io.NewArchive(rootDir),
-			s.config,
+			s.rootConfig(rootFile),
 			s.ctx,
 			io.FileSystem{},
 			SootHandler{s.config.Version()},
@@ -117,16 +108,36 @@ func NewStrategy(
 	return Strategy{config, CmdFactory{}, paths, exclusions, inclusions, finder, ctx}
 }
 
-func strategyWarning(errMsg string) {
-	err := fmt.Errorf("%s", errMsg)
-	warningColor := color.New(color.FgYellow, color.Bold).SprintFunc()
-	defaultOutputWriter := log.Writer()
-	log.Println(warningColor("Warning: ") + err.Error())
-	log.SetOutput(defaultOutputWriter)
+// packageManager returns the package manager building the project of rootFile
+func packageManager(rootFile string) string {
+	if strings.Contains(filepath.Base(rootFile), ".gradle") {
+		return gradle
+	}
+
+	return maven
+}
+
+// rootConfig returns the config of the job of rootFile, with the package manager building its project
+func (s Strategy) rootConfig(rootFile string) conf.IConfig {
+	pm := packageManager(rootFile)
+	if pm == s.config.PackageManager() {
+		return s.config
+	}
+
+	return conf.NewConfig(s.config.Language(), s.config.Args(), s.config.Kwargs(), s.config.Build(), pm, s.config.Version())
+}
+
+func (s Strategy) makeBuildCmd(rootFile string) (*exec.Cmd, error) {
+	rootDir := filepath.Dir(rootFile)
+	if packageManager(rootFile) == gradle {
+		return s.cmdFactory.MakeBuildGradleCmd(rootDir, s.ctx)
+	}
+
+	return s.cmdFactory.MakeBuildMavenCmd(rootDir, s.ctx)
 }
 
 func buildProjects(s Strategy, roots []string) error {
-	spinnerType := "building maven project"
+	spinnerType := "building java project"
 	spinnerManager := tui.NewSpinnerManager("Callgraph Build Project", spinnerType)
 	spinnerManager.Start()
 	success := false || len(roots) == 0
@@ -134,7 +145,7 @@ func buildProjects(s Strategy, roots []string) error {
 	for _, rootFile := range roots {
 		rootDir := filepath.Dir(rootFile)
 		spinner := spinnerManager.AddSpinner(rootDir)
-		osCmd, err := s.cmdFactory.MakeBuildMavenCmd(rootDir, s.ctx)
+		osCmd, err := s.makeBuildCmd(rootFile)
 		if err != nil {
 			err := "Error while building roots (Make command): " + err.Error() + "\nRoot: " + rootDir
 			errors = append(errors, err)
@@ -164,7 +175,7 @@ func buildProjects(s Strategy, roots []string) error {
 		return nil
 	} else {
 		for _, err := range errors {
-			strategyWarning(err)
+			finder.StrategyWarning(err)
 		}
 
 		return fmt.Errorf("%s", strings.Join([]string{
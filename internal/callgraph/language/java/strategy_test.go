@@ -97,3 +97,37 @@ func TestBuildProjectsError(t *testing.T) {
 
 	assert.NotNil(t, err)
 }
+
+func TestInvokeGradleRoot(t *testing.T) {
+	conf := config.NewConfig("java", []string{"arg1"}, map[string]string{"kwarg": "val"}, false, "maven", "v2.0.0")
+	finder := testdata.NewEmptyFinderMock()
+	testFiles := []string{"file-1", "file-2"}
+	finder.FindRootsNames = []string{"file-1/settings.gradle", "file-2/pom.xml"}
+	finder.FindDependencyDirsNames = []string{"file-1/app/build/classes/java/main", "file-2/target/classes"}
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, testFiles, []string{}, []string{}, finder, ctx)
+	jobs, _ := s.Invoke()
+	assert.Len(t, jobs, 2)
+	for _, j := range jobs {
+		javaJob, ok := j.(*Job)
+		assert.True(t, ok)
+		expected := maven
+		if filepath.Base(j.GetDir()) == "file-1" {
+			expected = gradle
+		}
+		assert.Equal(t, expected, javaJob.config.PackageManager())
+		assert.Equal(t, "v2.0.0", javaJob.config.Version())
+	}
+}
+
+func TestBuildProjectsGradle(t *testing.T) {
+	conf := config.NewConfig("java", []string{"arg1"}, map[string]string{"kwarg": "val"}, true, "maven", "v2.0.0")
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, []string{}, []string{}, []string{}, testdata.NewEmptyFinderMock(), ctx)
+	factoryMock := javaTestdata.NewEchoCmdFactory()
+	factoryMock.BuildMavenErr = fmt.Errorf("build-error")
+	s.cmdFactory = factoryMock
+
+	assert.NoError(t, buildProjects(s, []string{"file-1/build.gradle.kts"}))
+	assert.Error(t, buildProjects(s, []string{"file-2/pom.xml"}))
+}
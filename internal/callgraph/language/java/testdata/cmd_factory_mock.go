@@ -16,6 +16,10 @@ type CmdFactoryMock struct {
 	BuildMavenErr    error
 	JavaVersionName  string
 	JavaVersionErr   error
+	BuildGradleName  string
+	BuildGradleErr   error
+	GradleCopyDepCmd *exec.Cmd
+	GradleCopyDepErr error
 }
 
 func NewEchoCmdFactory() CmdFactoryMock {
@@ -24,6 +28,7 @@ func NewEchoCmdFactory() CmdFactoryMock {
 		CallGraphGenName: "echo",
 		BuildMavenName:   "echo",
 		JavaVersionName:  "echo",
+		BuildGradleName:  "echo",
 	}
 }
 
@@ -46,6 +51,18 @@ func (f CmdFactoryMock) MakeJavaVersionCmd(workingDirectory string, ctx cgexec.I
 	), f.JavaVersionErr
 }
 
+func (f CmdFactoryMock) MakeBuildGradleCmd(_ string, _ cgexec.IContext) (*exec.Cmd, error) {
+	return exec.Command(f.BuildGradleName, "BuildGradle"), f.BuildGradleErr
+}
+
+func (f CmdFactoryMock) MakeGradleCopyDependenciesCmd(_ string, _ string, _ string, _ cgexec.IContext) (*exec.Cmd, error) {
+	if f.GradleCopyDepCmd != nil {
+		return f.GradleCopyDepCmd, f.GradleCopyDepErr
+	}
+
+	return exec.Command("echo", "GradleCopyDep"), f.GradleCopyDepErr
+}
+
 type MockSootHandler struct {
 	GetSootWrapperError error
 }
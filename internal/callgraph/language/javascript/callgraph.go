@@ -0,0 +1,327 @@
+package javascript
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/debricked/cli/internal/callgraph/cgexec"
+	"github.com/debricked/cli/internal/callgraph/finder/javascriptfinder"
+	"github.com/debricked/cli/internal/callgraph/model"
+	ioFs "github.com/debricked/cli/internal/io"
+)
+
+const (
+	// maxFileSize is the size of the largest file parsed, larger files are usually bundled or minified
+	maxFileSize = 5 * 1024 * 1024
+	// maxExportDepth limits how many re-exports are followed to find an export
+	maxExportDepth = 16
+)
+
+type ICallgraphBuilder interface {
+	RunCallGraph() (string, error)
+}
+
+type CallgraphBuilder struct {
+	filesystem       ioFs.IFileSystem
+	workingDirectory string
+	root             string
+	files            []string
+	outputName       string
+	ctx              cgexec.IContext
+	cgModel          *model.CallGraph
+	modules          map[string]*module
+	queue            []queued
+}
+
+// queued is a function whose calls are yet to be added to the call graph
+type queued struct {
+	module   *module
+	function *function
+	node     *model.Node
+}
+
+func NewCallgraphBuilder(
+	workingDirectory string,
+	files []string,
+	outputName string,
+	filesystem ioFs.IFileSystem,
+	ctx cgexec.IContext,
+) CallgraphBuilder {
+	return CallgraphBuilder{
+		workingDirectory: workingDirectory,
+		files:            files,
+		outputName:       outputName,
+		filesystem:       filesystem,
+		ctx:              ctx,
+		cgModel:          model.NewCallGraph(),
+		modules:          map[string]*module{},
+	}
+}
+
+// constructCallGraph adds every function of the application files and, from their calls, the functions of
+// node_modules that are called
+func (cg *CallgraphBuilder) constructCallGraph() error {
+	// Packages are looked up in the node_modules of parent directories as well, which needs an absolute path
+	root, err := filepath.Abs(cg.workingDirectory)
+	if err != nil {
+		return err
+	}
+	cg.root = root
+	for _, file := range cg.files {
+		m, err := cg.load(filepath.Join(cg.root, file))
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		if m == nil {
+			continue
+		}
+		for _, fn := range m.order {
+			cg.functionNode(m, fn)
+		}
+	}
+
+	for len(cg.queue) > 0 {
+		if cg.ctx != nil && cg.ctx.Context().Err() != nil {
+			return cg.ctx.Context().Err()
+		}
+		next := cg.queue[0]
+		cg.queue = cg.queue[1:]
+		cg.addCalls(next)
+	}
+
+	return nil
+}
+
+// load parses a module once, returning nil for files too large to parse
+func (cg *CallgraphBuilder) load(file string) (*module, error) {
+	if m, ok := cg.modules[file]; ok {
+		return m, nil
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+	var m *module
+	if info.Size() <= maxFileSize {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		m = parse(file, string(content))
+	}
+	cg.modules[file] = m
+
+	return m, nil
+}
+
+func (cg *CallgraphBuilder) addCalls(caller queued) {
+	added := map[string]bool{}
+	for _, c := range caller.function.calls {
+		callee := cg.resolveCall(caller.module, caller.function, c)
+		if callee == nil {
+			continue
+		}
+		edge := fmt.Sprintf("%s:%d", callee.Symbol, c.line)
+		if added[edge] {
+			continue
+		}
+		added[edge] = true
+		cg.cgModel.AddEdge(caller.node, callee, c.line)
+	}
+}
+
+// functionNode returns the node of a function, adding it and queueing its calls the first time
+func (cg *CallgraphBuilder) functionNode(m *module, fn *function) *model.Node {
+	filename := cg.relativePath(m.path)
+	symbol := filename + ":" + fn.name
+	if node := cg.cgModel.GetNode(symbol); node != nil {
+		return node
+	}
+	node := cg.cgModel.AddNode(filename, fn.name, symbol, IsApplicationNode(filename), false, fn.lineStart, fn.lineEnd)
+	cg.queue = append(cg.queue, queued{module: m, function: fn, node: node})
+
+	return node
+}
+
+// externalNode returns the node of a function of a built-in module, or of a package that isn't installed
+func (cg *CallgraphBuilder) externalNode(kind moduleKind, name string, members []string) *model.Node {
+	if len(members) > 0 && members[0] == "default" && kind == builtinModule {
+		// The default export of a built-in module is the module itself
+		members = members[1:]
+	}
+	if len(members) == 0 {
+		members = []string{"default"}
+	}
+	member := strings.Join(members, ".")
+	symbol := name + ":" + member
+	if kind == builtinModule {
+		symbol = "node:" + name + "." + member
+	}
+
+	return cg.cgModel.AddNode("", member, symbol, false, kind == builtinModule, -1, -1)
+}
+
+func (cg *CallgraphBuilder) relativePath(file string) string {
+	relative, err := filepath.Rel(cg.root, file)
+	if err != nil {
+		relative = file
+	}
+
+	return filepath.ToSlash(relative)
+}
+
+// IsApplicationNode reports whether a file is part of the application rather than of its dependencies
+func IsApplicationNode(filename string) bool {
+	for _, part := range strings.Split(filename, "/") {
+		if part == "node_modules" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (cg *CallgraphBuilder) resolveCall(m *module, fn *function, c call) *model.Node {
+	if c.module != "" {
+		return cg.resolveImport(m, binding{module: c.module, name: "*"}, c.chain, c.isNew, 0)
+	}
+	chain := cg.instanceChain(m, fn, c.chain)
+	if chain[0] == "this" {
+		if callee := m.lookup(qualify(fn.this, chain[1:]...), c.isNew); callee != nil {
+			return cg.functionNode(m, callee)
+		}
+
+		return nil
+	}
+	if b, ok := m.imports[chain[0]]; ok {
+		return cg.resolveImport(m, b, chain, c.isNew, 0)
+	}
+	name := strings.Join(chain, ".")
+	for scope := fn.scope; ; scope = parent(scope) {
+		if callee := m.lookup(qualify(scope, name), c.isNew); callee != nil {
+			return cg.functionNode(m, callee)
+		}
+		if scope == "" {
+			return nil
+		}
+	}
+}
+
+// instanceChain replaces a variable assigned new Foo() at the start of a chain by Foo, so that its methods are found
+func (cg *CallgraphBuilder) instanceChain(m *module, fn *function, chain []string) []string {
+	if len(chain) < 2 {
+		return chain
+	}
+	for scope := fn.scope; ; scope = parent(scope) {
+		if class, ok := m.instances[qualify(scope, chain[0])]; ok {
+			return append(append([]string{}, class...), chain[1:]...)
+		}
+		if scope == "" {
+			return chain
+		}
+	}
+}
+
+// resolveImport resolves a call of chain, whose first name is bound to an import
+func (cg *CallgraphBuilder) resolveImport(m *module, b binding, chain []string, isNew bool, depth int) *model.Node {
+	name, rest := b.name, chain[1:]
+	if name == "*" {
+		if len(rest) == 0 {
+			name = "default"
+		} else {
+			name, rest = rest[0], rest[1:]
+		}
+	}
+
+	return cg.resolveExport(m.path, b.module, name, rest, isNew, depth)
+}
+
+// resolveExport resolves a call of the export name, followed by the members in rest, of the module imported as
+// specifier in the file from
+func (cg *CallgraphBuilder) resolveExport(from, specifier, name string, rest []string, isNew bool, depth int) *model.Node {
+	kind, resolved := resolveModule(from, specifier)
+	if resolved == "" {
+		return nil
+	}
+	if kind != fileModule {
+		return cg.externalNode(kind, resolved, append([]string{name}, rest...))
+	}
+	if !javascriptfinder.IsSource(resolved) {
+		return nil
+	}
+	m, err := cg.load(resolved)
+	if err != nil || m == nil {
+		return nil
+	}
+
+	return cg.lookupExport(m, name, rest, isNew, depth)
+}
+
+func (cg *CallgraphBuilder) lookupExport(m *module, name string, rest []string, isNew bool, depth int) *model.Node {
+	if depth > maxExportDepth {
+		return nil
+	}
+	if e, ok := m.exports[name]; ok {
+		return cg.resolveExported(m, e, rest, isNew, depth)
+	}
+	if name == "default" {
+		return nil
+	}
+	for _, specifier := range m.starts {
+		if node := cg.resolveExport(m.path, specifier, name, rest, isNew, depth+1); node != nil {
+			return node
+		}
+	}
+	if e, ok := m.exports["default"]; ok {
+		// Members of a CommonJS module.exports, or of an object or class exported as default
+		return cg.resolveExported(m, e, append([]string{name}, rest...), isNew, depth)
+	}
+
+	return nil
+}
+
+func (cg *CallgraphBuilder) resolveExported(m *module, e export, rest []string, isNew bool, depth int) *model.Node {
+	if e.module == "" {
+		if callee := m.lookup(qualify(e.local, rest...), isNew); callee != nil {
+			return cg.functionNode(m, callee)
+		}
+		if b, ok := m.imports[e.local]; ok {
+			// Export of an imported name
+			return cg.resolveImport(m, b, append([]string{e.local}, rest...), isNew, depth+1)
+		}
+
+		return nil
+	}
+	if e.name == "*" {
+		if len(rest) == 0 {
+			return cg.resolveExport(m.path, e.module, "default", rest, isNew, depth+1)
+		}
+
+		return cg.resolveExport(m.path, e.module, rest[0], rest[1:], isNew, depth+1)
+	}
+
+	return cg.resolveExport(m.path, e.module, e.name, rest, isNew, depth+1)
+}
+
+func (cg *CallgraphBuilder) RunCallGraph() (string, error) {
+	err := cg.constructCallGraph()
+	if err != nil {
+		return "", err
+	}
+
+	cgOutputBytes, err := cg.cgModel.ToBytes()
+	if err != nil {
+		return "", err
+	}
+
+	outputFullPath := path.Join(cg.workingDirectory, cg.outputName)
+	err = cg.filesystem.FsWriteFile(outputFullPath, cgOutputBytes, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	return outputFullPath, nil
+}
@@ -0,0 +1,136 @@
+package javascript
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	ctxTestdata "github.com/debricked/cli/internal/callgraph/cgexec/testdata"
+	"github.com/debricked/cli/internal/callgraph/model"
+	"github.com/debricked/cli/internal/io"
+	ioTestData "github.com/debricked/cli/internal/io/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+var fixtureFiles = []string{"src/app.js", "src/util.js", "src/shout.ts"}
+
+func parents(node *model.Node) []string {
+	var symbols []string
+	for _, edge := range node.Parents {
+		symbols = append(symbols, fmt.Sprintf("%s:%d", edge.Parent.Symbol, edge.CallLine))
+	}
+
+	return symbols
+}
+
+func TestConstructCallGraph(t *testing.T) {
+	ctx, _ := ctxTestdata.NewContextMock()
+	cg := NewCallgraphBuilder(filepath.Join("testdata", "fixture"), fixtureFiles, outputName, io.FileSystem{}, ctx)
+	err := cg.constructCallGraph()
+	assert.NoError(t, err)
+
+	cases := map[string][]string{
+		"src/app.js:main":                                      {"src/app.js:<module>:20"},
+		"src/app.js:readName":                                  {"src/app.js:main:10"},
+		"src/util.js:name":                                     {"src/app.js:main:11"},
+		"src/shout.ts:shout":                                   {"src/app.js:main:11"},
+		"src/shout.ts:Shouter.constructor":                     {"src/shout.ts:shout:21"},
+		"src/shout.ts:Shouter.shout":                           {"src/shout.ts:shout:21"},
+		"src/shout.ts:Shouter.upper":                           {"src/shout.ts:Shouter.shout:12"},
+		"node:fs.readFileSync":                                 {"src/app.js:readName:15"},
+		"node:path.basename":                                   {"src/util.js:name:4"},
+		"not-installed:missing":                                {"src/shout.ts:Shouter.upper:16"},
+		"node_modules/greeting/lib/index.js:greet":             {"src/app.js:main:11"},
+		"node_modules/greeting/lib/greeter.js:Greeter":         {"node_modules/greeting/lib/index.js:greet:6", "src/app.js:main:9"},
+		"node_modules/greeting/lib/greeter.js:Greeter.welcome": {"src/app.js:main:10"},
+		"node_modules/greeting/lib/greeter.js:Greeter.message": {"node_modules/greeting/lib/greeter.js:Greeter.welcome:12", "node_modules/greeting/lib/index.js:greet:6"},
+		"node_modules/@scope/format/index.cjs:format":          {"src/app.js:render:18"},
+		"node_modules/@scope/format/index.cjs:pad":             {"node_modules/@scope/format/index.cjs:format:1"},
+		"src/app.js:render":                                    nil,
+		"src/util.js:unused":                                   nil,
+		"src/app.js:<module>":                                  nil,
+	}
+	for symbol, expected := range cases {
+		t.Run(symbol, func(t *testing.T) {
+			node := cg.cgModel.GetNode(symbol)
+			assert.NotNil(t, node)
+			if node != nil {
+				assert.ElementsMatch(t, expected, parents(node))
+			}
+		})
+	}
+
+	// Functions of node_modules are only added when called
+	assert.Nil(t, cg.cgModel.GetNode("node_modules/greeting/lib/greeter.js:Greeter.unused"))
+}
+
+func TestConstructCallGraphNodes(t *testing.T) {
+	cg := NewCallgraphBuilder(filepath.Join("testdata", "fixture"), fixtureFiles, outputName, io.FileSystem{}, nil)
+	err := cg.constructCallGraph()
+	assert.NoError(t, err)
+
+	node := cg.cgModel.GetNode("src/shout.ts:Shouter.upper")
+	assert.Equal(t, "src/shout.ts", node.Filename)
+	assert.Equal(t, "Shouter.upper", node.Name)
+	assert.True(t, node.IsApplicationNode)
+	assert.False(t, node.IsStdLibNode)
+	assert.Equal(t, 15, node.LineStart)
+	assert.Equal(t, 17, node.LineEnd)
+
+	node = cg.cgModel.GetNode("node_modules/greeting/lib/index.js:greet")
+	assert.False(t, node.IsApplicationNode)
+	assert.False(t, node.IsStdLibNode)
+
+	node = cg.cgModel.GetNode("node:fs.readFileSync")
+	assert.False(t, node.IsApplicationNode)
+	assert.True(t, node.IsStdLibNode)
+}
+
+func TestConstructCallGraphMissingFile(t *testing.T) {
+	cg := NewCallgraphBuilder(filepath.Join("testdata", "fixture"), []string{"src/missing.js"}, outputName, io.FileSystem{}, nil)
+	err := cg.constructCallGraph()
+	assert.ErrorContains(t, err, "failed to parse src/missing.js")
+}
+
+func TestConstructCallGraphCancelled(t *testing.T) {
+	ctx, _ := ctxTestdata.NewContextMockCancelled()
+	cg := NewCallgraphBuilder(filepath.Join("testdata", "fixture"), fixtureFiles, outputName, io.FileSystem{}, ctx)
+	err := cg.constructCallGraph()
+	assert.Error(t, err)
+}
+
+func TestRunCallGraph(t *testing.T) {
+	outputPath := filepath.Join("testdata", "fixture", "debricked-call-graph.javascript-test")
+	defer func() {
+		err := os.Remove(outputPath)
+		if err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	ctx, _ := ctxTestdata.NewContextMock()
+	cg := NewCallgraphBuilder(filepath.Join("testdata", "fixture"), fixtureFiles, "debricked-call-graph.javascript-test", io.FileSystem{}, ctx)
+	output, err := cg.RunCallGraph()
+	assert.NoError(t, err)
+	assert.Equal(t, outputPath, output)
+
+	content, err := os.ReadFile(outputPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "{\"version\": \"5\", \"data\": [")
+	assert.Contains(t, string(content), "[\"src/app.js:main\", true, false, \"main\", \"src/app.js\", 8, 12, [")
+}
+
+func TestRunCallGraphWriteError(t *testing.T) {
+	fs := ioTestData.FileSystemMock{FsWriteFileError: fmt.Errorf("error")}
+	cg := NewCallgraphBuilder(filepath.Join("testdata", "fixture"), fixtureFiles, outputName, fs, nil)
+	_, err := cg.RunCallGraph()
+	assert.Error(t, err)
+}
+
+func TestIsApplicationNode(t *testing.T) {
+	assert.True(t, IsApplicationNode("src/app.js"))
+	assert.False(t, IsApplicationNode("node_modules/lib/index.js"))
+	assert.False(t, IsApplicationNode("../node_modules/lib/index.js"))
+	assert.True(t, IsApplicationNode("src/node_modules_utils.js"))
+}
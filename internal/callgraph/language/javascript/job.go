@@ -0,0 +1,84 @@
+package javascript
+
+import (
+	"os"
+	"syscall"
+
+	"github.com/debricked/cli/internal/callgraph/cgexec"
+	conf "github.com/debricked/cli/internal/callgraph/config"
+	"github.com/debricked/cli/internal/callgraph/job"
+	"github.com/debricked/cli/internal/io"
+	ioFs "github.com/debricked/cli/internal/io"
+)
+
+const (
+	outputName = "debricked-call-graph.javascript"
+)
+
+type Job struct {
+	job.BaseJob
+	config  conf.IConfig
+	archive io.IArchive
+	ctx     cgexec.IContext
+	fs      ioFs.IFileSystem
+}
+
+// NewJob creates a job generating the call graph of the JavaScript and TypeScript files, relative to dir, of the
+// project in dir
+func NewJob(dir string, files []string, writer ioFs.IFileWriter, archive io.IArchive, config conf.IConfig, ctx cgexec.IContext, fs ioFs.IFileSystem) *Job {
+	return &Job{
+		BaseJob: job.NewBaseJob(dir, files),
+		config:  config,
+		archive: archive,
+		ctx:     ctx,
+		fs:      fs,
+	}
+}
+
+func (j *Job) Run() {
+	callgraph := NewCallgraphBuilder(
+		j.GetDir(),
+		j.GetFiles(),
+		outputName,
+		j.fs,
+		j.ctx,
+	)
+	j.SendStatus("generating call graph")
+	j.runCallGraph(&callgraph)
+}
+
+func (j *Job) runCallGraph(callgraph ICallgraphBuilder) {
+	outputFullPath, err := callgraph.RunCallGraph()
+	if err != nil {
+		j.Errors().Critical(err)
+
+		return
+	}
+	outputFullPathZip := outputFullPath + ".zip"
+
+	j.SendStatus("zipping callgraph")
+	err = j.archive.ZipFile(outputFullPath, outputFullPathZip, outputName)
+	if err != nil {
+		j.Errors().Critical(err)
+
+		return
+	}
+
+	j.SendStatus("base64 encoding zipped callgraph")
+	err = j.archive.B64(outputFullPathZip, outputFullPath)
+	if err != nil {
+		j.Errors().Critical(err)
+
+		return
+	}
+
+	j.SendStatus("cleanup")
+	err = j.archive.Cleanup(outputFullPathZip)
+	if err != nil {
+		e, ok := err.(*os.PathError)
+		if ok && e.Err == syscall.ENOENT {
+			return
+		}
+		j.Errors().Critical(err)
+	}
+}
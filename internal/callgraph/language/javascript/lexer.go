@@ -0,0 +1,323 @@
+package javascript
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	identToken tokenKind = iota
+	punctToken
+	stringToken
+	templateToken
+	numberToken
+	regexToken
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	line  int
+	// newline is set if a line break precedes the token
+	newline bool
+}
+
+// punctuators holds the multi-character punctuators, longest first, so that = isn't confused with ==, => or +=
+var punctuators = []string{
+	">>>=", "...", "===", "!==", "**=", "<<=", ">>=", ">>>", "&&=", "||=", "??=",
+	"=>", "==", "!=", "<=", ">=", "&&", "||", "??", "?.", "**", "++", "--", "+=", "-=", "*=", "/=", "%=", "&=", "|=",
+	"^=", "<<", ">>",
+}
+
+// regexPrecedingKeywords are the keywords after which a / starts a regular expression rather than a division
+var regexPrecedingKeywords = map[string]bool{
+	"return": true, "typeof": true, "instanceof": true, "in": true, "of": true, "new": true, "delete": true,
+	"void": true, "throw": true, "case": true, "do": true, "else": true, "yield": true, "await": true,
+}
+
+type lexer struct {
+	src    string
+	pos    int
+	line   int
+	tokens []token
+	// newline is set if a line break was passed since the last token
+	newline bool
+	// braces is the depth of braces, and templates the depths at which template substitutions started
+	braces    int
+	templates []int
+}
+
+// lex splits JavaScript or TypeScript source into tokens, leaving out whitespace and comments. Template literals are
+// single tokens, except for the code of their substitutions.
+func lex(src string) []token {
+	l := &lexer{src: src, line: 1}
+	if strings.HasPrefix(src, "#!") {
+		l.skipLine()
+	}
+	for l.pos < len(l.src) {
+		l.next()
+	}
+
+	return l.tokens
+}
+
+func (l *lexer) emit(kind tokenKind, value string, line int) {
+	l.tokens = append(l.tokens, token{kind: kind, value: value, line: line, newline: l.newline})
+	l.newline = false
+}
+
+func (l *lexer) next() {
+	c := l.src[l.pos]
+	switch {
+	case c == '\n':
+		l.line++
+		l.newline = true
+		l.pos++
+	case c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v':
+		l.pos++
+	case strings.HasPrefix(l.src[l.pos:], "//"):
+		l.skipLine()
+	case strings.HasPrefix(l.src[l.pos:], "/*"):
+		l.skipBlockComment()
+	case c == '"' || c == '\'':
+		l.lexString(c)
+	case c == '`':
+		l.pos++
+		l.lexTemplate()
+	case c >= '0' && c <= '9' || c == '.' && l.pos+1 < len(l.src) && isDigit(l.src[l.pos+1]):
+		l.lexNumber()
+	case c == '/' && l.regexAllowed():
+		l.lexRegex()
+	case isIdentStart(l.src[l.pos:]):
+		l.lexIdent()
+	default:
+		l.lexPunct()
+	}
+}
+
+func (l *lexer) skipLine() {
+	end := strings.IndexByte(l.src[l.pos:], '\n')
+	if end < 0 {
+		l.pos = len(l.src)
+
+		return
+	}
+	l.pos += end
+}
+
+func (l *lexer) skipBlockComment() {
+	end := strings.Index(l.src[l.pos+2:], "*/")
+	if end < 0 {
+		end = len(l.src) - l.pos - 2
+	}
+	comment := l.src[l.pos : l.pos+2+end]
+	if lines := strings.Count(comment, "\n"); lines > 0 {
+		l.line += lines
+		l.newline = true
+	}
+	l.pos = min(l.pos+end+4, len(l.src))
+}
+
+func (l *lexer) lexString(quote byte) {
+	line := l.line
+	var value strings.Builder
+	l.pos++
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		if c == quote {
+			l.pos++
+
+			break
+		}
+		if c == '\n' {
+			// Unterminated string
+			break
+		}
+		if c == '\\' && l.pos+1 < len(l.src) {
+			if l.src[l.pos+1] == '\n' {
+				l.line++
+			}
+			value.WriteByte(l.src[l.pos+1])
+			l.pos += 2
+
+			continue
+		}
+		value.WriteByte(c)
+		l.pos++
+	}
+	l.emit(stringToken, value.String(), line)
+}
+
+// lexTemplate lexes the rest of a template literal, up to its end or the next substitution
+func (l *lexer) lexTemplate() {
+	line := l.line
+	start := l.pos
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '\\':
+			l.pos += 2
+
+			continue
+		case c == '\n':
+			l.line++
+		case c == '`':
+			l.emit(templateToken, l.src[start:l.pos], line)
+			l.pos++
+
+			return
+		case c == '$' && l.pos+1 < len(l.src) && l.src[l.pos+1] == '{':
+			l.emit(templateToken, l.src[start:l.pos], line)
+			l.pos += 2
+			l.templates = append(l.templates, l.braces)
+			l.braces++
+
+			return
+		}
+		l.pos++
+	}
+	l.emit(templateToken, l.src[start:min(l.pos, len(l.src))], line)
+}
+
+func (l *lexer) lexNumber() {
+	start := l.pos
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		if isDigit(c) || c == '.' || c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+			l.pos++
+
+			continue
+		}
+		if (c == '+' || c == '-') && (l.src[l.pos-1] == 'e' || l.src[l.pos-1] == 'E') && !strings.HasPrefix(l.src[start:], "0x") {
+			l.pos++
+
+			continue
+		}
+
+		break
+	}
+	l.emit(numberToken, l.src[start:l.pos], l.line)
+}
+
+// regexAllowed reports whether a / at the current position starts a regular expression, judging by the token before
+func (l *lexer) regexAllowed() bool {
+	if len(l.tokens) == 0 {
+		return true
+	}
+	previous := l.tokens[len(l.tokens)-1]
+	switch previous.kind {
+	case identToken:
+		return regexPrecedingKeywords[previous.value]
+	case punctToken:
+		return previous.value != ")" && previous.value != "]" && previous.value != "}" &&
+			previous.value != "++" && previous.value != "--"
+	case stringToken, templateToken, numberToken, regexToken:
+		return false
+	}
+
+	return false
+}
+
+func (l *lexer) lexRegex() {
+	start := l.pos
+	l.pos++
+	inClass := false
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		if c == '\n' {
+			break
+		}
+		if c == '\\' {
+			l.pos += 2
+
+			continue
+		}
+		l.pos++
+		if c == '[' {
+			inClass = true
+		} else if c == ']' {
+			inClass = false
+		} else if c == '/' && !inClass {
+			break
+		}
+	}
+	for l.pos < len(l.src) && isIdentPart(l.src[l.pos]) {
+		l.pos++
+	}
+	l.pos = min(l.pos, len(l.src))
+	l.emit(regexToken, l.src[start:l.pos], l.line)
+}
+
+func (l *lexer) lexIdent() {
+	start := l.pos
+	l.pos++
+	for l.pos < len(l.src) {
+		if isIdentPart(l.src[l.pos]) {
+			l.pos++
+
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+		if r >= utf8.RuneSelf && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			l.pos += size
+
+			continue
+		}
+
+		break
+	}
+	l.emit(identToken, l.src[start:l.pos], l.line)
+}
+
+func (l *lexer) lexPunct() {
+	rest := l.src[l.pos:]
+	for _, punctuator := range punctuators {
+		// ?. followed by a digit is a conditional operator followed by a number
+		if strings.HasPrefix(rest, punctuator) && (punctuator != "?." || len(rest) < 3 || !isDigit(rest[2])) {
+			l.emit(punctToken, punctuator, l.line)
+			l.pos += len(punctuator)
+
+			return
+		}
+	}
+	c := l.src[l.pos]
+	switch c {
+	case '{':
+		l.braces++
+	case '}':
+		if n := len(l.templates); n > 0 && l.templates[n-1] == l.braces-1 {
+			// End of a template substitution, the template literal continues
+			l.templates = l.templates[:n-1]
+			l.braces--
+			l.pos++
+			l.lexTemplate()
+
+			return
+		}
+		l.braces--
+	}
+	_, size := utf8.DecodeRuneInString(rest)
+	l.emit(punctToken, rest[:size], l.line)
+	l.pos += size
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isIdentPart(c byte) bool {
+	return c == '_' || c == '$' || isDigit(c) || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isIdentStart(s string) bool {
+	c := s[0]
+	if c == '_' || c == '$' || c == '#' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' {
+		return true
+	}
+	r, _ := utf8.DecodeRuneInString(s)
+
+	return r >= utf8.RuneSelf && unicode.IsLetter(r)
+}
@@ -0,0 +1,57 @@
+package javascript
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func values(tokens []token) []string {
+	result := make([]string, len(tokens))
+	for i, t := range tokens {
+		result[i] = t.value
+	}
+
+	return result
+}
+
+func TestLex(t *testing.T) {
+	tokens := lex("const a = b?.c(1) ?? 'd';")
+	assert.Equal(t, []string{"const", "a", "=", "b", "?.", "c", "(", "1", ")", "??", "d", ";"}, values(tokens))
+	assert.Equal(t, stringToken, tokens[10].kind)
+}
+
+func TestLexComments(t *testing.T) {
+	tokens := lex("#!/usr/bin/env node\n// comment\na /* multi\nline */ b")
+	assert.Equal(t, []string{"a", "b"}, values(tokens))
+	assert.Equal(t, 3, tokens[0].line)
+	assert.Equal(t, 4, tokens[1].line)
+	assert.True(t, tokens[1].newline)
+}
+
+func TestLexTemplate(t *testing.T) {
+	tokens := lex("`a ${f({ b: 1 })} c`; d")
+	assert.Equal(t, []string{"a ", "f", "(", "{", "b", ":", "1", "}", ")", " c", ";", "d"}, values(tokens))
+	assert.Equal(t, templateToken, tokens[0].kind)
+	assert.Equal(t, templateToken, tokens[9].kind)
+}
+
+func TestLexRegex(t *testing.T) {
+	tokens := lex("x = a / b; y = /[/]\\/(/g.test(s)")
+	assert.Equal(t, []string{"x", "=", "a", "/", "b", ";", "y", "=", "/[/]\\/(/g", ".", "test", "(", "s", ")"}, values(tokens))
+	assert.Equal(t, regexToken, tokens[8].kind)
+}
+
+func TestLexEscapedString(t *testing.T) {
+	tokens := lex(`f("a\"b", 'c\'d')`)
+	assert.Equal(t, []string{"f", "(", `a"b`, ",", "c'd", ")"}, values(tokens))
+}
+
+func TestLexUnterminated(t *testing.T) {
+	assert.NotPanics(t, func() {
+		lex("f('a")
+		lex("`a ${b")
+		lex("/* a")
+		lex("x = /a")
+	})
+}
@@ -0,0 +1,1126 @@
+package javascript
+
+import (
+	"strings"
+)
+
+const moduleFunctionName = "<module>"
+
+// function is a named function, method or the top-level code of a module
+type function struct {
+	// name is qualified by the names of the functions, classes and objects it's defined in, such as Greeter.greet
+	name  string
+	scope string
+	// this is the scope of the methods called on this
+	this      string
+	lineStart int
+	lineEnd   int
+	calls     []call
+}
+
+type call struct {
+	// chain is the callee, such as [this greet], [fs readFileSync] or [require foo] for require('x').foo()
+	chain []string
+	// module is set for members of require('x')
+	module string
+	line   int
+	isNew  bool
+}
+
+// binding is an import of name from module, where name is default or * for a namespace
+type binding struct {
+	module string
+	name   string
+}
+
+// export is a local name, or a name of another module when re-exported
+type export struct {
+	local  string
+	module string
+	name   string
+}
+
+type module struct {
+	path      string
+	functions map[string]*function
+	// order keeps the functions in order of definition
+	order     []*function
+	imports   map[string]binding
+	exports   map[string]export
+	starts    []string
+	instances map[string][]string
+}
+
+func newModule(path string) *module {
+	return &module{
+		path:      path,
+		functions: map[string]*function{},
+		imports:   map[string]binding{},
+		exports:   map[string]export{},
+		instances: map[string][]string{},
+	}
+}
+
+func (m *module) add(fn *function) {
+	if _, ok := m.functions[fn.name]; ok {
+		return
+	}
+	m.functions[fn.name] = fn
+	m.order = append(m.order, fn)
+}
+
+// lookup finds the function called by name, or the constructor of the class called by name if isNew
+func (m *module) lookup(name string, isNew bool) *function {
+	if isNew {
+		if fn, ok := m.functions[name+".constructor"]; ok {
+			return fn
+		}
+	}
+
+	return m.functions[name]
+}
+
+var reservedWords = map[string]bool{
+	"if": true, "for": true, "while": true, "switch": true, "catch": true, "return": true, "typeof": true,
+	"function": true, "super": true, "await": true, "void": true, "delete": true, "in": true, "of": true,
+	"new": true, "do": true, "else": true, "case": true, "throw": true, "yield": true, "instanceof": true,
+	"with": true, "import": true, "class": true, "const": true, "let": true, "var": true, "export": true,
+}
+
+var classModifiers = map[string]bool{
+	"static": true, "async": true, "get": true, "set": true, "public": true, "private": true, "protected": true,
+	"readonly": true, "override": true, "abstract": true, "declare": true, "accessor": true,
+}
+
+type parser struct {
+	tokens []token
+	match  []int
+	module *module
+}
+
+// parse analyses a JavaScript or TypeScript module, finding its functions, the calls made in them, its imports and its
+// exports. It's a best effort analysis of the source, which doesn't have to be valid.
+func parse(path string, src string) *module {
+	tokens := lex(src)
+	p := &parser{tokens: tokens, match: matchBrackets(tokens), module: newModule(path)}
+	lineEnd := 1
+	if len(tokens) > 0 {
+		lineEnd = tokens[len(tokens)-1].line
+	}
+	main := &function{name: moduleFunctionName, lineStart: 1, lineEnd: lineEnd}
+	p.module.add(main)
+	p.walk(0, len(tokens), main)
+
+	return p.module
+}
+
+// matchBrackets maps the index of each bracket to the index of its matching bracket, or to the end of the tokens if
+// it's unmatched
+func matchBrackets(tokens []token) []int {
+	match := make([]int, len(tokens))
+	var stack []int
+	pairs := map[string]string{")": "(", "]": "[", "}": "{"}
+	for i, t := range tokens {
+		match[i] = len(tokens)
+		if t.kind != punctToken {
+			continue
+		}
+		switch t.value {
+		case "(", "[", "{":
+			stack = append(stack, i)
+		case ")", "]", "}":
+			if n := len(stack); n > 0 && tokens[stack[n-1]].value == pairs[t.value] {
+				match[stack[n-1]] = i
+				match[i] = stack[n-1]
+				stack = stack[:n-1]
+			}
+		}
+	}
+
+	return match
+}
+
+func (p *parser) value(i int) string {
+	if i < 0 || i >= len(p.tokens) {
+		return ""
+	}
+
+	return p.tokens[i].value
+}
+
+func (p *parser) is(i int, value string) bool {
+	return i >= 0 && i < len(p.tokens) && p.tokens[i].value == value && p.tokens[i].kind != stringToken &&
+		p.tokens[i].kind != templateToken
+}
+
+func (p *parser) isString(i int) bool {
+	return i >= 0 && i < len(p.tokens) && p.tokens[i].kind == stringToken
+}
+
+func (p *parser) isIdent(i int) bool {
+	return i >= 0 && i < len(p.tokens) && p.tokens[i].kind == identToken
+}
+
+func (p *parser) line(i int) int {
+	if len(p.tokens) == 0 {
+		return 1
+	}
+
+	return p.tokens[max(0, min(i, len(p.tokens)-1))].line
+}
+
+// close returns the index of the bracket matching the one at i
+func (p *parser) close(i int) int {
+	if i < 0 || i >= len(p.match) {
+		return len(p.tokens)
+	}
+
+	return p.match[i]
+}
+
+func (p *parser) walk(start, end int, owner *function) {
+	for i := start; i < end && i < len(p.tokens); {
+		i = max(p.step(i, end, owner), i+1)
+	}
+}
+
+func (p *parser) step(i, end int, owner *function) int {
+	if !p.isIdent(i) || p.is(i-1, ".") || p.is(i-1, "?.") {
+		return i + 1
+	}
+	switch p.value(i) {
+	case "import":
+		return p.parseImport(i)
+	case "export":
+		return p.parseExport(i, end, owner)
+	case "function":
+		return p.parseFunction(i, owner, false)
+	case "async":
+		if p.is(i+1, "function") && !p.tokens[i+1].newline {
+			return p.parseFunction(i+1, owner, false)
+		}
+	case "class":
+		return p.parseClass(i, "", owner, false)
+	case "const", "let", "var":
+		return p.parseVariable(i, owner, false)
+	case "interface", "enum":
+		return p.skipDeclaration(i)
+	case "new":
+		return p.parseNew(i, owner)
+	}
+
+	return p.parseChain(i, owner)
+}
+
+// chain reads a member chain, such as this.greeter.greet, returning it and the index after it
+func (p *parser) chain(i int) ([]string, int) {
+	chain := []string{p.value(i)}
+	i++
+	for (p.is(i, ".") || p.is(i, "?.")) && p.isIdent(i+1) {
+		chain = append(chain, p.value(i+1))
+		i += 2
+	}
+
+	return chain, i
+}
+
+func (p *parser) parseChain(i int, owner *function) int {
+	chain, next := p.chain(i)
+	if p.is(next, "=") {
+		return p.parseAssignment(chain, next+1, owner)
+	}
+	paren := next
+	if p.is(paren, "?.") {
+		paren++
+	}
+	if !p.is(paren, "(") || (len(chain) == 1 && reservedWords[chain[0]]) {
+		return next
+	}
+	if len(chain) == 1 && p.is(p.close(paren)+1, "{") {
+		// Method shorthand of an anonymous object, which is walked as part of its owner
+		return next
+	}
+	if len(chain) == 1 && chain[0] == "require" {
+		return p.parseRequireCall(paren, owner)
+	}
+	owner.calls = append(owner.calls, call{chain: chain, line: p.line(i)})
+
+	return next
+}
+
+// parseRequireCall records calls to members of required modules, such as require('x').foo()
+func (p *parser) parseRequireCall(paren int, owner *function) int {
+	specifier, ok := p.requireSpecifier(paren - 1)
+	if !ok {
+		return paren
+	}
+	if !p.is(paren+3, ".") || !p.isIdent(paren+4) {
+		return paren + 3
+	}
+	members, next := p.chain(paren + 4)
+	if !p.is(next, "(") {
+		return next
+	}
+	chain := append([]string{"require"}, members...)
+	owner.calls = append(owner.calls, call{chain: chain, module: specifier, line: p.line(paren)})
+
+	return next
+}
+
+// requireSpecifier returns the module of require('x') at i
+func (p *parser) requireSpecifier(i int) (string, bool) {
+	if !p.is(i, "require") || !p.is(i+1, "(") || !p.isString(i+2) || !p.is(i+3, ")") {
+		return "", false
+	}
+
+	return p.tokens[i+2].value, true
+}
+
+func (p *parser) parseNew(i int, owner *function) int {
+	if !p.isIdent(i + 1) {
+		return i + 1
+	}
+	chain, next := p.chain(i + 1)
+	owner.calls = append(owner.calls, call{chain: chain, line: p.line(i), isNew: true})
+	if !p.is(next, "(") || !p.is(p.close(next)+1, ".") || !p.isIdent(p.close(next)+2) {
+		return next
+	}
+	// Method called on the new instance, such as new Foo().bar()
+	members, end := p.chain(p.close(next) + 2)
+	if p.is(end, "(") {
+		owner.calls = append(owner.calls, call{chain: append(append([]string{}, chain...), members...), line: p.line(i)})
+	}
+
+	return next
+}
+
+func (p *parser) parseImport(i int) int {
+	j := i + 1
+	switch {
+	case p.is(j, "(") || p.is(j, "."):
+		// Dynamic import or import.meta
+		return j
+	case p.is(j, "type") && !p.is(j+1, "from") && !p.is(j+1, ","):
+		return p.skipStatement(j)
+	case p.isIdent(j) && p.is(j+1, "=") && p.is(j+2, "require"):
+		// TypeScript import x = require('y')
+		if specifier, ok := p.requireSpecifier(j + 2); ok {
+			p.module.imports[p.value(j)] = binding{module: specifier, name: "*"}
+		}
+
+		return j + 2
+	}
+	bindings := map[string]binding{}
+	for ; j < len(p.tokens) && !p.is(j, "from") && !p.isString(j); j++ {
+		switch {
+		case p.is(j, "*") && p.is(j+1, "as"):
+			bindings[p.value(j+2)] = binding{name: "*"}
+			j += 2
+		case p.is(j, "{"):
+			for local, name := range p.namedBindings(j) {
+				bindings[local] = binding{name: name}
+			}
+			j = p.close(j)
+		case p.isIdent(j) && p.value(j) != "type":
+			bindings[p.value(j)] = binding{name: "default"}
+		}
+	}
+	if p.is(j, "from") {
+		j++
+	}
+	if !p.isString(j) {
+		return j
+	}
+	for local, b := range bindings {
+		p.module.imports[local] = binding{module: p.tokens[j].value, name: b.name}
+	}
+
+	return j + 1
+}
+
+// namedBindings reads { a, b as c, type d } at i, mapping local names to the names imported or exported
+func (p *parser) namedBindings(i int) map[string]string {
+	bindings := map[string]string{}
+	end := p.close(i)
+	for j := i + 1; j < end; j++ {
+		if p.is(j, "type") && (p.isIdent(j+1) || p.isString(j+1)) && !p.is(j+1, "as") {
+			// Type only bindings have no calls
+			j = p.skipUntil(j, end, ",")
+
+			continue
+		}
+		if !p.isIdent(j) && !p.isString(j) {
+			continue
+		}
+		name := p.value(j)
+		if p.is(j+1, "as") {
+			bindings[p.value(j+2)] = name
+			j += 2
+
+			continue
+		}
+		bindings[name] = name
+	}
+
+	return bindings
+}
+
+func (p *parser) skipUntil(i, end int, value string) int {
+	for i < end && !p.is(i, value) {
+		i++
+	}
+
+	return i
+}
+
+func (p *parser) parseExport(i, end int, owner *function) int {
+	j := i + 1
+	switch p.value(j) {
+	case "default":
+		return p.parseExportDefault(j+1, end, owner)
+	case "=":
+		// TypeScript export =, which is module.exports =
+		return p.parseAssignment([]string{"module", "exports"}, j+1, owner)
+	case "*":
+		return p.parseExportAll(j)
+	case "{":
+		return p.parseExportList(j)
+	case "type":
+		if p.is(j+1, "{") || p.is(j+1, "*") {
+			return p.skipStatement(j)
+		}
+
+		return p.skipDeclaration(j)
+	case "declare":
+		return p.skipStatement(j)
+	case "abstract":
+		j++
+	}
+
+	return p.parseExportedDeclaration(j, owner)
+}
+
+func (p *parser) parseExportedDeclaration(j int, owner *function) int {
+	switch p.value(j) {
+	case "async":
+		return p.parseFunction(j+1, owner, true)
+	case "function":
+		return p.parseFunction(j, owner, true)
+	case "class":
+		return p.parseClass(j, "", owner, true)
+	case "const", "let", "var":
+		return p.parseVariable(j, owner, true)
+	case "interface", "enum":
+		return p.skipDeclaration(j)
+	}
+
+	return j
+}
+
+func (p *parser) parseExportDefault(j, end int, owner *function) int {
+	if p.is(j, "async") && p.is(j+1, "function") {
+		j++
+	}
+	name := j + 1
+	if p.is(name, "*") {
+		name++
+	}
+	switch {
+	case p.is(j, "function") && p.isIdent(name):
+		p.module.exports["default"] = export{local: qualify(owner.scope, p.value(name))}
+
+		return p.parseFunction(j, owner, true)
+	case p.is(j, "class") && p.isIdent(name) && !p.is(name, "extends"):
+		p.module.exports["default"] = export{local: qualify(owner.scope, p.value(name))}
+
+		return p.parseClass(j, "", owner, true)
+	case p.is(j, "class"):
+		return p.parseClass(j, "default", owner, true)
+	case p.is(j, "{"):
+		p.module.exports["default"] = export{local: "default"}
+
+		return p.parseObject(j, "default", owner, false)
+	case p.isIdent(j) && (p.is(j+1, ";") || j+1 >= end || p.tokens[j+1].newline):
+		p.module.exports["default"] = export{local: p.value(j)}
+
+		return j + 1
+	}
+	if body, next, ok := p.functionValue(j); ok {
+		p.defineFunction("default", j, body, next, owner.scope, "")
+		p.module.exports["default"] = export{local: "default"}
+
+		return next
+	}
+
+	return j
+}
+
+func (p *parser) parseExportAll(j int) int {
+	if p.is(j+1, "as") && p.is(j+3, "from") && p.isString(j+4) {
+		// export * as ns from 'x'
+		p.module.exports[p.value(j+2)] = export{module: p.value(j + 4), name: "*"}
+
+		return j + 5
+	}
+	if p.is(j+1, "from") && p.isString(j+2) {
+		p.module.starts = append(p.module.starts, p.value(j+2))
+
+		return j + 3
+	}
+
+	return j + 1
+}
+
+func (p *parser) parseExportList(j int) int {
+	bindings := p.namedBindings(j)
+	next := p.close(j) + 1
+	specifier := ""
+	if p.is(next, "from") && p.isString(next+1) {
+		specifier = p.value(next + 1)
+		next += 2
+	}
+	for exported, local := range bindings {
+		if specifier != "" {
+			p.module.exports[exported] = export{module: specifier, name: local}
+
+			continue
+		}
+		if b, ok := p.module.imports[local]; ok {
+			// Re-export of an imported name
+			p.module.exports[exported] = export{module: b.module, name: b.name}
+
+			continue
+		}
+		p.module.exports[exported] = export{local: local}
+	}
+
+	return next
+}
+
+// skipStatement skips to the end of a statement, which is a semicolon or a new line outside of brackets
+func (p *parser) skipStatement(i int) int {
+	for j := i + 1; j < len(p.tokens); j++ {
+		switch {
+		case p.is(j, ";"):
+			return j + 1
+		case p.is(j, "(") || p.is(j, "[") || p.is(j, "{"):
+			j = p.close(j)
+		case p.tokens[j].newline && !p.is(j-1, ",") && !p.is(j-1, "=") && !p.is(j-1, "|") && !p.is(j-1, "&"):
+			return j
+		}
+	}
+
+	return len(p.tokens)
+}
+
+// skipDeclaration skips TypeScript interfaces, enums and type aliases, which have no calls
+func (p *parser) skipDeclaration(i int) int {
+	if p.is(i, "type") {
+		return p.skipStatement(i)
+	}
+	for j := i + 1; j < len(p.tokens); j++ {
+		if p.is(j, "{") {
+			return p.close(j) + 1
+		}
+	}
+
+	return len(p.tokens)
+}
+
+// parseFunction parses a function declaration at the function keyword at i
+func (p *parser) parseFunction(i int, owner *function, exported bool) int {
+	j := i + 1
+	if p.is(j, "*") {
+		j++
+	}
+	if !p.isIdent(j) {
+		// Anonymous functions are walked as part of their owner
+		return i + 1
+	}
+	name := p.value(j)
+	body, next, ok := p.functionValue(i)
+	if !ok {
+		// Overload signature or declaration without a body
+		return p.skipStatement(j)
+	}
+	fn := p.defineFunction(name, i, body, next, owner.scope, "")
+	if exported {
+		p.module.exports[name] = export{local: fn.name}
+	}
+
+	return next
+}
+
+// span is a range of tokens, from start to but not including end
+type span struct {
+	start int
+	end   int
+}
+
+// functionValue reads a function or arrow function expression at i, returning the range of its body and the index
+// after it
+func (p *parser) functionValue(i int) (span, int, bool) {
+	if p.is(i, "async") && !p.tokens[min(i+1, len(p.tokens)-1)].newline {
+		i++
+	}
+	switch {
+	case p.is(i, "function"):
+		j := i + 1
+		if p.is(j, "*") {
+			j++
+		}
+		if p.isIdent(j) {
+			j++
+		}
+		j = p.skipTypeParameters(j)
+		if !p.is(j, "(") {
+			return span{}, i, false
+		}
+
+		return p.functionBody(p.close(j) + 1)
+	case p.isIdent(i) && p.is(i+1, "=>"):
+		return p.arrowBody(i + 2)
+	case p.is(i, "<") || p.is(i, "("):
+		j := p.skipTypeParameters(i)
+		if !p.is(j, "(") {
+			return span{}, i, false
+		}
+		j = p.close(j) + 1
+		if p.is(j, ":") {
+			j = p.skipType(j+1, "=>", ";")
+		}
+		if !p.is(j, "=>") {
+			return span{}, i, false
+		}
+
+		return p.arrowBody(j + 1)
+	}
+
+	return span{}, i, false
+}
+
+// functionBody finds the body of a function after its parameters, skipping the return type
+func (p *parser) functionBody(j int) (span, int, bool) {
+	if p.is(j, ":") {
+		j = p.skipType(j+1, "{", ";")
+		if p.is(j, "{") && p.is(p.close(j)+1, "{") {
+			// Object type followed by the body
+			j = p.close(j) + 1
+		}
+	}
+	if !p.is(j, "{") {
+		return span{}, j, false
+	}
+	end := p.close(j)
+
+	return span{start: j + 1, end: end}, end + 1, true
+}
+
+func (p *parser) arrowBody(j int) (span, int, bool) {
+	if p.is(j, "{") {
+		end := p.close(j)
+
+		return span{start: j + 1, end: end}, end + 1, true
+	}
+	end := p.expressionEnd(j)
+
+	return span{start: j, end: end}, end, true
+}
+
+func (p *parser) skipTypeParameters(j int) int {
+	if !p.is(j, "<") {
+		return j
+	}
+	depth := 0
+	for ; j < len(p.tokens); j++ {
+		switch p.value(j) {
+		case "<":
+			depth++
+		case ">":
+			depth--
+		case ">>":
+			depth -= 2
+		case "(", "[", "{":
+			j = p.close(j)
+		}
+		if depth <= 0 {
+			return j + 1
+		}
+	}
+
+	return j
+}
+
+// skipType skips a type annotation, up to any of the stop tokens outside of brackets
+func (p *parser) skipType(j int, stops ...string) int {
+	depth := 0
+	for ; j < len(p.tokens); j++ {
+		value := p.value(j)
+		if depth <= 0 && p.tokens[j].kind == punctToken {
+			for _, stop := range stops {
+				if value == stop {
+					return j
+				}
+			}
+		}
+		switch value {
+		case "<":
+			depth++
+		case ">":
+			depth--
+		case ">>":
+			depth -= 2
+		case "(", "[", "{":
+			j = p.close(j)
+		case ")", "]", "}", ",":
+			if depth <= 0 {
+				return j
+			}
+		}
+	}
+
+	return j
+}
+
+// expressionEnd finds the end of an expression, which is a semicolon, a comma or a closing bracket outside of
+// brackets, or a new line ending a statement
+func (p *parser) expressionEnd(i int) int {
+	for j := i; j < len(p.tokens); j++ {
+		t := p.tokens[j]
+		if j > i && t.newline && p.endsStatement(j-1) && p.startsStatement(j) {
+			return j
+		}
+		if t.kind != punctToken {
+			continue
+		}
+		switch t.value {
+		case "(", "[", "{":
+			j = p.close(j)
+		case ")", "]", "}", ",", ";":
+			return j
+		}
+	}
+
+	return len(p.tokens)
+}
+
+func (p *parser) endsStatement(i int) bool {
+	t := p.tokens[i]
+	if t.kind == punctToken {
+		return t.value == ")" || t.value == "]" || t.value == "}" || t.value == "++" || t.value == "--"
+	}
+
+	return t.kind != identToken || !reservedWords[t.value] || t.value == "this"
+}
+
+func (p *parser) startsStatement(i int) bool {
+	t := p.tokens[i]
+	if t.kind == identToken {
+		return t.value != "instanceof" && t.value != "in" && t.value != "of" && t.value != "as"
+	}
+
+	return t.kind != punctToken
+}
+
+// defineFunction adds the function named name, qualified by scope, and walks its body. this is the scope of the
+// methods called on this in the function, which is the function itself if empty, as for constructor functions.
+func (p *parser) defineFunction(name string, start int, body span, next int, scope string, this string) *function {
+	fn := &function{
+		name:      qualify(scope, name),
+		this:      this,
+		lineStart: p.line(start),
+		lineEnd:   p.line(next - 1),
+	}
+	fn.scope = fn.name
+	if fn.this == "" {
+		fn.this = fn.name
+	}
+	p.module.add(fn)
+	p.walk(body.start, body.end, fn)
+
+	return fn
+}
+
+func (p *parser) parseClass(i int, name string, owner *function, exported bool) int {
+	j := i + 1
+	if p.isIdent(j) && p.value(j) != "extends" && p.value(j) != "implements" {
+		if name == "" {
+			name = p.value(j)
+		}
+		j++
+	}
+	for ; j < len(p.tokens) && !p.is(j, "{"); j++ {
+		if p.is(j, "(") || p.is(j, "[") {
+			j = p.close(j)
+		}
+	}
+	if j >= len(p.tokens) {
+		return j
+	}
+	if name == "" {
+		// Anonymous classes are walked as part of their owner
+		return j
+	}
+	qualified := qualify(owner.scope, name)
+	if exported {
+		p.module.exports[name] = export{local: qualified}
+	}
+	class := &function{name: qualified, scope: qualified}
+	p.parseMembers(j, class, owner, true)
+
+	return p.close(j) + 1
+}
+
+// parseMembers parses the methods of a class body or an object literal at open, qualified by the name of container
+func (p *parser) parseMembers(open int, container *function, owner *function, isClass bool) {
+	end := p.close(open)
+	for j := open + 1; j < end; {
+		next := p.parseMember(j, end, container, owner, isClass)
+		j = max(next, j+1)
+	}
+}
+
+func (p *parser) parseMember(j, end int, container *function, owner *function, isClass bool) int {
+	switch {
+	case p.is(j, ";") || p.is(j, ","):
+		return j + 1
+	case p.is(j, "@"):
+		return p.skipDecorator(j)
+	case p.is(j, "..."):
+		next := p.expressionEnd(j + 1)
+		p.walk(j+1, next, owner)
+
+		return next
+	case p.is(j, "static") && p.is(j+1, "{"):
+		p.walk(j+2, p.close(j+1), owner)
+
+		return p.close(j+1) + 1
+	case p.is(j, "*") || (p.isIdent(j) && classModifiers[p.value(j)] && p.isMemberName(j+1)):
+		return j + 1
+	}
+	if !p.isMemberName(j) {
+		return j + 1
+	}
+	start := j
+	name := p.value(j)
+	if p.is(j, "[") {
+		// Computed member names can't be called statically, but are walked for calls
+		name = ""
+		p.walk(j+1, p.close(j), owner)
+		j = p.close(j)
+	}
+	j++
+	if p.is(j, "?") || p.is(j, "!") {
+		j++
+	}
+
+	return p.parseMemberValue(start, j, end, name, container, owner, isClass)
+}
+
+func (p *parser) parseMemberValue(start, j, end int, name string, container *function, owner *function, isClass bool) int {
+	switch {
+	case p.is(j, "(") || p.is(j, "<"):
+		paren := p.skipTypeParameters(j)
+		body, next, ok := p.functionBody(p.close(paren) + 1)
+		if !ok {
+			return next
+		}
+		p.defineMember(name, start, body, next, container)
+
+		return next
+	case isClass && p.is(j, ":"):
+		j = p.skipType(j+1, "=", ";", "}")
+		if !p.is(j, "=") {
+			return j
+		}
+	case !isClass && (p.is(j, ",") || j >= end) && name != "" && container.name == "":
+		// Shorthand property of module.exports = { a }
+		p.module.exports[name] = export{local: name}
+
+		return j
+	case !p.is(j, "=") && !p.is(j, ":"):
+		return j
+	}
+
+	return p.parseMemberInitializer(start, j+1, name, container, owner)
+}
+
+func (p *parser) parseMemberInitializer(start, j int, name string, container *function, owner *function) int {
+	if body, next, ok := p.functionValue(j); ok && name != "" {
+		p.defineMember(name, start, body, next, container)
+
+		return next
+	}
+	if p.is(j, "{") && name != "" {
+		return p.parseObject(j, qualify(container.scope, name), owner, false)
+	}
+	next := p.expressionEnd(j)
+	if container.name == "" && p.isIdent(j) && next == j+1 {
+		// Property of module.exports = { a: b }
+		p.module.exports[name] = export{local: p.value(j)}
+	}
+	p.walk(j, next, owner)
+
+	return next
+}
+
+func (p *parser) defineMember(name string, start int, body span, next int, container *function) {
+	if name == "" {
+		name = "<computed>"
+	}
+	fn := p.defineFunction(name, start, body, next, container.scope, container.scope)
+	if container.name == "" {
+		p.module.exports[name] = export{local: fn.name}
+	}
+}
+
+func (p *parser) isMemberName(j int) bool {
+	if j >= len(p.tokens) {
+		return false
+	}
+	kind := p.tokens[j].kind
+
+	return kind == identToken || kind == stringToken || kind == numberToken || p.is(j, "[")
+}
+
+func (p *parser) skipDecorator(j int) int {
+	_, next := p.chain(j + 1)
+	if p.is(next, "(") {
+		return p.close(next) + 1
+	}
+
+	return next
+}
+
+// parseObject parses an object literal at open, whose methods are qualified by name. Methods of an object without a
+// name are the members of module.exports.
+func (p *parser) parseObject(open int, name string, owner *function, exported bool) int {
+	if exported {
+		p.module.exports[name] = export{local: name}
+	}
+	container := &function{name: name, scope: name}
+	p.parseMembers(open, container, owner, false)
+
+	return p.close(open) + 1
+}
+
+func (p *parser) parseVariable(i int, owner *function, exported bool) int {
+	j := i + 1
+	if p.is(j, "{") && p.is(p.close(j)+1, "=") {
+		return p.parseDestructuring(j, owner)
+	}
+	if !p.isIdent(j) {
+		return j
+	}
+	name := p.value(j)
+	k := j + 1
+	if p.is(k, ":") {
+		k = p.skipType(k+1, "=", ";")
+	}
+	if !p.is(k, "=") {
+		return k
+	}
+	if exported {
+		p.module.exports[name] = export{local: qualify(owner.scope, name)}
+	}
+
+	return p.parseValue(name, k+1, owner)
+}
+
+// parseValue parses the value assigned to name at v, which may define a function, class, object or import
+func (p *parser) parseValue(name string, v int, owner *function) int {
+	if specifier, ok := p.requireSpecifier(v); ok {
+		b := binding{module: specifier, name: "*"}
+		next := v + 4
+		if p.is(next, ".") && p.isIdent(next+1) {
+			// const x = require('y').z
+			b.name = p.value(next + 1)
+			next += 2
+		}
+		p.module.imports[name] = b
+
+		return next
+	}
+	if p.is(v, "await") && p.is(v+1, "import") && p.is(v+2, "(") && p.isString(v+3) {
+		p.module.imports[name] = binding{module: p.value(v + 3), name: "*"}
+
+		return v + 5
+	}
+	if body, next, ok := p.functionValue(v); ok {
+		p.defineFunction(name, v, body, next, owner.scope, "")
+
+		return next
+	}
+	switch {
+	case p.is(v, "class"):
+		return p.parseClass(v, name, owner, false)
+	case p.is(v, "{"):
+		return p.parseObject(v, qualify(owner.scope, name), owner, false)
+	case p.is(v, "new") && p.isIdent(v+1):
+		chain, _ := p.chain(v + 1)
+		p.module.instances[qualify(owner.scope, name)] = chain
+	}
+
+	return v
+}
+
+// parseDestructuring parses const { a, b: c } = require('x') at open
+func (p *parser) parseDestructuring(open int, owner *function) int {
+	v := p.close(open) + 2
+	specifier, ok := p.requireSpecifier(v)
+	if !ok {
+		if !p.is(v, "await") || !p.is(v+1, "import") || !p.is(v+2, "(") || !p.isString(v+3) {
+			return v
+		}
+		specifier = p.value(v + 3)
+	}
+	end := p.close(open)
+	for j := open + 1; j < end; j++ {
+		if !p.isIdent(j) || p.is(j-1, ":") || p.is(j-1, "=") {
+			continue
+		}
+		name, local := p.value(j), p.value(j)
+		if p.is(j+1, ":") && p.isIdent(j+2) {
+			local = p.value(j + 2)
+		}
+		p.module.imports[local] = binding{module: specifier, name: name}
+	}
+
+	return v
+}
+
+// parseAssignment parses chain = value, such as exports.x = function, module.exports = {} or
+// Greeter.prototype.greet = function
+func (p *parser) parseAssignment(chain []string, v int, owner *function) int {
+	path := strings.Join(chain, ".")
+	this := ""
+	switch {
+	case path == "module.exports":
+		return p.parseModuleExports(v, owner)
+	case chain[0] == "exports" && len(chain) == 2:
+		return p.parseExportsMember(chain[1], v, owner)
+	case strings.HasPrefix(path, "module.exports.") && len(chain) == 3:
+		return p.parseExportsMember(chain[2], v, owner)
+	case len(chain) == 3 && chain[1] == "prototype":
+		path = chain[0] + "." + chain[2]
+		this = chain[0]
+	case chain[0] == "this" && len(chain) == 2:
+		path = qualify(owner.this, chain[1])
+		this = owner.this
+	}
+	body, next, ok := p.functionValue(v)
+	if !ok {
+		return v
+	}
+	if this == "" && strings.Contains(path, ".") {
+		this = parent(path)
+	}
+	p.defineFunction(path, v, body, next, p.scopeOf(path, owner), this)
+
+	return next
+}
+
+// scopeOf is the scope of an assigned name, which is the scope of its owner unless it's qualified
+func (p *parser) scopeOf(path string, owner *function) string {
+	if strings.Contains(path, ".") {
+		return ""
+	}
+
+	return owner.scope
+}
+
+func (p *parser) parseModuleExports(v int, owner *function) int {
+	if specifier, ok := p.requireSpecifier(v); ok {
+		p.module.exports["default"] = export{module: specifier, name: "default"}
+		p.module.starts = append(p.module.starts, specifier)
+
+		return v + 4
+	}
+	switch {
+	case p.is(v, "{"):
+		return p.parseObject(v, "", owner, false)
+	case p.is(v, "class") && p.isIdent(v+1) && !p.is(v+1, "extends"):
+		p.module.exports["default"] = export{local: p.value(v + 1)}
+
+		return p.parseClass(v, "", owner, false)
+	case p.is(v, "class"):
+		p.module.exports["default"] = export{local: "default"}
+
+		return p.parseClass(v, "default", owner, false)
+	case p.is(v, "new") && p.isIdent(v+1):
+		// Methods of module.exports = new Foo() are the methods of Foo
+		chain, _ := p.chain(v + 1)
+		p.module.exports["default"] = export{local: strings.Join(chain, ".")}
+
+		return v
+	case p.isIdent(v) && !reservedWords[p.value(v)] && !p.is(v, "async") && !p.is(v+1, "=>"):
+		chain, next := p.chain(v)
+		if !p.is(next, "(") {
+			p.module.exports["default"] = export{local: strings.Join(chain, ".")}
+		}
+
+		return v
+	}
+	body, next, ok := p.functionValue(v)
+	if !ok {
+		return v
+	}
+	name := "default"
+	if p.is(v, "function") && p.isIdent(v+1) {
+		name = p.value(v + 1)
+	}
+	p.defineFunction(name, v, body, next, "", "")
+	p.module.exports["default"] = export{local: name}
+
+	return next
+}
+
+func (p *parser) parseExportsMember(name string, v int, owner *function) int {
+	if body, next, ok := p.functionValue(v); ok {
+		p.defineFunction(name, v, body, next, "", "")
+		p.module.exports[name] = export{local: name}
+
+		return next
+	}
+	if specifier, ok := p.requireSpecifier(v); ok {
+		p.module.exports[name] = export{module: specifier, name: "*"}
+
+		return v + 4
+	}
+	if p.is(v, "{") {
+		p.module.exports[name] = export{local: name}
+
+		return p.parseObject(v, name, owner, false)
+	}
+	if p.isIdent(v) {
+		chain, next := p.chain(v)
+		if !p.is(next, "(") && !reservedWords[chain[0]] {
+			p.module.exports[name] = export{local: strings.Join(chain, ".")}
+		}
+	}
+
+	return v
+}
+
+// qualify joins a scope and names into a qualified name
+func qualify(scope string, names ...string) string {
+	name := strings.Join(names, ".")
+	if scope == "" {
+		return name
+	}
+	if name == "" {
+		return scope
+	}
+
+	return scope + "." + name
+}
+
+// parent is the scope a qualified name is defined in
+func parent(name string) string {
+	i := strings.LastIndex(name, ".")
+	if i < 0 {
+		return ""
+	}
+
+	return name[:i]
+}
@@ -0,0 +1,214 @@
+package javascript
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func functionNames(m *module) []string {
+	var names []string
+	for _, fn := range m.order {
+		names = append(names, fn.name)
+	}
+
+	return names
+}
+
+func TestParseFunctions(t *testing.T) {
+	m := parse("a.ts", `
+function a() {
+  function nested() {}
+}
+async function* b<T>(x: T): AsyncGenerator<T> {}
+const c = async (x: number): Promise<void> => {}
+let d = x => x * 2
+var e = function () {}
+function overload(x: string): void
+function overload(x: any) {}
+declare function declared(): void;
+items.map(function (item) {})
+`)
+	assert.Equal(t, []string{"<module>", "a", "a.nested", "b", "c", "d", "e", "overload"}, functionNames(m))
+	assert.Equal(t, 2, m.functions["a"].lineStart)
+	assert.Equal(t, 4, m.functions["a"].lineEnd)
+}
+
+func TestParseClasses(t *testing.T) {
+	m := parse("a.ts", `
+@Injectable()
+export class A<T> extends B<T> implements C {
+  private static readonly x: number = 1;
+  #y = () => this.z();
+  constructor(private readonly d: D) { super(); }
+  @Log() async z(): Promise<void> {}
+  get w() { return 1 }
+  abstract v(): void;
+  [Symbol.iterator]() {}
+}
+`)
+	assert.Equal(t, []string{"<module>", "A.#y", "A.constructor", "A.z", "A.w", "A.<computed>"}, functionNames(m))
+	assert.Equal(t, export{local: "A"}, m.exports["A"])
+	assert.Equal(t, "A", m.functions["A.z"].this)
+}
+
+func TestParseObjects(t *testing.T) {
+	m := parse("a.js", `
+const api = {
+  get() {},
+  post: function () {},
+  nested: { put: () => {} },
+  value: 1,
+  ...rest,
+}
+`)
+	assert.Equal(t, []string{"<module>", "api.get", "api.post", "api.nested.put"}, functionNames(m))
+	assert.Equal(t, "api", m.functions["api.get"].this)
+}
+
+func TestParsePrototype(t *testing.T) {
+	m := parse("a.js", `
+function A() {
+  this.b = function () {};
+}
+A.prototype.c = function () { this.b() };
+A.d = () => {};
+`)
+	assert.Equal(t, []string{"<module>", "A", "A.b", "A.c", "A.d"}, functionNames(m))
+	assert.Equal(t, "A", m.functions["A.c"].this)
+	assert.Equal(t, [][]string{{"this", "b"}}, chains(m.functions["A.c"].calls))
+}
+
+func TestParseImports(t *testing.T) {
+	m := parse("a.ts", `
+import a, { b, c as d, type e } from './x';
+import * as f from "y";
+import type { g } from './types';
+import './side-effect';
+import h = require('z');
+const i = require('w');
+const { j, k: l } = require('v');
+const m = require('u').n;
+const o = await import('t');
+`)
+	assert.Equal(t, map[string]binding{
+		"a": {module: "./x", name: "default"},
+		"b": {module: "./x", name: "b"},
+		"d": {module: "./x", name: "c"},
+		"f": {module: "y", name: "*"},
+		"h": {module: "z", name: "*"},
+		"i": {module: "w", name: "*"},
+		"j": {module: "v", name: "j"},
+		"l": {module: "v", name: "k"},
+		"m": {module: "u", name: "n"},
+		"o": {module: "t", name: "*"},
+	}, m.imports)
+}
+
+func TestParseExports(t *testing.T) {
+	m := parse("a.js", `
+import { x } from './x';
+export function a() {}
+export const b = () => {};
+export default class C {}
+export { a as d, x as e };
+export { f } from './f';
+export * from './all';
+export * as ns from './ns';
+export interface I { g(): void }
+export type T = { h(): void };
+`)
+	assert.Equal(t, map[string]export{
+		"a":       {local: "a"},
+		"b":       {local: "b"},
+		"C":       {local: "C"},
+		"default": {local: "C"},
+		"d":       {local: "a"},
+		"e":       {module: "./x", name: "x"},
+		"f":       {module: "./f", name: "f"},
+		"ns":      {module: "./ns", name: "*"},
+	}, m.exports)
+	assert.Equal(t, []string{"./all"}, m.starts)
+	assert.Equal(t, []string{"<module>", "a", "b"}, functionNames(m))
+}
+
+func TestParseCommonJSExports(t *testing.T) {
+	cases := map[string]map[string]export{
+		"module.exports = { a, b: c, d() {} }": {
+			"a": {local: "a"},
+			"b": {local: "c"},
+			"d": {local: "d"},
+		},
+		"module.exports = A": {
+			"default": {local: "A"},
+		},
+		"module.exports = new A()": {
+			"default": {local: "A"},
+		},
+		"module.exports = function a() {}": {
+			"default": {local: "a"},
+		},
+		"module.exports = require('./b')": {
+			"default": {module: "./b", name: "default"},
+		},
+		"exports.a = function () {}; module.exports.b = c; exports.d = require('./d')": {
+			"a": {local: "a"},
+			"b": {local: "c"},
+			"d": {module: "./d", name: "*"},
+		},
+	}
+	for src, exports := range cases {
+		t.Run(src, func(t *testing.T) {
+			m := parse("a.js", src)
+			assert.Equal(t, exports, m.exports)
+		})
+	}
+}
+
+func chains(calls []call) [][]string {
+	var result [][]string
+	for _, c := range calls {
+		result = append(result, c.chain)
+	}
+
+	return result
+}
+
+func TestParseCalls(t *testing.T) {
+	m := parse("a.js", `
+a();
+b.c?.d(1, e());
+f?.();
+new G(h);
+new I().j();
+require('k').l.m();
+if (n) {}
+o.p = q;
+items.forEach((item) => r(item));
+`)
+	main := m.functions[moduleFunctionName]
+	assert.Equal(t, [][]string{
+		{"a"}, {"b", "c", "d"}, {"e"}, {"f"}, {"G"}, {"I"}, {"I", "j"}, {"require", "l", "m"}, {"items", "forEach"}, {"r"},
+	}, chains(main.calls))
+	assert.Equal(t, "k", main.calls[7].module)
+	assert.True(t, main.calls[4].isNew)
+	assert.Equal(t, 3, main.calls[2].line)
+}
+
+func TestParseInstances(t *testing.T) {
+	m := parse("a.js", `
+const a = new lib.A();
+function b() {
+  const c = new C();
+}
+`)
+	assert.Equal(t, map[string][]string{"a": {"lib", "A"}, "b.c": {"C"}}, m.instances)
+}
+
+func TestParseInvalid(t *testing.T) {
+	assert.NotPanics(t, func() {
+		for _, src := range []string{"function", "class A {", "export", "import {", "const a = (", "module.exports =", "a.prototype.b ="} {
+			parse("a.js", src)
+		}
+	})
+}
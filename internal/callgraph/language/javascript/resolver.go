@@ -0,0 +1,198 @@
+package javascript
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/debricked/cli/internal/callgraph/finder/javascriptfinder"
+)
+
+type moduleKind int
+
+const (
+	fileModule moduleKind = iota
+	builtinModule
+	unresolvedModule
+)
+
+// extensions are tried, in order, for specifiers without an extension
+var extensions = []string{".js", ".ts", ".tsx", ".jsx", ".mjs", ".cjs", ".mts", ".cts"}
+
+// typeScriptExtensions maps the extensions written in TypeScript imports to the extensions of their sources
+var typeScriptExtensions = map[string][]string{
+	".js":  {".ts", ".tsx"},
+	".jsx": {".tsx"},
+	".mjs": {".mts"},
+	".cjs": {".cts"},
+}
+
+var builtinModules = map[string]bool{
+	"assert": true, "async_hooks": true, "buffer": true, "child_process": true, "cluster": true, "console": true,
+	"constants": true, "crypto": true, "dgram": true, "diagnostics_channel": true, "dns": true, "domain": true,
+	"events": true, "fs": true, "http": true, "http2": true, "https": true, "inspector": true, "module": true,
+	"net": true, "os": true, "path": true, "perf_hooks": true, "process": true, "punycode": true,
+	"querystring": true, "readline": true, "repl": true, "stream": true, "string_decoder": true, "sys": true,
+	"timers": true, "tls": true, "trace_events": true, "tty": true, "url": true, "util": true, "v8": true,
+	"vm": true, "wasi": true, "worker_threads": true, "zlib": true,
+}
+
+// resolveModule resolves a specifier imported in the file from, the way Node.js and TypeScript do. It returns the
+// kind of module and, for files, its path or, for other modules, its name, which is empty for missing files.
+func resolveModule(from string, specifier string) (moduleKind, string) {
+	if name, ok := builtinName(specifier); ok {
+		return builtinModule, name
+	}
+	if strings.HasPrefix(specifier, ".") || filepath.IsAbs(specifier) {
+		base := specifier
+		if !filepath.IsAbs(base) {
+			base = filepath.Join(filepath.Dir(from), specifier)
+		}
+		if path, ok := resolvePath(base); ok {
+			return fileModule, path
+		}
+
+		// Files of the application that can't be found aren't external modules
+		return unresolvedModule, ""
+	}
+	if path, ok := resolvePackage(filepath.Dir(from), specifier); ok {
+		return fileModule, path
+	}
+
+	return unresolvedModule, packageName(specifier)
+}
+
+func builtinName(specifier string) (string, bool) {
+	name, isNode := strings.CutPrefix(specifier, "node:")
+	root, _, _ := strings.Cut(name, "/")
+
+	return name, isNode || builtinModules[root]
+}
+
+// packageName is the name of the package of a bare specifier, such as lodash for lodash/fp or @scope/pkg for
+// @scope/pkg/sub
+func packageName(specifier string) string {
+	parts := strings.SplitN(specifier, "/", 3)
+	if strings.HasPrefix(specifier, "@") && len(parts) > 1 {
+		return parts[0] + "/" + parts[1]
+	}
+
+	return parts[0]
+}
+
+// resolvePath resolves a file, a file without its extension or a directory
+func resolvePath(base string) (string, bool) {
+	if javascriptfinder.IsSource(base) && isFile(base) {
+		return base, true
+	}
+	ext := filepath.Ext(base)
+	for _, sourceExt := range typeScriptExtensions[ext] {
+		if path := strings.TrimSuffix(base, ext) + sourceExt; isFile(path) {
+			return path, true
+		}
+	}
+	for _, ext := range extensions {
+		if isFile(base + ext) {
+			return base + ext, true
+		}
+	}
+	if !isDir(base) {
+		return "", false
+	}
+	if main, ok := packageMain(base); ok {
+		if path, ok := resolvePath(filepath.Join(base, main)); ok {
+			return path, true
+		}
+	}
+	for _, ext := range extensions {
+		if path := filepath.Join(base, "index"+ext); isFile(path) {
+			return path, true
+		}
+	}
+
+	return "", false
+}
+
+// resolvePackage looks up a package in the node_modules directories of dir and its parents
+func resolvePackage(dir string, specifier string) (string, bool) {
+	name := packageName(specifier)
+	subpath := strings.TrimPrefix(strings.TrimPrefix(specifier, name), "/")
+	for {
+		packageDir := filepath.Join(dir, "node_modules", name)
+		if isDir(packageDir) {
+			if subpath != "" {
+				return resolvePath(filepath.Join(packageDir, subpath))
+			}
+
+			return resolvePath(packageDir)
+		}
+		parentDir := filepath.Dir(dir)
+		if parentDir == dir {
+			return "", false
+		}
+		dir = parentDir
+	}
+}
+
+// packageMain reads the entry point of a package from the exports or main fields of its package.json
+func packageMain(dir string) (string, bool) {
+	content, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	if err != nil {
+		return "", false
+	}
+	var manifest struct {
+		Exports any    `json:"exports"`
+		Module  string `json:"module"`
+		Main    string `json:"main"`
+	}
+	if json.Unmarshal(content, &manifest) != nil {
+		return "", false
+	}
+	if main, ok := exportsMain(manifest.Exports); ok {
+		return main, true
+	}
+	if manifest.Main != "" {
+		return manifest.Main, true
+	}
+
+	return manifest.Module, manifest.Module != ""
+}
+
+// exportsMain finds the entry point in the exports field of a package.json, preferring the require and import
+// conditions
+func exportsMain(exports any) (string, bool) {
+	switch value := exports.(type) {
+	case string:
+		return value, true
+	case []any:
+		for _, alternative := range value {
+			if main, ok := exportsMain(alternative); ok {
+				return main, true
+			}
+		}
+	case map[string]any:
+		if main, ok := value["."]; ok {
+			return exportsMain(main)
+		}
+		for _, condition := range []string{"require", "node", "import", "default"} {
+			if main, ok := exportsMain(value[condition]); ok {
+				return main, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && !info.IsDir()
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && info.IsDir()
+}
@@ -0,0 +1,56 @@
+package javascript
+
+import (
+	"github.com/debricked/cli/internal/callgraph/cgexec"
+	conf "github.com/debricked/cli/internal/callgraph/config"
+	"github.com/debricked/cli/internal/callgraph/finder"
+	"github.com/debricked/cli/internal/callgraph/finder/javascriptfinder"
+	"github.com/debricked/cli/internal/callgraph/job"
+	"github.com/debricked/cli/internal/io"
+)
+
+type Strategy struct {
+	config     conf.IConfig
+	paths      []string
+	exclusions []string
+	inclusions []string
+	finder     finder.IFinder
+	ctx        cgexec.IContext
+}
+
+func (s Strategy) Invoke() ([]job.IJob, error) {
+	var jobs []job.IJob
+
+	if s.config == nil {
+		finder.StrategyWarning("No config is setup")
+
+		return jobs, nil
+	}
+
+	for _, path := range s.paths {
+		projects, err := finder.FindProjects(s.finder, []string{path}, s.exclusions, s.inclusions, javascriptfinder.IsSource)
+		if err != nil {
+			finder.StrategyWarning("Error while finding projects: " + err.Error())
+
+			return jobs, err
+		}
+		for _, project := range projects {
+			jobs = append(jobs, NewJob(
+				project.Dir,
+				project.Sources,
+				io.FileWriter{},
+				io.NewArchive("."),
+				s.config,
+				s.ctx,
+				io.FileSystem{},
+			),
+			)
+		}
+	}
+
+	return jobs, nil
+}
+
+func NewStrategy(config conf.IConfig, paths []string, exclusions []string, inclusions []string, finder finder.IFinder, ctx cgexec.IContext) Strategy {
+	return Strategy{config, paths, exclusions, inclusions, finder, ctx}
+}
@@ -0,0 +1,87 @@
+package javascript
+
+import (
+	"path/filepath"
+	"testing"
+
+	ctxTestdata "github.com/debricked/cli/internal/callgraph/cgexec/testdata"
+	"github.com/debricked/cli/internal/callgraph/config"
+	"github.com/debricked/cli/internal/callgraph/finder/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStrategy(t *testing.T) {
+	s := NewStrategy(nil, nil, nil, nil, nil, nil)
+	assert.NotNil(t, s)
+
+	conf := config.NewConfig("javascript", []string{"arg1"}, map[string]string{"kwarg": "val"}, true, "npm", "")
+	finder := testdata.NewEmptyFinderMock()
+	ctx, _ := ctxTestdata.NewContextMock()
+	s = NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	assert.NotNil(t, s)
+	assert.Equal(t, s.config, conf)
+}
+
+func TestInvokeNoConfig(t *testing.T) {
+	s := NewStrategy(nil, []string{"."}, []string{}, []string{}, nil, nil)
+	jobs, _ := s.Invoke()
+	assert.Empty(t, jobs)
+}
+
+func TestInvoke(t *testing.T) {
+	conf := config.NewConfig("javascript", nil, nil, true, "npm", "")
+	finder := testdata.NewEmptyFinderMock()
+	finder.FindFilesNames = []string{
+		filepath.Join("app", "package.json"),
+		filepath.Join("app", "index.js"),
+		filepath.Join("app", "src", "util.ts"),
+		filepath.Join("app", "nested", "package.json"),
+		filepath.Join("app", "nested", "index.mjs"),
+		filepath.Join("empty", "package.json"),
+		filepath.Join("other", "script.js"),
+	}
+	finder.FindRootsNames = []string{
+		filepath.Join("app", "package.json"),
+		filepath.Join("app", "nested", "package.json"),
+		filepath.Join("empty", "package.json"),
+	}
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 2)
+
+	assert.Equal(t, "app", jobs[0].GetDir())
+	assert.Equal(t, []string{"index.js", filepath.Join("src", "util.ts")}, jobs[0].GetFiles())
+	assert.Equal(t, filepath.Join("app", "nested"), jobs[1].GetDir())
+	assert.Equal(t, []string{"index.mjs"}, jobs[1].GetFiles())
+}
+
+func TestInvokeWithErrors(t *testing.T) {
+	conf := config.NewConfig("javascript", nil, nil, true, "npm", "")
+	finder := testdata.NewEmptyFinderMock()
+	finder.FindRootsErr = assert.AnError
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	jobs, err := s.Invoke()
+	assert.Error(t, err)
+	assert.Empty(t, jobs)
+
+	finder.FindRootsErr = nil
+	finder.FindFilesErr = assert.AnError
+	s = NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	jobs, err = s.Invoke()
+	assert.Error(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestInvokeNoRoots(t *testing.T) {
+	conf := config.NewConfig("javascript", nil, nil, true, "npm", "")
+	finder := testdata.NewEmptyFinderMock()
+	finder.FindFilesNames = []string{"index.js"}
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+}
@@ -0,0 +1,20 @@
+import fs from 'node:fs';
+import { greet, Greeter } from 'greeting';
+import * as util from './util.js';
+import { shout } from './shout';
+
+const { format } = require('@scope/format');
+
+function main() {
+  const greeter = new Greeter('Hello');
+  greeter.welcome(readName());
+  console.log(shout(greet(util.name())));
+}
+
+function readName() {
+  return fs.readFileSync('name.txt', 'utf8').trim();
+}
+
+export const render = (value) => format(`${value}`);
+
+main();
@@ -0,0 +1,22 @@
+import type { Options } from './options';
+import { missing } from 'not-installed';
+
+export class Shouter {
+  private loud: boolean;
+
+  constructor(loud = true) {
+    this.loud = loud;
+  }
+
+  shout(text: string): string {
+    return this.loud ? this.upper(text) : text;
+  }
+
+  upper(text: string): string {
+    return missing(text.toUpperCase());
+  }
+}
+
+export function shout(text: string, options?: Options): string {
+  return new Shouter().shout(text);
+}
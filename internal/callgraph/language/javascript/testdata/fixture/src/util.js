@@ -0,0 +1,9 @@
+const path = require('path');
+
+exports.name = function () {
+  return path.basename(process.cwd());
+};
+
+module.exports.unused = () => {
+  /* never called */
+};
@@ -1,6 +1,10 @@
 package language
 
-import "github.com/debricked/cli/internal/callgraph/language/java"
+import (
+	"github.com/debricked/cli/internal/callgraph/language/java"
+	"github.com/debricked/cli/internal/callgraph/language/javascript"
+	"github.com/debricked/cli/internal/callgraph/language/python"
+)
 
 type ILanguage interface {
 	Name() string
@@ -10,5 +14,7 @@ type ILanguage interface {
 func Languages() []ILanguage {
 	return []ILanguage{
 		java.NewLanguage(),
+		javascript.NewLanguage(),
+		python.NewLanguage(),
 	}
 }
@@ -10,6 +10,8 @@ func TestLanguages(t *testing.T) {
 	langs := Languages()
 	langNames := []string{
 		"java",
+		"javascript",
+		"python",
 	}
 
 	for _, langName := range langNames {
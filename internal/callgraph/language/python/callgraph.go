@@ -0,0 +1,409 @@
+package python
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/debricked/cli/internal/callgraph/cgexec"
+	"github.com/debricked/cli/internal/callgraph/model"
+	ioFs "github.com/debricked/cli/internal/io"
+)
+
+const (
+	// maxFileSize is the size of the largest file parsed, larger files are usually generated
+	maxFileSize = 5 * 1024 * 1024
+	// maxDepth limits how many imports and base classes are followed to resolve a call
+	maxDepth = 16
+	// sourceDir is the directory of the packages of projects using the src layout
+	sourceDir = "src"
+)
+
+type ICallgraphBuilder interface {
+	RunCallGraph() (string, error)
+}
+
+type CallgraphBuilder struct {
+	filesystem       ioFs.IFileSystem
+	workingDirectory string
+	root             string
+	files            []string
+	sitePackages     []string
+	projectRoots     []string
+	outputName       string
+	ctx              cgexec.IContext
+	cgModel          *model.CallGraph
+	modules          map[string]*module
+	queue            []queued
+}
+
+// queued is a function whose calls are yet to be added to the call graph
+type queued struct {
+	module   *module
+	function *function
+	node     *model.Node
+}
+
+func NewCallgraphBuilder(
+	workingDirectory string,
+	files []string,
+	sitePackages []string,
+	outputName string,
+	filesystem ioFs.IFileSystem,
+	ctx cgexec.IContext,
+) CallgraphBuilder {
+	return CallgraphBuilder{
+		workingDirectory: workingDirectory,
+		files:            files,
+		sitePackages:     sitePackages,
+		outputName:       outputName,
+		filesystem:       filesystem,
+		ctx:              ctx,
+		cgModel:          model.NewCallGraph(),
+		modules:          map[string]*module{},
+	}
+}
+
+// constructCallGraph adds every function of the application files and, from their calls, the functions of
+// site-packages that are called
+func (cg *CallgraphBuilder) constructCallGraph() error {
+	root, err := filepath.Abs(cg.workingDirectory)
+	if err != nil {
+		return err
+	}
+	cg.root = root
+	cg.projectRoots = []string{root}
+	src := filepath.Join(root, sourceDir)
+	if isDir(src) && !isFile(filepath.Join(src, initFile)) {
+		// Packages of the src layout are imported from src, which takes precedence over the root
+		cg.projectRoots = []string{src, root}
+	}
+	sitePackages := make([]string, 0, len(cg.sitePackages))
+	for _, dir := range cg.sitePackages {
+		if absolute, err := filepath.Abs(dir); err == nil {
+			sitePackages = append(sitePackages, absolute)
+		}
+	}
+	cg.sitePackages = sitePackages
+
+	for _, file := range cg.files {
+		m, err := cg.load(filepath.Join(cg.root, file))
+		if err != nil {
+			return fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		if m == nil {
+			continue
+		}
+		for _, fn := range m.order {
+			cg.functionNode(m, fn)
+		}
+	}
+
+	for len(cg.queue) > 0 {
+		if cg.ctx != nil && cg.ctx.Context().Err() != nil {
+			return cg.ctx.Context().Err()
+		}
+		next := cg.queue[0]
+		cg.queue = cg.queue[1:]
+		cg.addCalls(next)
+	}
+
+	return nil
+}
+
+// load parses a module once, returning nil for files too large to parse
+func (cg *CallgraphBuilder) load(file string) (*module, error) {
+	if m, ok := cg.modules[file]; ok {
+		return m, nil
+	}
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, err
+	}
+	var m *module
+	if info.Size() <= maxFileSize {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		m = parse(file, cg.moduleName(file), filepath.Base(file) == initFile, string(content))
+	}
+	cg.modules[file] = m
+
+	return m, nil
+}
+
+// moduleName is the dotted name of a file, relative to the first of the roots it's in
+func (cg *CallgraphBuilder) moduleName(file string) string {
+	for _, root := range append(append([]string{}, cg.sitePackages...), cg.projectRoots...) {
+		if name, ok := moduleName(root, file); ok {
+			return name
+		}
+	}
+
+	return strings.TrimSuffix(filepath.Base(file), ".py")
+}
+
+func (cg *CallgraphBuilder) addCalls(caller queued) {
+	added := map[string]bool{}
+	for _, c := range caller.function.calls {
+		callee := cg.resolveCall(caller.module, caller.function, c)
+		if callee == nil {
+			continue
+		}
+		edge := fmt.Sprintf("%s:%d", callee.Symbol, c.line)
+		if added[edge] {
+			continue
+		}
+		added[edge] = true
+		cg.cgModel.AddEdge(caller.node, callee, c.line)
+	}
+}
+
+// functionNode returns the node of a function, adding it and queueing its calls the first time
+func (cg *CallgraphBuilder) functionNode(m *module, fn *function) *model.Node {
+	symbol := qualify(m.name, fn.name)
+	if node := cg.cgModel.GetNode(symbol); node != nil {
+		return node
+	}
+	filename := cg.relativePath(m.path)
+	node := cg.cgModel.AddNode(filename, fn.name, symbol, IsApplicationNode(filename), false, fn.lineStart, fn.lineEnd)
+	cg.queue = append(cg.queue, queued{module: m, function: fn, node: node})
+
+	return node
+}
+
+// externalNode returns the node of a function of the standard library, or of a package that isn't installed
+func (cg *CallgraphBuilder) externalNode(parts []string, isStdLib bool) *model.Node {
+	var names []string
+	for _, part := range parts {
+		if part != instanceCall {
+			names = append(names, part)
+		}
+	}
+	symbol := strings.Join(names, ".")
+
+	return cg.cgModel.AddNode("", symbol, symbol, false, isStdLib, -1, -1)
+}
+
+func (cg *CallgraphBuilder) relativePath(file string) string {
+	relative, err := filepath.Rel(cg.root, file)
+	if err != nil {
+		relative = file
+	}
+
+	return filepath.ToSlash(relative)
+}
+
+// IsApplicationNode reports whether a file is part of the application rather than of its dependencies
+func IsApplicationNode(filename string) bool {
+	for _, part := range strings.Split(filename, "/") {
+		if part == "site-packages" || part == "dist-packages" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (cg *CallgraphBuilder) resolveCall(m *module, fn *function, c call) *model.Node {
+	chain := c.chain
+	switch {
+	case chain[0] == "super":
+		return cg.resolveSuper(m, fn, chain)
+	case fn.class != "" && chain[0] == fn.self && len(chain) > 1:
+		return cg.resolveSelf(m, fn, chain[1:])
+	}
+	chain = cg.instanceChain(m, fn, chain)
+	if node := cg.resolveName(m, fn.scope, chain, 0); node != nil {
+		return node
+	}
+	if len(chain) == 1 && builtinFunctions[chain[0]] && !c.decorator {
+		return cg.externalNode([]string{"builtins", chain[0]}, true)
+	}
+
+	return nil
+}
+
+// resolveSuper resolves super().method() in the base classes of the class of fn
+func (cg *CallgraphBuilder) resolveSuper(m *module, fn *function, chain []string) *model.Node {
+	c, ok := m.classes[fn.class]
+	if !ok || len(chain) != 3 || chain[1] != instanceCall {
+		return nil
+	}
+
+	return cg.baseMethod(m, c, chain[2], 0)
+}
+
+// resolveSelf resolves a call on self, of a method or of a method of an attribute assigned an instance
+func (cg *CallgraphBuilder) resolveSelf(m *module, fn *function, rest []string) *model.Node {
+	c, ok := m.classes[fn.class]
+	if !ok {
+		return nil
+	}
+	if len(rest) == 1 {
+		return cg.classMethod(m, c, rest[0], 0)
+	}
+	if class, ok := m.instances[qualify(fn.class, fn.self, rest[0])]; ok {
+		return cg.resolveName(m, fn.scope, instanceOf(class, rest[1:]), 0)
+	}
+
+	return nil
+}
+
+// instanceChain replaces a variable assigned Foo() at the start of a chain by an instance of Foo, so that its methods
+// are found
+func (cg *CallgraphBuilder) instanceChain(m *module, fn *function, chain []string) []string {
+	if len(chain) < 2 {
+		return chain
+	}
+	for scope := fn.scope; ; scope = parent(scope) {
+		if class, ok := m.instances[qualify(scope, chain[0])]; ok {
+			return instanceOf(class, chain[1:])
+		}
+		if scope == "" {
+			return chain
+		}
+	}
+}
+
+func instanceOf(class []string, members []string) []string {
+	return append(append(append([]string{}, class...), instanceCall), members...)
+}
+
+// resolveName resolves a call of chain in a scope of a module, from the functions and classes defined in the scope and
+// the scopes it's in, and then from the imports of the module
+func (cg *CallgraphBuilder) resolveName(m *module, scope string, chain []string, depth int) *model.Node {
+	if depth > maxDepth {
+		return nil
+	}
+	for ; ; scope = parent(scope) {
+		// Names defined in the body of a class aren't in scope in its methods
+		if _, isClass := m.classes[scope]; !isClass {
+			if node := cg.resolveMember(m, qualify(scope, chain[0]), chain[1:], depth); node != nil {
+				return node
+			}
+		}
+		if scope == "" {
+			break
+		}
+	}
+	if dotted, ok := m.imports[chain[0]]; ok {
+		return cg.resolveDotted(append(strings.Split(dotted, "."), chain[1:]...), true, depth+1)
+	}
+	for _, star := range m.starImports {
+		if node := cg.resolveDotted(append(strings.Split(star, "."), chain...), false, depth+1); node != nil {
+			return node
+		}
+	}
+
+	return nil
+}
+
+// resolveMember resolves a call of the function or class name defined in a module, followed by the members in rest
+func (cg *CallgraphBuilder) resolveMember(m *module, name string, rest []string, depth int) *model.Node {
+	if fn, ok := m.functions[name]; ok && len(rest) == 0 {
+		return cg.functionNode(m, fn)
+	}
+	c, ok := m.classes[name]
+	if !ok {
+		return nil
+	}
+	if len(rest) > 0 && rest[0] == instanceCall {
+		rest = rest[1:]
+		if len(rest) == 0 {
+			return nil
+		}
+	}
+	switch len(rest) {
+	case 0:
+		// Calling a class calls its constructor
+		return cg.classMethod(m, c, initName, depth)
+	case 1:
+		return cg.classMethod(m, c, rest[0], depth)
+	}
+
+	return cg.resolveMember(m, qualify(name, rest[0]), rest[1:], depth)
+}
+
+// classMethod resolves a method of a class, or of its base classes
+func (cg *CallgraphBuilder) classMethod(m *module, c *class, name string, depth int) *model.Node {
+	if fn, ok := m.functions[qualify(c.name, name)]; ok {
+		return cg.functionNode(m, fn)
+	}
+
+	return cg.baseMethod(m, c, name, depth)
+}
+
+func (cg *CallgraphBuilder) baseMethod(m *module, c *class, name string, depth int) *model.Node {
+	if depth > maxDepth {
+		return nil
+	}
+	for _, base := range c.bases {
+		if node := cg.resolveName(m, parent(c.name), instanceOf(base, []string{name}), depth+1); node != nil {
+			return node
+		}
+	}
+
+	return nil
+}
+
+// resolveDotted resolves a call of a dotted name, such as os.path.join or pkg.mod.Class.method. Calls of modules that
+// can't be found are added as external nodes if external is set.
+func (cg *CallgraphBuilder) resolveDotted(parts []string, external bool, depth int) *model.Node {
+	if depth > maxDepth {
+		return nil
+	}
+	kind, loc := locate(cg.projectRoots, cg.sitePackages, parts)
+	switch {
+	case kind != fileModule:
+		if !external || !isClassInstance(parts) {
+			return nil
+		}
+
+		return cg.externalNode(parts, kind == stdlibModule)
+	case loc.path == "" || loc.names == len(parts):
+		return nil
+	}
+	m, err := cg.load(loc.path)
+	if err != nil || m == nil {
+		return nil
+	}
+
+	return cg.resolveName(m, "", parts[loc.names:], depth)
+}
+
+// isClassInstance reports whether the instances in a dotted name outside the analysed files are created by classes,
+// going by the convention of capitalised class names, rather than being values returned by functions
+func isClassInstance(parts []string) bool {
+	for i, part := range parts {
+		if part == instanceCall && (i == 0 || !unicode.IsUpper([]rune(parts[i-1])[0])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (cg *CallgraphBuilder) RunCallGraph() (string, error) {
+	err := cg.constructCallGraph()
+	if err != nil {
+		return "", err
+	}
+
+	cgOutputBytes, err := cg.cgModel.ToBytes()
+	if err != nil {
+		return "", err
+	}
+
+	outputFullPath := path.Join(cg.workingDirectory, cg.outputName)
+	err = cg.filesystem.FsWriteFile(outputFullPath, cgOutputBytes, 0600)
+	if err != nil {
+		return "", err
+	}
+
+	return outputFullPath, nil
+}
@@ -0,0 +1,206 @@
+package python
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/callgraph/cgexec"
+	ctxTestdata "github.com/debricked/cli/internal/callgraph/cgexec/testdata"
+	"github.com/debricked/cli/internal/callgraph/model"
+	"github.com/debricked/cli/internal/io"
+	ioTestData "github.com/debricked/cli/internal/io/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	fixtureFiles        = []string{"manage.py", "app/__init__.py", "app/main.py", "app/util.py", "app/shapes.py"}
+	fixtureSitePackages = []string{filepath.Join("testdata", "fixture", "requirements.txt.venv", "lib", "python3.11", "site-packages")}
+)
+
+const greeterFile = "requirements.txt.venv/lib/python3.11/site-packages/greeting/greeter.py"
+
+func parents(node *model.Node) []string {
+	var symbols []string
+	for _, edge := range node.Parents {
+		symbols = append(symbols, fmt.Sprintf("%s:%d", edge.Parent.Symbol, edge.CallLine))
+	}
+
+	return symbols
+}
+
+func newFixtureBuilder(ctx cgexec.IContext) CallgraphBuilder {
+	return NewCallgraphBuilder(filepath.Join("testdata", "fixture"), fixtureFiles, fixtureSitePackages, outputName, io.FileSystem{}, ctx)
+}
+
+func TestConstructCallGraph(t *testing.T) {
+	ctx, _ := ctxTestdata.NewContextMock()
+	cg := newFixtureBuilder(ctx)
+	err := cg.constructCallGraph()
+	assert.NoError(t, err)
+
+	cases := map[string][]string{
+		"app.main.main":                     {"manage.<module>:8"},
+		"app.main.read_name":                {"app.main.render.inner:28"},
+		"app.main.render.inner":             {"app.main.render:30"},
+		"app.util.name":                     {"app.main.main:17"},
+		"app.util.logged":                   {"app.main.<module>:25"},
+		"app.shapes.Square.__init__":        {"app.main.main:18", "app.main.main:21", "app.shapes.Canvas.__init__:23"},
+		"app.shapes.Square.area":            {"app.main.main:21"},
+		"app.shapes.Shape.__init__":         {"app.shapes.Square.__init__:14"},
+		"app.shapes.Shape.describe":         {"app.main.main:19", "app.shapes.Canvas.draw:26"},
+		"app.shapes.Shape.area":             {"app.shapes.Shape.describe:9"},
+		"app.shapes.Canvas.__init__":        {"app.main.main:22"},
+		"app.shapes.Canvas.draw":            {"app.main.main:22"},
+		"greeting.greet":                    {"app.main.main:17"},
+		"greeting.greeter.Greeter.__init__": {"app.main.main:15", "greeting.greet:5"},
+		"greeting.greeter.Greeter.welcome":  {"app.main.main:16"},
+		"greeting.greeter.Greeter.message":  {"greeting.greeter.Greeter.welcome:9", "greeting.greet:5"},
+		"builtins.open":                     {"app.main.read_name:10"},
+		"builtins.print":                    {"app.main.main:17", "greeting.greeter.Greeter.welcome:9"},
+		"json.load":                         {"app.main.read_name:11"},
+		"os.path.join":                      {"app.main.main:17"},
+		"os.path.basename":                  {"app.util.name:6"},
+		"functools.wraps":                   {"app.util.logged:10"},
+		"missing_pkg.helper":                {"app.main.main:20"},
+		"app.main.render":                   nil,
+		"app.util.unused":                   nil,
+		"app.util.logged.wrapper":           nil,
+		"manage.<module>":                   nil,
+	}
+	for symbol, expected := range cases {
+		t.Run(symbol, func(t *testing.T) {
+			node := cg.cgModel.GetNode(symbol)
+			assert.NotNil(t, node)
+			if node != nil {
+				assert.ElementsMatch(t, expected, parents(node))
+			}
+		})
+	}
+
+	// Functions of site-packages are only added when called, and values returned by functions aren't instances
+	assert.Nil(t, cg.cgModel.GetNode("greeting.greeter.Greeter.unused"))
+	assert.Nil(t, cg.cgModel.GetNode("os.path.basename.upper"))
+}
+
+func TestConstructCallGraphNodes(t *testing.T) {
+	cg := newFixtureBuilder(nil)
+	err := cg.constructCallGraph()
+	assert.NoError(t, err)
+
+	node := cg.cgModel.GetNode("app.shapes.Square.__init__")
+	assert.Equal(t, "app/shapes.py", node.Filename)
+	assert.Equal(t, "Square.__init__", node.Name)
+	assert.True(t, node.IsApplicationNode)
+	assert.False(t, node.IsStdLibNode)
+	assert.Equal(t, 13, node.LineStart)
+	assert.Equal(t, 15, node.LineEnd)
+
+	node = cg.cgModel.GetNode("greeting.greeter.Greeter.message")
+	assert.Equal(t, greeterFile, node.Filename)
+	assert.False(t, node.IsApplicationNode)
+	assert.False(t, node.IsStdLibNode)
+	assert.Equal(t, 5, node.LineStart)
+	assert.Equal(t, 6, node.LineEnd)
+
+	node = cg.cgModel.GetNode("os.path.join")
+	assert.Equal(t, "", node.Filename)
+	assert.False(t, node.IsApplicationNode)
+	assert.True(t, node.IsStdLibNode)
+	assert.Equal(t, -1, node.LineStart)
+
+	node = cg.cgModel.GetNode("missing_pkg.helper")
+	assert.False(t, node.IsApplicationNode)
+	assert.False(t, node.IsStdLibNode)
+}
+
+func TestConstructCallGraphWithoutSitePackages(t *testing.T) {
+	cg := NewCallgraphBuilder(filepath.Join("testdata", "fixture"), fixtureFiles, nil, outputName, io.FileSystem{}, nil)
+	err := cg.constructCallGraph()
+	assert.NoError(t, err)
+
+	// Packages that aren't installed are named by the names imported
+	node := cg.cgModel.GetNode("greeting.Greeter.welcome")
+	assert.NotNil(t, node)
+	assert.False(t, node.IsStdLibNode)
+	assert.Nil(t, cg.cgModel.GetNode("greeting.greeter.Greeter.message"))
+}
+
+func TestConstructCallGraphSourceLayout(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "src", "pkg"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "src", "pkg", "__init__.py"), []byte("from .core import run\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "src", "pkg", "core.py"), []byte("def run():\n    pass\n"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "main.py"), []byte("import pkg\n\npkg.run()\n"), 0600))
+
+	cg := NewCallgraphBuilder(dir, []string{"main.py", "src/pkg/__init__.py", "src/pkg/core.py"}, nil, outputName, io.FileSystem{}, nil)
+	err := cg.constructCallGraph()
+	assert.NoError(t, err)
+
+	node := cg.cgModel.GetNode("pkg.core.run")
+	assert.NotNil(t, node)
+	assert.Equal(t, "src/pkg/core.py", node.Filename)
+	assert.Equal(t, []string{"main.<module>:3"}, parents(node))
+}
+
+func TestConstructCallGraphMissingFile(t *testing.T) {
+	cg := NewCallgraphBuilder(filepath.Join("testdata", "fixture"), []string{"app/missing.py"}, nil, outputName, io.FileSystem{}, nil)
+	err := cg.constructCallGraph()
+	assert.ErrorContains(t, err, "failed to parse app/missing.py")
+}
+
+func TestConstructCallGraphCancelled(t *testing.T) {
+	ctx, _ := ctxTestdata.NewContextMockCancelled()
+	cg := newFixtureBuilder(ctx)
+	err := cg.constructCallGraph()
+	assert.Error(t, err)
+}
+
+func TestRunCallGraph(t *testing.T) {
+	outputPath := filepath.Join("testdata", "fixture", "debricked-call-graph.python-test")
+	defer func() {
+		err := os.Remove(outputPath)
+		if err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	ctx, _ := ctxTestdata.NewContextMock()
+	cg := NewCallgraphBuilder(filepath.Join("testdata", "fixture"), fixtureFiles, fixtureSitePackages, "debricked-call-graph.python-test", io.FileSystem{}, ctx)
+	output, err := cg.RunCallGraph()
+	assert.NoError(t, err)
+	assert.Equal(t, outputPath, output)
+
+	content, err := os.ReadFile(outputPath)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "{\"version\": \"5\", \"data\": [")
+	assert.Contains(t, string(content), "[\"app.main.main\", true, false, \"main\", \"app/main.py\", 14, 22, [")
+}
+
+func TestRunCallGraphWriteError(t *testing.T) {
+	fs := ioTestData.FileSystemMock{FsWriteFileError: fmt.Errorf("error")}
+	cg := NewCallgraphBuilder(filepath.Join("testdata", "fixture"), fixtureFiles, fixtureSitePackages, outputName, fs, nil)
+	_, err := cg.RunCallGraph()
+	assert.Error(t, err)
+}
+
+func TestIsApplicationNode(t *testing.T) {
+	assert.True(t, IsApplicationNode("app/main.py"))
+	assert.False(t, IsApplicationNode(".venv/lib/python3.11/site-packages/django/__init__.py"))
+	assert.False(t, IsApplicationNode("/usr/lib/python3/dist-packages/yaml/__init__.py"))
+	assert.True(t, IsApplicationNode("app/site_packages.py"))
+}
+
+func TestLocateStdlib(t *testing.T) {
+	kind, _ := locate([]string{filepath.Join("testdata", "fixture")}, fixtureSitePackages, []string{"os", "path", "join"})
+	assert.Equal(t, stdlibModule, kind)
+
+	kind, loc := locate([]string{filepath.Join("testdata", "fixture")}, fixtureSitePackages, []string{"greeting", "greeter", "Greeter"})
+	assert.Equal(t, fileModule, kind)
+	assert.Equal(t, 2, loc.names)
+	assert.False(t, loc.isPackage)
+
+	kind, _ = locate([]string{filepath.Join("testdata", "fixture")}, nil, []string{"missing_pkg", "helper"})
+	assert.Equal(t, unresolvedModule, kind)
+}
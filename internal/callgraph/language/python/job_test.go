@@ -0,0 +1,135 @@
+package python
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	ctxTestdata "github.com/debricked/cli/internal/callgraph/cgexec/testdata"
+	conf "github.com/debricked/cli/internal/callgraph/config"
+	jobTestdata "github.com/debricked/cli/internal/callgraph/job/testdata"
+	"github.com/debricked/cli/internal/callgraph/language/python/testdata"
+	io "github.com/debricked/cli/internal/io"
+	ioTestData "github.com/debricked/cli/internal/io/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	dir = "dir"
+)
+
+var files = []string{"main.py"}
+
+func TestNewJob(t *testing.T) {
+	writer := io.FileWriter{}
+	config := conf.Config{}
+	ctx, _ := ctxTestdata.NewContextMock()
+
+	fsMock := ioTestData.FileSystemMock{}
+	zip := ioTestData.ZipMock{}
+	archiveMock := io.NewArchiveWithStructs("dir", fsMock, zip)
+
+	fs := io.FileSystem{}
+
+	j := NewJob(dir, files, []string{"site-packages"}, writer, archiveMock, config, ctx, fs)
+	assert.Equal(t, files, j.GetFiles())
+	assert.Equal(t, []string{"site-packages"}, j.sitePackages)
+	assert.Equal(t, "dir", j.GetDir())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRun(t *testing.T) {
+	outputPath := filepath.Join("testdata", "fixture", outputName)
+	defer func() {
+		err := os.Remove(outputPath)
+		if err != nil {
+			fmt.Println(err)
+		}
+	}()
+
+	config := conf.NewConfig("python", nil, nil, true, "pip", "")
+	ctx, _ := ctxTestdata.NewContextMock()
+
+	j := NewJob(filepath.Join("testdata", "fixture"), fixtureFiles, fixtureSitePackages, io.FileWriter{}, io.NewArchive("."), config, ctx, io.FileSystem{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+
+	_, err := os.Stat(outputPath)
+	assert.False(t, os.IsNotExist(err))
+}
+
+func TestRunCallgraphMockError(t *testing.T) {
+	fileWriterMock := &ioTestData.FileWriterMock{}
+	config := conf.NewConfig("python", nil, nil, true, "pip", "")
+	ctx, _ := ctxTestdata.NewContextMock()
+	callgraphMock := testdata.CallgraphMock{RunCallGraphError: fmt.Errorf("error")}
+
+	fsMock := ioTestData.FileSystemMock{}
+	zip := ioTestData.ZipMock{}
+	archiveMock := io.NewArchiveWithStructs("dir", fsMock, zip)
+
+	j := NewJob(dir, files, nil, fileWriterMock, archiveMock, config, ctx, io.FileSystem{})
+	j.runCallGraph(callgraphMock)
+
+	assert.True(t, j.Errors().HasError())
+}
+
+func TestRunPostProcessZipFileError(t *testing.T) {
+	fileWriterMock := &ioTestData.FileWriterMock{}
+	config := conf.NewConfig("python", nil, nil, true, "pip", "")
+	ctx, _ := ctxTestdata.NewContextMock()
+	archiveMock := ioTestData.ArchiveMock{ZipFileError: fmt.Errorf("error")}
+
+	j := NewJob(dir, files, nil, fileWriterMock, archiveMock, config, ctx, io.FileSystem{})
+	go jobTestdata.WaitStatus(j)
+	j.runCallGraph(testdata.CallgraphMock{})
+
+	assert.True(t, j.Errors().HasError())
+}
+
+func TestRunPostProcessB64Error(t *testing.T) {
+	fileWriterMock := &ioTestData.FileWriterMock{}
+	config := conf.NewConfig("python", nil, nil, true, "pip", "")
+	ctx, _ := ctxTestdata.NewContextMock()
+	archiveMock := ioTestData.ArchiveMock{B64Error: fmt.Errorf("error")}
+
+	j := NewJob(dir, files, nil, fileWriterMock, archiveMock, config, ctx, io.FileSystem{})
+	go jobTestdata.WaitStatus(j)
+	j.runCallGraph(testdata.CallgraphMock{})
+
+	assert.True(t, j.Errors().HasError())
+}
+
+func TestRunPostProcessCleanupError(t *testing.T) {
+	fileWriterMock := &ioTestData.FileWriterMock{}
+	config := conf.NewConfig("python", nil, nil, true, "pip", "")
+	ctx, _ := ctxTestdata.NewContextMock()
+	archiveMock := ioTestData.ArchiveMock{CleanupError: fmt.Errorf("error")}
+
+	j := NewJob(dir, files, nil, fileWriterMock, archiveMock, config, ctx, io.FileSystem{})
+	go jobTestdata.WaitStatus(j)
+	j.runCallGraph(testdata.CallgraphMock{})
+
+	assert.True(t, j.Errors().HasError())
+}
+
+func TestRunPostProcessCleanupNoFileExistError(t *testing.T) {
+	fileWriterMock := &ioTestData.FileWriterMock{}
+	config := conf.NewConfig("python", nil, nil, true, "pip", "")
+	ctx, _ := ctxTestdata.NewContextMock()
+
+	err := &os.PathError{}
+	err.Err = syscall.ENOENT
+	archiveMock := ioTestData.ArchiveMock{CleanupError: err}
+
+	j := NewJob(dir, files, nil, fileWriterMock, archiveMock, config, ctx, io.FileSystem{})
+	go jobTestdata.WaitStatus(j)
+	j.runCallGraph(testdata.CallgraphMock{})
+
+	assert.False(t, j.Errors().HasError())
+}
@@ -0,0 +1,315 @@
+package python
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type tokenKind int
+
+const (
+	nameToken tokenKind = iota
+	opToken
+	stringToken
+	numberToken
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	line  int
+}
+
+// logicalLine is a statement, or the header of a compound statement, joined over the physical lines it spans
+type logicalLine struct {
+	indent    int
+	lineStart int
+	lineEnd   int
+	tokens    []token
+}
+
+// operators are the operators of more than one character, longest first
+var operators = []string{
+	"**=", "//=", ">>=", "<<=", "...",
+	"->", ":=", "==", "!=", "<=", ">=", "**", "//", "<<", ">>",
+	"+=", "-=", "*=", "/=", "%=", "&=", "|=", "^=", "@=",
+}
+
+const tabSize = 8
+
+type lexer struct {
+	src   string
+	pos   int
+	line  int
+	depth int
+	lines []logicalLine
+	// current is the logical line being read, nil between lines
+	current *logicalLine
+}
+
+// tokenize splits a Python source into logical lines, leaving out comments and blank lines
+func tokenize(src string) []logicalLine {
+	l := &lexer{src: src, line: 1}
+	atLineStart := true
+	for l.pos < len(l.src) {
+		if atLineStart && l.depth == 0 {
+			l.readIndent()
+			atLineStart = false
+
+			continue
+		}
+		c := l.src[l.pos]
+		switch {
+		case c == '\n':
+			l.pos++
+			l.line++
+			if l.depth == 0 {
+				l.endLine()
+				atLineStart = true
+			}
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			l.pos++
+		case c == '#':
+			l.skipComment()
+		case c == '\\' && l.pos+1 < len(l.src) && (l.src[l.pos+1] == '\n' || l.src[l.pos+1] == '\r'):
+			l.joinLines()
+		default:
+			l.readToken()
+		}
+	}
+	l.endLine()
+
+	return l.lines
+}
+
+// joinLines skips a backslash ending a physical line, which joins it with the next
+func (l *lexer) joinLines() {
+	l.pos++
+	if l.src[l.pos] == '\r' {
+		l.pos++
+	}
+	if l.pos < len(l.src) && l.src[l.pos] == '\n' {
+		l.pos++
+	}
+	l.line++
+}
+
+// readIndent reads the indentation of a line, starting a logical line unless it's blank
+func (l *lexer) readIndent() {
+	indent := 0
+	for l.pos < len(l.src) {
+		switch l.src[l.pos] {
+		case ' ':
+			indent++
+		case '\t':
+			indent += tabSize - indent%tabSize
+		case '\f':
+			indent = 0
+		default:
+			l.current = &logicalLine{indent: indent, lineStart: l.line, lineEnd: l.line}
+
+			return
+		}
+		l.pos++
+	}
+}
+
+func (l *lexer) endLine() {
+	if l.current != nil && len(l.current.tokens) > 0 {
+		l.lines = append(l.lines, *l.current)
+	}
+	l.current = nil
+	l.depth = 0
+}
+
+func (l *lexer) skipComment() {
+	for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+		l.pos++
+	}
+}
+
+func (l *lexer) emit(kind tokenKind, value string, line int) {
+	if l.current == nil {
+		l.current = &logicalLine{lineStart: line}
+	}
+	l.current.tokens = append(l.current.tokens, token{kind: kind, value: value, line: line})
+	l.current.lineEnd = l.line
+}
+
+func (l *lexer) readToken() {
+	start := l.pos
+	line := l.line
+	r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+	switch {
+	case isIdentifierStart(r):
+		if prefix := l.stringPrefix(); prefix != "" {
+			l.readString()
+			l.emit(stringToken, l.src[start:l.pos], line)
+			if strings.ContainsAny(prefix, "fF") {
+				l.emitFields(l.src[start+len(prefix):l.pos], line)
+			}
+
+			return
+		}
+		l.pos += size
+		for l.pos < len(l.src) {
+			r, size = utf8.DecodeRuneInString(l.src[l.pos:])
+			if !isIdentifierStart(r) && !unicode.IsDigit(r) {
+				break
+			}
+			l.pos += size
+		}
+		l.emit(nameToken, l.src[start:l.pos], line)
+	case r == '"' || r == '\'':
+		l.readString()
+		l.emit(stringToken, l.src[start:l.pos], line)
+	case unicode.IsDigit(r) || (r == '.' && l.pos+1 < len(l.src) && isDigit(l.src[l.pos+1])):
+		l.readNumber()
+		l.emit(numberToken, l.src[start:l.pos], line)
+	default:
+		l.emit(opToken, l.readOperator(), line)
+	}
+}
+
+// stringPrefix returns the prefix of a string at the current position, such as f or rb, or an empty string if the
+// identifier at the current position isn't a prefix
+func (l *lexer) stringPrefix() string {
+	end := l.pos
+	for end < len(l.src) && end-l.pos < 2 && strings.ContainsRune("rRbBuUfF", rune(l.src[end])) {
+		end++
+	}
+	if end < len(l.src) && (l.src[end] == '"' || l.src[end] == '\'') {
+		return l.src[l.pos:end]
+	}
+
+	return ""
+}
+
+// emitFields emits the tokens of the replacement fields of an f-string, such as {greet(name)!r:>10}, as if they
+// followed the string
+func (l *lexer) emitFields(literal string, line int) {
+	depth := 0
+	start := 0
+	for i := 0; i < len(literal); i++ {
+		switch c := literal[i]; {
+		case c == '{' && depth == 0 && i+1 < len(literal) && literal[i+1] == '{':
+			// An escaped brace
+			i++
+		case c == '{':
+			if depth == 0 {
+				start = i + 1
+			}
+			depth++
+		case c == '}' && depth > 0:
+			depth--
+			if depth == 0 {
+				l.emitField(literal[start:i], line+strings.Count(literal[:start], "\n"))
+			}
+		}
+	}
+}
+
+func (l *lexer) emitField(field string, line int) {
+	for _, fieldLine := range tokenize(field) {
+		for _, t := range fieldLine.tokens {
+			if t.kind == opToken && (t.value == "!" || t.value == ":") {
+				// The conversion and the format specification
+				return
+			}
+			l.emit(t.kind, t.value, line+t.line-1)
+		}
+	}
+}
+
+// readString reads a string, including its prefix, keeping track of the lines of triple-quoted strings
+func (l *lexer) readString() {
+	for l.src[l.pos] != '"' && l.src[l.pos] != '\'' {
+		l.pos++
+	}
+	quote := l.src[l.pos : l.pos+1]
+	if strings.HasPrefix(l.src[l.pos:], strings.Repeat(quote, 3)) {
+		quote = strings.Repeat(quote, 3)
+	}
+	l.pos += len(quote)
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case strings.HasPrefix(l.src[l.pos:], quote):
+			l.pos += len(quote)
+
+			return
+		case c == '\\' && l.pos+1 < len(l.src):
+			// The escaped character doesn't end the string, in raw strings too
+			if l.src[l.pos+1] == '\n' {
+				l.line++
+			}
+			l.pos += 2
+		case c == '\n':
+			if len(quote) == 1 {
+				// An unterminated string
+				return
+			}
+			l.line++
+			l.pos++
+		default:
+			l.pos++
+		}
+	}
+}
+
+func (l *lexer) readNumber() {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		isExponentSign := (c == '+' || c == '-') && (l.src[l.pos-1] == 'e' || l.src[l.pos-1] == 'E') && !isHex(l.src, l.pos)
+		if !isDigit(c) && !isLetter(c) && c != '.' && c != '_' && !isExponentSign {
+			return
+		}
+		l.pos++
+	}
+}
+
+func (l *lexer) readOperator() string {
+	for _, op := range operators {
+		if strings.HasPrefix(l.src[l.pos:], op) {
+			l.pos += len(op)
+
+			return op
+		}
+	}
+	c := l.src[l.pos]
+	switch c {
+	case '(', '[', '{':
+		l.depth++
+	case ')', ']', '}':
+		if l.depth > 0 {
+			l.depth--
+		}
+	}
+	_, size := utf8.DecodeRuneInString(l.src[l.pos:])
+	l.pos += size
+
+	return l.src[l.pos-size : l.pos]
+}
+
+// isHex reports whether the number ending at pos is hexadecimal, in which e is a digit
+func isHex(src string, pos int) bool {
+	start := pos
+	for start > 0 && (isDigit(src[start-1]) || isLetter(src[start-1]) || src[start-1] == '_') {
+		start--
+	}
+
+	return strings.HasPrefix(strings.ToLower(src[start:pos]), "0x")
+}
+
+func isIdentifierStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
@@ -0,0 +1,71 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func values(tokens []token) []string {
+	result := make([]string, len(tokens))
+	for i, t := range tokens {
+		result[i] = t.value
+	}
+
+	return result
+}
+
+func TestTokenize(t *testing.T) {
+	lines := tokenize("x: int = a.b(1) ** 2  # comment\n")
+	assert.Len(t, lines, 1)
+	assert.Equal(t, []string{"x", ":", "int", "=", "a", ".", "b", "(", "1", ")", "**", "2"}, values(lines[0].tokens))
+	assert.Equal(t, numberToken, lines[0].tokens[8].kind)
+}
+
+func TestTokenizeIndentation(t *testing.T) {
+	lines := tokenize("def a():\n\n    # comment\n    b()\n\tc()\nd()\n")
+	assert.Len(t, lines, 4)
+	assert.Equal(t, []int{0, 4, 8, 0}, []int{lines[0].indent, lines[1].indent, lines[2].indent, lines[3].indent})
+	assert.Equal(t, []int{1, 4, 5, 6}, []int{lines[0].lineStart, lines[1].lineStart, lines[2].lineStart, lines[3].lineStart})
+}
+
+func TestTokenizeLineJoining(t *testing.T) {
+	lines := tokenize("a = f(1,\n      2)\nb = 1 + \\\n    2\nc = [\n]\n")
+	assert.Len(t, lines, 3)
+	assert.Equal(t, []string{"a", "=", "f", "(", "1", ",", "2", ")"}, values(lines[0].tokens))
+	assert.Equal(t, 1, lines[0].lineStart)
+	assert.Equal(t, 2, lines[0].lineEnd)
+	assert.Equal(t, 2, lines[0].tokens[6].line)
+	assert.Equal(t, []string{"b", "=", "1", "+", "2"}, values(lines[1].tokens))
+	assert.Equal(t, 5, lines[2].lineStart)
+	assert.Equal(t, 6, lines[2].lineEnd)
+}
+
+func TestTokenizeStrings(t *testing.T) {
+	lines := tokenize("a = rb'\\'' + \"b#\" + '''c\n(\nd''' + u\"e\"\nf()\n")
+	assert.Len(t, lines, 2)
+	assert.Equal(t, []string{"a", "=", `rb'\''`, "+", `"b#"`, "+", "'''c\n(\nd'''", "+", `u"e"`}, values(lines[0].tokens))
+	assert.Equal(t, stringToken, lines[0].tokens[2].kind)
+	assert.Equal(t, 3, lines[0].lineEnd)
+	assert.Equal(t, 4, lines[1].lineStart)
+}
+
+func TestTokenizeFString(t *testing.T) {
+	lines := tokenize("f'{{a}} {b(c)!r:>{width}} {d[\"e\"]}'\n")
+	assert.Len(t, lines, 1)
+	assert.Equal(t, []string{"f'{{a}} {b(c)!r:>{width}} {d[\"e\"]}'", "b", "(", "c", ")", "d", "[", `"e"`, "]"}, values(lines[0].tokens))
+}
+
+func TestTokenizeNumbers(t *testing.T) {
+	lines := tokenize("a = 1e-3 + 0x1e-2 + 1_000.5j + .5\n")
+	assert.Equal(t, []string{"a", "=", "1e-3", "+", "0x1e", "-", "2", "+", "1_000.5j", "+", ".5"}, values(lines[0].tokens))
+}
+
+func TestTokenizeUnterminated(t *testing.T) {
+	assert.NotPanics(t, func() {
+		tokenize("a = 'b\nc = (\n")
+		tokenize("'''a")
+		tokenize("f'{a")
+		tokenize("a \\")
+	})
+}
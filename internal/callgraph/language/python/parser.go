@@ -0,0 +1,437 @@
+package python
+
+import (
+	"strings"
+)
+
+const (
+	moduleFunctionName = "<module>"
+	// instanceCall is the element of a chain standing for the instance created by calling a class, as in Foo().bar()
+	instanceCall = "()"
+	initName     = "__init__"
+)
+
+// function is a function, a method or the top-level code of a module
+type function struct {
+	// name is qualified by the names of the functions and classes it's defined in, such as Greeter.greet
+	name string
+	// scope qualifies the names defined in the function, it's empty for the top-level code
+	scope string
+	// class is the class of a method, and self the name of its first parameter
+	class     string
+	self      string
+	lineStart int
+	lineEnd   int
+	calls     []call
+}
+
+type call struct {
+	// chain is the callee, such as [self greet], [os path join] or [Greeter () greet] for Greeter().greet()
+	chain []string
+	line  int
+	// decorator is set for decorators applied without arguments, such as @staticmethod
+	decorator bool
+}
+
+type class struct {
+	name  string
+	bases [][]string
+}
+
+type module struct {
+	path string
+	// name is the dotted name the module is imported by
+	name      string
+	functions map[string]*function
+	// order keeps the functions in order of definition
+	order   []*function
+	classes map[string]*class
+	// imports maps the names bound by imports to the dotted names imported
+	imports map[string]string
+	// starImports are the modules all names are imported from
+	starImports []string
+	// instances maps variables, qualified by their scope, and attributes of self, qualified by their class, to the
+	// class assigned an instance of
+	instances map[string][]string
+}
+
+func newModule(path string, name string) *module {
+	return &module{
+		path:      path,
+		name:      name,
+		functions: map[string]*function{},
+		classes:   map[string]*class{},
+		imports:   map[string]string{},
+		instances: map[string][]string{},
+	}
+}
+
+// add adds a function, returning the function already defined with the same name, such as a property setter
+func (m *module) add(fn *function) *function {
+	if existing, ok := m.functions[fn.name]; ok {
+		return existing
+	}
+	m.functions[fn.name] = fn
+	m.order = append(m.order, fn)
+
+	return fn
+}
+
+var keywords = map[string]bool{
+	"False": true, "None": true, "True": true, "and": true, "as": true, "assert": true, "async": true,
+	"await": true, "break": true, "class": true, "continue": true, "def": true, "del": true, "elif": true,
+	"else": true, "except": true, "finally": true, "for": true, "from": true, "global": true, "if": true,
+	"import": true, "in": true, "is": true, "lambda": true, "nonlocal": true, "not": true, "or": true,
+	"pass": true, "raise": true, "return": true, "try": true, "while": true, "with": true, "yield": true,
+}
+
+// frame is a function or class whose body is being parsed
+type frame struct {
+	indent int
+	// owner is the function the calls of the body are made in, which for a class is the function it's defined in
+	owner   *function
+	scope   string
+	isClass bool
+}
+
+type parser struct {
+	module *module
+	// pkg is the package relative imports are resolved from
+	pkg   []string
+	stack []frame
+}
+
+// parse analyses a Python module named name, finding its functions and classes, the calls made in them and its
+// imports. It's a best effort analysis of the source, which doesn't have to be valid.
+func parse(path string, name string, isPackage bool, src string) *module {
+	p := &parser{module: newModule(path, name)}
+	if name != "" {
+		p.pkg = strings.Split(name, ".")
+	}
+	if !isPackage && len(p.pkg) > 0 {
+		p.pkg = p.pkg[:len(p.pkg)-1]
+	}
+	main := p.module.add(&function{name: moduleFunctionName, lineStart: 1, lineEnd: 1})
+	p.stack = []frame{{indent: -1, owner: main}}
+	for _, line := range tokenize(src) {
+		for len(p.stack) > 1 && line.indent <= p.top().indent {
+			p.stack = p.stack[:len(p.stack)-1]
+		}
+		p.statement(line, line.tokens)
+		for _, f := range p.stack {
+			if !f.isClass {
+				f.owner.lineEnd = max(f.owner.lineEnd, line.lineEnd)
+			}
+		}
+	}
+
+	return p.module
+}
+
+func (p *parser) top() frame {
+	return p.stack[len(p.stack)-1]
+}
+
+func (p *parser) statement(line logicalLine, tokens []token) {
+	if len(tokens) > 1 && tokens[0].value == "async" {
+		tokens = tokens[1:]
+	}
+	owner := p.top().owner
+	switch tokens[0].value {
+	case "@":
+		p.decorator(tokens[1:], owner)
+	case "def":
+		p.def(line, tokens)
+	case "class":
+		p.class(line, tokens)
+	case "import":
+		p.importNames(tokens[1:])
+	case "from":
+		p.fromImport(tokens[1:])
+	default:
+		p.assignment(tokens, owner)
+		p.calls(tokens, owner)
+	}
+}
+
+// decorator adds the call of a decorator to the function the decorated function is defined in
+func (p *parser) decorator(tokens []token, owner *function) {
+	chain, end := readChain(tokens, 0)
+	if len(chain) > 0 && end == len(tokens) {
+		owner.calls = append(owner.calls, call{chain: chain, line: tokens[0].line, decorator: true})
+
+		return
+	}
+	p.calls(tokens, owner)
+}
+
+func (p *parser) def(line logicalLine, tokens []token) {
+	if len(tokens) < 2 || tokens[1].kind != nameToken {
+		return
+	}
+	parent := p.top()
+	colon := headerEnd(tokens)
+	// Default values are evaluated where the function is defined
+	p.calls(tokens[2:colon], parent.owner)
+
+	fn := &function{lineStart: line.lineStart, lineEnd: line.lineEnd}
+	fn.name = qualify(parent.scope, tokens[1].value)
+	fn.scope = fn.name
+	if parent.isClass {
+		fn.class = parent.scope
+		if len(tokens) > 3 && tokens[2].value == "(" && tokens[3].kind == nameToken {
+			fn.self = tokens[3].value
+		}
+	}
+	fn = p.module.add(fn)
+	p.stack = append(p.stack, frame{indent: line.indent, owner: fn, scope: fn.name})
+	p.body(line, tokens, colon)
+}
+
+func (p *parser) class(line logicalLine, tokens []token) {
+	if len(tokens) < 2 || tokens[1].kind != nameToken {
+		return
+	}
+	parent := p.top()
+	colon := headerEnd(tokens)
+	c := &class{name: qualify(parent.scope, tokens[1].value)}
+	if len(tokens) > 2 && tokens[2].value == "(" {
+		for _, arg := range splitArguments(tokens[3:min(closing(tokens, 2), colon)]) {
+			if chain, end := readChain(arg, 0); len(chain) > 0 && end == len(arg) {
+				c.bases = append(c.bases, chain)
+			}
+		}
+		p.calls(tokens[2:colon], parent.owner)
+	}
+	if _, ok := p.module.classes[c.name]; !ok {
+		p.module.classes[c.name] = c
+	}
+	p.stack = append(p.stack, frame{indent: line.indent, owner: parent.owner, scope: c.name, isClass: true})
+	p.body(line, tokens, colon)
+}
+
+// body parses the body of a compound statement written on the line of its header
+func (p *parser) body(line logicalLine, tokens []token, colon int) {
+	for _, statement := range splitStatements(tokens[min(colon+1, len(tokens)):]) {
+		p.statement(line, statement)
+	}
+}
+
+// importNames binds the names of import a.b, c as d
+func (p *parser) importNames(tokens []token) {
+	for _, name := range splitArguments(tokens) {
+		chain, end := readChain(name, 0)
+		if len(chain) == 0 {
+			continue
+		}
+		dotted := strings.Join(chain, ".")
+		if end+1 < len(name) && name[end].value == "as" {
+			p.module.imports[name[end+1].value] = dotted
+		} else {
+			// import a.b binds a, from which a.b is reached
+			p.module.imports[chain[0]] = chain[0]
+		}
+	}
+}
+
+// fromImport binds the names of from .a import b as c, d or adds the module of from a import *
+func (p *parser) fromImport(tokens []token) {
+	level := 0
+	i := 0
+	for ; i < len(tokens) && (tokens[i].value == "." || tokens[i].value == "..."); i++ {
+		level += len(tokens[i].value)
+	}
+	chain, end := readChain(tokens, i)
+	if end >= len(tokens) || tokens[end].value != "import" {
+		return
+	}
+	base := p.absolute(level, chain)
+	for _, name := range splitArguments(trimParentheses(tokens[end+1:])) {
+		switch {
+		case len(name) == 0:
+			continue
+		case name[0].value == "*":
+			p.module.starImports = append(p.module.starImports, base)
+		case len(name) > 2 && name[1].value == "as":
+			p.module.imports[name[2].value] = qualify(base, name[0].value)
+		default:
+			p.module.imports[name[0].value] = qualify(base, name[0].value)
+		}
+	}
+}
+
+// absolute is the dotted name of a module imported relatively to the package of the module at level
+func (p *parser) absolute(level int, chain []string) string {
+	if level == 0 || level > len(p.pkg)+1 {
+		return strings.Join(chain, ".")
+	}
+	parts := append(append([]string{}, p.pkg[:len(p.pkg)-level+1]...), chain...)
+
+	return strings.Join(parts, ".")
+}
+
+// assignment records the class of the instance assigned by x = Foo() or self.x = Foo()
+func (p *parser) assignment(tokens []token, owner *function) {
+	assign := find(tokens, "=")
+	if assign == len(tokens) {
+		return
+	}
+	// The annotation of an annotated assignment is left out
+	target := tokens[:find(tokens[:assign], ":")]
+	value := tokens[assign+1:]
+	chain, end := readChain(value, 0)
+	if len(chain) == 0 || end >= len(value) || value[end].value != "(" || closing(value, end) != len(value)-1 {
+		return
+	}
+	switch {
+	case len(target) == 1 && target[0].kind == nameToken:
+		p.module.instances[qualify(owner.scope, target[0].value)] = chain
+	case len(target) == 3 && owner.class != "" && target[0].value == owner.self && target[1].value == ".":
+		p.module.instances[qualify(owner.class, owner.self, target[2].value)] = chain
+	}
+}
+
+// calls adds the calls made in tokens to owner
+func (p *parser) calls(tokens []token, owner *function) {
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		if t.kind != nameToken || keywords[t.value] || (i > 0 && tokens[i-1].value == ".") {
+			continue
+		}
+		chain, end := readChain(tokens, i)
+		if end < len(tokens) && tokens[end].value == "(" {
+			owner.calls = append(owner.calls, call{chain: chain, line: tokens[end-1].line})
+			// A method called on the instance created, as in Foo().bar()
+			closed := closing(tokens, end)
+			if closed+3 < len(tokens) && tokens[closed+1].value == "." && tokens[closed+2].kind == nameToken &&
+				tokens[closed+3].value == "(" {
+				instanceChain := append(append(append([]string{}, chain...), instanceCall), tokens[closed+2].value)
+				owner.calls = append(owner.calls, call{chain: instanceChain, line: tokens[closed+2].line})
+			}
+		}
+		i = end - 1
+	}
+}
+
+// readChain reads a dotted name, such as os.path.join, starting at i. It returns the names and the index after them.
+func readChain(tokens []token, i int) ([]string, int) {
+	if i >= len(tokens) || tokens[i].kind != nameToken || keywords[tokens[i].value] {
+		return nil, i
+	}
+	chain := []string{tokens[i].value}
+	i++
+	for i+1 < len(tokens) && tokens[i].value == "." && tokens[i+1].kind == nameToken {
+		chain = append(chain, tokens[i+1].value)
+		i += 2
+	}
+
+	return chain, i
+}
+
+// headerEnd returns the index of the colon ending the header of a compound statement
+func headerEnd(tokens []token) int {
+	return find(tokens, ":")
+}
+
+// find returns the index of the first operator op outside of brackets, or the length of tokens if there's none
+func find(tokens []token, op string) int {
+	depth := 0
+	for i, t := range tokens {
+		depth += bracketDepth(t)
+		if depth == 0 && t.kind == opToken && t.value == op {
+			return i
+		}
+	}
+
+	return len(tokens)
+}
+
+// closing returns the index of the bracket closing the one at open
+func closing(tokens []token, open int) int {
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		depth += bracketDepth(tokens[i])
+		if depth == 0 {
+			return i
+		}
+	}
+
+	return len(tokens)
+}
+
+// splitArguments splits tokens by the commas outside of brackets
+func splitArguments(tokens []token) [][]token {
+	return split(tokens, ",")
+}
+
+// splitStatements splits the simple statements of a line, such as a = 1; b()
+func splitStatements(tokens []token) [][]token {
+	var statements [][]token
+	for _, statement := range split(tokens, ";") {
+		if len(statement) > 0 {
+			statements = append(statements, statement)
+		}
+	}
+
+	return statements
+}
+
+func split(tokens []token, separator string) [][]token {
+	var parts [][]token
+	depth := 0
+	start := 0
+	for i, t := range tokens {
+		depth += bracketDepth(t)
+		if depth == 0 && t.kind == opToken && t.value == separator {
+			parts = append(parts, tokens[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(parts, tokens[start:])
+}
+
+func trimParentheses(tokens []token) []token {
+	if len(tokens) > 1 && tokens[0].value == "(" && tokens[len(tokens)-1].value == ")" {
+		return tokens[1 : len(tokens)-1]
+	}
+
+	return tokens
+}
+
+func bracketDepth(t token) int {
+	if t.kind != opToken {
+		return 0
+	}
+	switch t.value {
+	case "(", "[", "{":
+		return 1
+	case ")", "]", "}":
+		return -1
+	}
+
+	return 0
+}
+
+// qualify joins names to a scope, which is empty at the top level of a module
+func qualify(scope string, names ...string) string {
+	name := strings.Join(names, ".")
+	if scope == "" {
+		return name
+	}
+	if name == "" {
+		return scope
+	}
+
+	return scope + "." + name
+}
+
+// parent returns the scope a scope is defined in
+func parent(scope string) string {
+	i := strings.LastIndex(scope, ".")
+	if i < 0 {
+		return ""
+	}
+
+	return scope[:i]
+}
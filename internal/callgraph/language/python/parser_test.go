@@ -0,0 +1,171 @@
+package python
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func functionNames(m *module) []string {
+	var names []string
+	for _, fn := range m.order {
+		names = append(names, fn.name)
+	}
+
+	return names
+}
+
+func chains(calls []call) [][]string {
+	var result [][]string
+	for _, c := range calls {
+		result = append(result, c.chain)
+	}
+
+	return result
+}
+
+func callLines(calls []call) []int {
+	var lines []int
+	for _, c := range calls {
+		lines = append(lines, c.line)
+	}
+
+	return lines
+}
+
+func TestParseFunctions(t *testing.T) {
+	m := parse("a.py", "a", false, `
+def a(x, y=default()):
+    def nested():
+        pass
+
+    return nested()
+
+
+async def b(): await c()
+def d(
+    x: int,
+) -> dict[str, int]:
+    pass
+`)
+	assert.Equal(t, []string{"<module>", "a", "a.nested", "b", "d"}, functionNames(m))
+	assert.Equal(t, 2, m.functions["a"].lineStart)
+	assert.Equal(t, 6, m.functions["a"].lineEnd)
+	assert.Equal(t, 4, m.functions["a.nested"].lineEnd)
+	assert.Equal(t, 13, m.functions["d"].lineEnd)
+	assert.Equal(t, 13, m.functions["<module>"].lineEnd)
+	assert.Equal(t, [][]string{{"nested"}}, chains(m.functions["a"].calls))
+	assert.Equal(t, [][]string{{"c"}}, chains(m.functions["b"].calls))
+	assert.Equal(t, [][]string{{"default"}}, chains(m.functions["<module>"].calls))
+}
+
+func TestParseClasses(t *testing.T) {
+	m := parse("a.py", "a", false, `
+class A(base.B, C, metaclass=Meta):
+    field = Field()
+
+    def __init__(self, x):
+        super().__init__()
+        self.d = D(x)
+
+    @property
+    def e(self):
+        return self.d.f()
+
+    @e.setter
+    def e(self, value):
+        self.g(value)
+
+    @staticmethod
+    def h(x): return x
+
+    class Inner:
+        def i(cls):
+            pass
+`)
+	assert.Equal(t, []string{"<module>", "A.__init__", "A.e", "A.h", "A.Inner.i"}, functionNames(m))
+	assert.Equal(t, [][]string{{"base", "B"}, {"C"}}, m.classes["A"].bases)
+	assert.Contains(t, m.classes, "A.Inner")
+	assert.Equal(t, "A", m.functions["A.e"].class)
+	assert.Equal(t, "self", m.functions["A.e"].self)
+	assert.Equal(t, "A.Inner", m.functions["A.Inner.i"].class)
+	assert.Equal(t, "cls", m.functions["A.Inner.i"].self)
+	assert.Equal(t, [][]string{{"super"}, {"super", "()", "__init__"}, {"D"}}, chains(m.functions["A.__init__"].calls))
+	// The setter is merged into the property
+	assert.Equal(t, [][]string{{"self", "d", "f"}, {"self", "g"}}, chains(m.functions["A.e"].calls))
+	assert.Equal(t, []string{"D"}, m.instances["A.self.d"])
+
+	calls := m.functions["<module>"].calls
+	assert.Equal(t, [][]string{{"Field"}, {"property"}, {"e", "setter"}, {"staticmethod"}}, chains(calls))
+	assert.True(t, calls[1].decorator)
+	assert.Equal(t, 9, calls[1].line)
+	assert.False(t, calls[0].decorator)
+}
+
+func TestParseImports(t *testing.T) {
+	m := parse("pkg/sub/mod.py", "pkg.sub.mod", false, `
+import os, os.path
+import xml.etree.ElementTree as ET
+from . import sibling
+from .. import parent as p
+from ..other import (
+    a,
+    b as c,
+)
+from django.db import models
+from .star import *
+if True:
+    from json import loads
+`)
+	assert.Equal(t, map[string]string{
+		"os":      "os",
+		"ET":      "xml.etree.ElementTree",
+		"sibling": "pkg.sub.sibling",
+		"p":       "pkg.parent",
+		"a":       "pkg.other.a",
+		"c":       "pkg.other.b",
+		"models":  "django.db.models",
+		"loads":   "json.loads",
+	}, m.imports)
+	assert.Equal(t, []string{"pkg.sub.star"}, m.starImports)
+}
+
+func TestParseRelativeImportsOfPackage(t *testing.T) {
+	m := parse("pkg/__init__.py", "pkg", true, "from .core import run\nfrom ... import too_far\n")
+	assert.Equal(t, "pkg.core.run", m.imports["run"])
+	assert.Equal(t, "too_far", m.imports["too_far"])
+}
+
+func TestParseCalls(t *testing.T) {
+	m := parse("a.py", "a", false, `
+def a():
+    x = B()
+    y: C = d.E(1)
+    z = f() + 1
+    x.g(h(i.j()), "k".join([]), l()[0].m())
+    N().o(); p()
+    if q(): r()
+    print(f"{s()}")
+`)
+	fn := m.functions["a"]
+	assert.Equal(t, [][]string{
+		{"B"}, {"d", "E"}, {"f"}, {"x", "g"}, {"h"}, {"i", "j"}, {"l"}, {"N"}, {"N", "()", "o"}, {"p"}, {"q"}, {"r"},
+		{"print"}, {"s"},
+	}, chains(fn.calls))
+	assert.Equal(t, []int{3, 4, 5, 6, 6, 6, 6, 7, 7, 7, 8, 8, 9, 9}, callLines(fn.calls))
+	assert.Equal(t, map[string][]string{"a.x": {"B"}, "a.y": {"d", "E"}}, m.instances)
+}
+
+func TestParseInvalid(t *testing.T) {
+	assert.NotPanics(t, func() {
+		parse("a.py", "a", false, "def\nclass\ndef (:\nclass A(:\nfrom import\nimport\n@\nasync\nx = \n")
+	})
+}
+
+func TestQualify(t *testing.T) {
+	assert.Equal(t, "a", qualify("", "a"))
+	assert.Equal(t, "a.b.c", qualify("a", "b", "c"))
+	assert.Equal(t, "a", qualify("a"))
+	assert.Equal(t, "a", parent("a.b"))
+	assert.Equal(t, "", parent("a"))
+}
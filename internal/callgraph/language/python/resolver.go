@@ -0,0 +1,99 @@
+package python
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+type moduleKind int
+
+const (
+	fileModule moduleKind = iota
+	stdlibModule
+	unresolvedModule
+)
+
+const initFile = "__init__.py"
+
+// location is the file of the module named by the first names of a dotted name
+type location struct {
+	path string
+	// names is the number of names of the module, such as 2 for pkg.mod in pkg.mod.func
+	names     int
+	isPackage bool
+}
+
+// locate finds the module of the longest prefix of a dotted name the way Python imports it, from the project roots,
+// the standard library and then site-packages. Namespace packages, which have no __init__.py, are only looked for when
+// there's no regular package or module.
+func locate(projectRoots []string, sitePackages []string, parts []string) (moduleKind, location) {
+	roots := append(append([]string{}, projectRoots...), sitePackages...)
+	for _, root := range projectRoots {
+		if loc, ok := locateIn(root, parts, false); ok {
+			return fileModule, loc
+		}
+	}
+	if stdlibModules[parts[0]] {
+		return stdlibModule, location{}
+	}
+	for _, root := range sitePackages {
+		if loc, ok := locateIn(root, parts, false); ok {
+			return fileModule, loc
+		}
+	}
+	for _, root := range roots {
+		if loc, ok := locateIn(root, parts, true); ok {
+			return fileModule, loc
+		}
+	}
+
+	return unresolvedModule, location{}
+}
+
+// locateIn finds the module of the longest prefix of parts in the root. The path of the location is empty if the
+// prefix only names namespace packages.
+func locateIn(root string, parts []string, namespaces bool) (location, bool) {
+	top := filepath.Join(root, parts[0])
+	if !isFile(top+".py") && !isFile(filepath.Join(top, initFile)) && !(namespaces && isDir(top)) {
+		return location{}, false
+	}
+	var loc location
+	dir := root
+	for i, part := range parts {
+		if path := filepath.Join(dir, part, initFile); isFile(path) {
+			loc = location{path: path, names: i + 1, isPackage: true}
+		} else if path := filepath.Join(dir, part+".py"); isFile(path) {
+			return location{path: path, names: i + 1}, true
+		} else if !isDir(filepath.Join(dir, part)) {
+			break
+		}
+		dir = filepath.Join(dir, part)
+	}
+
+	return loc, true
+}
+
+// moduleName is the dotted name a file in root is imported by
+func moduleName(root string, file string) (string, bool) {
+	relative, err := filepath.Rel(root, file)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	name := strings.TrimSuffix(filepath.ToSlash(relative), ".py")
+	name = strings.TrimSuffix(strings.TrimSuffix(name, "__init__"), "/")
+
+	return strings.ReplaceAll(name, "/", "."), true
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && !info.IsDir()
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && info.IsDir()
+}
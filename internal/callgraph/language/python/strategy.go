@@ -0,0 +1,63 @@
+package python
+
+import (
+	"github.com/debricked/cli/internal/callgraph/cgexec"
+	conf "github.com/debricked/cli/internal/callgraph/config"
+	"github.com/debricked/cli/internal/callgraph/finder"
+	"github.com/debricked/cli/internal/callgraph/finder/pythonfinder"
+	"github.com/debricked/cli/internal/callgraph/job"
+	"github.com/debricked/cli/internal/io"
+)
+
+type Strategy struct {
+	config     conf.IConfig
+	paths      []string
+	exclusions []string
+	inclusions []string
+	finder     finder.IFinder
+	ctx        cgexec.IContext
+}
+
+func (s Strategy) Invoke() ([]job.IJob, error) {
+	var jobs []job.IJob
+
+	if s.config == nil {
+		finder.StrategyWarning("No config is setup")
+
+		return jobs, nil
+	}
+
+	for _, path := range s.paths {
+		projects, err := finder.FindProjects(s.finder, []string{path}, s.exclusions, s.inclusions, pythonfinder.IsSource)
+		if err != nil {
+			finder.StrategyWarning("Error while finding projects: " + err.Error())
+
+			return jobs, err
+		}
+		for _, project := range projects {
+			sitePackages, err := s.finder.FindDependencyDirs(project.RootFiles, false)
+			if err != nil {
+				finder.StrategyWarning("Error while finding site-packages: " + err.Error())
+
+				return jobs, err
+			}
+			jobs = append(jobs, NewJob(
+				project.Dir,
+				project.Sources,
+				sitePackages,
+				io.FileWriter{},
+				io.NewArchive("."),
+				s.config,
+				s.ctx,
+				io.FileSystem{},
+			),
+			)
+		}
+	}
+
+	return jobs, nil
+}
+
+func NewStrategy(config conf.IConfig, paths []string, exclusions []string, inclusions []string, finder finder.IFinder, ctx cgexec.IContext) Strategy {
+	return Strategy{config, paths, exclusions, inclusions, finder, ctx}
+}
@@ -0,0 +1,104 @@
+package python
+
+import (
+	"path/filepath"
+	"testing"
+
+	ctxTestdata "github.com/debricked/cli/internal/callgraph/cgexec/testdata"
+	"github.com/debricked/cli/internal/callgraph/config"
+	"github.com/debricked/cli/internal/callgraph/finder/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStrategy(t *testing.T) {
+	s := NewStrategy(nil, nil, nil, nil, nil, nil)
+	assert.NotNil(t, s)
+
+	conf := config.NewConfig("python", []string{"arg1"}, map[string]string{"kwarg": "val"}, true, "pip", "")
+	finder := testdata.NewEmptyFinderMock()
+	ctx, _ := ctxTestdata.NewContextMock()
+	s = NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	assert.NotNil(t, s)
+	assert.Equal(t, s.config, conf)
+}
+
+func TestInvokeNoConfig(t *testing.T) {
+	s := NewStrategy(nil, []string{"."}, []string{}, []string{}, nil, nil)
+	jobs, _ := s.Invoke()
+	assert.Empty(t, jobs)
+}
+
+func TestInvoke(t *testing.T) {
+	conf := config.NewConfig("python", nil, nil, true, "pip", "")
+	finder := testdata.NewEmptyFinderMock()
+	finder.FindFilesNames = []string{
+		filepath.Join("app", "requirements.txt"),
+		filepath.Join("app", "setup.py"),
+		filepath.Join("app", "manage.py"),
+		filepath.Join("app", "pkg", "views.py"),
+		filepath.Join("app", "nested", "pyproject.toml"),
+		filepath.Join("app", "nested", "tool.py"),
+		filepath.Join("empty", "requirements.txt"),
+		filepath.Join("other", "script.py"),
+	}
+	finder.FindRootsNames = []string{
+		filepath.Join("app", "requirements.txt"),
+		filepath.Join("app", "setup.py"),
+		filepath.Join("app", "nested", "pyproject.toml"),
+		filepath.Join("empty", "requirements.txt"),
+	}
+	finder.FindDependencyDirsNames = []string{"site-packages"}
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Len(t, jobs, 2)
+
+	assert.Equal(t, "app", jobs[0].GetDir())
+	assert.Equal(t, []string{"setup.py", "manage.py", filepath.Join("pkg", "views.py")}, jobs[0].GetFiles())
+	assert.Equal(t, []string{"site-packages"}, jobs[0].(*Job).sitePackages)
+	assert.Equal(t, filepath.Join("app", "nested"), jobs[1].GetDir())
+	assert.Equal(t, []string{"tool.py"}, jobs[1].GetFiles())
+}
+
+func TestInvokeDependencyDirsError(t *testing.T) {
+	conf := config.NewConfig("python", nil, nil, true, "pip", "")
+	finder := testdata.NewEmptyFinderMock()
+	finder.FindFilesNames = []string{"requirements.txt", "main.py"}
+	finder.FindRootsNames = []string{"requirements.txt"}
+	finder.FindDependencyDirsErr = assert.AnError
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	jobs, err := s.Invoke()
+	assert.Error(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestInvokeWithErrors(t *testing.T) {
+	conf := config.NewConfig("python", nil, nil, true, "pip", "")
+	finder := testdata.NewEmptyFinderMock()
+	finder.FindRootsErr = assert.AnError
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	jobs, err := s.Invoke()
+	assert.Error(t, err)
+	assert.Empty(t, jobs)
+
+	finder.FindRootsErr = nil
+	finder.FindFilesErr = assert.AnError
+	s = NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	jobs, err = s.Invoke()
+	assert.Error(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestInvokeNoRoots(t *testing.T) {
+	conf := config.NewConfig("python", nil, nil, true, "pip", "")
+	finder := testdata.NewEmptyFinderMock()
+	finder.FindFilesNames = []string{"main.py"}
+	ctx, _ := ctxTestdata.NewContextMock()
+	s := NewStrategy(conf, []string{"."}, []string{}, []string{}, finder, ctx)
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	assert.Empty(t, jobs)
+}
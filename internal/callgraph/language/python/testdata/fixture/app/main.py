@@ -0,0 +1,30 @@
+import json as j
+import os.path
+from greeting import greet, Greeter
+from missing_pkg import helper
+from . import util
+from .shapes import Square, Canvas
+
+
+def read_name(path):
+    with open(path) as f:
+        return j.load(f)["name"]
+
+
+def main():
+    greeter = Greeter("world")
+    greeter.welcome()
+    print(greet(util.name(os.path.join("a", "b"))))
+    square = Square(2)
+    square.describe()
+    helper()
+    Square(3).area()
+    Canvas().draw()
+
+
+@util.logged
+def render(value):
+    def inner():
+        return read_name(value)
+
+    return inner()
@@ -0,0 +1,26 @@
+class Shape:
+    def __init__(self, sides):
+        self.sides = sides
+
+    def area(self):
+        raise NotImplementedError()
+
+    def describe(self):
+        return f"{self.sides} sides, area {self.area()}"
+
+
+class Square(Shape):
+    def __init__(self, size):
+        super().__init__(4)
+        self.size = size
+
+    def area(self):
+        return self.size ** 2
+
+
+class Canvas:
+    def __init__(self):
+        self.shape = Square(1)
+
+    def draw(self):
+        return self.shape.describe()
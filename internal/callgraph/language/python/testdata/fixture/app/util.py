@@ -0,0 +1,18 @@
+import functools
+import os.path
+
+
+def name(path):
+    return os.path.basename(path).upper()
+
+
+def logged(fn):
+    @functools.wraps(fn)
+    def wrapper(*args, **kwargs):
+        return fn(*args, **kwargs)
+
+    return wrapper
+
+
+def unused():
+    pass
@@ -0,0 +1,8 @@
+"""Runs the application.
+
+Usage: python manage.py
+"""
+from app.main import main
+
+if __name__ == "__main__":
+    main()
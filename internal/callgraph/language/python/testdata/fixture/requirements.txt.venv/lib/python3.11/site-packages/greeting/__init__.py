@@ -0,0 +1,5 @@
+from .greeter import Greeter
+
+
+def greet(name):
+    return Greeter(name).message()
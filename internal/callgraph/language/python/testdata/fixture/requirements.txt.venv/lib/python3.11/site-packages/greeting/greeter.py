@@ -0,0 +1,12 @@
+class Greeter:
+    def __init__(self, name):
+        self.name = name
+
+    def message(self):
+        return "Hello " + self.name
+
+    def welcome(self):
+        print(self.message())
+
+    def unused(self):
+        pass
@@ -0,0 +1,86 @@
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type callGraphRecord struct {
+	Version string            `json:"version"`
+	Data    []json.RawMessage `json:"data"`
+}
+
+type nodeRecord struct {
+	symbol            string
+	isApplicationNode bool
+	isStdLibNode      bool
+	name              string
+	filename          string
+	lineStart         int
+	lineEnd           int
+	parents           []parentRecord
+}
+
+type parentRecord struct {
+	symbol   string
+	callLine int
+	filename string
+}
+
+func (n *nodeRecord) UnmarshalJSON(data []byte) error {
+	fields := []interface{}{&n.symbol, &n.isApplicationNode, &n.isStdLibNode, &n.name, &n.filename, &n.lineStart, &n.lineEnd, &n.parents}
+
+	return unmarshalArray(data, fields)
+}
+
+func (p *parentRecord) UnmarshalJSON(data []byte) error {
+	return unmarshalArray(data, []interface{}{&p.symbol, &p.callLine, &p.filename})
+}
+
+func unmarshalArray(data []byte, fields []interface{}) error {
+	var values []json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	if len(values) != len(fields) {
+		return fmt.Errorf("expected %d values but got %d", len(fields), len(values))
+	}
+
+	for i, value := range values {
+		if err := json.Unmarshal(value, fields[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Parse reads a call graph in the format written by CallGraph.ToBytes
+func Parse(data []byte) (*CallGraph, error) {
+	var record callGraphRecord
+	if err := json.Unmarshal(data, &record); err != nil {
+		return nil, fmt.Errorf("failed to parse call graph: %w", err)
+	}
+
+	nodes := make([]nodeRecord, len(record.Data))
+	cg := NewCallGraph()
+	cg.Version = record.Version
+	for i, data := range record.Data {
+		if err := json.Unmarshal(data, &nodes[i]); err != nil {
+			return nil, fmt.Errorf("failed to parse call graph node %d: %w", i, err)
+		}
+		n := nodes[i]
+		cg.AddNode(n.filename, n.name, n.symbol, n.isApplicationNode, n.isStdLibNode, n.lineStart, n.lineEnd)
+	}
+
+	for _, n := range nodes {
+		child := cg.GetNode(n.symbol)
+		for _, p := range n.parents {
+			// Parents are written as nodes of their own, but unknown parents are added rather than dropped
+			parent := cg.AddNode(p.filename, "", p.symbol, false, false, -1, -1)
+			cg.AddEdge(parent, child, p.callLine)
+		}
+	}
+
+	return cg, nil
+}
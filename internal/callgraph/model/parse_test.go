@@ -0,0 +1,65 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	cg := NewCallGraph()
+	parent := cg.AddNode("main.go", "main", "main.main", true, false, 1, 10)
+	child := cg.AddNode("", "Println", "fmt.Println", false, true, -1, -1)
+	cg.AddEdge(parent, child, 5)
+	cg.AddEdge(parent, child, 7)
+	data, err := cg.ToBytes()
+	assert.NoError(t, err)
+
+	parsed, err := Parse(data)
+	assert.NoError(t, err)
+	assert.Equal(t, CURRENT_VERSION, parsed.Version)
+	assert.Equal(t, 2, parsed.NodeCount())
+	assert.Equal(t, 2, parsed.EdgeCount())
+
+	node := parsed.GetNode("main.main")
+	assert.Equal(t, "main.go", node.Filename)
+	assert.Equal(t, "main", node.Name)
+	assert.True(t, node.IsApplicationNode)
+	assert.False(t, node.IsStdLibNode)
+	assert.Equal(t, 1, node.LineStart)
+	assert.Equal(t, 10, node.LineEnd)
+
+	node = parsed.GetNode("fmt.Println")
+	assert.True(t, node.IsStdLibNode)
+	assert.Equal(t, parsed.GetNode("main.main"), node.Parents[0].Parent)
+	assert.Equal(t, []int{5, 7}, []int{node.Parents[0].CallLine, node.Parents[1].CallLine})
+
+	reparsed, err := parsed.ToBytes()
+	assert.NoError(t, err)
+	assert.Equal(t, string(data), string(reparsed))
+}
+
+func TestParseUnknownParent(t *testing.T) {
+	cg, err := Parse([]byte(`{"version": "5", "data": [["a", false, false, "a", "a.go", 1, 2, [["b", 3, "b.go"]]]]}`))
+	assert.NoError(t, err)
+	node := cg.GetNode("b")
+	assert.NotNil(t, node)
+	assert.Equal(t, "b.go", node.Filename)
+	assert.Equal(t, node, cg.GetNode("a").Parents[0].Parent)
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := map[string]string{
+		"NotJSON":       "not json",
+		"NotArray":      `{"version": "5", "data": [{}]}`,
+		"MissingValues": `{"version": "5", "data": [["a", false, false, "a", "a.go", 1, 2]]}`,
+		"WrongType":     `{"version": "5", "data": [["a", "false", false, "a", "a.go", 1, 2, []]]}`,
+		"InvalidParent": `{"version": "5", "data": [["a", false, false, "a", "a.go", 1, 2, [["b", 3]]]]}`,
+	}
+	for name, data := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(data))
+			assert.ErrorContains(t, err, "failed to parse call graph")
+		})
+	}
+}
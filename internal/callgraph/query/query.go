@@ -0,0 +1,160 @@
+package query
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/callgraph/model"
+	"github.com/debricked/cli/internal/file"
+)
+
+const callgraphName = "debricked-call-graph"
+
+type DebrickedOptions struct {
+	Path       string
+	Symbol     string
+	MaxPaths   int
+	Exclusions []string
+}
+
+// Result is the reachability of a symbol in one call graph file
+type Result struct {
+	File      string `json:"file"`
+	Symbol    string `json:"symbol"`
+	Found     bool   `json:"found"`
+	Reachable bool   `json:"reachable"`
+	Paths     []Path `json:"paths"`
+}
+
+type IQuerier interface {
+	Query(options DebrickedOptions) ([]Result, error)
+}
+
+type Querier struct{}
+
+func NewQuerier() *Querier {
+	return &Querier{}
+}
+
+// Query loads the call graph files at options.Path, a file or a directory, and reports whether the symbol
+// is reachable in each of them
+func (q *Querier) Query(options DebrickedOptions) ([]Result, error) {
+	files, err := findCallGraphFiles(options.Path, options.Exclusions)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no call graph found in %s, generate one with `debricked callgraph`", options.Path)
+	}
+
+	var results []Result
+	for _, f := range files {
+		cg, err := Load(f)
+		if err != nil {
+			return nil, err
+		}
+
+		nodes := FindNodes(cg, options.Symbol)
+		if len(nodes) == 0 {
+			results = append(results, Result{File: f, Symbol: options.Symbol, Paths: []Path{}})
+
+			continue
+		}
+
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].Symbol < nodes[j].Symbol })
+		for _, node := range nodes {
+			reachable, paths := Reachability(node, options.MaxPaths)
+			results = append(results, Result{File: f, Symbol: node.Symbol, Found: true, Reachable: reachable, Paths: paths})
+		}
+	}
+
+	return results, nil
+}
+
+// Load reads a call graph file, either as written by the call graph builders
+// or zipped and base64 encoded as left by debricked callgraph
+func Load(path string) (*model.CallGraph, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	content = bytes.TrimSpace(content)
+	if !bytes.HasPrefix(content, []byte("{")) {
+		content, err = unpack(content)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read call graph %s: %w", path, err)
+		}
+	}
+
+	cg, err := model.Parse(content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return cg, nil
+}
+
+func unpack(content []byte) ([]byte, error) {
+	zipped, err := base64.StdEncoding.DecodeString(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(zipped), int64(len(zipped)))
+	if err != nil {
+		return nil, err
+	}
+	if len(reader.File) != 1 {
+		return nil, fmt.Errorf("archive does not contain exactly one file")
+	}
+
+	rc, err := reader.File[0].Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close() //nolint
+
+	return io.ReadAll(rc)
+}
+
+func findCallGraphFiles(path string, exclusions []string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.Walk(path, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		excluded := file.Excluded(exclusions, []string{}, p)
+		if info.IsDir() && excluded {
+			return filepath.SkipDir
+		}
+
+		if !info.IsDir() && !excluded && isCallGraphFile(info.Name()) {
+			files = append(files, p)
+		}
+
+		return nil
+	})
+
+	return files, err
+}
+
+func isCallGraphFile(name string) bool {
+	return strings.HasPrefix(name, callgraphName) && filepath.Ext(name) != ".zip"
+}
@@ -0,0 +1,133 @@
+package query
+
+import (
+	"archive/zip"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	callGraphFile  = "testdata/debricked-call-graph.golang"
+	vulnerableFile = "/go/pkg/mod/github.com/lib/decode.go"
+)
+
+// writePacked zips and base64 encodes the call graph, like the call graph jobs do
+func writePacked(t *testing.T, dir string) string {
+	content, err := os.ReadFile(callGraphFile)
+	assert.NoError(t, err)
+
+	zipPath := filepath.Join(dir, "callgraph.zip")
+	zipFile, err := os.Create(zipPath)
+	assert.NoError(t, err)
+	writer := zip.NewWriter(zipFile)
+	entry, err := writer.Create("debricked-call-graph.golang")
+	assert.NoError(t, err)
+	_, err = entry.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, writer.Close())
+	assert.NoError(t, zipFile.Close())
+
+	zipped, err := os.ReadFile(zipPath)
+	assert.NoError(t, err)
+	packedPath := filepath.Join(dir, "debricked-call-graph.golang")
+	assert.NoError(t, os.WriteFile(packedPath, []byte(base64.StdEncoding.EncodeToString(zipped)), 0600))
+
+	return packedPath
+}
+
+func TestLoad(t *testing.T) {
+	cg, err := Load(callGraphFile)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, cg.NodeCount())
+
+	packed, err := Load(writePacked(t, t.TempDir()))
+	assert.NoError(t, err)
+	assert.Equal(t, cg, packed)
+}
+
+func TestLoadErrors(t *testing.T) {
+	_, err := Load("testdata/missing")
+	assert.Error(t, err)
+
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "debricked-call-graph.java")
+	assert.NoError(t, os.WriteFile(invalid, []byte("not base64"), 0600))
+	_, err = Load(invalid)
+	assert.ErrorContains(t, err, "failed to read call graph")
+
+	assert.NoError(t, os.WriteFile(invalid, []byte(base64.StdEncoding.EncodeToString([]byte("not zip"))), 0600))
+	_, err = Load(invalid)
+	assert.ErrorContains(t, err, "failed to read call graph")
+
+	assert.NoError(t, os.WriteFile(invalid, []byte("{}}"), 0600))
+	_, err = Load(invalid)
+	assert.ErrorContains(t, err, "failed to parse call graph")
+}
+
+func TestQuery(t *testing.T) {
+	q := NewQuerier()
+	results, err := q.Query(DebrickedOptions{Path: callGraphFile, Symbol: "github.com/lib.parse", MaxPaths: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, []Result{{
+		File:      callGraphFile,
+		Symbol:    "github.com/lib.parse",
+		Found:     true,
+		Reachable: true,
+		Paths: []Path{{
+			{"example.com/app.run", "main.go", 12},
+			{"github.com/lib.Decode", vulnerableFile, 5},
+			{"github.com/lib.parse", vulnerableFile, 10},
+		}},
+	}}, results)
+}
+
+func TestQueryNotReachable(t *testing.T) {
+	q := NewQuerier()
+	results, err := q.Query(DebrickedOptions{Path: callGraphFile, Symbol: "github.com/lib.vulnerable", MaxPaths: 3})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.True(t, results[0].Found)
+	assert.False(t, results[0].Reachable)
+	assert.Empty(t, results[0].Paths)
+}
+
+func TestQueryNotFound(t *testing.T) {
+	q := NewQuerier()
+	results, err := q.Query(DebrickedOptions{Path: callGraphFile, Symbol: "github.com/lib.Missing", MaxPaths: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, []Result{{File: callGraphFile, Symbol: "github.com/lib.Missing", Paths: []Path{}}}, results)
+}
+
+func TestQueryDirectory(t *testing.T) {
+	dir := t.TempDir()
+	writePacked(t, dir)
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "node_modules"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "node_modules", "debricked-call-graph.javascript"), []byte("invalid"), 0600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "debricked-call-graph.golang.zip"), []byte("invalid"), 0600))
+
+	q := NewQuerier()
+	results, err := q.Query(DebrickedOptions{Path: dir, Symbol: "Decode", MaxPaths: 3, Exclusions: []string{"**/node_modules/**"}})
+	assert.NoError(t, err)
+	assert.Len(t, results, 1)
+	assert.Equal(t, filepath.Join(dir, "debricked-call-graph.golang"), results[0].File)
+	assert.Equal(t, "github.com/lib.Decode", results[0].Symbol)
+	assert.True(t, results[0].Reachable)
+}
+
+func TestQueryErrors(t *testing.T) {
+	q := NewQuerier()
+	_, err := q.Query(DebrickedOptions{Path: "testdata/missing", Symbol: "a"})
+	assert.Error(t, err)
+
+	_, err = q.Query(DebrickedOptions{Path: t.TempDir(), Symbol: "a"})
+	assert.ErrorContains(t, err, "no call graph found")
+
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "debricked-call-graph.java"), []byte("invalid"), 0600))
+	_, err = q.Query(DebrickedOptions{Path: dir, Symbol: "a"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,88 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/debricked/cli/internal/callgraph/model"
+)
+
+// Step is a function of a call path, Line is the line of the call to the next step,
+// or the first line of the function for the last step
+type Step struct {
+	Symbol   string `json:"symbol"`
+	Filename string `json:"filename"`
+	Line     int    `json:"line"`
+}
+
+type Path []Step
+
+type hop struct {
+	callee   *model.Node
+	callLine int
+}
+
+// FindNodes returns the node of symbol, or the nodes whose symbol ends with .symbol if there is no such node
+func FindNodes(cg *model.CallGraph, symbol string) []*model.Node {
+	if node := cg.GetNode(symbol); node != nil {
+		return []*model.Node{node}
+	}
+
+	var nodes []*model.Node
+	for s, node := range cg.Nodes {
+		if strings.HasSuffix(s, "."+symbol) {
+			nodes = append(nodes, node)
+		}
+	}
+
+	return nodes
+}
+
+// Reachability reports whether target is application code or called from it, with up to maxPaths
+// shortest call paths. Each path starts in the closest application function calling target.
+// As the graph is stored child -> parent, the search walks from target towards its callers,
+// visiting each node at most once.
+func Reachability(target *model.Node, maxPaths int) (bool, []Path) {
+	if target.IsApplicationNode {
+		return true, []Path{{targetStep(target)}}
+	}
+
+	hops := map[*model.Node]hop{target: {}}
+	var callers []*model.Node
+	queue := []*model.Node{target}
+	for len(queue) > 0 && len(callers) < max(maxPaths, 1) {
+		node := queue[0]
+		queue = queue[1:]
+		for _, edge := range node.Parents {
+			if _, visited := hops[edge.Parent]; visited {
+				continue
+			}
+			hops[edge.Parent] = hop{callee: node, callLine: edge.CallLine}
+			// Callers of application functions are left out, the closest application function is enough
+			if edge.Parent.IsApplicationNode {
+				callers = append(callers, edge.Parent)
+			} else {
+				queue = append(queue, edge.Parent)
+			}
+		}
+	}
+
+	paths := make([]Path, 0, len(callers))
+	for _, caller := range callers[:min(len(callers), max(maxPaths, 0))] {
+		paths = append(paths, path(caller, target, hops))
+	}
+
+	return len(callers) > 0, paths
+}
+
+func path(caller *model.Node, target *model.Node, hops map[*model.Node]hop) Path {
+	var p Path
+	for node := caller; node != target; node = hops[node].callee {
+		p = append(p, Step{Symbol: node.Symbol, Filename: node.Filename, Line: hops[node].callLine})
+	}
+
+	return append(p, targetStep(target))
+}
+
+func targetStep(target *model.Node) Step {
+	return Step{Symbol: target.Symbol, Filename: target.Filename, Line: target.LineStart}
+}
@@ -0,0 +1,74 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/debricked/cli/internal/callgraph/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCallGraph() *model.CallGraph {
+	cg := model.NewCallGraph()
+	main := cg.AddNode("main.go", "main", "app.main", true, false, 1, 10)
+	other := cg.AddNode("other.go", "other", "app.other", true, false, 1, 20)
+	a := cg.AddNode("lib/a.go", "a", "lib.a", false, false, 1, 5)
+	b := cg.AddNode("lib/b.go", "b", "lib.b", false, false, 1, 8)
+	c := cg.AddNode("lib/c.go", "c", "lib.c", false, false, 1, 8)
+	vuln := cg.AddNode("lib/vuln.go", "vuln", "lib.vuln", false, false, 4, 9)
+	unused := cg.AddNode("lib/unused.go", "unused", "lib.unused", false, false, 1, 3)
+	vuln2 := cg.AddNode("lib/vuln.go", "vuln2", "lib.vuln2", false, false, 11, 13)
+	cg.AddEdge(main, a, 3)
+	cg.AddEdge(a, b, 2)
+	cg.AddEdge(b, vuln, 7)
+	cg.AddEdge(other, vuln, 12)
+	cg.AddEdge(main, c, 5)
+	cg.AddEdge(c, c, 6)
+	cg.AddEdge(c, b, 7)
+	cg.AddEdge(unused, vuln2, 2)
+	cg.AddEdge(vuln2, vuln2, 12)
+
+	return cg
+}
+
+func TestReachability(t *testing.T) {
+	cg := newCallGraph()
+	reachable, paths := Reachability(cg.GetNode("lib.vuln"), 5)
+	assert.True(t, reachable)
+	assert.Equal(t, []Path{
+		{{"app.other", "other.go", 12}, {"lib.vuln", "lib/vuln.go", 4}},
+		{{"app.main", "main.go", 3}, {"lib.a", "lib/a.go", 2}, {"lib.b", "lib/b.go", 7}, {"lib.vuln", "lib/vuln.go", 4}},
+	}, paths)
+}
+
+func TestReachabilityMaxPaths(t *testing.T) {
+	cg := newCallGraph()
+	reachable, paths := Reachability(cg.GetNode("lib.vuln"), 1)
+	assert.True(t, reachable)
+	assert.Equal(t, []Path{{{"app.other", "other.go", 12}, {"lib.vuln", "lib/vuln.go", 4}}}, paths)
+
+	reachable, paths = Reachability(cg.GetNode("lib.vuln"), 0)
+	assert.True(t, reachable)
+	assert.Empty(t, paths)
+}
+
+func TestReachabilityNotReachable(t *testing.T) {
+	cg := newCallGraph()
+	reachable, paths := Reachability(cg.GetNode("lib.vuln2"), 5)
+	assert.False(t, reachable)
+	assert.Empty(t, paths)
+}
+
+func TestReachabilityApplicationNode(t *testing.T) {
+	cg := newCallGraph()
+	reachable, paths := Reachability(cg.GetNode("app.main"), 5)
+	assert.True(t, reachable)
+	assert.Equal(t, []Path{{{"app.main", "main.go", 1}}}, paths)
+}
+
+func TestFindNodes(t *testing.T) {
+	cg := newCallGraph()
+	assert.Equal(t, []*model.Node{cg.GetNode("lib.vuln")}, FindNodes(cg, "lib.vuln"))
+	assert.Equal(t, []*model.Node{cg.GetNode("lib.vuln2")}, FindNodes(cg, "vuln2"))
+	assert.Empty(t, FindNodes(cg, "uln2"))
+	assert.Empty(t, FindNodes(cg, "missing"))
+}
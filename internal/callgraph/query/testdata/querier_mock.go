@@ -0,0 +1,17 @@
+package testdata
+
+import (
+	"github.com/debricked/cli/internal/callgraph/query"
+)
+
+type QuerierMock struct {
+	Results []query.Result
+	Err     error
+	Options query.DebrickedOptions
+}
+
+func (q *QuerierMock) Query(options query.DebrickedOptions) ([]query.Result, error) {
+	q.Options = options
+
+	return q.Results, q.Err
+}
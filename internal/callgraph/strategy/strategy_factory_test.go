@@ -5,7 +5,11 @@ import (
 
 	"github.com/debricked/cli/internal/callgraph/config"
 	"github.com/debricked/cli/internal/callgraph/finder/javafinder"
+	"github.com/debricked/cli/internal/callgraph/finder/javascriptfinder"
+	"github.com/debricked/cli/internal/callgraph/finder/pythonfinder"
 	"github.com/debricked/cli/internal/callgraph/language/java"
+	"github.com/debricked/cli/internal/callgraph/language/javascript"
+	"github.com/debricked/cli/internal/callgraph/language/python"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -36,3 +40,19 @@ func TestMake(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeJavaScript(t *testing.T) {
+	conf := config.NewConfig(javascript.Name, nil, nil, true, "npm", "")
+	f := NewStrategyFactory()
+	s, err := f.Make(conf, []string{"."}, []string{}, []string{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, javascript.NewStrategy(conf, []string{"."}, []string{}, []string{}, javascriptfinder.JavaScriptFinder{}, nil), s)
+}
+
+func TestMakePython(t *testing.T) {
+	conf := config.NewConfig(python.Name, nil, nil, true, "pip", "")
+	f := NewStrategyFactory()
+	s, err := f.Make(conf, []string{"."}, []string{}, []string{}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, python.NewStrategy(conf, []string{"."}, []string{}, []string{}, pythonfinder.PythonFinder{}, nil), s)
+}
@@ -9,11 +9,14 @@ import (
 )
 
 type GeneratorMock struct {
-	Err   error
-	files []string
+	Err     error
+	Options callgraph.DebrickedOptions
+	files   []string
 }
 
-func (r *GeneratorMock) GenerateWithTimer(_ callgraph.DebrickedOptions) error {
+func (r *GeneratorMock) GenerateWithTimer(options callgraph.DebrickedOptions) error {
+	r.Options = options
+
 	return r.Err
 }
 
@@ -9,6 +9,9 @@ import (
 	"github.com/debricked/cli/internal/callgraph"
 	cg "github.com/debricked/cli/internal/callgraph"
 	conf "github.com/debricked/cli/internal/callgraph/config"
+	"github.com/debricked/cli/internal/callgraph/language/golang"
+	"github.com/debricked/cli/internal/callgraph/query"
+	queryCmd "github.com/debricked/cli/internal/cmd/callgraph/query"
 	"github.com/debricked/cli/internal/file"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -20,10 +23,15 @@ var (
 	buildDisabled      bool
 	generateTimeout    int
 	languages          string
-	supportedLanguages = []string{"java", "golang"}
+	goAlgorithm        string
+	goTestsDisabled    bool
+	goEntryPoints      []string
+	supportedLanguages = []string{"java", "golang", "javascript", "python"}
 	languageMap        = map[string]string{
-		"java":   "maven",
-		"golang": "go",
+		"java":       "maven",
+		"golang":     "go",
+		"javascript": "npm",
+		"python":     "pip",
 	}
 )
 
@@ -33,9 +41,12 @@ const (
 	NoBuildFlag         = "no-build"
 	GenerateTimeoutFlag = "generate-timeout"
 	LanguagesFlag       = "languages"
+	GoAlgorithmFlag     = "go-algorithm"
+	GoNoTestsFlag       = "go-no-tests"
+	GoEntryPointsFlag   = "go-entry-points"
 )
 
-func NewCallgraphCmd(generator cg.IGenerator) *cobra.Command {
+func NewCallgraphCmd(generator cg.IGenerator, querier query.IQuerier) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "callgraph [path]",
 		Short: "Generate a static call graph for the given directory and subdirectories",
@@ -47,6 +58,8 @@ The full documentation is available here https://docs.debricked.com/tools-and-in
 
 Example:
 $ debricked callgraph 
+
+Use debricked callgraph query to check whether a symbol is reachable in the generated call graphs.
 `,
 		PreRun: func(cmd *cobra.Command, _ []string) {
 			_ = viper.BindPFlags(cmd.Flags())
@@ -82,9 +95,18 @@ This option requires a pre-built project. For more detailed documentation on the
 https://docs.debricked.com/tools-and-integrations/cli/debricked-cli#callgraph`)
 	cmd.Flags().IntVar(&generateTimeout, GenerateTimeoutFlag, 60*60, "Timeout (in seconds) on call graph generation.")
 	cmd.Flags().StringVarP(&languages, LanguagesFlag, "l", strings.Join(supportedLanguages, ","), "Colon separated list of languages to create a call graph for.")
+	cmd.Flags().StringVar(&goAlgorithm, GoAlgorithmFlag, golang.AlgorithmCHA, `Algorithm used for Go call graphs, one of `+strings.Join(golang.Algorithms, ", ")+`.
+"rta" and "vta" are more precise, "rta" only includes functions reachable from the entry points.`)
+	cmd.Flags().BoolVar(&goTestsDisabled, GoNoTestsFlag, false, "Do not include test packages in Go call graphs.")
+	cmd.Flags().StringSliceVar(&goEntryPoints, GoEntryPointsFlag, []string{}, `Comma separated list of packages, functions or methods used as entry points for Go call graphs,
+instead of the main packages. Packages without a main package are otherwise rooted in their exported API.
+Example:
+$ debricked callgraph . --go-algorithm rta --go-entry-points github.com/org/repo/pkg.Run,github.com/org/repo/pkg.Client.Do`)
 
 	viper.MustBindEnv(ExclusionFlag)
 
+	cmd.AddCommand(queryCmd.NewQueryCmd(querier))
+
 	return cmd
 }
 
@@ -115,13 +137,34 @@ func isSupportedLanguage(language string) bool {
 	return false
 }
 
+func languageKwargs(language string) (map[string]string, error) {
+	if language != "golang" {
+		return map[string]string{}, nil
+	}
+
+	algorithm := viper.GetString(GoAlgorithmFlag)
+	if algorithm == "" {
+		algorithm = golang.AlgorithmCHA
+	}
+	if err := golang.ValidateAlgorithm(algorithm); err != nil {
+		return nil, err
+	}
+	options := golang.Options{
+		Algorithm:    algorithm,
+		IncludeTests: !viper.GetBool(GoNoTestsFlag),
+		EntryPoints:  viper.GetStringSlice(GoEntryPointsFlag),
+	}
+
+	return options.Kwargs(), nil
+}
+
 func RunE(callgraph callgraph.IGenerator) func(_ *cobra.Command, args []string) error {
 	return func(_ *cobra.Command, args []string) error {
 		if len(args) == 0 {
 			args = append(args, ".")
 		}
 
-		languages, err := parseAndValidateLanguages(languages)
+		languages, err := parseAndValidateLanguages(viper.GetString(LanguagesFlag))
 		if err != nil {
 			return err
 		}
@@ -130,7 +173,11 @@ func RunE(callgraph callgraph.IGenerator) func(_ *cobra.Command, args []string)
 		version := viper.GetString("cliVersion")
 
 		for _, language := range languages {
-			configs = append(configs, conf.NewConfig(language, args, map[string]string{}, !buildDisabled, languageMap[language], version))
+			kwargs, err := languageKwargs(language)
+			if err != nil {
+				return err
+			}
+			configs = append(configs, conf.NewConfig(language, args, kwargs, !viper.GetBool(NoBuildFlag), languageMap[language], version))
 		}
 
 		options := cg.DebrickedOptions{
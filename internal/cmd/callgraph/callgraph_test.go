@@ -2,21 +2,27 @@ package callgraph
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/debricked/cli/internal/callgraph"
+	queryTestdata "github.com/debricked/cli/internal/callgraph/query/testdata"
 	callgraphTestdata "github.com/debricked/cli/internal/callgraph/testdata"
+	cmdConfig "github.com/debricked/cli/internal/cmd/config"
+	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestNewCallgraphCmd(t *testing.T) {
 	var callgraphGenerator callgraph.IGenerator
-	cmd := NewCallgraphCmd(callgraphGenerator)
+	cmd := NewCallgraphCmd(callgraphGenerator, &queryTestdata.QuerierMock{})
 
 	commands := cmd.Commands()
-	nbrOfCommands := 0
+	nbrOfCommands := 1
 	assert.Len(t, commands, nbrOfCommands)
+	assert.Equal(t, "query", commands[0].Name())
 
 	flags := cmd.Flags()
 	flagAssertions := map[string]string{
@@ -24,6 +30,9 @@ func TestNewCallgraphCmd(t *testing.T) {
 		InclusionFlag:       "",
 		NoBuildFlag:         "",
 		GenerateTimeoutFlag: "",
+		GoAlgorithmFlag:     "",
+		GoNoTestsFlag:       "",
+		GoEntryPointsFlag:   "",
 	}
 	for name, shorthand := range flagAssertions {
 		flag := flags.Lookup(name)
@@ -74,7 +83,8 @@ func TestRunEError(t *testing.T) {
 
 	assert.EqualError(t, err, "finder-error", "error doesn't match expected")
 
-	languages = "python2"
+	viper.Set(LanguagesFlag, "python2")
+	defer viper.Set(LanguagesFlag, "")
 
 	g2 := &callgraphTestdata.GeneratorMock{}
 	runE2 := RunE(g2)
@@ -83,6 +93,63 @@ func TestRunEError(t *testing.T) {
 
 }
 
+func TestRunEInvalidGoAlgorithm(t *testing.T) {
+	viper.Set(LanguagesFlag, "golang")
+	viper.Set(GoAlgorithmFlag, "pointer")
+	defer func() {
+		viper.Set(LanguagesFlag, "")
+		viper.Set(GoAlgorithmFlag, "")
+	}()
+
+	g := &callgraphTestdata.GeneratorMock{}
+	err := RunE(g)(nil, []string{"."})
+	assert.ErrorContains(t, err, "pointer is not a supported algorithm")
+}
+
+func TestRunEProjectConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0o755))
+	content := "commands:\n  callgraph:\n    languages: golang,python\n    no-build: true\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".debricked.yaml"), []byte(content), 0o600))
+	g := &callgraphTestdata.GeneratorMock{}
+	rootCmd := &cobra.Command{Use: "debricked", PersistentPreRunE: cmdConfig.ApplyProjectConfig}
+	rootCmd.AddCommand(NewCallgraphCmd(g, &queryTestdata.QuerierMock{}))
+	rootCmd.SetArgs([]string{"callgraph", dir})
+
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	assert.Len(t, g.Options.Configs, 2)
+	for i, language := range []string{"golang", "python"} {
+		assert.Equal(t, language, g.Options.Configs[i].Language())
+		assert.False(t, g.Options.Configs[i].Build())
+	}
+}
+
+func TestLanguageKwargs(t *testing.T) {
+	kwargs, err := languageKwargs("java")
+	assert.NoError(t, err)
+	assert.Empty(t, kwargs)
+
+	kwargs, err = languageKwargs("golang")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"algorithm": "cha", "tests": "true", "entryPoints": ""}, kwargs)
+
+	viper.Set(GoAlgorithmFlag, "rta")
+	viper.Set(GoNoTestsFlag, true)
+	viper.Set(GoEntryPointsFlag, []string{"example.com/a", "example.com/b.Run"})
+	defer func() {
+		viper.Set(GoAlgorithmFlag, "")
+		viper.Set(GoNoTestsFlag, false)
+		viper.Set(GoEntryPointsFlag, []string{})
+	}()
+	kwargs, err = languageKwargs("golang")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"algorithm": "rta", "tests": "false", "entryPoints": "example.com/a,example.com/b.Run"}, kwargs)
+}
+
 func TestParseAndValidateLanguages(t *testing.T) {
 	languages := "java,golang"
 	parsedLanguages, err := parseAndValidateLanguages(languages)
@@ -94,7 +161,19 @@ func TestParseAndValidateLanguages(t *testing.T) {
 	parsedLanguages, err = parseAndValidateLanguages(languages)
 
 	assert.Nil(t, err)
-	assert.Equal(t, []string{"java", "golang"}, parsedLanguages)
+	assert.Equal(t, []string{"java", "golang", "javascript", "python"}, parsedLanguages)
+
+	languages = "javascript"
+	parsedLanguages, err = parseAndValidateLanguages(languages)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"javascript"}, parsedLanguages)
+
+	languages = "python"
+	parsedLanguages, err = parseAndValidateLanguages(languages)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"python"}, parsedLanguages)
 
 	languages = "java,golang,python2"
 	_, err = parseAndValidateLanguages(languages)
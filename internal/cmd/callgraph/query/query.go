@@ -0,0 +1,110 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/debricked/cli/internal/callgraph/query"
+	"github.com/debricked/cli/internal/file"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	exclusions = file.DefaultExclusions()
+	symbol     string
+	maxPaths   int
+	jsonPrint  bool
+)
+
+const (
+	ExclusionFlag = "exclusion"
+	SymbolFlag    = "symbol"
+	PathsFlag     = "paths"
+	JsonFlag      = "json"
+)
+
+func NewQueryCmd(querier query.IQuerier) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query [path]",
+		Short: "Check whether a symbol is reachable in generated call graphs",
+		Long: `Check whether a symbol is reachable from application code in the call graphs generated by debricked callgraph.
+The path is a call graph file, or a directory searched for call graph files. For each call graph, the shortest call paths
+from the closest application functions to the symbol are printed.
+
+Symbols are fully qualified, such as github.com/org/repo/pkg.Decode, com.example.Parser.parse or yaml.load.
+If no symbol matches exactly, all symbols ending with "." followed by the given symbol are queried.
+
+Example:
+$ debricked callgraph query . --symbol github.com/org/repo/pkg.Decode
+`,
+		Args: cobra.MaximumNArgs(1),
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			_ = viper.BindPFlags(cmd.Flags())
+		},
+		RunE: RunE(querier),
+	}
+	cmd.Flags().StringArrayVarP(&exclusions, ExclusionFlag, "e", exclusions, "Paths to exclude when searching for call graph files, see debricked callgraph --help for supported terms.")
+	cmd.Flags().StringVarP(&symbol, SymbolFlag, "s", "", "Fully qualified name of the symbol to query.")
+	cmd.Flags().IntVarP(&maxPaths, PathsFlag, "p", 3, "Maximum number of call paths printed per symbol.")
+	cmd.Flags().BoolVarP(&jsonPrint, JsonFlag, "j", false, "Print the results in JSON format.")
+
+	return cmd
+}
+
+func RunE(q query.IQuerier) func(_ *cobra.Command, args []string) error {
+	return func(_ *cobra.Command, args []string) error {
+		path := "."
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		if viper.GetString(SymbolFlag) == "" {
+			return errors.New("a symbol is required, use --" + SymbolFlag)
+		}
+
+		results, err := q.Query(query.DebrickedOptions{
+			Path:       path,
+			Symbol:     viper.GetString(SymbolFlag),
+			MaxPaths:   viper.GetInt(PathsFlag),
+			Exclusions: viper.GetStringSlice(ExclusionFlag),
+		})
+		if err != nil {
+			return err
+		}
+
+		if viper.GetBool(JsonFlag) {
+			jsonResults, _ := json.Marshal(results)
+			fmt.Println(string(jsonResults))
+		} else {
+			for _, result := range results {
+				printResult(result)
+			}
+		}
+
+		return nil
+	}
+}
+
+func printResult(result query.Result) {
+	switch {
+	case !result.Found:
+		fmt.Printf("%s: %s was not found\n", result.File, result.Symbol)
+	case !result.Reachable:
+		fmt.Printf("%s: %s is not reachable from application code\n", result.File, result.Symbol)
+	default:
+		fmt.Printf("%s: %s is reachable from application code\n", result.File, result.Symbol)
+	}
+
+	for i, path := range result.Paths {
+		fmt.Printf("  Path %d:\n", i+1)
+		for j, step := range path {
+			arrow := "  "
+			if j > 0 {
+				arrow = "->"
+			}
+			fmt.Printf("    %s %s (%s:%d)\n", arrow, step.Symbol, step.Filename, step.Line)
+		}
+	}
+}
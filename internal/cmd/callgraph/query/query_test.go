@@ -0,0 +1,112 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/debricked/cli/internal/callgraph/query"
+	"github.com/debricked/cli/internal/callgraph/query/testdata"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+var results = []query.Result{
+	{
+		File:      "debricked-call-graph.golang",
+		Symbol:    "github.com/lib.Decode",
+		Found:     true,
+		Reachable: true,
+		Paths: []query.Path{{
+			{Symbol: "example.com/app.main", Filename: "main.go", Line: 7},
+			{Symbol: "github.com/lib.Decode", Filename: "decode.go", Line: 3},
+		}},
+	},
+	{File: "debricked-call-graph.java", Symbol: "github.com/lib.Decode", Paths: []query.Path{}},
+	{File: "debricked-call-graph.python", Symbol: "lib.decode", Found: true, Paths: []query.Path{}},
+}
+
+func runAndCapture(t *testing.T, q query.IQuerier, args []string) string {
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := RunE(q)(nil, args)
+	assert.NoError(t, err)
+
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	return string(output)
+}
+
+func TestNewQueryCmd(t *testing.T) {
+	cmd := NewQueryCmd(&testdata.QuerierMock{})
+
+	flags := cmd.Flags()
+	flagAssertions := map[string]string{
+		ExclusionFlag: "e",
+		SymbolFlag:    "s",
+		PathsFlag:     "p",
+		JsonFlag:      "j",
+	}
+	for name, shorthand := range flagAssertions {
+		flag := flags.Lookup(name)
+		assert.NotNil(t, flag)
+		assert.Equal(t, shorthand, flag.Shorthand)
+	}
+}
+
+func TestRunE(t *testing.T) {
+	q := &testdata.QuerierMock{Results: results}
+	viper.Set(SymbolFlag, "github.com/lib.Decode")
+	viper.Set(PathsFlag, 2)
+	viper.Set(ExclusionFlag, []string{"**/vendor/**"})
+	defer viper.Reset()
+
+	output := runAndCapture(t, q, []string{"callgraphs"})
+
+	assert.Equal(t, query.DebrickedOptions{Path: "callgraphs", Symbol: "github.com/lib.Decode", MaxPaths: 2, Exclusions: []string{"**/vendor/**"}}, q.Options)
+	assert.Equal(t, `debricked-call-graph.golang: github.com/lib.Decode is reachable from application code
+  Path 1:
+       example.com/app.main (main.go:7)
+    -> github.com/lib.Decode (decode.go:3)
+debricked-call-graph.java: github.com/lib.Decode was not found
+debricked-call-graph.python: lib.decode is not reachable from application code
+`, output)
+}
+
+func TestRunEJson(t *testing.T) {
+	q := &testdata.QuerierMock{Results: results}
+	viper.Set(SymbolFlag, "github.com/lib.Decode")
+	viper.Set(JsonFlag, true)
+	defer viper.Reset()
+
+	output := runAndCapture(t, q, []string{})
+
+	assert.Equal(t, ".", q.Options.Path)
+	resultsJson, _ := json.Marshal(results)
+	assert.JSONEq(t, string(resultsJson), output)
+}
+
+func TestRunENoSymbol(t *testing.T) {
+	viper.Set(SymbolFlag, "")
+	err := RunE(&testdata.QuerierMock{})(nil, []string{"."})
+	assert.ErrorContains(t, err, "a symbol is required")
+}
+
+func TestRunEError(t *testing.T) {
+	viper.Set(SymbolFlag, "a")
+	defer viper.Reset()
+	q := &testdata.QuerierMock{Err: errors.New("query-error")}
+	err := RunE(q)(nil, []string{"."})
+	assert.EqualError(t, err, "query-error")
+}
+
+func TestPreRun(t *testing.T) {
+	cmd := NewQueryCmd(nil)
+	cmd.PreRun(cmd, nil)
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"github.com/debricked/cli/internal/cmd/config/show"
+	"github.com/debricked/cli/internal/cmd/config/validate"
+	"github.com/debricked/cli/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+func NewConfigCmd(validator config.IValidator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Work with debricked-config.yaml and " + config.ProjectConfigFileName,
+		Long:  "Work with debricked-config.yaml and " + config.ProjectConfigFileName,
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			_ = viper.BindPFlags(cmd.Flags())
+		},
+	}
+
+	cmd.AddCommand(validate.NewValidateCmd(validator))
+	cmd.AddCommand(show.NewShowCmd())
+
+	return cmd
+}
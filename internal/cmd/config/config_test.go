@@ -0,0 +1,21 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/debricked/cli/internal/config"
+	"github.com/debricked/cli/internal/file/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewConfigCmd(t *testing.T) {
+	cmd := NewConfigCmd(config.NewValidator(testdata.NewFinderMock()))
+	commands := cmd.Commands()
+	nbrOfCommands := 2
+	assert.Lenf(t, commands, nbrOfCommands, "failed to assert that there were %d sub commands connected", nbrOfCommands)
+}
+
+func TestPreRun(t *testing.T) {
+	cmd := NewConfigCmd(nil)
+	cmd.PreRun(cmd, nil)
+}
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"os"
+
+	"github.com/debricked/cli/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// ApplyProjectConfig merges the values of the project config found from the path argument of cmd, or the working
+// directory, into viper. Viper only uses them for flags that are neither set nor given through the environment.
+func ApplyProjectConfig(cmd *cobra.Command, args []string) error {
+	rootPath := "."
+	if len(args) > 0 {
+		if info, err := os.Stat(args[0]); err == nil && info.IsDir() {
+			rootPath = args[0]
+		}
+	}
+	path := config.FindProjectConfig(rootPath)
+	if path == "" {
+		return nil
+	}
+	projectConfig, err := config.LoadProjectConfig(path)
+	if err != nil {
+		return err
+	}
+
+	return viper.MergeConfigMap(projectConfig.CommandValues(cmd.CommandPath()))
+}
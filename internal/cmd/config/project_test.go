@@ -0,0 +1,47 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyProjectConfig(t *testing.T) {
+	dir := t.TempDir()
+	content := "project-config-global: global\ncommands:\n  scan:\n    project-config-scan: 5\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, config.ProjectConfigFileName), []byte(content), 0600))
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0755))
+	rootCmd := &cobra.Command{Use: "debricked"}
+	scanCmd := &cobra.Command{Use: "scan"}
+	rootCmd.AddCommand(scanCmd)
+
+	err := ApplyProjectConfig(scanCmd, []string{dir})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "global", viper.GetString("project-config-global"))
+	assert.Equal(t, 5, viper.GetInt("project-config-scan"))
+}
+
+func TestApplyProjectConfigInvalid(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, config.ProjectConfigFileName), []byte("commands: scan"), 0600))
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0755))
+
+	err := ApplyProjectConfig(&cobra.Command{Use: "scan"}, []string{dir})
+
+	assert.ErrorContains(t, err, "commands must be a mapping")
+}
+
+func TestApplyProjectConfigNotFound(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0755))
+
+	err := ApplyProjectConfig(&cobra.Command{Use: "scan"}, []string{dir})
+
+	assert.NoError(t, err)
+}
@@ -0,0 +1,135 @@
+package show
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/config"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+var path string
+
+const (
+	PathFlag = "path"
+
+	SourceEnv     = "env"
+	SourceFile    = "file"
+	SourceDefault = "default"
+
+	envPrefix = "DEBRICKED_"
+)
+
+// secretFlags are never printed
+var secretFlags = map[string]bool{"access-token": true, "token": true}
+
+func NewShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show [command]",
+		Short: "Print the effective configuration of a command",
+		Long: `Print the value of every flag of a command, and where the value comes from.
+Values are set, in order of precedence, by:
+  1. flags
+  2. environment variables, such as DEBRICKED_EXCLUSION
+  3. the ` + config.ProjectConfigFileName + ` file found in the scanned directory or its parents
+  4. flag defaults
+
+Example:
+$ debricked config show files find`,
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			_ = viper.BindPFlags(cmd.Flags())
+		},
+		RunE: RunE,
+	}
+
+	cmd.Flags().StringVar(&path, PathFlag, ".", "The directory to search for "+config.ProjectConfigFileName)
+
+	return cmd
+}
+
+// Setting is the effective value of a flag
+type Setting struct {
+	Flag   string
+	Value  string
+	Source string
+}
+
+func RunE(cmd *cobra.Command, args []string) error {
+	target, _, err := cmd.Root().Find(args)
+	if err != nil {
+		return err
+	}
+	rootPath := viper.GetString(PathFlag)
+	if rootPath == "" {
+		rootPath = "."
+	}
+	values := map[string]interface{}{}
+	projectConfigPath := config.FindProjectConfig(rootPath)
+	if projectConfigPath == "" {
+		fmt.Printf("No %s found in %s or its parent directories\n", config.ProjectConfigFileName, rootPath)
+	} else {
+		projectConfig, err := config.LoadProjectConfig(projectConfigPath)
+		if err != nil {
+			return err
+		}
+		values = projectConfig.CommandValues(target.CommandPath())
+		fmt.Printf("Configuration file: %s\n", color.YellowString(projectConfigPath))
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(os.Stdout)
+	t.SetStyle(table.StyleRounded)
+	t.AppendHeader(table.Row{"Flag", "Value", "Source"})
+	for _, setting := range Settings(target, values) {
+		t.AppendRow(table.Row{setting.Flag, setting.Value, setting.Source})
+	}
+	t.Render()
+
+	return nil
+}
+
+// Settings resolves the value of every flag of cmd from the environment, values and the flag defaults
+func Settings(cmd *cobra.Command, values map[string]interface{}) []Setting {
+	var settings []Setting
+	visit := func(flag *pflag.Flag) {
+		if flag.Hidden || flag.Name == "help" {
+			return
+		}
+		setting := Setting{Flag: flag.Name, Value: flag.DefValue, Source: SourceDefault}
+		if env, ok := os.LookupEnv(envPrefix + strings.ToUpper(flag.Name)); ok {
+			setting.Value, setting.Source = env, SourceEnv
+		} else if value, ok := values[flag.Name]; ok {
+			setting.Value, setting.Source = formatValue(value), SourceFile
+		}
+		if secretFlags[flag.Name] && setting.Value != "" {
+			setting.Value = "********"
+		}
+		settings = append(settings, setting)
+	}
+	cmd.LocalFlags().VisitAll(visit)
+	cmd.InheritedFlags().VisitAll(visit)
+	sort.SliceStable(settings, func(i, j int) bool {
+		return settings[i].Flag < settings[j].Flag
+	})
+
+	return settings
+}
+
+func formatValue(value interface{}) string {
+	if list, ok := value.([]interface{}); ok {
+		items := make([]string, 0, len(list))
+		for _, item := range list {
+			items = append(items, fmt.Sprint(item))
+		}
+
+		return "[" + strings.Join(items, ",") + "]"
+	}
+
+	return fmt.Sprint(value)
+}
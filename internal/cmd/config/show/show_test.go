@@ -0,0 +1,111 @@
+package show
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func newCommandTree() (*cobra.Command, *cobra.Command) {
+	rootCmd := &cobra.Command{Use: "debricked"}
+	rootCmd.PersistentFlags().String("access-token", "", "token")
+	scanCmd := &cobra.Command{Use: "scan"}
+	scanCmd.Flags().StringArray("exclusion", []string{"**/node_modules/**"}, "exclusions")
+	scanCmd.Flags().Int("poll-interval", 1, "interval")
+	scanCmd.Flags().Bool("verbose", true, "verbose")
+	rootCmd.AddCommand(scanCmd)
+	showCmd := NewShowCmd()
+	rootCmd.AddCommand(showCmd)
+
+	return scanCmd, showCmd
+}
+
+func TestNewShowCmd(t *testing.T) {
+	cmd := NewShowCmd()
+
+	flag := cmd.Flags().Lookup(PathFlag)
+	assert.NotNil(t, flag)
+	assert.Equal(t, ".", flag.DefValue)
+}
+
+func TestSettings(t *testing.T) {
+	scanCmd, _ := newCommandTree()
+	t.Setenv("DEBRICKED_VERBOSE", "false")
+	t.Setenv("DEBRICKED_ACCESS-TOKEN", "secret")
+
+	settings := Settings(scanCmd, map[string]interface{}{
+		"exclusion":     []interface{}{"**/vendor/**", "**/dist/**"},
+		"poll-interval": 5,
+		"verbose":       true,
+	})
+
+	assert.Equal(t, []Setting{
+		{Flag: "access-token", Value: "********", Source: SourceEnv},
+		{Flag: "exclusion", Value: "[**/vendor/**,**/dist/**]", Source: SourceFile},
+		{Flag: "poll-interval", Value: "5", Source: SourceFile},
+		{Flag: "verbose", Value: "false", Source: SourceEnv},
+	}, settings)
+}
+
+func TestSettingsDefaults(t *testing.T) {
+	scanCmd, _ := newCommandTree()
+
+	settings := Settings(scanCmd, map[string]interface{}{})
+
+	assert.Equal(t, []Setting{
+		{Flag: "access-token", Value: "", Source: SourceDefault},
+		{Flag: "exclusion", Value: "[**/node_modules/**]", Source: SourceDefault},
+		{Flag: "poll-interval", Value: "1", Source: SourceDefault},
+		{Flag: "verbose", Value: "true", Source: SourceDefault},
+	}, settings)
+}
+
+func TestRunE(t *testing.T) {
+	dir := t.TempDir()
+	content := "commands:\n  scan:\n    poll-interval: 5\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, config.ProjectConfigFileName), []byte(content), 0600))
+	_, showCmd := newCommandTree()
+	viper.Set(PathFlag, dir)
+	defer viper.Set(PathFlag, ".")
+
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := RunE(showCmd, []string{"scan"})
+
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), "Configuration file: "+filepath.Join(dir, config.ProjectConfigFileName))
+	assert.Regexp(t, `poll-interval\s+│ 5\s+│ file`, string(output))
+}
+
+func TestRunENoProjectConfig(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0755))
+	_, showCmd := newCommandTree()
+	viper.Set(PathFlag, dir)
+	defer viper.Set(PathFlag, ".")
+
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := RunE(showCmd, []string{"scan"})
+
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), "No .debricked.yaml found in "+dir)
+}
@@ -0,0 +1,86 @@
+package validate
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/debricked/cli/internal/config"
+	"github.com/debricked/cli/internal/file"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var InvalidConfigErr = errors.New("")
+
+var exclusions = file.Exclusions()
+var inclusions []string
+var failOnWarning bool
+var jsonPrint bool
+
+const (
+	ExclusionFlag     = "exclusion"
+	InclusionFlag     = "inclusion"
+	FailOnWarningFlag = "fail-on-warning"
+	JsonFlag          = "json"
+)
+
+func NewValidateCmd(validator config.IValidator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "validate [path]",
+		Short: "Validate debricked-config.yaml",
+		Long: `Validate debricked-config.yaml against the config schema, reporting problems with their line and column.
+Path is either the config file or a directory to search for it, and defaults to the working directory.
+The file regexes of overrides are matched against the dependency files found, listing the files each regex matches.
+
+Example:
+$ debricked config validate .`,
+		Args: cobra.MaximumNArgs(1),
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			_ = viper.BindPFlags(cmd.Flags())
+		},
+		RunE: RunE(validator),
+	}
+
+	cmd.Flags().StringArrayVarP(&exclusions, ExclusionFlag, "e", exclusions, `Exclude paths when searching for the config and dependency files.
+See "debricked files find --help" for supported terms.`)
+	cmd.Flags().StringArrayVar(&inclusions, InclusionFlag, []string{}, "Forces inclusion of specified terms, see exclusion flag for more information on supported terms.")
+	cmd.Flags().BoolVar(&failOnWarning, FailOnWarningFlag, false, "Fail on warnings, such as file regexes that match no dependency files, in addition to errors")
+	cmd.Flags().BoolVarP(&jsonPrint, JsonFlag, "j", false, "Print the validation report in JSON format")
+
+	return cmd
+}
+
+func RunE(validator config.IValidator) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		path := ""
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		report, err := validator.Validate(config.Options{
+			Path:       path,
+			Exclusions: viper.GetStringSlice(ExclusionFlag),
+			Inclusions: viper.GetStringSlice(InclusionFlag),
+		})
+		if err != nil {
+			return err
+		}
+
+		if viper.GetBool(JsonFlag) {
+			jsonReport, _ := json.MarshalIndent(report, "", "  ")
+			fmt.Println(string(jsonReport))
+		} else {
+			report.Render(os.Stdout, viper.GetBool(FailOnWarningFlag))
+		}
+		if !report.Valid(viper.GetBool(FailOnWarningFlag)) {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+
+			return InvalidConfigErr
+		}
+
+		return nil
+	}
+}
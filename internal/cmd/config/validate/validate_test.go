@@ -0,0 +1,89 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/debricked/cli/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+type validatorMock struct {
+	options config.Options
+	report  *config.Report
+	err     error
+}
+
+func (mock *validatorMock) Validate(options config.Options) (*config.Report, error) {
+	mock.options = options
+
+	return mock.report, mock.err
+}
+
+func TestNewValidateCmd(t *testing.T) {
+	cmd := NewValidateCmd(&validatorMock{})
+
+	flagAssertions := map[string]string{
+		ExclusionFlag:     "e",
+		InclusionFlag:     "",
+		FailOnWarningFlag: "",
+		JsonFlag:          "j",
+	}
+	for name, shorthand := range flagAssertions {
+		flag := cmd.Flags().Lookup(name)
+		assert.NotNilf(t, flag, "failed to assert that %s flag was set", name)
+		assert.Equalf(t, shorthand, flag.Shorthand, "failed to assert that %s flag shorthand %s was set correctly", name, shorthand)
+	}
+}
+
+func TestRunE(t *testing.T) {
+	mock := &validatorMock{report: &config.Report{ConfigPath: "debricked-config.yaml"}}
+	cmd := &cobra.Command{}
+
+	err := RunE(mock)(cmd, []string{"config/debricked-config.yaml"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "config/debricked-config.yaml", mock.options.Path)
+}
+
+func TestRunEInvalidConfig(t *testing.T) {
+	mock := &validatorMock{report: &config.Report{
+		ConfigPath: "debricked-config.yaml",
+		Issues:     []config.Issue{{Line: 1, Column: 1, Severity: config.SeverityError, Message: "config must be a mapping"}},
+	}}
+	cmd := &cobra.Command{}
+
+	err := RunE(mock)(cmd, nil)
+
+	assert.ErrorIs(t, err, InvalidConfigErr)
+	assert.True(t, cmd.SilenceUsage, "failed to assert that usage was silenced")
+	assert.True(t, cmd.SilenceErrors, "failed to assert that errors were silenced")
+}
+
+func TestRunEFailOnWarning(t *testing.T) {
+	mock := &validatorMock{report: &config.Report{
+		ConfigPath: "debricked-config.yaml",
+		Issues:     []config.Issue{{Line: 6, Column: 9, Severity: config.SeverityWarning, Message: "matches no dependency files"}},
+	}}
+	runE := RunE(mock)
+
+	assert.NoError(t, runE(&cobra.Command{}, nil))
+
+	viper.Set(FailOnWarningFlag, true)
+	defer viper.Set(FailOnWarningFlag, false)
+	viper.Set(JsonFlag, true)
+	defer viper.Set(JsonFlag, false)
+
+	assert.ErrorIs(t, runE(&cobra.Command{}, nil), InvalidConfigErr)
+}
+
+func TestRunEError(t *testing.T) {
+	validatorErr := errors.New("validator error")
+	mock := &validatorMock{err: validatorErr}
+
+	err := RunE(mock)(&cobra.Command{}, nil)
+
+	assert.ErrorIs(t, err, validatorErr)
+}
@@ -76,15 +76,15 @@ func RunE(f fingerprint.IFingerprint) func(_ *cobra.Command, args []string) erro
 		if len(args) > 0 {
 			path = args[0]
 		}
-		var outputFilePath = filepath.Join(outputDir, fingerprint.OutputFileNameFingerprints)
+		var outputFilePath = filepath.Join(viper.GetString(OutputDirFlag), fingerprint.OutputFileNameFingerprints)
 		options := fingerprint.DebrickedOptions{
 			OutputPath:                   outputFilePath,
-			Regenerate:                   shouldRegenerateFingerprintFile,
+			Regenerate:                   viper.GetBool(RegenerateFingerprintFile),
 			Path:                         path,
-			Exclusions:                   exclusions,
-			Inclusions:                   inclusions,
-			FingerprintCompressedContent: shouldFingerprintCompressedContent,
-			MinFingerprintContentLength:  minFingerprintContentLength,
+			Exclusions:                   viper.GetStringSlice(ExclusionFlag),
+			Inclusions:                   viper.GetStringSlice(InclusionFlag),
+			FingerprintCompressedContent: viper.GetBool(FingerprintCompressedContent),
+			MinFingerprintContentLength:  viper.GetInt(MinFingerprintContentLengthFlag),
 		}
 		output, err := f.FingerprintFiles(options)
 		if err != nil {
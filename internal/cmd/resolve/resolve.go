@@ -7,6 +7,10 @@ import (
 
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/resolution"
+	"github.com/debricked/cli/internal/resolution/cache"
+	"github.com/debricked/cli/internal/resolution/isolation"
+	"github.com/debricked/cli/internal/resolution/registry"
+	"github.com/debricked/cli/internal/scope"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -18,6 +22,15 @@ var (
 	npmPreferred         bool
 	regenerate           int
 	resolutionStrictness int
+	isolationMode        string
+	isolationImages      []string
+	useCache             bool
+	cacheDir             string
+	reportFile           string
+	resolutionTimeout    int
+	pmTimeouts           []string
+	scopes               []string
+	trustProjectCode     bool
 )
 
 const (
@@ -27,6 +40,15 @@ const (
 	NpmPreferredFlag     = "prefer-npm"
 	RegenerateFlag       = "regenerate"
 	ResolutionStrictFlag = "resolution-strictness"
+	IsolationFlag        = "isolation"
+	IsolationImageFlag   = "isolation-image"
+	CacheFlag            = "cache"
+	CacheDirFlag         = "cache-dir"
+	ReportFlag           = "report"
+	TimeoutFlag          = "resolution-timeout"
+	PmTimeoutFlag        = "resolution-pm-timeout"
+	ScopesFlag           = "scopes"
+	TrustProjectCodeFlag = "trust-project-code"
 )
 
 func NewResolveCmd(resolver resolution.IResolver) *cobra.Command {
@@ -99,8 +121,74 @@ Strictness Level | Meaning
 3                | Exit with code 1 if all files failed to resolve, if any but not all files failed to resolve exit with code 3, otherwise exit with code 0
 `)
 
+	isolationDoc := strings.Join(
+		[]string{
+			"Runs package managers in containers instead of on the host, using docker or podman.",
+			"The directory of each manifest file is copied into its container, the rest of the working directory is mounted read-only and lock files are copied out once resolved.",
+			"\nExample:\n$ debricked resolve . --isolation=docker",
+		}, "\n")
+	cmd.Flags().StringVar(&isolationMode, IsolationFlag, string(isolation.None), isolationDoc)
+	isolationImageDoc := strings.Join(
+		[]string{
+			"Sets the container image to resolve manifest files of a package manager in, overriding the default image.",
+			"Package managers are named as in the resolution output, such as mvn, gradle, go, pip, npm, yarn or nuget.",
+			"\nExample:\n$ debricked resolve . --isolation=docker --isolation-image mvn=maven:3.9-eclipse-temurin-21",
+		}, "\n")
+	cmd.Flags().StringArrayVar(&isolationImages, IsolationImageFlag, []string{}, isolationImageDoc)
+
+	cacheDoc := strings.Join(
+		[]string{
+			"Reuses the lock files of earlier resolutions of manifest files that haven't changed, instead of resolving them again.",
+			"Lock files of Maven, Gradle, sbt, pip, Go and Bower resolutions are cached, keyed by the manifest file, related files such as parent poms and the package manager version.",
+			"Cached lock files miss newer releases of dependencies without pinned versions until the manifest file changes, so pip requirements files are only cached if every requirement is pinned.",
+			"\nExample:\n$ debricked resolve . --cache",
+		}, "\n")
+	cmd.Flags().BoolVar(&useCache, CacheFlag, false, cacheDoc)
+	cacheDirDoc := strings.Join(
+		[]string{
+			"Sets the directory lock files are cached in, enabling the cache. Defaults to debricked/resolution in the user cache directory.",
+			"\nExample:\n$ debricked resolve . --cache-dir .cache/debricked",
+		}, "\n")
+	cmd.Flags().StringVar(&cacheDir, CacheDirFlag, "", cacheDirDoc)
+	reportDoc := strings.Join(
+		[]string{
+			"Writes a JSON report of the resolution to the file, with the package manager, commands, duration, lock files, status and errors of every manifest file.",
+			"\nExample:\n$ debricked resolve . --report resolution-report.json",
+		}, "\n")
+	cmd.Flags().StringVar(&reportFile, ReportFlag, "", reportDoc)
+	timeoutDoc := strings.Join(
+		[]string{
+			"Sets a timeout (in seconds) on resolving a manifest file. 0 resolves manifest files until done.",
+			"Package managers still running are killed, along with the processes they started, and the manifest file fails to resolve.",
+			"Timed out manifest files count as failed manifest files towards the resolution strictness.",
+			"\nExample:\n$ debricked resolve . --resolution-timeout 900",
+		}, "\n")
+	cmd.Flags().IntVar(&resolutionTimeout, TimeoutFlag, 0, timeoutDoc)
+	pmTimeoutDoc := strings.Join(
+		[]string{
+			"Sets a timeout (in seconds) on resolving manifest files of a package manager, overriding the resolution timeout.",
+			"\nExample:\n$ debricked resolve . --resolution-pm-timeout gradle=1800 --resolution-pm-timeout sbt=1800",
+		}, "\n")
+	cmd.Flags().StringArrayVar(&pmTimeouts, PmTimeoutFlag, []string{}, pmTimeoutDoc)
+	scopesDoc := strings.Join(
+		[]string{
+			"Only keeps dependencies of the scopes in the lock files written, out of prod, dev and test. Keeps every scope by default.",
+			"Applies to lock files written for Maven, sbt and Gradle, which are written as scoped.maven.debricked.lock and scoped.gradle.debricked.lock",
+			"and regenerated by later resolutions. Native lock files are left as they are.",
+			"\nExample:\n$ debricked resolve . --scopes prod",
+		}, "\n")
+	cmd.Flags().StringSliceVar(&scopes, ScopesFlag, []string{}, scopesDoc)
+	trustProjectCodeDoc := strings.Join(
+		[]string{
+			"Lets Bundler and Mix resolve manifest files on the host. They evaluate the Gemfile, gemspecs and mix.exs files as code,",
+			"so without this flag they only resolve in containers, see --isolation. Only use it for projects you trust.",
+			"\nExample:\n$ debricked resolve . --trust-project-code",
+		}, "\n")
+	cmd.Flags().BoolVar(&trustProjectCode, TrustProjectCodeFlag, false, trustProjectCodeDoc)
+
 	viper.MustBindEnv(ExclusionFlag)
 	viper.MustBindEnv(NpmPreferredFlag)
+	viper.MustBindEnv(IsolationFlag)
 
 	return cmd
 }
@@ -110,7 +198,27 @@ func RunE(resolver resolution.IResolver) func(_ *cobra.Command, args []string) e
 		if len(args) == 0 {
 			args = append(args, ".")
 		}
-		strictness, err := resolution.GetStrictnessLevel(resolutionStrictness)
+		strictness, err := resolution.GetStrictnessLevel(viper.GetInt(ResolutionStrictFlag))
+		if err != nil {
+			return err
+		}
+		mode, err := isolation.ParseMode(viper.GetString(IsolationFlag))
+		if err != nil {
+			return err
+		}
+		images, err := isolation.ParseImages(viper.GetStringSlice(IsolationImageFlag))
+		if err != nil {
+			return err
+		}
+		timeouts, err := resolution.ParseTimeouts(viper.GetInt(TimeoutFlag), viper.GetStringSlice(PmTimeoutFlag))
+		if err != nil {
+			return err
+		}
+		scopeFilter, err := scope.ParseFilter(viper.GetStringSlice(ScopesFlag))
+		if err != nil {
+			return err
+		}
+		registries, err := registry.Load(args[0], registry.NewSecretClient())
 		if err != nil {
 			return err
 		}
@@ -121,9 +229,31 @@ func RunE(resolver resolution.IResolver) func(_ *cobra.Command, args []string) e
 			Regenerate:           viper.GetInt(RegenerateFlag),
 			NpmPreferred:         viper.GetBool(NpmPreferredFlag),
 			ResolutionStrictness: strictness,
+			Isolation:            mode,
+			IsolationImages:      images,
+			CacheDir:             getCacheDir(),
+			Timeouts:             timeouts,
+			ReportFile:           viper.GetString(ReportFlag),
+			Scopes:               scopeFilter,
+			Registries:           registries,
+			TrustProjectCode:     viper.GetBool(TrustProjectCodeFlag),
 		}
 		_, err = resolver.Resolve(args, options)
 
 		return err
 	}
 }
+
+// getCacheDir returns the directory to cache lock files in, or an empty string if caching is off
+func getCacheDir() string {
+	if dir := viper.GetString(CacheDirFlag); len(dir) > 0 {
+		return dir
+	}
+	if !viper.GetBool(CacheFlag) {
+		return ""
+	}
+	// Lock files aren't cached if there is no user cache directory, such as when $HOME isn't set
+	dir, _ := cache.DefaultDir()
+
+	return dir
+}
@@ -2,12 +2,17 @@ package resolve
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
+	cmdConfig "github.com/debricked/cli/internal/cmd/config"
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/file/testdata"
 	"github.com/debricked/cli/internal/resolution"
+	"github.com/debricked/cli/internal/resolution/cache"
 	resolveTestdata "github.com/debricked/cli/internal/resolution/testdata"
+	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -30,6 +35,7 @@ func TestNewResolveCmd(t *testing.T) {
 
 	var flagKeys = []string{
 		ExclusionFlag,
+		IsolationFlag,
 	}
 	viperKeys := viper.AllKeys()
 	for _, flagKey := range flagKeys {
@@ -96,9 +102,98 @@ func TestRunEError(t *testing.T) {
 
 func TestRunEErrorInvalidStrictness(t *testing.T) {
 	r := &resolveTestdata.ResolverMock{}
-	resolutionStrictness = 123
+	viper.Set(ResolutionStrictFlag, 123)
+	defer viper.Set(ResolutionStrictFlag, 0)
 	runE := RunE(r)
 	err := runE(nil, []string{"."})
 
 	assert.EqualError(t, err, "invalid strictness level: 123", "error doesn't match expected")
 }
+
+func TestRunEErrorInvalidIsolation(t *testing.T) {
+	r := &resolveTestdata.ResolverMock{}
+	viper.Set(IsolationFlag, "vagrant")
+	defer viper.Set(IsolationFlag, "")
+	runE := RunE(r)
+	err := runE(nil, []string{"."})
+
+	assert.ErrorContains(t, err, "invalid isolation: vagrant")
+}
+
+func TestRunEErrorInvalidIsolationImage(t *testing.T) {
+	r := &resolveTestdata.ResolverMock{}
+	viper.Set(IsolationImageFlag, []string{"maven"})
+	defer viper.Set(IsolationImageFlag, []string{})
+	runE := RunE(r)
+	err := runE(nil, []string{"."})
+
+	assert.ErrorContains(t, err, "invalid isolation image: maven")
+}
+
+func TestRunEErrorInvalidPmTimeout(t *testing.T) {
+	r := &resolveTestdata.ResolverMock{}
+	viper.Set(PmTimeoutFlag, []string{"gradle=forever"})
+	defer viper.Set(PmTimeoutFlag, []string{})
+	runE := RunE(r)
+	err := runE(nil, []string{"."})
+
+	assert.ErrorContains(t, err, "invalid resolution timeout: gradle=forever")
+}
+
+func TestRunEErrorInvalidScope(t *testing.T) {
+	r := &resolveTestdata.ResolverMock{}
+	viper.Set(ScopesFlag, []string{"prod", "optional"})
+	defer viper.Set(ScopesFlag, []string{})
+	runE := RunE(r)
+	err := runE(nil, []string{"."})
+
+	assert.ErrorContains(t, err, "invalid scope: optional")
+}
+
+func TestRunEErrorInvalidRegistries(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0o755))
+	content := "registries:\n  npm:\n    url: https://npm.example.com/\n    token-env: DEBRICKED_TEST_UNSET_TOKEN\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".debricked.yaml"), []byte(content), 0o600))
+	r := &resolveTestdata.ResolverMock{}
+	runE := RunE(r)
+	err := runE(nil, []string{dir})
+
+	assert.ErrorContains(t, err, "invalid npm registry: environment variable DEBRICKED_TEST_UNSET_TOKEN is not set")
+}
+
+func TestRunEProjectConfig(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, ".git"), 0o755))
+	content := "commands:\n  resolve:\n    resolution-strictness: 3\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".debricked.yaml"), []byte(content), 0o600))
+	r := &resolveTestdata.ResolverMock{}
+	rootCmd := &cobra.Command{Use: "debricked", PersistentPreRunE: cmdConfig.ApplyProjectConfig}
+	rootCmd.AddCommand(NewResolveCmd(r))
+	rootCmd.SetArgs([]string{"resolve", dir})
+
+	err := rootCmd.Execute()
+
+	assert.NoError(t, err)
+	options, ok := r.Options.(resolution.DebrickedOptions)
+	assert.True(t, ok)
+	assert.Equal(t, resolution.FailOrWarn, options.ResolutionStrictness)
+}
+
+func TestGetCacheDir(t *testing.T) {
+	assert.Empty(t, getCacheDir())
+
+	viper.Set(CacheFlag, true)
+	defer viper.Set(CacheFlag, false)
+	defaultDir, err := cache.DefaultDir()
+	if err == nil {
+		assert.Equal(t, defaultDir, getCacheDir())
+	}
+
+	viper.Set(CacheFlag, false)
+	viper.Set(CacheDirFlag, "custom-cache")
+	defer viper.Set(CacheDirFlag, "")
+	assert.Equal(t, "custom-cache", getCacheDir())
+}
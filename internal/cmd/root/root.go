@@ -3,11 +3,13 @@ package root
 import (
 	"github.com/debricked/cli/internal/cmd/auth"
 	"github.com/debricked/cli/internal/cmd/callgraph"
+	"github.com/debricked/cli/internal/cmd/config"
 	"github.com/debricked/cli/internal/cmd/files"
 	"github.com/debricked/cli/internal/cmd/fingerprint"
 	"github.com/debricked/cli/internal/cmd/report"
 	"github.com/debricked/cli/internal/cmd/resolve"
 	"github.com/debricked/cli/internal/cmd/scan"
+	projectConfig "github.com/debricked/cli/internal/config"
 	"github.com/debricked/cli/internal/wire"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -23,7 +25,12 @@ func NewRootCmd(version string, container *wire.CliContainer) *cobra.Command {
 		Use:   "debricked",
 		Short: "Debricked CLI - Keep track of your dependencies!",
 		Long: `A fast and flexible software composition analysis CLI tool, given to you by Debricked.
-Complete documentation is available at https://docs.debricked.com/tools-and-integrations/cli/debricked-cli`,
+Complete documentation is available at https://docs.debricked.com/tools-and-integrations/cli/debricked-cli
+
+Flag defaults can be stored in a ` + projectConfig.ProjectConfigFileName + ` file in the scanned directory, or any of its parents up to
+the repository root. Flags take precedence over environment variables, which take precedence over the file.
+Run "debricked config show <command>" to print the effective configuration of a command.`,
+		PersistentPreRunE: config.ApplyProjectConfig,
 		PreRun: func(cmd *cobra.Command, _ []string) {
 			_ = viper.BindPFlags(cmd.PersistentFlags())
 		},
@@ -48,11 +55,12 @@ Read more: https://docs.debricked.com/product/administration/generate-access-tok
 
 	rootCmd.AddCommand(report.NewReportCmd(container.LicenseReporter(), container.VulnerabilityReporter(), container.SBOMReporter()))
 	rootCmd.AddCommand(files.NewFilesCmd(container.Finder()))
-	rootCmd.AddCommand(scan.NewScanCmd(container.Scanner()))
+	rootCmd.AddCommand(scan.NewScanCmd(container.Scanner(), container.StatusChecker()))
 	rootCmd.AddCommand(fingerprint.NewFingerprintCmd(container.Fingerprinter()))
 	rootCmd.AddCommand(resolve.NewResolveCmd(container.Resolver()))
-	rootCmd.AddCommand(callgraph.NewCallgraphCmd(container.CallgraphGenerator()))
+	rootCmd.AddCommand(callgraph.NewCallgraphCmd(container.CallgraphGenerator(), container.CallgraphQuerier()))
 	rootCmd.AddCommand(auth.NewAuthCmd(container.Authenticator()))
+	rootCmd.AddCommand(config.NewConfigCmd(container.ConfigValidator()))
 
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 
@@ -11,7 +11,7 @@ import (
 func TestNewRootCmd(t *testing.T) {
 	cmd := NewRootCmd("v0.0.0", wire.GetCliContainer())
 	commands := cmd.Commands()
-	nbrOfCommands := 7
+	nbrOfCommands := 8
 	if len(commands) != nbrOfCommands {
 		t.Errorf(
 			"failed to assert that there were %d sub commands connected (was %d)",
@@ -35,7 +35,7 @@ func TestNewRootCmd(t *testing.T) {
 		}
 	}
 	assert.Truef(t, match, "failed to assert that flag was present: "+OldAccessTokenFlag)
-	assert.Len(t, viperKeys, 23)
+	assert.Len(t, viperKeys, 24)
 }
 
 func TestPreRun(t *testing.T) {
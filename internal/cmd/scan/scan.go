@@ -8,8 +8,14 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/debricked/cli/internal/cmd/scan/status"
 	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/format"
+	"github.com/debricked/cli/internal/inventory"
+	"github.com/debricked/cli/internal/resolution"
+	"github.com/debricked/cli/internal/resolution/registry"
 	"github.com/debricked/cli/internal/scan"
+	"github.com/debricked/cli/internal/scope"
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -33,6 +39,9 @@ var noResolve bool
 var npmPreferred bool
 var passOnDowntime bool
 var regenerate int
+var resolutionTimeout int
+var resolutionPmTimeouts []string
+var scopes []string
 var repositoryName string
 var repositoryUrl string
 var verbose bool
@@ -41,6 +50,17 @@ var sbom string
 var sbomOutput string
 var tagCommitAsRelease bool
 var experimental bool
+var offline bool
+var inventoryOutput string
+var outputFormat string
+var formatOutput string
+var prComment bool
+var diffBase string
+var failOnUploadError bool
+var pollInterval int
+var maxWait int
+var detach bool
+var trustProjectCode bool
 
 const (
 	BranchFlag                      = "branch"
@@ -60,6 +80,9 @@ const (
 	NpmPreferredFlag                = "prefer-npm"
 	PassOnTimeOut                   = "pass-on-timeout"
 	RegenerateFlag                  = "regenerate"
+	ResolutionTimeoutFlag           = "resolution-timeout"
+	ResolutionPmTimeoutFlag         = "resolution-pm-timeout"
+	ScopesFlag                      = "scopes"
 	RepositoryFlag                  = "repository"
 	RepositoryUrlFlag               = "repository-url"
 	VerboseFlag                     = "verbose"
@@ -70,11 +93,22 @@ const (
 	TagCommitAsReleaseEnv           = "TAG_COMMIT_AS_RELEASE"
 	ExperimentalFlag                = "experimental"
 	GenerateCommitNameFlag          = "generate-commit-name"
+	OfflineFlag                     = "offline"
+	InventoryOutputFlag             = "inventory-output"
+	FormatFlag                      = "format"
+	FormatOutputFlag                = "format-output"
+	PRCommentFlag                   = "pr-comment"
+	DiffBaseFlag                    = "diff-base"
+	FailOnUploadErrorFlag           = "fail-on-upload-error"
+	PollIntervalFlag                = "poll-interval"
+	MaxWaitFlag                     = "max-wait"
+	DetachFlag                      = "detach"
+	TrustProjectCodeFlag            = "trust-project-code"
 )
 
 var scanCmdError error
 
-func NewScanCmd(scanner scan.IScanner) *cobra.Command {
+func NewScanCmd(scanner scan.IScanner, statusChecker scan.IStatusChecker) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "scan [path]",
 		Short: "Start a Debricked dependency scan",
@@ -167,6 +201,19 @@ For example, if there is a "go.mod" in the target path, its dependencies are goi
 	cmd.Flags().BoolVar(&callgraph, CallGraphFlag, false, `Enables call graph generation during scan.`)
 	cmd.Flags().IntVar(&callgraphUploadTimeout, CallGraphUploadTimeoutFlag, 10*60, "Set a timeout (in seconds) on call graph upload.")
 	cmd.Flags().IntVar(&callgraphGenerateTimeout, CallGraphGenerateTimeoutFlag, 60*60, "Set a timeout (in seconds) on call graph generation.")
+	cmd.Flags().IntVar(&resolutionTimeout, ResolutionTimeoutFlag, 0, "Set a timeout (in seconds) on resolving a manifest file. 0 resolves manifest files until done.")
+	cmd.Flags().StringArrayVar(
+		&resolutionPmTimeouts,
+		ResolutionPmTimeoutFlag,
+		[]string{},
+		"Set a timeout (in seconds) on resolving manifest files of a package manager, such as gradle=1800, overriding the resolution timeout.",
+	)
+	cmd.Flags().StringSliceVar(
+		&scopes,
+		ScopesFlag,
+		[]string{},
+		"Only keep dependencies of the scopes, out of prod, dev and test, such as --scopes prod. Applies to lock files resolved for Maven, sbt and Gradle, native lock files uploaded, the offline inventory and local policies.",
+	)
 	cmd.Flags().IntVar(&minFingerprintContentLength, MinFingerprintContentLengthFlag, 0, "Set minimum content length (in bytes) for files to fingerprint.")
 	npmPreferredDoc := strings.Join(
 		[]string{
@@ -176,9 +223,71 @@ For example, if there is a "go.mod" in the target path, its dependencies are goi
 	cmd.Flags().BoolP(NpmPreferredFlag, "", npmPreferred, npmPreferredDoc)
 	cmd.Flags().StringVar(&sbom, SBOMFlag, "", `Toggle generating and downloading SBOM report after scan completion of specified format.
 Supported formats are: 'CycloneDX', 'SPDX'
-Leaving the field empty results in no SBOM generation.`,
+Leaving the field empty results in no SBOM generation.
+In offline mode the SBOM is generated locally from the lock files, as CycloneDX 1.5 or SPDX 2.3 JSON.`,
 	)
 	cmd.Flags().StringVar(&sbomOutput, SBOMOutputFlag, "", `Set output path of downloaded SBOM report (if sbom is toggled)`)
+	offlineDoc := strings.Join(
+		[]string{
+			"Run resolution, fingerprinting and file matching locally and write a dependency inventory instead of uploading.",
+			"No requests are sent to Debricked in offline mode.",
+			"\nExample:\n$ debricked scan . --offline --inventory-output inventory.json",
+		}, "\n")
+	cmd.Flags().BoolVar(&offline, OfflineFlag, false, offlineDoc)
+	cmd.Flags().StringVar(&inventoryOutput, InventoryOutputFlag, "", "Set output path of the dependency inventory written in offline mode. Defaults to "+inventory.OutputFileNameInventory)
+	formatDoc := strings.Join(
+		[]string{
+			"Write the scan result in a machine-readable format after scan completion.",
+			"Supported formats are: " + strings.Join(format.Formats, ", "),
+			"sarif: SARIF 2.1.0 with one result per triggered automation rule event, for code scanning dashboards",
+			"junit: JUnit XML with one test case per automation rule, failing when the rule triggers",
+			"gitlab: GitLab dependency scanning report, for merge request widgets",
+			"\nExample:\n$ debricked scan . --format sarif --format-output debricked.sarif",
+		}, "\n")
+	cmd.Flags().StringVar(&outputFormat, FormatFlag, "", formatDoc)
+	cmd.Flags().StringVar(&formatOutput, FormatOutputFlag, "", fmt.Sprintf(
+		"Set output path of the formatted scan result. Defaults to %s, %s, %s or %s depending on format",
+		format.OutputFileNameJson,
+		format.OutputFileNameSarif,
+		format.OutputFileNameJUnit,
+		format.OutputFileNameGitLab,
+	))
+	prCommentDoc := strings.Join(
+		[]string{
+			"Post, or update, a Markdown summary of the scan result as a comment on the current pull request.",
+			"Supported on GitHub Actions, GitLab CI, Azure Pipelines and Bitbucket Pipelines. The API token is read from:",
+			"GitHub: GITHUB_TOKEN, GitLab: GITLAB_TOKEN, Azure: SYSTEM_ACCESSTOKEN, Bitbucket: BITBUCKET_ACCESS_TOKEN",
+		}, "\n")
+	cmd.Flags().BoolVar(&prComment, PRCommentFlag, false, prCommentDoc)
+	diffBaseDoc := strings.Join(
+		[]string{
+			"Compare committed dependency files with a base branch, tag or commit and print the dependencies added, removed or upgraded since then.",
+			"Triggered automation rules are filtered down to dependencies introduced since the base,",
+			"unless a changed manifest file, such as pom.xml, has no committed lock file to compare versions with.",
+			"\nExample:\n$ debricked scan . --diff-base origin/main",
+		}, "\n")
+	cmd.Flags().StringVar(&diffBase, DiffBaseFlag, "", diffBaseDoc)
+	cmd.Flags().BoolVar(
+		&failOnUploadError,
+		FailOnUploadErrorFlag,
+		false,
+		"Fail the scan if any dependency file failed to upload. Files are retried before they are considered failed.",
+	)
+	cmd.Flags().IntVar(&pollInterval, PollIntervalFlag, 1, "Set the interval (in seconds) between scan status requests.")
+	cmd.Flags().IntVar(&maxWait, MaxWaitFlag, 0, "Set the maximum time (in seconds) to wait for the scan to complete. 0 waits until Debricked reports a long queue.")
+	detachDoc := strings.Join(
+		[]string{
+			"Upload dependency files and start the scan without waiting for the result.",
+			"The scan id is stored in " + scan.OutputFileNameDetachedScan + ", use \"debricked scan status\" to get the result later on.",
+			"\nExample:\n$ debricked scan . --detach\n$ debricked scan status",
+		}, "\n")
+	cmd.Flags().BoolVar(&detach, DetachFlag, false, detachDoc)
+	cmd.Flags().BoolVar(
+		&trustProjectCode,
+		TrustProjectCodeFlag,
+		false,
+		"Let Bundler and Mix resolve manifest files, which evaluates the Gemfile, gemspecs and mix.exs files as code on the host. Only use it for projects you trust.",
+	)
 	cmd.Flags().BoolVar(
 		&tagCommitAsRelease,
 		TagCommitAsReleaseFlag,
@@ -204,6 +313,8 @@ Leaving the field empty results in no SBOM generation.`,
 		fmt.Println("Trying to hide non-existing flag")
 	}
 
+	cmd.AddCommand(status.NewStatusCmd(statusChecker))
+
 	return cmd
 }
 
@@ -229,6 +340,25 @@ func RunE(s *scan.IScanner) func(_ *cobra.Command, args []string) error {
 			tagCommitAsRelease = viper.GetBool(TagCommitAsReleaseFlag)
 		}
 
+		resolutionTimeouts, err := resolution.ParseTimeouts(
+			viper.GetInt(ResolutionTimeoutFlag),
+			viper.GetStringSlice(ResolutionPmTimeoutFlag),
+		)
+		if err != nil {
+			return err
+		}
+		scopeFilter, err := scope.ParseFilter(viper.GetStringSlice(ScopesFlag))
+		if err != nil {
+			return err
+		}
+		var registries *registry.Config
+		if !viper.GetBool(NoResolveFlag) {
+			registries, err = registry.Load(filepath.Join(".", path), registry.NewSecretClient())
+			if err != nil {
+				return err
+			}
+		}
+
 		options := scan.DebrickedOptions{
 			Path:                        path,
 			Resolve:                     !viper.GetBool(NoResolveFlag),
@@ -240,6 +370,9 @@ func RunE(s *scan.IScanner) func(_ *cobra.Command, args []string) error {
 			Verbose:                     viper.GetBool(VerboseFlag),
 			Debug:                       viper.GetBool(DebugFlag),
 			Regenerate:                  viper.GetInt(RegenerateFlag),
+			ResolutionTimeouts:          resolutionTimeouts,
+			Scopes:                      scopeFilter,
+			Registries:                  registries,
 			VersionHint:                 viper.GetBool(VersionHintFlag),
 			RepositoryName:              viper.GetString(RepositoryFlag),
 			CommitName:                  viper.GetString(CommitFlag),
@@ -257,6 +390,18 @@ func RunE(s *scan.IScanner) func(_ *cobra.Command, args []string) error {
 			MinFingerprintContentLength: viper.GetInt(MinFingerprintContentLengthFlag),
 			TagCommitAsRelease:          tagCommitAsRelease,
 			Experimental:                viper.GetBool(ExperimentalFlag),
+			Offline:                     viper.GetBool(OfflineFlag),
+			InventoryOutput:             viper.GetString(InventoryOutputFlag),
+			Format:                      viper.GetString(FormatFlag),
+			FormatOutput:                viper.GetString(FormatOutputFlag),
+			PRComment:                   viper.GetBool(PRCommentFlag),
+			DiffBase:                    viper.GetString(DiffBaseFlag),
+			FailOnUploadError:           viper.GetBool(FailOnUploadErrorFlag),
+			PollInterval:                viper.GetInt(PollIntervalFlag),
+			MaxWait:                     viper.GetInt(MaxWaitFlag),
+			Detach:                      viper.GetBool(DetachFlag),
+			TrustProjectCode:            viper.GetBool(TrustProjectCodeFlag),
+			Version:                     viper.GetString("cliVersion"),
 		}
 		if s != nil {
 			scanCmdError = (*s).Scan(options)
@@ -10,7 +10,7 @@ import (
 )
 
 func TestNewScanCmd(t *testing.T) {
-	cmd := NewScanCmd(&scannerMock{})
+	cmd := NewScanCmd(&scannerMock{}, nil)
 
 	flagAssertions := map[string]string{
 		RepositoryFlag:               "r",
@@ -25,6 +25,19 @@ func TestNewScanCmd(t *testing.T) {
 		CallGraphFlag:                "",
 		CallGraphUploadTimeoutFlag:   "",
 		CallGraphGenerateTimeoutFlag: "",
+		ResolutionTimeoutFlag:        "",
+		ResolutionPmTimeoutFlag:      "",
+		ScopesFlag:                   "",
+		OfflineFlag:                  "",
+		InventoryOutputFlag:          "",
+		FormatFlag:                   "",
+		FormatOutputFlag:             "",
+		PRCommentFlag:                "",
+		DiffBaseFlag:                 "",
+		FailOnUploadErrorFlag:        "",
+		PollIntervalFlag:             "",
+		MaxWaitFlag:                  "",
+		DetachFlag:                   "",
 	}
 	flags := cmd.Flags()
 	for name, shorthand := range flagAssertions {
@@ -94,8 +107,30 @@ func TestRunEError(t *testing.T) {
 	assert.ErrorContains(t, err, "⨯ scanner was nil")
 }
 
+func TestRunEErrorInvalidResolutionTimeout(t *testing.T) {
+	var s scan.IScanner = &scannerMock{}
+	viper.Set(ResolutionTimeoutFlag, -1)
+	defer viper.Set(ResolutionTimeoutFlag, 0)
+	runE := RunE(&s)
+
+	err := runE(nil, []string{"."})
+
+	assert.ErrorContains(t, err, "invalid resolution timeout: -1")
+}
+
+func TestRunEErrorInvalidScope(t *testing.T) {
+	var s scan.IScanner = &scannerMock{}
+	viper.Set(ScopesFlag, []string{"optional"})
+	defer viper.Set(ScopesFlag, []string{})
+	runE := RunE(&s)
+
+	err := runE(nil, []string{"."})
+
+	assert.ErrorContains(t, err, "invalid scope: optional")
+}
+
 func TestPreRun(t *testing.T) {
-	cmd := NewScanCmd(nil)
+	cmd := NewScanCmd(nil, nil)
 	cmd.PreRun(cmd, nil)
 }
 
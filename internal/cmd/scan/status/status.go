@@ -0,0 +1,108 @@
+package status
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/debricked/cli/internal/format"
+	"github.com/debricked/cli/internal/scan"
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var pollInterval int
+var maxWait int
+var passOnDowntime bool
+var jsonFilePath string
+var outputFormat string
+var formatOutput string
+var prComment bool
+var debug bool
+
+const (
+	PollIntervalFlag = "poll-interval"
+	MaxWaitFlag      = "max-wait"
+	PassOnTimeOut    = "pass-on-timeout"
+	JsonFilePathFlag = "json-path"
+	FormatFlag       = "format"
+	FormatOutputFlag = "format-output"
+	PRCommentFlag    = "pr-comment"
+	DebugFlag        = "debug"
+)
+
+func NewStatusCmd(checker scan.IStatusChecker) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status [id]",
+		Short: "Resume polling of a scan started earlier",
+		Long: `Poll the status of a scan until it completes and handle the result like "debricked scan" does.
+The scan is identified by the id printed when it was started. If no id is given, the scan stored by the last
+detached scan started in the working directory, ` + scan.OutputFileNameDetachedScan + `, is used.
+Local policies and the diff base of the stored scan are applied to the result, in the directory that was scanned.
+
+Example:
+$ debricked scan . --detach
+$ debricked scan status`,
+		Args: cobra.MaximumNArgs(1),
+		PreRun: func(cmd *cobra.Command, _ []string) {
+			_ = viper.BindPFlags(cmd.Flags())
+		},
+		RunE: RunE(checker),
+	}
+
+	cmd.Flags().IntVar(&pollInterval, PollIntervalFlag, 1, "Set the interval (in seconds) between scan status requests.")
+	cmd.Flags().IntVar(&maxWait, MaxWaitFlag, 0, "Set the maximum time (in seconds) to wait for the scan to complete. 0 waits until Debricked reports a long queue.")
+	cmd.Flags().BoolVarP(&passOnDowntime, PassOnTimeOut, "p", false, "pass if there is a service access timeout")
+	cmd.Flags().StringVarP(&jsonFilePath, JsonFilePathFlag, "j", "", "write scan result as json to provided path")
+	cmd.Flags().StringVar(&outputFormat, FormatFlag, "", "Write the scan result in a machine-readable format. Supported formats are: "+strings.Join(format.Formats, ", "))
+	cmd.Flags().StringVar(&formatOutput, FormatOutputFlag, "", "Set output path of the formatted scan result")
+	cmd.Flags().BoolVar(&prComment, PRCommentFlag, false, "Post, or update, a Markdown summary of the scan result as a comment on the current pull request.")
+	cmd.Flags().BoolVar(&debug, DebugFlag, false, "write all debug output to stderr")
+
+	return cmd
+}
+
+func RunE(checker scan.IStatusChecker) func(_ *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		ciUploadId := 0
+		if len(args) > 0 {
+			id, err := strconv.Atoi(args[0])
+			if err != nil || id <= 0 {
+				return fmt.Errorf("%s invalid scan id %q", color.RedString("⨯"), args[0])
+			}
+			ciUploadId = id
+		}
+
+		options := scan.StatusOptions{
+			CiUploadId:    ciUploadId,
+			PollInterval:  viper.GetInt(PollIntervalFlag),
+			MaxWait:       viper.GetInt(MaxWaitFlag),
+			PassOnTimeOut: viper.GetBool(PassOnTimeOut),
+			JsonFilePath:  viper.GetString(JsonFilePathFlag),
+			Format:        viper.GetString(FormatFlag),
+			FormatOutput:  viper.GetString(FormatOutputFlag),
+			PRComment:     viper.GetBool(PRCommentFlag),
+			Debug:         viper.GetBool(DebugFlag),
+			Version:       viper.GetString("cliVersion"),
+		}
+		var err error
+		if checker != nil {
+			err = checker.Status(options)
+		} else {
+			err = errors.New("status checker was nil")
+		}
+
+		if err == scan.FailPipelineErr {
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+
+			return err
+		} else if err != nil {
+			return fmt.Errorf("%s %s\n", color.RedString("⨯"), err.Error())
+		}
+
+		return nil
+	}
+}
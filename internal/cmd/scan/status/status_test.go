@@ -0,0 +1,97 @@
+package status
+
+import (
+	"testing"
+
+	"github.com/debricked/cli/internal/scan"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStatusCmd(t *testing.T) {
+	cmd := NewStatusCmd(&statusCheckerMock{})
+
+	flagAssertions := map[string]string{
+		PollIntervalFlag: "",
+		MaxWaitFlag:      "",
+		PassOnTimeOut:    "p",
+		JsonFilePathFlag: "j",
+		FormatFlag:       "",
+		FormatOutputFlag: "",
+		PRCommentFlag:    "",
+		DebugFlag:        "",
+	}
+	for name, shorthand := range flagAssertions {
+		flag := cmd.Flags().Lookup(name)
+		assert.NotNilf(t, flag, "failed to assert that %s flag was set", name)
+		assert.Equalf(t, shorthand, flag.Shorthand, "failed to assert that %s flag shorthand %s was set correctly", name, shorthand)
+	}
+}
+
+func TestRunE(t *testing.T) {
+	mock := &statusCheckerMock{}
+	runE := RunE(mock)
+
+	viper.Set(DebugFlag, true)
+	defer viper.Set(DebugFlag, false)
+
+	err := runE(&cobra.Command{}, []string{"42"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, mock.options.CiUploadId)
+	assert.True(t, mock.options.Debug)
+}
+
+func TestRunENoId(t *testing.T) {
+	mock := &statusCheckerMock{}
+	runE := RunE(mock)
+
+	err := runE(&cobra.Command{}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, mock.options.CiUploadId)
+}
+
+func TestRunEInvalidId(t *testing.T) {
+	runE := RunE(&statusCheckerMock{})
+
+	err := runE(&cobra.Command{}, []string{"abc"})
+
+	assert.ErrorContains(t, err, `invalid scan id "abc"`)
+}
+
+func TestRunEFailPipelineErr(t *testing.T) {
+	runE := RunE(&statusCheckerMock{err: scan.FailPipelineErr})
+	cmd := &cobra.Command{}
+
+	err := runE(cmd, []string{"42"})
+
+	assert.ErrorIs(t, err, scan.FailPipelineErr)
+	assert.True(t, cmd.SilenceUsage, "failed to assert that usage was silenced")
+	assert.True(t, cmd.SilenceErrors, "failed to assert that errors were silenced")
+}
+
+func TestRunENilChecker(t *testing.T) {
+	runE := RunE(nil)
+
+	err := runE(&cobra.Command{}, []string{"42"})
+
+	assert.ErrorContains(t, err, "⨯ status checker was nil")
+}
+
+func TestPreRun(t *testing.T) {
+	cmd := NewStatusCmd(nil)
+	cmd.PreRun(cmd, nil)
+}
+
+type statusCheckerMock struct {
+	err     error
+	options scan.StatusOptions
+}
+
+func (s *statusCheckerMock) Status(o scan.IOptions) error {
+	s.options = o.(scan.StatusOptions)
+
+	return s.err
+}
@@ -0,0 +1,101 @@
+package comment
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const (
+	azureApiVersion = "7.0"
+	// azureThreadActive and azureCommentText are the numeric values of CommentThreadStatus.active and CommentType.text
+	azureThreadActive = 1
+	azureCommentText  = 1
+)
+
+type azureComment struct {
+	Id              int64  `json:"id,omitempty"`
+	ParentCommentId int64  `json:"parentCommentId"`
+	Content         string `json:"content"`
+	CommentType     int    `json:"commentType"`
+}
+
+type azureThread struct {
+	Id       int64          `json:"id,omitempty"`
+	Comments []azureComment `json:"comments"`
+	Status   int            `json:"status"`
+}
+
+// AzurePublisher posts pull request threads using the build's System.AccessToken, which has to be mapped to SYSTEM_ACCESSTOKEN
+type AzurePublisher struct {
+	CollectionUri string
+	Project       string
+	RepositoryId  string
+	PullRequest   string
+	Token         string
+	Client        *http.Client
+}
+
+func NewAzurePublisher(client *http.Client) (AzurePublisher, error) {
+	values, err := lookupEnv("SYSTEM_COLLECTIONURI", "SYSTEM_TEAMPROJECT", "BUILD_REPOSITORY_ID", "SYSTEM_ACCESSTOKEN")
+	if err != nil {
+		return AzurePublisher{}, err
+	}
+	pullRequest, err := lookupEnv("SYSTEM_PULLREQUEST_PULLREQUESTID")
+	if err != nil {
+		return AzurePublisher{}, ErrNoPullRequest
+	}
+
+	return AzurePublisher{
+		CollectionUri: strings.TrimSuffix(values[0], "/"),
+		Project:       values[1],
+		RepositoryId:  values[2],
+		PullRequest:   pullRequest[0],
+		Token:         values[3],
+		Client:        client,
+	}, nil
+}
+
+func (p AzurePublisher) Publish(body string) error {
+	threadsUrl := fmt.Sprintf(
+		"%s/%s/_apis/git/repositories/%s/pullRequests/%s/threads",
+		p.CollectionUri,
+		url.PathEscape(p.Project),
+		p.RepositoryId,
+		p.PullRequest,
+	)
+	var threads struct {
+		Value []azureThread `json:"value"`
+	}
+	err := p.request(http.MethodGet, threadsUrl, nil).do(&threads)
+	if err != nil {
+		return err
+	}
+	for _, thread := range threads.Value {
+		for _, comment := range thread.Comments {
+			if strings.Contains(comment.Content, Marker) {
+				commentUrl := fmt.Sprintf("%s/%d/comments/%d", threadsUrl, thread.Id, comment.Id)
+
+				return p.request(http.MethodPatch, commentUrl, map[string]string{"content": body}).do(nil)
+			}
+		}
+	}
+
+	thread := azureThread{
+		Comments: []azureComment{{Content: body, CommentType: azureCommentText}},
+		Status:   azureThreadActive,
+	}
+
+	return p.request(http.MethodPost, threadsUrl, thread).do(nil)
+}
+
+func (p AzurePublisher) request(method string, url string, body interface{}) apiRequest {
+	return apiRequest{
+		client:  p.Client,
+		method:  method,
+		url:     url + "?api-version=" + azureApiVersion,
+		headers: map[string]string{"Authorization": "Bearer " + p.Token},
+		body:    body,
+	}
+}
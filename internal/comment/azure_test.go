@@ -0,0 +1,70 @@
+package comment
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewAzurePublisher(t *testing.T) {
+	t.Setenv("SYSTEM_COLLECTIONURI", "https://dev.azure.com/debricked/")
+	t.Setenv("SYSTEM_TEAMPROJECT", "cli")
+	t.Setenv("BUILD_REPOSITORY_ID", "repo-id")
+	t.Setenv("SYSTEM_ACCESSTOKEN", "token")
+	t.Setenv("SYSTEM_PULLREQUEST_PULLREQUESTID", "5")
+
+	publisher, err := NewAzurePublisher(http.DefaultClient)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://dev.azure.com/debricked", publisher.CollectionUri)
+	assert.Equal(t, "5", publisher.PullRequest)
+}
+
+func TestNewAzurePublisherNoPullRequest(t *testing.T) {
+	t.Setenv("SYSTEM_COLLECTIONURI", "https://dev.azure.com/debricked/")
+	t.Setenv("SYSTEM_TEAMPROJECT", "cli")
+	t.Setenv("BUILD_REPOSITORY_ID", "repo-id")
+	t.Setenv("SYSTEM_ACCESSTOKEN", "token")
+	t.Setenv("SYSTEM_PULLREQUEST_PULLREQUESTID", "")
+
+	_, err := NewAzurePublisher(http.DefaultClient)
+
+	assert.ErrorIs(t, err, ErrNoPullRequest)
+}
+
+func TestAzurePublisherCreatesThread(t *testing.T) {
+	standIn, server := newProviderStandIn(t, func(r *http.Request) interface{} {
+		return map[string]interface{}{"value": []azureThread{{Id: 1, Comments: []azureComment{{Id: 1, Content: "comment"}}}}}
+	})
+	publisher := AzurePublisher{CollectionUri: server.URL, Project: "my project", RepositoryId: "repo-id", PullRequest: "5", Token: "token", Client: server.Client()}
+
+	err := publisher.Publish(Marker)
+
+	assert.NoError(t, err)
+	writes := standIn.writes()
+	assert.Len(t, writes, 1)
+	assert.Equal(t, http.MethodPost, writes[0].Method)
+	assert.Equal(t, "/my project/_apis/git/repositories/repo-id/pullRequests/5/threads", writes[0].Path)
+	assert.Equal(t, "api-version=7.0", writes[0].Query)
+	assert.Equal(t, "Bearer token", writes[0].Header.Get("Authorization"))
+	assert.Equal(t, float64(1), writes[0].Body["status"])
+	comments := writes[0].Body["comments"].([]interface{})
+	assert.Equal(t, Marker, comments[0].(map[string]interface{})["content"])
+}
+
+func TestAzurePublisherUpdatesComment(t *testing.T) {
+	standIn, server := newProviderStandIn(t, func(r *http.Request) interface{} {
+		return map[string]interface{}{"value": []azureThread{{Id: 4, Comments: []azureComment{{Id: 2, Content: Marker}}}}}
+	})
+	publisher := AzurePublisher{CollectionUri: server.URL, Project: "cli", RepositoryId: "repo-id", PullRequest: "5", Token: "token", Client: server.Client()}
+
+	err := publisher.Publish(Marker + "\nnew")
+
+	assert.NoError(t, err)
+	writes := standIn.writes()
+	assert.Len(t, writes, 1)
+	assert.Equal(t, http.MethodPatch, writes[0].Method)
+	assert.Equal(t, "/cli/_apis/git/repositories/repo-id/pullRequests/5/threads/4/comments/2", writes[0].Path)
+	assert.Equal(t, Marker+"\nnew", writes[0].Body["content"])
+}
@@ -0,0 +1,84 @@
+package comment
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const bitbucketDefaultApiUrl = "https://api.bitbucket.org/2.0"
+
+type bitbucketContent struct {
+	Raw string `json:"raw"`
+}
+
+type bitbucketComment struct {
+	Id      int64            `json:"id,omitempty"`
+	Content bitbucketContent `json:"content"`
+}
+
+type bitbucketCommentPage struct {
+	Values []bitbucketComment `json:"values"`
+	Next   string             `json:"next"`
+}
+
+// BitbucketPublisher posts pull request comments using a repository access token in BITBUCKET_ACCESS_TOKEN
+type BitbucketPublisher struct {
+	ApiUrl      string
+	Workspace   string
+	Repository  string
+	PullRequest string
+	Token       string
+	Client      *http.Client
+}
+
+func NewBitbucketPublisher(client *http.Client) (BitbucketPublisher, error) {
+	values, err := lookupEnv("BITBUCKET_WORKSPACE", "BITBUCKET_REPO_SLUG", "BITBUCKET_ACCESS_TOKEN")
+	if err != nil {
+		return BitbucketPublisher{}, err
+	}
+	pullRequest, err := lookupEnv("BITBUCKET_PR_ID")
+	if err != nil {
+		return BitbucketPublisher{}, ErrNoPullRequest
+	}
+
+	return BitbucketPublisher{
+		ApiUrl:      bitbucketDefaultApiUrl,
+		Workspace:   values[0],
+		Repository:  values[1],
+		PullRequest: pullRequest[0],
+		Token:       values[2],
+		Client:      client,
+	}, nil
+}
+
+func (p BitbucketPublisher) Publish(body string) error {
+	commentsUrl := fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%s/comments", p.ApiUrl, p.Workspace, p.Repository, p.PullRequest)
+	content := bitbucketComment{Content: bitbucketContent{Raw: body}}
+	// Follow the next links, which are absolute URLs, until the marker is found or the pages run out
+	next := commentsUrl + "?pagelen=100"
+	for next != "" {
+		var page bitbucketCommentPage
+		if err := p.request(http.MethodGet, next, nil).do(&page); err != nil {
+			return err
+		}
+		for _, comment := range page.Values {
+			if strings.Contains(comment.Content.Raw, Marker) {
+				return p.request(http.MethodPut, fmt.Sprintf("%s/%d", commentsUrl, comment.Id), content).do(nil)
+			}
+		}
+		next = page.Next
+	}
+
+	return p.request(http.MethodPost, commentsUrl, content).do(nil)
+}
+
+func (p BitbucketPublisher) request(method string, url string, body interface{}) apiRequest {
+	return apiRequest{
+		client:  p.Client,
+		method:  method,
+		url:     url,
+		headers: map[string]string{"Authorization": "Bearer " + p.Token},
+		body:    body,
+	}
+}
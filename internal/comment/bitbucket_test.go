@@ -0,0 +1,72 @@
+package comment
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBitbucketPublisher(t *testing.T) {
+	t.Setenv("BITBUCKET_WORKSPACE", "debricked")
+	t.Setenv("BITBUCKET_REPO_SLUG", "cli")
+	t.Setenv("BITBUCKET_ACCESS_TOKEN", "token")
+	t.Setenv("BITBUCKET_PR_ID", "8")
+
+	publisher, err := NewBitbucketPublisher(http.DefaultClient)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://api.bitbucket.org/2.0", publisher.ApiUrl)
+	assert.Equal(t, "8", publisher.PullRequest)
+}
+
+func TestNewBitbucketPublisherNoPullRequest(t *testing.T) {
+	t.Setenv("BITBUCKET_WORKSPACE", "debricked")
+	t.Setenv("BITBUCKET_REPO_SLUG", "cli")
+	t.Setenv("BITBUCKET_ACCESS_TOKEN", "token")
+	t.Setenv("BITBUCKET_PR_ID", "")
+
+	_, err := NewBitbucketPublisher(http.DefaultClient)
+
+	assert.ErrorIs(t, err, ErrNoPullRequest)
+}
+
+func TestBitbucketPublisherCreatesComment(t *testing.T) {
+	standIn, server := newProviderStandIn(t, func(r *http.Request) interface{} {
+		return bitbucketCommentPage{Values: []bitbucketComment{{Id: 1, Content: bitbucketContent{Raw: "comment"}}}}
+	})
+	publisher := BitbucketPublisher{ApiUrl: server.URL, Workspace: "debricked", Repository: "cli", PullRequest: "8", Token: "token", Client: server.Client()}
+
+	err := publisher.Publish(Marker)
+
+	assert.NoError(t, err)
+	writes := standIn.writes()
+	assert.Len(t, writes, 1)
+	assert.Equal(t, http.MethodPost, writes[0].Method)
+	assert.Equal(t, "/repositories/debricked/cli/pullrequests/8/comments", writes[0].Path)
+	assert.Equal(t, map[string]interface{}{"raw": Marker}, writes[0].Body["content"])
+}
+
+func TestBitbucketPublisherUpdatesCommentOnNextPage(t *testing.T) {
+	var serverUrl string
+	standIn, server := newProviderStandIn(t, func(r *http.Request) interface{} {
+		if r.URL.Query().Get("page") == "" {
+			return bitbucketCommentPage{
+				Values: []bitbucketComment{{Id: 1, Content: bitbucketContent{Raw: "comment"}}},
+				Next:   serverUrl + "/repositories/debricked/cli/pullrequests/8/comments?pagelen=100&page=2",
+			}
+		}
+
+		return bitbucketCommentPage{Values: []bitbucketComment{{Id: 3, Content: bitbucketContent{Raw: Marker}}}}
+	})
+	serverUrl = server.URL
+	publisher := BitbucketPublisher{ApiUrl: server.URL, Workspace: "debricked", Repository: "cli", PullRequest: "8", Token: "token", Client: server.Client()}
+
+	err := publisher.Publish(Marker + "\nnew")
+
+	assert.NoError(t, err)
+	writes := standIn.writes()
+	assert.Len(t, writes, 1)
+	assert.Equal(t, http.MethodPut, writes[0].Method)
+	assert.Equal(t, "/repositories/debricked/cli/pullrequests/8/comments/3", writes[0].Path)
+}
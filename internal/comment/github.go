@@ -0,0 +1,94 @@
+package comment
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const gitHubDefaultApiUrl = "https://api.github.com"
+
+var gitHubPullRequestRefRegex = regexp.MustCompile(`^refs/pull/(\d+)/merge$`)
+
+type gitHubComment struct {
+	Id   int64  `json:"id"`
+	Body string `json:"body"`
+}
+
+type GitHubPublisher struct {
+	ApiUrl      string
+	Repository  string
+	PullRequest string
+	Token       string
+	Client      *http.Client
+}
+
+func NewGitHubPublisher(client *http.Client) (GitHubPublisher, error) {
+	values, err := lookupEnv("GITHUB_REPOSITORY", "GITHUB_TOKEN")
+	if err != nil {
+		return GitHubPublisher{}, err
+	}
+	match := gitHubPullRequestRefRegex.FindStringSubmatch(os.Getenv("GITHUB_REF"))
+	if match == nil {
+		return GitHubPublisher{}, ErrNoPullRequest
+	}
+	apiUrl := os.Getenv("GITHUB_API_URL")
+	if apiUrl == "" {
+		apiUrl = gitHubDefaultApiUrl
+	}
+
+	return GitHubPublisher{
+		ApiUrl:      strings.TrimSuffix(apiUrl, "/"),
+		Repository:  values[0],
+		PullRequest: match[1],
+		Token:       values[1],
+		Client:      client,
+	}, nil
+}
+
+func (p GitHubPublisher) Publish(body string) error {
+	commentsUrl := fmt.Sprintf("%s/repos/%s/issues/%s/comments", p.ApiUrl, p.Repository, p.PullRequest)
+	existing, err := p.findComment(commentsUrl)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return p.request(http.MethodPatch, fmt.Sprintf("%s/repos/%s/issues/comments/%d", p.ApiUrl, p.Repository, existing.Id), map[string]string{"body": body}).do(nil)
+	}
+
+	return p.request(http.MethodPost, commentsUrl, map[string]string{"body": body}).do(nil)
+}
+
+func (p GitHubPublisher) findComment(commentsUrl string) (*gitHubComment, error) {
+	const perPage = 100
+	for page := 1; ; page++ {
+		var comments []gitHubComment
+		err := p.request(http.MethodGet, fmt.Sprintf("%s?per_page=%d&page=%d", commentsUrl, perPage, page), nil).do(&comments)
+		if err != nil {
+			return nil, err
+		}
+		for _, comment := range comments {
+			if strings.Contains(comment.Body, Marker) {
+				return &comment, nil
+			}
+		}
+		if len(comments) < perPage {
+			return nil, nil
+		}
+	}
+}
+
+func (p GitHubPublisher) request(method string, url string, body interface{}) apiRequest {
+	return apiRequest{
+		client: p.Client,
+		method: method,
+		url:    url,
+		headers: map[string]string{
+			"Authorization":        "Bearer " + p.Token,
+			"X-GitHub-Api-Version": "2022-11-28",
+		},
+		body: body,
+	}
+}
@@ -0,0 +1,87 @@
+package comment
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGitHubPublisher(t *testing.T) {
+	t.Setenv("GITHUB_REPOSITORY", "debricked/cli")
+	t.Setenv("GITHUB_TOKEN", "token")
+	t.Setenv("GITHUB_REF", "refs/pull/12/merge")
+	t.Setenv("GITHUB_API_URL", "https://github.example.com/api/v3/")
+
+	publisher, err := NewGitHubPublisher(http.DefaultClient)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://github.example.com/api/v3", publisher.ApiUrl)
+	assert.Equal(t, "debricked/cli", publisher.Repository)
+	assert.Equal(t, "12", publisher.PullRequest)
+}
+
+func TestNewGitHubPublisherNoPullRequest(t *testing.T) {
+	t.Setenv("GITHUB_REPOSITORY", "debricked/cli")
+	t.Setenv("GITHUB_TOKEN", "token")
+	t.Setenv("GITHUB_REF", "refs/heads/main")
+
+	_, err := NewGitHubPublisher(http.DefaultClient)
+
+	assert.ErrorIs(t, err, ErrNoPullRequest)
+}
+
+func TestGitHubPublisherCreatesComment(t *testing.T) {
+	standIn, server := newProviderStandIn(t, func(r *http.Request) interface{} {
+		return []gitHubComment{{Id: 1, Body: "LGTM"}}
+	})
+	publisher := GitHubPublisher{ApiUrl: server.URL, Repository: "debricked/cli", PullRequest: "12", Token: "token", Client: server.Client()}
+
+	err := publisher.Publish(Marker + "\nsummary")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "per_page=100&page=1", standIn.requests[0].Query)
+	writes := standIn.writes()
+	assert.Len(t, writes, 1)
+	assert.Equal(t, http.MethodPost, writes[0].Method)
+	assert.Equal(t, "/repos/debricked/cli/issues/12/comments", writes[0].Path)
+	assert.Equal(t, "Bearer token", writes[0].Header.Get("Authorization"))
+	assert.Equal(t, Marker+"\nsummary", writes[0].Body["body"])
+}
+
+func TestGitHubPublisherUpdatesComment(t *testing.T) {
+	standIn, server := newProviderStandIn(t, func(r *http.Request) interface{} {
+		// The summary is on the second page
+		if r.URL.Query().Get("page") == "1" {
+			comments := make([]gitHubComment, 100)
+			for i := range comments {
+				comments[i] = gitHubComment{Id: int64(i), Body: "comment"}
+			}
+
+			return comments
+		}
+
+		return []gitHubComment{{Id: 7, Body: Marker + "\nold summary"}}
+	})
+	publisher := GitHubPublisher{ApiUrl: server.URL, Repository: "debricked/cli", PullRequest: "12", Token: "token", Client: server.Client()}
+
+	err := publisher.Publish(Marker + "\nnew summary")
+
+	assert.NoError(t, err)
+	writes := standIn.writes()
+	assert.Len(t, writes, 1)
+	assert.Equal(t, http.MethodPatch, writes[0].Method)
+	assert.Equal(t, "/repos/debricked/cli/issues/comments/7", writes[0].Path)
+	assert.Equal(t, Marker+"\nnew summary", writes[0].Body["body"])
+}
+
+func TestGitHubPublisherListError(t *testing.T) {
+	standIn, server := newProviderStandIn(t, nil)
+	standIn.status = http.StatusUnauthorized
+	publisher := GitHubPublisher{ApiUrl: server.URL, Repository: "debricked/cli", PullRequest: "12", Client: server.Client()}
+
+	err := publisher.Publish(Marker)
+
+	assert.ErrorContains(t, err, "status code 401")
+	assert.Empty(t, standIn.writes())
+}
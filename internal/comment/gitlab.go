@@ -0,0 +1,82 @@
+package comment
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type gitLabNote struct {
+	Id   int64  `json:"id"`
+	Body string `json:"body"`
+}
+
+// GitLabPublisher posts merge request notes. CI_JOB_TOKEN cannot write notes, so a project or personal access token is needed in GITLAB_TOKEN.
+type GitLabPublisher struct {
+	ApiUrl       string
+	ProjectId    string
+	MergeRequest string
+	Token        string
+	Client       *http.Client
+}
+
+func NewGitLabPublisher(client *http.Client) (GitLabPublisher, error) {
+	values, err := lookupEnv("CI_API_V4_URL", "CI_PROJECT_ID", "GITLAB_TOKEN")
+	if err != nil {
+		return GitLabPublisher{}, err
+	}
+	mergeRequest, err := lookupEnv("CI_MERGE_REQUEST_IID")
+	if err != nil {
+		return GitLabPublisher{}, ErrNoPullRequest
+	}
+
+	return GitLabPublisher{
+		ApiUrl:       strings.TrimSuffix(values[0], "/"),
+		ProjectId:    values[1],
+		MergeRequest: mergeRequest[0],
+		Token:        values[2],
+		Client:       client,
+	}, nil
+}
+
+func (p GitLabPublisher) Publish(body string) error {
+	notesUrl := fmt.Sprintf("%s/projects/%s/merge_requests/%s/notes", p.ApiUrl, p.ProjectId, p.MergeRequest)
+	existing, err := p.findNote(notesUrl)
+	if err != nil {
+		return err
+	}
+	if existing != nil {
+		return p.request(http.MethodPut, fmt.Sprintf("%s/%d", notesUrl, existing.Id), map[string]string{"body": body}).do(nil)
+	}
+
+	return p.request(http.MethodPost, notesUrl, map[string]string{"body": body}).do(nil)
+}
+
+func (p GitLabPublisher) findNote(notesUrl string) (*gitLabNote, error) {
+	const perPage = 100
+	for page := 1; ; page++ {
+		var notes []gitLabNote
+		err := p.request(http.MethodGet, fmt.Sprintf("%s?per_page=%d&page=%d", notesUrl, perPage, page), nil).do(&notes)
+		if err != nil {
+			return nil, err
+		}
+		for _, note := range notes {
+			if strings.Contains(note.Body, Marker) {
+				return &note, nil
+			}
+		}
+		if len(notes) < perPage {
+			return nil, nil
+		}
+	}
+}
+
+func (p GitLabPublisher) request(method string, url string, body interface{}) apiRequest {
+	return apiRequest{
+		client:  p.Client,
+		method:  method,
+		url:     url,
+		headers: map[string]string{"PRIVATE-TOKEN": p.Token},
+		body:    body,
+	}
+}
@@ -0,0 +1,65 @@
+package comment
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGitLabPublisher(t *testing.T) {
+	t.Setenv("CI_API_V4_URL", "https://gitlab.com/api/v4")
+	t.Setenv("CI_PROJECT_ID", "42")
+	t.Setenv("GITLAB_TOKEN", "token")
+	t.Setenv("CI_MERGE_REQUEST_IID", "3")
+
+	publisher, err := NewGitLabPublisher(http.DefaultClient)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://gitlab.com/api/v4", publisher.ApiUrl)
+	assert.Equal(t, "42", publisher.ProjectId)
+	assert.Equal(t, "3", publisher.MergeRequest)
+}
+
+func TestNewGitLabPublisherNoMergeRequest(t *testing.T) {
+	t.Setenv("CI_API_V4_URL", "https://gitlab.com/api/v4")
+	t.Setenv("CI_PROJECT_ID", "42")
+	t.Setenv("GITLAB_TOKEN", "token")
+	t.Setenv("CI_MERGE_REQUEST_IID", "")
+
+	_, err := NewGitLabPublisher(http.DefaultClient)
+
+	assert.ErrorIs(t, err, ErrNoPullRequest)
+}
+
+func TestGitLabPublisherCreatesNote(t *testing.T) {
+	standIn, server := newProviderStandIn(t, func(r *http.Request) interface{} {
+		return []gitLabNote{}
+	})
+	publisher := GitLabPublisher{ApiUrl: server.URL, ProjectId: "42", MergeRequest: "3", Token: "token", Client: server.Client()}
+
+	err := publisher.Publish(Marker)
+
+	assert.NoError(t, err)
+	writes := standIn.writes()
+	assert.Len(t, writes, 1)
+	assert.Equal(t, http.MethodPost, writes[0].Method)
+	assert.Equal(t, "/projects/42/merge_requests/3/notes", writes[0].Path)
+	assert.Equal(t, "token", writes[0].Header.Get("PRIVATE-TOKEN"))
+}
+
+func TestGitLabPublisherUpdatesNote(t *testing.T) {
+	standIn, server := newProviderStandIn(t, func(r *http.Request) interface{} {
+		return []gitLabNote{{Id: 1, Body: "comment"}, {Id: 9, Body: Marker}}
+	})
+	publisher := GitLabPublisher{ApiUrl: server.URL, ProjectId: "42", MergeRequest: "3", Token: "token", Client: server.Client()}
+
+	err := publisher.Publish(Marker + "\nnew")
+
+	assert.NoError(t, err)
+	writes := standIn.writes()
+	assert.Len(t, writes, 1)
+	assert.Equal(t, http.MethodPut, writes[0].Method)
+	assert.Equal(t, "/projects/42/merge_requests/3/notes/9", writes[0].Path)
+	assert.Equal(t, Marker+"\nnew", writes[0].Body["body"])
+}
@@ -0,0 +1,109 @@
+package comment
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/upload"
+)
+
+// Marker identifies the summary comment, so that later scans update it instead of posting a new one
+const Marker = "<!-- debricked-scan-summary -->"
+
+// NewSummary renders result as a Markdown pull request comment
+func NewSummary(result *upload.UploadResult) string {
+	var b strings.Builder
+	b.WriteString(Marker + "\n")
+	b.WriteString("## Debricked scan summary\n\n")
+	b.WriteString(fmt.Sprintf("**%d %s found**\n\n", result.VulnerabilitiesFound, plural(result.VulnerabilitiesFound, "vulnerability", "vulnerabilities")))
+
+	var triggered []automation.Rule
+	for _, rule := range result.AutomationRules {
+		if rule.Triggered {
+			triggered = append(triggered, rule)
+		}
+	}
+	if len(triggered) == 0 {
+		b.WriteString("No automation rules were triggered.\n\n")
+	} else {
+		b.WriteString(fmt.Sprintf("### %d triggered %s\n\n", len(triggered), plural(len(triggered), "rule", "rules")))
+	}
+	for _, rule := range triggered {
+		writeRule(&b, rule)
+	}
+
+	if result.DetailsUrl != "" {
+		b.WriteString(fmt.Sprintf("[View full details on Debricked](%s)\n", result.DetailsUrl))
+	}
+
+	return b.String()
+}
+
+func writeRule(b *strings.Builder, rule automation.Rule) {
+	icon := "⚠️"
+	if rule.FailPipeline() {
+		icon = "❌"
+	}
+	description := strings.Join(strings.Fields(rule.RuleDescription), " ")
+	b.WriteString(fmt.Sprintf("#### %s %s\n\n", icon, description))
+	if rule.RuleLink != "" {
+		b.WriteString(fmt.Sprintf("[Manage rule](%s)\n\n", rule.RuleLink))
+	}
+	if len(rule.TriggerEvents) == 0 {
+		return
+	}
+
+	b.WriteString("| Dependency | Vulnerability | CVSS | Licenses |\n")
+	b.WriteString("| --- | --- | --- | --- |\n")
+	events := append([]automation.TriggerEvent(nil), rule.TriggerEvents...)
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Dependency < events[j].Dependency
+	})
+	for _, event := range events {
+		b.WriteString(fmt.Sprintf(
+			"| %s | %s | %s | %s |\n",
+			link(event.Dependency, event.DependencyLink),
+			link(event.Cve, event.CveLink),
+			score(event),
+			escape(strings.Join(event.Licenses, ", ")),
+		))
+	}
+	b.WriteString("\n")
+}
+
+func score(event automation.TriggerEvent) string {
+	switch {
+	case event.Cvss3 > 0:
+		return fmt.Sprintf("%g", event.Cvss3)
+	case event.Cvss2 > 0:
+		return fmt.Sprintf("%g", event.Cvss2)
+	default:
+		return ""
+	}
+}
+
+func link(text string, url string) string {
+	if text == "" {
+		return ""
+	}
+	if url == "" {
+		return escape(text)
+	}
+
+	return fmt.Sprintf("[%s](%s)", escape(text), url)
+}
+
+// escape prevents text from breaking the table layout
+func escape(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(text)
+}
+
+func plural(count int, singular string, plural string) string {
+	if count == 1 {
+		return singular
+	}
+
+	return plural
+}
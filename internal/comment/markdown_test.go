@@ -0,0 +1,66 @@
+package comment
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/upload"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSummary(t *testing.T) {
+	result := &upload.UploadResult{
+		VulnerabilitiesFound: 2,
+		DetailsUrl:           "https://debricked.com/app/en/repository/1/commit/2",
+		AutomationRules: []automation.Rule{
+			{
+				RuleDescription: "If a vulnerability with CVSS of at least 7 is found\nthen fail pipeline",
+				RuleActions:     []string{"failPipeline"},
+				RuleLink:        "https://debricked.com/app/en/automations/rule/1",
+				Triggered:       true,
+				TriggerEvents: []automation.TriggerEvent{
+					{Dependency: "minimist (npm)", Cve: "CVE-2021-44906", Cvss2: 7.5},
+					{
+						Dependency:     "lodash (npm)",
+						DependencyLink: "https://debricked.com/app/en/dependency/1",
+						Cve:            "CVE-2021-23337",
+						CveLink:        "https://debricked.com/app/en/vulnerability/1",
+						Cvss2:          6.5,
+						Cvss3:          7.2,
+					},
+				},
+			},
+			{
+				RuleDescription: "Notify on GPL",
+				RuleActions:     []string{"sendEmail"},
+				Triggered:       true,
+				TriggerEvents:   []automation.TriggerEvent{{Dependency: "readline (npm)", Licenses: []string{"GPL-2.0|GPL-3.0"}}},
+			},
+			{RuleDescription: "Not triggered"},
+		},
+	}
+
+	summary := NewSummary(result)
+
+	assert.True(t, strings.HasPrefix(summary, Marker+"\n"))
+	assert.Contains(t, summary, "**2 vulnerabilities found**")
+	assert.Contains(t, summary, "### 2 triggered rules")
+	assert.Contains(t, summary, "#### ❌ If a vulnerability with CVSS of at least 7 is found then fail pipeline")
+	assert.Contains(t, summary, "[Manage rule](https://debricked.com/app/en/automations/rule/1)")
+	assert.Contains(t, summary, "| [lodash (npm)](https://debricked.com/app/en/dependency/1) | [CVE-2021-23337](https://debricked.com/app/en/vulnerability/1) | 7.2 |  |")
+	assert.Contains(t, summary, "| minimist (npm) | CVE-2021-44906 | 7.5 |  |")
+	assert.Less(t, strings.Index(summary, "lodash"), strings.Index(summary, "minimist"))
+	assert.Contains(t, summary, "#### ⚠️ Notify on GPL")
+	assert.Contains(t, summary, `GPL-2.0\|GPL-3.0`)
+	assert.NotContains(t, summary, "Not triggered")
+	assert.Contains(t, summary, "[View full details on Debricked](https://debricked.com/app/en/repository/1/commit/2)")
+}
+
+func TestNewSummaryNothingTriggered(t *testing.T) {
+	summary := NewSummary(&upload.UploadResult{VulnerabilitiesFound: 1})
+
+	assert.Contains(t, summary, "**1 vulnerability found**")
+	assert.Contains(t, summary, "No automation rules were triggered.")
+	assert.NotContains(t, summary, "View full details")
+}
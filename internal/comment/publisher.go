@@ -0,0 +1,112 @@
+package comment
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/debricked/cli/internal/ci/azure"
+	"github.com/debricked/cli/internal/ci/bitbucket"
+	"github.com/debricked/cli/internal/ci/github"
+	"github.com/debricked/cli/internal/ci/gitlab"
+)
+
+const requestTimeout = 30 * time.Second
+
+var (
+	ErrUnsupportedIntegration = errors.New("pull request comments are supported on GitHub Actions, GitLab CI, Azure Pipelines and Bitbucket Pipelines")
+	ErrNoPullRequest          = errors.New("no pull request found for the current build")
+)
+
+type MissingEnvError struct {
+	Key string
+}
+
+func (e MissingEnvError) Error() string {
+	return fmt.Sprintf("environment variable %s is required to publish pull request comments", e.Key)
+}
+
+// IPublisher posts a comment on the pull request of the current build, or updates the one posted by an earlier build
+type IPublisher interface {
+	Publish(body string) error
+}
+
+// NewPublisher creates the publisher of the CI integration found by ci.Service
+func NewPublisher(integration string) (IPublisher, error) {
+	httpClient := &http.Client{Timeout: requestTimeout}
+	switch integration {
+	case github.Integration:
+		return NewGitHubPublisher(httpClient)
+	case gitlab.Integration:
+		return NewGitLabPublisher(httpClient)
+	case azure.Integration:
+		return NewAzurePublisher(httpClient)
+	case bitbucket.Integration:
+		return NewBitbucketPublisher(httpClient)
+	}
+
+	return nil, ErrUnsupportedIntegration
+}
+
+// lookupEnv returns the values of keys, failing on the first unset key
+func lookupEnv(keys ...string) ([]string, error) {
+	values := make([]string, len(keys))
+	for i, key := range keys {
+		values[i] = os.Getenv(key)
+		if values[i] == "" {
+			return nil, MissingEnvError{Key: key}
+		}
+	}
+
+	return values, nil
+}
+
+type apiRequest struct {
+	client  *http.Client
+	method  string
+	url     string
+	headers map[string]string
+	body    interface{}
+}
+
+// do sends the request and decodes a JSON response into out, unless out is nil
+func (r apiRequest) do(out interface{}) error {
+	var body io.Reader
+	if r.body != nil {
+		content, err := json.Marshal(r.body)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(content)
+	}
+	req, err := http.NewRequest(r.method, r.url, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if r.body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range r.headers {
+		req.Header.Set(key, value)
+	}
+
+	res, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%s %s failed with status code %d", r.method, req.URL.Redacted(), res.StatusCode)
+	}
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(res.Body).Decode(out)
+}
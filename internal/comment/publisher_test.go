@@ -0,0 +1,102 @@
+package comment
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/debricked/cli/internal/ci/azure"
+	"github.com/debricked/cli/internal/ci/bitbucket"
+	"github.com/debricked/cli/internal/ci/github"
+	"github.com/debricked/cli/internal/ci/gitlab"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedRequest struct {
+	Method string
+	Path   string
+	Query  string
+	Header http.Header
+	Body   map[string]interface{}
+}
+
+// providerStandIn is a local HTTP stand-in for a provider API, answering GET requests with listing
+type providerStandIn struct {
+	mu       sync.Mutex
+	requests []recordedRequest
+	listing  func(r *http.Request) interface{}
+	status   int
+}
+
+func newProviderStandIn(t *testing.T, listing func(r *http.Request) interface{}) (*providerStandIn, *httptest.Server) {
+	standIn := &providerStandIn{listing: listing, status: http.StatusOK}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		standIn.mu.Lock()
+		defer standIn.mu.Unlock()
+		request := recordedRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.RawQuery, Header: r.Header}
+		content, _ := io.ReadAll(r.Body)
+		if len(content) > 0 {
+			_ = json.Unmarshal(content, &request.Body)
+		}
+		standIn.requests = append(standIn.requests, request)
+		if standIn.status != http.StatusOK {
+			w.WriteHeader(standIn.status)
+
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(standIn.listing(r))
+
+			return
+		}
+		_, _ = w.Write([]byte("{}"))
+	}))
+	t.Cleanup(server.Close)
+
+	return standIn, server
+}
+
+func (s *providerStandIn) writes() []recordedRequest {
+	var writes []recordedRequest
+	for _, request := range s.requests {
+		if request.Method != http.MethodGet {
+			writes = append(writes, request)
+		}
+	}
+
+	return writes
+}
+
+func TestNewPublisher(t *testing.T) {
+	t.Setenv("GITHUB_REPOSITORY", "debricked/cli")
+	t.Setenv("GITHUB_TOKEN", "token")
+	t.Setenv("GITHUB_REF", "refs/pull/12/merge")
+	publisher, err := NewPublisher(github.Integration)
+	assert.NoError(t, err)
+	assert.IsType(t, GitHubPublisher{}, publisher)
+
+	_, err = NewPublisher("travis")
+	assert.ErrorIs(t, err, ErrUnsupportedIntegration)
+
+	for _, integration := range []string{gitlab.Integration, azure.Integration, bitbucket.Integration} {
+		_, err = NewPublisher(integration)
+		assert.ErrorAs(t, err, &MissingEnvError{}, integration)
+	}
+}
+
+func TestMissingEnvError(t *testing.T) {
+	assert.Equal(t, "environment variable GITHUB_TOKEN is required to publish pull request comments", MissingEnvError{Key: "GITHUB_TOKEN"}.Error())
+}
+
+func TestApiRequestErrorStatus(t *testing.T) {
+	standIn, server := newProviderStandIn(t, nil)
+	standIn.status = http.StatusForbidden
+
+	err := apiRequest{client: server.Client(), method: http.MethodPost, url: server.URL + "/comments", body: map[string]string{}}.do(nil)
+
+	assert.ErrorContains(t, err, "POST "+server.URL+"/comments failed with status code 403")
+}
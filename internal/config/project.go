@@ -0,0 +1,117 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectConfigFileName is the name of the project-level CLI configuration file. Top-level keys are flag names
+// applying to every command, while the commands section sets defaults per command:
+//
+//	exclusion:
+//	  - "**/node_modules/**"
+//	commands:
+//	  scan:
+//	    poll-interval: 5
+//	  files:
+//	    find:
+//	      strict: 1
+//
+// Values are used when neither the flag nor the environment variable is set, so precedence is flags > env > file.
+const ProjectConfigFileName = ".debricked.yaml"
+
+const commandsKey = "commands"
+
+type ProjectConfig struct {
+	Path   string
+	values map[string]interface{}
+}
+
+// FindProjectConfig searches rootPath and its parent directories, up to the root of the git repository, for
+// ProjectConfigFileName. An empty string is returned if there is none.
+func FindProjectConfig(rootPath string) string {
+	dir, err := filepath.Abs(rootPath)
+	if err != nil {
+		return ""
+	}
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+	for {
+		path := filepath.Join(dir, ProjectConfigFileName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+func LoadProjectConfig(path string) (*ProjectConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]interface{}{}
+	if err = yaml.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	if commands, ok := values[commandsKey]; ok {
+		if _, isMap := commands.(map[string]interface{}); !isMap {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, errors.New(commandsKey+" must be a mapping"))
+		}
+	}
+
+	return &ProjectConfig{Path: path, values: values}, nil
+}
+
+// CommandValues returns the flag values for the command at commandPath, such as `debricked files find`, where the
+// first name is the root command. Values of a command override those of its parent commands, which override the
+// top-level values.
+func (projectConfig *ProjectConfig) CommandValues(commandPath string) map[string]interface{} {
+	merged := map[string]interface{}{}
+	mergeFlagValues(merged, projectConfig.values, commandsKey)
+	section, _ := projectConfig.values[commandsKey].(map[string]interface{})
+	names := strings.Fields(commandPath)
+	if len(names) > 0 {
+		names = names[1:]
+	}
+	for _, name := range names {
+		section, _ = section[name].(map[string]interface{})
+		if section == nil {
+			break
+		}
+		mergeFlagValues(merged, section, "")
+	}
+
+	return merged
+}
+
+// Value returns the top-level value of key, such as a section configuring something other than flags, or nil
+func (projectConfig *ProjectConfig) Value(key string) interface{} {
+	return projectConfig.values[key]
+}
+
+// mergeFlagValues copies the values of section into merged. Mappings are subcommand sections and left out.
+func mergeFlagValues(merged map[string]interface{}, section map[string]interface{}, skip string) {
+	for key, value := range section {
+		if _, isSection := value.(map[string]interface{}); isSection || key == skip {
+			continue
+		}
+		merged[key] = value
+	}
+}
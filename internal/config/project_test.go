@@ -0,0 +1,124 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const projectConfig = `exclusion:
+  - "**/vendor/**"
+verbose: true
+commands:
+  scan:
+    verbose: false
+    poll-interval: 5
+    status:
+      poll-interval: 10
+  files:
+    find:
+      strict: 2
+`
+
+func writeProjectConfig(t *testing.T, dir string, content string) string {
+	path := filepath.Join(dir, ProjectConfigFileName)
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestFindProjectConfig(t *testing.T) {
+	repository := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(repository, ".git"), 0755))
+	service := filepath.Join(repository, "services", "api")
+	assert.NoError(t, os.MkdirAll(service, 0755))
+
+	assert.Empty(t, FindProjectConfig(service))
+
+	path := writeProjectConfig(t, repository, projectConfig)
+	assert.Equal(t, path, FindProjectConfig(service))
+	assert.Equal(t, path, FindProjectConfig(repository))
+
+	servicePath := writeProjectConfig(t, service, "verbose: true")
+	assert.Equal(t, servicePath, FindProjectConfig(service))
+}
+
+func TestFindProjectConfigStopsAtRepositoryRoot(t *testing.T) {
+	parent := t.TempDir()
+	writeProjectConfig(t, parent, projectConfig)
+	repository := filepath.Join(parent, "repository")
+	assert.NoError(t, os.MkdirAll(filepath.Join(repository, ".git"), 0755))
+
+	assert.Empty(t, FindProjectConfig(repository))
+}
+
+func TestCommandValues(t *testing.T) {
+	projectConfig, err := LoadProjectConfig(writeProjectConfig(t, t.TempDir(), projectConfig))
+	assert.NoError(t, err)
+
+	cases := map[string]map[string]interface{}{
+		"debricked resolve": {
+			"exclusion": []interface{}{"**/vendor/**"},
+			"verbose":   true,
+		},
+		"debricked scan": {
+			"exclusion":     []interface{}{"**/vendor/**"},
+			"verbose":       false,
+			"poll-interval": 5,
+		},
+		"debricked scan status": {
+			"exclusion":     []interface{}{"**/vendor/**"},
+			"verbose":       false,
+			"poll-interval": 10,
+		},
+		"debricked files find": {
+			"exclusion": []interface{}{"**/vendor/**"},
+			"verbose":   true,
+			"strict":    2,
+		},
+	}
+	for commandPath, expected := range cases {
+		assert.Equal(t, expected, projectConfig.CommandValues(commandPath), commandPath)
+	}
+}
+
+func TestValue(t *testing.T) {
+	content := "registries:\n  npm:\n    url: https://npm.example.com/\n"
+	projectConfig, err := LoadProjectConfig(writeProjectConfig(t, t.TempDir(), content))
+	assert.NoError(t, err)
+
+	registries, isMap := projectConfig.Value("registries").(map[string]interface{})
+	assert.True(t, isMap)
+	assert.Contains(t, registries, "npm")
+	assert.Nil(t, projectConfig.Value("commands"))
+	assert.Empty(t, projectConfig.CommandValues("debricked scan"))
+}
+
+func TestLoadProjectConfigEmpty(t *testing.T) {
+	projectConfig, err := LoadProjectConfig(writeProjectConfig(t, t.TempDir(), ""))
+
+	assert.NoError(t, err)
+	assert.Empty(t, projectConfig.CommandValues("debricked scan"))
+}
+
+func TestLoadProjectConfigInvalid(t *testing.T) {
+	cases := map[string]string{
+		"exclusion: [":     "failed to parse",
+		"- verbose":        "failed to parse",
+		"commands: [scan]": "commands must be a mapping",
+	}
+	for content, expectedErr := range cases {
+		projectConfig, err := LoadProjectConfig(writeProjectConfig(t, t.TempDir(), content))
+		assert.Nil(t, projectConfig, content)
+		assert.ErrorContains(t, err, expectedErr, content)
+	}
+}
+
+func TestLoadProjectConfigMissing(t *testing.T) {
+	projectConfig, err := LoadProjectConfig(filepath.Join(t.TempDir(), ProjectConfigFileName))
+
+	assert.Nil(t, projectConfig)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
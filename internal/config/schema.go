@@ -0,0 +1,377 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/debricked/cli/internal/policy"
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaVersion is the latest version of the debricked-config.yaml schema. Configs without a version use version 1.
+const SchemaVersion = 1
+
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+const (
+	keyVersion     = "version"
+	keyOverrides   = "overrides"
+	keyIgnore      = "ignore"
+	keyPolicies    = "policies"
+	keyPURL        = "pURL"
+	keyFileRegexes = "fileRegexes"
+	keyPackages    = "packages"
+	keyLicenses    = "licenses"
+	keyAllow       = "allow"
+	keyDeny        = "deny"
+	keyMaxCvss     = "maxCvss"
+)
+
+var yamlErrorLine = regexp.MustCompile(`line (\d+)`)
+
+// Issue is a problem found in debricked-config.yaml. Line and Column are 1-based, and zero if unknown.
+type Issue struct {
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+func (issue Issue) String() string {
+	return fmt.Sprintf("%d:%d: %s: %s", issue.Line, issue.Column, issue.Severity, issue.Message)
+}
+
+// HasErrors reports whether any of issues is an error
+func HasErrors(issues []Issue) bool {
+	for _, issue := range issues {
+		if issue.Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+type schemaValidator struct {
+	issues []Issue
+}
+
+// ValidateSchema strictly validates content against the debricked-config.yaml schema.
+// Unlike upload.GetDebrickedConfig, unknown keys and values of the wrong type are reported instead of dropped.
+func ValidateSchema(content []byte) []Issue {
+	validator := &schemaValidator{issues: []Issue{}}
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		line := 0
+		if match := yamlErrorLine.FindStringSubmatch(err.Error()); match != nil {
+			line, _ = strconv.Atoi(match[1])
+		}
+		validator.issues = append(validator.issues, Issue{
+			Line:     line,
+			Severity: SeverityError,
+			Message:  strings.TrimPrefix(err.Error(), "yaml: "),
+		})
+
+		return validator.issues
+	}
+	if len(document.Content) == 0 {
+		validator.warn(&document, "config is empty")
+
+		return validator.issues
+	}
+	validator.validateRoot(document.Content[0])
+	sortIssues(validator.issues)
+
+	return validator.issues
+}
+
+func sortIssues(issues []Issue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].Line != issues[j].Line {
+			return issues[i].Line < issues[j].Line
+		}
+
+		return issues[i].Column < issues[j].Column
+	})
+}
+
+func (validator *schemaValidator) error(node *yaml.Node, format string, args ...interface{}) {
+	validator.add(node, SeverityError, fmt.Sprintf(format, args...))
+}
+
+func (validator *schemaValidator) warn(node *yaml.Node, format string, args ...interface{}) {
+	validator.add(node, SeverityWarning, fmt.Sprintf(format, args...))
+}
+
+func (validator *schemaValidator) add(node *yaml.Node, severity string, message string) {
+	validator.issues = append(validator.issues, Issue{
+		Line:     node.Line,
+		Column:   node.Column,
+		Severity: severity,
+		Message:  message,
+	})
+}
+
+func (validator *schemaValidator) validateRoot(root *yaml.Node) {
+	if !validator.expectMapping(root, "config") {
+		return
+	}
+	overridesKey := ""
+	validator.forEachKey(root, func(key *yaml.Node, value *yaml.Node) {
+		name := key.Value
+		if isOverridesKey(name) {
+			if overridesKey != "" {
+				validator.error(key, "\"%s\" and \"%s\" are both set, use only \"%s\"", overridesKey, name, keyOverrides)
+			}
+			overridesKey = name
+			if name != keyOverrides {
+				validator.warn(key, "\"%s\" is deprecated, use \"%s\"", name, keyOverrides)
+			}
+			name = keyOverrides
+		}
+		switch name {
+		case keyVersion:
+			validator.validateVersion(value)
+		case keyOverrides:
+			validator.validateOverrides(value)
+		case keyIgnore:
+			validator.validateIgnore(value)
+		case keyPolicies:
+			validator.validatePolicies(value)
+		default:
+			validator.unknownKey(key, "config", keyVersion, keyOverrides, keyIgnore, keyPolicies)
+		}
+	})
+}
+
+func (validator *schemaValidator) validateVersion(node *yaml.Node) {
+	version, err := strconv.Atoi(node.Value)
+	if node.Kind != yaml.ScalarNode || node.Tag != "!!int" || err != nil {
+		validator.error(node, "version must be an integer")
+
+		return
+	}
+	if version < 1 || version > SchemaVersion {
+		validator.error(node, "unsupported version %d, the latest supported version is %d", version, SchemaVersion)
+	}
+}
+
+func (validator *schemaValidator) validateOverrides(node *yaml.Node) {
+	if !validator.expectSequence(node, keyOverrides) {
+		return
+	}
+	for _, override := range node.Content {
+		if !validator.expectMapping(override, "override") {
+			continue
+		}
+		hasPURL := false
+		validator.forEachKey(override, func(key *yaml.Node, value *yaml.Node) {
+			switch key.Value {
+			case keyPURL:
+				hasPURL = true
+				validator.validatePURL(value)
+			case keyVersion:
+				if validator.expectScalar(value, keyVersion) && value.Tag == "!!bool" && value.Value != "false" {
+					validator.error(value, "version must be a version string or false")
+				}
+			case keyFileRegexes:
+				validator.validateFileRegexes(value)
+			default:
+				validator.unknownKey(key, "override", keyPURL, keyVersion, keyFileRegexes)
+			}
+		})
+		if !hasPURL {
+			validator.error(override, "override is missing \"%s\"", keyPURL)
+		}
+	}
+}
+
+func (validator *schemaValidator) validateFileRegexes(node *yaml.Node) {
+	if !validator.expectSequence(node, keyFileRegexes) {
+		return
+	}
+	for _, fileRegex := range node.Content {
+		if !validator.expectScalar(fileRegex, "file regex") {
+			continue
+		}
+		if _, err := regexp.Compile(fileRegex.Value); err != nil {
+			// Debricked uses PCRE2, so regexes Go cannot compile may still be valid
+			validator.warn(fileRegex, "file regex \"%s\" cannot be checked locally: %s", fileRegex.Value, err.Error())
+		}
+	}
+}
+
+func (validator *schemaValidator) validatePURL(node *yaml.Node) {
+	if !validator.expectScalar(node, keyPURL) {
+		return
+	}
+	if !strings.HasPrefix(node.Value, "pkg:") {
+		validator.error(node, "\"%s\" is not a package URL, expected pkg:<type>/<name>", node.Value)
+
+		return
+	}
+	if _, err := policy.ParsePackageSpec(node.Value); err != nil {
+		validator.error(node, "invalid package URL \"%s\": %s", node.Value, err.Error())
+	}
+}
+
+func (validator *schemaValidator) validateIgnore(node *yaml.Node) {
+	if !validator.expectMapping(node, keyIgnore) {
+		return
+	}
+	validator.forEachKey(node, func(key *yaml.Node, value *yaml.Node) {
+		if key.Value != keyPackages {
+			validator.unknownKey(key, keyIgnore, keyPackages)
+
+			return
+		}
+		if !validator.expectSequence(value, keyPackages) {
+			return
+		}
+		for _, ignored := range value.Content {
+			if !validator.expectMapping(ignored, "ignored package") {
+				continue
+			}
+			hasPURL := false
+			validator.forEachKey(ignored, func(key *yaml.Node, value *yaml.Node) {
+				switch key.Value {
+				case keyPURL:
+					hasPURL = true
+					validator.validatePURL(value)
+				case keyVersion:
+					validator.expectScalar(value, keyVersion)
+				default:
+					validator.unknownKey(key, "ignored package", keyPURL, keyVersion)
+				}
+			})
+			if !hasPURL {
+				validator.error(ignored, "ignored package is missing \"%s\"", keyPURL)
+			}
+		}
+	})
+}
+
+func (validator *schemaValidator) validatePolicies(node *yaml.Node) {
+	if !validator.expectMapping(node, keyPolicies) {
+		return
+	}
+	validator.forEachKey(node, func(key *yaml.Node, value *yaml.Node) {
+		switch key.Value {
+		case keyAllow:
+			validator.validatePolicyPackages(value, keyAllow, keyPackages)
+		case keyDeny:
+			validator.validatePolicyPackages(value, keyDeny, keyPackages, keyLicenses)
+		case keyMaxCvss:
+			cvss, err := strconv.ParseFloat(value.Value, 64)
+			if value.Kind != yaml.ScalarNode || (value.Tag != "!!int" && value.Tag != "!!float") || err != nil {
+				validator.error(value, "%s must be a number", keyMaxCvss)
+			} else if cvss < 0 || cvss > 10 {
+				validator.error(value, "%s must be between 0 and 10", keyMaxCvss)
+			}
+		default:
+			validator.unknownKey(key, keyPolicies, keyAllow, keyDeny, keyMaxCvss)
+		}
+	})
+}
+
+func (validator *schemaValidator) validatePolicyPackages(node *yaml.Node, section string, keys ...string) {
+	if !validator.expectMapping(node, section) {
+		return
+	}
+	validator.forEachKey(node, func(key *yaml.Node, value *yaml.Node) {
+		if !containsKey(keys, key.Value) {
+			validator.unknownKey(key, section, keys...)
+
+			return
+		}
+		if !validator.expectSequence(value, key.Value) {
+			return
+		}
+		for _, entry := range value.Content {
+			if !validator.expectScalar(entry, key.Value) || key.Value != keyPackages {
+				continue
+			}
+			if _, err := policy.ParsePackageSpec(entry.Value); err != nil {
+				validator.error(entry, "invalid package \"%s\": %s", entry.Value, err.Error())
+			}
+		}
+	})
+}
+
+// forEachKey calls f for every key of mapping, reporting keys that are set more than once
+func (validator *schemaValidator) forEachKey(mapping *yaml.Node, f func(key *yaml.Node, value *yaml.Node)) {
+	seen := map[string]bool{}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key, value := mapping.Content[i], mapping.Content[i+1]
+		if seen[key.Value] {
+			validator.error(key, "duplicate key \"%s\"", key.Value)
+		}
+		seen[key.Value] = true
+		f(key, value)
+	}
+}
+
+func (validator *schemaValidator) unknownKey(key *yaml.Node, section string, known ...string) {
+	for _, candidate := range known {
+		lowerKey, lowerCandidate := strings.ToLower(key.Value), strings.ToLower(candidate)
+		if strings.HasPrefix(lowerKey, lowerCandidate) || strings.HasPrefix(lowerCandidate, lowerKey) {
+			validator.error(key, "unknown key \"%s\" in %s, did you mean \"%s\"?", key.Value, section, candidate)
+
+			return
+		}
+	}
+	validator.error(key, "unknown key \"%s\" in %s, expected one of: %s", key.Value, section, strings.Join(known, ", "))
+}
+
+func (validator *schemaValidator) expectMapping(node *yaml.Node, name string) bool {
+	if node.Kind != yaml.MappingNode {
+		validator.error(node, "%s must be a mapping", name)
+
+		return false
+	}
+
+	return true
+}
+
+func (validator *schemaValidator) expectSequence(node *yaml.Node, name string) bool {
+	if node.Kind != yaml.SequenceNode {
+		validator.error(node, "%s must be a list", name)
+
+		return false
+	}
+
+	return true
+}
+
+func (validator *schemaValidator) expectScalar(node *yaml.Node, name string) bool {
+	if node.Kind != yaml.ScalarNode || node.Tag == "!!null" {
+		validator.error(node, "%s must be a string", name)
+
+		return false
+	}
+
+	return true
+}
+
+// isOverridesKey reports whether key is overrides. The CLI has always accepted any casing, as well as override.
+func isOverridesKey(key string) bool {
+	lower := strings.ToLower(key)
+
+	return lower == "overrides" || lower == "override"
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+
+	return false
+}
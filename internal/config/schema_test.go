@@ -0,0 +1,103 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSchemaValid(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "project", "debricked-config.yaml"))
+	assert.NoError(t, err)
+
+	issues := ValidateSchema(content)
+
+	assert.Empty(t, issues)
+	assert.False(t, HasErrors(issues))
+}
+
+func TestValidateSchemaExistingConfigs(t *testing.T) {
+	files := []string{
+		"debricked-config.yaml",
+		"debricked-config-ignore.yaml",
+		"debricked-config-override-ignore.yaml",
+		"debricked-config-policies.yaml",
+		"debricked-config-policies-only.yaml",
+		"debricked-config-policies-local.yaml",
+	}
+	for _, name := range files {
+		content, err := os.ReadFile(filepath.Join("..", "upload", "testdata", name))
+		assert.NoError(t, err)
+		assert.Empty(t, ValidateSchema(content), name)
+	}
+}
+
+func TestValidateSchemaInvalid(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "invalid.yaml"))
+	assert.NoError(t, err)
+
+	issues := ValidateSchema(content)
+
+	assert.True(t, HasErrors(issues))
+	assert.Equal(t, []Issue{
+		{Line: 1, Column: 10, Severity: SeverityError, Message: "unsupported version 2, the latest supported version is 1"},
+		{Line: 2, Column: 1, Severity: SeverityWarning, Message: "\"override\" is deprecated, use \"overrides\""},
+		{Line: 3, Column: 11, Severity: SeverityError, Message: "\"npm/lodash\" is not a package URL, expected pkg:<type>/<name>"},
+		{Line: 4, Column: 5, Severity: SeverityError, Message: "unknown key \"fileRegex\" in override, did you mean \"fileRegexes\"?"},
+		{Line: 5, Column: 5, Severity: SeverityError, Message: "override is missing \"pURL\""},
+		{Line: 5, Column: 14, Severity: SeverityError, Message: "version must be a version string or false"},
+		{Line: 7, Column: 9, Severity: SeverityWarning, Message: "file regex \"[lodash/.*\" cannot be checked locally: error parsing regexp: missing closing ]: `[lodash/.*`"},
+		{Line: 11, Column: 16, Severity: SeverityError, Message: "version must be a string"},
+		{Line: 13, Column: 12, Severity: SeverityError, Message: "maxCvss must be between 0 and 10"},
+		{Line: 16, Column: 9, Severity: SeverityError, Message: "invalid package \"lodash@\": missing version"},
+		{Line: 17, Column: 5, Severity: SeverityError, Message: "unknown key \"licenses\" in allow, expected one of: packages"},
+		{Line: 19, Column: 9, Severity: SeverityError, Message: "deny must be a mapping"},
+		{Line: 20, Column: 1, Severity: SeverityError, Message: "unknown key \"owner\" in config, expected one of: version, overrides, ignore, policies"},
+	}, issues)
+}
+
+func TestValidateSchemaSyntaxError(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("..", "upload", "testdata", "debricked-config-error.yaml"))
+	assert.NoError(t, err)
+
+	issues := ValidateSchema(content)
+
+	assert.Len(t, issues, 1)
+	assert.Equal(t, SeverityError, issues[0].Severity)
+	assert.Equal(t, 5, issues[0].Line)
+	assert.Contains(t, issues[0].Message, "line 5")
+}
+
+func TestValidateSchemaTypes(t *testing.T) {
+	cases := map[string]string{
+		"":                               "config is empty",
+		"- overrides":                    "config must be a mapping",
+		"version: one":                   "version must be an integer",
+		"overrides: {}":                  "overrides must be a list",
+		"overrides:\n  - pkg:npm/lodash": "override must be a mapping",
+		"overrides:\n  - pURL: pkg:npm/lodash\n    fileRegexes: x": "fileRegexes must be a list",
+		"ignore: []":                               "ignore must be a mapping",
+		"ignore:\n  package: []":                   "unknown key \"package\" in ignore, did you mean \"packages\"?",
+		"ignore:\n  packages:\n    - version: 1":   "ignored package is missing \"pURL\"",
+		"policies:\n  maxCvss: high":               "maxCvss must be a number",
+		"policies:\n  deny:\n    packages: lodash": "packages must be a list",
+		"overrides: []\nOverrides: []":             "\"overrides\" and \"Overrides\" are both set, use only \"overrides\"",
+		"ignore: {}\nignore: {}":                   "duplicate key \"ignore\"",
+	}
+	for content, expected := range cases {
+		issues := ValidateSchema([]byte(content))
+		messages := []string{}
+		for _, issue := range issues {
+			messages = append(messages, issue.Message)
+		}
+		assert.Contains(t, messages, expected, content)
+	}
+}
+
+func TestIssueString(t *testing.T) {
+	issue := Issue{Line: 3, Column: 5, Severity: SeverityError, Message: "unknown key"}
+
+	assert.Equal(t, "3:5: error: unknown key", issue.String())
+}
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"gopkg.in/yaml.v3"
+)
+
+var NoConfigErr = errors.New("no debricked-config.yaml found")
+
+// OverrideMatch lists the dependency files a file regex of an override matches.
+// FileRegex is empty for overrides without fileRegexes, which apply to every file.
+type OverrideMatch struct {
+	PURL      string   `json:"pURL"`
+	FileRegex string   `json:"fileRegex"`
+	Line      int      `json:"line"`
+	Files     []string `json:"files"`
+}
+
+// Report is the outcome of validating a debricked-config.yaml
+type Report struct {
+	ConfigPath string          `json:"configPath"`
+	Issues     []Issue         `json:"issues"`
+	Overrides  []OverrideMatch `json:"overrides"`
+}
+
+// Valid reports whether the config has no errors, nor any warnings if failOnWarning is set
+func (report Report) Valid(failOnWarning bool) bool {
+	if failOnWarning {
+		return len(report.Issues) == 0
+	}
+
+	return !HasErrors(report.Issues)
+}
+
+// Render writes the issues of the config, followed by the files matched by each override
+func (report Report) Render(mirror io.Writer, failOnWarning bool) {
+	for _, issue := range report.Issues {
+		severity := color.YellowString(issue.Severity)
+		if issue.Severity == SeverityError {
+			severity = color.RedString(issue.Severity)
+		}
+		_, _ = fmt.Fprintf(mirror, "%s:%d:%d: %s: %s\n", report.ConfigPath, issue.Line, issue.Column, severity, issue.Message)
+	}
+	if len(report.Issues) > 0 {
+		_, _ = fmt.Fprintln(mirror)
+	}
+
+	if len(report.Overrides) > 0 {
+		t := table.NewWriter()
+		t.SetOutputMirror(mirror)
+		t.SetStyle(table.StyleRounded)
+		t.AppendHeader(table.Row{"Override", "File regex", "Matched files"})
+		for _, override := range report.Overrides {
+			fileRegex, files := override.FileRegex, fmt.Sprintf("%d", len(override.Files))
+			if fileRegex == "" {
+				fileRegex, files = "(any)", "all"
+			}
+			for _, matchedFile := range override.Files {
+				files += "\n" + matchedFile
+			}
+			t.AppendRow(table.Row{override.PURL, fileRegex, files})
+		}
+		t.Render()
+		_, _ = fmt.Fprintln(mirror)
+	}
+
+	if report.Valid(failOnWarning) {
+		_, _ = fmt.Fprintf(mirror, "%s %s is valid\n", color.GreenString("✔"), report.ConfigPath)
+	} else {
+		_, _ = fmt.Fprintf(mirror, "%s %s is invalid\n", color.RedString("✖"), report.ConfigPath)
+	}
+}
+
+type Options struct {
+	// Path is either the config file, or a directory to search for debricked-config.yaml
+	Path       string
+	Exclusions []string
+	Inclusions []string
+}
+
+type IValidator interface {
+	Validate(options Options) (*Report, error)
+}
+
+type Validator struct {
+	finder file.IFinder
+}
+
+func NewValidator(finder file.IFinder) Validator {
+	return Validator{finder: finder}
+}
+
+// Validate validates the config against the schema, and matches the file regexes of its overrides against the
+// dependency files found next to, or below, the config
+func (validator Validator) Validate(options Options) (*Report, error) {
+	path := options.Path
+	if path == "" {
+		path = "."
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	configPath, rootPath := path, filepath.Dir(path)
+	if info.IsDir() {
+		configPath, rootPath = validator.finder.GetConfigPath(path, options.Exclusions, options.Inclusions), path
+		if configPath == "" {
+			return nil, fmt.Errorf("%w in %s", NoConfigErr, path)
+		}
+	}
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{ConfigPath: configPath, Issues: ValidateSchema(content), Overrides: []OverrideMatch{}}
+	overrides := readOverrides(content)
+	if len(overrides) == 0 {
+		return report, nil
+	}
+	files, err := validator.findFiles(rootPath, options)
+	if err != nil {
+		return nil, err
+	}
+	for _, override := range overrides {
+		if len(override.fileRegexes) == 0 {
+			report.Overrides = append(report.Overrides, OverrideMatch{PURL: override.pURL, Line: override.line})
+
+			continue
+		}
+		for _, fileRegex := range override.fileRegexes {
+			compiled, err := regexp.Compile(fileRegex.Value)
+			if err != nil {
+				// Reported by the schema validation
+				continue
+			}
+			match := OverrideMatch{PURL: override.pURL, FileRegex: fileRegex.Value, Line: fileRegex.Line, Files: []string{}}
+			for _, dependencyFile := range files {
+				if compiled.MatchString(dependencyFile) {
+					match.Files = append(match.Files, dependencyFile)
+				}
+			}
+			if len(match.Files) == 0 {
+				report.Issues = append(report.Issues, Issue{
+					Line:     fileRegex.Line,
+					Column:   fileRegex.Column,
+					Severity: SeverityWarning,
+					Message:  fmt.Sprintf("file regex \"%s\" of %s matches no dependency files", fileRegex.Value, override.pURL),
+				})
+			}
+			report.Overrides = append(report.Overrides, match)
+		}
+	}
+	sortIssues(report.Issues)
+
+	return report, nil
+}
+
+// findFiles returns the dependency files below rootPath, relative to rootPath
+func (validator Validator) findFiles(rootPath string, options Options) ([]string, error) {
+	groups, err := validator.finder.GetGroups(file.DebrickedOptions{
+		RootPath:   rootPath,
+		Exclusions: options.Exclusions,
+		Inclusions: options.Inclusions,
+		Strictness: file.StrictAll,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, dependencyFile := range groups.GetFiles() {
+		if relativePath, err := filepath.Rel(rootPath, dependencyFile); err == nil {
+			dependencyFile = relativePath
+		}
+		files = append(files, filepath.ToSlash(dependencyFile))
+	}
+	sort.Strings(files)
+
+	return files, nil
+}
+
+type override struct {
+	pURL        string
+	line        int
+	fileRegexes []*yaml.Node
+}
+
+// readOverrides reads the overrides that have a pURL, keeping the position of each file regex
+func readOverrides(content []byte) []override {
+	var document yaml.Node
+	if yaml.Unmarshal(content, &document) != nil || len(document.Content) == 0 {
+		return nil
+	}
+	root := document.Content[0]
+	var overrides []override
+	for i := 0; root.Kind == yaml.MappingNode && i+1 < len(root.Content); i += 2 {
+		if !isOverridesKey(root.Content[i].Value) || root.Content[i+1].Kind != yaml.SequenceNode {
+			continue
+		}
+		for _, entry := range root.Content[i+1].Content {
+			if o, ok := readOverride(entry); ok {
+				overrides = append(overrides, o)
+			}
+		}
+	}
+
+	return overrides
+}
+
+func readOverride(entry *yaml.Node) (override, bool) {
+	o := override{line: entry.Line}
+	for i := 0; entry.Kind == yaml.MappingNode && i+1 < len(entry.Content); i += 2 {
+		key, value := entry.Content[i], entry.Content[i+1]
+		switch {
+		case key.Value == keyPURL && value.Kind == yaml.ScalarNode:
+			o.pURL = value.Value
+		case key.Value == keyFileRegexes && value.Kind == yaml.SequenceNode:
+			for _, fileRegex := range value.Content {
+				if fileRegex.Kind == yaml.ScalarNode {
+					o.fileRegexes = append(o.fileRegexes, fileRegex)
+				}
+			}
+		}
+	}
+
+	return o, o.pURL != ""
+}
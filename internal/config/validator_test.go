@@ -0,0 +1,131 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/file/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+var projectPath = filepath.Join("testdata", "project")
+
+func newProjectFinder() *testdata.FinderMock {
+	finder := testdata.NewFinderMock()
+	groups := file.Groups{}
+	groups.Add(file.Group{
+		ManifestFile: filepath.Join(projectPath, "frontend", "package.json"),
+		LockFiles:    []string{filepath.Join(projectPath, "frontend", "package-lock.json")},
+	})
+	groups.Add(file.Group{ManifestFile: filepath.Join(projectPath, "vendor", "lodash", "package.json")})
+	finder.SetGetGroupsReturnMock(groups, nil)
+	finder.SetGetConfigPathReturnMock(filepath.Join(projectPath, "debricked-config.yaml"))
+
+	return finder
+}
+
+func TestValidate(t *testing.T) {
+	validator := NewValidator(newProjectFinder())
+
+	report, err := validator.Validate(Options{Path: projectPath})
+
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(projectPath, "debricked-config.yaml"), report.ConfigPath)
+	assert.Empty(t, report.Issues)
+	assert.Equal(t, []OverrideMatch{
+		{PURL: "pkg:npm/lodash", FileRegex: ".*/lodash/.*", Line: 6, Files: []string{"vendor/lodash/package.json"}},
+		{PURL: "pkg:npm/lodash", FileRegex: "^frontend/", Line: 7, Files: []string{"frontend/package-lock.json", "frontend/package.json"}},
+		{PURL: "pkg:maven/org.openjfx/javafx-base", Line: 8},
+	}, report.Overrides)
+	assert.True(t, report.Valid(true))
+}
+
+func TestValidateConfigFile(t *testing.T) {
+	finder := newProjectFinder()
+	finder.SetGetConfigPathReturnMock("")
+	validator := NewValidator(finder)
+
+	report, err := validator.Validate(Options{Path: filepath.Join(projectPath, "debricked-config.yaml")})
+
+	assert.NoError(t, err)
+	assert.Len(t, report.Overrides, 3)
+}
+
+func TestValidateUnmatchedFileRegex(t *testing.T) {
+	finder := newProjectFinder()
+	finder.SetGetGroupsReturnMock(file.Groups{}, nil)
+	validator := NewValidator(finder)
+
+	report, err := validator.Validate(Options{Path: projectPath})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Issue{
+		{Line: 6, Column: 9, Severity: SeverityWarning, Message: "file regex \".*/lodash/.*\" of pkg:npm/lodash matches no dependency files"},
+		{Line: 7, Column: 9, Severity: SeverityWarning, Message: "file regex \"^frontend/\" of pkg:npm/lodash matches no dependency files"},
+	}, report.Issues)
+	assert.True(t, report.Valid(false))
+	assert.False(t, report.Valid(true))
+}
+
+func TestValidateNoConfig(t *testing.T) {
+	finder := newProjectFinder()
+	finder.SetGetConfigPathReturnMock("")
+	validator := NewValidator(finder)
+
+	report, err := validator.Validate(Options{Path: projectPath})
+
+	assert.Nil(t, report)
+	assert.ErrorIs(t, err, NoConfigErr)
+}
+
+func TestValidateFinderError(t *testing.T) {
+	finder := newProjectFinder()
+	finderErr := errors.New("finder error")
+	finder.SetGetGroupsReturnMock(file.Groups{}, finderErr)
+	validator := NewValidator(finder)
+
+	report, err := validator.Validate(Options{Path: projectPath})
+
+	assert.Nil(t, report)
+	assert.ErrorIs(t, err, finderErr)
+}
+
+func TestValidateInvalidConfig(t *testing.T) {
+	validator := NewValidator(newProjectFinder())
+
+	report, err := validator.Validate(Options{Path: filepath.Join("testdata", "invalid.yaml")})
+
+	assert.NoError(t, err)
+	assert.True(t, HasErrors(report.Issues))
+	assert.False(t, report.Valid(false))
+	// The override without pURL is left out. The misspelled fileRegex leaves the first override without file regexes.
+	assert.Equal(t, []OverrideMatch{{PURL: "npm/lodash", Line: 3}}, report.Overrides)
+}
+
+func TestRender(t *testing.T) {
+	report := Report{
+		ConfigPath: "debricked-config.yaml",
+		Issues:     []Issue{{Line: 4, Column: 5, Severity: SeverityError, Message: "unknown key \"fileRegex\" in override"}},
+		Overrides: []OverrideMatch{
+			{PURL: "pkg:npm/lodash", FileRegex: ".*/lodash/.*", Files: []string{"vendor/lodash/package.json"}},
+			{PURL: "pkg:maven/org.openjfx/javafx-base"},
+		},
+	}
+	var output bytes.Buffer
+
+	report.Render(&output, false)
+
+	assert.Contains(t, output.String(), "debricked-config.yaml:4:5: error: unknown key \"fileRegex\" in override")
+	assert.Contains(t, output.String(), "vendor/lodash/package.json")
+	assert.Contains(t, output.String(), "(any)")
+	assert.Contains(t, output.String(), "debricked-config.yaml is invalid")
+
+	output.Reset()
+	report.Issues = nil
+	report.Render(&output, true)
+
+	assert.Contains(t, output.String(), "debricked-config.yaml is valid")
+}
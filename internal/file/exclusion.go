@@ -22,6 +22,7 @@ var defaultExclusions = DefaultExclusionList{
 		"obj",              // nuget
 		"bower_components", // bower
 		".vscode-test",     // excluding testing framework
+		"*.venv",           // virtual environments, such as those kept by pip resolution
 	},
 }
 
@@ -60,6 +60,7 @@ func TestExclusionsWithEmptyTokenEnvVariable(t *testing.T) {
 		"**/obj/**",
 		"**/bower_components/**",
 		"**/.vscode-test/**",
+		"**/*.venv/**",
 	}
 	defaultExclusions := Exclusions()
 	assert.Equal(t, gt, defaultExclusions)
@@ -169,6 +170,13 @@ func TestExcluded(t *testing.T) {
 			path:       "node_modules/package.json",
 			expected:   false,
 		},
+		{
+			name:       "VirtualEnv",
+			exclusions: DefaultExclusions(),
+			inclusions: []string{},
+			path:       "app/requirements.txt.venv/lib/python3.11/site-packages/pkg/requirements.txt",
+			expected:   true,
+		},
 	}
 
 	for _, c := range cases {
@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"regexp"
 
 	"github.com/debricked/cli/internal/client"
 	ioFs "github.com/debricked/cli/internal/io"
@@ -20,6 +21,12 @@ import (
 var supportedFormats embed.FS
 
 const SupportedFormatsFallbackFilePath = "embedded/supported_formats.json"
+
+const (
+	cargoManifestRegex = `Cargo\.toml$`
+	cargoLockFile      = "Cargo.lock"
+)
+
 const SupportedFormatsUri = "/api/1.0/open/files/supported-formats"
 
 type DebrickedOptions struct {
@@ -28,6 +35,8 @@ type DebrickedOptions struct {
 	Inclusions   []string
 	LockFileOnly bool
 	Strictness   int
+	// Offline uses the embedded supported formats instead of fetching them from Debricked
+	Offline bool
 }
 
 type IFinder interface {
@@ -173,7 +182,13 @@ func reportExclusions(excludedFiles []string) {
 func (finder *Finder) GetGroups(options DebrickedOptions) (Groups, error) {
 	var groups Groups
 
-	formats, err := finder.GetSupportedFormats()
+	var formats []*CompiledFormat
+	var err error
+	if options.Offline {
+		formats, err = finder.GetOfflineSupportedFormats()
+	} else {
+		formats, err = finder.GetSupportedFormats()
+	}
 	if err != nil {
 
 		return groups, err
@@ -207,8 +222,22 @@ func (finder *Finder) GetSupportedFormats() ([]*CompiledFormat, error) {
 		return nil, err
 	}
 
+	return compileSupportedFormats(body)
+}
+
+// GetOfflineSupportedFormats returns the embedded dependency file formats without contacting Debricked
+func (finder *Finder) GetOfflineSupportedFormats() ([]*CompiledFormat, error) {
+	body, err := finder.GetSupportedFormatsFallbackJson()
+	if err != nil {
+		return nil, err
+	}
+
+	return compileSupportedFormats(body)
+}
+
+func compileSupportedFormats(body []byte) ([]*CompiledFormat, error) {
 	var formats []*Format
-	err = json.Unmarshal(body, &formats)
+	err := json.Unmarshal(body, &formats)
 	if err != nil {
 		return nil, err
 	}
@@ -219,7 +248,7 @@ func (finder *Finder) GetSupportedFormats() ([]*CompiledFormat, error) {
 		LockFileRegexes:   []string{""},
 	}
 
-	formats = append(formats, sbtEntry)
+	formats = append(addCargoManifest(formats), sbtEntry)
 
 	var compiledDependencyFileFormats []*CompiledFormat
 	for _, format := range formats {
@@ -234,6 +263,39 @@ func (finder *Finder) GetSupportedFormats() ([]*CompiledFormat, error) {
 	return compiledDependencyFileFormats, nil
 }
 
+// addCargoManifest pairs Cargo.lock with Cargo.toml. Debricked may only know Cargo.lock as a lock file without
+// manifest, so Cargo.toml files without a Cargo.lock would never be grouped, and therefore never be resolved.
+func addCargoManifest(formats []*Format) []*Format {
+	for _, format := range formats {
+		if format.ManifestFileRegex == cargoManifestRegex {
+			return formats
+		}
+	}
+	for _, format := range formats {
+		if format.ManifestFileRegex == "" && matchesAny(format.LockFileRegexes, cargoLockFile) {
+			format.ManifestFileRegex = cargoManifestRegex
+
+			return formats
+		}
+	}
+
+	return append(formats, &Format{
+		ManifestFileRegex: cargoManifestRegex,
+		DocumentationUrl:  "https://docs.debricked.com/overview/language-support/rust",
+		LockFileRegexes:   []string{`Cargo\.lock$`},
+	})
+}
+
+func matchesAny(regexes []string, file string) bool {
+	for _, regex := range regexes {
+		if matched, err := regexp.MatchString(regex, file); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (finder *Finder) GetSupportedFormatsJson() ([]byte, error) {
 	res, err := finder.debClient.Get(SupportedFormatsUri, "application/json")
 
@@ -114,6 +114,30 @@ func TestGetSupportedFormatsEndpointUnaccessible(t *testing.T) {
 	}
 }
 
+func TestGetOfflineSupportedFormats(t *testing.T) {
+	setUp(true)
+	formats, err := finder.GetOfflineSupportedFormats()
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, len(formats), 1)
+}
+
+func TestGetGroupsOffline(t *testing.T) {
+	setUp(false)
+	options := DebrickedOptions{
+		RootPath:     "testdata/pip",
+		LockFileOnly: false,
+		Strictness:   StrictAll,
+		Offline:      true,
+	}
+
+	output := CaptureStdout(finder.GetGroups, options)
+	fileGroups, err := finder.GetGroups(options)
+
+	assert.NoError(t, err)
+	assert.Greater(t, fileGroups.Size(), 0)
+	assert.NotContains(t, output, "Unable to get supported formats from the server")
+}
+
 func TestGetGroups(t *testing.T) {
 	setUp(true)
 	path := ""
@@ -439,3 +463,53 @@ func TestGetGroupsWithStrictFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestAddCargoManifest(t *testing.T) {
+	lockOnly := &Format{ManifestFileRegex: "", LockFileRegexes: []string{`Cargo\.lock$`}}
+	fingerprints := &Format{ManifestFileRegex: "", LockFileRegexes: []string{`\.debricked\.fingerprints\.txt$`}}
+
+	formats := addCargoManifest([]*Format{fingerprints, lockOnly})
+
+	assert.Len(t, formats, 2)
+	assert.Equal(t, cargoManifestRegex, lockOnly.ManifestFileRegex)
+	assert.Empty(t, fingerprints.ManifestFileRegex)
+
+	formats = addCargoManifest([]*Format{fingerprints})
+
+	assert.Len(t, formats, 2)
+	assert.Equal(t, cargoManifestRegex, formats[1].ManifestFileRegex)
+	assert.Equal(t, []string{`Cargo\.lock$`}, formats[1].LockFileRegexes)
+}
+
+func TestGetGroupsCargoManifestOffline(t *testing.T) {
+	setUp(false)
+	dir := t.TempDir()
+	for _, name := range []string{"Cargo.toml", "Package.swift", "Gemfile"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte{}, 0600))
+	}
+
+	fileGroups, err := finder.GetGroups(DebrickedOptions{RootPath: dir, Strictness: StrictAll, Offline: true})
+
+	assert.NoError(t, err)
+	var manifestFiles []string
+	for _, group := range fileGroups.ToSlice() {
+		manifestFiles = append(manifestFiles, filepath.Base(group.ManifestFile))
+	}
+	assert.ElementsMatch(t, []string{"Cargo.toml", "Package.swift", "Gemfile"}, manifestFiles)
+}
+
+func TestGetGroupsCargoManifestWithLockFile(t *testing.T) {
+	setUp(true)
+	dir := t.TempDir()
+	for _, name := range []string{"Cargo.toml", "Cargo.lock"} {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte{}, 0600))
+	}
+
+	fileGroups, err := finder.GetGroups(DebrickedOptions{RootPath: dir, Strictness: StrictAll})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, fileGroups.Size())
+	group := fileGroups.ToSlice()[0]
+	assert.Equal(t, filepath.Join(dir, "Cargo.toml"), group.ManifestFile)
+	assert.Equal(t, []string{filepath.Join(dir, "Cargo.lock")}, group.LockFiles)
+}
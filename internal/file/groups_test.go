@@ -239,3 +239,32 @@ func TestAddWorkspaceLockFiles(t *testing.T) {
 		}
 	}
 }
+
+func TestMatchSwiftAndCocoaPods(t *testing.T) {
+	spm, _ := NewCompiledFormat(&Format{ManifestFileRegex: `Package\.swift$`, LockFileRegexes: []string{`Package\.resolved$`}})
+	cocoapods, _ := NewCompiledFormat(&Format{ManifestFileRegex: `Podfile$`, LockFileRegexes: []string{`Podfile\.lock$`}})
+	var gs Groups
+
+	for _, path := range []string{
+		"app/Package.resolved",
+		"app/Podfile",
+		"app/Package.swift",
+		"app/Podfile.lock",
+		"kit/Package.swift",
+	} {
+		matched := false
+		for _, format := range []*CompiledFormat{spm, cocoapods} {
+			matched = gs.Match(format, path, false) || matched
+		}
+		assert.True(t, matched, path)
+	}
+
+	groups := gs.ToSlice()
+	assert.Len(t, groups, 3)
+	assert.Equal(t, "app/Package.swift", groups[0].ManifestFile)
+	assert.Equal(t, []string{"app/Package.resolved"}, groups[0].LockFiles)
+	assert.Equal(t, "app/Podfile", groups[1].ManifestFile)
+	assert.Equal(t, []string{"app/Podfile.lock"}, groups[1].LockFiles)
+	assert.Equal(t, "kit/Package.swift", groups[2].ManifestFile)
+	assert.Empty(t, groups[2].LockFiles)
+}
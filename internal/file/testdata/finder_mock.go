@@ -7,6 +7,7 @@ import (
 type FinderMock struct {
 	groups          file.Groups
 	compiledFormats []*file.CompiledFormat
+	configPath      string
 	error           error
 }
 
@@ -24,7 +25,7 @@ func (f *FinderMock) GetGroups(_ file.DebrickedOptions) (file.Groups, error) {
 }
 
 func (f *FinderMock) GetConfigPath(_ string, _ []string, _ []string) string {
-	return ""
+	return f.configPath
 }
 
 func (f *FinderMock) GetSupportedFormats() ([]*file.CompiledFormat, error) {
@@ -40,3 +41,7 @@ func (f *FinderMock) SetGetSupportedFormatsReturnMock(compiledFormats []*file.Co
 	f.compiledFormats = compiledFormats
 	f.error = err
 }
+
+func (f *FinderMock) SetGetConfigPathReturnMock(configPath string) {
+	f.configPath = configPath
+}
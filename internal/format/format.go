@@ -0,0 +1,157 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/inventory"
+	"github.com/debricked/cli/internal/upload"
+)
+
+const (
+	Json   = "json"
+	Sarif  = "sarif"
+	JUnit  = "junit"
+	GitLab = "gitlab"
+
+	toolName       = "Debricked"
+	toolUri        = "https://debricked.com"
+	toolVendorName = "Debricked"
+)
+
+var Formats = []string{Json, Sarif, JUnit, GitLab}
+
+type UnsupportedFormatError struct {
+	Format string
+}
+
+func (e UnsupportedFormatError) Error() string {
+	return fmt.Sprintf("unsupported format %q. Supported formats are: %s", e.Format, strings.Join(Formats, ", "))
+}
+
+// IWriter writes a scan result in a machine-readable format
+type IWriter interface {
+	Write(w io.Writer, result *upload.UploadResult) error
+	// DefaultFileName is the file name used when no output path is given
+	DefaultFileName() string
+}
+
+// Context holds what writers need to know about the scan, beyond the result returned by Debricked
+type Context struct {
+	// Inventory is used to locate the dependencies of trigger events, it may be nil
+	Inventory *inventory.Inventory
+	// Version is the version of the CLI
+	Version string
+}
+
+func NewWriter(format string, context Context) (IWriter, error) {
+	switch strings.ToLower(format) {
+	case Json:
+		return JsonWriter{}, nil
+	case Sarif:
+		return SarifWriter{Context: context}, nil
+	case JUnit:
+		return JUnitWriter{}, nil
+	case GitLab:
+		return GitLabWriter{Context: context}, nil
+	}
+
+	return nil, UnsupportedFormatError{Format: format}
+}
+
+// ToFile writes result in format to outputFile, or to the default file name of format if outputFile is empty
+func ToFile(format string, outputFile string, result *upload.UploadResult, context Context) (string, error) {
+	writer, err := NewWriter(format, context)
+	if err != nil {
+		return "", err
+	}
+	if outputFile == "" {
+		outputFile = writer.DefaultFileName()
+	}
+	if err = os.MkdirAll(filepath.Dir(outputFile), 0755); err != nil {
+		return "", fmt.Errorf("failed to ensure directory exists: %w", err)
+	}
+	file, err := os.Create(outputFile)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	return outputFile, writer.Write(file, result)
+}
+
+// locate returns the file declaring the dependency of event, relative to the scan root, and the version in use.
+// Dependencies missing from the inventory are located in the first file of the same ecosystem, or else the first
+// dependency file found, without a version.
+func (context Context) locate(event automation.TriggerEvent) (string, string) {
+	inv := context.Inventory
+	if inv == nil || event.Dependency == "" {
+		return "", ""
+	}
+	name, ecosystem := inventory.SplitEventDependency(event.Dependency)
+	if dependency, found := inv.Find(ecosystem, name); found {
+		return toUri(dependency.SourceFile), dependency.Version
+	}
+	for _, dependency := range inv.Dependencies {
+		if dependency.Ecosystem == ecosystem {
+			return toUri(dependency.SourceFile), ""
+		}
+	}
+	for _, files := range [][]string{inv.ParsedFiles, inv.UnparsedFiles} {
+		if len(files) > 0 {
+			return toUri(files[0]), ""
+		}
+	}
+
+	return "", ""
+}
+
+func toUri(filePath string) string {
+	return path.Clean(filepath.ToSlash(filePath))
+}
+
+// triggeredEvents returns the trigger events of rule, or a single empty event if it triggered without any
+func triggeredEvents(rule automation.Rule) []automation.TriggerEvent {
+	if !rule.Triggered {
+		return nil
+	}
+	if len(rule.TriggerEvents) == 0 {
+		return []automation.TriggerEvent{{}}
+	}
+
+	return rule.TriggerEvents
+}
+
+// cvss returns the CVSS3 score of event, falling back to CVSS2
+func cvss(event automation.TriggerEvent) float32 {
+	if event.Cvss3 > 0 {
+		return event.Cvss3
+	}
+
+	return event.Cvss2
+}
+
+// eventMessage describes why event triggered rule
+func eventMessage(rule automation.Rule, event automation.TriggerEvent) string {
+	description := strings.TrimSpace(rule.RuleDescription)
+	if event.Dependency == "" {
+		return description
+	}
+	message := fmt.Sprintf("%s triggered rule: %s", event.Dependency, description)
+	if event.Cve != "" {
+		message += fmt.Sprintf("\nVulnerability: %s", event.Cve)
+		if score := cvss(event); score > 0 {
+			message += fmt.Sprintf(" (CVSS %.1f)", score)
+		}
+	}
+	if len(event.Licenses) > 0 {
+		message += fmt.Sprintf("\nLicenses: %s", strings.Join(event.Licenses, ", "))
+	}
+
+	return message
+}
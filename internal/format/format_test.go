@@ -0,0 +1,164 @@
+package format
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/inventory"
+	"github.com/debricked/cli/internal/upload"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestResult() *upload.UploadResult {
+	return &upload.UploadResult{
+		VulnerabilitiesFound: 2,
+		DetailsUrl:           "https://debricked.com/app/en/repository/1/commit/2",
+		AutomationRules: []automation.Rule{
+			{
+				RuleDescription: "If a vulnerability with CVSS of at least 7 is found\nthen fail pipeline",
+				RuleActions:     []string{"failPipeline"},
+				RuleLink:        "https://debricked.com/app/en/automations/rule/1",
+				HasCves:         true,
+				Triggered:       true,
+				TriggerEvents: []automation.TriggerEvent{
+					{
+						Dependency:     "lodash (npm)",
+						DependencyLink: "https://debricked.com/app/en/dependency/1",
+						Cve:            "CVE-2021-23337",
+						Cvss2:          6.5,
+						Cvss3:          7.2,
+						CveLink:        "https://debricked.com/app/en/vulnerability/1",
+					},
+					{
+						Dependency: "minimist (npm)",
+						Cve:        "CVE-2021-44906",
+						Cvss2:      7.5,
+					},
+				},
+			},
+			{
+				RuleDescription: "If a GPL license is found then notify",
+				RuleActions:     []string{"sendEmail"},
+				Triggered:       true,
+				TriggerEvents: []automation.TriggerEvent{
+					{Dependency: "readline (npm)", Licenses: []string{"GPL-3.0"}},
+				},
+			},
+			{
+				RuleDescription: "Never triggers",
+				RuleActions:     []string{"failPipeline"},
+			},
+		},
+	}
+}
+
+func newTestContext() Context {
+	return Context{
+		Inventory: &inventory.Inventory{
+			Dependencies: []inventory.Dependency{
+				{Name: "lodash", Version: "4.17.20", Ecosystem: inventory.EcosystemNpm, SourceFile: "frontend/package-lock.json"},
+				{Name: "serde", Version: "1.0.188", Ecosystem: inventory.EcosystemCargo, SourceFile: "./Cargo.lock"},
+			},
+			ParsedFiles:   []string{"./Cargo.lock", "frontend/package-lock.json"},
+			UnparsedFiles: []string{"pom.xml"},
+		},
+		Version: "v2.0.0",
+	}
+}
+
+func TestNewWriter(t *testing.T) {
+	context := newTestContext()
+	cases := map[string]IWriter{
+		"json":   JsonWriter{},
+		"sarif":  SarifWriter{Context: context},
+		"SARIF":  SarifWriter{Context: context},
+		"junit":  JUnitWriter{},
+		"gitlab": GitLabWriter{Context: context},
+	}
+	for name, expected := range cases {
+		writer, err := NewWriter(name, context)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, writer)
+	}
+
+	writer, err := NewWriter("xml", context)
+	assert.Nil(t, writer)
+	assert.ErrorIs(t, err, UnsupportedFormatError{Format: "xml"})
+	assert.ErrorContains(t, err, "json, sarif, junit, gitlab")
+}
+
+func TestToFile(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "reports", "result.sarif")
+
+	output, err := ToFile(Sarif, outputFile, newTestResult(), Context{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, outputFile, output)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"version": "2.1.0"`)
+}
+
+func TestToFileDefaultFileName(t *testing.T) {
+	cwd, _ := os.Getwd()
+	defer func() {
+		_ = os.Chdir(cwd)
+	}()
+	_ = os.Chdir(t.TempDir())
+
+	output, err := ToFile(GitLab, "", newTestResult(), Context{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, OutputFileNameGitLab, output)
+	assert.FileExists(t, OutputFileNameGitLab)
+}
+
+func TestToFileUnsupportedFormat(t *testing.T) {
+	_, err := ToFile("xml", "", newTestResult(), Context{})
+	assert.Error(t, err)
+}
+
+func TestEventMessage(t *testing.T) {
+	rules := newTestResult().AutomationRules
+
+	assert.Equal(
+		t,
+		"lodash (npm) triggered rule: If a vulnerability with CVSS of at least 7 is found\nthen fail pipeline\nVulnerability: CVE-2021-23337 (CVSS 7.2)",
+		eventMessage(rules[0], rules[0].TriggerEvents[0]),
+	)
+	assert.Contains(t, eventMessage(rules[0], rules[0].TriggerEvents[1]), "(CVSS 7.5)")
+	assert.Contains(t, eventMessage(rules[1], rules[1].TriggerEvents[0]), "\nLicenses: GPL-3.0")
+	assert.Equal(t, "Never triggers", eventMessage(rules[2], automation.TriggerEvent{}))
+}
+
+func TestTriggeredEvents(t *testing.T) {
+	assert.Nil(t, triggeredEvents(automation.Rule{}))
+	assert.Len(t, triggeredEvents(automation.Rule{Triggered: true}), 1)
+	assert.Len(t, triggeredEvents(newTestResult().AutomationRules[0]), 2)
+}
+
+func TestLocate(t *testing.T) {
+	context := newTestContext()
+	cases := map[string][2]string{
+		"lodash (npm)":    {"frontend/package-lock.json", "4.17.20"},
+		"minimist (npm)":  {"frontend/package-lock.json", ""},
+		"serde (Cargo)":   {"Cargo.lock", "1.0.188"},
+		"guava (Maven)":   {"Cargo.lock", ""},
+		"":                {"", ""},
+		"requests (PyPI)": {"Cargo.lock", ""},
+	}
+	for dependency, expected := range cases {
+		file, version := context.locate(automation.TriggerEvent{Dependency: dependency})
+		assert.Equal(t, expected, [2]string{file, version}, dependency)
+	}
+
+	file, version := Context{}.locate(automation.TriggerEvent{Dependency: "lodash (npm)"})
+	assert.Empty(t, file)
+	assert.Empty(t, version)
+	file, _ = Context{Inventory: &inventory.Inventory{UnparsedFiles: []string{"pom.xml"}}}.locate(
+		automation.TriggerEvent{Dependency: "guava (Maven)"},
+	)
+	assert.Equal(t, "pom.xml", file)
+}
@@ -0,0 +1,170 @@
+package format
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/inventory"
+	"github.com/debricked/cli/internal/upload"
+)
+
+const (
+	OutputFileNameGitLab = "gl-dependency-scanning-report.json"
+	gitLabSchemaVersion  = "15.0.7"
+	gitLabTimeLayout     = "2006-01-02T15:04:05"
+)
+
+type gitLabIdentifier struct {
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	Value string `json:"value"`
+	Url   string `json:"url,omitempty"`
+}
+
+type gitLabLink struct {
+	Name string `json:"name,omitempty"`
+	Url  string `json:"url"`
+}
+
+type gitLabLocation struct {
+	File       string `json:"file"`
+	Dependency struct {
+		Package struct {
+			Name string `json:"name"`
+		} `json:"package"`
+		Version string `json:"version"`
+	} `json:"dependency"`
+}
+
+type gitLabVulnerability struct {
+	Id          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Severity    string             `json:"severity"`
+	Identifiers []gitLabIdentifier `json:"identifiers"`
+	Links       []gitLabLink       `json:"links,omitempty"`
+	Location    gitLabLocation     `json:"location"`
+}
+
+type gitLabTool struct {
+	Id      string `json:"id"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+	Url     string `json:"url"`
+	Vendor  struct {
+		Name string `json:"name"`
+	} `json:"vendor"`
+}
+
+type gitLabReport struct {
+	Version         string                `json:"version"`
+	Vulnerabilities []gitLabVulnerability `json:"vulnerabilities"`
+	DependencyFiles []interface{}         `json:"dependency_files"`
+	Scan            struct {
+		Analyzer  gitLabTool `json:"analyzer"`
+		Scanner   gitLabTool `json:"scanner"`
+		Type      string     `json:"type"`
+		StartTime string     `json:"start_time"`
+		EndTime   string     `json:"end_time"`
+		Status    string     `json:"status"`
+	} `json:"scan"`
+}
+
+// GitLabWriter writes the GitLab dependency scanning report schema, with one vulnerability per triggered CVE and dependency
+type GitLabWriter struct {
+	Context Context
+}
+
+func (w GitLabWriter) Write(out io.Writer, result *upload.UploadResult) error {
+	report := gitLabReport{
+		Version:         gitLabSchemaVersion,
+		Vulnerabilities: []gitLabVulnerability{},
+		DependencyFiles: []interface{}{},
+	}
+	tool := gitLabTool{Id: "debricked", Name: toolName, Version: w.Context.Version, Url: toolUri}
+	tool.Vendor.Name = toolVendorName
+	report.Scan.Analyzer = tool
+	report.Scan.Scanner = tool
+	report.Scan.Type = "dependency_scanning"
+	now := time.Now().UTC().Format(gitLabTimeLayout)
+	report.Scan.StartTime = now
+	report.Scan.EndTime = now
+	report.Scan.Status = "success"
+
+	seen := map[string]bool{}
+	for _, rule := range result.AutomationRules {
+		for _, event := range triggeredEvents(rule) {
+			// Only vulnerabilities are reported, license and policy triggers have no place in the schema
+			if event.Cve == "" {
+				continue
+			}
+			id := gitLabVulnerabilityId(event)
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+			vulnerability := newGitLabVulnerability(id, rule, event)
+			vulnerability.Location.File, vulnerability.Location.Dependency.Version = w.Context.locate(event)
+			report.Vulnerabilities = append(report.Vulnerabilities, vulnerability)
+		}
+	}
+
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(content)
+
+	return err
+}
+
+func (w GitLabWriter) DefaultFileName() string {
+	return OutputFileNameGitLab
+}
+
+func newGitLabVulnerability(id string, rule automation.Rule, event automation.TriggerEvent) gitLabVulnerability {
+	vulnerability := gitLabVulnerability{
+		Id:          id,
+		Name:        fmt.Sprintf("%s in %s", event.Cve, event.Dependency),
+		Description: eventMessage(rule, event),
+		Severity:    gitLabSeverity(cvss(event)),
+		Identifiers: []gitLabIdentifier{{Type: "cve", Name: event.Cve, Value: event.Cve, Url: event.CveLink}},
+	}
+	if event.CveLink != "" {
+		vulnerability.Links = append(vulnerability.Links, gitLabLink{Name: event.Cve, Url: event.CveLink})
+	}
+	if event.DependencyLink != "" {
+		vulnerability.Links = append(vulnerability.Links, gitLabLink{Name: event.Dependency, Url: event.DependencyLink})
+	}
+	vulnerability.Location.Dependency.Package.Name, _ = inventory.SplitEventDependency(event.Dependency)
+
+	return vulnerability
+}
+
+// gitLabVulnerabilityId derives a stable UUID formatted id, so the same finding keeps its id between pipelines
+func gitLabVulnerabilityId(event automation.TriggerEvent) string {
+	sum := sha256.Sum256([]byte(event.Cve + "|" + event.Dependency))
+	h := hex.EncodeToString(sum[:16])
+
+	return fmt.Sprintf("%s-%s-%s-%s-%s", h[0:8], h[8:12], h[12:16], h[16:20], h[20:32])
+}
+
+func gitLabSeverity(score float32) string {
+	switch {
+	case score >= 9:
+		return "Critical"
+	case score >= 7:
+		return "High"
+	case score >= 4:
+		return "Medium"
+	case score > 0:
+		return "Low"
+	default:
+		return "Unknown"
+	}
+}
@@ -0,0 +1,79 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/upload"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitLabWriter(t *testing.T) {
+	var out bytes.Buffer
+	result := newTestResult()
+	// The same finding triggering several rules is reported once
+	result.AutomationRules = append(result.AutomationRules, result.AutomationRules[0])
+
+	err := GitLabWriter{Context: newTestContext()}.Write(&out, result)
+
+	assert.NoError(t, err)
+	var report gitLabReport
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &report))
+	assert.Equal(t, "15.0.7", report.Version)
+	assert.Equal(t, "dependency_scanning", report.Scan.Type)
+	assert.Equal(t, "success", report.Scan.Status)
+	assert.Equal(t, "Debricked", report.Scan.Analyzer.Vendor.Name)
+	assert.Equal(t, "v2.0.0", report.Scan.Analyzer.Version)
+	assert.Equal(t, "v2.0.0", report.Scan.Scanner.Version)
+	assert.Regexp(t, `^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}$`, report.Scan.StartTime)
+
+	assert.Len(t, report.Vulnerabilities, 2)
+	vulnerability := report.Vulnerabilities[0]
+	assert.Equal(t, "CVE-2021-23337 in lodash (npm)", vulnerability.Name)
+	assert.Equal(t, "High", vulnerability.Severity)
+	assert.Equal(t, []gitLabIdentifier{{
+		Type:  "cve",
+		Name:  "CVE-2021-23337",
+		Value: "CVE-2021-23337",
+		Url:   "https://debricked.com/app/en/vulnerability/1",
+	}}, vulnerability.Identifiers)
+	assert.Len(t, vulnerability.Links, 2)
+	assert.Equal(t, "lodash", vulnerability.Location.Dependency.Package.Name)
+	assert.Equal(t, "4.17.20", vulnerability.Location.Dependency.Version)
+	assert.Equal(t, "frontend/package-lock.json", vulnerability.Location.File)
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`, vulnerability.Id)
+	assert.Equal(t, OutputFileNameGitLab, GitLabWriter{}.DefaultFileName())
+}
+
+func TestGitLabWriterNoVulnerabilities(t *testing.T) {
+	var out bytes.Buffer
+
+	err := GitLabWriter{}.Write(&out, &upload.UploadResult{})
+
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), `"vulnerabilities": []`)
+}
+
+func TestGitLabVulnerabilityIdIsStable(t *testing.T) {
+	event := automation.TriggerEvent{Dependency: "lodash (npm)", Cve: "CVE-2021-23337"}
+	other := automation.TriggerEvent{Dependency: "lodash (npm)", Cve: "CVE-2020-8203"}
+
+	assert.Equal(t, gitLabVulnerabilityId(event), gitLabVulnerabilityId(event))
+	assert.NotEqual(t, gitLabVulnerabilityId(event), gitLabVulnerabilityId(other))
+}
+
+func TestGitLabSeverity(t *testing.T) {
+	cases := map[float32]string{
+		9.8: "Critical",
+		9:   "Critical",
+		7.2: "High",
+		5:   "Medium",
+		0.1: "Low",
+		0:   "Unknown",
+	}
+	for score, expected := range cases {
+		assert.Equal(t, expected, gitLabSeverity(score))
+	}
+}
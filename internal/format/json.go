@@ -0,0 +1,27 @@
+package format
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/debricked/cli/internal/upload"
+)
+
+const OutputFileNameJson = "debricked-result.json"
+
+// JsonWriter writes the raw upload result
+type JsonWriter struct{}
+
+func (w JsonWriter) Write(out io.Writer, result *upload.UploadResult) error {
+	content, err := json.MarshalIndent(result, "", " ")
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(content)
+
+	return err
+}
+
+func (w JsonWriter) DefaultFileName() string {
+	return OutputFileNameJson
+}
@@ -0,0 +1,23 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/debricked/cli/internal/upload"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJsonWriter(t *testing.T) {
+	var out bytes.Buffer
+	result := newTestResult()
+
+	err := JsonWriter{}.Write(&out, result)
+
+	assert.NoError(t, err)
+	var actual upload.UploadResult
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &actual))
+	assert.Equal(t, *result, actual)
+	assert.Equal(t, OutputFileNameJson, JsonWriter{}.DefaultFileName())
+}
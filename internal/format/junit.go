@@ -0,0 +1,93 @@
+package format
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/debricked/cli/internal/upload"
+)
+
+const (
+	OutputFileNameJUnit = "debricked-junit.xml"
+	junitClassName      = "debricked.automation"
+)
+
+type junitFailure struct {
+	Message  string `xml:"message,attr"`
+	Type     string `xml:"type,attr"`
+	Contents string `xml:",chardata"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+// JUnitWriter writes one test case per automation rule, failing when the rule triggered
+type JUnitWriter struct{}
+
+func (w JUnitWriter) Write(out io.Writer, result *upload.UploadResult) error {
+	suite := junitTestSuite{Name: "Debricked automation rules", TestCases: []junitTestCase{}}
+	for i, rule := range result.AutomationRules {
+		testCase := junitTestCase{
+			Name:      fmt.Sprintf("%d. %s", i+1, firstLine(strings.TrimSpace(rule.RuleDescription))),
+			ClassName: junitClassName,
+		}
+		if rule.Triggered {
+			var messages []string
+			for _, event := range triggeredEvents(rule) {
+				messages = append(messages, eventMessage(rule, event))
+			}
+			if rule.RuleLink != "" {
+				messages = append(messages, fmt.Sprintf("Manage rule: %s", rule.RuleLink))
+			}
+			testCase.Failure = &junitFailure{
+				Message:  firstLine(strings.TrimSpace(rule.RuleDescription)),
+				Type:     strings.Join(rule.RuleActions, ","),
+				Contents: strings.Join(messages, "\n\n"),
+			}
+			suite.Failures++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+	suite.Tests = len(suite.TestCases)
+	suites := junitTestSuites{
+		Name:       toolName,
+		Tests:      suite.Tests,
+		Failures:   suite.Failures,
+		TestSuites: []junitTestSuite{suite},
+	}
+
+	content, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err = io.WriteString(out, xml.Header); err != nil {
+		return err
+	}
+	_, err = out.Write(content)
+
+	return err
+}
+
+func (w JUnitWriter) DefaultFileName() string {
+	return OutputFileNameJUnit
+}
@@ -0,0 +1,35 @@
+package format
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJUnitWriter(t *testing.T) {
+	var out bytes.Buffer
+
+	err := JUnitWriter{}.Write(&out, newTestResult())
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(out.String(), xml.Header))
+	var suites junitTestSuites
+	assert.NoError(t, xml.Unmarshal(out.Bytes(), &suites))
+	assert.Equal(t, 3, suites.Tests)
+	assert.Equal(t, 2, suites.Failures)
+	assert.Len(t, suites.TestSuites, 1)
+
+	testCases := suites.TestSuites[0].TestCases
+	assert.Len(t, testCases, 3)
+	assert.Equal(t, "1. If a vulnerability with CVSS of at least 7 is found", testCases[0].Name)
+	assert.Equal(t, "failPipeline", testCases[0].Failure.Type)
+	assert.Contains(t, testCases[0].Failure.Contents, "CVE-2021-23337")
+	assert.Contains(t, testCases[0].Failure.Contents, "CVE-2021-44906")
+	assert.Contains(t, testCases[0].Failure.Contents, "Manage rule: https://debricked.com/app/en/automations/rule/1")
+	assert.NotNil(t, testCases[1].Failure)
+	assert.Nil(t, testCases[2].Failure)
+	assert.Equal(t, OutputFileNameJUnit, JUnitWriter{}.DefaultFileName())
+}
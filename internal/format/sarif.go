@@ -0,0 +1,191 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/upload"
+)
+
+const (
+	OutputFileNameSarif = "debricked.sarif"
+	sarifVersion        = "2.1.0"
+	sarifSchema         = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifRule struct {
+	Id               string                 `json:"id"`
+	Name             string                 `json:"name"`
+	ShortDescription sarifMessage           `json:"shortDescription"`
+	FullDescription  sarifMessage           `json:"fullDescription"`
+	HelpUri          string                 `json:"helpUri,omitempty"`
+	Properties       map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	Uri       string `json:"uri"`
+	UriBaseId string `json:"uriBaseId,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	} `json:"physicalLocation"`
+}
+
+type sarifResult struct {
+	RuleId     string                 `json:"ruleId"`
+	RuleIndex  int                    `json:"ruleIndex"`
+	Level      string                 `json:"level"`
+	Message    sarifMessage           `json:"message"`
+	Locations  []sarifLocation        `json:"locations,omitempty"`
+	Properties map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifRun struct {
+	Tool struct {
+		Driver struct {
+			Name           string      `json:"name"`
+			InformationUri string      `json:"informationUri"`
+			Rules          []sarifRule `json:"rules"`
+		} `json:"driver"`
+	} `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+// SarifWriter writes SARIF 2.1.0 with one reporting descriptor per automation rule and one result per trigger event.
+// Results are located in the lock file, or manifest file, of their dependency.
+type SarifWriter struct {
+	Context Context
+}
+
+func (w SarifWriter) Write(out io.Writer, result *upload.UploadResult) error {
+	run := sarifRun{Results: []sarifResult{}}
+	run.Tool.Driver.Name = toolName
+	run.Tool.Driver.InformationUri = toolUri
+	run.Tool.Driver.Rules = []sarifRule{}
+
+	for i, rule := range result.AutomationRules {
+		id := sarifRuleId(i)
+		description := strings.TrimSpace(rule.RuleDescription)
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+			Id:               id,
+			Name:             fmt.Sprintf("AutomationRule%d", i+1),
+			ShortDescription: sarifMessage{Text: firstLine(description)},
+			FullDescription:  sarifMessage{Text: description},
+			HelpUri:          rule.RuleLink,
+			Properties:       sarifRuleProperties(rule),
+		})
+		for _, event := range triggeredEvents(rule) {
+			run.Results = append(run.Results, sarifResult{
+				RuleId:     id,
+				RuleIndex:  i,
+				Level:      sarifLevel(rule),
+				Message:    sarifMessage{Text: eventMessage(rule, event)},
+				Locations:  w.locations(event),
+				Properties: sarifProperties(event),
+			})
+		}
+	}
+
+	content, err := json.MarshalIndent(sarifLog{Schema: sarifSchema, Version: sarifVersion, Runs: []sarifRun{run}}, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(content)
+
+	return err
+}
+
+func (w SarifWriter) DefaultFileName() string {
+	return OutputFileNameSarif
+}
+
+func (w SarifWriter) locations(event automation.TriggerEvent) []sarifLocation {
+	file, _ := w.Context.locate(event)
+	if file == "" {
+		return nil
+	}
+	var location sarifLocation
+	location.PhysicalLocation.ArtifactLocation = sarifArtifactLocation{Uri: file, UriBaseId: "%SRCROOT%"}
+
+	return []sarifLocation{location}
+}
+
+func sarifRuleId(index int) string {
+	return fmt.Sprintf("debricked-automation-rule-%d", index+1)
+}
+
+func sarifLevel(rule automation.Rule) string {
+	if rule.FailPipeline() {
+		return "error"
+	}
+
+	return "warning"
+}
+
+// sarifRuleProperties sets security-severity, which code scanning reads from rules to rank results, to the highest
+// CVSS score among the trigger events of rule
+func sarifRuleProperties(rule automation.Rule) map[string]interface{} {
+	properties := map[string]interface{}{"actions": rule.RuleActions}
+	var severity float32
+	for _, event := range triggeredEvents(rule) {
+		if score := cvss(event); score > severity {
+			severity = score
+		}
+	}
+	if severity > 0 {
+		properties["security-severity"] = fmt.Sprintf("%.1f", severity)
+	}
+
+	return properties
+}
+
+func sarifProperties(event automation.TriggerEvent) map[string]interface{} {
+	properties := map[string]interface{}{}
+	if event.Dependency != "" {
+		properties["dependency"] = event.Dependency
+	}
+	if event.DependencyLink != "" {
+		properties["dependencyLink"] = event.DependencyLink
+	}
+	if event.Cve != "" {
+		properties["cve"] = event.Cve
+	}
+	if event.CveLink != "" {
+		properties["cveLink"] = event.CveLink
+	}
+	if event.Cvss2 > 0 {
+		properties["cvss2"] = event.Cvss2
+	}
+	if event.Cvss3 > 0 {
+		properties["cvss3"] = event.Cvss3
+	}
+	if len(event.Licenses) > 0 {
+		properties["licenses"] = event.Licenses
+	}
+	if len(properties) == 0 {
+		return nil
+	}
+
+	return properties
+}
+
+func firstLine(text string) string {
+	line, _, _ := strings.Cut(text, "\n")
+
+	return line
+}
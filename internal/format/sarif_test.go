@@ -0,0 +1,61 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/debricked/cli/internal/upload"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSarifWriter(t *testing.T) {
+	var out bytes.Buffer
+
+	err := SarifWriter{Context: newTestContext()}.Write(&out, newTestResult())
+
+	assert.NoError(t, err)
+	var log sarifLog
+	assert.NoError(t, json.Unmarshal(out.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	assert.Len(t, log.Runs, 1)
+
+	run := log.Runs[0]
+	assert.Equal(t, "Debricked", run.Tool.Driver.Name)
+	assert.Len(t, run.Tool.Driver.Rules, 3)
+	rule := run.Tool.Driver.Rules[0]
+	assert.Equal(t, "debricked-automation-rule-1", rule.Id)
+	assert.Equal(t, "If a vulnerability with CVSS of at least 7 is found", rule.ShortDescription.Text)
+	assert.Equal(t, "https://debricked.com/app/en/automations/rule/1", rule.HelpUri)
+	assert.Equal(t, "7.5", rule.Properties["security-severity"])
+	assert.NotContains(t, run.Tool.Driver.Rules[1].Properties, "security-severity")
+
+	assert.Len(t, run.Results, 3)
+	result := run.Results[0]
+	assert.Equal(t, "debricked-automation-rule-1", result.RuleId)
+	assert.Equal(t, 0, result.RuleIndex)
+	assert.Equal(t, "error", result.Level)
+	assert.Equal(t, "CVE-2021-23337", result.Properties["cve"])
+	assert.NotContains(t, result.Properties, "security-severity")
+	assert.Len(t, result.Locations, 1)
+	assert.Equal(t, sarifArtifactLocation{Uri: "frontend/package-lock.json", UriBaseId: "%SRCROOT%"}, result.Locations[0].PhysicalLocation.ArtifactLocation)
+	assert.Equal(t, "lodash (npm)", result.Properties["dependency"])
+
+	licenseResult := run.Results[2]
+	assert.Equal(t, 1, licenseResult.RuleIndex)
+	assert.Equal(t, "warning", licenseResult.Level)
+	assert.NotContains(t, licenseResult.Properties, "security-severity")
+	assert.Equal(t, []interface{}{"GPL-3.0"}, licenseResult.Properties["licenses"])
+}
+
+func TestSarifWriterNoRules(t *testing.T) {
+	var out bytes.Buffer
+
+	err := SarifWriter{}.Write(&out, &upload.UploadResult{})
+
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), `"results": []`)
+	assert.Contains(t, out.String(), `"rules": []`)
+	assert.NotContains(t, out.String(), `"locations"`)
+	assert.Equal(t, OutputFileNameSarif, SarifWriter{}.DefaultFileName())
+}
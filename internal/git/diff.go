@@ -0,0 +1,131 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// FindEnclosingRepository opens the repository whose worktree contains path, which may be a subdirectory
+func FindEnclosingRepository(path string) (*git.Repository, error) {
+	return git.PlainOpenWithOptions(path, &git.PlainOpenOptions{DetectDotGit: true})
+}
+
+// FindWorktreePath returns the slash separated path of dir relative to the worktree root of repository,
+// which is "." for the root itself
+func FindWorktreePath(repository *git.Repository, dir string) (string, error) {
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return "", err
+	}
+	root, err := filepath.EvalSymlinks(worktree.Filesystem.Root())
+	if err != nil {
+		return "", err
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	dir, err = filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", err
+	}
+	relative, err := filepath.Rel(root, dir)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.ToSlash(relative), nil
+}
+
+// FindRevisionCommit resolves revision, such as a branch, tag, remote branch or commit hash, to a commit
+func FindRevisionCommit(repository *git.Repository, revision string) (*object.Commit, error) {
+	hash, err := repository.ResolveRevision(plumbing.Revision(revision))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", revision, err)
+	}
+
+	return repository.CommitObject(*hash)
+}
+
+// FindChangedFiles returns the sorted paths of files that were added, modified or deleted between base and head
+func FindChangedFiles(base *object.Commit, head *object.Commit) ([]string, error) {
+	baseTree, err := base.Tree()
+	if err != nil {
+		return nil, err
+	}
+	headTree, err := head.Tree()
+	if err != nil {
+		return nil, err
+	}
+	changes, err := object.DiffTree(baseTree, headTree)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(changes))
+	for _, change := range changes {
+		// Renamed files are reported as a deletion and an addition, so both names are kept
+		for _, name := range []string{change.From.Name, change.To.Name} {
+			if name != "" && (len(paths) == 0 || paths[len(paths)-1] != name) {
+				paths = append(paths, name)
+			}
+		}
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
+
+// ReadFile returns the content of the file at path in commit. The boolean is false if the file does not exist.
+func ReadFile(commit *object.Commit, path string) ([]byte, bool, error) {
+	f, err := commit.File(path)
+	if errors.Is(err, object.ErrFileNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	reader, err := f.Reader()
+	if err != nil {
+		return nil, false, err
+	}
+	defer reader.Close()
+	content, err := io.ReadAll(reader)
+
+	return content, err == nil, err
+}
+
+// ListFiles returns the sorted paths of the files directly inside dir in commit, none if dir does not exist
+func ListFiles(commit *object.Commit, dir string) ([]string, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	if dir != "." && dir != "" {
+		tree, err = tree.Tree(dir)
+		if errors.Is(err, object.ErrDirectoryNotFound) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var paths []string
+	for _, entry := range tree.Entries {
+		if entry.Mode.IsFile() {
+			paths = append(paths, path.Join(dir, entry.Name))
+		}
+	}
+	sort.Strings(paths)
+
+	return paths, nil
+}
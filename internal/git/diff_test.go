@@ -0,0 +1,143 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func commitFiles(t *testing.T, r *git.Repository, files map[string]string, removed ...string) plumbing.Hash {
+	w, err := r.Worktree()
+	assert.NoError(t, err)
+	for name, content := range files {
+		assert.NoError(t, util.WriteFile(w.Filesystem, name, []byte(content), 0600))
+		_, err = w.Add(name)
+		assert.NoError(t, err)
+	}
+	for _, name := range removed {
+		_, err = w.Remove(name)
+		assert.NoError(t, err)
+	}
+	hash, err := w.Commit("commit", &git.CommitOptions{Author: &object.Signature{Name: "author"}})
+	assert.NoError(t, err)
+
+	return hash
+}
+
+func mockDiffRepository(t *testing.T) (*git.Repository, plumbing.Hash) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	assert.NoError(t, err)
+	base := commitFiles(t, r, map[string]string{
+		"package.json":      "{}",
+		"package-lock.json": "base",
+		"README.md":         "readme",
+		"old.txt":           "old",
+	})
+	_, err = r.CreateTag("v1.0.0", base, nil)
+	assert.NoError(t, err)
+	commitFiles(t, r, map[string]string{
+		"package-lock.json":   "head",
+		"service/Cargo.lock":  "cargo",
+		"service/Cargo.toml":  "toml",
+		"unchanged/README.md": "readme",
+	}, "old.txt")
+
+	return r, base
+}
+
+func TestFindRevisionCommit(t *testing.T) {
+	r, base := mockDiffRepository(t)
+
+	for _, revision := range []string{"v1.0.0", base.String(), "HEAD~1"} {
+		commit, err := FindRevisionCommit(r, revision)
+		assert.NoError(t, err, revision)
+		assert.Equal(t, base, commit.Hash, revision)
+	}
+}
+
+func TestFindRevisionCommitUnknown(t *testing.T) {
+	r, _ := mockDiffRepository(t)
+
+	commit, err := FindRevisionCommit(r, "does-not-exist")
+
+	assert.Nil(t, commit)
+	assert.ErrorContains(t, err, "failed to resolve does-not-exist")
+}
+
+func TestFindChangedFiles(t *testing.T) {
+	r, base := mockDiffRepository(t)
+	baseCommit, err := r.CommitObject(base)
+	assert.NoError(t, err)
+	head, err := FindCommit(r)
+	assert.NoError(t, err)
+
+	paths, err := FindChangedFiles(baseCommit, head)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"old.txt",
+		"package-lock.json",
+		"service/Cargo.lock",
+		"service/Cargo.toml",
+		"unchanged/README.md",
+	}, paths)
+}
+
+func TestReadFile(t *testing.T) {
+	r, base := mockDiffRepository(t)
+	baseCommit, err := r.CommitObject(base)
+	assert.NoError(t, err)
+
+	content, found, err := ReadFile(baseCommit, "package-lock.json")
+	assert.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, "base", string(content))
+
+	content, found, err = ReadFile(baseCommit, "service/Cargo.lock")
+	assert.NoError(t, err)
+	assert.False(t, found)
+	assert.Nil(t, content)
+}
+
+func TestListFiles(t *testing.T) {
+	r, _ := mockDiffRepository(t)
+	head, err := FindCommit(r)
+	assert.NoError(t, err)
+
+	paths, err := ListFiles(head, ".")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"README.md", "package-lock.json", "package.json"}, paths)
+
+	paths, err = ListFiles(head, "service")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"service/Cargo.lock", "service/Cargo.toml"}, paths)
+
+	paths, err = ListFiles(head, "missing")
+	assert.NoError(t, err)
+	assert.Empty(t, paths)
+}
+
+func TestFindWorktreePath(t *testing.T) {
+	dir := t.TempDir()
+	_, err := git.PlainInit(dir, false)
+	assert.NoError(t, err)
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "services", "api"), 0755))
+
+	r, err := FindEnclosingRepository(filepath.Join(dir, "services", "api"))
+	assert.NoError(t, err)
+
+	worktreePath, err := FindWorktreePath(r, filepath.Join(dir, "services", "api"))
+	assert.NoError(t, err)
+	assert.Equal(t, "services/api", worktreePath)
+	worktreePath, err = FindWorktreePath(r, dir)
+	assert.NoError(t, err)
+	assert.Equal(t, ".", worktreePath)
+}
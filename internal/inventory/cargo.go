@@ -0,0 +1,79 @@
+package inventory
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+type cargoLockPackage struct {
+	Name         string   `toml:"name"`
+	Version      string   `toml:"version"`
+	Source       string   `toml:"source"`
+	Checksum     string   `toml:"checksum"`
+	Dependencies []string `toml:"dependencies"`
+}
+
+type cargoLockFile struct {
+	Packages []cargoLockPackage `toml:"package"`
+}
+
+// CargoParser parses Cargo.lock files
+type CargoParser struct{}
+
+func (p CargoParser) Parse(lockFile string, _ string) ([]Dependency, error) {
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		return nil, err
+	}
+	var lock cargoLockFile
+	err = toml.Unmarshal(content, &lock)
+	if err != nil {
+		return nil, err
+	}
+
+	// Workspace members are the packages without a source
+	directNames := map[string]bool{}
+	versions := map[string][]string{}
+	for _, pkg := range lock.Packages {
+		versions[pkg.Name] = append(versions[pkg.Name], pkg.Version)
+		if pkg.Source != "" {
+			continue
+		}
+		for _, dependency := range pkg.Dependencies {
+			directNames[strings.Fields(dependency)[0]] = true
+		}
+	}
+
+	set := newDependencySet()
+	for _, pkg := range lock.Packages {
+		if pkg.Source == "" {
+			continue
+		}
+		var children []string
+		for _, dependency := range pkg.Dependencies {
+			// Cargo only writes the version, and the source, when they are needed to disambiguate
+			fields := strings.Fields(dependency)
+			if len(fields) > 1 {
+				children = append(children, NewPurl(EcosystemCargo, fields[0], fields[1]))
+			} else if len(versions[fields[0]]) == 1 {
+				children = append(children, NewPurl(EcosystemCargo, fields[0], versions[fields[0]][0]))
+			}
+		}
+		var hashes []Hash
+		if hash, ok := newHexHash("sha256", pkg.Checksum); ok {
+			hashes = append(hashes, hash)
+		}
+		set.add(Dependency{
+			Name:         pkg.Name,
+			Version:      pkg.Version,
+			Ecosystem:    EcosystemCargo,
+			Direct:       directNames[pkg.Name],
+			Hashes:       hashes,
+			Dependencies: children,
+		})
+	}
+
+	return set.toSlice(), nil
+}
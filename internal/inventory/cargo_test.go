@@ -0,0 +1,29 @@
+package inventory
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCargoParser(t *testing.T) {
+	dependencies, err := CargoParser{}.Parse(filepath.Join("testdata", "cargo", "Cargo.lock"), "")
+
+	assert.NoError(t, err)
+	assertDependencies(t, map[string]bool{
+		"itoa@1.0.9":         false,
+		"serde@1.0.188":      true,
+		"serde_json@1.0.107": true,
+	}, dependencies)
+}
+
+func TestCargoParserGraphAndHashes(t *testing.T) {
+	dependencies, err := CargoParser{}.Parse(filepath.Join("testdata", "cargo", "Cargo.lock"), "")
+
+	assert.NoError(t, err)
+	assertGraph(t, map[string][]string{
+		"serde_json@1.0.107": {"itoa@1.0.9", "serde@1.0.188"},
+	}, dependencies)
+	assert.Equal(t, []Hash{{Algorithm: HashSHA256, Value: "af150ab688ff2122fcef229be89cb50dd66af9e01a4ff320cc137eecc9bacc38"}}, findDependency(t, dependencies, "itoa", "1.0.9").Hashes)
+}
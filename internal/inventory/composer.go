@@ -0,0 +1,102 @@
+package inventory
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/debricked/cli/internal/scope"
+)
+
+type composerPackage struct {
+	Name    string            `json:"name"`
+	Version string            `json:"version"`
+	Require map[string]string `json:"require"`
+	Dist    struct {
+		Shasum string `json:"shasum"`
+	} `json:"dist"`
+}
+
+type composerLockFile struct {
+	Packages    []composerPackage `json:"packages"`
+	PackagesDev []composerPackage `json:"packages-dev"`
+}
+
+type composerJson struct {
+	Require    map[string]string `json:"require"`
+	RequireDev map[string]string `json:"require-dev"`
+}
+
+// ComposerParser parses composer.lock files
+type ComposerParser struct{}
+
+func (p ComposerParser) Parse(lockFile string, manifestFile string) ([]Dependency, error) {
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		return nil, err
+	}
+	var lock composerLockFile
+	err = json.Unmarshal(content, &lock)
+	if err != nil {
+		return nil, err
+	}
+
+	packages := append(lock.Packages, lock.PackagesDev...)
+	purls := map[string]string{}
+	for _, pkg := range packages {
+		purls[strings.ToLower(pkg.Name)] = NewPurl(EcosystemComposer, pkg.Name, composerVersion(pkg.Version))
+	}
+
+	directNames := p.readDirectNames(manifestFile)
+	set := newDependencySet()
+	for i, pkg := range packages {
+		var children []string
+		// Platform requirements such as php and ext-json are not in the lock file and therefore never resolved
+		for name := range pkg.Require {
+			if purl, ok := purls[strings.ToLower(name)]; ok {
+				children = append(children, purl)
+			}
+		}
+		var hashes []Hash
+		if hash, ok := newHexHash("sha1", pkg.Dist.Shasum); ok {
+			hashes = append(hashes, hash)
+		}
+		set.add(Dependency{
+			Name:         pkg.Name,
+			Version:      composerVersion(pkg.Version),
+			Ecosystem:    EcosystemComposer,
+			Direct:       directNames[strings.ToLower(pkg.Name)],
+			Hashes:       hashes,
+			Dependencies: children,
+			Scopes:       []string{scope.DevIf(i >= len(lock.Packages))},
+		})
+	}
+
+	return set.toSlice(), nil
+}
+
+func composerVersion(version string) string {
+	return strings.TrimPrefix(version, "v")
+}
+
+func (p ComposerParser) readDirectNames(manifestFile string) map[string]bool {
+	names := map[string]bool{}
+	if manifestFile == "" {
+		return names
+	}
+	content, err := os.ReadFile(manifestFile)
+	if err != nil {
+		return names
+	}
+	var manifest composerJson
+	if json.Unmarshal(content, &manifest) != nil {
+		return names
+	}
+	for _, deps := range []map[string]string{manifest.Require, manifest.RequireDev} {
+		for name := range deps {
+			names[strings.ToLower(name)] = true
+		}
+	}
+
+	return names
+}
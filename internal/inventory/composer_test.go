@@ -0,0 +1,55 @@
+package inventory
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/scope"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComposerParser(t *testing.T) {
+	dependencies, err := ComposerParser{}.Parse(
+		filepath.Join("testdata", "composer", "composer.lock"),
+		filepath.Join("testdata", "composer", "composer.json"),
+	)
+
+	assert.NoError(t, err)
+	assertDependencies(t, map[string]bool{
+		"guzzlehttp/guzzle@7.8.0": true,
+		"guzzlehttp/psr7@2.6.1":   false,
+		"phpunit/phpunit@10.4.1":  true,
+	}, dependencies)
+}
+
+func TestComposerParserWithoutManifest(t *testing.T) {
+	dependencies, err := ComposerParser{}.Parse(filepath.Join("testdata", "composer", "composer.lock"), "")
+
+	assert.NoError(t, err)
+	assert.Len(t, dependencies, 3)
+	for _, dependency := range dependencies {
+		assert.False(t, dependency.Direct)
+	}
+}
+
+func TestComposerParserGraph(t *testing.T) {
+	dependencies, err := ComposerParser{}.Parse(filepath.Join("testdata", "composer", "composer.lock"), "")
+
+	assert.NoError(t, err)
+	assertGraph(t, map[string][]string{
+		"guzzlehttp/guzzle@7.8.0": {"guzzlehttp/psr7@2.6.1"},
+	}, dependencies)
+	assert.Empty(t, findDependency(t, dependencies, "guzzlehttp/guzzle", "7.8.0").Hashes)
+	assert.Equal(t, []Hash{{Algorithm: HashSHA1, Value: "8a2d6e5d1bd5b4b1e8c5e6bba3f5a7e0b5f4c0d9"}}, findDependency(t, dependencies, "guzzlehttp/psr7", "2.6.1").Hashes)
+}
+
+func TestComposerParserScopes(t *testing.T) {
+	dependencies, err := ComposerParser{}.Parse(filepath.Join("testdata", "composer", "composer.lock"), "")
+
+	assert.NoError(t, err)
+	assertScopes(t, map[string][]string{
+		"guzzlehttp/guzzle@7.8.0": {scope.Prod},
+		"guzzlehttp/psr7@2.6.1":   {scope.Prod},
+		"phpunit/phpunit@10.4.1":  {scope.Dev},
+	}, dependencies)
+}
@@ -0,0 +1,140 @@
+package inventory
+
+import (
+	"sort"
+	"strings"
+)
+
+// VersionChange describes a dependency resolved to a different version than before
+type VersionChange struct {
+	Name        string `json:"name"`
+	Ecosystem   string `json:"ecosystem"`
+	FromVersion string `json:"fromVersion"`
+	ToVersion   string `json:"toVersion"`
+	Direct      bool   `json:"direct"`
+}
+
+// Delta holds the dependency changes between two sets of dependencies
+type Delta struct {
+	Added    []Dependency    `json:"added"`
+	Removed  []Dependency    `json:"removed"`
+	Upgraded []VersionChange `json:"upgraded"`
+}
+
+// NewDelta compares dependencies on ecosystem and name. A dependency whose versions differ between base and head
+// is reported as upgraded, regardless of whether the version went up or down.
+func NewDelta(base []Dependency, head []Dependency) Delta {
+	baseVersions := groupVersions(base)
+	headVersions := groupVersions(head)
+	delta := Delta{Added: []Dependency{}, Removed: []Dependency{}, Upgraded: []VersionChange{}}
+
+	for key, headDependencies := range headVersions {
+		baseDependencies, ok := baseVersions[key]
+		if !ok {
+			delta.Added = append(delta.Added, headDependencies...)
+
+			continue
+		}
+		from, to := versionsOf(baseDependencies), versionsOf(headDependencies)
+		if from == to {
+			continue
+		}
+		delta.Upgraded = append(delta.Upgraded, VersionChange{
+			Name:        headDependencies[0].Name,
+			Ecosystem:   headDependencies[0].Ecosystem,
+			FromVersion: from,
+			ToVersion:   to,
+			Direct:      isDirect(headDependencies),
+		})
+	}
+	for key, baseDependencies := range baseVersions {
+		if _, ok := headVersions[key]; !ok {
+			delta.Removed = append(delta.Removed, baseDependencies...)
+		}
+	}
+
+	sortDependencies(delta.Added)
+	sortDependencies(delta.Removed)
+	sort.Slice(delta.Upgraded, func(i, j int) bool {
+		a, b := delta.Upgraded[i], delta.Upgraded[j]
+		if a.Ecosystem != b.Ecosystem {
+			return a.Ecosystem < b.Ecosystem
+		}
+
+		return a.Name < b.Name
+	})
+
+	return delta
+}
+
+func (delta Delta) IsEmpty() bool {
+	return len(delta.Added) == 0 && len(delta.Removed) == 0 && len(delta.Upgraded) == 0
+}
+
+// Introduced returns the keys of the added and upgraded dependencies, as returned by DependencyKey
+func (delta Delta) Introduced() map[string]bool {
+	keys := map[string]bool{}
+	for _, dependency := range delta.Added {
+		keys[DependencyKey(dependency.Ecosystem, dependency.Name)] = true
+	}
+	for _, change := range delta.Upgraded {
+		keys[DependencyKey(change.Ecosystem, change.Name)] = true
+	}
+
+	return keys
+}
+
+// groupVersions groups dependencies on ecosystem and name, keeping one entry per version
+func groupVersions(dependencies []Dependency) map[string][]Dependency {
+	groups := map[string][]Dependency{}
+	for _, dependency := range dependencies {
+		key := DependencyKey(dependency.Ecosystem, dependency.Name)
+		duplicate := false
+		for i, existing := range groups[key] {
+			if existing.Version == dependency.Version {
+				groups[key][i].Direct = existing.Direct || dependency.Direct
+				duplicate = true
+			}
+		}
+		if !duplicate {
+			groups[key] = append(groups[key], dependency)
+		}
+	}
+
+	return groups
+}
+
+// versionsOf returns the sorted versions of dependencies, comma separated
+func versionsOf(dependencies []Dependency) string {
+	versions := make([]string, 0, len(dependencies))
+	for _, dependency := range dependencies {
+		versions = append(versions, dependency.Version)
+	}
+	sort.Strings(versions)
+
+	return strings.Join(versions, ", ")
+}
+
+func isDirect(dependencies []Dependency) bool {
+	for _, dependency := range dependencies {
+		if dependency.Direct {
+			return true
+		}
+	}
+
+	return false
+}
+
+func sortDependencies(dependencies []Dependency) {
+	sort.Slice(dependencies, func(i, j int) bool {
+		a, b := dependencies[i], dependencies[j]
+		if a.Ecosystem != b.Ecosystem {
+			return a.Ecosystem < b.Ecosystem
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+
+		return a.Version < b.Version
+	})
+}
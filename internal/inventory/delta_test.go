@@ -0,0 +1,80 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDelta(t *testing.T) {
+	base := []Dependency{
+		{Name: "lodash", Version: "4.17.20", Ecosystem: EcosystemNpm, Direct: true},
+		{Name: "left-pad", Version: "1.3.0", Ecosystem: EcosystemNpm},
+		{Name: "chalk", Version: "4.1.2", Ecosystem: EcosystemNpm},
+		{Name: "serde", Version: "1.0.188", Ecosystem: EcosystemCargo},
+	}
+	head := []Dependency{
+		{Name: "lodash", Version: "4.17.21", Ecosystem: EcosystemNpm, Direct: true},
+		{Name: "chalk", Version: "4.1.2", Ecosystem: EcosystemNpm},
+		{Name: "chalk", Version: "4.1.2", Ecosystem: EcosystemNpm, SourceFile: "other/package-lock.json"},
+		{Name: "axios", Version: "1.6.0", Ecosystem: EcosystemNpm},
+		{Name: "serde", Version: "1.0.188", Ecosystem: EcosystemCargo},
+	}
+
+	delta := NewDelta(base, head)
+
+	assert.False(t, delta.IsEmpty())
+	assert.Len(t, delta.Added, 1)
+	assert.Equal(t, "axios", delta.Added[0].Name)
+	assert.Len(t, delta.Removed, 1)
+	assert.Equal(t, "left-pad", delta.Removed[0].Name)
+	assert.Equal(t, []VersionChange{
+		{Name: "lodash", Ecosystem: EcosystemNpm, FromVersion: "4.17.20", ToVersion: "4.17.21", Direct: true},
+	}, delta.Upgraded)
+	assert.Equal(t, map[string]bool{"npm/axios": true, "npm/lodash": true}, delta.Introduced())
+}
+
+func TestNewDeltaMultipleVersions(t *testing.T) {
+	base := []Dependency{
+		{Name: "ms", Version: "2.0.0", Ecosystem: EcosystemNpm},
+	}
+	head := []Dependency{
+		{Name: "MS", Version: "2.1.3", Ecosystem: EcosystemNpm},
+		{Name: "ms", Version: "2.0.0", Ecosystem: EcosystemNpm},
+	}
+
+	delta := NewDelta(base, head)
+
+	assert.Len(t, delta.Upgraded, 1)
+	assert.Equal(t, "2.0.0", delta.Upgraded[0].FromVersion)
+	assert.Equal(t, "2.0.0, 2.1.3", delta.Upgraded[0].ToVersion)
+}
+
+func TestNewDeltaNormalizedNames(t *testing.T) {
+	base := []Dependency{
+		{Name: "Foo_Bar", Version: "1.0.0", Ecosystem: EcosystemPypi},
+		{Name: "foo", Version: "1.0.0", Ecosystem: EcosystemNpm},
+	}
+	head := []Dependency{
+		{Name: "foo-bar", Version: "1.0.0", Ecosystem: EcosystemPypi},
+		{Name: "foo", Version: "1.0.0", Ecosystem: EcosystemNpm},
+		{Name: "foo", Version: "2.0.0", Ecosystem: EcosystemPypi},
+	}
+
+	delta := NewDelta(base, head)
+
+	assert.Empty(t, delta.Upgraded)
+	assert.Empty(t, delta.Removed)
+	assert.Equal(t, map[string]bool{"pypi/foo": true}, delta.Introduced())
+}
+
+func TestNewDeltaUnchanged(t *testing.T) {
+	dependencies := []Dependency{
+		{Name: "serde", Version: "1.0.188", Ecosystem: EcosystemCargo},
+	}
+
+	delta := NewDelta(dependencies, dependencies)
+
+	assert.True(t, delta.IsEmpty())
+	assert.Empty(t, delta.Introduced())
+}
@@ -0,0 +1,333 @@
+package inventory
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/debricked/cli/internal/scope"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// lockFileFilter removes the entries of the dependencies identified by the purls in excluded from a lock file
+type lockFileFilter func(content []byte, excluded map[string]bool) ([]byte, error)
+
+func lockFileFilterFor(lockFile string) lockFileFilter {
+	switch filepath.Base(lockFile) {
+	case "package-lock.json", "npm-shrinkwrap.json":
+		return filterNpmLock
+	case "yarn.lock":
+		return filterYarnLock
+	case "pnpm-lock.yaml":
+		return filterPnpmLock
+	case "composer.lock":
+		return filterComposerLock
+	case "poetry.lock":
+		return filterPoetryLock
+	}
+
+	return nil
+}
+
+// FilterLockFile returns the content of the native lock file lockFile without the dependencies outside the scopes of
+// filter, using manifestFile to tell the scopes if the lock file does not. Lock files that can't be filtered, such as
+// those without scopes, are returned as they are.
+func FilterLockFile(lockFile string, manifestFile string, filter scope.Filter) ([]byte, error) {
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		return nil, err
+	}
+	remove := lockFileFilterFor(lockFile)
+	if remove == nil || len(filter) == 0 {
+		return content, nil
+	}
+	dependencies, err := ParseLockFile(lockFile, manifestFile)
+	if err != nil {
+		return nil, err
+	}
+	excluded := map[string]bool{}
+	for _, dependency := range dependencies {
+		if !filter.Keeps(dependency.Scopes...) {
+			excluded[dependency.Purl] = true
+		}
+	}
+	if len(excluded) == 0 {
+		return content, nil
+	}
+
+	return remove(content, excluded)
+}
+
+// filterNpmLock removes packages from the packages of lockfileVersion 2 and 3 and the dependencies of version 1
+func filterNpmLock(content []byte, excluded map[string]bool) ([]byte, error) {
+	var lock map[string]json.RawMessage
+	err := json.Unmarshal(content, &lock)
+	if err != nil {
+		return nil, err
+	}
+	if raw, ok := lock["packages"]; ok {
+		lock["packages"], err = filterNpmPackages(raw, excluded)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if raw, ok := lock["dependencies"]; ok {
+		lock["dependencies"], err = filterNpmDependencies(raw, excluded)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return marshalJson(lock)
+}
+
+// filterNpmPackages removes packages from the packages of lockfileVersion 2 and 3, which are keyed by their path
+func filterNpmPackages(raw json.RawMessage, excluded map[string]bool) (json.RawMessage, error) {
+	var packages map[string]json.RawMessage
+	err := json.Unmarshal(raw, &packages)
+	if err != nil {
+		return nil, err
+	}
+	for key, rawPackage := range packages {
+		var pkg npmLockPackage
+		if json.Unmarshal(rawPackage, &pkg) == nil && strings.Contains(key, nodeModules) &&
+			excluded[NewPurl(EcosystemNpm, npmPackageName(key, pkg), pkg.Version)] {
+			delete(packages, key)
+		}
+	}
+
+	return json.Marshal(packages)
+}
+
+// filterNpmDependencies removes dependencies from the nested dependencies of lockfileVersion 1
+func filterNpmDependencies(raw json.RawMessage, excluded map[string]bool) (json.RawMessage, error) {
+	var dependencies map[string]map[string]json.RawMessage
+	err := json.Unmarshal(raw, &dependencies)
+	if err != nil {
+		return nil, err
+	}
+	for name, dependency := range dependencies {
+		var version string
+		_ = json.Unmarshal(dependency["version"], &version)
+		if excluded[NewPurl(EcosystemNpm, name, version)] {
+			delete(dependencies, name)
+
+			continue
+		}
+		if nested, ok := dependency["dependencies"]; ok {
+			dependency["dependencies"], err = filterNpmDependencies(nested, excluded)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return json.Marshal(dependencies)
+}
+
+// filterComposerLock removes packages from both the packages and the packages-dev of composer.lock
+func filterComposerLock(content []byte, excluded map[string]bool) ([]byte, error) {
+	var lock map[string]json.RawMessage
+	err := json.Unmarshal(content, &lock)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range []string{"packages", "packages-dev"} {
+		raw, ok := lock[key]
+		if !ok {
+			continue
+		}
+		var packages []json.RawMessage
+		err = json.Unmarshal(raw, &packages)
+		if err != nil {
+			return nil, err
+		}
+		kept := make([]json.RawMessage, 0, len(packages))
+		for _, rawPackage := range packages {
+			var pkg composerPackage
+			if json.Unmarshal(rawPackage, &pkg) == nil &&
+				excluded[NewPurl(EcosystemComposer, pkg.Name, composerVersion(pkg.Version))] {
+				continue
+			}
+			kept = append(kept, rawPackage)
+		}
+		lock[key], err = json.Marshal(kept)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return marshalJson(lock)
+}
+
+// marshalJson indents like npm and Composer do, without escaping characters such as > in version constraints
+func marshalJson(value interface{}) ([]byte, error) {
+	var content bytes.Buffer
+	encoder := json.NewEncoder(&content)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "    ")
+	err := encoder.Encode(value)
+
+	return content.Bytes(), err
+}
+
+// filterYarnLock removes entries from yarn.lock, which are separated by unindented headers such as `lodash@^4.17.21:`
+func filterYarnLock(content []byte, excluded map[string]bool) ([]byte, error) {
+	var filtered bytes.Buffer
+	for _, entry := range splitBlocks(string(content), func(line string) bool {
+		return strings.TrimSpace(line) != "" && !strings.HasPrefix(line, " ")
+	}) {
+		lines := strings.Split(entry, "\n")
+		name := ""
+		if !strings.HasPrefix(lines[0], "#") {
+			name = yarnEntryName(lines[0])
+		}
+		version := ""
+		for _, line := range lines[1:] {
+			if field, value := yarnField(line); field == "version" && !strings.HasPrefix(line, "    ") {
+				version = value
+			}
+		}
+		if name != "" && excluded[NewPurl(EcosystemNpm, name, version)] {
+			continue
+		}
+		filtered.WriteString(entry)
+	}
+
+	return filtered.Bytes(), nil
+}
+
+// filterPoetryLock removes [[package]] tables, along with their sub-tables such as [package.dependencies], from
+// poetry.lock
+func filterPoetryLock(content []byte, excluded map[string]bool) ([]byte, error) {
+	var filtered bytes.Buffer
+	for _, table := range splitBlocks(string(content), func(line string) bool {
+		line = strings.TrimSpace(line)
+
+		return line == "[[package]]" || (strings.HasPrefix(line, "[") && !strings.HasPrefix(line, "[package."))
+	}) {
+		var lock struct {
+			Packages []pythonLockPackage `toml:"package"`
+		}
+		if strings.HasPrefix(table, "[[package]]") && toml.Unmarshal([]byte(table), &lock) == nil && len(lock.Packages) == 1 &&
+			excluded[NewPurl(EcosystemPypi, lock.Packages[0].Name, lock.Packages[0].Version)] {
+			continue
+		}
+		filtered.WriteString(table)
+	}
+
+	return filtered.Bytes(), nil
+}
+
+// splitBlocks splits content into blocks starting at the lines isStart holds for, keeping line endings, so that the
+// blocks add up to content
+func splitBlocks(content string, isStart func(line string) bool) []string {
+	var blocks []string
+	var block strings.Builder
+	for _, line := range strings.SplitAfter(content, "\n") {
+		if isStart(line) && block.Len() > 0 {
+			blocks = append(blocks, block.String())
+			block.Reset()
+		}
+		block.WriteString(line)
+	}
+	if block.Len() > 0 {
+		blocks = append(blocks, block.String())
+	}
+
+	return blocks
+}
+
+// filterPnpmLock removes packages, snapshots and importer dependencies from pnpm-lock.yaml
+func filterPnpmLock(content []byte, excluded map[string]bool) ([]byte, error) {
+	var document yaml.Node
+	err := yaml.Unmarshal(content, &document)
+	if err != nil || len(document.Content) == 0 {
+		return content, err
+	}
+	root := document.Content[0]
+	filterPnpmImporters(root, excluded)
+	for _, key := range []string{"packages", "snapshots"} {
+		removeYamlMappingEntries(root, key, func(key string, _ *yaml.Node) bool {
+			name, version := pnpmPackageKey(key)
+
+			return version != "" && excluded[NewPurl(EcosystemNpm, name, version)]
+		})
+	}
+
+	var filtered bytes.Buffer
+	encoder := yaml.NewEncoder(&filtered)
+	encoder.SetIndent(2)
+	err = encoder.Encode(&document)
+	if err != nil {
+		return nil, err
+	}
+
+	return filtered.Bytes(), encoder.Close()
+}
+
+// filterPnpmImporters removes the dependencies of the importers of pnpm-lock.yaml, which are found at the root of lock
+// files of a single project before lockfile version 6
+func filterPnpmImporters(root *yaml.Node, excluded map[string]bool) {
+	importers := []*yaml.Node{root}
+	if node := yamlMappingValue(root, "importers"); node != nil {
+		for i := 1; i < len(node.Content); i += 2 {
+			importers = append(importers, node.Content[i])
+		}
+	}
+	for _, importer := range importers {
+		for _, key := range []string{"dependencies", "devDependencies", "optionalDependencies"} {
+			removeYamlMappingEntries(importer, key, func(name string, value *yaml.Node) bool {
+				reference := value.Value
+				if version := yamlMappingValue(value, "version"); version != nil {
+					reference = version.Value
+				}
+				name, version := pnpmDependencyReference(name, reference)
+
+				return version != "" && excluded[NewPurl(EcosystemNpm, name, version)]
+			})
+		}
+	}
+}
+
+func yamlMappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
+
+// removeYamlMappingEntries removes the entries remove holds for from the mapping at key in node, and the mapping
+// itself if no entries are left
+func removeYamlMappingEntries(node *yaml.Node, key string, remove func(key string, value *yaml.Node) bool) {
+	mapping := yamlMappingValue(node, key)
+	if mapping == nil || mapping.Kind != yaml.MappingNode {
+		return
+	}
+	var kept []*yaml.Node
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if !remove(mapping.Content[i].Value, mapping.Content[i+1]) {
+			kept = append(kept, mapping.Content[i], mapping.Content[i+1])
+		}
+	}
+	mapping.Content = kept
+	if len(kept) > 0 {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content = append(node.Content[:i], node.Content[i+2:]...)
+
+			return
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package inventory
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/scope"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterLockFile(t *testing.T) {
+	cases := []struct {
+		lockFile     string
+		manifestFile string
+	}{
+		{lockFile: filepath.Join("npm", "package-lock.json"), manifestFile: filepath.Join("npm", "package.json")},
+		{lockFile: filepath.Join("npm-v1", "package-lock.json"), manifestFile: filepath.Join("npm-v1", "package.json")},
+		{lockFile: filepath.Join("yarn", "yarn.lock"), manifestFile: filepath.Join("yarn", "package.json")},
+		{lockFile: filepath.Join("yarn-berry", "yarn.lock"), manifestFile: filepath.Join("yarn-berry", "package.json")},
+		{lockFile: filepath.Join("pnpm", "pnpm-lock.yaml")},
+		{lockFile: filepath.Join("composer", "composer.lock"), manifestFile: filepath.Join("composer", "composer.json")},
+		{lockFile: filepath.Join("poetry", "poetry.lock"), manifestFile: filepath.Join("poetry", "pyproject.toml")},
+	}
+	for _, c := range cases {
+		t.Run(c.lockFile, func(t *testing.T) {
+			lockFile := filepath.Join("testdata", c.lockFile)
+			manifestFile := ""
+			if c.manifestFile != "" {
+				manifestFile = filepath.Join("testdata", c.manifestFile)
+			}
+			filter := scope.Filter{scope.Prod: true}
+			dependencies, err := ParseLockFile(lockFile, manifestFile)
+			assert.NoError(t, err)
+			inv := Inventory{Dependencies: dependencies}
+			inv.Filter(filter)
+
+			content, err := FilterLockFile(lockFile, manifestFile, filter)
+
+			assert.NoError(t, err)
+			filteredLockFile := filepath.Join(t.TempDir(), filepath.Base(lockFile))
+			assert.NoError(t, os.WriteFile(filteredLockFile, content, 0600))
+			filtered, err := ParseLockFile(filteredLockFile, manifestFile)
+			assert.NoError(t, err)
+			assert.ElementsMatch(t, purls(inv.Dependencies), purls(filtered))
+		})
+	}
+}
+
+func TestFilterLockFileKeepingEveryScope(t *testing.T) {
+	lockFile := filepath.Join("testdata", "npm", "package-lock.json")
+	expected, err := os.ReadFile(lockFile)
+	assert.NoError(t, err)
+
+	content, err := FilterLockFile(lockFile, "", scope.Filter{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, content)
+}
+
+func TestFilterLockFileWithoutScopes(t *testing.T) {
+	lockFile := filepath.Join("testdata", "cargo", "Cargo.lock")
+	expected, err := os.ReadFile(lockFile)
+	assert.NoError(t, err)
+
+	content, err := FilterLockFile(lockFile, "", scope.Filter{scope.Prod: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, expected, content)
+}
+
+func TestFilterLockFileNotFound(t *testing.T) {
+	_, err := FilterLockFile(filepath.Join("testdata", "npm", "missing", "package-lock.json"), "", scope.Filter{scope.Prod: true})
+
+	assert.Error(t, err)
+}
+
+func purls(dependencies []Dependency) []string {
+	var purls []string
+	for _, dependency := range dependencies {
+		purls = append(purls, dependency.Purl)
+	}
+
+	return purls
+}
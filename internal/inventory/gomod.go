@@ -0,0 +1,68 @@
+package inventory
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// GoModParser parses gomod.debricked.lock files, i.e. `go mod graph` output followed by `go list -m all` output
+type GoModParser struct{}
+
+func (p GoModParser) Parse(lockFile string, _ string) ([]Dependency, error) {
+	f, err := os.Open(lockFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	directModules := map[string]bool{}
+	edges := map[string][]string{}
+	versions := map[string]string{}
+	var modules []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+		if strings.Contains(fields[1], "@") {
+			module, _, _ := strings.Cut(fields[1], "@")
+			// Graph edge, the main module is the only node without a version
+			if !strings.Contains(fields[0], "@") {
+				directModules[module] = true
+			} else {
+				edges[fields[0]] = append(edges[fields[0]], module)
+			}
+
+			continue
+		}
+		if _, ok := versions[fields[0]]; !ok {
+			modules = append(modules, fields[0])
+		}
+		versions[fields[0]] = fields[1]
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	set := newDependencySet()
+	for _, module := range modules {
+		// Edges are only followed from the selected version, and point to the selected version of each requirement
+		var children []string
+		for _, child := range edges[module+"@"+versions[module]] {
+			if version, ok := versions[child]; ok {
+				children = append(children, NewPurl(EcosystemGo, child, version))
+			}
+		}
+		set.add(Dependency{
+			Name:         module,
+			Version:      versions[module],
+			Ecosystem:    EcosystemGo,
+			Direct:       directModules[module],
+			Dependencies: children,
+		})
+	}
+
+	return set.toSlice(), nil
+}
@@ -0,0 +1,35 @@
+package inventory
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoModParser(t *testing.T) {
+	dependencies, err := GoModParser{}.Parse(filepath.Join("testdata", "gomod", "gomod.debricked.lock"), "")
+
+	assert.NoError(t, err)
+	assertDependencies(t, map[string]bool{
+		"github.com/davecgh/go-spew@v1.1.1":  false,
+		"github.com/stretchr/testify@v1.9.0": true,
+		"golang.org/x/mod@v0.27.0":           true,
+		"gopkg.in/yaml.v3@v3.0.1":            false,
+	}, dependencies)
+}
+
+func TestGoModParserMissingFile(t *testing.T) {
+	_, err := GoModParser{}.Parse(filepath.Join("testdata", "missing", "gomod.debricked.lock"), "")
+
+	assert.Error(t, err)
+}
+
+func TestGoModParserGraph(t *testing.T) {
+	dependencies, err := GoModParser{}.Parse(filepath.Join("testdata", "gomod", "gomod.debricked.lock"), "")
+
+	assert.NoError(t, err)
+	assertGraph(t, map[string][]string{
+		"github.com/stretchr/testify@v1.9.0": {"github.com/davecgh/go-spew@v1.1.1", "gopkg.in/yaml.v3@v3.0.1"},
+	}, dependencies)
+}
@@ -0,0 +1,107 @@
+package inventory
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/debricked/cli/internal/scope"
+)
+
+const gradleTreeIndent = 5
+
+// gradleConfigurationRegex matches the headers of configurations, such as `compileClasspath - Compile classpath.`
+var gradleConfigurationRegex = regexp.MustCompile(`^([A-Za-z]\w*)(?: \(n\))?(?: - .*)?$`)
+
+// GradleParser parses gradle.debricked.lock files written by the `debrickedAllDeps` DependencyReportTask
+type GradleParser struct{}
+
+func (p GradleParser) Parse(lockFile string, _ string) ([]Dependency, error) {
+	f, err := os.Open(lockFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	set := newDependencySet()
+	// parents holds the purl of the latest dependency at each depth, empty for projects
+	var parents []string
+	// roots holds the scopes of the configurations each direct dependency is in
+	roots := map[string][]string{}
+	configuration := ""
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match := gradleConfigurationRegex.FindStringSubmatch(line); match != nil {
+			configuration = match[1]
+
+			continue
+		}
+		marker := strings.Index(line, "+--- ")
+		if marker < 0 {
+			marker = strings.Index(line, `\--- `)
+		}
+		if marker < 0 {
+			continue
+		}
+		depth := marker / gradleTreeIndent
+		if depth > len(parents) {
+			continue
+		}
+		parents = parents[:depth]
+		name, version, ok := parseGradleDependency(line[marker+len("+--- "):])
+		if !ok {
+			parents = append(parents, "")
+
+			continue
+		}
+		purl := set.add(Dependency{
+			Name:      name,
+			Version:   version,
+			Ecosystem: EcosystemMaven,
+			Direct:    depth == 0,
+		})
+		if depth > 0 {
+			set.addDependencies(parents[depth-1], purl)
+		} else if configuration != "" {
+			roots[purl] = addScopes(roots[purl], scope.Gradle(configuration))
+		}
+		parents = append(parents, purl)
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	// Repeated dependencies, marked (*), are only listed once along with their dependencies
+	set.propagateScopes(roots)
+
+	return set.toSlice(), nil
+}
+
+// parseGradleDependency parses entries such as `g:a:1.0 -> 1.1 (*)`, `g:a -> 1.1` and `g:a:{strictly 1.0} -> 1.0`
+func parseGradleDependency(entry string) (string, string, bool) {
+	if strings.HasPrefix(entry, "project ") {
+		return "", "", false
+	}
+	for _, suffix := range []string{" (*)", " (c)", " (n)"} {
+		entry = strings.TrimSuffix(entry, suffix)
+	}
+	coordinates, resolved, conflict := strings.Cut(entry, " -> ")
+	parts := strings.Split(strings.TrimSpace(coordinates), ":")
+	if len(parts) < 2 {
+		return "", "", false
+	}
+	version := ""
+	if len(parts) > 2 {
+		version = parts[2]
+	}
+	if conflict {
+		version = strings.TrimSpace(resolved)
+	}
+	if version == "" || strings.HasPrefix(version, "project ") {
+		return "", "", false
+	}
+
+	return parts[0] + ":" + parts[1], version, true
+}
@@ -0,0 +1,64 @@
+package inventory
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/scope"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGradleParser(t *testing.T) {
+	dependencies, err := GradleParser{}.Parse(filepath.Join("testdata", "gradle", "gradle.debricked.lock"), "")
+
+	assert.NoError(t, err)
+	assertDependencies(t, map[string]bool{
+		"org.springframework:spring-core@5.3.30": true,
+		"org.springframework:spring-jcl@5.3.30":  false,
+		"com.google.guava:guava@32.1.2-jre":      true,
+		"com.google.guava:failureaccess@1.0.1":   false,
+		"org.slf4j:slf4j-api@2.0.9":              true,
+		"junit:junit@4.13.2":                     true,
+		"org.hamcrest:hamcrest-core@1.3":         false,
+	}, dependencies)
+}
+
+func TestParseGradleDependency(t *testing.T) {
+	_, _, ok := parseGradleDependency("project :lib")
+	assert.False(t, ok)
+
+	_, _, ok = parseGradleDependency("org.slf4j:slf4j-api")
+	assert.False(t, ok)
+
+	name, version, ok := parseGradleDependency("com.google.guava:guava:31.0-jre -> 32.1.2-jre (*)")
+	assert.True(t, ok)
+	assert.Equal(t, "com.google.guava:guava", name)
+	assert.Equal(t, "32.1.2-jre", version)
+}
+
+func TestGradleParserGraph(t *testing.T) {
+	dependencies, err := GradleParser{}.Parse(filepath.Join("testdata", "gradle", "gradle.debricked.lock"), "")
+
+	assert.NoError(t, err)
+	assertGraph(t, map[string][]string{
+		"org.springframework:spring-core@5.3.30": {"org.springframework:spring-jcl@5.3.30"},
+		"com.google.guava:guava@32.1.2-jre":      {"com.google.guava:failureaccess@1.0.1"},
+		"junit:junit@4.13.2":                     {"org.hamcrest:hamcrest-core@1.3"},
+	}, dependencies)
+}
+
+func TestGradleParserScopes(t *testing.T) {
+	dependencies, err := GradleParser{}.Parse(filepath.Join("testdata", "gradle", "gradle.debricked.lock"), "")
+
+	assert.NoError(t, err)
+	assertScopes(t, map[string][]string{
+		"org.springframework:spring-core@5.3.30": {scope.Prod, scope.Test},
+		"org.springframework:spring-jcl@5.3.30":  {scope.Prod, scope.Test},
+		"com.google.guava:guava@32.1.2-jre":      {scope.Prod},
+		"com.google.guava:failureaccess@1.0.1":   {scope.Prod},
+		"org.slf4j:slf4j-api@2.0.9":              {scope.Prod},
+		"junit:junit@4.13.2":                     {scope.Test},
+		"org.hamcrest:hamcrest-core@1.3":         {scope.Test},
+	}, dependencies)
+}
@@ -0,0 +1,86 @@
+package inventory
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// Hash algorithm names follow the CycloneDX hash-alg enumeration
+const (
+	HashSHA1   = "SHA-1"
+	HashSHA256 = "SHA-256"
+	HashSHA384 = "SHA-384"
+	HashSHA512 = "SHA-512"
+)
+
+type Hash struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"value"`
+}
+
+var hashAlgorithms = map[string]string{
+	"sha1":   HashSHA1,
+	"sha256": HashSHA256,
+	"sha384": HashSHA384,
+	"sha512": HashSHA512,
+}
+
+var hashHexLengths = map[string]int{
+	HashSHA1:   40,
+	HashSHA256: 64,
+	HashSHA384: 96,
+	HashSHA512: 128,
+}
+
+// hashesFromIntegrity converts Subresource Integrity strings such as `sha512-<base64>` into hex encoded hashes
+func hashesFromIntegrity(integrity string) []Hash {
+	var hashes []Hash
+	for _, entry := range strings.Fields(integrity) {
+		algorithm, digest, found := strings.Cut(entry, "-")
+		if !found {
+			continue
+		}
+		if hash, ok := newBase64Hash(algorithm, digest); ok {
+			hashes = append(hashes, hash)
+		}
+	}
+
+	return hashes
+}
+
+// hashFromPrefixed converts hashes such as `sha256:<hex>` used by Python lock files
+func hashFromPrefixed(prefixed string) []Hash {
+	algorithm, digest, found := strings.Cut(prefixed, ":")
+	if !found {
+		return nil
+	}
+	if hash, ok := newHexHash(algorithm, digest); ok {
+		return []Hash{hash}
+	}
+
+	return nil
+}
+
+func newBase64Hash(algorithm string, digest string) (Hash, bool) {
+	decoded, err := base64.StdEncoding.DecodeString(digest)
+	if err != nil {
+		return Hash{}, false
+	}
+
+	return newHexHash(algorithm, hex.EncodeToString(decoded))
+}
+
+// newHexHash validates digest against the expected length of algorithm
+func newHexHash(algorithm string, digest string) (Hash, bool) {
+	name, ok := hashAlgorithms[strings.ToLower(algorithm)]
+	if !ok {
+		return Hash{}, false
+	}
+	digest = strings.ToLower(digest)
+	if _, err := hex.DecodeString(digest); err != nil || len(digest) != hashHexLengths[name] {
+		return Hash{}, false
+	}
+
+	return Hash{Algorithm: name, Value: digest}, true
+}
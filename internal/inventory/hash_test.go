@@ -0,0 +1,23 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHashesFromIntegrity(t *testing.T) {
+	hashes := hashesFromIntegrity("sha1-2jmj7l5rSw0yVb/vlWAYkK/YBwk= sha512-invalid md5-1B2M2Y8AsgTpgAmY7PhCfg==")
+
+	assert.Equal(t, []Hash{{Algorithm: HashSHA1, Value: "da39a3ee5e6b4b0d3255bfef95601890afd80709"}}, hashes)
+	assert.Nil(t, hashesFromIntegrity(""))
+}
+
+func TestHashFromPrefixed(t *testing.T) {
+	hashes := hashFromPrefixed("sha256:E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855")
+
+	assert.Equal(t, []Hash{{Algorithm: HashSHA256, Value: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"}}, hashes)
+	assert.Nil(t, hashFromPrefixed("sha256"))
+	assert.Nil(t, hashFromPrefixed("sha256:abc"))
+	assert.Nil(t, hashFromPrefixed("md5:d41d8cd98f00b204e9800998ecf8427e"))
+}
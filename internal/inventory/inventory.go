@@ -0,0 +1,181 @@
+package inventory
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/scope"
+)
+
+const OutputFileNameInventory = "debricked.inventory.json"
+
+type Dependency struct {
+	Name       string `json:"name"`
+	Version    string `json:"version"`
+	Ecosystem  string `json:"ecosystem"`
+	Purl       string `json:"purl"`
+	Direct     bool   `json:"direct"`
+	SourceFile string `json:"sourceFile"`
+	Hashes     []Hash `json:"hashes,omitempty"`
+	// Dependencies holds the purls of the dependencies this dependency requires
+	Dependencies []string `json:"dependencies,omitempty"`
+	// Scopes holds what the dependency is used for, see package scope. It is empty if the lock file doesn't tell.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
+type Inventory struct {
+	Dependencies  []Dependency `json:"dependencies"`
+	ParsedFiles   []string     `json:"parsedFiles"`
+	UnparsedFiles []string     `json:"unparsedFiles"`
+}
+
+func (inv *Inventory) Len() int {
+	return len(inv.Dependencies)
+}
+
+// DirectCount returns the number of dependencies declared directly in a manifest
+func (inv *Inventory) DirectCount() int {
+	count := 0
+	for _, dependency := range inv.Dependencies {
+		if dependency.Direct {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Find returns the first dependency named name in ecosystem. The ecosystem is ignored if it is empty.
+func (inv *Inventory) Find(ecosystem string, name string) (Dependency, bool) {
+	for _, dependency := range inv.Dependencies {
+		if ecosystem != "" && dependency.Ecosystem != ecosystem {
+			continue
+		}
+		if NormalizeName(dependency.Ecosystem, dependency.Name) == NormalizeName(dependency.Ecosystem, name) {
+			return dependency, true
+		}
+	}
+
+	return Dependency{}, false
+}
+
+// Filter removes the dependencies outside the scopes of filter, along with the edges to them
+func (inv *Inventory) Filter(filter scope.Filter) {
+	if len(filter) == 0 {
+		return
+	}
+	kept := map[string]bool{}
+	dependencies := make([]Dependency, 0, len(inv.Dependencies))
+	for _, dependency := range inv.Dependencies {
+		if filter.Keeps(dependency.Scopes...) {
+			dependencies = append(dependencies, dependency)
+			kept[dependency.Purl] = true
+		}
+	}
+	for i := range dependencies {
+		var children []string
+		for _, child := range dependencies[i].Dependencies {
+			if kept[child] {
+				children = append(children, child)
+			}
+		}
+		dependencies[i].Dependencies = children
+	}
+	inv.Dependencies = dependencies
+}
+
+func (inv *Inventory) ToFile(outputFile string) error {
+	dir := filepath.Dir(outputFile)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to ensure directory exists: %w", err)
+	}
+
+	content, err := json.MarshalIndent(inv, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(outputFile, content, 0600)
+}
+
+type IGenerator interface {
+	Generate(groups file.Groups) (*Inventory, error)
+}
+
+type Generator struct{}
+
+func NewGenerator() Generator {
+	return Generator{}
+}
+
+// Generate parses every lock file in groups into a normalized dependency inventory. Lock files that can't be parsed,
+// such as corrupt ones, are added to the unparsed files rather than failing the whole inventory.
+func (g Generator) Generate(groups file.Groups) (*Inventory, error) {
+	inv := &Inventory{
+		Dependencies:  []Dependency{},
+		ParsedFiles:   []string{},
+		UnparsedFiles: []string{},
+	}
+
+	for _, group := range groups.ToSlice() {
+		if !group.HasLockFiles() && group.HasFile() {
+			inv.UnparsedFiles = append(inv.UnparsedFiles, group.ManifestFile)
+		}
+		for _, lockFile := range group.LockFiles {
+			if !IsLockFile(lockFile) {
+				inv.UnparsedFiles = append(inv.UnparsedFiles, lockFile)
+
+				continue
+			}
+
+			dependencies, err := ParseLockFile(lockFile, group.ManifestFile)
+			if err != nil {
+				inv.UnparsedFiles = append(inv.UnparsedFiles, lockFile)
+
+				continue
+			}
+			inv.Dependencies = append(inv.Dependencies, dependencies...)
+			inv.ParsedFiles = append(inv.ParsedFiles, lockFile)
+		}
+	}
+
+	sort.SliceStable(inv.Dependencies, func(i, j int) bool {
+		a, b := inv.Dependencies[i], inv.Dependencies[j]
+		if a.SourceFile != b.SourceFile {
+			return a.SourceFile < b.SourceFile
+		}
+
+		return a.Purl < b.Purl
+	})
+
+	return inv, nil
+}
+
+// IsLockFile reports whether the dependencies of lockFile can be parsed offline
+func IsLockFile(lockFile string) bool {
+	return parserFor(lockFile) != nil
+}
+
+// ParseLockFile parses lockFile, using manifestFile to tell direct dependencies apart if the lock file does not
+func ParseLockFile(lockFile string, manifestFile string) ([]Dependency, error) {
+	parser := parserFor(lockFile)
+	if parser == nil {
+		return nil, fmt.Errorf("failed to parse %s: unsupported lock file", lockFile)
+	}
+	dependencies, err := parser.Parse(lockFile, manifestFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", lockFile, err)
+	}
+	for i := range dependencies {
+		dependencies[i].SourceFile = lockFile
+		if dependencies[i].Purl == "" {
+			dependencies[i].Purl = NewPurl(dependencies[i].Ecosystem, dependencies[i].Name, dependencies[i].Version)
+		}
+	}
+
+	return dependencies, nil
+}
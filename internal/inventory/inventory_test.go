@@ -0,0 +1,122 @@
+package inventory
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/scope"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerate(t *testing.T) {
+	var groups file.Groups
+	groups.Add(*file.NewGroup(
+		filepath.Join("testdata", "npm", "package.json"),
+		nil,
+		[]string{filepath.Join("testdata", "npm", "package-lock.json")},
+	))
+	groups.Add(*file.NewGroup("", nil, []string{filepath.Join("testdata", "cargo", "Cargo.lock")}))
+	groups.Add(*file.NewGroup("", nil, []string{filepath.Join("testdata", "misc", "unknown.lock")}))
+	groups.Add(*file.NewGroup(filepath.Join("testdata", "poetry", "pyproject.toml"), nil, []string{}))
+
+	inv, err := NewGenerator().Generate(groups)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 8, inv.Len())
+	assert.Equal(t, 5, inv.DirectCount())
+	assert.Len(t, inv.ParsedFiles, 2)
+	assert.Equal(t, []string{
+		filepath.Join("testdata", "misc", "unknown.lock"),
+		filepath.Join("testdata", "poetry", "pyproject.toml"),
+	}, inv.UnparsedFiles)
+	first := inv.Dependencies[0]
+	assert.Equal(t, filepath.Join("testdata", "cargo", "Cargo.lock"), first.SourceFile)
+	assert.Equal(t, "pkg:cargo/itoa@1.0.9", first.Purl)
+	assert.Equal(t, EcosystemCargo, first.Ecosystem)
+}
+
+func TestGenerateParseError(t *testing.T) {
+	corrupt := filepath.Join(t.TempDir(), "package-lock.json")
+	assert.NoError(t, os.WriteFile(corrupt, []byte(`{"lockfileVersion": 3, "packages": `), 0600))
+	var groups file.Groups
+	groups.Add(*file.NewGroup("", nil, []string{corrupt}))
+	groups.Add(*file.NewGroup("", nil, []string{filepath.Join("testdata", "cargo", "Cargo.lock")}))
+
+	inv, err := NewGenerator().Generate(groups)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join("testdata", "cargo", "Cargo.lock")}, inv.ParsedFiles)
+	assert.Equal(t, []string{corrupt}, inv.UnparsedFiles)
+	assert.NotEmpty(t, inv.Dependencies)
+}
+
+func TestFind(t *testing.T) {
+	inv := Inventory{Dependencies: []Dependency{
+		{Name: "lodash", Version: "4.17.21", Ecosystem: EcosystemNpm},
+		{Name: "Django_Rest", Version: "3.14.0", Ecosystem: EcosystemPypi},
+	}}
+
+	dependency, found := inv.Find(EcosystemPypi, "django-rest")
+	assert.True(t, found)
+	assert.Equal(t, "3.14.0", dependency.Version)
+	dependency, found = inv.Find("", "Lodash")
+	assert.True(t, found)
+	assert.Equal(t, "4.17.21", dependency.Version)
+	_, found = inv.Find(EcosystemCargo, "lodash")
+	assert.False(t, found)
+}
+
+func TestFilter(t *testing.T) {
+	inv := Inventory{Dependencies: []Dependency{
+		{Purl: "pkg:npm/lodash@4.17.21", Scopes: []string{scope.Prod}},
+		{Purl: "pkg:npm/jest@29.7.0", Scopes: []string{scope.Dev}, Dependencies: []string{"pkg:npm/chalk@4.1.2"}},
+		{Purl: "pkg:npm/chalk@4.1.2", Scopes: []string{scope.Prod, scope.Dev}},
+		{Purl: "pkg:cargo/itoa@1.0.9", Dependencies: []string{"pkg:npm/jest@29.7.0"}},
+	}}
+
+	inv.Filter(scope.Filter{scope.Prod: true})
+
+	assert.Equal(t, []Dependency{
+		{Purl: "pkg:npm/lodash@4.17.21", Scopes: []string{scope.Prod}},
+		{Purl: "pkg:npm/chalk@4.1.2", Scopes: []string{scope.Prod, scope.Dev}},
+		{Purl: "pkg:cargo/itoa@1.0.9"},
+	}, inv.Dependencies)
+}
+
+func TestFilterKeepsEveryScope(t *testing.T) {
+	dependencies := []Dependency{{Purl: "pkg:npm/jest@29.7.0", Scopes: []string{scope.Dev}}}
+	inv := Inventory{Dependencies: dependencies}
+
+	inv.Filter(scope.Filter{})
+
+	assert.Equal(t, dependencies, inv.Dependencies)
+}
+
+func TestToFile(t *testing.T) {
+	inv := &Inventory{
+		Dependencies: []Dependency{{
+			Name:       "lodash",
+			Version:    "4.17.21",
+			Ecosystem:  EcosystemNpm,
+			Purl:       "pkg:npm/lodash@4.17.21",
+			Direct:     true,
+			SourceFile: "package-lock.json",
+		}},
+		ParsedFiles:   []string{"package-lock.json"},
+		UnparsedFiles: []string{},
+	}
+	output := filepath.Join(t.TempDir(), "nested", OutputFileNameInventory)
+
+	err := inv.ToFile(output)
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(output)
+	assert.NoError(t, err)
+	var written Inventory
+	assert.NoError(t, json.Unmarshal(content, &written))
+	assert.Equal(t, *inv, written)
+	assert.Contains(t, string(content), `"purl": "pkg:npm/lodash@4.17.21"`)
+}
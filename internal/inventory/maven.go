@@ -0,0 +1,116 @@
+package inventory
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/debricked/cli/internal/scope"
+)
+
+// MavenParser parses maven.debricked.lock files written in Trivial Graph Format by `mvn dependency:tree`
+type MavenParser struct{}
+
+type mavenNode struct {
+	name    string
+	version string
+}
+
+func (p MavenParser) Parse(lockFile string, _ string) ([]Dependency, error) {
+	f, err := os.Open(lockFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	nodes := map[string]mavenNode{}
+	var nodeIds []string
+	rootIds := map[string]bool{}
+	directIds := map[string]bool{}
+	edges := map[string][]string{}
+	// scopes holds the scopes of each node, which are the labels of the edges to it
+	scopes := map[string][]string{}
+	inEdges := false
+	startOfGraph := true
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if line == "#" {
+			inEdges = true
+			startOfGraph = true
+
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+		if inEdges && !strings.Contains(fields[1], ":") {
+			if len(fields) > 2 {
+				scopes[fields[1]] = addScopes(scopes[fields[1]], scope.Maven(fields[2]))
+			}
+			if rootIds[fields[0]] {
+				directIds[fields[1]] = true
+			} else {
+				edges[fields[0]] = append(edges[fields[0]], fields[1])
+			}
+
+			continue
+		}
+		inEdges = false
+		// Multi-module builds append one graph per module, the first node of each graph is the module itself
+		if startOfGraph {
+			rootIds[fields[0]] = true
+			startOfGraph = false
+		}
+		if node, ok := parseMavenCoordinates(fields[1]); ok {
+			nodes[fields[0]] = node
+			nodeIds = append(nodeIds, fields[0])
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	set := newDependencySet()
+	for _, id := range nodeIds {
+		if rootIds[id] {
+			continue
+		}
+		var children []string
+		for _, childId := range edges[id] {
+			if child, ok := nodes[childId]; ok {
+				children = append(children, NewPurl(EcosystemMaven, child.name, child.version))
+			}
+		}
+		set.add(Dependency{
+			Name:         nodes[id].name,
+			Version:      nodes[id].version,
+			Ecosystem:    EcosystemMaven,
+			Direct:       directIds[id],
+			Dependencies: children,
+			Scopes:       scopes[id],
+		})
+	}
+
+	return set.toSlice(), nil
+}
+
+// parseMavenCoordinates parses groupId:artifactId:type[:classifier]:version[:scope]
+func parseMavenCoordinates(coordinates string) (mavenNode, bool) {
+	parts := strings.Split(coordinates, ":")
+	var version string
+	switch len(parts) {
+	case 4, 5:
+		version = parts[3]
+	case 6:
+		version = parts[4]
+	default:
+		return mavenNode{}, false
+	}
+
+	return mavenNode{name: parts[0] + ":" + parts[1], version: version}, true
+}
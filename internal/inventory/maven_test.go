@@ -0,0 +1,59 @@
+package inventory
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/scope"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMavenParser(t *testing.T) {
+	dependencies, err := MavenParser{}.Parse(filepath.Join("testdata", "maven", "maven.debricked.lock"), "")
+
+	assert.NoError(t, err)
+	assertDependencies(t, map[string]bool{
+		"com.google.guava:guava@32.1.2-jre":                   true,
+		"com.google.guava:failureaccess@1.0.1":                false,
+		"junit:junit@4.13.2":                                  true,
+		"org.hamcrest:hamcrest-core@1.3":                      false,
+		"io.netty:netty-transport-native-epoll@4.1.100.Final": true,
+	}, dependencies)
+}
+
+func TestParseMavenCoordinates(t *testing.T) {
+	node, ok := parseMavenCoordinates("com.example:app:jar:1.0")
+	assert.True(t, ok)
+	assert.Equal(t, mavenNode{name: "com.example:app", version: "1.0"}, node)
+
+	node, ok = parseMavenCoordinates("io.netty:netty:jar:linux-x86_64:4.1.100.Final:compile")
+	assert.True(t, ok)
+	assert.Equal(t, "4.1.100.Final", node.version)
+
+	_, ok = parseMavenCoordinates("invalid")
+	assert.False(t, ok)
+}
+
+func TestMavenParserGraph(t *testing.T) {
+	dependencies, err := MavenParser{}.Parse(filepath.Join("testdata", "maven", "maven.debricked.lock"), "")
+
+	assert.NoError(t, err)
+	assertGraph(t, map[string][]string{
+		"com.google.guava:guava@32.1.2-jre": {"com.google.guava:failureaccess@1.0.1"},
+		"junit:junit@4.13.2":                {"org.hamcrest:hamcrest-core@1.3"},
+	}, dependencies)
+}
+
+func TestMavenParserScopes(t *testing.T) {
+	dependencies, err := MavenParser{}.Parse(filepath.Join("testdata", "maven", "maven.debricked.lock"), "")
+
+	assert.NoError(t, err)
+	assertScopes(t, map[string][]string{
+		"com.google.guava:guava@32.1.2-jre":                   {scope.Prod},
+		"com.google.guava:failureaccess@1.0.1":                {scope.Prod},
+		"junit:junit@4.13.2":                                  {scope.Test},
+		"org.hamcrest:hamcrest-core@1.3":                      {scope.Test},
+		"io.netty:netty-transport-native-epoll@4.1.100.Final": {scope.Prod},
+	}, dependencies)
+}
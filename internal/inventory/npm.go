@@ -0,0 +1,196 @@
+package inventory
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+
+	"github.com/debricked/cli/internal/scope"
+)
+
+const nodeModules = "node_modules/"
+
+type packageJson struct {
+	Dependencies         map[string]string `json:"dependencies"`
+	DevDependencies      map[string]string `json:"devDependencies"`
+	OptionalDependencies map[string]string `json:"optionalDependencies"`
+	PeerDependencies     map[string]string `json:"peerDependencies"`
+}
+
+func (p packageJson) names() map[string]bool {
+	names := map[string]bool{}
+	for _, deps := range []map[string]string{p.Dependencies, p.DevDependencies, p.OptionalDependencies, p.PeerDependencies} {
+		for name := range deps {
+			names[name] = true
+		}
+	}
+
+	return names
+}
+
+// scopedDependencies returns the declared dependencies, mapping names to version ranges, by scope
+func (p packageJson) scopedDependencies() map[string][]map[string]string {
+	return map[string][]map[string]string{
+		scope.Prod: {p.Dependencies, p.OptionalDependencies, p.PeerDependencies},
+		scope.Dev:  {p.DevDependencies},
+	}
+}
+
+// readPackageJson returns the package.json manifestFile, which is empty if it can't be read
+func readPackageJson(manifestFile string) packageJson {
+	var manifest packageJson
+	if manifestFile == "" {
+		return manifest
+	}
+	content, err := os.ReadFile(manifestFile)
+	if err != nil {
+		return manifest
+	}
+	if json.Unmarshal(content, &manifest) != nil {
+		return packageJson{}
+	}
+
+	return manifest
+}
+
+// readPackageJsonNames returns the names of all dependencies declared in a package.json
+func readPackageJsonNames(manifestFile string) map[string]bool {
+	return readPackageJson(manifestFile).names()
+}
+
+type npmLockPackage struct {
+	packageJson
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Link      bool   `json:"link"`
+	Integrity string `json:"integrity"`
+	// Dev is set for packages only needed by devDependencies, and DevOptional for packages needed by both
+	// devDependencies and optionalDependencies
+	Dev         bool `json:"dev"`
+	DevOptional bool `json:"devOptional"`
+}
+
+type npmLockDependency struct {
+	Version      string                       `json:"version"`
+	Dev          bool                         `json:"dev"`
+	Integrity    string                       `json:"integrity"`
+	Requires     map[string]string            `json:"requires"`
+	Dependencies map[string]npmLockDependency `json:"dependencies"`
+}
+
+type npmLockFile struct {
+	Packages     map[string]npmLockPackage    `json:"packages"`
+	Dependencies map[string]npmLockDependency `json:"dependencies"`
+}
+
+// NpmParser parses package-lock.json and npm-shrinkwrap.json files
+type NpmParser struct{}
+
+func (p NpmParser) Parse(lockFile string, manifestFile string) ([]Dependency, error) {
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		return nil, err
+	}
+	var lock npmLockFile
+	err = json.Unmarshal(content, &lock)
+	if err != nil {
+		return nil, err
+	}
+
+	set := newDependencySet()
+	if len(lock.Packages) > 0 {
+		p.parsePackages(lock.Packages, set)
+	} else {
+		scopes := []map[string]npmLockDependency{lock.Dependencies}
+		p.parseDependencies(lock.Dependencies, scopes, readPackageJsonNames(manifestFile), set)
+	}
+
+	return set.toSlice(), nil
+}
+
+// parsePackages handles lockfileVersion 2 and 3
+func (p NpmParser) parsePackages(packages map[string]npmLockPackage, set *dependencySet) {
+	directNames := packages[""].names()
+	for key, pkg := range packages {
+		if pkg.Link || pkg.Version == "" || !strings.Contains(key, nodeModules) {
+			continue
+		}
+		var children []string
+		for childName := range pkg.names() {
+			if childKey, ok := resolveNpmPackage(packages, key, childName); ok {
+				children = append(children, NewPurl(EcosystemNpm, npmPackageName(childKey, packages[childKey]), packages[childKey].Version))
+			}
+		}
+		name := npmPackageName(key, pkg)
+		set.add(Dependency{
+			Name:         name,
+			Version:      pkg.Version,
+			Ecosystem:    EcosystemNpm,
+			Direct:       key == nodeModules+name && directNames[name],
+			Hashes:       hashesFromIntegrity(pkg.Integrity),
+			Dependencies: children,
+			Scopes:       []string{scope.DevIf(pkg.Dev || pkg.DevOptional)},
+		})
+	}
+}
+
+func npmPackageName(key string, pkg npmLockPackage) string {
+	if pkg.Name != "" {
+		return pkg.Name
+	}
+
+	return key[strings.LastIndex(key, nodeModules)+len(nodeModules):]
+}
+
+// resolveNpmPackage finds the package key name resolves to from key, walking up the node_modules hierarchy like Node.js does
+func resolveNpmPackage(packages map[string]npmLockPackage, key string, name string) (string, bool) {
+	for {
+		candidate := nodeModules + name
+		if key != "" {
+			candidate = key + "/" + candidate
+		}
+		if pkg, ok := packages[candidate]; ok && !pkg.Link && pkg.Version != "" {
+			return candidate, true
+		}
+		if key == "" {
+			return "", false
+		}
+		i := strings.LastIndex(key, "/"+nodeModules)
+		if i < 0 {
+			key = ""
+		} else {
+			key = key[:i]
+		}
+	}
+}
+
+// parseDependencies handles lockfileVersion 1. scopes holds the nested dependency maps from the root down to dependencies.
+func (p NpmParser) parseDependencies(dependencies map[string]npmLockDependency, scopes []map[string]npmLockDependency, directNames map[string]bool, set *dependencySet) {
+	topLevel := len(scopes) == 1
+	for name, dependency := range dependencies {
+		if dependency.Version == "" || strings.HasPrefix(dependency.Version, "file:") {
+			continue
+		}
+		childScopes := append(scopes[:len(scopes):len(scopes)], dependency.Dependencies)
+		var children []string
+		for childName := range dependency.Requires {
+			for i := len(childScopes) - 1; i >= 0; i-- {
+				if child, ok := childScopes[i][childName]; ok {
+					children = append(children, NewPurl(EcosystemNpm, childName, child.Version))
+
+					break
+				}
+			}
+		}
+		set.add(Dependency{
+			Name:         name,
+			Version:      dependency.Version,
+			Ecosystem:    EcosystemNpm,
+			Direct:       topLevel && directNames[name],
+			Hashes:       hashesFromIntegrity(dependency.Integrity),
+			Dependencies: children,
+			Scopes:       []string{scope.DevIf(dependency.Dev)},
+		})
+		p.parseDependencies(dependency.Dependencies, childScopes, directNames, set)
+	}
+}
@@ -0,0 +1,136 @@
+package inventory
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/scope"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNpmParserLockfileV3(t *testing.T) {
+	dependencies, err := NpmParser{}.Parse(
+		filepath.Join("testdata", "npm", "package-lock.json"),
+		filepath.Join("testdata", "npm", "package.json"),
+	)
+
+	assert.NoError(t, err)
+	assertDependencies(t, map[string]bool{
+		"@babel/code-frame@7.22.13": true,
+		"chalk@2.4.2":               false,
+		"chalk@4.1.2":               false,
+		"jest@29.7.0":               true,
+		"lodash@4.17.21":            true,
+	}, dependencies)
+}
+
+func TestNpmParserLockfileV1(t *testing.T) {
+	dependencies, err := NpmParser{}.Parse(
+		filepath.Join("testdata", "npm-v1", "package-lock.json"),
+		filepath.Join("testdata", "npm-v1", "package.json"),
+	)
+
+	assert.NoError(t, err)
+	assertDependencies(t, map[string]bool{
+		"@babel/code-frame@7.22.13": true,
+		"chalk@2.4.2":               false,
+		"chalk@4.1.2":               false,
+		"jest@29.7.0":               true,
+		"lodash@4.17.21":            true,
+	}, dependencies)
+}
+
+func TestNpmParserLockfileV1WithoutManifest(t *testing.T) {
+	dependencies, err := NpmParser{}.Parse(filepath.Join("testdata", "npm-v1", "package-lock.json"), "")
+
+	assert.NoError(t, err)
+	assert.Len(t, dependencies, 5)
+	for _, dependency := range dependencies {
+		assert.False(t, dependency.Direct)
+	}
+}
+
+func TestNpmParserInvalidFile(t *testing.T) {
+	_, err := NpmParser{}.Parse(filepath.Join("testdata", "yarn", "yarn.lock"), "")
+	assert.Error(t, err)
+
+	_, err = NpmParser{}.Parse(filepath.Join("testdata", "missing", "package-lock.json"), "")
+	assert.Error(t, err)
+}
+
+func TestNpmParserLockfileV3Graph(t *testing.T) {
+	dependencies, err := NpmParser{}.Parse(filepath.Join("testdata", "npm", "package-lock.json"), "")
+
+	assert.NoError(t, err)
+	assertGraph(t, map[string][]string{
+		"@babel/code-frame@7.22.13": {"chalk@2.4.2"},
+		"jest@29.7.0":               {"chalk@4.1.2"},
+	}, dependencies)
+	lodash := findDependency(t, dependencies, "lodash", "4.17.21")
+	assert.Equal(t, []Hash{{
+		Algorithm: HashSHA512,
+		Value:     "bf690311ee7b95e713ba568322e3533f2dd1cb880b189e99d4edef13592b81764daec43e2c54c61d5c558dc5cfb35ecb85b65519e74026ff17675b6f8f916f4a",
+	}}, lodash.Hashes)
+}
+
+func TestNpmParserLockfileV1Graph(t *testing.T) {
+	dependencies, err := NpmParser{}.Parse(filepath.Join("testdata", "npm-v1", "package-lock.json"), "")
+
+	assert.NoError(t, err)
+	assertGraph(t, map[string][]string{
+		"@babel/code-frame@7.22.13": {"chalk@2.4.2"},
+		"jest@29.7.0":               {"chalk@4.1.2"},
+	}, dependencies)
+	assert.Len(t, findDependency(t, dependencies, "chalk", "2.4.2").Hashes, 1)
+	assert.Empty(t, findDependency(t, dependencies, "chalk", "4.1.2").Hashes)
+}
+
+func TestResolveNpmPackage(t *testing.T) {
+	packages := map[string]npmLockPackage{
+		"node_modules/a":                                 {Version: "1.0.0"},
+		"node_modules/b":                                 {Version: "1.0.0"},
+		"node_modules/a/node_modules/b":                  {Version: "2.0.0"},
+		"node_modules/a/node_modules/c":                  {Version: "1.0.0"},
+		"node_modules/a/node_modules/c/node_modules/d":   {Version: "1.0.0"},
+		"node_modules/linked":                            {Link: true},
+		"node_modules/@scope/e":                          {Version: "1.0.0"},
+		"node_modules/@scope/e/node_modules/@scope/f":    {Version: "1.0.0"},
+		"node_modules/@scope/e/node_modules/@scope/f/ok": {Version: "1.0.0"},
+	}
+	cases := []struct {
+		from     string
+		name     string
+		expected string
+	}{
+		{"", "a", "node_modules/a"},
+		{"node_modules/a", "b", "node_modules/a/node_modules/b"},
+		{"node_modules/a/node_modules/c", "b", "node_modules/a/node_modules/b"},
+		{"node_modules/a/node_modules/c/node_modules/d", "b", "node_modules/a/node_modules/b"},
+		{"node_modules/b", "a", "node_modules/a"},
+		{"node_modules/@scope/e/node_modules/@scope/f", "@scope/e", "node_modules/@scope/e"},
+		{"node_modules/a", "linked", ""},
+		{"node_modules/a", "missing", ""},
+	}
+	for _, c := range cases {
+		key, ok := resolveNpmPackage(packages, c.from, c.name)
+		assert.Equal(t, c.expected, key, c.from+" -> "+c.name)
+		assert.Equal(t, c.expected != "", ok)
+	}
+}
+
+func TestNpmParserScopes(t *testing.T) {
+	for _, dir := range []string{"npm", "npm-v1"} {
+		t.Run(dir, func(t *testing.T) {
+			dependencies, err := NpmParser{}.Parse(filepath.Join("testdata", dir, "package-lock.json"), "")
+
+			assert.NoError(t, err)
+			assertScopes(t, map[string][]string{
+				"@babel/code-frame@7.22.13": {scope.Prod},
+				"chalk@2.4.2":               {scope.Prod},
+				"chalk@4.1.2":               {scope.Dev},
+				"jest@29.7.0":               {scope.Dev},
+				"lodash@4.17.21":            {scope.Prod},
+			}, dependencies)
+		})
+	}
+}
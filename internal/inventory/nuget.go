@@ -0,0 +1,67 @@
+package inventory
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+)
+
+type nugetLockPackage struct {
+	Type         string            `json:"type"`
+	Resolved     string            `json:"resolved"`
+	ContentHash  string            `json:"contentHash"`
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+type nugetLockFile struct {
+	Dependencies map[string]map[string]nugetLockPackage `json:"dependencies"`
+}
+
+// NugetParser parses packages.lock.json files, including those written for packages.config by the nuget resolver
+type NugetParser struct{}
+
+func (p NugetParser) Parse(lockFile string, _ string) ([]Dependency, error) {
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		return nil, err
+	}
+	var lock nugetLockFile
+	err = json.Unmarshal(content, &lock)
+	if err != nil {
+		return nil, err
+	}
+
+	set := newDependencySet()
+	for _, framework := range lock.Dependencies {
+		// Package ids are case insensitive, so dependencies may be cased differently than the package itself
+		purls := map[string]string{}
+		for name, pkg := range framework {
+			purls[strings.ToLower(name)] = NewPurl(EcosystemNuget, name, pkg.Resolved)
+		}
+		for name, pkg := range framework {
+			if pkg.Type == "Project" || pkg.Resolved == "" {
+				continue
+			}
+			var children []string
+			for childName := range pkg.Dependencies {
+				if purl, ok := purls[strings.ToLower(childName)]; ok {
+					children = append(children, purl)
+				}
+			}
+			var hashes []Hash
+			if hash, ok := newBase64Hash("sha512", pkg.ContentHash); ok {
+				hashes = append(hashes, hash)
+			}
+			set.add(Dependency{
+				Name:         name,
+				Version:      pkg.Resolved,
+				Ecosystem:    EcosystemNuget,
+				Direct:       pkg.Type == "Direct",
+				Hashes:       hashes,
+				Dependencies: children,
+			})
+		}
+	}
+
+	return set.toSlice(), nil
+}
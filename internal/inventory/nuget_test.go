@@ -0,0 +1,31 @@
+package inventory
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNugetParser(t *testing.T) {
+	dependencies, err := NugetParser{}.Parse(filepath.Join("testdata", "nuget", "packages.lock.json"), "")
+
+	assert.NoError(t, err)
+	assertDependencies(t, map[string]bool{
+		"Newtonsoft.Json@13.0.3": true,
+		"Serilog@3.0.1":          false,
+	}, dependencies)
+}
+
+func TestNugetParserGraphAndHashes(t *testing.T) {
+	dependencies, err := NugetParser{}.Parse(filepath.Join("testdata", "nuget", "packages.lock.json"), "")
+
+	assert.NoError(t, err)
+	assertGraph(t, map[string][]string{
+		"Newtonsoft.Json@13.0.3": {"Serilog@3.0.1"},
+	}, dependencies)
+	hashes := findDependency(t, dependencies, "Newtonsoft.Json", "13.0.3").Hashes
+	assert.Len(t, hashes, 1)
+	assert.Equal(t, HashSHA512, hashes[0].Algorithm)
+	assert.Len(t, hashes[0].Value, 128)
+}
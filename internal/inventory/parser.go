@@ -0,0 +1,164 @@
+package inventory
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+type ILockFileParser interface {
+	// Parse reads lockFile and returns its dependencies. manifestFile is empty if the lock file lacks a manifest.
+	Parse(lockFile string, manifestFile string) ([]Dependency, error)
+}
+
+func parserFor(lockFile string) ILockFileParser {
+	base := filepath.Base(lockFile)
+	switch {
+	case base == "package-lock.json" || base == "npm-shrinkwrap.json":
+		return NpmParser{}
+	case base == "yarn.lock":
+		return YarnParser{}
+	case base == "pnpm-lock.yaml":
+		return PnpmParser{}
+	case base == "composer.lock":
+		return ComposerParser{}
+	case strings.HasSuffix(base, "gomod.debricked.lock"):
+		return GoModParser{}
+	case strings.HasSuffix(base, "maven.debricked.lock"):
+		return MavenParser{}
+	case strings.HasSuffix(base, "gradle.debricked.lock"):
+		return GradleParser{}
+	case strings.HasSuffix(base, ".pip.debricked.lock"):
+		return PipParser{}
+	case base == "poetry.lock":
+		return PoetryParser{}
+	case base == "uv.lock":
+		return UvParser{}
+	case base == "Cargo.lock":
+		return CargoParser{}
+	case base == "pubspec.lock":
+		return PubParser{}
+	case base == "packages.lock.json" || strings.HasSuffix(base, ".nuget.debricked.lock"):
+		return NugetParser{}
+	}
+
+	return nil
+}
+
+// dependencySet deduplicates dependencies on purl, i.e. on ecosystem, name and version
+type dependencySet struct {
+	index        map[string]int
+	dependencies []Dependency
+}
+
+func newDependencySet() *dependencySet {
+	return &dependencySet{index: map[string]int{}, dependencies: []Dependency{}}
+}
+
+// add adds dependency to the set and returns its purl. Duplicates are merged into the existing entry.
+func (set *dependencySet) add(dependency Dependency) string {
+	if dependency.Purl == "" {
+		dependency.Purl = NewPurl(dependency.Ecosystem, dependency.Name, dependency.Version)
+	}
+	i, ok := set.index[dependency.Purl]
+	if !ok {
+		set.index[dependency.Purl] = len(set.dependencies)
+		children := dependency.Dependencies
+		dependency.Dependencies = nil
+		set.dependencies = append(set.dependencies, dependency)
+		set.addDependencies(dependency.Purl, children...)
+
+		return dependency.Purl
+	}
+	existing := &set.dependencies[i]
+	existing.Direct = existing.Direct || dependency.Direct
+	existing.Scopes = addScopes(existing.Scopes, dependency.Scopes...)
+	if len(existing.Hashes) == 0 {
+		existing.Hashes = dependency.Hashes
+	}
+	set.addDependencies(dependency.Purl, dependency.Dependencies...)
+
+	return dependency.Purl
+}
+
+// addDependencies adds edges from the dependency identified by purl to children
+func (set *dependencySet) addDependencies(purl string, children ...string) {
+	i, ok := set.index[purl]
+	if !ok {
+		return
+	}
+	existing := &set.dependencies[i]
+	for _, child := range children {
+		if child == "" || child == purl || contains(existing.Dependencies, child) {
+			continue
+		}
+		existing.Dependencies = append(existing.Dependencies, child)
+	}
+}
+
+// propagateScopes adds the scopes of the dependencies identified by the purls of roots to them and to every dependency
+// they transitively depend on, for lock files only telling the scopes of direct dependencies
+func (set *dependencySet) propagateScopes(roots map[string][]string) {
+	for purl, scopes := range roots {
+		if len(scopes) == 0 {
+			continue
+		}
+		queue := []string{purl}
+		for len(queue) > 0 {
+			i, ok := set.index[queue[0]]
+			queue = queue[1:]
+			if !ok {
+				continue
+			}
+			dependency := &set.dependencies[i]
+			before := len(dependency.Scopes)
+			dependency.Scopes = addScopes(dependency.Scopes, scopes...)
+			if before == len(dependency.Scopes) && before > 0 {
+				// Already visited with these scopes, so its dependencies have them already as well
+				continue
+			}
+			queue = append(queue, dependency.Dependencies...)
+		}
+	}
+}
+
+// toSlice returns the dependencies with edges to dependencies outside the set removed
+func (set *dependencySet) toSlice() []Dependency {
+	for i := range set.dependencies {
+		children := set.dependencies[i].Dependencies[:0]
+		for _, child := range set.dependencies[i].Dependencies {
+			if _, ok := set.index[child]; ok {
+				children = append(children, child)
+			}
+		}
+		if len(children) == 0 {
+			children = nil
+		}
+		sort.Strings(children)
+		set.dependencies[i].Dependencies = children
+		sort.Strings(set.dependencies[i].Scopes)
+	}
+
+	return set.dependencies
+}
+
+// addScopes adds the scopes not in existing to it
+func addScopes(existing []string, scopes ...string) []string {
+	for _, s := range scopes {
+		if s != "" && !contains(existing, s) {
+			existing = append(existing, s)
+		}
+	}
+
+	return existing
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,123 @@
+package inventory
+
+import (
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParserFor(t *testing.T) {
+	cases := map[string]ILockFileParser{
+		"package-lock.json":                    NpmParser{},
+		"npm-shrinkwrap.json":                  NpmParser{},
+		"yarn.lock":                            YarnParser{},
+		"pnpm-lock.yaml":                       PnpmParser{},
+		"composer.lock":                        ComposerParser{},
+		"gomod.debricked.lock":                 GoModParser{},
+		"maven.debricked.lock":                 MavenParser{},
+		"gradle.debricked.lock":                GradleParser{},
+		"requirements.txt.pip.debricked.lock":  PipParser{},
+		"poetry.lock":                          PoetryParser{},
+		"uv.lock":                              UvParser{},
+		"Cargo.lock":                           CargoParser{},
+		"pubspec.lock":                         PubParser{},
+		"packages.lock.json":                   NugetParser{},
+		"packages.config.nuget.debricked.lock": NugetParser{},
+	}
+	for name, expected := range cases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, expected, parserFor(filepath.Join("dir", name)))
+		})
+	}
+
+	assert.Nil(t, parserFor("go.mod"))
+	assert.Nil(t, parserFor(".debricked.fingerprints.txt"))
+}
+
+func TestDependencySetDeduplicates(t *testing.T) {
+	set := newDependencySet()
+	set.add(Dependency{Name: "a", Version: "1.0.0", Ecosystem: EcosystemNpm})
+	set.add(Dependency{Name: "a", Version: "1.0.0", Ecosystem: EcosystemNpm, Direct: true})
+	set.add(Dependency{Name: "a", Version: "2.0.0", Ecosystem: EcosystemNpm})
+
+	dependencies := set.toSlice()
+	assert.Len(t, dependencies, 2)
+	assert.True(t, dependencies[0].Direct)
+	assert.False(t, dependencies[1].Direct)
+}
+
+// assertDependencies asserts that dependencies consist of expected, given as name@version mapped to direct
+func assertDependencies(t *testing.T, expected map[string]bool, dependencies []Dependency) {
+	t.Helper()
+	actual := map[string]bool{}
+	for _, dependency := range dependencies {
+		actual[dependency.Name+"@"+dependency.Version] = dependency.Direct
+	}
+	assert.Equal(t, expected, actual)
+}
+
+// assertGraph asserts the edges of dependencies, given as name@version mapped to the name@version of its dependencies
+func assertGraph(t *testing.T, expected map[string][]string, dependencies []Dependency) {
+	t.Helper()
+	ids := map[string]string{}
+	for _, dependency := range dependencies {
+		ids[dependency.Purl] = dependency.Name + "@" + dependency.Version
+	}
+	actual := map[string][]string{}
+	for _, dependency := range dependencies {
+		for _, child := range dependency.Dependencies {
+			id := dependency.Name + "@" + dependency.Version
+			actual[id] = append(actual[id], ids[child])
+		}
+	}
+	for id := range actual {
+		sort.Strings(actual[id])
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func findDependency(t *testing.T, dependencies []Dependency, name string, version string) Dependency {
+	t.Helper()
+	for _, dependency := range dependencies {
+		if dependency.Name == name && dependency.Version == version {
+			return dependency
+		}
+	}
+	t.Fatalf("dependency %s@%s not found", name, version)
+
+	return Dependency{}
+}
+
+func TestDependencySetMergesEdgesAndHashes(t *testing.T) {
+	set := newDependencySet()
+	a := set.add(Dependency{Name: "a", Version: "1.0.0", Ecosystem: EcosystemNpm, Dependencies: []string{"pkg:npm/b@1.0.0"}})
+	set.add(Dependency{Name: "b", Version: "1.0.0", Ecosystem: EcosystemNpm})
+	set.add(Dependency{
+		Name:         "a",
+		Version:      "1.0.0",
+		Ecosystem:    EcosystemNpm,
+		Hashes:       []Hash{{Algorithm: HashSHA1, Value: "da39a3ee5e6b4b0d3255bfef95601890afd80709"}},
+		Dependencies: []string{"pkg:npm/b@1.0.0", "pkg:npm/missing@1.0.0", a},
+	})
+
+	dependencies := set.toSlice()
+	assert.Equal(t, "pkg:npm/a@1.0.0", a)
+	assert.Len(t, dependencies, 2)
+	assert.Equal(t, []string{"pkg:npm/b@1.0.0"}, dependencies[0].Dependencies)
+	assert.Len(t, dependencies[0].Hashes, 1)
+	assert.Nil(t, dependencies[1].Dependencies)
+}
+
+// assertScopes asserts the scopes of dependencies, given as name@version mapped to its scopes
+func assertScopes(t *testing.T, expected map[string][]string, dependencies []Dependency) {
+	t.Helper()
+	actual := map[string][]string{}
+	for _, dependency := range dependencies {
+		if len(dependency.Scopes) > 0 {
+			actual[dependency.Name+"@"+dependency.Version] = dependency.Scopes
+		}
+	}
+	assert.Equal(t, expected, actual)
+}
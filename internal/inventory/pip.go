@@ -0,0 +1,97 @@
+package inventory
+
+import (
+	"os"
+	"regexp"
+	"strings"
+)
+
+const pipLockFileDelimiter = "***"
+
+var pipRequirementNameRegex = regexp.MustCompile(`^\s*([A-Za-z0-9][A-Za-z0-9._-]*)`)
+
+// PipParser parses .pip.debricked.lock files, i.e. the requirements file, `pip list` and `pip show` output separated by ***
+type PipParser struct{}
+
+func (p PipParser) Parse(lockFile string, _ string) ([]Dependency, error) {
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		return nil, err
+	}
+	sections := strings.Split(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n"+pipLockFileDelimiter+"\n")
+	if len(sections) < 2 {
+		return []Dependency{}, nil
+	}
+
+	directNames := map[string]bool{}
+	for _, line := range strings.Split(sections[0], "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "-") {
+			continue
+		}
+		if match := pipRequirementNameRegex.FindStringSubmatch(line); match != nil {
+			directNames[NormalizePythonName(match[1])] = true
+		}
+	}
+
+	installed := map[string]string{}
+	var names []string
+	for _, line := range strings.Split(sections[1], "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] == "Package" || strings.HasPrefix(fields[0], "---") {
+			continue
+		}
+		names = append(names, fields[0])
+		installed[NormalizePythonName(fields[0])] = fields[1]
+	}
+
+	requires := map[string][]string{}
+	if len(sections) > 2 {
+		requires = p.parseRequires(sections[2])
+	}
+
+	set := newDependencySet()
+	for _, name := range names {
+		normalized := NormalizePythonName(name)
+		var children []string
+		for _, child := range requires[normalized] {
+			if version, ok := installed[NormalizePythonName(child)]; ok {
+				children = append(children, NewPurl(EcosystemPypi, child, version))
+			}
+		}
+		set.add(Dependency{
+			Name:         name,
+			Version:      installed[normalized],
+			Ecosystem:    EcosystemPypi,
+			Direct:       directNames[normalized],
+			Dependencies: children,
+		})
+	}
+
+	return set.toSlice(), nil
+}
+
+// parseRequires maps normalized package names to the Requires field of the `pip show` output
+func (p PipParser) parseRequires(section string) map[string][]string {
+	requires := map[string][]string{}
+	name := ""
+	for _, line := range strings.Split(section, "\n") {
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case "Name":
+			name = NormalizePythonName(value)
+		case "Requires":
+			for _, requirement := range strings.Split(value, ",") {
+				if requirement = strings.TrimSpace(requirement); requirement != "" && name != "" {
+					requires[name] = append(requires[name], requirement)
+				}
+			}
+		}
+	}
+
+	return requires
+}
@@ -0,0 +1,38 @@
+package inventory
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPipParser(t *testing.T) {
+	dependencies, err := PipParser{}.Parse(filepath.Join("testdata", "pip", "requirements.txt.pip.debricked.lock"), "")
+
+	assert.NoError(t, err)
+	assertDependencies(t, map[string]bool{
+		"certifi@2023.7.22": false,
+		"click@8.1.7":       false,
+		"Flask@2.1.2":       true,
+		"requests@2.31.0":   true,
+		"Werkzeug@2.2.3":    false,
+	}, dependencies)
+}
+
+func TestPipParserWithoutDelimiters(t *testing.T) {
+	dependencies, err := PipParser{}.Parse(filepath.Join("testdata", "gomod", "gomod.debricked.lock"), "")
+
+	assert.NoError(t, err)
+	assert.Empty(t, dependencies)
+}
+
+func TestPipParserGraph(t *testing.T) {
+	dependencies, err := PipParser{}.Parse(filepath.Join("testdata", "pip", "requirements.txt.pip.debricked.lock"), "")
+
+	assert.NoError(t, err)
+	assertGraph(t, map[string][]string{
+		"Flask@2.1.2":     {"Werkzeug@2.2.3", "click@8.1.7"},
+		"requests@2.31.0": {"certifi@2023.7.22"},
+	}, dependencies)
+}
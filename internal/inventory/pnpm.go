@@ -0,0 +1,161 @@
+package inventory
+
+import (
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/debricked/cli/internal/scope"
+	"gopkg.in/yaml.v3"
+)
+
+type pnpmImporter struct {
+	Dependencies         map[string]interface{} `yaml:"dependencies"`
+	DevDependencies      map[string]interface{} `yaml:"devDependencies"`
+	OptionalDependencies map[string]interface{} `yaml:"optionalDependencies"`
+}
+
+type pnpmPackage struct {
+	Resolution struct {
+		Integrity string `yaml:"integrity"`
+	} `yaml:"resolution"`
+	Dependencies         map[string]string `yaml:"dependencies"`
+	OptionalDependencies map[string]string `yaml:"optionalDependencies"`
+}
+
+type pnpmLockFile struct {
+	pnpmImporter `yaml:",inline"`
+	Importers    map[string]pnpmImporter `yaml:"importers"`
+	Packages     map[string]pnpmPackage  `yaml:"packages"`
+	// Snapshots holds the dependencies of each package as of lockfile version 9
+	Snapshots map[string]pnpmPackage `yaml:"snapshots"`
+}
+
+// PnpmParser parses pnpm-lock.yaml files, lockfile versions 5 through 9
+type PnpmParser struct{}
+
+func (p PnpmParser) Parse(lockFile string, _ string) ([]Dependency, error) {
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		return nil, err
+	}
+	var lock pnpmLockFile
+	err = yaml.Unmarshal(content, &lock)
+	if err != nil {
+		return nil, err
+	}
+
+	directNames := map[string]bool{}
+	importers := []pnpmImporter{lock.pnpmImporter}
+	for _, importer := range lock.Importers {
+		importers = append(importers, importer)
+	}
+	for _, importer := range importers {
+		for _, deps := range []map[string]interface{}{importer.Dependencies, importer.DevDependencies, importer.OptionalDependencies} {
+			for name := range deps {
+				directNames[name] = true
+			}
+		}
+	}
+
+	set := newDependencySet()
+	for _, packages := range []map[string]pnpmPackage{lock.Packages, lock.Snapshots} {
+		for key, pkg := range packages {
+			name, version := pnpmPackageKey(key)
+			if name == "" || version == "" {
+				continue
+			}
+			var children []string
+			for _, deps := range []map[string]string{pkg.Dependencies, pkg.OptionalDependencies} {
+				for childName, reference := range deps {
+					if childName, childVersion := pnpmDependencyReference(childName, reference); childVersion != "" {
+						children = append(children, NewPurl(EcosystemNpm, childName, childVersion))
+					}
+				}
+			}
+			set.add(Dependency{
+				Name:         name,
+				Version:      version,
+				Ecosystem:    EcosystemNpm,
+				Direct:       directNames[name],
+				Hashes:       hashesFromIntegrity(pkg.Resolution.Integrity),
+				Dependencies: children,
+			})
+		}
+	}
+
+	roots := map[string][]string{}
+	for _, importer := range importers {
+		for s, dependencies := range importer.scopedDependencies() {
+			for _, deps := range dependencies {
+				for name, reference := range deps {
+					if name, version := pnpmDependencyReference(name, pnpmImporterVersion(reference)); version != "" {
+						purl := NewPurl(EcosystemNpm, name, version)
+						roots[purl] = addScopes(roots[purl], s)
+					}
+				}
+			}
+		}
+	}
+	set.propagateScopes(roots)
+
+	return set.toSlice(), nil
+}
+
+// scopedDependencies returns the dependencies of the importer, mapping names to references, by scope
+func (importer pnpmImporter) scopedDependencies() map[string][]map[string]interface{} {
+	return map[string][]map[string]interface{}{
+		scope.Prod: {importer.Dependencies, importer.OptionalDependencies},
+		scope.Dev:  {importer.DevDependencies},
+	}
+}
+
+// pnpmImporterVersion returns the version of importer dependencies, which are references as of lockfile version 6
+// and plain versions before
+func pnpmImporterVersion(reference interface{}) string {
+	switch value := reference.(type) {
+	case string:
+		return value
+	case map[string]interface{}:
+		version, _ := value["version"].(string)
+
+		return version
+	}
+
+	return ""
+}
+
+// pnpmDependencyReference resolves dependency references such as 1.0.0, 1.0.0_peer@2.0.0, 1.0.0(peer@2.0.0) and aliases like /b/1.0.0 or b@1.0.0
+func pnpmDependencyReference(name string, reference string) (string, string) {
+	if reference == "" || strings.HasPrefix(reference, "link:") || strings.HasPrefix(reference, "file:") {
+		return "", ""
+	}
+	if unicode.IsDigit(rune(reference[0])) {
+		if i := strings.Index(reference, "("); i >= 0 {
+			reference = reference[:i]
+		}
+		version, _, _ := strings.Cut(reference, "_")
+
+		return name, version
+	}
+
+	return pnpmPackageKey(reference)
+}
+
+// pnpmPackageKey splits keys like /a/1.0.0 (v5), /@s/a@1.0.0(peer@2.0.0) (v6) and a@1.0.0 (v9)
+func pnpmPackageKey(key string) (string, string) {
+	key = strings.TrimPrefix(key, "/")
+	if i := strings.Index(key, "("); i >= 0 {
+		key = key[:i]
+	}
+	if slash := strings.LastIndex(key, "/"); slash > 0 && slash+1 < len(key) && unicode.IsDigit(rune(key[slash+1])) {
+		version, _, _ := strings.Cut(key[slash+1:], "_")
+
+		return key[:slash], version
+	}
+	if at := strings.LastIndex(key, "@"); at > 0 {
+		return key[:at], key[at+1:]
+	}
+
+	return "", ""
+}
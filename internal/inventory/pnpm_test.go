@@ -0,0 +1,85 @@
+package inventory
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/scope"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPnpmParser(t *testing.T) {
+	dependencies, err := PnpmParser{}.Parse(filepath.Join("testdata", "pnpm", "pnpm-lock.yaml"), "")
+
+	assert.NoError(t, err)
+	assertDependencies(t, map[string]bool{
+		"@babel/code-frame@7.22.13": true,
+		"chalk@2.4.2":               false,
+		"jest@29.7.0":               true,
+		"lodash@4.17.21":            true,
+	}, dependencies)
+}
+
+func TestPnpmPackageKey(t *testing.T) {
+	cases := map[string][2]string{
+		"/lodash/4.17.21":                    {"lodash", "4.17.21"},
+		"/@babel/code-frame/7.22.13":         {"@babel/code-frame", "7.22.13"},
+		"/react-dom/18.2.0_react@18.2.0":     {"react-dom", "18.2.0"},
+		"/@babel/code-frame@7.22.13":         {"@babel/code-frame", "7.22.13"},
+		"/jest@29.7.0(@types/node@20.8.0)":   {"jest", "29.7.0"},
+		"lodash@4.17.21":                     {"lodash", "4.17.21"},
+		"@babel/code-frame@7.22.13(a@1.0.0)": {"@babel/code-frame", "7.22.13"},
+		"invalid":                            {"", ""},
+	}
+	for key, expected := range cases {
+		name, version := pnpmPackageKey(key)
+		assert.Equal(t, expected, [2]string{name, version}, key)
+	}
+}
+
+func TestPnpmParserGraph(t *testing.T) {
+	dependencies, err := PnpmParser{}.Parse(filepath.Join("testdata", "pnpm", "pnpm-lock.yaml"), "")
+
+	assert.NoError(t, err)
+	assertGraph(t, map[string][]string{
+		"@babel/code-frame@7.22.13": {"chalk@2.4.2"},
+	}, dependencies)
+	for _, dependency := range dependencies {
+		assert.Len(t, dependency.Hashes, 1, dependency.Name)
+	}
+}
+
+func TestPnpmDependencyReference(t *testing.T) {
+	cases := map[string][2]string{
+		"1.0.0":                 {"a", "1.0.0"},
+		"1.0.0_react@18.2.0":    {"a", "1.0.0"},
+		"1.0.0(react@18.2.0)":   {"a", "1.0.0"},
+		"/b/2.0.0":              {"b", "2.0.0"},
+		"b@2.0.0(react@18.2.0)": {"b", "2.0.0"},
+		"link:../b":             {"", ""},
+		"file:b.tgz":            {"", ""},
+		"":                      {"", ""},
+	}
+	for reference, expected := range cases {
+		name, version := pnpmDependencyReference("a", reference)
+		assert.Equal(t, expected, [2]string{name, version}, reference)
+	}
+}
+
+func TestPnpmParserScopes(t *testing.T) {
+	dependencies, err := PnpmParser{}.Parse(filepath.Join("testdata", "pnpm", "pnpm-lock.yaml"), "")
+
+	assert.NoError(t, err)
+	assertScopes(t, map[string][]string{
+		"@babel/code-frame@7.22.13": {scope.Prod},
+		"chalk@2.4.2":               {scope.Prod},
+		"jest@29.7.0":               {scope.Dev},
+		"lodash@4.17.21":            {scope.Prod},
+	}, dependencies)
+}
+
+func TestPnpmImporterVersion(t *testing.T) {
+	assert.Equal(t, "4.17.21", pnpmImporterVersion("4.17.21"))
+	assert.Equal(t, "4.17.21", pnpmImporterVersion(map[string]interface{}{"specifier": "^4.17.21", "version": "4.17.21"}))
+	assert.Empty(t, pnpmImporterVersion(nil))
+}
@@ -0,0 +1,211 @@
+package inventory
+
+import (
+	"os"
+	"strings"
+
+	"github.com/debricked/cli/internal/scope"
+	"github.com/pelletier/go-toml/v2"
+)
+
+type pythonLockPackage struct {
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+}
+
+type pythonDistribution struct {
+	File string `toml:"file"`
+	Url  string `toml:"url"`
+	Hash string `toml:"hash"`
+}
+
+func (d pythonDistribution) isSdist() bool {
+	name := d.File + d.Url
+
+	return strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".zip")
+}
+
+// pythonDistributionHashes picks the source distribution hash, or the hash of the only distribution
+func pythonDistributionHashes(distributions []pythonDistribution) []Hash {
+	for _, distribution := range distributions {
+		if distribution.isSdist() {
+			return hashFromPrefixed(distribution.Hash)
+		}
+	}
+	if len(distributions) == 1 {
+		return hashFromPrefixed(distributions[0].Hash)
+	}
+
+	return nil
+}
+
+type poetryLockPackage struct {
+	pythonLockPackage
+	Files        []pythonDistribution   `toml:"files"`
+	Dependencies map[string]interface{} `toml:"dependencies"`
+	// Category is main or dev before Poetry 1.5, which replaced it with the groups of the package
+	Category string   `toml:"category"`
+	Groups   []string `toml:"groups"`
+}
+
+// scopes returns the scopes of the package, which are unknown for lock files without categories and groups
+func (pkg poetryLockPackage) scopes() []string {
+	var scopes []string
+	if pkg.Category != "" {
+		scopes = addScopes(scopes, scope.Group(pkg.Category))
+	}
+	for _, group := range pkg.Groups {
+		scopes = addScopes(scopes, scope.Group(group))
+	}
+
+	return scopes
+}
+
+type poetryLockFile struct {
+	Packages []poetryLockPackage `toml:"package"`
+}
+
+type poetryDependencyGroup struct {
+	Dependencies map[string]interface{} `toml:"dependencies"`
+}
+
+type pyprojectToml struct {
+	Project struct {
+		Dependencies         []string            `toml:"dependencies"`
+		OptionalDependencies map[string][]string `toml:"optional-dependencies"`
+	} `toml:"project"`
+	DependencyGroups map[string][]interface{} `toml:"dependency-groups"`
+	Tool             struct {
+		Poetry struct {
+			poetryDependencyGroup
+			DevDependencies map[string]interface{}           `toml:"dev-dependencies"`
+			Group           map[string]poetryDependencyGroup `toml:"group"`
+		} `toml:"poetry"`
+	} `toml:"tool"`
+}
+
+// names returns all dependency names declared in pyproject.toml, both PEP 621 and Poetry style
+func (p pyprojectToml) names() map[string]bool {
+	names := map[string]bool{}
+	for name := range p.scopedNames() {
+		names[name] = true
+	}
+
+	return names
+}
+
+// scopedNames returns the scopes of all dependency names declared in pyproject.toml, by dependency group
+func (p pyprojectToml) scopedNames() map[string][]string {
+	scopes := map[string][]string{}
+	addRequirements := func(requirements []string, s string) {
+		for _, requirement := range requirements {
+			if match := pipRequirementNameRegex.FindStringSubmatch(requirement); match != nil {
+				name := NormalizePythonName(match[1])
+				scopes[name] = addScopes(scopes[name], s)
+			}
+		}
+	}
+	addRequirements(p.Project.Dependencies, scope.Prod)
+	for _, optional := range p.Project.OptionalDependencies {
+		addRequirements(optional, scope.Prod)
+	}
+	for group, requirements := range p.DependencyGroups {
+		var names []string
+		for _, requirement := range requirements {
+			if s, ok := requirement.(string); ok {
+				names = append(names, s)
+			}
+		}
+		addRequirements(names, scope.Group(group))
+	}
+	addDependencies := func(dependencies map[string]interface{}, s string) {
+		for name := range dependencies {
+			if name != "python" {
+				name = NormalizePythonName(name)
+				scopes[name] = addScopes(scopes[name], s)
+			}
+		}
+	}
+	poetry := p.Tool.Poetry
+	addDependencies(poetry.Dependencies, scope.Prod)
+	addDependencies(poetry.DevDependencies, scope.Dev)
+	for group, dependencies := range poetry.Group {
+		addDependencies(dependencies.Dependencies, scope.Group(group))
+	}
+
+	return scopes
+}
+
+func readPyproject(manifestFile string) pyprojectToml {
+	var manifest pyprojectToml
+	if manifestFile == "" {
+		return manifest
+	}
+	content, err := os.ReadFile(manifestFile)
+	if err != nil {
+		return manifest
+	}
+	if toml.Unmarshal(content, &manifest) != nil {
+		return pyprojectToml{}
+	}
+
+	return manifest
+}
+
+func readPyprojectNames(manifestFile string) map[string]bool {
+	return readPyproject(manifestFile).names()
+}
+
+// PoetryParser parses poetry.lock files
+type PoetryParser struct{}
+
+func (p PoetryParser) Parse(lockFile string, manifestFile string) ([]Dependency, error) {
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		return nil, err
+	}
+	var lock poetryLockFile
+	err = toml.Unmarshal(content, &lock)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := map[string]string{}
+	for _, pkg := range lock.Packages {
+		versions[NormalizePythonName(pkg.Name)] = pkg.Version
+	}
+
+	manifest := readPyproject(manifestFile)
+	directNames := manifest.names()
+	set := newDependencySet()
+	locksScopes := false
+	for _, pkg := range lock.Packages {
+		var children []string
+		for name := range pkg.Dependencies {
+			if version, ok := versions[NormalizePythonName(name)]; ok {
+				children = append(children, NewPurl(EcosystemPypi, name, version))
+			}
+		}
+		set.add(Dependency{
+			Name:         pkg.Name,
+			Version:      pkg.Version,
+			Ecosystem:    EcosystemPypi,
+			Direct:       directNames[NormalizePythonName(pkg.Name)],
+			Hashes:       pythonDistributionHashes(pkg.Files),
+			Dependencies: children,
+			Scopes:       pkg.scopes(),
+		})
+		locksScopes = locksScopes || len(pkg.scopes()) > 0
+	}
+	if !locksScopes {
+		roots := map[string][]string{}
+		for name, scopes := range manifest.scopedNames() {
+			if version, ok := versions[name]; ok {
+				roots[NewPurl(EcosystemPypi, name, version)] = scopes
+			}
+		}
+		set.propagateScopes(roots)
+	}
+
+	return set.toSlice(), nil
+}
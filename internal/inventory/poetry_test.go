@@ -0,0 +1,103 @@
+package inventory
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/scope"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoetryParser(t *testing.T) {
+	dependencies, err := PoetryParser{}.Parse(
+		filepath.Join("testdata", "poetry", "poetry.lock"),
+		filepath.Join("testdata", "poetry", "pyproject.toml"),
+	)
+
+	assert.NoError(t, err)
+	assertDependencies(t, map[string]bool{
+		"asgiref@3.7.2": false,
+		"django@4.2.6":  true,
+		"pytest@7.4.2":  true,
+	}, dependencies)
+}
+
+func TestPyprojectNames(t *testing.T) {
+	var manifest pyprojectToml
+	manifest.Project.Dependencies = []string{"Django>=4.2", "requests[socks] ; python_version > '3.8'"}
+	manifest.DependencyGroups = map[string][]interface{}{"test": {"pytest", map[string]interface{}{"include-group": "lint"}}}
+
+	assert.Equal(t, map[string]bool{"django": true, "requests": true, "pytest": true}, manifest.names())
+}
+
+func TestPoetryParserGraphAndHashes(t *testing.T) {
+	dependencies, err := PoetryParser{}.Parse(filepath.Join("testdata", "poetry", "poetry.lock"), "")
+
+	assert.NoError(t, err)
+	assertGraph(t, map[string][]string{
+		"django@4.2.6": {"asgiref@3.7.2"},
+	}, dependencies)
+	assert.Equal(t, []Hash{{Algorithm: HashSHA256, Value: "89b2ef2247e3b562a16eef663bc0e2e703ec6468e2fa8a5cd61cd449786d4f6e"}}, findDependency(t, dependencies, "asgiref", "3.7.2").Hashes)
+	assert.Empty(t, findDependency(t, dependencies, "pytest", "7.4.2").Hashes)
+}
+
+func TestPythonDistributionHashes(t *testing.T) {
+	wheel := pythonDistribution{File: "a-1.0-py3-none-any.whl", Hash: "sha256:89b2ef2247e3b562a16eef663bc0e2e703ec6468e2fa8a5cd61cd449786d4f6e"}
+	otherWheel := pythonDistribution{File: "a-1.0-cp311-none-any.whl", Hash: "sha256:9e0ce3aa93a819ba5b45120216b23878cf6e8525eb3848653452b4192b92afed"}
+	sdist := pythonDistribution{Url: "https://files.pythonhosted.org/a-1.0.tar.gz", Hash: "sha256:08f41f468b63335aea0d904c5729e0250300f6a1907bf293a65499496cdbc68f"}
+
+	assert.Equal(t, "08f41f468b63335aea0d904c5729e0250300f6a1907bf293a65499496cdbc68f", pythonDistributionHashes([]pythonDistribution{wheel, sdist})[0].Value)
+	assert.Equal(t, "89b2ef2247e3b562a16eef663bc0e2e703ec6468e2fa8a5cd61cd449786d4f6e", pythonDistributionHashes([]pythonDistribution{wheel})[0].Value)
+	assert.Nil(t, pythonDistributionHashes([]pythonDistribution{wheel, otherWheel}))
+	assert.Nil(t, pythonDistributionHashes(nil))
+}
+
+func TestPoetryParserScopes(t *testing.T) {
+	dependencies, err := PoetryParser{}.Parse(
+		filepath.Join("testdata", "poetry", "poetry.lock"),
+		filepath.Join("testdata", "poetry", "pyproject.toml"),
+	)
+
+	assert.NoError(t, err)
+	assertScopes(t, map[string][]string{
+		"asgiref@3.7.2": {scope.Prod},
+		"django@4.2.6":  {scope.Prod},
+		"pytest@7.4.2":  {scope.Dev},
+	}, dependencies)
+}
+
+func TestPoetryParserLockedScopes(t *testing.T) {
+	lockFile := filepath.Join(t.TempDir(), "poetry.lock")
+	content := `[[package]]
+name = "django"
+version = "4.2.6"
+category = "main"
+
+[[package]]
+name = "pytest"
+version = "7.4.2"
+groups = ["test", "dev"]
+`
+	assert.NoError(t, os.WriteFile(lockFile, []byte(content), 0o600))
+
+	dependencies, err := PoetryParser{}.Parse(lockFile, "")
+
+	assert.NoError(t, err)
+	assertScopes(t, map[string][]string{
+		"django@4.2.6": {scope.Prod},
+		"pytest@7.4.2": {scope.Dev, scope.Test},
+	}, dependencies)
+}
+
+func TestPyprojectScopedNames(t *testing.T) {
+	var manifest pyprojectToml
+	manifest.Project.Dependencies = []string{"Django>=4.2"}
+	manifest.DependencyGroups = map[string][]interface{}{"test": {"pytest"}, "lint": {"ruff"}}
+
+	assert.Equal(t, map[string][]string{
+		"django": {scope.Prod},
+		"pytest": {scope.Test},
+		"ruff":   {scope.Dev},
+	}, manifest.scopedNames())
+}
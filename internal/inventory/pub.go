@@ -0,0 +1,64 @@
+package inventory
+
+import (
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type pubLockPackage struct {
+	Dependency string `yaml:"dependency"`
+	// Description is a map for hosted and git packages but a plain string for sdk packages
+	Description interface{} `yaml:"description"`
+	Source      string      `yaml:"source"`
+	Version     string      `yaml:"version"`
+}
+
+func (pkg pubLockPackage) hashes() []Hash {
+	description, ok := pkg.Description.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	digest, _ := description["sha256"].(string)
+	if hash, ok := newHexHash("sha256", digest); ok {
+		return []Hash{hash}
+	}
+
+	return nil
+}
+
+type pubLockFile struct {
+	Packages map[string]pubLockPackage `yaml:"packages"`
+}
+
+// PubParser parses pubspec.lock files
+type PubParser struct{}
+
+func (p PubParser) Parse(lockFile string, _ string) ([]Dependency, error) {
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		return nil, err
+	}
+	var lock pubLockFile
+	err = yaml.Unmarshal(content, &lock)
+	if err != nil {
+		return nil, err
+	}
+
+	set := newDependencySet()
+	for name, pkg := range lock.Packages {
+		if pkg.Source == "sdk" || pkg.Source == "path" {
+			continue
+		}
+		set.add(Dependency{
+			Name:      name,
+			Version:   pkg.Version,
+			Ecosystem: EcosystemPub,
+			Direct:    strings.HasPrefix(pkg.Dependency, "direct"),
+			Hashes:    pkg.hashes(),
+		})
+	}
+
+	return set.toSlice(), nil
+}
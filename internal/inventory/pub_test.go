@@ -0,0 +1,26 @@
+package inventory
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPubParser(t *testing.T) {
+	dependencies, err := PubParser{}.Parse(filepath.Join("testdata", "pub", "pubspec.lock"), "")
+
+	assert.NoError(t, err)
+	assertDependencies(t, map[string]bool{
+		"async@2.11.0": false,
+		"http@1.1.0":   true,
+		"test@1.24.6":  true,
+	}, dependencies)
+}
+
+func TestPubParserHashes(t *testing.T) {
+	dependencies, err := PubParser{}.Parse(filepath.Join("testdata", "pub", "pubspec.lock"), "")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Hash{{Algorithm: HashSHA256, Value: "759d1a329847dd0f39226c688d3e06a6b8679668e350e2891a6474f8b4bb8525"}}, findDependency(t, dependencies, "http", "1.1.0").Hashes)
+}
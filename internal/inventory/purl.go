@@ -0,0 +1,100 @@
+package inventory
+
+import (
+	"net/url"
+	"strings"
+)
+
+const (
+	EcosystemCargo    = "cargo"
+	EcosystemComposer = "composer"
+	EcosystemGo       = "golang"
+	EcosystemMaven    = "maven"
+	EcosystemNpm      = "npm"
+	EcosystemNuget    = "nuget"
+	EcosystemPub      = "pub"
+	EcosystemPypi     = "pypi"
+)
+
+// NewPurl builds a package URL, see https://github.com/package-url/purl-spec
+func NewPurl(ecosystem string, name string, version string) string {
+	var namespace string
+	switch ecosystem {
+	case EcosystemMaven:
+		if i := strings.Index(name, ":"); i >= 0 {
+			namespace, name = name[:i], name[i+1:]
+		}
+	case EcosystemNpm, EcosystemComposer, EcosystemGo:
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			namespace, name = name[:i], name[i+1:]
+		}
+	case EcosystemPypi:
+		name = NormalizePythonName(name)
+	}
+
+	purl := "pkg:" + ecosystem + "/"
+	if namespace != "" {
+		purl += escapeSegments(namespace) + "/"
+	}
+	purl += escapeSegment(name)
+	if version != "" {
+		purl += "@" + escapeSegment(version)
+	}
+
+	return purl
+}
+
+// eventEcosystems maps the ecosystem names used by Debricked to PURL types, where they differ
+var eventEcosystems = map[string]string{
+	"go":        EcosystemGo,
+	"packagist": EcosystemComposer,
+}
+
+// SplitEventDependency splits dependencies named by Debricked, such as `lodash (npm)`, into name and ecosystem
+func SplitEventDependency(dependency string) (string, string) {
+	name := strings.TrimSpace(dependency)
+	i := strings.LastIndex(name, " (")
+	if i <= 0 || !strings.HasSuffix(name, ")") {
+		return name, ""
+	}
+	ecosystem := strings.ToLower(name[i+2 : len(name)-1])
+	if mapped, ok := eventEcosystems[ecosystem]; ok {
+		ecosystem = mapped
+	}
+
+	return name[:i], ecosystem
+}
+
+// NormalizeName normalizes a package name so that names referring to the same package in ecosystem are equal
+func NormalizeName(ecosystem string, name string) string {
+	if strings.EqualFold(ecosystem, EcosystemPypi) {
+		return NormalizePythonName(name)
+	}
+
+	return strings.ToLower(name)
+}
+
+// DependencyKey identifies a dependency by ecosystem and normalized name, regardless of its version
+func DependencyKey(ecosystem string, name string) string {
+	return ecosystem + "/" + NormalizeName(ecosystem, name)
+}
+
+// NormalizePythonName normalizes a Python package name according to PEP 503
+func NormalizePythonName(name string) string {
+	name = strings.ToLower(name)
+
+	return strings.NewReplacer("_", "-", ".", "-").Replace(name)
+}
+
+func escapeSegments(namespace string) string {
+	segments := strings.Split(namespace, "/")
+	for i, segment := range segments {
+		segments[i] = escapeSegment(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func escapeSegment(segment string) string {
+	return strings.ReplaceAll(url.PathEscape(segment), "@", "%40")
+}
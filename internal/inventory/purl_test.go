@@ -0,0 +1,47 @@
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPurl(t *testing.T) {
+	cases := []struct {
+		ecosystem string
+		name      string
+		version   string
+		expected  string
+	}{
+		{EcosystemNpm, "lodash", "4.17.21", "pkg:npm/lodash@4.17.21"},
+		{EcosystemNpm, "@babel/code-frame", "7.22.13", "pkg:npm/%40babel/code-frame@7.22.13"},
+		{EcosystemMaven, "com.google.guava:guava", "32.1.2-jre", "pkg:maven/com.google.guava/guava@32.1.2-jre"},
+		{EcosystemGo, "github.com/stretchr/testify", "v1.9.0", "pkg:golang/github.com/stretchr/testify@v1.9.0"},
+		{EcosystemPypi, "Django_Rest.Framework", "3.14.0", "pkg:pypi/django-rest-framework@3.14.0"},
+		{EcosystemComposer, "guzzlehttp/guzzle", "7.8.0", "pkg:composer/guzzlehttp/guzzle@7.8.0"},
+		{EcosystemCargo, "serde", "", "pkg:cargo/serde"},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.expected, NewPurl(c.ecosystem, c.name, c.version))
+	}
+}
+
+func TestSplitEventDependency(t *testing.T) {
+	cases := map[string][2]string{
+		"guzzlehttp/guzzle (Packagist)": {"guzzlehttp/guzzle", EcosystemComposer},
+		"Django (PyPI)":                 {"Django", EcosystemPypi},
+		"broken (":                      {"broken (", ""},
+		"no-ecosystem":                  {"no-ecosystem", ""},
+		" spaced (npm) ":                {"spaced", EcosystemNpm},
+		"name (with parens) (Cargo)":    {"name (with parens)", EcosystemCargo},
+	}
+	for dependency, expected := range cases {
+		name, ecosystem := SplitEventDependency(dependency)
+		assert.Equal(t, expected, [2]string{name, ecosystem}, dependency)
+	}
+}
+
+func TestNormalizeName(t *testing.T) {
+	assert.Equal(t, "django-rest-framework", NormalizeName(EcosystemPypi, "Django_Rest.Framework"))
+	assert.Equal(t, "com.google.guava:guava", NormalizeName(EcosystemMaven, "com.Google.guava:Guava"))
+}
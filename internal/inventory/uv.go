@@ -0,0 +1,106 @@
+package inventory
+
+import (
+	"os"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+type uvLockDependency struct {
+	Name    string `toml:"name"`
+	Version string `toml:"version"`
+}
+
+type uvLockPackage struct {
+	pythonLockPackage
+	Source struct {
+		Virtual  string `toml:"virtual"`
+		Editable string `toml:"editable"`
+	} `toml:"source"`
+	Sdist                *pythonDistribution           `toml:"sdist"`
+	Wheels               []pythonDistribution          `toml:"wheels"`
+	Dependencies         []uvLockDependency            `toml:"dependencies"`
+	OptionalDependencies map[string][]uvLockDependency `toml:"optional-dependencies"`
+	DevDependencies      map[string][]uvLockDependency `toml:"dev-dependencies"`
+}
+
+func (pkg uvLockPackage) isProject() bool {
+	return pkg.Source.Virtual != "" || pkg.Source.Editable != ""
+}
+
+func (pkg uvLockPackage) allDependencies() []uvLockDependency {
+	dependencies := pkg.Dependencies
+	for _, group := range pkg.OptionalDependencies {
+		dependencies = append(dependencies, group...)
+	}
+	for _, group := range pkg.DevDependencies {
+		dependencies = append(dependencies, group...)
+	}
+
+	return dependencies
+}
+
+func (pkg uvLockPackage) hashes() []Hash {
+	if pkg.Sdist != nil {
+		return hashFromPrefixed(pkg.Sdist.Hash)
+	}
+
+	return pythonDistributionHashes(pkg.Wheels)
+}
+
+type uvLockFile struct {
+	Packages []uvLockPackage `toml:"package"`
+}
+
+// UvParser parses uv.lock files
+type UvParser struct{}
+
+func (p UvParser) Parse(lockFile string, _ string) ([]Dependency, error) {
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		return nil, err
+	}
+	var lock uvLockFile
+	err = toml.Unmarshal(content, &lock)
+	if err != nil {
+		return nil, err
+	}
+
+	directNames := map[string]bool{}
+	versions := map[string]string{}
+	for _, pkg := range lock.Packages {
+		versions[NormalizePythonName(pkg.Name)] = pkg.Version
+		if !pkg.isProject() {
+			continue
+		}
+		for _, dependency := range pkg.allDependencies() {
+			directNames[NormalizePythonName(dependency.Name)] = true
+		}
+	}
+
+	set := newDependencySet()
+	for _, pkg := range lock.Packages {
+		if pkg.isProject() {
+			continue
+		}
+		var children []string
+		for _, dependency := range pkg.allDependencies() {
+			// uv only records the version when several versions of a package are locked
+			version := dependency.Version
+			if version == "" {
+				version = versions[NormalizePythonName(dependency.Name)]
+			}
+			children = append(children, NewPurl(EcosystemPypi, dependency.Name, version))
+		}
+		set.add(Dependency{
+			Name:         pkg.Name,
+			Version:      pkg.Version,
+			Ecosystem:    EcosystemPypi,
+			Direct:       directNames[NormalizePythonName(pkg.Name)],
+			Hashes:       pkg.hashes(),
+			Dependencies: children,
+		})
+	}
+
+	return set.toSlice(), nil
+}
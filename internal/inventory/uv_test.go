@@ -0,0 +1,30 @@
+package inventory
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUvParser(t *testing.T) {
+	dependencies, err := UvParser{}.Parse(filepath.Join("testdata", "uv", "uv.lock"), "")
+
+	assert.NoError(t, err)
+	assertDependencies(t, map[string]bool{
+		"asgiref@3.7.2": false,
+		"django@4.2.6":  true,
+		"pytest@7.4.2":  true,
+	}, dependencies)
+}
+
+func TestUvParserGraphAndHashes(t *testing.T) {
+	dependencies, err := UvParser{}.Parse(filepath.Join("testdata", "uv", "uv.lock"), "")
+
+	assert.NoError(t, err)
+	assertGraph(t, map[string][]string{
+		"django@4.2.6": {"asgiref@3.7.2"},
+	}, dependencies)
+	assert.Equal(t, []Hash{{Algorithm: HashSHA256, Value: "9e0ce3aa93a819ba5b45120216b23878cf6e8525eb3848653452b4192b92afed"}}, findDependency(t, dependencies, "asgiref", "3.7.2").Hashes)
+	assert.Empty(t, findDependency(t, dependencies, "pytest", "7.4.2").Hashes)
+}
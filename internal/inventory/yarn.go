@@ -0,0 +1,173 @@
+package inventory
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+type yarnEntry struct {
+	name         string
+	version      string
+	integrity    string
+	dependencies map[string]string
+}
+
+// YarnParser parses both classic (v1) and berry (v2+) yarn.lock files
+type YarnParser struct{}
+
+func (p YarnParser) Parse(lockFile string, manifestFile string) ([]Dependency, error) {
+	f, err := os.Open(lockFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []*yarnEntry
+	specs := map[string]*yarnEntry{}
+	var entry *yarnEntry
+	inDependencies := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") {
+			entry = nil
+			if name := yarnEntryName(line); name != "" {
+				entry = &yarnEntry{name: name, dependencies: map[string]string{}}
+				entries = append(entries, entry)
+				for _, spec := range yarnEntrySpecs(line) {
+					specs[spec] = entry
+				}
+			}
+
+			continue
+		}
+		if entry == nil {
+			continue
+		}
+		if strings.HasPrefix(line, "    ") {
+			if inDependencies {
+				name, version := yarnField(line)
+				entry.dependencies[name] = version
+			}
+
+			continue
+		}
+		field, value := yarnField(line)
+		inDependencies = field == "dependencies" || field == "optionalDependencies"
+		switch field {
+		case "version":
+			entry.version = value
+		case "integrity":
+			entry.integrity = value
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	manifest := readPackageJson(manifestFile)
+	directNames := manifest.names()
+	set := newDependencySet()
+	for _, entry := range entries {
+		if entry.version == "" || strings.HasSuffix(entry.version, "-use.local") {
+			continue
+		}
+		var children []string
+		for name, version := range entry.dependencies {
+			child, ok := specs[name+"@"+version]
+			if !ok {
+				child, ok = specs[name+"@npm:"+version]
+			}
+			if ok {
+				children = append(children, NewPurl(EcosystemNpm, child.name, child.version))
+			}
+		}
+		set.add(Dependency{
+			Name:         entry.name,
+			Version:      entry.version,
+			Ecosystem:    EcosystemNpm,
+			Direct:       directNames[entry.name],
+			Hashes:       hashesFromIntegrity(entry.integrity),
+			Dependencies: children,
+		})
+	}
+	// yarn.lock doesn't tell dev dependencies apart, so scopes follow from the dependencies declared in package.json
+	roots := map[string][]string{}
+	for s, dependencies := range manifest.scopedDependencies() {
+		for _, deps := range dependencies {
+			for name, version := range deps {
+				root, ok := specs[name+"@"+version]
+				if !ok {
+					root, ok = specs[name+"@npm:"+version]
+				}
+				if ok {
+					purl := NewPurl(EcosystemNpm, root.name, root.version)
+					roots[purl] = addScopes(roots[purl], s)
+				}
+			}
+		}
+	}
+	set.propagateScopes(roots)
+
+	return set.toSlice(), nil
+}
+
+// yarnField splits indented lines such as `version "1.0.0"`, `version: 1.0.0` and `"@scope/a" "^1.0.0"`
+func yarnField(line string) (string, string) {
+	line = strings.TrimSpace(line)
+	var key, value string
+	if strings.HasPrefix(line, `"`) {
+		end := strings.Index(line[1:], `"`)
+		if end < 0 {
+			return "", ""
+		}
+		key, value = line[1:end+1], line[end+2:]
+	} else {
+		i := strings.IndexAny(line, ": ")
+		if i < 0 {
+			return strings.TrimSuffix(line, ":"), ""
+		}
+		key, value = line[:i], line[i:]
+	}
+	value = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(value), ":"))
+
+	return key, strings.Trim(value, `"`)
+}
+
+// yarnEntrySpecs returns all specs in an entry header such as `lodash@^4.17.20, lodash@^4.17.21:`
+func yarnEntrySpecs(header string) []string {
+	header = strings.TrimSuffix(strings.TrimSpace(header), ":")
+	var specs []string
+	for _, spec := range strings.Split(header, ",") {
+		specs = append(specs, strings.Trim(strings.TrimSpace(spec), `"`))
+	}
+
+	return specs
+}
+
+// yarnEntryName extracts the package name from an entry header such as `"@scope/a@^1.0.0", "@scope/a@^1.1.0":`
+func yarnEntryName(header string) string {
+	header = strings.TrimSuffix(strings.TrimSpace(header), ":")
+	spec := strings.Trim(strings.TrimSpace(strings.Split(header, ",")[0]), `"`)
+	if spec == "__metadata" {
+		return ""
+	}
+	at := strings.LastIndex(spec, "@")
+	if at <= 0 {
+		return ""
+	}
+	name := spec[:at]
+	// Berry writes protocols into the range, e.g. lodash@npm:^4.17.21 or a@patch:a@npm%3A1.0.0#...
+	if i := strings.Index(name, "@npm:"); i > 0 {
+		name = name[:i]
+	}
+	if i := strings.Index(name, "@patch:"); i > 0 {
+		name = name[:i]
+	}
+
+	return name
+}
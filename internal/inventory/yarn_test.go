@@ -0,0 +1,121 @@
+package inventory
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/scope"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestYarnParserClassic(t *testing.T) {
+	dependencies, err := YarnParser{}.Parse(
+		filepath.Join("testdata", "yarn", "yarn.lock"),
+		filepath.Join("testdata", "yarn", "package.json"),
+	)
+
+	assert.NoError(t, err)
+	assertDependencies(t, map[string]bool{
+		"@babel/code-frame@7.22.13": true,
+		"chalk@2.4.2":               false,
+		"chalk@4.1.2":               false,
+		"jest@29.7.0":               true,
+		"lodash@4.17.21":            true,
+	}, dependencies)
+}
+
+func TestYarnParserBerry(t *testing.T) {
+	dependencies, err := YarnParser{}.Parse(
+		filepath.Join("testdata", "yarn-berry", "yarn.lock"),
+		filepath.Join("testdata", "yarn-berry", "package.json"),
+	)
+
+	assert.NoError(t, err)
+	assertDependencies(t, map[string]bool{
+		"@babel/code-frame@7.22.13": true,
+		"chalk@2.4.2":               false,
+		"lodash@4.17.21":            true,
+	}, dependencies)
+}
+
+func TestYarnEntryName(t *testing.T) {
+	cases := map[string]string{
+		`lodash@^4.17.20, lodash@^4.17.21:`:              "lodash",
+		`"@babel/code-frame@^7.0.0":`:                    "@babel/code-frame",
+		`"@babel/code-frame@npm:^7.0.0":`:                "@babel/code-frame",
+		`"resolve@patch:resolve@npm%3A^1.1.7#~builtin":`: "resolve",
+		`__metadata:`: "",
+		`invalid:`:    "",
+	}
+	for header, expected := range cases {
+		assert.Equal(t, expected, yarnEntryName(header), header)
+	}
+}
+
+func TestYarnParserGraph(t *testing.T) {
+	cases := map[string]map[string][]string{
+		"yarn": {
+			"@babel/code-frame@7.22.13": {"chalk@2.4.2"},
+			"jest@29.7.0":               {"chalk@4.1.2"},
+		},
+		"yarn-berry": {
+			"@babel/code-frame@7.22.13": {"chalk@2.4.2"},
+		},
+	}
+	for dir, expected := range cases {
+		t.Run(dir, func(t *testing.T) {
+			dependencies, err := YarnParser{}.Parse(filepath.Join("testdata", dir, "yarn.lock"), "")
+
+			assert.NoError(t, err)
+			assertGraph(t, expected, dependencies)
+		})
+	}
+}
+
+func TestYarnParserHashes(t *testing.T) {
+	dependencies, err := YarnParser{}.Parse(filepath.Join("testdata", "yarn", "yarn.lock"), "")
+
+	assert.NoError(t, err)
+	for _, dependency := range dependencies {
+		assert.Len(t, dependency.Hashes, 1, dependency.Name)
+	}
+}
+
+func TestYarnField(t *testing.T) {
+	cases := map[string][2]string{
+		`  version "7.22.13"`:                  {"version", "7.22.13"},
+		`  version: 7.22.13`:                   {"version", "7.22.13"},
+		`  dependencies:`:                      {"dependencies", ""},
+		`    chalk "^2.4.2"`:                   {"chalk", "^2.4.2"},
+		`    chalk: ^2.4.2`:                    {"chalk", "^2.4.2"},
+		`    "@babel/highlight" "^7.0.0"`:      {"@babel/highlight", "^7.0.0"},
+		`    "@babel/highlight": "npm:^7.0.0"`: {"@babel/highlight", "npm:^7.0.0"},
+	}
+	for line, expected := range cases {
+		key, value := yarnField(line)
+		assert.Equal(t, expected, [2]string{key, value}, line)
+	}
+}
+
+func TestYarnParserScopes(t *testing.T) {
+	dependencies, err := YarnParser{}.Parse(
+		filepath.Join("testdata", "yarn", "yarn.lock"),
+		filepath.Join("testdata", "yarn", "package.json"),
+	)
+
+	assert.NoError(t, err)
+	assertScopes(t, map[string][]string{
+		"@babel/code-frame@7.22.13": {scope.Prod},
+		"chalk@2.4.2":               {scope.Prod},
+		"chalk@4.1.2":               {scope.Dev},
+		"jest@29.7.0":               {scope.Dev},
+		"lodash@4.17.21":            {scope.Prod},
+	}, dependencies)
+}
+
+func TestYarnParserScopesWithoutManifest(t *testing.T) {
+	dependencies, err := YarnParser{}.Parse(filepath.Join("testdata", "yarn", "yarn.lock"), "")
+
+	assert.NoError(t, err)
+	assertScopes(t, map[string][]string{}, dependencies)
+}
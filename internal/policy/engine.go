@@ -0,0 +1,187 @@
+package policy
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/inventory"
+	"github.com/debricked/cli/internal/upload"
+)
+
+const (
+	PolicyDeny    = "deny"
+	PolicyMaxCvss = "maxCvss"
+	PolicyLicense = "license"
+)
+
+// Engine evaluates the policies of debricked-config.yaml locally, against trigger events returned by Debricked
+// and the dependency inventory parsed from lock files. The maximum CVSS score and banned licenses are only known to
+// the CLI, so they are only checked against the vulnerabilities and licenses of trigger events. Dependencies that
+// don't trigger any automation rule, or that are only found in manifest files without a parsed lock file, pass them.
+type Engine struct {
+	allow          []PackageSpec
+	deny           []PackageSpec
+	ignore         []PackageSpec
+	bannedLicenses map[string]bool
+	maxCvss        float64
+}
+
+// NewEngine parses the policies and ignored packages of config. Allowed and ignored packages are exempt from all
+// policies.
+func NewEngine(config *upload.DebrickedConfig) (*Engine, error) {
+	engine := &Engine{bannedLicenses: map[string]bool{}}
+	if config == nil {
+		return engine, nil
+	}
+	if config.Ignore != nil {
+		for _, ignored := range config.Ignore.Packages {
+			raw := ignored.PURL
+			if ignored.Version != "" {
+				raw += "@" + ignored.Version
+			}
+			spec, err := ParsePackageSpec(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid ignored package \"%s\": %w", raw, err)
+			}
+			engine.ignore = append(engine.ignore, spec)
+		}
+	}
+	policies := config.Policies
+	if policies == nil {
+		return engine, nil
+	}
+	if policies.MaxCvss < 0 || policies.MaxCvss > 10 {
+		return nil, fmt.Errorf("invalid maxCvss %.1f, expected a score between 0 and 10", policies.MaxCvss)
+	}
+	engine.maxCvss = policies.MaxCvss
+	var err error
+	if policies.Allow != nil {
+		if engine.allow, err = parsePackageSpecs(policies.Allow.Packages); err != nil {
+			return nil, err
+		}
+	}
+	if policies.Deny != nil {
+		if engine.deny, err = parsePackageSpecs(policies.Deny.Packages); err != nil {
+			return nil, err
+		}
+		for _, license := range policies.Deny.Licenses {
+			engine.bannedLicenses[strings.ToLower(strings.TrimSpace(license))] = true
+		}
+	}
+
+	return engine, nil
+}
+
+func parsePackageSpecs(packages []string) ([]PackageSpec, error) {
+	specs := make([]PackageSpec, 0, len(packages))
+	for _, raw := range packages {
+		spec, err := ParsePackageSpec(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid policy package \"%s\": %w", raw, err)
+		}
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
+
+// Evaluate checks the dependencies of inv against the denied packages, and the trigger events of result against the
+// maximum CVSS score and banned licenses. Either argument may be nil.
+func (engine *Engine) Evaluate(result *upload.UploadResult, inv *inventory.Inventory) Verdict {
+	verdict := Verdict{Violations: []Violation{}}
+	seen := map[Violation]bool{}
+	add := func(violation Violation) {
+		if !seen[violation] {
+			seen[violation] = true
+			verdict.Violations = append(verdict.Violations, violation)
+		}
+	}
+
+	versions := map[string]string{}
+	if inv != nil {
+		for _, dependency := range inv.Dependencies {
+			versions[dependencyKey(dependency.Ecosystem, dependency.Name)] = dependency.Version
+			if engine.exempt(dependency.Ecosystem, dependency.Name, dependency.Version) {
+				continue
+			}
+			if spec, denied := findMatch(engine.deny, dependency.Ecosystem, dependency.Name, dependency.Version); denied {
+				add(Violation{
+					Policy:     PolicyDeny,
+					Dependency: dependency.Name,
+					Version:    dependency.Version,
+					Ecosystem:  dependency.Ecosystem,
+					Reason:     fmt.Sprintf("denied by %s", spec.Raw),
+				})
+			}
+		}
+	}
+
+	if result != nil {
+		for _, rule := range result.AutomationRules {
+			for _, event := range rule.TriggerEvents {
+				name, ecosystem := eventDependency(event)
+				version := versions[dependencyKey(ecosystem, name)]
+				if engine.exempt(ecosystem, name, version) {
+					continue
+				}
+				for _, violation := range engine.evaluateEvent(event) {
+					violation.Dependency, violation.Version, violation.Ecosystem = name, version, ecosystem
+					add(violation)
+				}
+			}
+		}
+	}
+
+	return verdict
+}
+
+func (engine *Engine) evaluateEvent(event automation.TriggerEvent) []Violation {
+	var violations []Violation
+	cvss := float64(event.Cvss3)
+	if cvss == 0 {
+		cvss = float64(event.Cvss2)
+	}
+	if engine.maxCvss > 0 && cvss > engine.maxCvss {
+		violations = append(violations, Violation{
+			Policy: PolicyMaxCvss,
+			Reason: fmt.Sprintf("%s has CVSS %.1f, above the maximum of %.1f", event.Cve, cvss, engine.maxCvss),
+		})
+	}
+	for _, license := range event.Licenses {
+		if engine.bannedLicenses[strings.ToLower(strings.TrimSpace(license))] {
+			violations = append(violations, Violation{
+				Policy: PolicyLicense,
+				Reason: fmt.Sprintf("license %s is banned", license),
+			})
+		}
+	}
+
+	return violations
+}
+
+func (engine *Engine) exempt(ecosystem string, name string, version string) bool {
+	_, ignored := findMatch(engine.ignore, ecosystem, name, version)
+	_, allowed := findMatch(engine.allow, ecosystem, name, version)
+
+	return ignored || allowed
+}
+
+func findMatch(specs []PackageSpec, ecosystem string, name string, version string) (PackageSpec, bool) {
+	for _, spec := range specs {
+		if spec.Matches(ecosystem, name, version) {
+			return spec, true
+		}
+	}
+
+	return PackageSpec{}, false
+}
+
+// eventDependency splits dependencies such as `lodash (npm)` into name and ecosystem
+func eventDependency(event automation.TriggerEvent) (string, string) {
+	return inventory.SplitEventDependency(event.Dependency)
+}
+
+func dependencyKey(ecosystem string, name string) string {
+	return inventory.DependencyKey(ecosystem, name)
+}
@@ -0,0 +1,131 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/inventory"
+	"github.com/debricked/cli/internal/upload"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestConfig() *upload.DebrickedConfig {
+	return &upload.DebrickedConfig{
+		Ignore: &upload.IgnoreConfig{
+			Packages: []upload.IgnorePackage{{PURL: "pkg:npm/chart.js", Version: "2.6.0"}},
+		},
+		Policies: &upload.PoliciesConfig{
+			Allow: &upload.PolicyPackages{Packages: []string{"log4j@2.15.0-2.17.1"}},
+			Deny: &upload.PolicyPackages{
+				Packages: []string{"pkg:npm/request", "minimist@<1.2.6", "log4j", "chart.js"},
+				Licenses: []string{"GPL-3.0"},
+			},
+			MaxCvss: 7,
+		},
+	}
+}
+
+func newTestInventory() *inventory.Inventory {
+	return &inventory.Inventory{Dependencies: []inventory.Dependency{
+		{Name: "request", Version: "2.88.2", Ecosystem: inventory.EcosystemNpm},
+		{Name: "minimist", Version: "1.2.5", Ecosystem: inventory.EcosystemNpm},
+		{Name: "minimist", Version: "1.2.8", Ecosystem: inventory.EcosystemNpm},
+		{Name: "chart.js", Version: "2.6.0", Ecosystem: inventory.EcosystemNpm},
+		{Name: "log4j", Version: "2.16.0", Ecosystem: inventory.EcosystemMaven},
+		{Name: "lodash", Version: "4.17.20", Ecosystem: inventory.EcosystemNpm},
+	}}
+}
+
+func TestEvaluateInventory(t *testing.T) {
+	engine, err := NewEngine(newTestConfig())
+	assert.NoError(t, err)
+
+	verdict := engine.Evaluate(nil, newTestInventory())
+
+	assert.False(t, verdict.Passed())
+	assert.Equal(t, []Violation{
+		{Policy: PolicyDeny, Dependency: "request", Version: "2.88.2", Ecosystem: "npm", Reason: "denied by pkg:npm/request"},
+		{Policy: PolicyDeny, Dependency: "minimist", Version: "1.2.5", Ecosystem: "npm", Reason: "denied by minimist@<1.2.6"},
+	}, verdict.Violations)
+}
+
+func TestEvaluateTriggerEvents(t *testing.T) {
+	engine, err := NewEngine(newTestConfig())
+	assert.NoError(t, err)
+	result := &upload.UploadResult{AutomationRules: []automation.Rule{
+		{TriggerEvents: []automation.TriggerEvent{
+			{Dependency: "lodash (npm)", Cve: "CVE-2021-23337", Cvss3: 7.2},
+			{Dependency: "lodash (npm)", Cve: "CVE-2020-28500", Cvss3: 5.3},
+			{Dependency: "log4j (Maven)", Cve: "CVE-2021-45046", Cvss3: 9},
+			{Dependency: "chart.js (npm)", Cve: "CVE-2020-7746", Cvss3: 9.8},
+			{Dependency: "gpl-lib (PyPI)", Licenses: []string{"gpl-3.0", "MIT"}},
+		}},
+		{TriggerEvents: []automation.TriggerEvent{
+			{Dependency: "lodash (npm)", Cve: "CVE-2021-23337", Cvss3: 7.2},
+			{Dependency: "old-lib (Go)", Cve: "CVE-2019-0001", Cvss2: 7.5},
+		}},
+	}}
+
+	verdict := engine.Evaluate(result, newTestInventory())
+
+	assert.Equal(t, []Violation{
+		{Policy: PolicyDeny, Dependency: "request", Version: "2.88.2", Ecosystem: "npm", Reason: "denied by pkg:npm/request"},
+		{Policy: PolicyDeny, Dependency: "minimist", Version: "1.2.5", Ecosystem: "npm", Reason: "denied by minimist@<1.2.6"},
+		{Policy: PolicyMaxCvss, Dependency: "lodash", Version: "4.17.20", Ecosystem: "npm", Reason: "CVE-2021-23337 has CVSS 7.2, above the maximum of 7.0"},
+		{Policy: PolicyLicense, Dependency: "gpl-lib", Ecosystem: "pypi", Reason: "license gpl-3.0 is banned"},
+		{Policy: PolicyMaxCvss, Dependency: "old-lib", Ecosystem: "golang", Reason: "CVE-2019-0001 has CVSS 7.5, above the maximum of 7.0"},
+	}, verdict.Violations)
+}
+
+func TestEvaluateWithoutPolicies(t *testing.T) {
+	engine, err := NewEngine(nil)
+	assert.NoError(t, err)
+
+	verdict := engine.Evaluate(&upload.UploadResult{}, newTestInventory())
+
+	assert.True(t, verdict.Passed())
+	assert.Empty(t, verdict.Violations)
+}
+
+func TestNewEngineInvalidPackage(t *testing.T) {
+	config := newTestConfig()
+	config.Policies.Deny.Packages = append(config.Policies.Deny.Packages, "lodash@")
+
+	engine, err := NewEngine(config)
+
+	assert.Nil(t, engine)
+	assert.ErrorContains(t, err, "invalid policy package \"lodash@\": missing version")
+}
+
+func TestNewEngineInvalidIgnoredPackage(t *testing.T) {
+	config := newTestConfig()
+	config.Ignore.Packages = append(config.Ignore.Packages, upload.IgnorePackage{PURL: "pkg:"})
+
+	engine, err := NewEngine(config)
+
+	assert.Nil(t, engine)
+	assert.ErrorContains(t, err, "invalid ignored package \"pkg:\"")
+}
+
+func TestNewEngineInvalidMaxCvss(t *testing.T) {
+	config := newTestConfig()
+	config.Policies.MaxCvss = 11
+
+	engine, err := NewEngine(config)
+
+	assert.Nil(t, engine)
+	assert.ErrorContains(t, err, "invalid maxCvss 11.0")
+}
+
+func TestEventDependency(t *testing.T) {
+	cases := map[string][2]string{
+		"lodash (npm)":                {"lodash", "npm"},
+		"org.slf4j:slf4j-api (Maven)": {"org.slf4j:slf4j-api", "maven"},
+		"golang.org/x/net (Go)":       {"golang.org/x/net", "golang"},
+		"no-ecosystem":                {"no-ecosystem", ""},
+	}
+	for dependency, expected := range cases {
+		name, ecosystem := eventDependency(automation.TriggerEvent{Dependency: dependency})
+		assert.Equal(t, expected, [2]string{name, ecosystem}, dependency)
+	}
+}
@@ -0,0 +1,184 @@
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/debricked/cli/internal/inventory"
+)
+
+var (
+	EmptyPackageSpecErr = errors.New("empty package")
+	MissingVersionErr   = errors.New("missing version")
+)
+
+// operators are ordered so that two character operators are matched before their one character prefixes
+var operators = []string{">=", "<=", "!=", "==", ">", "<", "="}
+
+type constraint struct {
+	operator string
+	version  string
+}
+
+func (c constraint) satisfiedBy(version string) bool {
+	comparison := CompareVersions(version, c.version)
+	switch c.operator {
+	case ">=":
+		return comparison >= 0
+	case "<=":
+		return comparison <= 0
+	case ">":
+		return comparison > 0
+	case "<":
+		return comparison < 0
+	case "!=":
+		return comparison != 0
+	default:
+		return comparison == 0
+	}
+}
+
+// PackageSpec is a package identifier from the policies or ignore section of debricked-config.yaml.
+// Supported forms are PURLs (pkg:npm/lodash@4.17.21), names (lodash), names with a version (lodash@4.17.21),
+// comma separated constraints (django@>=3.2.0,<5.0.0) and hyphen ranges (log4j@2.15.0-2.17.1).
+type PackageSpec struct {
+	Raw string
+	// Ecosystem is the PURL type. It is empty for specs given by name, which match every ecosystem.
+	Ecosystem   string
+	Name        string
+	constraints []constraint
+}
+
+func ParsePackageSpec(raw string) (PackageSpec, error) {
+	spec := PackageSpec{Raw: raw}
+	identifier := strings.TrimSpace(raw)
+	if identifier == "" {
+		return spec, EmptyPackageSpecErr
+	}
+
+	isPurl := strings.HasPrefix(identifier, "pkg:")
+	if isPurl {
+		identifier = strings.TrimPrefix(identifier, "pkg:")
+		if i := strings.IndexAny(identifier, "?#"); i >= 0 {
+			identifier = identifier[:i]
+		}
+		i := strings.Index(identifier, "/")
+		if i <= 0 {
+			return spec, fmt.Errorf("missing package type in %s", raw)
+		}
+		spec.Ecosystem = strings.ToLower(identifier[:i])
+		identifier = identifier[i+1:]
+	}
+
+	name, version, hasVersion := splitNameVersion(identifier)
+	if name == "" {
+		return spec, EmptyPackageSpecErr
+	}
+	if isPurl {
+		unescaped, err := url.PathUnescape(name)
+		if err != nil {
+			return spec, err
+		}
+		name = unescaped
+		if spec.Ecosystem == inventory.EcosystemMaven {
+			if i := strings.LastIndex(name, "/"); i >= 0 {
+				name = name[:i] + ":" + name[i+1:]
+			}
+		}
+	}
+	spec.Name = name
+
+	if hasVersion {
+		constraints, err := parseConstraints(version)
+		if err != nil {
+			return spec, err
+		}
+		spec.constraints = constraints
+	}
+
+	return spec, nil
+}
+
+// splitNameVersion splits at the last @ after the last /, since scoped npm names such as @angular/core contain an @
+func splitNameVersion(identifier string) (string, string, bool) {
+	start := strings.LastIndex(identifier, "/") + 1
+	if i := strings.LastIndex(identifier[start:], "@"); i > 0 || (i == 0 && start > 0) {
+		return identifier[:start+i], identifier[start+i+1:], true
+	}
+
+	return identifier, "", false
+}
+
+func parseConstraints(version string) ([]constraint, error) {
+	var constraints []constraint
+	for _, part := range strings.Split(version, ",") {
+		part = strings.TrimSpace(part)
+		operator := ""
+		for _, op := range operators {
+			if strings.HasPrefix(part, op) {
+				operator = op
+				part = strings.TrimSpace(strings.TrimPrefix(part, op))
+
+				break
+			}
+		}
+		if part == "" {
+			return nil, MissingVersionErr
+		}
+		if operator == "" {
+			if from, to, ok := splitHyphenRange(part); ok {
+				constraints = append(constraints, constraint{">=", from}, constraint{"<=", to})
+
+				continue
+			}
+			operator = "="
+		}
+		constraints = append(constraints, constraint{operator, part})
+	}
+
+	return constraints, nil
+}
+
+// splitHyphenRange splits ranges such as 2.15.0-2.17.1, while leaving pre-releases such as 1.0.0-beta intact
+func splitHyphenRange(version string) (string, string, bool) {
+	i := strings.Index(version, "-")
+	if i <= 0 || i == len(version)-1 {
+		return "", "", false
+	}
+	from, to := version[:i], version[i+1:]
+	if !startsWithDigit(from) || !startsWithDigit(to) || !strings.Contains(to, ".") {
+		return "", "", false
+	}
+
+	return from, to, true
+}
+
+func startsWithDigit(s string) bool {
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
+// Matches reports whether the dependency is identified by spec. Specs with version constraints never match
+// dependencies of unknown version.
+func (spec PackageSpec) Matches(ecosystem string, name string, version string) bool {
+	if spec.Ecosystem != "" && !strings.EqualFold(spec.Ecosystem, ecosystem) {
+		return false
+	}
+	if inventory.NormalizeName(ecosystem, spec.Name) != inventory.NormalizeName(ecosystem, name) {
+		return false
+	}
+	if len(spec.constraints) == 0 {
+		return true
+	}
+	if version == "" {
+		return false
+	}
+	for _, c := range spec.constraints {
+		if !c.satisfiedBy(version) {
+			return false
+		}
+	}
+
+	return true
+}
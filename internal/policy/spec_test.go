@@ -0,0 +1,80 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePackageSpec(t *testing.T) {
+	cases := map[string]PackageSpec{
+		"react":                           {Name: "react"},
+		"pkg:npm/request":                 {Ecosystem: "npm", Name: "request"},
+		"pkg:npm/@angular/core@15.0.0":    {Ecosystem: "npm", Name: "@angular/core", constraints: []constraint{{"=", "15.0.0"}}},
+		"pkg:npm/%40types/node":           {Ecosystem: "npm", Name: "@types/node"},
+		"pkg:maven/log4j/log4j@1.2.17":    {Ecosystem: "maven", Name: "log4j:log4j", constraints: []constraint{{"=", "1.2.17"}}},
+		"pkg:pypi/setuptools@<65.0.0":     {Ecosystem: "pypi", Name: "setuptools", constraints: []constraint{{"<", "65.0.0"}}},
+		"pkg:golang/golang.org/x/net?x=y": {Ecosystem: "golang", Name: "golang.org/x/net"},
+		"@types/node":                     {Name: "@types/node"},
+		"axios@1.3.0":                     {Name: "axios", constraints: []constraint{{"=", "1.3.0"}}},
+		"django@>=3.2.0,<5.0.0":           {Name: "django", constraints: []constraint{{">=", "3.2.0"}, {"<", "5.0.0"}}},
+		"log4j@2.15.0-2.17.1":             {Name: "log4j", constraints: []constraint{{">=", "2.15.0"}, {"<=", "2.17.1"}}},
+		"lib@1.0.0-beta":                  {Name: "lib", constraints: []constraint{{"=", "1.0.0-beta"}}},
+		"moment@<= 2.29.1":                {Name: "moment", constraints: []constraint{{"<=", "2.29.1"}}},
+	}
+	for raw, expected := range cases {
+		expected.Raw = raw
+		spec, err := ParsePackageSpec(raw)
+		assert.NoError(t, err, raw)
+		assert.Equal(t, expected, spec, raw)
+	}
+}
+
+func TestParsePackageSpecInvalid(t *testing.T) {
+	cases := map[string]string{
+		"":            EmptyPackageSpecErr.Error(),
+		"lodash@":     MissingVersionErr.Error(),
+		"lodash@>=":   MissingVersionErr.Error(),
+		"pkg:lodash":  "missing package type in pkg:lodash",
+		"pkg:npm/":    EmptyPackageSpecErr.Error(),
+		"pkg:npm/%zz": "invalid URL escape",
+	}
+	for raw, expectedErr := range cases {
+		_, err := ParsePackageSpec(raw)
+		assert.ErrorContains(t, err, expectedErr, raw)
+	}
+}
+
+func TestPackageSpecMatches(t *testing.T) {
+	cases := []struct {
+		spec      string
+		ecosystem string
+		name      string
+		version   string
+		expected  bool
+	}{
+		{"react", "npm", "react", "18.2.0", true},
+		{"React", "npm", "react", "", true},
+		{"pkg:npm/request", "npm", "request", "2.88.2", true},
+		{"pkg:npm/request", "pypi", "request", "2.88.2", false},
+		{"pkg:pypi/Pillow", "pypi", "pillow", "8.0.0", true},
+		{"pkg:maven/log4j/log4j@1.2.17", "maven", "log4j:log4j", "1.2.17", true},
+		{"pkg:maven/log4j/log4j@1.2.17", "maven", "log4j:log4j", "1.2.16", false},
+		{"minimist@<1.2.6", "npm", "minimist", "1.2.5", true},
+		{"minimist@<1.2.6", "npm", "minimist", "1.2.6", false},
+		{"minimist@<1.2.6", "npm", "minimist", "", false},
+		{"django@>=3.2.0,<5.0.0", "pypi", "Django", "4.2.7", true},
+		{"django@>=3.2.0,<5.0.0", "pypi", "django", "5.0.0", false},
+		{"log4j@1.0-2.14.1", "maven", "log4j", "2.14.1", true},
+		{"log4j@1.0-2.14.1", "maven", "log4j", "2.15.0", false},
+		{"lodash@!=4.17.20", "npm", "lodash", "4.17.21", true},
+		{"lodash@==4.17.21", "npm", "lodash", "4.17.21", true},
+		{"lodash@>4.17.21", "npm", "lodash", "4.17.21", false},
+		{"lodash", "npm", "lodash-es", "4.17.21", false},
+	}
+	for _, c := range cases {
+		spec, err := ParsePackageSpec(c.spec)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, spec.Matches(c.ecosystem, c.name, c.version), "%s matches %s %s", c.spec, c.name, c.version)
+	}
+}
@@ -0,0 +1,56 @@
+package policy
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+type Violation struct {
+	Policy     string
+	Dependency string
+	Version    string
+	Ecosystem  string
+	Reason     string
+}
+
+// Verdict is the outcome of evaluating policies locally
+type Verdict struct {
+	Violations []Violation
+}
+
+func (verdict Verdict) Passed() bool {
+	return len(verdict.Violations) == 0
+}
+
+// Render writes the verdict, listing every violation if the policies failed
+func (verdict Verdict) Render(mirror io.Writer) {
+	if verdict.Passed() {
+		_, _ = fmt.Fprintf(mirror, "%s Local policy check passed\n\n", color.GreenString("✔"))
+
+		return
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(mirror)
+	style := table.StyleRounded
+	style.Format.Footer = text.FormatDefault
+	t.SetStyle(style)
+	t.AppendHeader(table.Row{"Policy", "Dependency", "Violation"})
+	for _, violation := range verdict.Violations {
+		dependency := violation.Dependency
+		if violation.Version != "" {
+			dependency += " " + violation.Version
+		}
+		if violation.Ecosystem != "" {
+			dependency += " (" + violation.Ecosystem + ")"
+		}
+		t.AppendRow(table.Row{violation.Policy, dependency, violation.Reason})
+	}
+	t.AppendFooter(table.Row{fmt.Sprintf("%d violations", len(verdict.Violations))})
+	t.Render()
+	_, _ = fmt.Fprintf(mirror, "%s Local policy check failed\n\n", color.RedString("✖"))
+}
@@ -0,0 +1,32 @@
+package policy
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderPassed(t *testing.T) {
+	var output bytes.Buffer
+
+	Verdict{}.Render(&output)
+
+	assert.Contains(t, output.String(), "Local policy check passed")
+}
+
+func TestRenderFailed(t *testing.T) {
+	var output bytes.Buffer
+	verdict := Verdict{Violations: []Violation{
+		{Policy: PolicyDeny, Dependency: "request", Version: "2.88.2", Ecosystem: "npm", Reason: "denied by pkg:npm/request"},
+		{Policy: PolicyLicense, Dependency: "gpl-lib", Reason: "license GPL-3.0 is banned"},
+	}}
+
+	verdict.Render(&output)
+
+	assert.Contains(t, output.String(), "request 2.88.2 (npm)")
+	assert.Contains(t, output.String(), "denied by pkg:npm/request")
+	assert.Contains(t, output.String(), "gpl-lib ")
+	assert.Contains(t, output.String(), "2 violations")
+	assert.Contains(t, output.String(), "Local policy check failed")
+}
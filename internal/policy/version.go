@@ -0,0 +1,94 @@
+package policy
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CompareVersions compares two versions segment by segment, returning -1, 0 or 1.
+// Numeric segments are compared as numbers and missing segments count as zero, so 1.2 equals 1.2.0.
+// A pre-release such as 1.0.0-beta is lower than the release it precedes.
+func CompareVersions(a string, b string) int {
+	aRelease, aPreRelease := splitVersion(a)
+	bRelease, bPreRelease := splitVersion(b)
+	if c := compareSegments(aRelease, bRelease, true); c != 0 {
+		return c
+	}
+	switch {
+	case aPreRelease == "" && bPreRelease == "":
+		return 0
+	case aPreRelease == "":
+		return 1
+	case bPreRelease == "":
+		return -1
+	}
+
+	return compareSegments(aPreRelease, bPreRelease, false)
+}
+
+// splitVersion strips a leading v and build metadata, and splits the release from the pre-release
+func splitVersion(version string) (string, string) {
+	version = strings.TrimPrefix(strings.TrimSpace(version), "v")
+	if i := strings.Index(version, "+"); i >= 0 {
+		version = version[:i]
+	}
+	if i := strings.Index(version, "-"); i >= 0 {
+		return version[:i], version[i+1:]
+	}
+
+	return version, ""
+}
+
+func compareSegments(a string, b string, padWithZero bool) int {
+	aSegments := strings.FieldsFunc(a, isSeparator)
+	bSegments := strings.FieldsFunc(b, isSeparator)
+	for i := 0; i < len(aSegments) || i < len(bSegments); i++ {
+		if !padWithZero && (i >= len(aSegments) || i >= len(bSegments)) {
+			return compareInts(len(aSegments), len(bSegments))
+		}
+		if c := compareSegment(segment(aSegments, i), segment(bSegments, i)); c != 0 {
+			return c
+		}
+	}
+
+	return 0
+}
+
+func segment(segments []string, i int) string {
+	if i < len(segments) {
+		return segments[i]
+	}
+
+	return "0"
+}
+
+func compareSegment(a string, b string) int {
+	aNumber, aErr := strconv.ParseUint(a, 10, 64)
+	bNumber, bErr := strconv.ParseUint(b, 10, 64)
+	switch {
+	case aErr == nil && bErr == nil:
+		return compareInts(int(aNumber), int(bNumber))
+	case aErr == nil:
+		// Numeric segments are lower than alphanumeric ones, as in semver pre-releases
+		return -1
+	case bErr == nil:
+		return 1
+	}
+
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+func compareInts(a int, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+
+	return 0
+}
+
+func isSeparator(r rune) bool {
+	return r == '.' || r == '-' || r == '_'
+}
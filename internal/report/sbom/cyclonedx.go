@@ -0,0 +1,80 @@
+package sbom
+
+const (
+	cycloneDXFormat      = "CycloneDX"
+	cycloneDXSpecVersion = "1.5"
+	cycloneDXRootRef     = "root"
+)
+
+type cycloneDXHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cycloneDXComponent struct {
+	Type    string          `json:"type"`
+	BomRef  string          `json:"bom-ref,omitempty"`
+	Name    string          `json:"name"`
+	Version string          `json:"version,omitempty"`
+	Purl    string          `json:"purl,omitempty"`
+	Hashes  []cycloneDXHash `json:"hashes,omitempty"`
+}
+
+type cycloneDXDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn"`
+}
+
+type cycloneDXMetadata struct {
+	Timestamp string `json:"timestamp"`
+	Tools     struct {
+		Components []cycloneDXComponent `json:"components"`
+	} `json:"tools"`
+	Component cycloneDXComponent `json:"component"`
+}
+
+type cycloneDXDocument struct {
+	BomFormat    string                `json:"bomFormat"`
+	SpecVersion  string                `json:"specVersion"`
+	SerialNumber string                `json:"serialNumber"`
+	Version      int                   `json:"version"`
+	Metadata     cycloneDXMetadata     `json:"metadata"`
+	Components   []cycloneDXComponent  `json:"components"`
+	Dependencies []cycloneDXDependency `json:"dependencies"`
+}
+
+// newCycloneDXDocument builds a CycloneDX 1.5 document, using purls as bom-refs
+func newCycloneDXDocument(graph dependencyGraph) cycloneDXDocument {
+	document := cycloneDXDocument{
+		BomFormat:    cycloneDXFormat,
+		SpecVersion:  cycloneDXSpecVersion,
+		SerialNumber: "urn:uuid:" + graph.id,
+		Version:      1,
+		Components:   []cycloneDXComponent{},
+		Dependencies: []cycloneDXDependency{{Ref: cycloneDXRootRef, DependsOn: graph.direct}},
+	}
+	document.Metadata.Timestamp = graph.timestamp
+	document.Metadata.Tools.Components = []cycloneDXComponent{{Type: "application", Name: toolName}}
+	document.Metadata.Component = cycloneDXComponent{Type: "application", BomRef: cycloneDXRootRef, Name: graph.name}
+
+	for _, node := range graph.nodes {
+		component := cycloneDXComponent{
+			Type:    "library",
+			BomRef:  node.Purl,
+			Name:    node.Name,
+			Version: node.Version,
+			Purl:    node.Purl,
+		}
+		for _, hash := range node.Hashes {
+			component.Hashes = append(component.Hashes, cycloneDXHash{Alg: hash.Algorithm, Content: hash.Value})
+		}
+		document.Components = append(document.Components, component)
+		dependsOn := node.Dependencies
+		if dependsOn == nil {
+			dependsOn = []string{}
+		}
+		document.Dependencies = append(document.Dependencies, cycloneDXDependency{Ref: node.Purl, DependsOn: dependsOn})
+	}
+
+	return document
+}
@@ -0,0 +1,159 @@
+package sbom
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/debricked/cli/internal/inventory"
+	internalIO "github.com/debricked/cli/internal/io"
+	"github.com/debricked/cli/internal/report"
+)
+
+const toolName = "debricked-cli"
+
+var ErrUnsupportedFormat = errors.New("unsupported SBOM format. Supported formats are: 'CycloneDX', 'SPDX'")
+
+type LocalOrderArgs struct {
+	Inventory *inventory.Inventory
+	// Name is the name of the described project, typically the scanned directory
+	Name   string
+	Format string
+	Output string
+}
+
+// LocalReporter generates SBOMs from a dependency inventory without contacting Debricked
+type LocalReporter struct {
+	FileWriter internalIO.IFileWriter
+}
+
+func (r LocalReporter) Order(args report.IOrderArgs) error {
+	orderArgs, ok := args.(LocalOrderArgs)
+	if !ok || orderArgs.Inventory == nil {
+		return ErrHandleArgs
+	}
+
+	sbom, err := Generate(orderArgs.Inventory, orderArgs.Name, orderArgs.Format)
+	if err != nil {
+		return err
+	}
+
+	filename := orderArgs.Output
+	if filename == "" {
+		filename = orderArgs.Name + fileEnding(normalizeFormat(orderArgs.Format))
+	}
+	file, err := r.FileWriter.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer r.FileWriter.Close(file)
+
+	return r.FileWriter.Write(file, sbom)
+}
+
+// Generate creates a CycloneDX 1.5 or SPDX 2.3 JSON document describing inv
+func Generate(inv *inventory.Inventory, name string, format string) ([]byte, error) {
+	id, err := newUUID()
+	if err != nil {
+		return nil, err
+	}
+	graph := newDependencyGraph(inv, name, id, time.Now().UTC().Format(time.RFC3339))
+
+	var document interface{}
+	switch normalizeFormat(format) {
+	case cycloneDXFormat:
+		document = newCycloneDXDocument(graph)
+	case spdxFormat:
+		document = newSpdxDocument(graph)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// normalizeFormat maps format case insensitively to the canonical format name, an empty format defaults to CycloneDX
+func normalizeFormat(format string) string {
+	switch {
+	case format == "" || strings.EqualFold(format, cycloneDXFormat):
+		return cycloneDXFormat
+	case strings.EqualFold(format, spdxFormat):
+		return spdxFormat
+	default:
+		return format
+	}
+}
+
+// dependencyGraph is the format independent content of an SBOM
+type dependencyGraph struct {
+	id        string
+	name      string
+	timestamp string
+	// nodes holds one dependency per purl, merged across all lock files
+	nodes []inventory.Dependency
+	// direct holds the purls of dependencies declared directly by the project
+	direct []string
+}
+
+func newDependencyGraph(inv *inventory.Inventory, name string, id string, timestamp string) dependencyGraph {
+	graph := dependencyGraph{id: id, name: name, timestamp: timestamp, direct: []string{}}
+	index := map[string]int{}
+	for _, dependency := range inv.Dependencies {
+		i, ok := index[dependency.Purl]
+		if !ok {
+			index[dependency.Purl] = len(graph.nodes)
+			dependency.Dependencies = append([]string(nil), dependency.Dependencies...)
+			graph.nodes = append(graph.nodes, dependency)
+
+			continue
+		}
+		node := &graph.nodes[i]
+		node.Direct = node.Direct || dependency.Direct
+		if len(node.Hashes) == 0 {
+			node.Hashes = dependency.Hashes
+		}
+		for _, child := range dependency.Dependencies {
+			if !contains(node.Dependencies, child) {
+				node.Dependencies = append(node.Dependencies, child)
+			}
+		}
+	}
+
+	sort.Slice(graph.nodes, func(i, j int) bool {
+		return graph.nodes[i].Purl < graph.nodes[j].Purl
+	})
+	for i := range graph.nodes {
+		sort.Strings(graph.nodes[i].Dependencies)
+		if graph.nodes[i].Direct {
+			graph.direct = append(graph.direct, graph.nodes[i].Purl)
+		}
+	}
+
+	return graph
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// newUUID returns a random version 4 UUID
+func newUUID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
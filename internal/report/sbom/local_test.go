@@ -0,0 +1,154 @@
+package sbom
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/debricked/cli/internal/inventory"
+	ioTestData "github.com/debricked/cli/internal/io/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	purlA = "pkg:npm/a@1.0.0"
+	purlB = "pkg:npm/b@2.0.0"
+	purlC = "pkg:maven/g/c@3.0.0"
+)
+
+func newTestInventory() *inventory.Inventory {
+	return &inventory.Inventory{
+		Dependencies: []inventory.Dependency{
+			{
+				Name:         "a",
+				Version:      "1.0.0",
+				Ecosystem:    inventory.EcosystemNpm,
+				Purl:         purlA,
+				Direct:       true,
+				SourceFile:   "package-lock.json",
+				Hashes:       []inventory.Hash{{Algorithm: inventory.HashSHA1, Value: "da39a3ee5e6b4b0d3255bfef95601890afd80709"}},
+				Dependencies: []string{purlB},
+			},
+			{Name: "b", Version: "2.0.0", Ecosystem: inventory.EcosystemNpm, Purl: purlB, SourceFile: "package-lock.json"},
+			{Name: "b", Version: "2.0.0", Ecosystem: inventory.EcosystemNpm, Purl: purlB, Direct: true, SourceFile: "sub/package-lock.json"},
+			{Name: "g:c", Version: "3.0.0", Ecosystem: inventory.EcosystemMaven, Purl: purlC, SourceFile: "maven.debricked.lock"},
+		},
+	}
+}
+
+func TestGenerateCycloneDX(t *testing.T) {
+	content, err := Generate(newTestInventory(), "app", "cyclonedx")
+	assert.NoError(t, err)
+
+	var document cycloneDXDocument
+	assert.NoError(t, json.Unmarshal(content, &document))
+	assert.Equal(t, "CycloneDX", document.BomFormat)
+	assert.Equal(t, "1.5", document.SpecVersion)
+	assert.Regexp(t, regexp.MustCompile(`^urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`), document.SerialNumber)
+	assert.Equal(t, "app", document.Metadata.Component.Name)
+	assert.NotEmpty(t, document.Metadata.Timestamp)
+
+	assert.Len(t, document.Components, 3)
+	assert.Equal(t, purlC, document.Components[0].Purl)
+	assert.Equal(t, purlA, document.Components[1].BomRef)
+	assert.Equal(t, []cycloneDXHash{{Alg: "SHA-1", Content: "da39a3ee5e6b4b0d3255bfef95601890afd80709"}}, document.Components[1].Hashes)
+
+	assert.Equal(t, []cycloneDXDependency{
+		{Ref: "root", DependsOn: []string{purlA, purlB}},
+		{Ref: purlC, DependsOn: []string{}},
+		{Ref: purlA, DependsOn: []string{purlB}},
+		{Ref: purlB, DependsOn: []string{}},
+	}, document.Dependencies)
+}
+
+func TestGenerateSPDX(t *testing.T) {
+	content, err := Generate(newTestInventory(), "app", "SPDX")
+	assert.NoError(t, err)
+
+	var document spdxDocument
+	assert.NoError(t, json.Unmarshal(content, &document))
+	assert.Equal(t, "SPDX-2.3", document.SpdxVersion)
+	assert.Equal(t, "SPDXRef-DOCUMENT", document.SPDXID)
+	assert.Contains(t, document.DocumentNamespace, "https://debricked.com/spdxdocs/app-")
+	assert.Equal(t, []string{"Tool: debricked-cli"}, document.CreationInfo.Creators)
+
+	assert.Len(t, document.Packages, 4)
+	assert.Equal(t, "SPDXRef-RootPackage", document.Packages[0].SPDXID)
+	pkgA := document.Packages[2]
+	assert.Equal(t, "SPDXRef-Package-2", pkgA.SPDXID)
+	assert.Equal(t, "1.0.0", pkgA.VersionInfo)
+	assert.Equal(t, []spdxChecksum{{Algorithm: "SHA1", ChecksumValue: "da39a3ee5e6b4b0d3255bfef95601890afd80709"}}, pkgA.Checksums)
+	assert.Equal(t, []spdxExternalRef{{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: purlA}}, pkgA.ExternalRefs)
+
+	assert.Equal(t, []spdxRelationship{
+		{SpdxElementId: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSpdxElement: "SPDXRef-RootPackage"},
+		{SpdxElementId: "SPDXRef-RootPackage", RelationshipType: "DEPENDS_ON", RelatedSpdxElement: "SPDXRef-Package-2"},
+		{SpdxElementId: "SPDXRef-RootPackage", RelationshipType: "DEPENDS_ON", RelatedSpdxElement: "SPDXRef-Package-3"},
+		{SpdxElementId: "SPDXRef-Package-2", RelationshipType: "DEPENDS_ON", RelatedSpdxElement: "SPDXRef-Package-3"},
+	}, document.Relationships)
+}
+
+func TestGenerateUnsupportedFormat(t *testing.T) {
+	_, err := Generate(newTestInventory(), "app", "xml")
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestGenerateEmptyInventory(t *testing.T) {
+	content, err := Generate(&inventory.Inventory{}, "app", "")
+	assert.NoError(t, err)
+
+	var document cycloneDXDocument
+	assert.NoError(t, json.Unmarshal(content, &document))
+	assert.Empty(t, document.Components)
+	assert.Equal(t, []cycloneDXDependency{{Ref: "root", DependsOn: []string{}}}, document.Dependencies)
+}
+
+func TestLocalOrder(t *testing.T) {
+	fileWriterMock := &ioTestData.FileWriterMock{}
+	reporter := LocalReporter{FileWriter: fileWriterMock}
+
+	err := reporter.Order(LocalOrderArgs{Inventory: newTestInventory(), Name: "app", Format: "SPDX"})
+	assert.NoError(t, err)
+	assert.Contains(t, string(fileWriterMock.Contents), `"spdxVersion": "SPDX-2.3"`)
+}
+
+func TestLocalOrderArgsError(t *testing.T) {
+	reporter := LocalReporter{FileWriter: &ioTestData.FileWriterMock{}}
+
+	assert.ErrorIs(t, reporter.Order(OrderArgs{}), ErrHandleArgs)
+	assert.ErrorIs(t, reporter.Order(LocalOrderArgs{}), ErrHandleArgs)
+}
+
+func TestLocalOrderCreateError(t *testing.T) {
+	createErr := errors.New("create error")
+	reporter := LocalReporter{FileWriter: &ioTestData.FileWriterMock{CreateErr: createErr}}
+
+	err := reporter.Order(LocalOrderArgs{Inventory: newTestInventory(), Name: "app"})
+	assert.ErrorIs(t, err, createErr)
+}
+
+func TestNormalizeFormat(t *testing.T) {
+	assert.Equal(t, "CycloneDX", normalizeFormat(""))
+	assert.Equal(t, "CycloneDX", normalizeFormat("CYCLONEDX"))
+	assert.Equal(t, "SPDX", normalizeFormat("spdx"))
+	assert.Equal(t, "xml", normalizeFormat("xml"))
+}
+
+func TestNewSpdxDocumentMissingPackages(t *testing.T) {
+	graph := dependencyGraph{
+		id:   "id",
+		name: "my app",
+		nodes: []inventory.Dependency{
+			{Name: "a", Version: "1", Purl: "pkg:npm/a@1", Dependencies: []string{"pkg:npm/missing@1"}},
+		},
+		direct: []string{"pkg:npm/a@1", "pkg:npm/gone@1"},
+	}
+	document := newSpdxDocument(graph)
+
+	assert.Equal(t, "https://debricked.com/spdxdocs/my%20app-id", document.DocumentNamespace)
+	assert.Equal(t, []spdxRelationship{
+		{SpdxElementId: "SPDXRef-DOCUMENT", RelationshipType: "DESCRIBES", RelatedSpdxElement: "SPDXRef-RootPackage"},
+		{SpdxElementId: "SPDXRef-RootPackage", RelationshipType: "DEPENDS_ON", RelatedSpdxElement: "SPDXRef-Package-1"},
+	}, document.Relationships)
+}
@@ -0,0 +1,132 @@
+package sbom
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	spdxFormat          = "SPDX"
+	spdxVersion         = "SPDX-2.3"
+	spdxDocumentId      = "SPDXRef-DOCUMENT"
+	spdxRootPackageId   = "SPDXRef-RootPackage"
+	spdxNoAssertion     = "NOASSERTION"
+	spdxNamespacePrefix = "https://debricked.com/spdxdocs/"
+)
+
+type spdxChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+type spdxPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo,omitempty"`
+	DownloadLocation string            `json:"downloadLocation"`
+	FilesAnalyzed    bool              `json:"filesAnalyzed"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	LicenseDeclared  string            `json:"licenseDeclared"`
+	CopyrightText    string            `json:"copyrightText"`
+	Checksums        []spdxChecksum    `json:"checksums,omitempty"`
+	ExternalRefs     []spdxExternalRef `json:"externalRefs,omitempty"`
+}
+
+type spdxRelationship struct {
+	SpdxElementId      string `json:"spdxElementId"`
+	RelationshipType   string `json:"relationshipType"`
+	RelatedSpdxElement string `json:"relatedSpdxElement"`
+}
+
+type spdxDocument struct {
+	SpdxVersion       string `json:"spdxVersion"`
+	DataLicense       string `json:"dataLicense"`
+	SPDXID            string `json:"SPDXID"`
+	Name              string `json:"name"`
+	DocumentNamespace string `json:"documentNamespace"`
+	CreationInfo      struct {
+		Created  string   `json:"created"`
+		Creators []string `json:"creators"`
+	} `json:"creationInfo"`
+	Packages      []spdxPackage      `json:"packages"`
+	Relationships []spdxRelationship `json:"relationships"`
+}
+
+func newSpdxPackage(id string, name string) spdxPackage {
+	return spdxPackage{
+		SPDXID:           id,
+		Name:             name,
+		DownloadLocation: spdxNoAssertion,
+		LicenseConcluded: spdxNoAssertion,
+		LicenseDeclared:  spdxNoAssertion,
+		CopyrightText:    spdxNoAssertion,
+	}
+}
+
+// newSpdxDocument builds an SPDX 2.3 document where the root package depends on the direct dependencies
+func newSpdxDocument(graph dependencyGraph) spdxDocument {
+	document := spdxDocument{
+		SpdxVersion:       spdxVersion,
+		DataLicense:       "CC0-1.0",
+		SPDXID:            spdxDocumentId,
+		Name:              graph.name,
+		DocumentNamespace: spdxNamespacePrefix + url.PathEscape(graph.name) + "-" + graph.id,
+		Packages:          []spdxPackage{newSpdxPackage(spdxRootPackageId, graph.name)},
+		Relationships: []spdxRelationship{
+			{SpdxElementId: spdxDocumentId, RelationshipType: "DESCRIBES", RelatedSpdxElement: spdxRootPackageId},
+		},
+	}
+	document.CreationInfo.Created = graph.timestamp
+	document.CreationInfo.Creators = []string{"Tool: " + toolName}
+
+	ids := map[string]string{}
+	for i, node := range graph.nodes {
+		ids[node.Purl] = fmt.Sprintf("SPDXRef-Package-%d", i+1)
+	}
+	for _, node := range graph.nodes {
+		pkg := newSpdxPackage(ids[node.Purl], node.Name)
+		pkg.VersionInfo = node.Version
+		pkg.ExternalRefs = []spdxExternalRef{
+			{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: node.Purl},
+		}
+		for _, hash := range node.Hashes {
+			// SPDX spells algorithms without the dash used by CycloneDX, e.g. SHA256
+			pkg.Checksums = append(pkg.Checksums, spdxChecksum{
+				Algorithm:     strings.ReplaceAll(hash.Algorithm, "-", ""),
+				ChecksumValue: hash.Value,
+			})
+		}
+		document.Packages = append(document.Packages, pkg)
+	}
+
+	for _, purl := range graph.direct {
+		document.Relationships = appendDependsOn(document.Relationships, spdxRootPackageId, ids[purl])
+	}
+	for _, node := range graph.nodes {
+		for _, child := range node.Dependencies {
+			document.Relationships = appendDependsOn(document.Relationships, ids[node.Purl], ids[child])
+		}
+	}
+
+	return document
+}
+
+// appendDependsOn skips dependencies without a package, which would leave dangling relationships
+func appendDependsOn(relationships []spdxRelationship, id string, relatedId string) []spdxRelationship {
+	if relatedId == "" {
+		return relationships
+	}
+
+	return append(relationships, spdxRelationship{
+		SpdxElementId:      id,
+		RelationshipType:   "DEPENDS_ON",
+		RelatedSpdxElement: relatedId,
+	})
+}
@@ -0,0 +1,224 @@
+// Package cache reuses lock files of earlier resolutions when neither the manifest file, the files related to it nor
+// the version of the package manager have changed since.
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// keyVersion is part of every key, so that it can be bumped to invalidate entries when what they depend on changes
+const keyVersion = "1"
+
+const entryFile = "entry.json"
+
+var ErrNotCacheable = errors.New("resolution isn't cacheable")
+
+type ICache interface {
+	// Key returns the key of the resolution of manifestFile by pm, or ErrNotCacheable if it can't be cached
+	Key(pm string, manifestFile string) (string, error)
+	// Restore copies the lock files cached for key next to manifestFile, returning the lock files copied
+	Restore(key string, manifestFile string) ([]string, error)
+	// Store caches the lock files written by the resolution of manifestFile by pm since the Unix time since
+	Store(key string, pm string, manifestFile string, since int64) error
+}
+
+type Cache struct {
+	dir string
+	// images replaces versions of package managers, which run in the images rather than on the host
+	images    map[string]string
+	versions  map[string]string
+	versionMu sync.Mutex
+}
+
+// entry lists the lock files of a cached resolution, relative to the directory of the manifest file
+type entry struct {
+	LockFiles []string `json:"lockFiles"`
+}
+
+// NewCache creates a cache in dir. images are set if package managers run in containers of the images, by package
+// manager name.
+func NewCache(dir string, images map[string]string) *Cache {
+	return &Cache{
+		dir:      dir,
+		images:   images,
+		versions: map[string]string{},
+	}
+}
+
+// DefaultDir returns the directory used unless the cache dir is configured
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "debricked", "resolution"), nil
+}
+
+func IsCacheable(pm string) bool {
+	_, ok := specs[pm]
+
+	return ok
+}
+
+func (cache *Cache) Key(pm string, manifestFile string) (string, error) {
+	s, ok := specs[pm]
+	if !ok || (s.cacheable != nil && !s.cacheable(manifestFile)) {
+		return "", ErrNotCacheable
+	}
+	inputs, err := s.inputs(manifestFile)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.New()
+	_, _ = fmt.Fprintf(hash, "%s\n%s\n%s\n", keyVersion, pm, cache.version(pm, s))
+	dir := filepath.Dir(manifestFile)
+	for _, input := range inputs {
+		content, err := os.ReadFile(input)
+		if err != nil {
+			return "", err
+		}
+		rel, err := filepath.Rel(dir, input)
+		if err != nil {
+			return "", err
+		}
+		_, _ = fmt.Fprintf(hash, "%s %x\n", filepath.ToSlash(rel), sha256.Sum256(content))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func (cache *Cache) Restore(key string, manifestFile string) ([]string, error) {
+	entryDir := filepath.Join(cache.dir, key)
+	content, err := os.ReadFile(filepath.Join(entryDir, entryFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var e entry
+	if err = json.Unmarshal(content, &e); err != nil || len(e.LockFiles) == 0 {
+		return nil, err
+	}
+
+	dir := filepath.Dir(manifestFile)
+	var lockFiles []string
+	for _, lockFile := range e.LockFiles {
+		rel := filepath.FromSlash(lockFile)
+		err = copyFile(filepath.Join(entryDir, rel), filepath.Join(dir, rel))
+		if err != nil {
+			return nil, err
+		}
+		lockFiles = append(lockFiles, filepath.Join(dir, rel))
+	}
+
+	return lockFiles, nil
+}
+
+func (cache *Cache) Store(key string, pm string, manifestFile string, since int64) error {
+	s, ok := specs[pm]
+	if !ok {
+		return ErrNotCacheable
+	}
+	lockFiles, err := s.lockFiles(manifestFile, since)
+	if err != nil || len(lockFiles) == 0 {
+		return err
+	}
+
+	// Entries are written to a temporary directory first, so that concurrent runs never see partial entries
+	err = os.MkdirAll(cache.dir, 0o755)
+	if err != nil {
+		return err
+	}
+	tmpDir, err := os.MkdirTemp(cache.dir, key+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	dir := filepath.Dir(manifestFile)
+	e := entry{}
+	for _, lockFile := range lockFiles {
+		rel, err := filepath.Rel(dir, lockFile)
+		if err != nil {
+			return err
+		}
+		if err = copyFile(lockFile, filepath.Join(tmpDir, rel)); err != nil {
+			return err
+		}
+		e.LockFiles = append(e.LockFiles, filepath.ToSlash(rel))
+	}
+	content, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	if err = os.WriteFile(filepath.Join(tmpDir, entryFile), content, 0o600); err != nil {
+		return err
+	}
+
+	entryDir := filepath.Join(cache.dir, key)
+	if err = os.RemoveAll(entryDir); err != nil {
+		return err
+	}
+
+	return os.Rename(tmpDir, entryDir)
+}
+
+// version returns the version of the package manager, or its image if it runs in a container
+func (cache *Cache) version(pm string, s spec) string {
+	if image, ok := cache.images[pm]; ok {
+		return "image " + image
+	}
+	if len(s.version) == 0 {
+		return ""
+	}
+
+	cache.versionMu.Lock()
+	defer cache.versionMu.Unlock()
+	if version, ok := cache.versions[pm]; ok {
+		return version
+	}
+	output, err := exec.Command(s.version[0], s.version[1:]...).Output()
+	version := strings.TrimSpace(string(output))
+	if err != nil {
+		// The version is unknown if the package manager is missing, such as when the Gradle wrapper is used
+		version = "unknown"
+	}
+	cache.versions[pm] = version
+
+	return version
+}
+
+func copyFile(source string, destination string) error {
+	in, err := os.Open(source)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	if err = os.MkdirAll(filepath.Dir(destination), 0o755); err != nil {
+		return err
+	}
+	out, err := os.Create(destination)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		_ = out.Close()
+
+		return err
+	}
+
+	return out.Close()
+}
@@ -0,0 +1,139 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// makeRepository creates a git repository with a parent pom and a module pom, returning the module pom
+func makeRepository(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(root, ".git"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(root, "pom.xml"), []byte("<project>parent</project>"), 0o600))
+	assert.NoError(t, os.Mkdir(filepath.Join(root, "app"), 0o755))
+	pom := filepath.Join(root, "app", "pom.xml")
+	assert.NoError(t, os.WriteFile(pom, []byte("<project>app</project>"), 0o600))
+
+	return pom
+}
+
+func TestKey(t *testing.T) {
+	pom := makeRepository(t)
+	cache := NewCache(t.TempDir(), map[string]string{"mvn": "maven:3"})
+
+	key, err := cache.Key("mvn", pom)
+	assert.NoError(t, err)
+	assert.Len(t, key, 64)
+
+	sameKey, err := cache.Key("mvn", pom)
+	assert.NoError(t, err)
+	assert.Equal(t, key, sameKey)
+
+	parentPom := filepath.Join(filepath.Dir(filepath.Dir(pom)), "pom.xml")
+	assert.NoError(t, os.WriteFile(parentPom, []byte("<project>changed parent</project>"), 0o600))
+	parentChangedKey, err := cache.Key("mvn", pom)
+	assert.NoError(t, err)
+	assert.NotEqual(t, key, parentChangedKey)
+
+	otherImageKey, err := NewCache(t.TempDir(), map[string]string{"mvn": "maven:4"}).Key("mvn", pom)
+	assert.NoError(t, err)
+	assert.NotEqual(t, parentChangedKey, otherImageKey)
+}
+
+func TestKeyNotCacheable(t *testing.T) {
+	_, err := NewCache(t.TempDir(), nil).Key("npm", "package.json")
+	assert.ErrorIs(t, err, ErrNotCacheable)
+}
+
+func TestKeyMissingManifest(t *testing.T) {
+	cache := NewCache(t.TempDir(), map[string]string{"mvn": "maven:3"})
+	_, err := cache.Key("mvn", filepath.Join(t.TempDir(), "pom.xml"))
+	assert.Error(t, err)
+}
+
+func TestStoreAndRestore(t *testing.T) {
+	dir := t.TempDir()
+	buildFile := filepath.Join(dir, "build.gradle")
+	assert.NoError(t, os.WriteFile(buildFile, []byte("plugins {}"), 0o600))
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "lib"), 0o755))
+	lockFiles := map[string]string{
+		filepath.Join(dir, "gradle.debricked.lock"):        "root",
+		filepath.Join(dir, "lib", "gradle.debricked.lock"): "lib",
+	}
+	for lockFile, content := range lockFiles {
+		assert.NoError(t, os.WriteFile(lockFile, []byte(content), 0o600))
+	}
+	cache := NewCache(t.TempDir(), map[string]string{"gradle": "gradle:8"})
+
+	assert.NoError(t, cache.Store("key", "gradle", buildFile, 0))
+	for lockFile := range lockFiles {
+		assert.NoError(t, os.Remove(lockFile))
+	}
+
+	restored, err := cache.Restore("key", buildFile)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(dir, "gradle.debricked.lock"),
+		filepath.Join(dir, "lib", "gradle.debricked.lock"),
+	}, restored)
+	for lockFile, expected := range lockFiles {
+		content, err := os.ReadFile(lockFile)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, string(content))
+	}
+}
+
+func TestStoreSkipsOldLockFiles(t *testing.T) {
+	dir := t.TempDir()
+	pom := filepath.Join(dir, "pom.xml")
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "maven.debricked.lock"), []byte("old"), 0o600))
+	cache := NewCache(t.TempDir(), nil)
+
+	assert.NoError(t, cache.Store("key", "mvn", pom, 1<<40))
+
+	restored, err := cache.Restore("key", pom)
+	assert.NoError(t, err)
+	assert.Empty(t, restored)
+}
+
+func TestStoreNotCacheable(t *testing.T) {
+	err := NewCache(t.TempDir(), nil).Store("key", "npm", "package.json", 0)
+	assert.ErrorIs(t, err, ErrNotCacheable)
+}
+
+func TestRestoreMiss(t *testing.T) {
+	restored, err := NewCache(t.TempDir(), nil).Restore("key", "pom.xml")
+	assert.NoError(t, err)
+	assert.Empty(t, restored)
+}
+
+func TestRestoreCorruptEntry(t *testing.T) {
+	cacheDir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(cacheDir, "key"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(cacheDir, "key", entryFile), []byte("{"), 0o600))
+
+	restored, err := NewCache(cacheDir, nil).Restore("key", "pom.xml")
+	assert.Error(t, err)
+	assert.Empty(t, restored)
+}
+
+func TestVersionUnknown(t *testing.T) {
+	cache := NewCache(t.TempDir(), nil)
+	s := spec{version: []string{"debricked-non-existing-executable", "--version"}}
+
+	assert.Equal(t, "unknown", cache.version("mvn", s))
+	assert.Equal(t, "unknown", cache.versions["mvn"])
+	assert.Equal(t, "", cache.version("sbt", spec{}))
+}
+
+func TestDefaultDir(t *testing.T) {
+	dir, err := DefaultDir()
+	if err != nil {
+		t.Skip("no user cache directory")
+	}
+	assert.Equal(t, "resolution", filepath.Base(dir))
+}
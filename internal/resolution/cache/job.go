@@ -0,0 +1,132 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+// Job restores the lock files of a job from the cache, and only runs the job if they aren't cached. The lock files
+// written by successful jobs are cached.
+type Job struct {
+	inner    job.IJob
+	pm       string
+	cache    ICache
+	restored []string
+}
+
+func NewJob(inner job.IJob, pm string, cache ICache) *Job {
+	return &Job{
+		inner: inner,
+		pm:    pm,
+		cache: cache,
+	}
+}
+
+func (j *Job) GetFile() string {
+	return j.inner.GetFile()
+}
+
+func (j *Job) Errors() job.IErrors {
+	return j.inner.Errors()
+}
+
+func (j *Job) ReceiveStatus() chan string {
+	return j.inner.ReceiveStatus()
+}
+
+func (j *Job) SetContext(ctx context.Context) {
+	if cancelable, ok := j.inner.(job.ICancelableJob); ok {
+		cancelable.SetContext(ctx)
+	}
+}
+
+// Commands returns the commands run by the inner job, which are none if the lock files were restored
+func (j *Job) Commands() []string {
+	if history, ok := j.inner.(job.ICommandHistory); ok {
+		return history.Commands()
+	}
+
+	return nil
+}
+
+// Restored reports whether the lock files were restored from the cache rather than resolved
+func (j *Job) Restored() bool {
+	return len(j.restored) > 0
+}
+
+// LockFiles returns the lock files restored from the cache, or else those written by the inner job
+func (j *Job) LockFiles() []string {
+	if j.Restored() {
+		return j.restored
+	}
+	if writer, ok := j.inner.(job.ILockFileWriter); ok {
+		return writer.LockFiles()
+	}
+
+	return nil
+}
+
+func (j *Job) Run() {
+	j.sendStatus("looking up cache")
+	key, err := j.cache.Key(j.pm, j.GetFile())
+	if errors.Is(err, ErrNotCacheable) {
+		j.inner.Run()
+
+		return
+	} else if err != nil {
+		j.warn(err, "looking up cache", "Failed to compute the cache key, so the lock file was resolved without the cache.")
+		j.inner.Run()
+
+		return
+	}
+
+	restored, err := j.cache.Restore(key, j.GetFile())
+	if err != nil {
+		j.warn(err, "restoring from cache", "Failed to restore the cached lock file, so the lock file was resolved again.")
+	} else if len(restored) > 0 {
+		j.restored = restored
+		j.sendStatus("restored from cache")
+
+		return
+	}
+
+	since := time.Now().Unix()
+	j.inner.Run()
+	if j.Errors().HasError() {
+		return
+	}
+
+	err = j.cache.Store(key, j.pm, j.GetFile(), since)
+	if err != nil {
+		j.warn(err, "storing in cache", "Failed to cache the lock file, so it will be resolved again next time.")
+	}
+}
+
+func (j *Job) warn(err error, status string, documentation string) {
+	jobErr := util.NewPMJobError(err.Error())
+	jobErr.SetStatus(status)
+	jobErr.SetDocumentation(documentation)
+	jobErr.SetIsCritical(false)
+	j.Errors().Warning(jobErr)
+}
+
+func (j *Job) sendStatus(status string) {
+	j.inner.ReceiveStatus() <- status
+}
+
+// Wrap makes the jobs of pm use cache, unless resolutions of pm aren't cacheable
+func Wrap(jobs []job.IJob, pm string, cache ICache) []job.IJob {
+	if !IsCacheable(pm) {
+		return jobs
+	}
+	cached := make([]job.IJob, 0, len(jobs))
+	for _, j := range jobs {
+		cached = append(cached, NewJob(j, pm, cache))
+	}
+
+	return cached
+}
@@ -0,0 +1,165 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/stretchr/testify/assert"
+)
+
+type lockJob struct {
+	job.BaseJob
+	runs int
+	fail bool
+}
+
+func newLockJob(file string) *lockJob {
+	return &lockJob{BaseJob: job.NewBaseJob(file)}
+}
+
+func (j *lockJob) Run() {
+	j.runs++
+	if j.fail {
+		j.Errors().Critical(util.NewPMJobError("mvn failed"))
+
+		return
+	}
+	lockFile := filepath.Join(filepath.Dir(j.GetFile()), "maven.debricked.lock")
+	_ = os.WriteFile(lockFile, []byte("resolved"), 0o600)
+	j.AddLockFile(lockFile)
+}
+
+type cacheMock struct {
+	keyErr     error
+	restoreErr error
+	storeErr   error
+}
+
+func (c cacheMock) Key(string, string) (string, error) {
+	return "key", c.keyErr
+}
+
+func (c cacheMock) Restore(string, string) ([]string, error) {
+	return nil, c.restoreErr
+}
+
+func (c cacheMock) Store(string, string, string, int64) error {
+	return c.storeErr
+}
+
+func TestRunRestoresCachedLockFile(t *testing.T) {
+	pom := makeRepository(t)
+	lockFile := filepath.Join(filepath.Dir(pom), "maven.debricked.lock")
+	cache := NewCache(t.TempDir(), map[string]string{"mvn": "maven:3"})
+
+	first := newLockJob(pom)
+	j := NewJob(first, "mvn", cache)
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+	assert.False(t, j.Errors().HasError())
+	assert.Equal(t, 1, first.runs)
+	assert.False(t, j.Restored())
+	assert.Equal(t, []string{lockFile}, j.LockFiles())
+
+	assert.NoError(t, os.Remove(lockFile))
+	second := newLockJob(pom)
+	j = NewJob(second, "mvn", cache)
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.Equal(t, 0, second.runs)
+	assert.True(t, j.Restored())
+	assert.Empty(t, j.Commands())
+	assert.FileExists(t, lockFile)
+	assert.Equal(t, []string{lockFile}, j.LockFiles())
+
+	assert.NoError(t, os.WriteFile(pom, []byte("<project>changed</project>"), 0o600))
+	third := newLockJob(pom)
+	j = NewJob(third, "mvn", cache)
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Equal(t, 1, third.runs)
+}
+
+func TestRunDoesNotCacheFailedJob(t *testing.T) {
+	pom := makeRepository(t)
+	cache := NewCache(t.TempDir(), map[string]string{"mvn": "maven:3"})
+	inner := newLockJob(pom)
+	inner.fail = true
+	j := NewJob(inner, "mvn", cache)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	key, _ := cache.Key("mvn", pom)
+	restored, err := cache.Restore(key, pom)
+	assert.NoError(t, err)
+	assert.Empty(t, restored)
+}
+
+func TestRunCacheErrs(t *testing.T) {
+	cases := []struct {
+		name   string
+		cache  cacheMock
+		status string
+	}{
+		{name: "Key error", cache: cacheMock{keyErr: errors.New("key-error")}, status: "looking up cache"},
+		{name: "Restore error", cache: cacheMock{restoreErr: errors.New("restore-error")}, status: "restoring from cache"},
+		{name: "Store error", cache: cacheMock{storeErr: errors.New("store-error")}, status: "storing in cache"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			inner := newLockJob(filepath.Join(t.TempDir(), "pom.xml"))
+			j := NewJob(inner, "mvn", c.cache)
+
+			go jobTestdata.WaitStatus(j)
+			j.Run()
+
+			assert.Equal(t, 1, inner.runs)
+			assert.Empty(t, j.Errors().GetCriticalErrors())
+			warnings := j.Errors().GetWarningErrors()
+			assert.Len(t, warnings, 1)
+			assert.Equal(t, c.status, warnings[0].Status())
+		})
+	}
+}
+
+func TestRunNotCacheable(t *testing.T) {
+	inner := newLockJob(filepath.Join(t.TempDir(), "requirements.txt"))
+	j := NewJob(inner, "pip", cacheMock{keyErr: ErrNotCacheable})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Equal(t, 1, inner.runs)
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestWrap(t *testing.T) {
+	jobs := []job.IJob{newLockJob("pom.xml")}
+	cache := NewCache(t.TempDir(), nil)
+
+	assert.IsType(t, &Job{}, Wrap(jobs, "mvn", cache)[0])
+	assert.IsType(t, &lockJob{}, Wrap(jobs, "npm", cache)[0])
+}
+
+func TestSetContext(t *testing.T) {
+	inner := newLockJob("pom.xml")
+	j := NewJob(inner, "mvn", cacheMock{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	j.SetContext(ctx)
+
+	assert.ErrorIs(t, inner.Sandboxed(exec.Command("echo")).Run(), context.Canceled)
+}
@@ -0,0 +1,208 @@
+package cache
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/pm/bower"
+	"github.com/debricked/cli/internal/resolution/pm/gomod"
+	"github.com/debricked/cli/internal/resolution/pm/gradle"
+	"github.com/debricked/cli/internal/resolution/pm/maven"
+	"github.com/debricked/cli/internal/resolution/pm/pip"
+	"github.com/debricked/cli/internal/resolution/pm/sbt"
+)
+
+// spec describes what a resolution of a package manager depends on, and which lock files it writes
+type spec struct {
+	// siblings are globs, relative to the directory of the manifest file, of files the resolution depends on
+	siblings []string
+	// ancestors are file names in the directories above the manifest file, such as parent poms and workspace roots
+	ancestors []string
+	// descendants matches names of files below the directory of the manifest file, such as Gradle subprojects
+	descendants *regexp.Regexp
+	// lockFile is the name of the lock files written, where %s is replaced by the name of the manifest file
+	lockFile string
+	// recursive lock files are written below the directory of the manifest file as well
+	recursive bool
+	// version is the command printing the version of the package manager
+	version []string
+	// cacheable reports whether the resolution of a manifest file can be cached, unless nil
+	cacheable func(manifestFile string) bool
+}
+
+var specs = map[string]spec{
+	maven.Name: {
+		siblings:  []string{".mvn/maven.config", ".mvn/extensions.xml"},
+		ancestors: []string{"pom.xml"},
+		lockFile:  "maven.debricked.lock",
+		version:   []string{"mvn", "--version"},
+	},
+	gradle.Name: {
+		siblings:    []string{"gradle/libs.versions.toml", "gradle/wrapper/gradle-wrapper.properties"},
+		ancestors:   []string{"settings.gradle", "settings.gradle.kts", "gradle.properties"},
+		descendants: regexp.MustCompile(`^(.*\.gradle|.*\.gradle\.kts|gradle\.properties)$`),
+		lockFile:    "gradle.debricked.lock",
+		recursive:   true,
+		version:     []string{"gradle", "--version"},
+	},
+	sbt.Name: {
+		siblings: []string{"*.sbt", "project/*.sbt", "project/*.scala", "project/build.properties"},
+		lockFile: "maven.debricked.lock",
+	},
+	pip.Name: {
+		lockFile:  "%s.pip.debricked.lock",
+		version:   []string{"pip", "--version"},
+		cacheable: isPinnedRequirements,
+	},
+	gomod.Name: {
+		siblings:  []string{"go.sum"},
+		ancestors: []string{"go.work", "go.work.sum"},
+		lockFile:  "gomod.debricked.lock",
+		version:   []string{"go", "version"},
+	},
+	bower.Name: {
+		siblings: []string{".bowerrc"},
+		lockFile: "bower.debricked.lock",
+		version:  []string{"bower", "--version"},
+	},
+}
+
+// inputs returns the files that the resolution of manifestFile depends on, sorted and including manifestFile itself
+func (s spec) inputs(manifestFile string) ([]string, error) {
+	dir := filepath.Dir(manifestFile)
+	files := map[string]bool{manifestFile: true}
+	for _, sibling := range s.siblings {
+		matches, err := filepath.Glob(filepath.Join(dir, filepath.FromSlash(sibling)))
+		if err != nil {
+			return nil, err
+		}
+		for _, match := range matches {
+			files[match] = true
+		}
+	}
+	for _, ancestor := range ancestorDirs(dir) {
+		for _, name := range s.ancestors {
+			file := filepath.Join(ancestor, name)
+			if isFile(file) {
+				files[file] = true
+			}
+		}
+	}
+	if s.descendants != nil {
+		err := walk(dir, func(path string) {
+			if s.descendants.MatchString(filepath.Base(path)) {
+				files[path] = true
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	inputs := make([]string, 0, len(files))
+	for file := range files {
+		inputs = append(inputs, file)
+	}
+	sort.Strings(inputs)
+
+	return inputs, nil
+}
+
+// lockFiles returns the lock files of manifestFile written at or after since
+func (s spec) lockFiles(manifestFile string, since int64) ([]string, error) {
+	dir := filepath.Dir(manifestFile)
+	name := strings.ReplaceAll(s.lockFile, "%s", filepath.Base(manifestFile))
+	var lockFiles []string
+	isWritten := func(path string) {
+		info, err := os.Stat(path)
+		if err == nil && !info.IsDir() && info.ModTime().Unix() >= since {
+			lockFiles = append(lockFiles, path)
+		}
+	}
+	if !s.recursive {
+		isWritten(filepath.Join(dir, name))
+
+		return lockFiles, nil
+	}
+	err := walk(dir, func(path string) {
+		if filepath.Base(path) == name {
+			isWritten(path)
+		}
+	})
+
+	return lockFiles, err
+}
+
+// isPinnedRequirements reports whether every requirement of requirementsFile is pinned to an exact version. Otherwise
+// newer releases would be resolved without requirementsFile changing. Options, such as included files, are assumed
+// to leave requirements unpinned.
+func isPinnedRequirements(requirementsFile string) bool {
+	content, err := os.ReadFile(requirementsFile)
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(content), "\n") {
+		if comment := strings.Index(line, "#"); comment >= 0 {
+			line = line[:comment]
+		}
+		requirement, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		if len(requirement) == 0 {
+			continue
+		}
+		if strings.HasPrefix(requirement, "-") || !strings.Contains(requirement, "==") || strings.Contains(requirement, "*") {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ancestorDirs returns the directories above dir, up to the root of the git repository it is in
+func ancestorDirs(dir string) []string {
+	current, err := filepath.Abs(dir)
+	if err != nil {
+		return nil
+	}
+	var dirs []string
+	for {
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			return dirs
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return dirs
+		}
+		dirs = append(dirs, parent)
+		current = parent
+	}
+}
+
+// walk calls fn for the files below dir, skipping hidden, dependency and build output directories
+func walk(dir string, fn func(path string)) error {
+	return filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			name := entry.Name()
+			if path != dir && (strings.HasPrefix(name, ".") || name == "node_modules" || name == "build") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+		fn(path)
+
+		return nil
+	})
+}
+
+func isFile(path string) bool {
+	info, err := os.Stat(path)
+
+	return err == nil && !info.IsDir()
+}
@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFiles(t *testing.T, root string, files ...string) {
+	t.Helper()
+	for _, file := range files {
+		path := filepath.Join(root, filepath.FromSlash(file))
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NoError(t, os.WriteFile(path, []byte(file), 0o600))
+	}
+}
+
+func TestInputsGradle(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(
+		t,
+		root,
+		".git/HEAD",
+		"settings.gradle",
+		"gradle.properties",
+		"service/build.gradle",
+		"service/gradle/libs.versions.toml",
+		"service/lib/build.gradle.kts",
+		"service/lib/src/Main.java",
+		"service/node_modules/x/build.gradle",
+		"service/.gradle/cache.gradle",
+	)
+
+	inputs, err := specs["gradle"].inputs(filepath.Join(root, "service", "build.gradle"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(root, "gradle.properties"),
+		filepath.Join(root, "service", "build.gradle"),
+		filepath.Join(root, "service", "gradle", "libs.versions.toml"),
+		filepath.Join(root, "service", "lib", "build.gradle.kts"),
+		filepath.Join(root, "settings.gradle"),
+	}, inputs)
+}
+
+func TestInputsStopAtRepositoryRoot(t *testing.T) {
+	outside := t.TempDir()
+	writeFiles(t, outside, "pom.xml", "repo/.git/HEAD", "repo/pom.xml", "repo/app/pom.xml")
+
+	inputs, err := specs["mvn"].inputs(filepath.Join(outside, "repo", "app", "pom.xml"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(outside, "repo", "app", "pom.xml"),
+		filepath.Join(outside, "repo", "pom.xml"),
+	}, inputs)
+}
+
+func TestLockFilesPip(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, "requirements.txt", "requirements.txt.pip.debricked.lock", "dev.txt.pip.debricked.lock")
+
+	lockFiles, err := specs["pip"].lockFiles(filepath.Join(root, "requirements.txt"), 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(root, "requirements.txt.pip.debricked.lock")}, lockFiles)
+}
+
+func TestIsPinnedRequirements(t *testing.T) {
+	cases := []struct {
+		content string
+		pinned  bool
+	}{
+		{content: "# Pinned\nrequests==2.31.0  # HTTP\nDjango==4.2.7 ; python_version >= '3.8'\n\n", pinned: true},
+		{content: "requests>=2.31.0\n", pinned: false},
+		{content: "requests\n", pinned: false},
+		{content: "requests==2.*\n", pinned: false},
+		{content: "-r base.txt\nrequests==2.31.0\n", pinned: false},
+	}
+
+	for _, c := range cases {
+		file := filepath.Join(t.TempDir(), "requirements.txt")
+		assert.NoError(t, os.WriteFile(file, []byte(c.content), 0o600))
+		assert.Equal(t, c.pinned, isPinnedRequirements(file), c.content)
+	}
+	assert.False(t, isPinnedRequirements(filepath.Join(t.TempDir(), "missing.txt")))
+}
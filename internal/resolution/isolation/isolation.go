@@ -0,0 +1,91 @@
+// Package isolation runs resolution jobs in containers, so that package managers neither have to be installed on
+// the host nor run with its permissions.
+package isolation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/pm/bundler"
+	"github.com/debricked/cli/internal/resolution/pm/cargo"
+	"github.com/debricked/cli/internal/resolution/pm/composer"
+	"github.com/debricked/cli/internal/resolution/pm/gomod"
+	"github.com/debricked/cli/internal/resolution/pm/gradle"
+	"github.com/debricked/cli/internal/resolution/pm/maven"
+	"github.com/debricked/cli/internal/resolution/pm/mix"
+	"github.com/debricked/cli/internal/resolution/pm/npm"
+	"github.com/debricked/cli/internal/resolution/pm/nuget"
+	"github.com/debricked/cli/internal/resolution/pm/pip"
+	"github.com/debricked/cli/internal/resolution/pm/pub"
+	"github.com/debricked/cli/internal/resolution/pm/spm"
+	"github.com/debricked/cli/internal/resolution/pm/uv"
+	"github.com/debricked/cli/internal/resolution/pm/yarn"
+)
+
+type Mode string
+
+const (
+	None   Mode = "none"
+	Docker Mode = "docker"
+	Podman Mode = "podman"
+)
+
+func ParseMode(mode string) (Mode, error) {
+	switch Mode(strings.ToLower(mode)) {
+	case "", None:
+		return None, nil
+	case Docker:
+		return Docker, nil
+	case Podman:
+		return Podman, nil
+	default:
+		return None, fmt.Errorf("invalid isolation: %s, expected one of none, docker or podman", mode)
+	}
+}
+
+// defaultImages maps package manager names to the images their jobs run in, unless configured otherwise
+var defaultImages = map[string]string{
+	bundler.Name:  "ruby:3",
+	cargo.Name:    "rust:1",
+	composer.Name: "composer:2",
+	gomod.Name:    "golang:1",
+	gradle.Name:   "gradle:8-jdk17",
+	maven.Name:    "maven:3-eclipse-temurin-17",
+	mix.Name:      "elixir:1.17",
+	npm.Name:      "node:lts",
+	nuget.Name:    "mcr.microsoft.com/dotnet/sdk:8.0",
+	pip.Name:      "python:3",
+	pub.Name:      "dart:stable",
+	spm.Name:      "swift:5.10",
+	uv.Name:       "ghcr.io/astral-sh/uv:python3.12-bookworm",
+	yarn.Name:     "node:lts",
+}
+
+// ParseImages parses image configurations on the form <package manager>=<image>, such as mvn=maven:3.9
+func ParseImages(values []string) (map[string]string, error) {
+	images := map[string]string{}
+	for _, value := range values {
+		pm, image, found := strings.Cut(value, "=")
+		pm = strings.TrimSpace(pm)
+		image = strings.TrimSpace(image)
+		if !found || len(pm) == 0 || len(image) == 0 {
+			return nil, fmt.Errorf("invalid isolation image: %s, expected <package manager>=<image>", value)
+		}
+		images[pm] = image
+	}
+
+	return images, nil
+}
+
+// Images returns the default images with the configured images taking precedence
+func Images(configured map[string]string) map[string]string {
+	images := map[string]string{}
+	for pm, image := range defaultImages {
+		images[pm] = image
+	}
+	for pm, image := range configured {
+		images[pm] = image
+	}
+
+	return images
+}
@@ -0,0 +1,43 @@
+package isolation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMode(t *testing.T) {
+	cases := map[string]Mode{
+		"":       None,
+		"none":   None,
+		"docker": Docker,
+		"Podman": Podman,
+	}
+	for value, expected := range cases {
+		mode, err := ParseMode(value)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, mode)
+	}
+
+	_, err := ParseMode("vagrant")
+	assert.ErrorContains(t, err, "invalid isolation: vagrant")
+}
+
+func TestParseImages(t *testing.T) {
+	images, err := ParseImages([]string{"mvn=maven:3.9-eclipse-temurin-21", " npm = node:20 "})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"mvn": "maven:3.9-eclipse-temurin-21", "npm": "node:20"}, images)
+
+	for _, value := range []string{"maven", "=maven:3", "mvn="} {
+		_, err = ParseImages([]string{value})
+		assert.ErrorContains(t, err, "invalid isolation image")
+	}
+}
+
+func TestImages(t *testing.T) {
+	images := Images(map[string]string{"mvn": "registry.example.com/maven:3", "sbt": "sbt:1"})
+	assert.Equal(t, "registry.example.com/maven:3", images["mvn"])
+	assert.Equal(t, "sbt:1", images["sbt"])
+	assert.Equal(t, defaultImages["npm"], images["npm"])
+	assert.Equal(t, "maven:3-eclipse-temurin-17", defaultImages["mvn"])
+}
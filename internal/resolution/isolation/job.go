@@ -0,0 +1,185 @@
+package isolation
+
+import (
+	"context"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+type ISandboxedJob interface {
+	job.IJob
+	SetSandbox(sandbox job.ISandbox)
+}
+
+// Job runs the commands of a package manager job in a container of the image configured for its package manager.
+// Lock files written next to the manifest in the container are copied out once the job is done, while files written
+// below it are copied out by the job itself through job.BaseJob.Retrieve.
+type Job struct {
+	inner     job.IJob
+	pm        string
+	image     string
+	root      string
+	runtime   IRuntime
+	container string
+	dir       string
+}
+
+func NewJob(inner job.IJob, pm string, image string, root string, runtime IRuntime) *Job {
+	return &Job{
+		inner:   inner,
+		pm:      pm,
+		image:   image,
+		root:    root,
+		runtime: runtime,
+	}
+}
+
+func (j *Job) GetFile() string {
+	return j.inner.GetFile()
+}
+
+func (j *Job) Errors() job.IErrors {
+	return j.inner.Errors()
+}
+
+func (j *Job) ReceiveStatus() chan string {
+	return j.inner.ReceiveStatus()
+}
+
+func (j *Job) SetContext(ctx context.Context) {
+	if cancelable, ok := j.inner.(job.ICancelableJob); ok {
+		cancelable.SetContext(ctx)
+	}
+}
+
+// Commands returns the commands run by the inner job, as they would have run on the host
+func (j *Job) Commands() []string {
+	if history, ok := j.inner.(job.ICommandHistory); ok {
+		return history.Commands()
+	}
+
+	return nil
+}
+
+// LockFiles returns the lock files written by the inner job, which are copied to the host once it has run
+func (j *Job) LockFiles() []string {
+	if writer, ok := j.inner.(job.ILockFileWriter); ok {
+		return writer.LockFiles()
+	}
+
+	return nil
+}
+
+func (j *Job) Command(cmd *exec.Cmd) *exec.Cmd {
+	return j.runtime.Command(j.container, cmd)
+}
+
+func (j *Job) CopyOut(patterns []string) error {
+	_, err := j.runtime.CopyOut(j.container, j.dir, patterns)
+
+	return err
+}
+
+func (j *Job) Run() {
+	sandboxedJob, ok := j.inner.(ISandboxedJob)
+	if !ok {
+		j.handleError(util.NewPMJobError("job can't run in a container"), "starting container", util.UnknownError)
+
+		return
+	}
+	if len(j.image) == 0 {
+		j.handleError(
+			util.NewPMJobError("no container image configured for "+j.pm),
+			"starting container",
+			"Please configure the image to resolve "+j.pm+" manifest files in, for example `--isolation-image "+j.pm+"=<image>`.",
+		)
+
+		return
+	}
+	var err error
+	j.dir, err = filepath.Abs(filepath.Dir(j.GetFile()))
+	if err != nil {
+		j.handleError(util.NewPMJobError(err.Error()), "starting container", util.UnknownError)
+
+		return
+	}
+
+	status := "starting container"
+	j.sendStatus(status)
+	j.container, err = j.runtime.Start(j.image, j.root, j.dir)
+	if err != nil {
+		j.handleError(
+			util.NewPMJobError(err.Error()),
+			status,
+			strings.Join(
+				[]string{
+					"Failed to start a container of " + j.image + ".",
+					"Please check that the container runtime is running and that the image is available.",
+				}, " "),
+		)
+
+		return
+	}
+	defer j.remove()
+
+	sandboxedJob.SetSandbox(j)
+	sandboxedJob.Run()
+	if len(j.Errors().GetCriticalErrors()) > 0 {
+		return
+	}
+
+	status = "copying lock file"
+	j.sendStatus(status)
+	err = j.CopyOut(nil)
+	if err != nil {
+		j.handleError(util.NewPMJobError(err.Error()), status, "Failed to copy the lock file out of the container.")
+	}
+}
+
+func (j *Job) remove() {
+	err := j.runtime.Remove(j.container)
+	if err != nil {
+		jobErr := util.NewPMJobError(err.Error())
+		jobErr.SetIsCritical(false)
+		j.handleError(jobErr, "removing container", "Failed to remove container "+j.container+", please remove it manually.")
+	}
+}
+
+func (j *Job) handleError(jobErr *util.PMJobError, status string, documentation string) {
+	jobErr.SetStatus(status)
+	jobErr.SetDocumentation(documentation)
+	j.Errors().Append(jobErr)
+}
+
+func (j *Job) sendStatus(status string) {
+	j.inner.ReceiveStatus() <- status
+}
+
+// Isolator wraps jobs so that they run in containers
+type Isolator struct {
+	runtime IRuntime
+	images  map[string]string
+	root    string
+}
+
+// NewIsolator creates an Isolator running jobs with runtime in images, by package manager name, with root mounted
+func NewIsolator(runtime IRuntime, images map[string]string, root string) Isolator {
+	return Isolator{
+		runtime: runtime,
+		images:  images,
+		root:    root,
+	}
+}
+
+func (isolator Isolator) Isolate(jobs []job.IJob, pm string) []job.IJob {
+	isolated := make([]job.IJob, 0, len(jobs))
+	for _, j := range jobs {
+		isolated = append(isolated, NewJob(j, pm, isolator.images[pm], isolator.root, isolator.runtime))
+	}
+
+	return isolated
+}
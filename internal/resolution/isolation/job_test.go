@@ -0,0 +1,181 @@
+package isolation
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/resolution/isolation/testdata"
+	"github.com/debricked/cli/internal/resolution/job"
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/stretchr/testify/assert"
+)
+
+type echoJob struct {
+	job.BaseJob
+	fail     bool
+	retrieve []string
+}
+
+func newEchoJob(file string) *echoJob {
+	return &echoJob{BaseJob: job.NewBaseJob(file)}
+}
+
+func (j *echoJob) Run() {
+	j.SendStatus("echoing")
+	_, err := j.Sandboxed(exec.Command("echo", "resolved")).Output()
+	if err == nil && len(j.retrieve) > 0 {
+		err = j.Retrieve(j.retrieve...)
+	}
+	if err != nil || j.fail {
+		j.Errors().Critical(util.NewPMJobError("echo failed"))
+	}
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	runtimeMock := &testdata.RuntimeMock{Written: map[string][]byte{"maven.debricked.lock": []byte("lock")}}
+	j := NewJob(newEchoJob(filepath.Join(dir, "pom.xml")), "mvn", "maven:3", dir, runtimeMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.Equal(t, []string{"maven:3"}, runtimeMock.Started)
+	assert.Equal(t, [][]string{{"container", "echo", "resolved"}}, runtimeMock.Commands)
+	assert.Len(t, j.Commands(), 1)
+	assert.Contains(t, j.Commands()[0], "echo resolved")
+	assert.Equal(t, []string{"container"}, runtimeMock.Removed)
+	lock, err := os.ReadFile(filepath.Join(dir, "maven.debricked.lock"))
+	assert.NoError(t, err)
+	assert.Equal(t, "lock", string(lock))
+}
+
+func TestRunRetrievesNestedFiles(t *testing.T) {
+	dir := t.TempDir()
+	runtimeMock := &testdata.RuntimeMock{Written: map[string][]byte{
+		filepath.Join("target", "scala-2.13", "app.pom"): []byte("pom"),
+	}}
+	inner := newEchoJob(filepath.Join(dir, "build.sbt"))
+	inner.retrieve = []string{"target/scala-*/*.pom"}
+	j := NewJob(inner, "sbt", "sbt:1", dir, runtimeMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.Equal(t, [][]string{{"target/scala-*/*.pom"}, nil}, runtimeMock.CopiedOut)
+	assert.FileExists(t, filepath.Join(dir, "target", "scala-2.13", "app.pom"))
+}
+
+func TestRunWithoutImage(t *testing.T) {
+	runtimeMock := &testdata.RuntimeMock{}
+	j := NewJob(newEchoJob("pom.xml"), "mvn", "", ".", runtimeMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	errs := j.Errors().GetCriticalErrors()
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "no container image configured for mvn", errs[0].Error())
+	assert.Contains(t, errs[0].Documentation(), "--isolation-image mvn=<image>")
+	assert.Empty(t, runtimeMock.Started)
+}
+
+func TestRunNotSandboxable(t *testing.T) {
+	j := NewJob(jobTestdata.NewJobMock("pom.xml"), "mvn", "maven:3", ".", &testdata.RuntimeMock{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Len(t, j.Errors().GetCriticalErrors(), 1)
+}
+
+func TestRunStartErr(t *testing.T) {
+	runtimeMock := &testdata.RuntimeMock{StartErr: errors.New("Cannot connect to the Docker daemon")}
+	j := NewJob(newEchoJob("pom.xml"), "mvn", "maven:3", ".", runtimeMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	errs := j.Errors().GetCriticalErrors()
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "starting container", errs[0].Status())
+	assert.Contains(t, errs[0].Documentation(), "Failed to start a container of maven:3.")
+	assert.Empty(t, runtimeMock.Commands)
+	assert.Empty(t, runtimeMock.Removed)
+}
+
+func TestRunJobErrSkipsCopyOut(t *testing.T) {
+	dir := t.TempDir()
+	runtimeMock := &testdata.RuntimeMock{Written: map[string][]byte{"maven.debricked.lock": []byte("lock")}}
+	inner := newEchoJob(filepath.Join(dir, "pom.xml"))
+	inner.fail = true
+	j := NewJob(inner, "mvn", "maven:3", dir, runtimeMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Len(t, j.Errors().GetCriticalErrors(), 1)
+	assert.NoFileExists(t, filepath.Join(dir, "maven.debricked.lock"))
+	assert.Equal(t, []string{"container"}, runtimeMock.Removed)
+}
+
+func TestRunCanceled(t *testing.T) {
+	dir := t.TempDir()
+	runtimeMock := &testdata.RuntimeMock{Written: map[string][]byte{"maven.debricked.lock": []byte("lock")}}
+	j := NewJob(newEchoJob(filepath.Join(dir, "pom.xml")), "mvn", "maven:3", dir, runtimeMock)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	j.SetContext(ctx)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Len(t, j.Errors().GetCriticalErrors(), 1)
+	assert.NoFileExists(t, filepath.Join(dir, "maven.debricked.lock"))
+	assert.Equal(t, []string{"container"}, runtimeMock.Removed)
+}
+
+func TestRunCopyOutErr(t *testing.T) {
+	runtimeMock := &testdata.RuntimeMock{CopyOutErr: errors.New("copy-error")}
+	j := NewJob(newEchoJob("pom.xml"), "mvn", "maven:3", ".", runtimeMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	errs := j.Errors().GetCriticalErrors()
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "copying lock file", errs[0].Status())
+}
+
+func TestRunRemoveErr(t *testing.T) {
+	runtimeMock := &testdata.RuntimeMock{RemoveErr: errors.New("remove-error")}
+	j := NewJob(newEchoJob("pom.xml"), "mvn", "maven:3", ".", runtimeMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Empty(t, j.Errors().GetCriticalErrors())
+	warnings := j.Errors().GetWarningErrors()
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, "removing container", warnings[0].Status())
+}
+
+func TestIsolate(t *testing.T) {
+	runtimeMock := &testdata.RuntimeMock{}
+	isolator := NewIsolator(runtimeMock, map[string]string{"mvn": "maven:3"}, ".")
+	jobs := isolator.Isolate([]job.IJob{newEchoJob("a/pom.xml"), newEchoJob("b/pom.xml")}, "mvn")
+
+	assert.Len(t, jobs, 2)
+	for _, j := range jobs {
+		isolated, ok := j.(*Job)
+		assert.True(t, ok)
+		assert.Equal(t, "maven:3", isolated.image)
+	}
+	assert.Equal(t, "a/pom.xml", jobs[0].GetFile())
+}
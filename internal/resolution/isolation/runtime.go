@@ -0,0 +1,206 @@
+package isolation
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	internalOs "github.com/debricked/cli/internal/runtime/os"
+)
+
+const (
+	// sourceDir is where the manifest directory is mounted read-only. It is copied to a writable volume at the
+	// path of the manifest directory on the host, so that host paths in commands keep working.
+	sourceDir   = "/debricked/source"
+	startedFile = "/debricked/started"
+	syncedFile  = "/debricked/synced"
+	dirEnv      = "DEBRICKED_DIR"
+)
+
+const (
+	initScript = `cp -a ` + sourceDir + `/. "$` + dirEnv + `" && touch ` + startedFile + ` && : > ` + syncedFile
+	// syncScript copies files created on the host after the container started, such as temporary manifests
+	// written by jobs, before running the command
+	syncScript = `(cd ` + sourceDir + ` && find . -type f -newer ` + startedFile + `) | while IFS= read -r file; do
+	mkdir -p "$` + dirEnv + `/$(dirname "$file")" && cp -p "` + sourceDir + `/$file" "$` + dirEnv + `/$file" && echo "$file" >> ` + syncedFile + `
+done
+exec "$@"`
+	// changedScript lists the files written in the container next to the manifest, and the files below it with paths
+	// matching the patterns given as arguments
+	changedScript = `cd "$` + dirEnv + `" && {
+	find . -maxdepth 1 -type f -newer ` + startedFile + `
+	for pattern in "$@"; do find . -mindepth 2 -type f -newer ` + startedFile + ` -path "./$pattern"; done
+} | sort -u | while IFS= read -r file; do
+	grep -qxF "$file" ` + syncedFile + ` || echo "$file"
+done`
+)
+
+var ErrUnsupportedOs = errors.New("container isolation isn't supported on Windows")
+
+type IRuntime interface {
+	// Start starts a container of image with root mounted read-only and a writable copy of dir, returning its ID
+	Start(image string, root string, dir string) (string, error)
+	// Command returns cmd rewritten to run in container
+	Command(container string, cmd *exec.Cmd) *exec.Cmd
+	// CopyOut copies the files written next to the manifest in container to dir on the host, along with those below
+	// it with paths relative to it matching any of patterns. Patterns are matched by find -path, so * matches / too.
+	CopyOut(container string, dir string, patterns []string) ([]string, error)
+	Remove(container string) error
+}
+
+// CliRuntime runs containers with the docker CLI, or another CLI compatible with it such as podman
+type CliRuntime struct {
+	executable string
+}
+
+func NewCliRuntime(executable string) CliRuntime {
+	return CliRuntime{executable: executable}
+}
+
+func (cliRuntime CliRuntime) Start(image string, root string, dir string) (string, error) {
+	if runtime.GOOS == internalOs.Windows {
+		return "", ErrUnsupportedOs
+	}
+	args := []string{"run", "--detach", "--rm", "--entrypoint", "sleep", "--env", dirEnv + "=" + dir}
+	args = append(args, mounts(root, dir)...)
+	args = append(args, image, "infinity")
+	output, err := cliRuntime.run(args...)
+	if err != nil {
+		return "", err
+	}
+	container := strings.TrimSpace(string(output))
+
+	_, err = cliRuntime.run("exec", container, "sh", "-c", initScript)
+	if err != nil {
+		_ = cliRuntime.Remove(container)
+
+		return "", err
+	}
+
+	return container, nil
+}
+
+func (cliRuntime CliRuntime) Command(container string, cmd *exec.Cmd) *exec.Cmd {
+	workingDirectory := cmd.Dir
+	if absDir, err := filepath.Abs(workingDirectory); err == nil {
+		workingDirectory = absDir
+	}
+	args := []string{cliRuntime.executable, "exec", "--workdir", workingDirectory}
+	// Variables are passed by name, taking their values from the environment of the runtime, so that secrets such as
+	// registry credentials don't show up in its arguments
+	added := addedEnv(cmd.Env)
+	for _, env := range added {
+		name, _, _ := strings.Cut(env, "=")
+		args = append(args, "--env", name)
+	}
+	args = append(args, container, "sh", "-c", syncScript, "sh")
+	if len(cmd.Args) > 0 {
+		args = append(args, cmd.Args...)
+	} else {
+		args = append(args, cmd.Path)
+	}
+
+	path, err := exec.LookPath(cliRuntime.executable)
+	if err != nil {
+		path = cliRuntime.executable
+	}
+
+	var env []string
+	if len(added) > 0 {
+		env = append(os.Environ(), added...)
+	}
+
+	return &exec.Cmd{
+		Path:   path,
+		Args:   args,
+		Env:    env,
+		Stdin:  cmd.Stdin,
+		Stderr: cmd.Stderr,
+	}
+}
+
+func (cliRuntime CliRuntime) CopyOut(container string, dir string, patterns []string) ([]string, error) {
+	output, err := cliRuntime.run(append([]string{"exec", container, "sh", "-c", changedScript, "sh"}, patterns...)...)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, file := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if len(file) == 0 {
+			continue
+		}
+		file = filepath.Join(dir, filepath.FromSlash(file))
+		err = os.MkdirAll(filepath.Dir(file), 0o755)
+		if err != nil {
+			return files, err
+		}
+		_, err = cliRuntime.run("cp", container+":"+filepath.ToSlash(file), file)
+		if err != nil {
+			return files, err
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+func (cliRuntime CliRuntime) Remove(container string) error {
+	_, err := cliRuntime.run("rm", "--force", container)
+
+	return err
+}
+
+func (cliRuntime CliRuntime) run(args ...string) ([]byte, error) {
+	output, err := exec.Command(cliRuntime.executable, args...).Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+		return output, errors.New(strings.TrimSpace(string(exitErr.Stderr)))
+	}
+
+	return output, err
+}
+
+// mounts returns the mount arguments for root, read-only at the same path as on the host, and dir, read-only at
+// sourceDir and as a writable volume at the same path as on the host. The temporary directory of the host is mounted
+// read-only as well, since jobs may pass files there to commands.
+func mounts(root string, dir string) []string {
+	var args []string
+	if root != dir && isWithin(dir, root) {
+		args = append(args, "--mount", "type=bind,source="+root+",target="+root+",readonly")
+	}
+	args = append(
+		args,
+		"--mount", "type=bind,source="+dir+",target="+sourceDir+",readonly",
+		"--mount", "type=volume,target="+dir,
+	)
+	if tmp, err := filepath.Abs(os.TempDir()); err == nil && !isWithin(dir, tmp) && !isWithin(tmp, root) {
+		args = append(args, "--mount", "type=bind,source="+tmp+",target="+tmp+",readonly")
+	}
+
+	return args
+}
+
+func isWithin(path string, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// addedEnv returns the variables of env which aren't inherited from the host, such as those set by command factories
+func addedEnv(env []string) []string {
+	hostEnv := map[string]bool{}
+	for _, variable := range os.Environ() {
+		hostEnv[variable] = true
+	}
+	var added []string
+	for _, variable := range env {
+		if !hostEnv[variable] {
+			added = append(added, variable)
+		}
+	}
+
+	return added
+}
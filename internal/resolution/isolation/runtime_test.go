@@ -0,0 +1,95 @@
+package isolation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommand(t *testing.T) {
+	dir := t.TempDir()
+	cmd := exec.Command("mvn", "dependency:tree")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "BUNDLE_FROZEN=false", "DEBRICKED_REGISTRY_TOKEN=secret")
+
+	isolated := NewCliRuntime("docker").Command("container", cmd)
+
+	expected := []string{
+		"docker", "exec", "--workdir", dir, "--env", "BUNDLE_FROZEN", "--env", "DEBRICKED_REGISTRY_TOKEN",
+		"container", "sh", "-c", syncScript, "sh", "mvn", "dependency:tree",
+	}
+	assert.Equal(t, expected, isolated.Args)
+	assert.Empty(t, isolated.Dir)
+	assert.Contains(t, isolated.Env, "DEBRICKED_REGISTRY_TOKEN=secret")
+	assert.NotContains(t, isolated.String(), "secret")
+}
+
+func TestCommandWithoutAddedEnv(t *testing.T) {
+	isolated := NewCliRuntime("docker").Command("container", exec.Command("mvn", "dependency:tree"))
+
+	assert.NotContains(t, isolated.Args, "--env")
+	assert.Empty(t, isolated.Env)
+}
+
+func TestCommandRelativeDir(t *testing.T) {
+	cmd := exec.Command("npm", "install")
+	cmd.Dir = "testdata"
+
+	isolated := NewCliRuntime("podman").Command("container", cmd)
+
+	wd, _ := os.Getwd()
+	assert.Equal(t, filepath.Join(wd, "testdata"), isolated.Args[3])
+	assert.Equal(t, "podman", isolated.Args[0])
+}
+
+func TestStartErr(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip()
+	}
+	_, err := NewCliRuntime("debricked-non-existing-runtime").Start("maven:3", "/project", "/project")
+	assert.ErrorContains(t, err, "executable file not found")
+}
+
+func TestMounts(t *testing.T) {
+	root := filepath.Join(os.TempDir(), "..", "project")
+	root, _ = filepath.Abs(root)
+	dir := filepath.Join(root, "service")
+	tmp, _ := filepath.Abs(os.TempDir())
+
+	args := mounts(root, dir)
+
+	assert.Equal(t, []string{
+		"--mount", "type=bind,source=" + root + ",target=" + root + ",readonly",
+		"--mount", "type=bind,source=" + dir + ",target=" + sourceDir + ",readonly",
+		"--mount", "type=volume,target=" + dir,
+		"--mount", "type=bind,source=" + tmp + ",target=" + tmp + ",readonly",
+	}, args)
+}
+
+func TestMountsManifestInRoot(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "project")
+
+	args := mounts(root, root)
+
+	assert.Equal(t, []string{
+		"--mount", "type=bind,source=" + root + ",target=" + sourceDir + ",readonly",
+		"--mount", "type=volume,target=" + root,
+	}, args)
+}
+
+func TestIsWithin(t *testing.T) {
+	assert.True(t, isWithin(filepath.Join("a", "b"), "a"))
+	assert.True(t, isWithin("a", "a"))
+	assert.False(t, isWithin("b", "a"))
+	assert.False(t, isWithin(filepath.Join("a", "..", ".."), "a"))
+}
+
+func TestAddedEnv(t *testing.T) {
+	env := append(os.Environ(), "COCOAPODS_DISABLE_STATS=true")
+	assert.Equal(t, []string{"COCOAPODS_DISABLE_STATS=true"}, addedEnv(env))
+	assert.Empty(t, addedEnv(nil))
+}
@@ -0,0 +1,61 @@
+package testdata
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+)
+
+// RuntimeMock is a container runtime running commands on the host. Files listed in Written are written to the
+// manifest directory when copied out, and the patterns copied out are recorded in CopiedOut.
+type RuntimeMock struct {
+	StartErr   error
+	CopyOutErr error
+	RemoveErr  error
+	Written    map[string][]byte
+	Started    []string
+	Commands   [][]string
+	Removed    []string
+	CopiedOut  [][]string
+}
+
+func (r *RuntimeMock) Start(image string, _ string, _ string) (string, error) {
+	if r.StartErr != nil {
+		return "", r.StartErr
+	}
+	r.Started = append(r.Started, image)
+
+	return "container", nil
+}
+
+func (r *RuntimeMock) Command(container string, cmd *exec.Cmd) *exec.Cmd {
+	r.Commands = append(r.Commands, append([]string{container}, cmd.Args...))
+
+	return cmd
+}
+
+func (r *RuntimeMock) CopyOut(_ string, dir string, patterns []string) ([]string, error) {
+	r.CopiedOut = append(r.CopiedOut, patterns)
+	if r.CopyOutErr != nil {
+		return nil, r.CopyOutErr
+	}
+	var files []string
+	for name, content := range r.Written {
+		file := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+			return files, err
+		}
+		if err := os.WriteFile(file, content, 0600); err != nil {
+			return files, err
+		}
+		files = append(files, file)
+	}
+
+	return files, nil
+}
+
+func (r *RuntimeMock) Remove(container string) error {
+	r.Removed = append(r.Removed, container)
+
+	return r.RemoveErr
+}
@@ -1,15 +1,36 @@
 package job
 
 import (
+	"context"
 	"errors"
 	"os/exec"
 	"strings"
+	"sync"
+	"time"
 )
 
+// waitDelay is how long commands get to close their output once killed, before it is closed for them
+const waitDelay = 10 * time.Second
+
+// ISandbox runs the commands of a job somewhere other than on the host, such as in a container
+type ISandbox interface {
+	Command(cmd *exec.Cmd) *exec.Cmd
+	// CopyOut copies the files written below the directory of the manifest file, with paths relative to it matching
+	// any of patterns, to the host
+	CopyOut(patterns []string) error
+}
+
+// BaseJob guards its commands and lock files, since jobs left running once they time out may still add them while
+// they are reported
 type BaseJob struct {
-	file   string
-	errs   IErrors
-	status chan string
+	file      string
+	errs      IErrors
+	status    chan string
+	sandbox   ISandbox
+	commands  []string
+	lockFiles []string
+	mutex     sync.Mutex
+	ctx       context.Context
 }
 
 func NewBaseJob(file string) BaseJob {
@@ -36,6 +57,92 @@ func (j *BaseJob) SendStatus(status string) {
 	j.status <- status
 }
 
+func (j *BaseJob) SetSandbox(sandbox ISandbox) {
+	j.sandbox = sandbox
+}
+
+// SetContext makes the commands of the job killed, including the processes they started, once ctx is done
+func (j *BaseJob) SetContext(ctx context.Context) {
+	j.ctx = ctx
+}
+
+// Context returns the context set by SetContext, or the background context if there is none
+func (j *BaseJob) Context() context.Context {
+	if j.ctx == nil {
+		return context.Background()
+	}
+
+	return j.ctx
+}
+
+// Sandboxed returns the command to run in place of cmd, which is cmd itself unless the job has a sandbox or a
+// context. cmd is added to the commands run by the job.
+func (j *BaseJob) Sandboxed(cmd *exec.Cmd) *exec.Cmd {
+	j.mutex.Lock()
+	j.commands = append(j.commands, Redact(cmd.String()))
+	j.mutex.Unlock()
+	if j.sandbox != nil {
+		cmd = j.sandbox.Command(cmd)
+	}
+	if j.ctx == nil {
+		return cmd
+	}
+
+	return WithContext(j.ctx, cmd)
+}
+
+// Retrieve copies the files written by the commands of the job below the directory of its manifest file, with paths
+// relative to it matching any of patterns, out of its sandbox. Without a sandbox the files are already on the host.
+func (j *BaseJob) Retrieve(patterns ...string) error {
+	if j.sandbox == nil {
+		return nil
+	}
+
+	return j.sandbox.CopyOut(patterns)
+}
+
+// Commands returns the command lines run by the job, in the order they were run
+func (j *BaseJob) Commands() []string {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	return append([]string(nil), j.commands...)
+}
+
+// AddLockFile records that the job wrote lockFile
+func (j *BaseJob) AddLockFile(lockFile string) {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.lockFiles = append(j.lockFiles, lockFile)
+}
+
+// LockFiles returns the lock files written by the job, in the order they were written
+func (j *BaseJob) LockFiles() []string {
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	return append([]string(nil), j.lockFiles...)
+}
+
+// WithContext returns a copy of cmd whose process tree is killed once ctx is done
+func WithContext(ctx context.Context, cmd *exec.Cmd) *exec.Cmd {
+	cancelable := exec.CommandContext(ctx, cmd.Path)
+	cancelable.Path = cmd.Path
+	cancelable.Args = cmd.Args
+	cancelable.Err = cmd.Err
+	cancelable.Env = cmd.Env
+	cancelable.Dir = cmd.Dir
+	cancelable.Stdin = cmd.Stdin
+	cancelable.Stdout = cmd.Stdout
+	cancelable.Stderr = cmd.Stderr
+	cancelable.ExtraFiles = cmd.ExtraFiles
+	cancelable.SysProcAttr = cmd.SysProcAttr
+	cancelable.WaitDelay = waitDelay
+	killProcessTree(cancelable)
+
+	return cancelable
+}
+
 func (j *BaseJob) GetExitError(err error, commandOutput string) error {
 	exitErr, ok := err.(*exec.ExitError)
 	if !ok {
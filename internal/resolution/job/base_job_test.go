@@ -1,6 +1,7 @@
 package job
 
 import (
+	"context"
 	"errors"
 	"os/exec"
 	"testing"
@@ -86,3 +87,75 @@ func TestGetExitErrorWithNoneExitError(t *testing.T) {
 	exitErr := j.GetExitError(err, "")
 	assert.ErrorContains(t, exitErr, err.Error())
 }
+
+type sandboxMock struct {
+	copiedOut *[]string
+}
+
+func (sandboxMock) Command(cmd *exec.Cmd) *exec.Cmd {
+	return exec.Command("sandbox", cmd.Args...)
+}
+
+func (sandbox sandboxMock) CopyOut(patterns []string) error {
+	*sandbox.copiedOut = append(*sandbox.copiedOut, patterns...)
+
+	return nil
+}
+
+func TestSandboxed(t *testing.T) {
+	j := NewBaseJob(testFile)
+	cmd := exec.Command("mvn", "--version")
+	assert.Same(t, cmd, j.Sandboxed(cmd))
+
+	j.SetSandbox(sandboxMock{})
+	sandboxed := j.Sandboxed(cmd)
+	assert.Equal(t, []string{"sandbox", "mvn", "--version"}, sandboxed.Args)
+	assert.Equal(t, []string{cmd.String(), cmd.String()}, j.Commands())
+}
+
+func TestRetrieve(t *testing.T) {
+	j := NewBaseJob(testFile)
+	assert.NoError(t, j.Retrieve("target/*.pom"))
+
+	var copiedOut []string
+	j.SetSandbox(sandboxMock{copiedOut: &copiedOut})
+	assert.NoError(t, j.Retrieve("target/*.pom"))
+	assert.Equal(t, []string{"target/*.pom"}, copiedOut)
+}
+
+func TestLockFiles(t *testing.T) {
+	j := NewBaseJob(testFile)
+	assert.Empty(t, j.LockFiles())
+
+	j.AddLockFile("maven.debricked.lock")
+	j.AddLockFile("module/maven.debricked.lock")
+	assert.Equal(t, []string{"maven.debricked.lock", "module/maven.debricked.lock"}, j.LockFiles())
+}
+
+func TestContext(t *testing.T) {
+	j := NewBaseJob(testFile)
+	assert.Equal(t, context.Background(), j.Context())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	j.SetContext(ctx)
+	assert.Equal(t, ctx, j.Context())
+}
+
+func TestSandboxedWithContext(t *testing.T) {
+	j := NewBaseJob(testFile)
+	ctx, cancel := context.WithCancel(context.Background())
+	j.SetContext(ctx)
+	cmd := exec.Command("mvn", "--version")
+	cmd.Dir = "dir"
+
+	cancelable := j.Sandboxed(cmd)
+	assert.NotSame(t, cmd, cancelable)
+	assert.Equal(t, cmd.Path, cancelable.Path)
+	assert.Equal(t, cmd.Args, cancelable.Args)
+	assert.Equal(t, "dir", cancelable.Dir)
+	assert.NotNil(t, cancelable.Cancel)
+
+	cancel()
+	assert.ErrorIs(t, j.Sandboxed(exec.Command("echo")).Run(), context.Canceled)
+}
@@ -1,5 +1,15 @@
 package job
 
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	StatusTimedOut = "timed out"
+	StatusCanceled = "canceled"
+)
+
 type IError interface {
 	Error() string
 	Command() string
@@ -21,11 +31,11 @@ type BaseJobError struct {
 }
 
 func (e BaseJobError) Error() string {
-	return e.err
+	return Redact(e.err)
 }
 
 func (e BaseJobError) Command() string {
-	return e.command
+	return Redact(e.command)
 }
 
 func (e BaseJobError) Documentation() string {
@@ -65,3 +75,34 @@ func NewBaseJobError(err string) *BaseJobError {
 		isCritical:    true,
 	}
 }
+
+// NewTimeoutError creates the error of a job stopped for running longer than timeout
+func NewTimeoutError(timeout time.Duration) *BaseJobError {
+	err := NewBaseJobError(fmt.Sprintf("resolution timed out after %s", timeout))
+	err.SetStatus(StatusTimedOut)
+	err.SetDocumentation(
+		"The package manager was stopped since it didn't finish in time, which may be because it waited for input or a lock. " +
+			"Increase the timeout with --resolution-timeout, or for the package manager only with --resolution-pm-timeout <package manager>=<seconds>.",
+	)
+
+	return err
+}
+
+// NewCanceledError creates the error of a job stopped, or never started, since the resolution was canceled
+func NewCanceledError() *BaseJobError {
+	err := NewBaseJobError("resolution canceled")
+	err.SetStatus(StatusCanceled)
+	err.SetDocumentation("The resolution was canceled, such as by an interrupt signal, before the job was done.")
+
+	return err
+}
+
+// IsTimeout reports whether err is the error of a job stopped for running too long
+func IsTimeout(err IError) bool {
+	return err.Status() == StatusTimedOut
+}
+
+// IsCanceled reports whether err is the error of a job stopped since the resolution was canceled
+func IsCanceled(err IError) bool {
+	return err.Status() == StatusCanceled
+}
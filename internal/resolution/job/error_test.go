@@ -2,6 +2,7 @@ package job
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -73,3 +74,20 @@ func TestBaseJobErrorSetStatus(t *testing.T) {
 	jobError.SetStatus("status")
 	assert.Equal(t, "status", jobError.Status())
 }
+
+func TestNewTimeoutError(t *testing.T) {
+	jobError := NewTimeoutError(90 * time.Second)
+	assert.Equal(t, "resolution timed out after 1m30s", jobError.Error())
+	assert.True(t, jobError.IsCritical())
+	assert.True(t, IsTimeout(jobError))
+	assert.False(t, IsCanceled(jobError))
+	assert.Contains(t, jobError.Documentation(), "--resolution-pm-timeout")
+}
+
+func TestNewCanceledError(t *testing.T) {
+	jobError := NewCanceledError()
+	assert.Equal(t, "resolution canceled", jobError.Error())
+	assert.True(t, jobError.IsCritical())
+	assert.True(t, IsCanceled(jobError))
+	assert.False(t, IsTimeout(jobError))
+}
@@ -1,5 +1,7 @@
 package job
 
+import "sync"
+
 type IErrors interface {
 	Warning(err IError)
 	Critical(err IError)
@@ -10,10 +12,12 @@ type IErrors interface {
 	HasError() bool
 }
 
+// Errors is safe for concurrent use, since jobs left running once they time out may still add errors
 type Errors struct {
 	title        string
 	warningErrs  []IError
 	criticalErrs []IError
+	mutex        sync.Mutex
 }
 
 func NewErrors(title string) *Errors {
@@ -25,10 +29,14 @@ func NewErrors(title string) *Errors {
 }
 
 func (errors *Errors) Warning(err IError) {
+	errors.mutex.Lock()
+	defer errors.mutex.Unlock()
 	errors.warningErrs = append(errors.warningErrs, err)
 }
 
 func (errors *Errors) Critical(err IError) {
+	errors.mutex.Lock()
+	defer errors.mutex.Unlock()
 	errors.criticalErrs = append(errors.criticalErrs, err)
 }
 
@@ -41,17 +49,29 @@ func (errors *Errors) Append(err IError) {
 }
 
 func (errors *Errors) GetWarningErrors() []IError {
-	return errors.warningErrs
+	errors.mutex.Lock()
+	defer errors.mutex.Unlock()
+
+	return append([]IError{}, errors.warningErrs...)
 }
 
 func (errors *Errors) GetCriticalErrors() []IError {
-	return errors.criticalErrs
+	errors.mutex.Lock()
+	defer errors.mutex.Unlock()
+
+	return append([]IError{}, errors.criticalErrs...)
 }
 
 func (errors *Errors) GetAll() []IError {
-	return append(errors.warningErrs, errors.criticalErrs...)
+	errors.mutex.Lock()
+	defer errors.mutex.Unlock()
+
+	return append(append([]IError{}, errors.warningErrs...), errors.criticalErrs...)
 }
 
 func (errors *Errors) HasError() bool {
+	errors.mutex.Lock()
+	defer errors.mutex.Unlock()
+
 	return len(errors.criticalErrs) > 0 || len(errors.warningErrs) > 0
 }
@@ -1,8 +1,25 @@
 package job
 
+import "context"
+
 type IJob interface {
 	GetFile() string
 	Errors() IErrors
 	Run()
 	ReceiveStatus() chan string
 }
+
+// ICommandHistory is implemented by jobs keeping track of the commands they run
+type ICommandHistory interface {
+	Commands() []string
+}
+
+// ILockFileWriter is implemented by jobs keeping track of the lock files they write
+type ILockFileWriter interface {
+	LockFiles() []string
+}
+
+// ICancelableJob is implemented by jobs which stop running their commands once a context is done
+type ICancelableJob interface {
+	SetContext(ctx context.Context)
+}
@@ -0,0 +1,22 @@
+//go:build !windows
+
+package job
+
+import (
+	"os/exec"
+	"syscall"
+)
+
+// killProcessTree runs cmd in a process group of its own, which is killed when cmd is canceled so that processes
+// started by cmd, such as Gradle and sbt daemons, don't outlive it
+func killProcessTree(cmd *exec.Cmd) {
+	attr := syscall.SysProcAttr{}
+	if cmd.SysProcAttr != nil {
+		attr = *cmd.SysProcAttr
+	}
+	attr.Setpgid = true
+	cmd.SysProcAttr = &attr
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
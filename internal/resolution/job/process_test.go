@@ -0,0 +1,33 @@
+//go:build !windows
+
+package job
+
+import (
+	"context"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSandboxedKillsProcessTree(t *testing.T) {
+	j := NewBaseJob(testFile)
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	j.SetContext(ctx)
+
+	start := time.Now()
+	output, err := j.Sandboxed(exec.Command("sh", "-c", "sleep 30 & echo $!; wait")).Output()
+
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), 10*time.Second)
+	pid, err := strconv.Atoi(strings.TrimSpace(string(output)))
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return syscall.Kill(pid, 0) != nil
+	}, 5*time.Second, 50*time.Millisecond)
+}
@@ -0,0 +1,14 @@
+package job
+
+import (
+	"os/exec"
+	"strconv"
+)
+
+// killProcessTree makes cmd kill the processes it started as well when canceled, so that processes started by cmd,
+// such as Gradle and sbt daemons, don't outlive it
+func killProcessTree(cmd *exec.Cmd) {
+	cmd.Cancel = func() error {
+		return exec.Command("taskkill", "/T", "/F", "/PID", strconv.Itoa(cmd.Process.Pid)).Run()
+	}
+}
@@ -3,6 +3,8 @@ package bower
 import (
 	"os/exec"
 	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
 )
 
 type ICmdFactory interface {
@@ -18,7 +20,7 @@ type ExecPath struct {
 }
 
 func (ExecPath) LookPath(file string) (string, error) {
-	return exec.LookPath(file)
+	return util.LookPath(file)
 }
 
 type CmdFactory struct {
@@ -59,7 +59,8 @@ func (j *Job) Run() {
 
 	status = "creating lock file"
 	j.SendStatus(status)
-	lockFile, err := j.fileWriter.Create(util.MakePathFromManifestFile(j.GetFile(), fileName))
+	lockFilePath := util.MakePathFromManifestFile(j.GetFile(), fileName)
+	lockFile, err := j.fileWriter.Create(lockFilePath)
 	if err != nil {
 		j.handleError(j.createError(err.Error(), cmd, status))
 
@@ -70,7 +71,10 @@ func (j *Job) Run() {
 	err = j.fileWriter.Write(lockFile, listCmdOutput)
 	if err != nil {
 		j.handleError(j.createError(err.Error(), cmd, status))
+
+		return
 	}
+	j.AddLockFile(lockFilePath)
 }
 
 func (j *Job) runInstallCmd(file string) (string, error) {
@@ -79,7 +83,7 @@ func (j *Job) runInstallCmd(file string) (string, error) {
 		return installCmd.String(), err
 	}
 
-	_, err = installCmd.Output()
+	_, err = j.Sandboxed(installCmd).Output()
 	if err != nil {
 		return installCmd.String(), j.GetExitError(err, "")
 	}
@@ -93,7 +97,7 @@ func (j *Job) runListCmd(file string) ([]byte, string, error) {
 		return nil, listCmd.String(), err
 	}
 
-	listCmdOutput, err := listCmd.Output()
+	listCmdOutput, err := j.Sandboxed(listCmd).Output()
 	if err != nil {
 		return nil, listCmd.String(), j.GetExitError(err, "")
 	}
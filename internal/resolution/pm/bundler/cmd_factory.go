@@ -0,0 +1,58 @@
+package bundler
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+type ICmdFactory interface {
+	MakeLockCmd(manifestFile string) (*exec.Cmd, error)
+}
+
+type IExecPath interface {
+	LookPath(file string) (string, error)
+}
+
+type ExecPath struct{}
+
+func (_ ExecPath) LookPath(file string) (string, error) {
+	return util.LookPath(file)
+}
+
+type CmdFactory struct {
+	execPath IExecPath
+	// trustProjectCode lets Bundler evaluate the Gemfile on the host, rather than only in containers
+	trustProjectCode bool
+}
+
+// MakeLockCmd creates an exec.Cmd that runs `bundle lock` for the given Gemfile.
+// Unlike `bundle install`, it only resolves dependencies and writes Gemfile.lock,
+// so no gems are installed and no native extensions or install hooks are run. The
+// Gemfile, and the gemspecs of path and git gems, are still evaluated as Ruby code,
+// so util.ErrUntrustedProjectCode is returned on the host unless the project is trusted.
+func (cmdf CmdFactory) MakeLockCmd(manifestFile string) (*exec.Cmd, error) {
+	if !cmdf.trustProjectCode && !util.IsIsolated() {
+		return nil, fmt.Errorf("bundle lock evaluates the Gemfile as Ruby code: %w", util.ErrUntrustedProjectCode)
+	}
+	bundlePath, err := cmdf.execPath.LookPath("bundle")
+	if err != nil {
+		return nil, err
+	}
+
+	manifestFile = filepath.Clean(manifestFile)
+
+	return &exec.Cmd{
+		Path: bundlePath,
+		Args: []string{"bundle", "lock"},
+		Dir:  filepath.Dir(manifestFile),
+		Env: append(
+			os.Environ(),
+			"BUNDLE_GEMFILE="+filepath.Base(manifestFile), // Lock this Gemfile, even if a parent directory has one
+			"BUNDLE_FROZEN=false",                         // Frozen settings would refuse to write Gemfile.lock
+		),
+	}, nil
+}
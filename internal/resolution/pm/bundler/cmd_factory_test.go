@@ -0,0 +1,43 @@
+package bundler
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/stretchr/testify/assert"
+)
+
+type execPathMock struct{}
+
+func (execPathMock) LookPath(file string) (string, error) {
+	return "/usr/bin/" + file, nil
+}
+
+func TestMakeLockCmd(t *testing.T) {
+	factory := CmdFactory{execPath: execPathMock{}, trustProjectCode: true}
+	manifest := filepath.Join("some", "path", "Gemfile")
+
+	cmd, err := factory.MakeLockCmd(manifest)
+	assert.NoError(t, err)
+	assert.Equal(t, "/usr/bin/bundle", cmd.Path)
+	assert.Equal(t, []string{"bundle", "lock"}, cmd.Args)
+	assert.Equal(t, filepath.Dir(manifest), cmd.Dir)
+	assert.Contains(t, cmd.Env, "BUNDLE_GEMFILE=Gemfile")
+	assert.Contains(t, cmd.Env, "BUNDLE_FROZEN=false")
+}
+
+func TestMakeLockCmdUntrusted(t *testing.T) {
+	factory := CmdFactory{execPath: execPathMock{}}
+	manifest := filepath.Join("some", "path", "Gemfile")
+
+	cmd, err := factory.MakeLockCmd(manifest)
+	assert.Nil(t, cmd)
+	assert.ErrorIs(t, err, util.ErrUntrustedProjectCode)
+
+	util.SetIsolated(true)
+	defer util.SetIsolated(false)
+	cmd, err = factory.MakeLockCmd(manifest)
+	assert.NoError(t, err)
+	assert.Equal(t, "/usr/bin/bundle", cmd.Path)
+}
@@ -0,0 +1,224 @@
+package bundler
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+const (
+	executableNotFoundErrRegex = `executable file not found`
+	rubyVersionErrRegex        = `Your Ruby version is ([^,\s]+), but your Gemfile specified ([^\s]+)`
+	rubyNotInstalledErrRegex   = "version `([^']+)' is not installed"
+	authenticationErrRegex     = `(?:Authentication is required for|Bad username or password for) (\S+?)\.?\s`
+	gemNotFoundErrRegex        = `Could not find gem '([^']+)' in`
+	versionConflictErrRegex    = `Bundler could not find compatible versions for gem "([^"]+)"`
+	invalidGemfileErrRegex     = "There was an error parsing `Gemfile`: ([^\\n]+)"
+	noInternetErrRegex         = `Could not reach host (\S+?)\.\s`
+	lockFileName               = "Gemfile.lock"
+)
+
+// Job runs `bundle lock` for a Gemfile, producing a Gemfile.lock.
+type Job struct {
+	job.BaseJob
+	cmdFactory ICmdFactory
+}
+
+func NewJob(file string, cmdFactory ICmdFactory) *Job {
+	return &Job{
+		BaseJob:    job.NewBaseJob(file),
+		cmdFactory: cmdFactory,
+	}
+}
+
+func (j *Job) Run() {
+	status := "generating Gemfile.lock"
+	j.SendStatus(status)
+
+	lockCmd, err := j.cmdFactory.MakeLockCmd(j.GetFile())
+	if err != nil {
+		cmdError := j.createError(err.Error(), "", status)
+		if errors.Is(err, util.ErrUntrustedProjectCode) {
+			cmdError.SetDocumentation(util.UntrustedProjectCodeMessage)
+		}
+		j.handleError(cmdError)
+
+		return
+	}
+
+	if output, err := j.Sandboxed(lockCmd).Output(); err != nil {
+		exitErr := j.GetExitError(err, string(output))
+		errorMessage := strings.Join([]string{string(output), exitErr.Error()}, "")
+		j.handleError(j.createError(errorMessage, lockCmd.String(), status))
+
+		return
+	}
+	j.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
+}
+
+func (j *Job) createError(errorStr string, cmd string, status string) job.IError {
+	cmdError := util.NewPMJobError(errorStr)
+	cmdError.SetCommand(cmd)
+	cmdError.SetStatus(status)
+
+	return cmdError
+}
+
+func (j *Job) handleError(cmdError job.IError) {
+	expressions := []string{
+		executableNotFoundErrRegex,
+		rubyVersionErrRegex,
+		rubyNotInstalledErrRegex,
+		authenticationErrRegex,
+		gemNotFoundErrRegex,
+		versionConflictErrRegex,
+		invalidGemfileErrRegex,
+		noInternetErrRegex,
+	}
+
+	for _, expression := range expressions {
+		regex := regexp.MustCompile(expression)
+		matches := regex.FindAllStringSubmatch(cmdError.Error(), -1)
+
+		if len(matches) > 0 {
+			cmdError = j.addDocumentation(expression, matches, cmdError)
+			j.Errors().Append(cmdError)
+
+			return
+		}
+	}
+
+	j.Errors().Append(cmdError)
+}
+
+func (j *Job) addDocumentation(expr string, matches [][]string, cmdError job.IError) job.IError {
+	documentation := cmdError.Documentation()
+
+	switch expr {
+	case executableNotFoundErrRegex:
+		documentation = j.GetExecutableNotFoundErrorDocumentation("Bundler")
+	case rubyVersionErrRegex:
+		documentation = j.getRubyVersionErrorDocumentation(matches)
+	case rubyNotInstalledErrRegex:
+		documentation = j.getRubyNotInstalledErrorDocumentation(matches)
+	case authenticationErrRegex:
+		documentation = j.getAuthenticationErrorDocumentation(matches)
+	case gemNotFoundErrRegex:
+		documentation = j.getGemNotFoundErrorDocumentation(matches)
+	case versionConflictErrRegex:
+		documentation = j.getVersionConflictErrorDocumentation(matches)
+	case invalidGemfileErrRegex:
+		documentation = j.getInvalidGemfileErrorDocumentation(matches)
+	case noInternetErrRegex:
+		documentation = j.getNoInternetErrorDocumentation(matches)
+	}
+
+	cmdError.SetDocumentation(documentation)
+
+	return cmdError
+}
+
+func (j *Job) getRubyVersionErrorDocumentation(matches [][]string) string {
+	installed := ""
+	required := ""
+	if len(matches) > 0 && len(matches[0]) > 2 {
+		installed = matches[0][1]
+		required = matches[0][2]
+	}
+
+	return strings.Join(
+		[]string{
+			"The Gemfile requires Ruby " + required + ", but Ruby " + installed + " is installed.",
+			"Please install the required Ruby version before running the debricked CLI.",
+		}, " ")
+}
+
+func (j *Job) getRubyNotInstalledErrorDocumentation(matches [][]string) string {
+	version := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		version = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Ruby " + version + " is required by the project, but it is not installed.",
+			"Please install the required Ruby version before running the debricked CLI.",
+		}, " ")
+}
+
+func (j *Job) getAuthenticationErrorDocumentation(matches [][]string) string {
+	source := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		source = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to authenticate to the gem source",
+			"\"" + source + "\".",
+			"Please supply credentials, for example with `bundle config set --global " + source + " username:password`.",
+			"\n" + util.InstallPrivateDependencyMessage,
+		}, " ")
+}
+
+func (j *Job) getGemNotFoundErrorDocumentation(matches [][]string) string {
+	gem := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		gem = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to find gem",
+			"\"" + gem + "\"",
+			"that satisfies the requirements.",
+			"Please check that dependencies are correct in the Gemfile.",
+			"\n" + util.InstallPrivateDependencyMessage,
+		}, " ")
+}
+
+func (j *Job) getVersionConflictErrorDocumentation(matches [][]string) string {
+	gem := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		gem = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to find a version of gem",
+			"\"" + gem + "\"",
+			"compatible with all requirements.",
+			"Please check that gem versions are correct in the Gemfile.",
+		}, " ")
+}
+
+func (j *Job) getInvalidGemfileErrorDocumentation(matches [][]string) string {
+	reason := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		reason = strings.TrimSuffix(strings.TrimSpace(matches[0][1]), ".")
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to evaluate the Gemfile: " + reason + ".",
+			"Please check that the Gemfile is valid.",
+		}, " ")
+}
+
+func (j *Job) getNoInternetErrorDocumentation(matches [][]string) string {
+	registry := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		registry = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Registry",
+			"\"" + registry + "\"",
+			"is not available at the moment.",
+			"There might be a trouble with your network connection.",
+		}, " ")
+}
@@ -0,0 +1,113 @@
+package bundler
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/bundler/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJob(t *testing.T) {
+	j := NewJob("file", testdata.CmdFactoryMock{})
+	assert.Equal(t, "file", j.GetFile())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRunLockCmdErr(t *testing.T) {
+	cases := []struct {
+		name  string
+		error string
+		doc   string
+	}{
+		{
+			name:  "General error",
+			error: "cmd-error",
+			doc:   util.UnknownError,
+		},
+		{
+			name:  "Bundler not found",
+			error: "exec: \"bundle\": executable file not found in $PATH",
+			doc:   "Bundler wasn't found. Please check if it is installed and accessible by the CLI.",
+		},
+		{
+			name:  "Wrong Ruby version",
+			error: "Your Ruby version is 3.0.2, but your Gemfile specified 3.2.2",
+			doc:   "The Gemfile requires Ruby 3.2.2, but Ruby 3.0.2 is installed. Please install the required Ruby version before running the debricked CLI.",
+		},
+		{
+			name:  "Ruby version not installed",
+			error: "rbenv: version `3.2.2' is not installed (set by /app/.ruby-version)",
+			doc:   "Ruby 3.2.2 is required by the project, but it is not installed. Please install the required Ruby version before running the debricked CLI.",
+		},
+		{
+			name:  "Private gem source",
+			error: "Authentication is required for gems.example.com.\nPlease supply credentials for this source. You can do this by running:\n`bundle config set --global gems.example.com username:password`",
+			doc:   "Failed to authenticate to the gem source \"gems.example.com\". Please supply credentials, for example with `bundle config set --global gems.example.com username:password`. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "Gem not found",
+			error: "Could not find gem 'railz' in rubygems repository https://rubygems.org/ or installed locally.",
+			doc:   "Failed to find gem \"railz\" that satisfies the requirements. Please check that dependencies are correct in the Gemfile. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "Version conflict",
+			error: "Bundler could not find compatible versions for gem \"activesupport\":\n  In Gemfile:\n    rails (= 7.1.0) was resolved to 7.1.0, which depends on\n      activesupport (= 7.1.0)",
+			doc:   "Failed to find a version of gem \"activesupport\" compatible with all requirements. Please check that gem versions are correct in the Gemfile.",
+		},
+		{
+			name:  "Invalid Gemfile",
+			error: "[!] There was an error parsing `Gemfile`: syntax error, unexpected end-of-input. Bundler cannot continue.",
+			doc:   "Failed to evaluate the Gemfile: syntax error, unexpected end-of-input. Bundler cannot continue. Please check that the Gemfile is valid.",
+		},
+		{
+			name:  "No internet connection",
+			error: "Could not reach host index.rubygems.org. Check your network connection and try again.",
+			doc:   "Registry \"index.rubygems.org\" is not available at the moment. There might be a trouble with your network connection.",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			j := NewJob("file", testdata.CmdFactoryMock{LockErr: errors.New(c.error), Name: "echo"})
+
+			go jobTestdata.WaitStatus(j)
+
+			j.Run()
+
+			errs := j.Errors().GetAll()
+			assert.Len(t, errs, 1)
+			assert.Equal(t, c.error, errs[0].Error())
+			assert.Equal(t, c.doc+"\n", errs[0].Documentation())
+			assert.Equal(t, "generating Gemfile.lock", errs[0].Status())
+		})
+	}
+}
+
+func TestRunUntrusted(t *testing.T) {
+	err := fmt.Errorf("project code: %w", util.ErrUntrustedProjectCode)
+	j := NewJob("file", testdata.CmdFactoryMock{LockErr: err, Name: "echo"})
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.Equal(t, err.Error(), errs[0].Error())
+	assert.Equal(t, util.UntrustedProjectCodeMessage+"\n", errs[0].Documentation())
+	assert.Equal(t, "generating Gemfile.lock", errs[0].Status())
+}
+
+func TestRunSuccess(t *testing.T) {
+	j := NewJob("file", testdata.CmdFactoryMock{Name: "echo"})
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+}
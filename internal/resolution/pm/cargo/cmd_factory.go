@@ -0,0 +1,50 @@
+package cargo
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+type ICmdFactory interface {
+	MakeLockCmd(manifestFile string, offline bool) (*exec.Cmd, error)
+}
+
+type IExecPath interface {
+	LookPath(file string) (string, error)
+}
+
+type ExecPath struct{}
+
+func (_ ExecPath) LookPath(file string) (string, error) {
+	return util.LookPath(file)
+}
+
+type CmdFactory struct {
+	execPath IExecPath
+}
+
+// MakeLockCmd creates an exec.Cmd that runs `cargo generate-lockfile` for the given manifest file.
+// Cargo writes Cargo.lock next to the workspace root manifest. If offline is set, `--offline` is passed
+// so that only crates already present in the local Cargo registry cache are used.
+func (cmdf CmdFactory) MakeLockCmd(manifestFile string, offline bool) (*exec.Cmd, error) {
+	cargoPath, err := cmdf.execPath.LookPath("cargo")
+	if err != nil {
+		return nil, err
+	}
+
+	workingDir := filepath.Dir(filepath.Clean(manifestFile))
+	args := []string{"cargo", "generate-lockfile", "--manifest-path", filepath.Base(manifestFile)}
+	if offline {
+		args = append(args, "--offline")
+	}
+
+	return &exec.Cmd{
+		Path: cargoPath,
+		Args: args,
+		Dir:  workingDir,
+		Env:  os.Environ(),
+	}, nil
+}
@@ -0,0 +1,45 @@
+package cargo
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type execPathMock struct {
+	err error
+}
+
+func (mock execPathMock) LookPath(file string) (string, error) {
+	return "/usr/bin/" + file, mock.err
+}
+
+func TestMakeLockCmd(t *testing.T) {
+	factory := CmdFactory{execPath: execPathMock{}}
+	manifest := filepath.Join("some", "path", "Cargo.toml")
+
+	cmd, err := factory.MakeLockCmd(manifest, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "/usr/bin/cargo", cmd.Path)
+	assert.Equal(t, []string{"cargo", "generate-lockfile", "--manifest-path", "Cargo.toml"}, cmd.Args)
+	assert.Equal(t, filepath.Dir(manifest), cmd.Dir)
+}
+
+func TestMakeLockCmdOffline(t *testing.T) {
+	factory := CmdFactory{execPath: execPathMock{}}
+
+	cmd, err := factory.MakeLockCmd(filepath.Join("some", "path", "Cargo.toml"), true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"cargo", "generate-lockfile", "--manifest-path", "Cargo.toml", "--offline"}, cmd.Args)
+}
+
+func TestMakeLockCmdExecutableNotFound(t *testing.T) {
+	lookPathErr := errors.New("exec: \"cargo\": executable file not found in $PATH")
+	factory := CmdFactory{execPath: execPathMock{err: lookPathErr}}
+
+	cmd, err := factory.MakeLockCmd("Cargo.toml", false)
+	assert.Nil(t, cmd)
+	assert.ErrorIs(t, err, lookPathErr)
+}
@@ -0,0 +1,228 @@
+package cargo
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+const (
+	executableNotFoundErrRegex = `executable file not found`
+	packageNotFoundErrRegex    = "no matching package named `([^`]+)` found"
+	versionNotFoundErrRegex    = "failed to select a version for the requirement `([^`]+)`"
+	authenticationErrRegex     = "failed to get `([^`]+)` as a dependency[\\s\\S]*failed to authenticate"
+	notWorkspaceMemberErrRegex = `current package believes it's in a workspace when it's not:\s*current:\s+(\S+)\s+workspace:\s+(\S+)`
+	workspaceMemberErrRegex    = "failed to load manifest for workspace member `([^`]+)`"
+	invalidManifestErrRegex    = "failed to parse manifest at `([^`]+)`"
+	noInternetErrRegex         = `Could not resolve host: ([^\s)]+)`
+)
+
+// Job runs `cargo generate-lockfile` for a Cargo.toml. If the crates registry can not be reached,
+// the lock file is generated again in offline mode, using the crates already in the local cache.
+type Job struct {
+	job.BaseJob
+	cmdFactory ICmdFactory
+}
+
+func NewJob(file string, cmdFactory ICmdFactory) *Job {
+	return &Job{
+		BaseJob:    job.NewBaseJob(file),
+		cmdFactory: cmdFactory,
+	}
+}
+
+func (j *Job) Run() {
+	status := "generating Cargo.lock"
+	j.SendStatus(status)
+
+	cmd, err := j.runLockCmd(false)
+	if err == nil {
+		j.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
+
+		return
+	}
+	if !regexp.MustCompile(noInternetErrRegex).MatchString(err.Error()) {
+		j.handleError(j.createError(err.Error(), cmd, status))
+
+		return
+	}
+
+	j.SendStatus("generating Cargo.lock offline")
+	if _, offlineErr := j.runLockCmd(true); offlineErr != nil {
+		// The network error is reported, since it is the root cause of the failure
+		j.handleError(j.createError(err.Error(), cmd, status))
+
+		return
+	}
+	j.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
+}
+
+func (j *Job) runLockCmd(offline bool) (string, error) {
+	lockCmd, err := j.cmdFactory.MakeLockCmd(j.GetFile(), offline)
+	if err != nil {
+		return "", err
+	}
+
+	if output, err := j.Sandboxed(lockCmd).Output(); err != nil {
+		exitErr := j.GetExitError(err, string(output))
+
+		return lockCmd.String(), exitErr
+	}
+
+	return lockCmd.String(), nil
+}
+
+func (j *Job) createError(errorStr string, cmd string, status string) job.IError {
+	cmdError := util.NewPMJobError(errorStr)
+	cmdError.SetCommand(cmd)
+	cmdError.SetStatus(status)
+
+	return cmdError
+}
+
+func (j *Job) handleError(cmdError job.IError) {
+	expressions := []string{
+		executableNotFoundErrRegex,
+		packageNotFoundErrRegex,
+		versionNotFoundErrRegex,
+		authenticationErrRegex,
+		notWorkspaceMemberErrRegex,
+		workspaceMemberErrRegex,
+		invalidManifestErrRegex,
+		noInternetErrRegex,
+	}
+
+	for _, expression := range expressions {
+		regex := regexp.MustCompile(expression)
+		matches := regex.FindAllStringSubmatch(cmdError.Error(), -1)
+
+		if len(matches) > 0 {
+			cmdError = j.addDocumentation(expression, matches, cmdError)
+			j.Errors().Append(cmdError)
+
+			return
+		}
+	}
+
+	j.Errors().Append(cmdError)
+}
+
+func (j *Job) addDocumentation(expr string, matches [][]string, cmdError job.IError) job.IError {
+	documentation := cmdError.Documentation()
+
+	switch expr {
+	case executableNotFoundErrRegex:
+		documentation = j.GetExecutableNotFoundErrorDocumentation("Cargo")
+	case packageNotFoundErrRegex:
+		documentation = j.getDependencyNotFoundErrorDocumentation(matches)
+	case versionNotFoundErrRegex:
+		documentation = j.getVersionNotFoundErrorDocumentation(matches)
+	case authenticationErrRegex:
+		documentation = j.getDependencyNotFoundErrorDocumentation(matches)
+	case notWorkspaceMemberErrRegex:
+		documentation = j.getNotWorkspaceMemberErrorDocumentation(matches)
+	case workspaceMemberErrRegex:
+		documentation = j.getWorkspaceMemberErrorDocumentation(matches)
+	case invalidManifestErrRegex:
+		documentation = j.getInvalidManifestErrorDocumentation(matches)
+	case noInternetErrRegex:
+		documentation = j.getNoInternetErrorDocumentation(matches)
+	}
+
+	cmdError.SetDocumentation(documentation)
+
+	return cmdError
+}
+
+func (j *Job) getDependencyNotFoundErrorDocumentation(matches [][]string) string {
+	dependency := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		dependency = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to find package",
+			"\"" + dependency + "\"",
+			"that satisfies the requirements.",
+			"Please check that dependencies are correct in the manifest file.",
+			"\n" + util.InstallPrivateDependencyMessage,
+		}, " ")
+}
+
+func (j *Job) getVersionNotFoundErrorDocumentation(matches [][]string) string {
+	requirement := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		requirement = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to find a version matching the requirement",
+			"\"" + requirement + "\".",
+			"Please check that package versions are correct in the manifest file.",
+		}, " ")
+}
+
+func (j *Job) getNotWorkspaceMemberErrorDocumentation(matches [][]string) string {
+	member := ""
+	workspace := ""
+	if len(matches) > 0 && len(matches[0]) > 2 {
+		member = matches[0][1]
+		workspace = matches[0][2]
+	}
+
+	return strings.Join(
+		[]string{
+			"\"" + member + "\" is located inside the workspace",
+			"\"" + workspace + "\"",
+			"but is not one of its members.",
+			"Please add it to `workspace.members` or `workspace.exclude` of the workspace manifest.",
+		}, " ")
+}
+
+func (j *Job) getWorkspaceMemberErrorDocumentation(matches [][]string) string {
+	member := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		member = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to load the workspace member",
+			"\"" + member + "\".",
+			"Please check that `workspace.members` only lists directories containing a valid Cargo.toml.",
+		}, " ")
+}
+
+func (j *Job) getInvalidManifestErrorDocumentation(matches [][]string) string {
+	manifest := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		manifest = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to parse",
+			"\"" + manifest + "\".",
+			"Please check that the manifest file is valid, for example by running `cargo metadata`.",
+		}, " ")
+}
+
+func (j *Job) getNoInternetErrorDocumentation(matches [][]string) string {
+	registry := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		registry = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Registry",
+			"\"" + registry + "\"",
+			"is not available at the moment.",
+			"There might be a trouble with your network connection.",
+			"Resolving offline failed as well, run `cargo fetch` while online to populate the local cache.",
+		}, " ")
+}
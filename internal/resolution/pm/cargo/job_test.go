@@ -0,0 +1,124 @@
+package cargo
+
+import (
+	"errors"
+	"testing"
+
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/cargo/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/stretchr/testify/assert"
+)
+
+const noInternetErr = "error: failed to get `serde` as a dependency of package `app v0.1.0 (/app)`\n\n" +
+	"Caused by:\n  download of config.json failed\n\n" +
+	"Caused by:\n  [6] Couldn't resolve host name (Could not resolve host: index.crates.io)"
+
+func TestNewJob(t *testing.T) {
+	j := NewJob("file", testdata.CmdFactoryMock{})
+	assert.Equal(t, "file", j.GetFile())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRunLockCmdErr(t *testing.T) {
+	cases := []struct {
+		name  string
+		error string
+		doc   string
+	}{
+		{
+			name:  "General error",
+			error: "cmd-error",
+			doc:   util.UnknownError,
+		},
+		{
+			name:  "Cargo not found",
+			error: "exec: \"cargo\": executable file not found in $PATH",
+			doc:   "Cargo wasn't found. Please check if it is installed and accessible by the CLI.",
+		},
+		{
+			name:  "Package not found",
+			error: "error: no matching package named `serdee` found\nlocation searched: registry `crates-io`\nrequired by package `app v0.1.0 (/app)`",
+			doc:   "Failed to find package \"serdee\" that satisfies the requirements. Please check that dependencies are correct in the manifest file. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "Version not found",
+			error: "error: failed to select a version for the requirement `serde = \"^99.0\"`\ncandidate versions found which didn't match: 1.0.188, 1.0.187",
+			doc:   "Failed to find a version matching the requirement \"serde = \"^99.0\"\". Please check that package versions are correct in the manifest file.",
+		},
+		{
+			name:  "Private git dependency",
+			error: "error: failed to get `internal` as a dependency of package `app v0.1.0 (/app)`\n\nCaused by:\n  failed to load source for dependency `internal`\n\nCaused by:\n  failed to authenticate when downloading repository: git@github.com:org/internal.git",
+			doc:   "Failed to find package \"internal\" that satisfies the requirements. Please check that dependencies are correct in the manifest file. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "Not a workspace member",
+			error: "error: current package believes it's in a workspace when it's not:\ncurrent:   /app/tools/Cargo.toml\nworkspace: /app/Cargo.toml\n\nthis may be fixable by adding `tools` to the `workspace.members` array",
+			doc:   "\"/app/tools/Cargo.toml\" is located inside the workspace \"/app/Cargo.toml\" but is not one of its members. Please add it to `workspace.members` or `workspace.exclude` of the workspace manifest.",
+		},
+		{
+			name:  "Invalid workspace member",
+			error: "error: failed to load manifest for workspace member `/app/crates/missing`\n\nCaused by:\n  failed to read `/app/crates/missing/Cargo.toml`",
+			doc:   "Failed to load the workspace member \"/app/crates/missing\". Please check that `workspace.members` only lists directories containing a valid Cargo.toml.",
+		},
+		{
+			name:  "Invalid manifest",
+			error: "error: failed to parse manifest at `/app/Cargo.toml`\n\nCaused by:\n  TOML parse error at line 3, column 1",
+			doc:   "Failed to parse \"/app/Cargo.toml\". Please check that the manifest file is valid, for example by running `cargo metadata`.",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			j := NewJob("file", testdata.CmdFactoryMock{LockErr: errors.New(c.error), Name: "echo"})
+
+			go jobTestdata.WaitStatus(j)
+
+			j.Run()
+
+			errs := j.Errors().GetAll()
+			assert.Len(t, errs, 1)
+			assert.Contains(t, errs[0].Error(), c.error)
+			assert.Equal(t, c.doc+"\n", errs[0].Documentation())
+			assert.Equal(t, "generating Cargo.lock", errs[0].Status())
+		})
+	}
+}
+
+func TestRunNoInternetResolvesOffline(t *testing.T) {
+	j := NewJob("file", testdata.CmdFactoryMock{LockErr: errors.New(noInternetErr), Name: "echo"})
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRunNoInternetOfflineErr(t *testing.T) {
+	offlineErr := errors.New("error: no matching package named `serde` found\nAs a reminder, you're using offline mode (--offline)")
+	j := NewJob("file", testdata.CmdFactoryMock{LockErr: errors.New(noInternetErr), OfflineLockErr: offlineErr, Name: "echo"})
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "Could not resolve host: index.crates.io")
+	assert.Equal(
+		t,
+		"Registry \"index.crates.io\" is not available at the moment. There might be a trouble with your network connection. Resolving offline failed as well, run `cargo fetch` while online to populate the local cache.\n",
+		errs[0].Documentation(),
+	)
+}
+
+func TestRunSuccess(t *testing.T) {
+	j := NewJob("file", testdata.CmdFactoryMock{Name: "echo"})
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+}
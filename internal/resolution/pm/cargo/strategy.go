@@ -0,0 +1,140 @@
+package cargo
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/pelletier/go-toml/v2"
+)
+
+const (
+	manifestFileName = "Cargo.toml"
+	lockFileName     = "Cargo.lock"
+)
+
+type Strategy struct {
+	files []string
+}
+
+func NewStrategy(files []string) Strategy {
+	return Strategy{files: files}
+}
+
+// Invoke creates one job per workspace. Cargo writes a single Cargo.lock at the workspace root, so
+// members are resolved through their workspace root manifest. Members of a workspace that already
+// has a Cargo.lock are skipped, unless the workspace root manifest itself is up for resolution.
+func (s Strategy) Invoke() ([]job.IJob, error) {
+	var jobs []job.IJob
+	requested := make(map[string]bool)
+	for _, file := range s.files {
+		requested[filepath.Clean(file)] = true
+	}
+
+	resolved := make(map[string]bool)
+	for _, file := range s.files {
+		manifest := findWorkspaceRoot(filepath.Clean(file))
+		if resolved[manifest] {
+			continue
+		}
+		if !requested[manifest] && hasLockFile(manifest) {
+			continue
+		}
+		resolved[manifest] = true
+		jobs = append(jobs, NewJob(
+			manifest,
+			CmdFactory{execPath: ExecPath{}},
+		))
+	}
+
+	return jobs, nil
+}
+
+type cargoManifest struct {
+	Package *struct {
+		Workspace string `toml:"workspace"`
+	} `toml:"package"`
+	Workspace *struct {
+		Members []string `toml:"members"`
+		Exclude []string `toml:"exclude"`
+	} `toml:"workspace"`
+}
+
+func readManifest(manifestFile string) *cargoManifest {
+	content, err := os.ReadFile(manifestFile)
+	if err != nil {
+		return nil
+	}
+	var manifest cargoManifest
+	if toml.Unmarshal(content, &manifest) != nil {
+		return nil
+	}
+
+	return &manifest
+}
+
+// findWorkspaceRoot returns the manifest of the workspace that manifestFile belongs to, or manifestFile
+// itself if it is a workspace root or a standalone package. Manifests that can not be read are
+// returned as is, leaving it to Cargo to report the problem.
+func findWorkspaceRoot(manifestFile string) string {
+	manifest := readManifest(manifestFile)
+	if manifest == nil || manifest.Workspace != nil {
+		return manifestFile
+	}
+
+	memberDir := filepath.Dir(manifestFile)
+	if manifest.Package != nil && manifest.Package.Workspace != "" {
+		return filepath.Join(memberDir, manifest.Package.Workspace, manifestFileName)
+	}
+
+	for dir := filepath.Dir(memberDir); ; dir = filepath.Dir(dir) {
+		rootFile := filepath.Join(dir, manifestFileName)
+		root := readManifest(rootFile)
+		if root != nil && root.Workspace != nil && isWorkspaceMember(dir, root, memberDir) {
+			return rootFile
+		}
+		if dir == filepath.Dir(dir) {
+			break
+		}
+	}
+
+	return manifestFile
+}
+
+func isWorkspaceMember(rootDir string, root *cargoManifest, memberDir string) bool {
+	relPath, err := filepath.Rel(rootDir, memberDir)
+	if err != nil {
+		return false
+	}
+	relPath = filepath.ToSlash(relPath)
+
+	for _, exclude := range root.Workspace.Exclude {
+		if matchesMemberPattern(exclude, relPath) {
+			return false
+		}
+	}
+	for _, member := range root.Workspace.Members {
+		if matchesMemberPattern(member, relPath) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchesMemberPattern(pattern string, relPath string) bool {
+	pattern = path.Clean(filepath.ToSlash(pattern))
+	if pattern == relPath {
+		return true
+	}
+	matched, err := path.Match(pattern, relPath)
+
+	return err == nil && matched
+}
+
+func hasLockFile(manifestFile string) bool {
+	_, err := os.Stat(filepath.Join(filepath.Dir(manifestFile), lockFileName))
+
+	return err == nil
+}
@@ -0,0 +1,93 @@
+package cargo
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+}
+
+func jobFiles(t *testing.T, s Strategy) []string {
+	jobs, err := s.Invoke()
+	assert.NoError(t, err)
+	files := []string{}
+	for _, j := range jobs {
+		files = append(files, j.GetFile())
+	}
+
+	return files
+}
+
+func TestNewStrategy(t *testing.T) {
+	s := NewStrategy(nil)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 0)
+
+	s = NewStrategy([]string{"file"})
+	assert.Len(t, s.files, 1)
+}
+
+func TestStrategyInvokeStandalonePackages(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a/Cargo.toml": "[package]\nname = \"a\"",
+		"b/Cargo.toml": "[package]\nname = \"b\"",
+	})
+	files := []string{filepath.Join(dir, "a", "Cargo.toml"), filepath.Join(dir, "b", "Cargo.toml")}
+
+	assert.Equal(t, files, jobFiles(t, NewStrategy(files)))
+}
+
+func TestStrategyInvokeWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"Cargo.toml":                 "[workspace]\nmembers = [\"crates/*\", \"cli\"]\nexclude = [\"crates/excluded\"]",
+		"cli/Cargo.toml":             "[package]\nname = \"cli\"",
+		"crates/core/Cargo.toml":     "[package]\nname = \"core\"",
+		"crates/excluded/Cargo.toml": "[package]\nname = \"excluded\"",
+		"tools/Cargo.toml":           "[package]\nname = \"tools\"\nworkspace = \"..\"",
+	})
+	root := filepath.Join(dir, "Cargo.toml")
+	excluded := filepath.Join(dir, "crates", "excluded", "Cargo.toml")
+
+	files := jobFiles(t, NewStrategy([]string{
+		filepath.Join(dir, "cli", "Cargo.toml"),
+		filepath.Join(dir, "crates", "core", "Cargo.toml"),
+		excluded,
+		filepath.Join(dir, "tools", "Cargo.toml"),
+		root,
+	}))
+
+	assert.Equal(t, []string{root, excluded}, files)
+}
+
+func TestStrategyInvokeWorkspaceWithLockFile(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"Cargo.toml":             "[workspace]\nmembers = [\"crates/core\"]",
+		"Cargo.lock":             "version = 3",
+		"crates/core/Cargo.toml": "[package]\nname = \"core\"",
+	})
+	root := filepath.Join(dir, "Cargo.toml")
+	member := filepath.Join(dir, "crates", "core", "Cargo.toml")
+
+	assert.Empty(t, jobFiles(t, NewStrategy([]string{member})))
+	assert.Equal(t, []string{root}, jobFiles(t, NewStrategy([]string{member, root})))
+}
+
+func TestStrategyInvokeUnreadableManifest(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"invalid/Cargo.toml": "[package"})
+	files := []string{filepath.Join(dir, "invalid", "Cargo.toml"), filepath.Join(dir, "missing", "Cargo.toml")}
+
+	assert.Equal(t, files, jobFiles(t, NewStrategy(files)))
+}
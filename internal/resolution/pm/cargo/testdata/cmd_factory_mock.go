@@ -0,0 +1,30 @@
+package testdata
+
+import (
+	"os/exec"
+	"runtime"
+)
+
+type CmdFactoryMock struct {
+	LockErr        error
+	OfflineLockErr error
+	Name           string
+	Arg            string
+}
+
+func (f CmdFactoryMock) MakeLockCmd(_ string, offline bool) (*exec.Cmd, error) {
+	if len(f.Arg) == 0 {
+		f.Arg = `"MakeLockCmd"`
+	}
+
+	err := f.LockErr
+	if offline {
+		err = f.OfflineLockErr
+	}
+
+	if runtime.GOOS == "windows" && f.Name == "echo" {
+		return exec.Command("cmd", "/C", f.Name, f.Arg), err
+	}
+
+	return exec.Command(f.Name, f.Arg), err
+}
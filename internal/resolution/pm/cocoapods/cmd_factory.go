@@ -0,0 +1,46 @@
+package cocoapods
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+type ICmdFactory interface {
+	MakeInstallCmd(manifestFile string) (*exec.Cmd, error)
+}
+
+type IExecPath interface {
+	LookPath(file string) (string, error)
+}
+
+type ExecPath struct{}
+
+func (_ ExecPath) LookPath(file string) (string, error) {
+	return util.LookPath(file)
+}
+
+type CmdFactory struct {
+	execPath IExecPath
+}
+
+// MakeInstallCmd creates an exec.Cmd that runs `pod install` in the directory
+// of the given Podfile. CocoaPods has no way of only resolving dependencies, so
+// the pods are installed as well, writing a Podfile.lock next to the Podfile.
+func (cmdf CmdFactory) MakeInstallCmd(manifestFile string) (*exec.Cmd, error) {
+	podPath, err := cmdf.execPath.LookPath("pod")
+	if err != nil {
+		return nil, err
+	}
+
+	workingDir := filepath.Dir(filepath.Clean(manifestFile))
+
+	return &exec.Cmd{
+		Path: podPath,
+		Args: []string{"pod", "install", "--ansi=false"},
+		Dir:  workingDir,
+		Env:  append(os.Environ(), "COCOAPODS_DISABLE_STATS=true"),
+	}, nil
+}
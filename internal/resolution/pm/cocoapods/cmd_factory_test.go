@@ -0,0 +1,26 @@
+package cocoapods
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type execPathMock struct{}
+
+func (execPathMock) LookPath(file string) (string, error) {
+	return "/usr/bin/" + file, nil
+}
+
+func TestMakeInstallCmd(t *testing.T) {
+	factory := CmdFactory{execPath: execPathMock{}}
+	manifest := filepath.Join("some", "path", "Podfile")
+
+	cmd, err := factory.MakeInstallCmd(manifest)
+	assert.NoError(t, err)
+	assert.Equal(t, "/usr/bin/pod", cmd.Path)
+	assert.Equal(t, []string{"pod", "install", "--ansi=false"}, cmd.Args)
+	assert.Equal(t, filepath.Dir(manifest), cmd.Dir)
+	assert.Contains(t, cmd.Env, "COCOAPODS_DISABLE_STATS=true")
+}
@@ -0,0 +1,181 @@
+package cocoapods
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+const (
+	executableNotFoundErrRegex  = `executable file not found`
+	specNotFoundErrRegex        = "Unable to find a specification for `([^`]+)`"
+	noSatisfyingSpecErrRegex    = "None of your spec sources contain a spec satisfying the dependency: `([^`]+)`"
+	incompatibleVersionErrRegex = `CocoaPods could not find compatible versions for pod "([^"]+)"`
+	invalidPodfileErrRegex      = "Invalid `Podfile` file: ([^\\n]+)"
+	noXcodeProjectErrRegex      = `Could not automatically select an Xcode project|No Xcode project found`
+	noInternetErrRegex          = `Could not resolve host: ([^\s)'"]+)`
+	lockFileName                = "Podfile.lock"
+)
+
+// Job runs `pod install` for a Podfile, producing a Podfile.lock.
+type Job struct {
+	job.BaseJob
+	cmdFactory ICmdFactory
+}
+
+func NewJob(file string, cmdFactory ICmdFactory) *Job {
+	return &Job{
+		BaseJob:    job.NewBaseJob(file),
+		cmdFactory: cmdFactory,
+	}
+}
+
+func (j *Job) Run() {
+	status := "generating Podfile.lock"
+	j.SendStatus(status)
+
+	installCmd, err := j.cmdFactory.MakeInstallCmd(j.GetFile())
+	if err != nil {
+		j.handleError(j.createError(err.Error(), "", status))
+
+		return
+	}
+
+	if output, err := j.Sandboxed(installCmd).Output(); err != nil {
+		exitErr := j.GetExitError(err, string(output))
+		errorMessage := strings.Join([]string{string(output), exitErr.Error()}, "")
+		j.handleError(j.createError(errorMessage, installCmd.String(), status))
+
+		return
+	}
+	j.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
+}
+
+func (j *Job) createError(errorStr string, cmd string, status string) job.IError {
+	cmdError := util.NewPMJobError(errorStr)
+	cmdError.SetCommand(cmd)
+	cmdError.SetStatus(status)
+
+	return cmdError
+}
+
+func (j *Job) handleError(cmdError job.IError) {
+	expressions := []string{
+		executableNotFoundErrRegex,
+		specNotFoundErrRegex,
+		noSatisfyingSpecErrRegex,
+		incompatibleVersionErrRegex,
+		invalidPodfileErrRegex,
+		noXcodeProjectErrRegex,
+		noInternetErrRegex,
+	}
+
+	for _, expression := range expressions {
+		regex := regexp.MustCompile(expression)
+		matches := regex.FindAllStringSubmatch(cmdError.Error(), -1)
+
+		if len(matches) > 0 {
+			cmdError = j.addDocumentation(expression, matches, cmdError)
+			j.Errors().Append(cmdError)
+
+			return
+		}
+	}
+
+	j.Errors().Append(cmdError)
+}
+
+func (j *Job) addDocumentation(expr string, matches [][]string, cmdError job.IError) job.IError {
+	documentation := cmdError.Documentation()
+
+	switch expr {
+	case executableNotFoundErrRegex:
+		documentation = j.GetExecutableNotFoundErrorDocumentation("CocoaPods")
+	case specNotFoundErrRegex:
+		documentation = j.getDependencyNotFoundErrorDocumentation(matches)
+	case noSatisfyingSpecErrRegex:
+		documentation = j.getDependencyNotFoundErrorDocumentation(matches)
+	case incompatibleVersionErrRegex:
+		documentation = j.getIncompatibleVersionErrorDocumentation(matches)
+	case invalidPodfileErrRegex:
+		documentation = j.getInvalidPodfileErrorDocumentation(matches)
+	case noXcodeProjectErrRegex:
+		documentation = j.getNoXcodeProjectErrorDocumentation()
+	case noInternetErrRegex:
+		documentation = j.getNoInternetErrorDocumentation(matches)
+	}
+
+	cmdError.SetDocumentation(documentation)
+
+	return cmdError
+}
+
+func (j *Job) getDependencyNotFoundErrorDocumentation(matches [][]string) string {
+	dependency := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		dependency = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to find pod",
+			"\"" + dependency + "\"",
+			"that satisfies the requirements.",
+			"Please check that dependencies are correct in the Podfile.",
+			"\n" + util.InstallPrivateDependencyMessage,
+		}, " ")
+}
+
+func (j *Job) getIncompatibleVersionErrorDocumentation(matches [][]string) string {
+	dependency := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		dependency = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to find a version of pod",
+			"\"" + dependency + "\"",
+			"compatible with all requirements.",
+			"Please check that pod versions are correct in the Podfile.",
+		}, " ")
+}
+
+func (j *Job) getInvalidPodfileErrorDocumentation(matches [][]string) string {
+	reason := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		reason = strings.TrimSuffix(strings.TrimSpace(matches[0][1]), ".")
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to evaluate the Podfile: " + reason + ".",
+			"Please check that the Podfile is valid.",
+		}, " ")
+}
+
+func (j *Job) getNoXcodeProjectErrorDocumentation() string {
+	return strings.Join(
+		[]string{
+			"CocoaPods requires an Xcode project to install pods into.",
+			"Please make sure the project is checked out next to the Podfile,",
+			"or specify it in the Podfile with `project 'Path/To/App.xcodeproj'`.",
+		}, " ")
+}
+
+func (j *Job) getNoInternetErrorDocumentation(matches [][]string) string {
+	registry := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		registry = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Registry",
+			"\"" + registry + "\"",
+			"is not available at the moment.",
+			"There might be a trouble with your network connection.",
+		}, " ")
+}
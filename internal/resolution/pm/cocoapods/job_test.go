@@ -0,0 +1,92 @@
+package cocoapods
+
+import (
+	"errors"
+	"testing"
+
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/cocoapods/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJob(t *testing.T) {
+	j := NewJob("file", testdata.CmdFactoryMock{})
+	assert.Equal(t, "file", j.GetFile())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRunInstallCmdErr(t *testing.T) {
+	cases := []struct {
+		name  string
+		error string
+		doc   string
+	}{
+		{
+			name:  "General error",
+			error: "cmd-error",
+			doc:   util.UnknownError,
+		},
+		{
+			name:  "CocoaPods not found",
+			error: "exec: \"pod\": executable file not found in $PATH",
+			doc:   "CocoaPods wasn't found. Please check if it is installed and accessible by the CLI.",
+		},
+		{
+			name:  "Spec not found",
+			error: "[!] Unable to find a specification for `PrivateKit` depended upon by `App`",
+			doc:   "Failed to find pod \"PrivateKit\" that satisfies the requirements. Please check that dependencies are correct in the Podfile. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "No satisfying spec",
+			error: "[!] CocoaPods could not find compatible versions for pod \"Alamofire\":\n  In Podfile:\n    Alamofire (~> 99.0)\n\nNone of your spec sources contain a spec satisfying the dependency: `Alamofire (~> 99.0)`.",
+			doc:   "Failed to find pod \"Alamofire (~> 99.0)\" that satisfies the requirements. Please check that dependencies are correct in the Podfile. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "Incompatible versions",
+			error: "[!] CocoaPods could not find compatible versions for pod \"Firebase/Core\":\n  In Podfile:\n    Firebase/Core (= 10.0.0)\n    FirebaseUI (= 13.0.0) was resolved to 13.0.0, which depends on\n      Firebase/Auth (~> 10.7)",
+			doc:   "Failed to find a version of pod \"Firebase/Core\" compatible with all requirements. Please check that pod versions are correct in the Podfile.",
+		},
+		{
+			name:  "Invalid Podfile",
+			error: "[!] Invalid `Podfile` file: undefined local variable or method `pood' for #<Pod::Podfile:0x000>.\n\n #  from /app/Podfile:3",
+			doc:   "Failed to evaluate the Podfile: undefined local variable or method `pood' for #<Pod::Podfile:0x000>. Please check that the Podfile is valid.",
+		},
+		{
+			name:  "No Xcode project",
+			error: "[!] Could not automatically select an Xcode project. Specify one in your Podfile like so:\n\n    project 'path/to/Project.xcodeproj'",
+			doc:   "CocoaPods requires an Xcode project to install pods into. Please make sure the project is checked out next to the Podfile, or specify it in the Podfile with `project 'Path/To/App.xcodeproj'`.",
+		},
+		{
+			name:  "No internet connection",
+			error: "[!] CDN: trunk URL couldn't be downloaded: https://cdn.cocoapods.org/all_pods_versions_2_2_2.txt Response: Couldn't resolve host name (Could not resolve host: cdn.cocoapods.org)",
+			doc:   "Registry \"cdn.cocoapods.org\" is not available at the moment. There might be a trouble with your network connection.",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			j := NewJob("file", testdata.CmdFactoryMock{InstallErr: errors.New(c.error), Name: "echo"})
+
+			go jobTestdata.WaitStatus(j)
+
+			j.Run()
+
+			errs := j.Errors().GetAll()
+			assert.Len(t, errs, 1)
+			assert.Equal(t, c.error, errs[0].Error())
+			assert.Equal(t, c.doc+"\n", errs[0].Documentation())
+			assert.Equal(t, "generating Podfile.lock", errs[0].Status())
+		})
+	}
+}
+
+func TestRunSuccess(t *testing.T) {
+	j := NewJob("file", testdata.CmdFactoryMock{Name: "echo"})
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+}
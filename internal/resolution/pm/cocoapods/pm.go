@@ -0,0 +1,23 @@
+package cocoapods
+
+const Name = "cocoapods"
+
+type Pm struct {
+	name string
+}
+
+func NewPm() Pm {
+	return Pm{
+		name: Name,
+	}
+}
+
+func (pm Pm) Name() string {
+	return pm.name
+}
+
+func (_ Pm) Manifests() []string {
+	return []string{
+		`Podfile$`,
+	}
+}
@@ -0,0 +1,31 @@
+package cocoapods
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStrategy(t *testing.T) {
+	s := NewStrategy(nil)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 0)
+
+	s = NewStrategy([]string{"file"})
+	assert.Len(t, s.files, 1)
+}
+
+func TestStrategyInvoke(t *testing.T) {
+	cases := [][]string{
+		{},
+		{"Podfile"},
+		{"a/Podfile", "b/Podfile"},
+	}
+
+	for _, files := range cases {
+		s := NewStrategy(files)
+		jobs, err := s.Invoke()
+		assert.NoError(t, err)
+		assert.Len(t, jobs, len(files))
+	}
+}
@@ -0,0 +1,24 @@
+package testdata
+
+import (
+	"os/exec"
+	"runtime"
+)
+
+type CmdFactoryMock struct {
+	InstallErr error
+	Name       string
+	Arg        string
+}
+
+func (f CmdFactoryMock) MakeInstallCmd(_ string) (*exec.Cmd, error) {
+	if len(f.Arg) == 0 {
+		f.Arg = `"MakeInstallCmd"`
+	}
+
+	if runtime.GOOS == "windows" && f.Name == "echo" {
+		return exec.Command("cmd", "/C", f.Name, f.Arg), f.InstallErr
+	}
+
+	return exec.Command(f.Name, f.Arg), f.InstallErr
+}
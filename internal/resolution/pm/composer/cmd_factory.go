@@ -4,6 +4,9 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/registry"
 )
 
 type ICmdFactory interface {
@@ -18,19 +21,19 @@ type ExecPath struct {
 }
 
 func (ExecPath) LookPath(file string) (string, error) {
-	return exec.LookPath(file)
+	return util.LookPath(file)
 }
 
 type CmdFactory struct {
-	execPath IExecPath
+	execPath   IExecPath
+	registries *registry.Config
 }
 
 func (cmdf CmdFactory) MakeInstallCmd(command string, file string) (*exec.Cmd, error) {
 	path, err := cmdf.execPath.LookPath(command)
 
 	fileDir := filepath.Dir(file)
-
-	return &exec.Cmd{
+	cmd := &exec.Cmd{
 		Path: path,
 		Args: []string{command, "update",
 			"--no-interaction",       // We can't answer any prompts...
@@ -43,5 +46,8 @@ func (cmdf CmdFactory) MakeInstallCmd(command string, file string) (*exec.Cmd, e
 		},
 		Dir: fileDir,
 		Env: os.Environ(),
-	}, err
+	}
+	cmdf.registries.ApplyComposer(cmd)
+
+	return cmd, err
 }
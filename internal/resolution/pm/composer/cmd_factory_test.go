@@ -3,6 +3,7 @@ package composer
 import (
 	"testing"
 
+	"github.com/debricked/cli/internal/resolution/registry"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,3 +18,22 @@ func TestMakeInstallCmd(t *testing.T) {
 	assert.Contains(t, args, "composer")
 	assert.Contains(t, args, "update")
 }
+
+type execPathMock struct{}
+
+func (execPathMock) LookPath(file string) (string, error) {
+	return file, nil
+}
+
+func TestMakeInstallCmdRegistry(t *testing.T) {
+	registries := &registry.Config{Composer: &registry.Registry{Url: "https://composer.example.com"}}
+	assert.NoError(t, registries.Write(t.TempDir()))
+	cmd, err := CmdFactory{
+		execPath:   execPathMock{},
+		registries: registries,
+	}.MakeInstallCmd("composer", "file")
+	assert.NoError(t, err)
+	assert.Contains(t, cmd.Args, "update")
+	assert.NotEmpty(t, cmd.Env)
+	assert.Contains(t, cmd.Env[len(cmd.Env)-1], "COMPOSER_HOME=")
+}
@@ -16,6 +16,7 @@ const (
 	noNetworkRegex              = `The following exception probably indicates you( are offline or)? have misconfigured DNS resolver\(s\)`
 	invalidVersionErrRegex      = `requires\s+([^/]+/[^,]+),\s+found.*but it does not match the constraint\.`
 	dependenciesResolveErrRegex = `requires\s+([^/]+/[^,]+),\s+it\s+could\s+not\s+be\s+found\s+in\s+any\s+version`
+	lockFileName                = "composer.lock"
 )
 
 type Job struct {
@@ -52,6 +53,7 @@ func (j *Job) Run() {
 
 			return
 		}
+		j.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
 	}
 
 }
@@ -63,7 +65,7 @@ func (j *Job) runInstallCmd() ([]byte, error) {
 		return nil, err
 	}
 
-	installCmdOutput, err := installCmd.Output()
+	installCmdOutput, err := j.Sandboxed(installCmd).Output()
 	if err != nil {
 		return nil, j.GetExitError(err, string(installCmdOutput))
 	}
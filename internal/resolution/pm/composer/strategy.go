@@ -2,10 +2,12 @@ package composer
 
 import (
 	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/registry"
 )
 
 type Strategy struct {
-	files []string
+	files      []string
+	registries *registry.Config
 }
 
 func (s Strategy) Invoke() ([]job.IJob, error) {
@@ -15,7 +17,8 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 			file,
 			true,
 			CmdFactory{
-				execPath: ExecPath{},
+				execPath:   ExecPath{},
+				registries: s.registries,
 			},
 		),
 		)
@@ -24,6 +27,7 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 	return jobs, nil
 }
 
-func NewStrategy(files []string) Strategy {
-	return Strategy{files}
+// NewStrategy makes jobs resolving files through registries, unless registries is nil
+func NewStrategy(files []string, registries *registry.Config) Strategy {
+	return Strategy{files, registries}
 }
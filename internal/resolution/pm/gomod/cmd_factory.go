@@ -1,6 +1,10 @@
 package gomod
 
-import "os/exec"
+import (
+	"os/exec"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
 
 type ICmdFactory interface {
 	MakeGraphCmd(workingDirectory string) (*exec.Cmd, error)
@@ -11,7 +15,7 @@ type ICmdFactory interface {
 type CmdFactory struct{}
 
 func (_ CmdFactory) MakeGraphCmd(workingDirectory string) (*exec.Cmd, error) {
-	path, err := exec.LookPath("go")
+	path, err := util.LookPath("go")
 
 	return &exec.Cmd{
 		Path: path,
@@ -21,7 +25,7 @@ func (_ CmdFactory) MakeGraphCmd(workingDirectory string) (*exec.Cmd, error) {
 }
 
 func (_ CmdFactory) MakeListCmd(workingDirectory string) (*exec.Cmd, error) {
-	path, err := exec.LookPath("go")
+	path, err := util.LookPath("go")
 
 	return &exec.Cmd{
 		Path: path,
@@ -31,7 +35,7 @@ func (_ CmdFactory) MakeListCmd(workingDirectory string) (*exec.Cmd, error) {
 }
 
 func (_ CmdFactory) MakeListJsonCmd(workingDirectory string) (*exec.Cmd, error) {
-	path, err := exec.LookPath("go")
+	path, err := util.LookPath("go")
 
 	return &exec.Cmd{
 		Path: path,
@@ -79,7 +79,8 @@ func (j *Job) Run() {
 
 	status = "creating lock file"
 	j.SendStatus(status)
-	lockFile, err := j.fileWriter.Create(util.MakePathFromManifestFile(j.GetFile(), fileName))
+	lockFilePath := util.MakePathFromManifestFile(j.GetFile(), fileName)
+	lockFile, err := j.fileWriter.Create(lockFilePath)
 	if err != nil {
 		j.handleError(j.createError(err.Error(), cmd, status))
 
@@ -100,7 +101,10 @@ func (j *Job) Run() {
 	err = j.fileWriter.Write(lockFile, fileContents)
 	if err != nil {
 		j.handleError(j.createError(err.Error(), cmd, status))
+
+		return
 	}
+	j.AddLockFile(lockFilePath)
 }
 
 func (j *Job) getWorkingDir() string {
@@ -135,7 +139,7 @@ func (j *Job) runListJsonCmd() ([]byte, string, error) {
 }
 
 func (j *Job) handleCmdOutput(cmd *exec.Cmd) ([]byte, string, error) {
-	output, err := cmd.Output()
+	output, err := j.Sandboxed(cmd).Output()
 	if err != nil {
 		return nil, cmd.String(), j.GetExitError(err, "")
 	}
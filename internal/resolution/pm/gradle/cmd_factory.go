@@ -2,6 +2,8 @@ package gradle
 
 import (
 	"os/exec"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
 )
 
 type ICmdFactory interface {
@@ -12,7 +14,7 @@ type ICmdFactory interface {
 type CmdFactory struct{}
 
 func (cf CmdFactory) MakeFindSubGraphCmd(workingDirectory string, gradlew string, initScript string) (*exec.Cmd, error) {
-	path, err := exec.LookPath(gradlew)
+	path, err := util.LookPath(gradlew)
 
 	return &exec.Cmd{
 		Path: path,
@@ -22,7 +24,7 @@ func (cf CmdFactory) MakeFindSubGraphCmd(workingDirectory string, gradlew string
 }
 
 func (cf CmdFactory) MakeDependenciesGraphCmd(workingDirectory string, gradlew string, initScript string) (*exec.Cmd, error) {
-	path, err := exec.LookPath(gradlew)
+	path, err := util.LookPath(gradlew)
 
 	return &exec.Cmd{
 		Path: path,
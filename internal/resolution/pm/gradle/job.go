@@ -11,12 +11,16 @@ import (
 	"github.com/debricked/cli/internal/resolution/pm/writer"
 )
 
+// LockFile is the name of the lock files written by Gradle, one per project
+const LockFile = "gradle.debricked.lock"
+
 const (
 	bugErrRegex                = "BUG! (.*)"
 	executableNotFoundErrRegex = `executable file not found`
 	notRootDirErrRegex         = "Error: (Could not find or load main class .*)"
 	unrelatedBuildErrRegex     = "(Project directory '.*' is not part of the build defined by settings file '.*')"
 	unknownPropertyErrRegex    = "(Could not get unknown property .*)"
+	subProjectLockFilePattern  = "*/" + LockFile
 )
 
 type Job struct {
@@ -24,8 +28,10 @@ type Job struct {
 	dir              string
 	gradlew          string
 	groovyInitScript string
-	cmdFactory       ICmdFactory
-	fileWriter       writer.IFileWriter
+	// subProjectDirs are the directories of the subprojects resolved along with the project in dir
+	subProjectDirs []string
+	cmdFactory     ICmdFactory
+	fileWriter     writer.IFileWriter
 }
 
 func NewJob(
@@ -73,7 +79,7 @@ func (j *Job) Run() {
 
 	status := "creating dependency graph"
 	j.SendStatus(status)
-	_, err = dependenciesCmd.Output()
+	_, err = j.Sandboxed(dependenciesCmd).Output()
 
 	if permissionErr != nil {
 		cmdErr := util.NewPMJobError(permissionErr.Error())
@@ -88,6 +94,20 @@ func (j *Job) Run() {
 
 		return
 	}
+
+	// Lock files of subprojects are written in their directories, below the directory of this job
+	err = j.Retrieve(subProjectLockFilePattern)
+	if err != nil {
+		cmdErr := util.NewPMJobError(err.Error())
+		cmdErr.SetStatus("copying lock files of subprojects")
+		j.handleError(cmdErr)
+
+		return
+	}
+	j.AddLockFile(filepath.Join(workingDirectory, LockFile))
+	for _, subProjectDir := range j.subProjectDirs {
+		j.AddLockFile(filepath.Join(subProjectDir, LockFile))
+	}
 }
 
 func (j *Job) GetDir() string {
@@ -2,6 +2,7 @@ package gradle
 
 import (
 	"errors"
+	"path/filepath"
 	"testing"
 
 	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
@@ -204,3 +205,19 @@ func TestRun(t *testing.T) {
 	assert.False(t, j.Errors().HasError())
 	assert.Equal(t, fileContents, fileWriterMock.Contents)
 }
+
+func TestRunAddsLockFilesOfSubProjects(t *testing.T) {
+	j := NewJob("file", "dir", "gradlew", "path", testdata.CmdFactoryMock{Name: "echo"}, writer.FileWriter{})
+	j.subProjectDirs = []string{filepath.Join("dir", "app"), filepath.Join("dir", "lib")}
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.Equal(t, []string{
+		filepath.Join("dir", LockFile),
+		filepath.Join("dir", "app", LockFile),
+		filepath.Join("dir", "lib", LockFile),
+	}, j.LockFiles())
+}
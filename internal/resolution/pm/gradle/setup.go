@@ -3,6 +3,7 @@ package gradle
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"embed"
 	"os"
 	"path/filepath"
@@ -10,6 +11,8 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
 	"github.com/debricked/cli/internal/resolution/pm/writer"
 	internalOs "github.com/debricked/cli/internal/runtime/os"
 )
@@ -24,7 +27,7 @@ const (
 var gradleInitScript embed.FS
 
 type ISetup interface {
-	Configure(files []string, paths []string) (Setup, error)
+	Configure(ctx context.Context, files []string, paths []string) (Setup, error)
 }
 
 type Setup struct {
@@ -63,7 +66,7 @@ func NewGradleSetup() *Setup {
 	}
 }
 
-func (gs *Setup) Configure(_ []string, paths []string) (Setup, error) {
+func (gs *Setup) Configure(ctx context.Context, files []string, paths []string) (Setup, error) {
 	err := gs.InitScriptHandler.WriteInitFile(gs.groovyScriptPath, gs.Writer)
 	if err != nil {
 
@@ -76,7 +79,11 @@ func (gs *Setup) Configure(_ []string, paths []string) (Setup, error) {
 
 		return *gs, err
 	}
-	err = gs.setupGradleProjectMappings()
+	if util.IsIsolated() {
+		// Finding subprojects runs Gradle, and thereby the build scripts, which must not run on the host when isolated
+		gs.assumeSubProjectPaths(files)
+	}
+	err = gs.setupGradleProjectMappings(ctx)
 	if err != nil && len(err.Error()) > 0 {
 		return *gs, err
 	}
@@ -102,7 +109,7 @@ func (gs *Setup) setupFilePathMappings(files []string) {
 	}
 }
 
-func (gs *Setup) setupGradleProjectMappings() error {
+func (gs *Setup) setupGradleProjectMappings(ctx context.Context) error {
 	var errors SetupError
 	var settingsDirs []string
 	for k := range gs.settingsMap {
@@ -116,10 +123,11 @@ func (gs *Setup) setupGradleProjectMappings() error {
 		gradlew := gs.GetGradleW(dir)
 		mainFile := gs.settingsMap[dir]
 		gradleProject := Project{dir: dir, gradlew: gradlew, mainBuildFile: mainFile}
-		err := gs.setupSubProjectPaths(gradleProject)
-
-		if err != nil {
-			errors = append(errors, err)
+		if !util.IsIsolated() {
+			err := gs.setupSubProjectPaths(ctx, gradleProject)
+			if err != nil {
+				errors = append(errors, err)
+			}
 		}
 		gs.GradleProjects = append(gs.GradleProjects, gradleProject)
 	}
@@ -127,8 +135,9 @@ func (gs *Setup) setupGradleProjectMappings() error {
 	return SetupSubprojectError{message: errors.Error()}
 }
 
-func (gs *Setup) setupSubProjectPaths(gp Project) error {
+func (gs *Setup) setupSubProjectPaths(ctx context.Context, gp Project) error {
 	dependenciesCmd, _ := gs.CmdFactory.MakeFindSubGraphCmd(gp.dir, gp.gradlew, gs.groovyScriptPath)
+	dependenciesCmd = job.WithContext(ctx, dependenciesCmd)
 	var stderr bytes.Buffer
 	dependenciesCmd.Stderr = &stderr
 	_, err := dependenciesCmd.Output()
@@ -160,6 +169,44 @@ func (gs *Setup) setupSubProjectPaths(gp Project) error {
 	return nil
 }
 
+// assumeSubProjectPaths maps the directories of files below a settings file, which have no settings file of their own,
+// to the directory of the closest settings file as its subprojects. The job of that directory resolves them as well.
+func (gs *Setup) assumeSubProjectPaths(files []string) {
+	for _, file := range files {
+		dir, err := filepath.Abs(filepath.Dir(file))
+		if err != nil {
+			continue
+		}
+		if _, ok := gs.settingsMap[dir]; ok {
+			continue
+		}
+		closest := ""
+		for settingsDir := range gs.settingsMap {
+			rel, err := filepath.Rel(settingsDir, dir)
+			isBelow := err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+			if isBelow && len(settingsDir) > len(closest) {
+				closest = settingsDir
+			}
+		}
+		if len(closest) > 0 {
+			gs.subProjectMap[dir] = closest
+		}
+	}
+}
+
+// subProjectDirs returns the sorted directories of the subprojects of the project in dir
+func (gs *Setup) subProjectDirs(dir string) []string {
+	var dirs []string
+	for subProjectDir, mainDir := range gs.subProjectMap {
+		if mainDir == dir && subProjectDir != dir {
+			dirs = append(dirs, subProjectDir)
+		}
+	}
+	sort.Strings(dirs)
+
+	return dirs
+}
+
 func (gs *Setup) GetGradleW(dir string) string {
 	gradlew := initGradle
 	val, ok := gs.gradlewMap[dir]
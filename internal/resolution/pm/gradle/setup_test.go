@@ -1,6 +1,7 @@
 package gradle
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -10,6 +11,7 @@ import (
 
 	writerTestdata "github.com/debricked/cli/internal/resolution/pm/writer/testdata"
 
+	"github.com/debricked/cli/internal/resolution/pm/util"
 	"github.com/debricked/cli/internal/resolution/pm/writer"
 	"github.com/stretchr/testify/assert"
 )
@@ -67,7 +69,7 @@ func TestSetupGradleProjectMappings(t *testing.T) {
 		filepath.Join("testdata", "project"): filepath.Join("testdata", "project", "settings.gradle"),
 	}
 	gs.subProjectMap = map[string]string{}
-	err := gs.setupGradleProjectMappings()
+	err := gs.setupGradleProjectMappings(context.Background())
 	// assert GradleSetupSubprojectError
 	assert.NotNil(t, err)
 
@@ -117,7 +119,7 @@ func TestSetupSubProjectPathsNoFileCreated(t *testing.T) {
 
 	absPath, _ := filepath.Abs(filepath.Join("testdata", "project"))
 	gradleProject := Project{dir: absPath, gradlew: filepath.Join("testdata", "project", "gradlew")}
-	err := gs.setupSubProjectPaths(gradleProject)
+	err := gs.setupSubProjectPaths(context.Background(), gradleProject)
 	fmt.Println(err)
 	assert.NotNil(t, err)
 	assert.Len(t, gs.subProjectMap, 0)
@@ -129,23 +131,37 @@ func TestSetupSubProjectPaths(t *testing.T) {
 
 	absPath, _ := filepath.Abs(filepath.Join("testdata", "project"))
 	gradleProject := Project{dir: absPath, gradlew: filepath.Join("testdata", "project", "gradlew")}
-	err := gs.setupSubProjectPaths(gradleProject)
+	err := gs.setupSubProjectPaths(context.Background(), gradleProject)
 	assert.Nil(t, err)
 	assert.Len(t, gs.subProjectMap, 1)
 
 	absPath, _ = filepath.Abs(filepath.Join("testdata", "project", "subproject"))
 	gradleProject = Project{dir: absPath, gradlew: filepath.Join("testdata", "project", "gradlew")}
-	err = gs.setupSubProjectPaths(gradleProject)
+	err = gs.setupSubProjectPaths(context.Background(), gradleProject)
 	assert.Nil(t, err)
 	assert.Len(t, gs.subProjectMap, 2)
 }
 
+func TestSetupSubProjectPathsCanceled(t *testing.T) {
+	gs := NewGradleSetup()
+	gs.CmdFactory = &mockCmdFactory{createFile: false}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	absPath, _ := filepath.Abs(filepath.Join("testdata", "project"))
+	gradleProject := Project{dir: absPath, gradlew: filepath.Join("testdata", "project", "gradlew")}
+	err := gs.setupSubProjectPaths(ctx, gradleProject)
+
+	assert.ErrorContains(t, err, context.Canceled.Error())
+	assert.Len(t, gs.subProjectMap, 0)
+}
+
 func TestSetupSubProjectPathsError(t *testing.T) {
 	gs := NewGradleSetup()
 
 	absPath, _ := filepath.Abs(filepath.Join("testdata", "project"))
 	gradleProject := Project{dir: absPath, gradlew: filepath.Join("testdata", "project", "gradlew")}
-	err := gs.setupSubProjectPaths(gradleProject)
+	err := gs.setupSubProjectPaths(context.Background(), gradleProject)
 
 	assert.NotNil(t, err)
 }
@@ -189,15 +205,15 @@ func (f mockFileHandler) Find(_ []string) (map[string]string, map[string]string,
 func TestConfigureErrors(t *testing.T) {
 	gs := NewGradleSetup()
 	gs.Writer = &writerTestdata.FileWriterMock{}
-	_, err := gs.Configure([]string{"testdata/project"}, []string{"testdata/project"})
+	_, err := gs.Configure(context.Background(), []string{"testdata/project"}, []string{"testdata/project"})
 	assert.NotNil(t, err)
 
 	gs.MetaFileFinder = mockFileHandler{setupWalkErr: SetupScriptError{message: "mock error"}}
-	_, err = gs.Configure([]string{"testdata/project"}, []string{"testdata/project"})
+	_, err = gs.Configure(context.Background(), []string{"testdata/project"}, []string{"testdata/project"})
 	assert.Equal(t, "mock error", err.Error())
 
 	gs.InitScriptHandler = mockInitScriptHandler{writeInitFileErr: SetupScriptError{message: "write-init-file-err"}}
-	_, err = gs.Configure([]string{"testdata/project"}, []string{"testdata/project"})
+	_, err = gs.Configure(context.Background(), []string{"testdata/project"}, []string{"testdata/project"})
 	assert.Equal(t, "write-init-file-err", err.Error())
 }
 
@@ -207,6 +223,28 @@ func TestConfigure(t *testing.T) {
 	gs.MetaFileFinder = mockFileHandler{setupWalkErr: nil}
 	gs.InitScriptHandler = mockInitScriptHandler{writeInitFileErr: nil}
 
-	_, err := gs.Configure([]string{"testdata/project"}, []string{"testdata/project"})
+	_, err := gs.Configure(context.Background(), []string{"testdata/project"}, []string{"testdata/project"})
+	assert.NoError(t, err)
+}
+
+func TestConfigureIsolated(t *testing.T) {
+	util.SetIsolated(true)
+	defer util.SetIsolated(false)
+	gs := NewGradleSetup()
+	gs.Writer = &writerTestdata.FileWriterMock{}
+	gs.InitScriptHandler = mockInitScriptHandler{writeInitFileErr: nil}
+	// Subprojects are found without running Gradle, which would fail on the files missing from this directory
+	gs.CmdFactory = &mockCmdFactory{createFile: false}
+	files := []string{
+		filepath.Join("testdata", "project", "build.gradle"),
+		filepath.Join("testdata", "project", "subproject", "build.gradle"),
+	}
+
+	setup, err := gs.Configure(context.Background(), files, []string{filepath.Join("testdata", "project")})
+
 	assert.NoError(t, err)
+	projectDir, _ := filepath.Abs(filepath.Join("testdata", "project"))
+	assert.Equal(t, map[string]string{filepath.Join(projectDir, "subproject"): projectDir}, setup.subProjectMap)
+	assert.Len(t, setup.GradleProjects, 1)
+	assert.Equal(t, []string{filepath.Join(projectDir, "subproject")}, setup.subProjectDirs(projectDir))
 }
@@ -1,6 +1,7 @@
 package gradle
 
 import (
+	"context"
 	"io"
 	"log"
 	"os"
@@ -13,6 +14,7 @@ import (
 )
 
 type Strategy struct {
+	ctx         context.Context
 	files       []string
 	paths       []string
 	ErrorWriter io.Writer
@@ -23,7 +25,7 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 	var jobs []job.IJob
 	fileWriter := writer.FileWriter{}
 	factory := CmdFactory{}
-	gradleSetup, err := s.GradleSetup.Configure(s.files, s.paths)
+	gradleSetup, err := s.GradleSetup.Configure(s.ctx, s.files, s.paths)
 	if err != nil {
 		if _, ok := err.(SetupSubprojectError); ok {
 			warningColor := color.New(color.FgYellow, color.Bold).SprintFunc()
@@ -42,7 +44,9 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 			continue
 		}
 		gradleMainDirs[dir] = true
-		jobs = append(jobs, NewJob(gradleProject.mainBuildFile, dir, gradleProject.gradlew, gradleSetup.groovyScriptPath, factory, fileWriter))
+		j := NewJob(gradleProject.mainBuildFile, dir, gradleProject.gradlew, gradleSetup.groovyScriptPath, factory, fileWriter)
+		j.subProjectDirs = gradleSetup.subProjectDirs(dir)
+		jobs = append(jobs, j)
 
 	}
 	for _, file := range s.files {
@@ -61,6 +65,7 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 	return jobs, nil
 }
 
-func NewStrategy(files []string, paths []string) Strategy {
-	return Strategy{files, paths, os.Stdout, NewGradleSetup()}
+// NewStrategy makes jobs resolving files, stopping Gradle while it finds subprojects once ctx is done
+func NewStrategy(ctx context.Context, files []string, paths []string) Strategy {
+	return Strategy{ctx, files, paths, os.Stdout, NewGradleSetup()}
 }
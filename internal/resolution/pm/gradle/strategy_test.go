@@ -1,6 +1,7 @@
 package gradle
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -9,37 +10,37 @@ import (
 )
 
 func TestNewStrategy(t *testing.T) {
-	s := NewStrategy(nil, nil)
+	s := NewStrategy(context.Background(), nil, nil)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 0)
 
-	s = NewStrategy([]string{}, nil)
+	s = NewStrategy(context.Background(), []string{}, nil)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 0)
 
-	s = NewStrategy([]string{"file"}, nil)
+	s = NewStrategy(context.Background(), []string{"file"}, nil)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 1)
 
-	s = NewStrategy([]string{"file-1", "file-2"}, nil)
+	s = NewStrategy(context.Background(), []string{"file-1", "file-2"}, nil)
 	assert.NotNil(t, s)
 	assert.Len(t, s.files, 2)
 }
 
 func TestInvokeNoFiles(t *testing.T) {
-	s := NewStrategy([]string{}, nil)
+	s := NewStrategy(context.Background(), []string{}, nil)
 	jobs, _ := s.Invoke()
 	assert.Empty(t, jobs)
 }
 
 func TestInvokeOneFile(t *testing.T) {
-	s := NewStrategy([]string{"file"}, nil)
+	s := NewStrategy(context.Background(), []string{"file"}, nil)
 	jobs, _ := s.Invoke()
 	assert.Len(t, jobs, 1)
 }
 
 func TestInvokeManyFiles(t *testing.T) {
-	s := NewStrategy([]string{"test/file-1", "test/file-2", "test2/file-2"}, nil)
+	s := NewStrategy(context.Background(), []string{"test/file-1", "test/file-2", "test2/file-2"}, nil)
 	jobs, _ := s.Invoke()
 	assert.Len(t, jobs, 2)
 }
@@ -50,14 +51,14 @@ type mockGradleSetup struct {
 }
 
 // mock for Setup
-func (m *mockGradleSetup) Configure(_ []string, _ []string) (Setup, error) {
+func (m *mockGradleSetup) Configure(_ context.Context, _ []string, _ []string) (Setup, error) {
 	args := m.Called()
 
 	return args.Get(0).(Setup), args.Error(1)
 }
 
 func TestInvokeWalkError(t *testing.T) {
-	s := NewStrategy([]string{"file"}, []string{"path"})
+	s := NewStrategy(context.Background(), []string{"file"}, []string{"path"})
 	mocked := &mockGradleSetup{}
 	mocked.On("Configure").Return(Setup{}, SetupWalkError{})
 
@@ -68,7 +69,7 @@ func TestInvokeWalkError(t *testing.T) {
 }
 
 func TestInvokeSubprojectError(t *testing.T) {
-	s := NewStrategy([]string{"file"}, []string{"path"})
+	s := NewStrategy(context.Background(), []string{"file"}, []string{"path"})
 	mocked := &mockGradleSetup{}
 	mocked.On("Configure").Return(Setup{}, SetupSubprojectError{})
 	s.GradleSetup = mocked
@@ -79,7 +80,7 @@ func TestInvokeSubprojectError(t *testing.T) {
 }
 
 func TestInvokeFoundProject(t *testing.T) {
-	s := NewStrategy([]string{"file"}, []string{"file"})
+	s := NewStrategy(context.Background(), []string{"file"}, []string{"file"})
 	subprojectMap := make(map[string]string)
 	dir, _ := os.Getwd()
 	subprojectMap[dir] = ""
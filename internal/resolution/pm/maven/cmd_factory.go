@@ -1,25 +1,34 @@
 package maven
 
-import "os/exec"
+import (
+	"os/exec"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/registry"
+)
 
 type ICmdFactory interface {
 	MakeDependencyTreeCmd(workingDirectory string) (*exec.Cmd, error)
 }
 
-type CmdFactory struct{}
-
-func (_ CmdFactory) MakeDependencyTreeCmd(workingDirectory string) (*exec.Cmd, error) {
-	path, err := exec.LookPath("mvn")
+type CmdFactory struct {
+	registries *registry.Config
+}
 
-	return &exec.Cmd{
+func (cmdf CmdFactory) MakeDependencyTreeCmd(workingDirectory string) (*exec.Cmd, error) {
+	path, err := util.LookPath("mvn")
+	cmd := &exec.Cmd{
 		Path: path,
 		Args: []string{
 			"mvn",
 			"dependency:tree",
-			"-DoutputFile=" + lockFileExtension,
+			"-DoutputFile=" + LockFile,
 			"-DoutputType=tgf",
 			"--fail-at-end",
 		},
 		Dir: workingDirectory,
-	}, err
+	}
+	cmdf.registries.ApplyMaven(cmd)
+
+	return cmd, err
 }
@@ -3,6 +3,7 @@ package maven
 import (
 	"testing"
 
+	"github.com/debricked/cli/internal/resolution/registry"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -16,3 +17,11 @@ func TestMakeDependencyTreeCmd(t *testing.T) {
 	assert.Contains(t, args, "-DoutputType=tgf")
 	assert.Contains(t, args, "--fail-at-end")
 }
+
+func TestMakeDependencyTreeCmdRegistry(t *testing.T) {
+	registries := &registry.Config{Maven: &registry.Registry{Url: "https://maven.example.com/"}}
+	cmd, _ := CmdFactory{registries: registries}.MakeDependencyTreeCmd(".")
+	assert.NotNil(t, cmd)
+	assert.Contains(t, cmd.Args, "dependency:tree")
+	assert.Contains(t, cmd.Args, "--settings")
+}
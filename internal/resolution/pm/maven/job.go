@@ -1,17 +1,23 @@
 package maven
 
 import (
+	"errors"
+	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/static"
 	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
 )
 
+// LockFile is the name of the lock files written by Maven
+const LockFile = "maven.debricked.lock"
+
 const (
 	executableNotFoundErrRegex  = `executable file not found`
-	lockFileExtension           = "maven.debricked.lock"
 	nonParseablePomErrRegex     = "Non-parseable POM (.*)"
 	networkUnreachableErrRegex  = "Failed to retrieve plugin descriptor"
 	invalidVersionErrRegex      = "('[\\w\\.]+' for [\\w\\.:-]+ must not contain any of these characters .* but found .)"
@@ -37,7 +43,7 @@ func (j *Job) Run() {
 	j.SendStatus(status)
 
 	file := j.GetFile()
-	_, err := j.pomService.ParsePomModules(file)
+	modules, err := j.pomService.ParsePomModules(file)
 
 	if err != nil {
 		doc := err.Error()
@@ -58,6 +64,9 @@ func (j *Job) Run() {
 	workingDirectory := filepath.Dir(filepath.Clean(file))
 	cmd, err := j.cmdFactory.MakeDependencyTreeCmd(workingDirectory)
 	if err != nil {
+		if j.resolveStatically(err.Error()) {
+			return
+		}
 		j.handleError(util.NewPMJobError(err.Error()))
 
 		return
@@ -66,7 +75,7 @@ func (j *Job) Run() {
 	status = "creating dependency graph"
 	j.SendStatus(status)
 	var output []byte
-	output, err = cmd.Output()
+	output, err = j.Sandboxed(cmd).Output()
 	if err != nil {
 		errContent := err.Error()
 		if output != nil {
@@ -77,7 +86,47 @@ func (j *Job) Run() {
 		cmdErr.SetStatus(status)
 
 		j.handleError(cmdErr)
+
+		return
 	}
+
+	// A lock file parsed without Maven would otherwise be found next to the one written by Maven
+	err = os.Remove(filepath.Join(workingDirectory, static.MavenLockFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		j.Errors().Warning(util.NewPMJobError(err.Error()))
+	}
+	j.addLockFiles(workingDirectory, modules)
+}
+
+// addLockFiles records the lock files written by Maven for the project in dir and, recursively, its modules
+func (j *Job) addLockFiles(dir string, modules []string) {
+	j.AddLockFile(filepath.Join(dir, LockFile))
+	for _, module := range modules {
+		// Modules are directories with a pom.xml, or pom files themselves
+		pomFile := filepath.Join(dir, module, "pom.xml")
+		if strings.HasSuffix(module, ".xml") {
+			pomFile = filepath.Join(dir, module)
+		}
+		// Maven has built the module, so a pom that can't be parsed here is taken to have no modules of its own
+		nested, _ := j.pomService.ParsePomModules(pomFile)
+		j.addLockFiles(filepath.Dir(pomFile), nested)
+	}
+}
+
+// resolveStatically parses the pom.xml without Maven if Maven isn't installed, reporting whether it succeeded
+func (j *Job) resolveStatically(cause string) bool {
+	if !regexp.MustCompile(executableNotFoundErrRegex).MatchString(cause) {
+		return false
+	}
+	j.SendStatus("resolving statically")
+	lockFile, warning, err := static.Resolve(j.GetFile(), "Maven", cause, static.ParsePom, writer.FileWriter{})
+	if err != nil {
+		return false
+	}
+	j.AddLockFile(lockFile)
+	j.Errors().Warning(warning)
+
+	return true
 }
 
 func (j *Job) handleError(cmdError job.IError) {
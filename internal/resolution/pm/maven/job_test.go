@@ -2,11 +2,13 @@ package maven
 
 import (
 	"errors"
+	"os"
 	"path/filepath"
 	"testing"
 
 	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
 	"github.com/debricked/cli/internal/resolution/pm/maven/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/static"
 	"github.com/debricked/cli/internal/resolution/pm/util"
 	"github.com/stretchr/testify/assert"
 )
@@ -180,3 +182,72 @@ func TestRunWithActualFileErrOutput(t *testing.T) {
 		})
 	}
 }
+
+func TestRunResolvesStaticallyWithoutMaven(t *testing.T) {
+	dir := t.TempDir()
+	pomFile := filepath.Join(dir, "pom.xml")
+	content, err := os.ReadFile(filepath.Join("testdata", "pom.xml"))
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(pomFile, content, 0600))
+	execErr := errors.New("exec: \"mvn\": executable file not found in $PATH")
+	j := NewJob(pomFile, testdata.CmdFactoryMock{Err: execErr}, PomService{})
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.Empty(t, j.Errors().GetCriticalErrors())
+	warnings := j.Errors().GetWarningErrors()
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, execErr.Error(), warnings[0].Error())
+	assert.Contains(t, warnings[0].Documentation(), "static.maven.debricked.lock was generated by parsing pom.xml without it")
+	assert.FileExists(t, filepath.Join(dir, static.MavenLockFile))
+	assert.NoFileExists(t, filepath.Join(dir, "maven.debricked.lock"))
+}
+
+func TestRunRemovesStaticLockFile(t *testing.T) {
+	dir := t.TempDir()
+	pomFile := filepath.Join(dir, "pom.xml")
+	content, err := os.ReadFile(filepath.Join("testdata", "pom.xml"))
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(pomFile, content, 0600))
+	staticLockFile := filepath.Join(dir, static.MavenLockFile)
+	assert.NoError(t, os.WriteFile(staticLockFile, []byte("1 g:a:jar:1.0.0\n#\n"), 0600))
+	j := NewJob(pomFile, testdata.CmdFactoryMock{Name: "echo"}, PomService{})
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.NoFileExists(t, staticLockFile)
+}
+
+func TestRunAddsLockFilesOfModules(t *testing.T) {
+	dir := t.TempDir()
+	pomFile := filepath.Join(dir, "pom.xml")
+	apiPomFile := filepath.Join(dir, "api", "pom.xml")
+	j := NewJob(pomFile, testdata.CmdFactoryMock{Name: "echo"}, modulesPomService{
+		pomFile:    {"api", "impl/pom.xml"},
+		apiPomFile: {"client"},
+	})
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.Equal(t, []string{
+		filepath.Join(dir, "maven.debricked.lock"),
+		filepath.Join(dir, "api", "maven.debricked.lock"),
+		filepath.Join(dir, "api", "client", "maven.debricked.lock"),
+		filepath.Join(dir, "impl", "maven.debricked.lock"),
+	}, j.LockFiles())
+}
+
+// modulesPomService returns the modules of each pom file
+type modulesPomService map[string][]string
+
+func (service modulesPomService) ParsePomModules(path string) ([]string, error) {
+	return service[path], nil
+}
@@ -2,6 +2,7 @@ package maven
 
 import (
 	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/registry"
 )
 
 type Strategy struct {
@@ -9,8 +10,9 @@ type Strategy struct {
 	cmdFactory ICmdFactory
 }
 
-func NewStrategy(files []string) Strategy {
-	return Strategy{files, CmdFactory{}}
+// NewStrategy makes jobs resolving files through registries, unless registries is nil
+func NewStrategy(files []string, registries *registry.Config) Strategy {
+	return Strategy{files, CmdFactory{registries: registries}}
 }
 
 func (s Strategy) Invoke() ([]job.IJob, error) {
@@ -0,0 +1,56 @@
+package mix
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+type ICmdFactory interface {
+	MakeDepsGetCmd(manifestFile string) (*exec.Cmd, error)
+}
+
+type IExecPath interface {
+	LookPath(file string) (string, error)
+}
+
+type ExecPath struct{}
+
+func (_ ExecPath) LookPath(file string) (string, error) {
+	return util.LookPath(file)
+}
+
+type CmdFactory struct {
+	execPath IExecPath
+	// trustProjectCode lets Mix evaluate mix.exs files on the host, rather than only in containers
+	trustProjectCode bool
+}
+
+// MakeDepsGetCmd creates an exec.Cmd that runs `mix deps.get` in the directory of the
+// given mix.exs. It fetches dependencies and writes mix.lock without compiling them, so no
+// compile hooks or NIF builds are run. The mix.exs of the project and of every fetched
+// dependency is still evaluated, which runs their code, so util.ErrUntrustedProjectCode is
+// returned on the host unless the project is trusted.
+func (cmdf CmdFactory) MakeDepsGetCmd(manifestFile string) (*exec.Cmd, error) {
+	if !cmdf.trustProjectCode && !util.IsIsolated() {
+		return nil, fmt.Errorf("mix deps.get evaluates mix.exs files as Elixir code: %w", util.ErrUntrustedProjectCode)
+	}
+	mixPath, err := cmdf.execPath.LookPath("mix")
+	if err != nil {
+		return nil, err
+	}
+
+	workingDir := filepath.Dir(filepath.Clean(manifestFile))
+
+	return &exec.Cmd{
+		Path: mixPath,
+		Args: []string{"mix", "deps.get",
+			"--no-archives-check", // Archives are only needed to compile the project
+		},
+		Dir: workingDir,
+		Env: os.Environ(),
+	}, nil
+}
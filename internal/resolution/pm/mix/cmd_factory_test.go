@@ -0,0 +1,41 @@
+package mix
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/stretchr/testify/assert"
+)
+
+type execPathMock struct{}
+
+func (execPathMock) LookPath(file string) (string, error) {
+	return "/usr/bin/" + file, nil
+}
+
+func TestMakeDepsGetCmd(t *testing.T) {
+	factory := CmdFactory{execPath: execPathMock{}, trustProjectCode: true}
+	manifest := filepath.Join("some", "path", "mix.exs")
+
+	cmd, err := factory.MakeDepsGetCmd(manifest)
+	assert.NoError(t, err)
+	assert.Equal(t, "/usr/bin/mix", cmd.Path)
+	assert.Equal(t, []string{"mix", "deps.get", "--no-archives-check"}, cmd.Args)
+	assert.Equal(t, filepath.Dir(manifest), cmd.Dir)
+}
+
+func TestMakeDepsGetCmdUntrusted(t *testing.T) {
+	factory := CmdFactory{execPath: execPathMock{}}
+	manifest := filepath.Join("some", "path", "mix.exs")
+
+	cmd, err := factory.MakeDepsGetCmd(manifest)
+	assert.Nil(t, cmd)
+	assert.ErrorIs(t, err, util.ErrUntrustedProjectCode)
+
+	util.SetIsolated(true)
+	defer util.SetIsolated(false)
+	cmd, err = factory.MakeDepsGetCmd(manifest)
+	assert.NoError(t, err)
+	assert.Equal(t, "/usr/bin/mix", cmd.Path)
+}
@@ -0,0 +1,180 @@
+package mix
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+const (
+	executableNotFoundErrRegex = `executable file not found`
+	elixirVersionErrRegex      = `You're trying to run :(\S+) on Elixir v(\S+) but it has declared in its mix\.exs file it supports only Elixir ([^\n]+)`
+	privatePackageErrRegex     = `Failed to fetch record for '?([^'\s]+?)'? from registry`
+	gitAuthenticationErrRegex  = `Getting (\S+) \([^)]*\)\s+fatal: could not read Username`
+	packageNotFoundErrRegex    = `No package with name (\S+) \(from: [^)]+\) in registry`
+	versionConflictErrRegex    = `depends on "([^"]+)" which doesn't match any versions`
+	noInternetErrRegex         = `:to_address, \{(?:~c)?["']([^"']+)["']`
+	lockFileName               = "mix.lock"
+)
+
+// Job runs `mix deps.get` for a mix.exs, producing a mix.lock.
+type Job struct {
+	job.BaseJob
+	cmdFactory ICmdFactory
+}
+
+func NewJob(file string, cmdFactory ICmdFactory) *Job {
+	return &Job{
+		BaseJob:    job.NewBaseJob(file),
+		cmdFactory: cmdFactory,
+	}
+}
+
+func (j *Job) Run() {
+	status := "generating mix.lock"
+	j.SendStatus(status)
+
+	depsGetCmd, err := j.cmdFactory.MakeDepsGetCmd(j.GetFile())
+	if err != nil {
+		cmdError := j.createError(err.Error(), "", status)
+		if errors.Is(err, util.ErrUntrustedProjectCode) {
+			cmdError.SetDocumentation(util.UntrustedProjectCodeMessage)
+		}
+		j.handleError(cmdError)
+
+		return
+	}
+
+	if output, err := j.Sandboxed(depsGetCmd).Output(); err != nil {
+		exitErr := j.GetExitError(err, string(output))
+		errorMessage := strings.Join([]string{string(output), exitErr.Error()}, "")
+		j.handleError(j.createError(errorMessage, depsGetCmd.String(), status))
+
+		return
+	}
+	j.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
+}
+
+func (j *Job) createError(errorStr string, cmd string, status string) job.IError {
+	cmdError := util.NewPMJobError(errorStr)
+	cmdError.SetCommand(cmd)
+	cmdError.SetStatus(status)
+
+	return cmdError
+}
+
+func (j *Job) handleError(cmdError job.IError) {
+	expressions := []string{
+		executableNotFoundErrRegex,
+		elixirVersionErrRegex,
+		noInternetErrRegex,
+		privatePackageErrRegex,
+		gitAuthenticationErrRegex,
+		packageNotFoundErrRegex,
+		versionConflictErrRegex,
+	}
+
+	for _, expression := range expressions {
+		regex := regexp.MustCompile(expression)
+		matches := regex.FindAllStringSubmatch(cmdError.Error(), -1)
+
+		if len(matches) > 0 {
+			cmdError = j.addDocumentation(expression, matches, cmdError)
+			j.Errors().Append(cmdError)
+
+			return
+		}
+	}
+
+	j.Errors().Append(cmdError)
+}
+
+func (j *Job) addDocumentation(expr string, matches [][]string, cmdError job.IError) job.IError {
+	documentation := cmdError.Documentation()
+
+	switch expr {
+	case executableNotFoundErrRegex:
+		documentation = j.GetExecutableNotFoundErrorDocumentation("Mix")
+	case elixirVersionErrRegex:
+		documentation = j.getElixirVersionErrorDocumentation(matches)
+	case privatePackageErrRegex:
+		documentation = j.getDependencyNotFoundErrorDocumentation(matches)
+	case gitAuthenticationErrRegex:
+		documentation = j.getDependencyNotFoundErrorDocumentation(matches)
+	case packageNotFoundErrRegex:
+		documentation = j.getDependencyNotFoundErrorDocumentation(matches)
+	case versionConflictErrRegex:
+		documentation = j.getVersionConflictErrorDocumentation(matches)
+	case noInternetErrRegex:
+		documentation = j.getNoInternetErrorDocumentation(matches)
+	}
+
+	cmdError.SetDocumentation(documentation)
+
+	return cmdError
+}
+
+func (j *Job) getElixirVersionErrorDocumentation(matches [][]string) string {
+	project := ""
+	installed := ""
+	required := ""
+	if len(matches) > 0 && len(matches[0]) > 3 {
+		project = matches[0][1]
+		installed = matches[0][2]
+		required = matches[0][3]
+	}
+
+	return strings.Join(
+		[]string{
+			"\"" + project + "\" requires Elixir " + required + ", but Elixir " + installed + " is installed.",
+			"Please install the required Elixir version before running the debricked CLI.",
+		}, " ")
+}
+
+func (j *Job) getDependencyNotFoundErrorDocumentation(matches [][]string) string {
+	dependency := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		dependency = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to find package",
+			"\"" + dependency + "\"",
+			"that satisfies the requirements.",
+			"Please check that dependencies are correct in the manifest file.",
+			"\n" + util.InstallPrivateDependencyMessage,
+		}, " ")
+}
+
+func (j *Job) getVersionConflictErrorDocumentation(matches [][]string) string {
+	requirement := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		requirement = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to find a version matching the requirement",
+			"\"" + requirement + "\".",
+			"Please check that package versions are correct in the manifest file.",
+		}, " ")
+}
+
+func (j *Job) getNoInternetErrorDocumentation(matches [][]string) string {
+	registry := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		registry = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Registry",
+			"\"" + registry + "\"",
+			"is not available at the moment.",
+			"There might be a trouble with your network connection.",
+		}, " ")
+}
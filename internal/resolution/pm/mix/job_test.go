@@ -0,0 +1,108 @@
+package mix
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/mix/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJob(t *testing.T) {
+	j := NewJob("file", testdata.CmdFactoryMock{})
+	assert.Equal(t, "file", j.GetFile())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRunDepsGetCmdErr(t *testing.T) {
+	cases := []struct {
+		name  string
+		error string
+		doc   string
+	}{
+		{
+			name:  "General error",
+			error: "cmd-error",
+			doc:   util.UnknownError,
+		},
+		{
+			name:  "Mix not found",
+			error: "exec: \"mix\": executable file not found in $PATH",
+			doc:   "Mix wasn't found. Please check if it is installed and accessible by the CLI.",
+		},
+		{
+			name:  "Wrong Elixir version",
+			error: "** (Mix) You're trying to run :app on Elixir v1.14.0 but it has declared in its mix.exs file it supports only Elixir ~> 1.16",
+			doc:   "\"app\" requires Elixir ~> 1.16, but Elixir 1.14.0 is installed. Please install the required Elixir version before running the debricked CLI.",
+		},
+		{
+			name:  "Private Hex organization",
+			error: "Failed to fetch record for 'hexpm:acme/billing' from registry (using cache instead)\n{:failed_to_fetch, 401}",
+			doc:   "Failed to find package \"hexpm:acme/billing\" that satisfies the requirements. Please check that dependencies are correct in the manifest file. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "Private git dependency",
+			error: "* Getting billing (https://github.com/acme/billing.git)\nfatal: could not read Username for 'https://github.com': terminal prompts disabled",
+			doc:   "Failed to find package \"billing\" that satisfies the requirements. Please check that dependencies are correct in the manifest file. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "Package not found",
+			error: "** (Mix) No package with name phoenx (from: mix.exs) in registry",
+			doc:   "Failed to find package \"phoenx\" that satisfies the requirements. Please check that dependencies are correct in the manifest file. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "Version conflict",
+			error: "Resolving Hex dependencies...\nBecause your app depends on \"plug ~> 99.0\" which doesn't match any versions, version solving failed.",
+			doc:   "Failed to find a version matching the requirement \"plug ~> 99.0\". Please check that package versions are correct in the manifest file.",
+		},
+		{
+			name:  "No internet connection",
+			error: "** (Mix) Failed to fetch record for 'hexpm/plug' from registry: {:failed_connect, [{:to_address, {~c\"repo.hex.pm\", 443}}, {:inet, [:inet], :nxdomain}]}",
+			doc:   "Registry \"repo.hex.pm\" is not available at the moment. There might be a trouble with your network connection.",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			j := NewJob("file", testdata.CmdFactoryMock{DepsGetErr: errors.New(c.error), Name: "echo"})
+
+			go jobTestdata.WaitStatus(j)
+
+			j.Run()
+
+			errs := j.Errors().GetAll()
+			assert.Len(t, errs, 1)
+			assert.Equal(t, c.error, errs[0].Error())
+			assert.Equal(t, c.doc+"\n", errs[0].Documentation())
+			assert.Equal(t, "generating mix.lock", errs[0].Status())
+		})
+	}
+}
+
+func TestRunUntrusted(t *testing.T) {
+	err := fmt.Errorf("project code: %w", util.ErrUntrustedProjectCode)
+	j := NewJob("file", testdata.CmdFactoryMock{DepsGetErr: err, Name: "echo"})
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.Equal(t, err.Error(), errs[0].Error())
+	assert.Equal(t, util.UntrustedProjectCodeMessage+"\n", errs[0].Documentation())
+	assert.Equal(t, "generating mix.lock", errs[0].Status())
+}
+
+func TestRunSuccess(t *testing.T) {
+	j := NewJob("file", testdata.CmdFactoryMock{Name: "echo"})
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+}
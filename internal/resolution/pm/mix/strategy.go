@@ -0,0 +1,25 @@
+package mix
+
+import "github.com/debricked/cli/internal/resolution/job"
+
+type Strategy struct {
+	files            []string
+	trustProjectCode bool
+}
+
+// NewStrategy returns a strategy whose jobs only run project code on the host if trustProjectCode is true
+func NewStrategy(files []string, trustProjectCode bool) Strategy {
+	return Strategy{files: files, trustProjectCode: trustProjectCode}
+}
+
+func (s Strategy) Invoke() ([]job.IJob, error) {
+	var jobs []job.IJob
+	for _, file := range s.files {
+		jobs = append(jobs, NewJob(
+			file,
+			CmdFactory{execPath: ExecPath{}, trustProjectCode: s.trustProjectCode},
+		))
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,35 @@
+package mix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStrategy(t *testing.T) {
+	s := NewStrategy(nil, false)
+	assert.NotNil(t, s)
+	assert.Len(t, s.files, 0)
+
+	s = NewStrategy([]string{"file"}, false)
+	assert.Len(t, s.files, 1)
+	assert.False(t, s.trustProjectCode)
+
+	s = NewStrategy([]string{"file"}, true)
+	assert.True(t, s.trustProjectCode)
+}
+
+func TestStrategyInvoke(t *testing.T) {
+	cases := [][]string{
+		{},
+		{"mix.exs"},
+		{"a/mix.exs", "b/mix.exs"},
+	}
+
+	for _, files := range cases {
+		s := NewStrategy(files, false)
+		jobs, err := s.Invoke()
+		assert.NoError(t, err)
+		assert.Len(t, jobs, len(files))
+	}
+}
@@ -0,0 +1,24 @@
+package testdata
+
+import (
+	"os/exec"
+	"runtime"
+)
+
+type CmdFactoryMock struct {
+	DepsGetErr error
+	Name       string
+	Arg        string
+}
+
+func (f CmdFactoryMock) MakeDepsGetCmd(_ string) (*exec.Cmd, error) {
+	if len(f.Arg) == 0 {
+		f.Arg = `"MakeDepsGetCmd"`
+	}
+
+	if runtime.GOOS == "windows" && f.Name == "echo" {
+		return exec.Command("cmd", "/C", f.Name, f.Arg), f.DepsGetErr
+	}
+
+	return exec.Command(f.Name, f.Arg), f.DepsGetErr
+}
@@ -3,6 +3,9 @@ package npm
 import (
 	"os/exec"
 	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/registry"
 )
 
 type ICmdFactory interface {
@@ -17,19 +20,19 @@ type ExecPath struct {
 }
 
 func (ExecPath) LookPath(file string) (string, error) {
-	return exec.LookPath(file)
+	return util.LookPath(file)
 }
 
 type CmdFactory struct {
-	execPath IExecPath
+	execPath   IExecPath
+	registries *registry.Config
 }
 
 func (cmdf CmdFactory) MakeInstallCmd(command string, file string) (*exec.Cmd, error) {
 	path, err := cmdf.execPath.LookPath(command)
 
 	fileDir := filepath.Dir(file)
-
-	return &exec.Cmd{
+	cmd := &exec.Cmd{
 		Path: path,
 		Args: []string{
 			//"yes |", // Answer 'y' to any prompts...
@@ -40,5 +43,8 @@ func (cmdf CmdFactory) MakeInstallCmd(command string, file string) (*exec.Cmd, e
 			"--bin-links=false", // We don't need symlinks to binaries as we won't run any code
 		},
 		Dir: fileDir,
-	}, err
+	}
+	cmdf.registries.ApplyNpm(cmd)
+
+	return cmd, err
 }
@@ -3,6 +3,7 @@ package npm
 import (
 	"testing"
 
+	"github.com/debricked/cli/internal/resolution/registry"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -17,3 +18,13 @@ func TestMakeInstallCmd(t *testing.T) {
 	assert.Contains(t, args, "npm")
 	assert.Contains(t, args, "install")
 }
+
+func TestMakeInstallCmdRegistry(t *testing.T) {
+	registries := &registry.Config{Npm: &registry.Registry{Url: "https://npm.example.com/"}}
+	cmd, err := CmdFactory{
+		execPath:   ExecPath{},
+		registries: registries,
+	}.MakeInstallCmd("npm", "file")
+	assert.NoError(t, err)
+	assert.Contains(t, cmd.Args, "--registry=https://npm.example.com/")
+}
@@ -5,7 +5,9 @@ import (
 	"strings"
 
 	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/static"
 	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
 )
 
 const (
@@ -15,6 +17,7 @@ const (
 	dependencyNotFoundErrRegex  = `404\s+'([^"\s:]+)'`
 	registryUnavailableErrRegex = `EAI_AGAIN ([\w\.]+)`
 	permissionDeniedErrRegex    = `Error: EACCES, open '([^"\s:]+)'`
+	lockFileName                = "package-lock.json"
 )
 
 type Job struct {
@@ -49,20 +52,43 @@ func (j *Job) Run() {
 		installCmd, err := j.cmdFactory.MakeInstallCmd(j.npmCommand, j.GetFile())
 
 		if err != nil {
+			if j.resolveStatically(err.Error()) {
+				return
+			}
 			j.handleError(j.createError(err.Error(), installCmd.String(), status))
 
 			return
 		}
 
-		if output, err := installCmd.Output(); err != nil {
+		if output, err := j.Sandboxed(installCmd).Output(); err != nil {
 			error := strings.Join([]string{string(output), j.GetExitError(err, "").Error()}, "")
+			if j.resolveStatically(error) {
+				return
+			}
 			j.handleError(j.createError(error, installCmd.String(), status))
 
 			return
 		}
+		j.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
 	}
 }
 
+// resolveStatically parses the package.json without npm if npm isn't installed, reporting whether it succeeded
+func (j *Job) resolveStatically(cause string) bool {
+	if !regexp.MustCompile(executableNotFoundErrRegex).MatchString(cause) {
+		return false
+	}
+	j.SendStatus("resolving statically")
+	lockFile, warning, err := static.Resolve(j.GetFile(), npm, cause, static.ParsePackageJson, writer.FileWriter{})
+	if err != nil {
+		return false
+	}
+	j.AddLockFile(lockFile)
+	j.Errors().Warning(warning)
+
+	return true
+}
+
 func (j *Job) createError(error string, cmd string, status string) job.IError {
 	cmdError := util.NewPMJobError(error)
 	cmdError.SetCommand(cmd)
@@ -2,6 +2,8 @@ package npm
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
 
 	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
@@ -103,3 +105,23 @@ func TestRunInstallCmdOutputErr(t *testing.T) {
 
 	jobTestdata.AssertPathErr(t, j.Errors())
 }
+
+func TestRunResolvesStaticallyWithoutNpm(t *testing.T) {
+	dir := t.TempDir()
+	packageJson := filepath.Join(dir, "package.json")
+	content := `{"name": "app", "dependencies": {"lodash": "4.17.21", "react": "^18.0.0"}}`
+	assert.NoError(t, os.WriteFile(packageJson, []byte(content), 0600))
+	cmdErr := errors.New("exec: \"npm\": executable file not found in $PATH")
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.MakeInstallErr = cmdErr
+	j := NewJob(packageJson, true, cmdFactoryMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Empty(t, j.Errors().GetCriticalErrors())
+	warnings := j.Errors().GetWarningErrors()
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0].Documentation(), "npm wasn't found")
+	assert.FileExists(t, filepath.Join(dir, "package-lock.json"))
+}
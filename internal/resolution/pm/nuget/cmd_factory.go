@@ -2,6 +2,7 @@ package nuget
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
 	"html/template"
 	"io"
@@ -11,13 +12,18 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/registry"
 )
 
 const packagesConfigLockfile = "packages.config.nuget.debricked.lock"
 const nugetLockfile = "packages.lock.json"
 
 type ICmdFactory interface {
-	MakeInstallCmd(command string, file string) (*exec.Cmd, error)
+	// MakeInstallCmd makes the command restoring file. Commands run to make it are stopped once ctx is done.
+	MakeInstallCmd(ctx context.Context, command string, file string) (*exec.Cmd, error)
 	GetTempoCsproj() string
 }
 
@@ -29,7 +35,7 @@ type ExecPath struct {
 }
 
 func (ExecPath) LookPath(file string) (string, error) {
-	return exec.LookPath(file)
+	return util.LookPath(file)
 }
 
 var packagesConfigTemplate = `
@@ -50,14 +56,16 @@ type CmdFactory struct {
 	packageConfigRegex     string
 	packagesConfigTemplate string
 	tempoCsproj            string
+	registries             *registry.Config
 }
 
-func NewCmdFactory(execPath IExecPath) *CmdFactory {
+func NewCmdFactory(execPath IExecPath, registries *registry.Config) *CmdFactory {
 	return &CmdFactory{
 		execPath:               execPath,
 		packageConfigRegex:     PackagesConfigRegex,
 		packagesConfigTemplate: packagesConfigTemplate,
 		tempoCsproj:            "",
+		registries:             registries,
 	}
 }
 
@@ -65,7 +73,7 @@ func (cmdf *CmdFactory) GetTempoCsproj() string {
 	return cmdf.tempoCsproj
 }
 
-func (cmdf *CmdFactory) MakeInstallCmd(command string, file string) (*exec.Cmd, error) {
+func (cmdf *CmdFactory) MakeInstallCmd(ctx context.Context, command string, file string) (*exec.Cmd, error) {
 
 	path, err := cmdf.execPath.LookPath(command)
 
@@ -82,7 +90,7 @@ func (cmdf *CmdFactory) MakeInstallCmd(command string, file string) (*exec.Cmd,
 
 	fileLockName := nugetLockfile
 	if packageConfig.MatchString(file) {
-		file, err = cmdf.convertPackagesConfigToCsproj(file, command)
+		file, err = cmdf.convertPackagesConfigToCsproj(ctx, file, command)
 		cmdf.tempoCsproj = file
 		if err != nil {
 			return nil, err
@@ -93,7 +101,7 @@ func (cmdf *CmdFactory) MakeInstallCmd(command string, file string) (*exec.Cmd,
 	fileDir := filepath.Dir(file)
 	file = filepath.Base(file)
 
-	return &exec.Cmd{
+	cmd := &exec.Cmd{
 		Path: path,
 		Args: []string{command, "restore",
 			file,
@@ -102,7 +110,10 @@ func (cmdf *CmdFactory) MakeInstallCmd(command string, file string) (*exec.Cmd,
 			fileLockName,
 		},
 		Dir: fileDir,
-	}, err
+	}
+	cmdf.registries.ApplyNuget(cmd)
+
+	return cmd, err
 }
 
 type Packages struct {
@@ -120,13 +131,13 @@ type Package struct {
 // that enables debricked to parse out transitive dependencies.
 // This may add some additional framework dependencies that will not show up if
 // we only scan the packages.config file.
-func (cmdf *CmdFactory) convertPackagesConfigToCsproj(filePath string, command string) (string, error) {
+func (cmdf *CmdFactory) convertPackagesConfigToCsproj(ctx context.Context, filePath string, command string) (string, error) {
 	packages, err := parsePackagesConfig(filePath)
 	if err != nil {
 		return "", err
 	}
 
-	targetFrameworksStr, err := collectUniqueTargetFrameworks(packages.Packages, command)
+	targetFrameworksStr, err := collectUniqueTargetFrameworks(ctx, packages.Packages, command)
 	if err != nil {
 		return "", err
 	}
@@ -164,8 +175,8 @@ func (cmdf *CmdFactory) createCsprojContentWithTemplate(targetFrameworksStr stri
 
 var ioReadAllCsproj = io.ReadAll
 
-func getDotnetVersion(command string) (string, error) {
-	cmd := exec.Command(command, "--version")
+func getDotnetVersion(ctx context.Context, command string) (string, error) {
+	cmd := job.WithContext(ctx, exec.Command(command, "--version"))
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	err := cmd.Run()
@@ -209,7 +220,7 @@ func parsePackagesConfig(filePath string) (*Packages, error) {
 	return &packages, nil
 }
 
-func collectUniqueTargetFrameworks(packages []Package, command string) (string, error) {
+func collectUniqueTargetFrameworks(ctx context.Context, packages []Package, command string) (string, error) {
 	uniqueTargetFrameworks := make(map[string]struct{})
 	for _, pkg := range packages {
 		uniqueTargetFrameworks[pkg.TargetFramework] = struct{}{}
@@ -225,7 +236,7 @@ func collectUniqueTargetFrameworks(packages []Package, command string) (string,
 	sort.Strings(targetFrameworks) // Sort the targetFrameworks slice
 
 	if len(targetFrameworks) == 0 {
-		dotnetVersion, err := getDotnetVersion(command)
+		dotnetVersion, err := getDotnetVersion(ctx, command)
 		if err != nil {
 			return "", err
 		}
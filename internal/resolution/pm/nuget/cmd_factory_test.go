@@ -1,6 +1,7 @@
 package nuget
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -8,14 +9,16 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/debricked/cli/internal/resolution/registry"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestMakeInstallCmd(t *testing.T) {
 	cmdf := NewCmdFactory(
 		ExecPath{},
+		nil,
 	)
-	cmd, err := cmdf.MakeInstallCmd(nuget, "file")
+	cmd, err := cmdf.MakeInstallCmd(context.Background(), nuget, "file")
 	assert.NoError(t, err)
 	assert.NotNil(t, cmd)
 	args := cmd.Args
@@ -33,8 +36,9 @@ func TestMakeInstallCmdPackagsConfig(t *testing.T) {
 
 	cmdf := NewCmdFactory(
 		ExecPath{},
+		nil,
 	)
-	cmd, err := cmdf.MakeInstallCmd(nuget, "testdata/valid/packages.config")
+	cmd, err := cmdf.MakeInstallCmd(context.Background(), nuget, "testdata/valid/packages.config")
 	assert.NoError(t, err)
 	assert.NotNil(t, cmd)
 	args := cmd.Args
@@ -154,7 +158,7 @@ func TestCollectUniqueTargetFrameworks(t *testing.T) {
 		{TargetFramework: "net46"},
 		{TargetFramework: "net45"},
 	}
-	got, err := collectUniqueTargetFrameworks(packages, nuget)
+	got, err := collectUniqueTargetFrameworks(context.Background(), packages, nuget)
 	if err != nil {
 		t.Errorf("collectUniqueTargetFrameworks() error = %v", err)
 	}
@@ -319,7 +323,7 @@ func TestMakeInstallCmdBadPackagesConfigRegex(t *testing.T) {
 	cmd, err := (&CmdFactory{
 		execPath:           ExecPath{},
 		packageConfigRegex: "[",
-	}).MakeInstallCmd(nuget, "file")
+	}).MakeInstallCmd(context.Background(), nuget, "file")
 
 	assert.Error(t, err)
 	assert.Nil(t, cmd)
@@ -349,7 +353,8 @@ func TestMakeInstallCmdNotAccessToFile(t *testing.T) {
 
 	_, err = NewCmdFactory(
 		ExecPath{},
-	).MakeInstallCmd(nuget, file.Name())
+		nil,
+	).MakeInstallCmd(context.Background(), nuget, file.Name())
 
 	assert.Error(t, err)
 }
@@ -366,7 +371,7 @@ func TestMakeInstallCmdExecPathError(t *testing.T) {
 	cmd, err := (&CmdFactory{
 		execPath:           ExecPathErr{},
 		packageConfigRegex: PackagesConfigRegex,
-	}).MakeInstallCmd(nuget, "file")
+	}).MakeInstallCmd(context.Background(), nuget, "file")
 
 	assert.Error(t, err)
 	assert.Nil(t, cmd)
@@ -424,7 +429,7 @@ func TestConvertPackagesConfigToCsproj(t *testing.T) {
 				packageConfigRegex:     PackagesConfigRegex,
 				packagesConfigTemplate: tt.packagesConfigTemplate,
 			}
-			_, err := cmd.convertPackagesConfigToCsproj(tt.filePath, nugetCommand)
+			_, err := cmd.convertPackagesConfigToCsproj(context.Background(), tt.filePath, nugetCommand)
 			if (err != nil) != tt.wantError {
 				t.Errorf("convertPackagesConfigToCsproj(%q) = %v, want error: %v", tt.filePath, err, tt.wantError)
 			}
@@ -439,7 +444,7 @@ func TestConvertPackagesConfigToCsproj(t *testing.T) {
 }
 
 func TestGetDotnetVersion(t *testing.T) {
-	version, err := getDotnetVersion(nuget)
+	version, err := getDotnetVersion(context.Background(), nuget)
 	if err != nil {
 		t.Errorf("getDotnetVersion returned an error: %v", err)
 	}
@@ -448,12 +453,21 @@ func TestGetDotnetVersion(t *testing.T) {
 	}
 
 	// Test with a non-existent command
-	_, err = getDotnetVersion("non-existent-command")
+	_, err = getDotnetVersion(context.Background(), "non-existent-command")
 	if err == nil {
 		t.Errorf("getDotnetVersion did not return an error")
 	}
 }
 
+func TestGetDotnetVersionCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := getDotnetVersion(ctx, "echo")
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
 func TestGetDefaultFrameworkOfDotnetVersion(t *testing.T) {
 	tests := []struct {
 		version string
@@ -474,3 +488,11 @@ func TestGetDefaultFrameworkOfDotnetVersion(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeInstallCmdRegistry(t *testing.T) {
+	registries := &registry.Config{Nuget: &registry.Registry{Url: "https://nuget.example.com/v3/index.json"}}
+	cmd, err := NewCmdFactory(ExecPath{}, registries).MakeInstallCmd(context.Background(), nuget, "file")
+	assert.NoError(t, err)
+	assert.Contains(t, cmd.Args, "restore")
+	assert.Contains(t, cmd.Args, "--configfile")
+}
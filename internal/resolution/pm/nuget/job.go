@@ -8,7 +8,9 @@ import (
 	"strings"
 
 	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/static"
 	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
 )
 
 const (
@@ -51,19 +53,51 @@ func (j *Job) Run() {
 		defer j.cleanupTempCsproj()
 		if err != nil {
 			formatted_error := fmt.Errorf("%s\n%s", output, err)
+			if j.resolveStatically(formatted_error.Error()) {
+				return
+			}
 			j.handleError(j.createError(formatted_error.Error(), cmd, status))
 
 			return
 		}
+		j.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), j.lockFileName()))
 	}
 
 }
 
+// lockFileName returns the name of the lock file written by dotnet for the manifest file
+func (j *Job) lockFileName() string {
+	if regexp.MustCompile(PackagesConfigRegex).MatchString(filepath.Base(j.GetFile())) {
+		return packagesConfigLockfile
+	}
+
+	return nugetLockfile
+}
+
+// resolveStatically parses a packages.config without dotnet if dotnet isn't installed, reporting whether it succeeded
+func (j *Job) resolveStatically(cause string) bool {
+	if !regexp.MustCompile(PackagesConfigRegex).MatchString(filepath.Base(j.GetFile())) {
+		return false
+	}
+	if !regexp.MustCompile(executableNotFoundErrRegex).MatchString(cause) {
+		return false
+	}
+	j.SendStatus("resolving statically")
+	lockFile, warning, err := static.Resolve(j.GetFile(), nuget, cause, static.ParsePackagesConfig, writer.FileWriter{})
+	if err != nil {
+		return false
+	}
+	j.AddLockFile(lockFile)
+	j.Errors().Warning(warning)
+
+	return true
+}
+
 var osRemoveAll = os.RemoveAll
 
 func (j *Job) runInstallCmd() ([]byte, string, error) {
 	j.nugetCommand = nuget
-	installCmd, err := j.cmdFactory.MakeInstallCmd(j.nugetCommand, j.GetFile())
+	installCmd, err := j.cmdFactory.MakeInstallCmd(j.Context(), j.nugetCommand, j.GetFile())
 
 	if err != nil {
 		command := ""
@@ -74,7 +108,7 @@ func (j *Job) runInstallCmd() ([]byte, string, error) {
 		return nil, command, err
 	}
 
-	installCmdOutput, err := installCmd.Output()
+	installCmdOutput, err := j.Sandboxed(installCmd).Output()
 	if err != nil {
 		return installCmdOutput, installCmd.String(), j.GetExitError(err, "")
 	}
@@ -1,6 +1,7 @@
 package nuget
 
 import (
+	"context"
 	"errors"
 	"os"
 	"path/filepath"
@@ -124,7 +125,7 @@ func TestRunInstallCmdErr(t *testing.T) {
 			cmdErr := errors.New(c.error)
 			cmdFactoryMock := testdata.NewEchoCmdFactory()
 			cmdFactoryMock.MakeInstallErr = cmdErr
-			cmd, _ := cmdFactoryMock.MakeInstallCmd("echo", "package.json")
+			cmd, _ := cmdFactoryMock.MakeInstallCmd(context.Background(), "echo", "package.json")
 
 			expectedError := util.NewPMJobError("\n" + c.error)
 			expectedError.SetDocumentation(c.doc)
@@ -146,7 +147,7 @@ func TestRunInstallCmdErr(t *testing.T) {
 
 func TestInstallCmdErrCleansUpLockFile(t *testing.T) {
 	dir := "testdata/invalid_dependency"
-	cmdFactory := NewCmdFactory(ExecPath{})
+	cmdFactory := NewCmdFactory(ExecPath{}, nil)
 
 	j := NewJob(filepath.Join(dir, "packages.config"), true, cmdFactory)
 
@@ -163,7 +164,7 @@ func TestInstallCmdErrCleansUpLockFile(t *testing.T) {
 
 func TestSuccessfulInstallCmdWontDeleteLockFile(t *testing.T) {
 	dir := "testdata/valid"
-	cmdFactory := NewCmdFactory(ExecPath{})
+	cmdFactory := NewCmdFactory(ExecPath{}, nil)
 
 	j := NewJob(filepath.Join(dir, "packages.config"), true, cmdFactory)
 
@@ -207,3 +208,35 @@ func TestNoCmdOutputError(t *testing.T) {
 	assert.Len(t, j.Errors().GetAll(), 1)
 	assert.Contains(t, allErrors, expectedError)
 }
+
+func TestRunResolvesStaticallyWithoutDotnet(t *testing.T) {
+	dir := t.TempDir()
+	packagesConfig := filepath.Join(dir, "packages.config")
+	content := `<packages><package id="Newtonsoft.Json" version="13.0.1" targetFramework="net472" /></packages>`
+	assert.NoError(t, os.WriteFile(packagesConfig, []byte(content), 0600))
+	cmdErr := errors.New("exec: \"dotnet\": executable file not found in $PATH")
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.MakeInstallErr = cmdErr
+	j := NewJob(packagesConfig, true, cmdFactoryMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Empty(t, j.Errors().GetCriticalErrors())
+	warnings := j.Errors().GetWarningErrors()
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0].Documentation(), "dotnet wasn't found")
+	assert.FileExists(t, filepath.Join(dir, "packages.config.nuget.debricked.lock"))
+}
+
+func TestRunWontResolveCsprojStatically(t *testing.T) {
+	cmdErr := errors.New("exec: \"dotnet\": executable file not found in $PATH")
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.MakeInstallErr = cmdErr
+	j := NewJob(filepath.Join(t.TempDir(), "app.csproj"), true, cmdFactoryMock)
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Len(t, j.Errors().GetCriticalErrors(), 1)
+}
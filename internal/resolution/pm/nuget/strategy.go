@@ -2,10 +2,12 @@ package nuget
 
 import (
 	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/registry"
 )
 
 type Strategy struct {
-	files []string
+	files      []string
+	registries *registry.Config
 }
 
 func (s Strategy) Invoke() ([]job.IJob, error) {
@@ -14,7 +16,7 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 		jobs = append(jobs, NewJob(
 			file,
 			true,
-			NewCmdFactory(ExecPath{}),
+			NewCmdFactory(ExecPath{}, s.registries),
 		),
 		)
 	}
@@ -22,6 +24,7 @@ func (s Strategy) Invoke() ([]job.IJob, error) {
 	return jobs, nil
 }
 
-func NewStrategy(files []string) Strategy {
-	return Strategy{files}
+// NewStrategy makes jobs resolving files through registries, unless registries is nil
+func NewStrategy(files []string, registries *registry.Config) Strategy {
+	return Strategy{files, registries}
 }
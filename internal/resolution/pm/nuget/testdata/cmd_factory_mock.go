@@ -1,6 +1,7 @@
 package testdata
 
 import (
+	"context"
 	"os/exec"
 )
 
@@ -17,7 +18,7 @@ func NewEchoCmdFactory() CmdFactoryMock {
 	}
 }
 
-func (f CmdFactoryMock) MakeInstallCmd(command string, file string) (*exec.Cmd, error) {
+func (f CmdFactoryMock) MakeInstallCmd(_ context.Context, command string, file string) (*exec.Cmd, error) {
 	return exec.Command(f.InstallCmdName), f.MakeInstallErr
 }
 
@@ -1,6 +1,7 @@
 package testdata
 
 import (
+	"context"
 	"os/exec"
 )
 
@@ -12,7 +13,7 @@ func NewEmptyCmdFactory() EmptyCmdFactoryMock {
 	return EmptyCmdFactoryMock{}
 }
 
-func (f EmptyCmdFactoryMock) MakeInstallCmd(_ string, _ string) (*exec.Cmd, error) {
+func (f EmptyCmdFactoryMock) MakeInstallCmd(_ context.Context, _ string, _ string) (*exec.Cmd, error) {
 	return nil, f.MakeErr
 }
 
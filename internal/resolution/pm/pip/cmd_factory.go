@@ -5,6 +5,8 @@ import (
 	"runtime"
 	"strings"
 
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/registry"
 	"github.com/debricked/cli/internal/runtime/os"
 )
 
@@ -24,11 +26,12 @@ type ExecPath struct {
 }
 
 func (_ ExecPath) LookPath(file string) (string, error) {
-	return exec.LookPath(file)
+	return util.LookPath(file)
 }
 
 type CmdFactory struct {
-	execPath IExecPath
+	execPath   IExecPath
+	registries *registry.Config
 }
 
 func (cmdf CmdFactory) MakeCreateVenvCmd(fpath string) (*exec.Cmd, error) {
@@ -55,11 +58,13 @@ func (cmdf CmdFactory) MakeCreateVenvCmd(fpath string) (*exec.Cmd, error) {
 
 func (cmdf CmdFactory) MakeInstallCmd(command string, file string) (*exec.Cmd, error) {
 	path, err := cmdf.execPath.LookPath(command)
-
-	return &exec.Cmd{
+	cmd := &exec.Cmd{
 		Path: path,
 		Args: []string{command, "install", "-r", file},
-	}, err
+	}
+	cmdf.registries.ApplyPip(cmd)
+
+	return cmd, err
 }
 
 func (cmdf CmdFactory) MakeCatCmd(file string) (*exec.Cmd, error) {
@@ -5,6 +5,7 @@ import (
 	"runtime"
 	"testing"
 
+	"github.com/debricked/cli/internal/resolution/registry"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -123,3 +124,14 @@ func TestMakeShowCmd(t *testing.T) {
 	assert.Contains(t, args, "package1")
 	assert.Contains(t, args, "package2")
 }
+
+func TestMakeInstallCmdRegistry(t *testing.T) {
+	registries := &registry.Config{Pip: &registry.Registry{Url: "https://pypi.example.com/simple"}}
+	cmd, err := CmdFactory{
+		execPath:   ExecPath{},
+		registries: registries,
+	}.MakeInstallCmd("pip", "file")
+	assert.NoError(t, err)
+	assert.Contains(t, cmd.Args, "--index-url")
+	assert.Contains(t, cmd.Args, "https://pypi.example.com/simple")
+}
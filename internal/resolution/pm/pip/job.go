@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/static"
 	"github.com/debricked/cli/internal/resolution/pm/util"
 	"github.com/debricked/cli/internal/resolution/pm/writer"
 	internalOs "github.com/debricked/cli/internal/runtime/os"
@@ -31,6 +32,7 @@ type Job struct {
 	job.BaseJob
 	install    bool
 	venvPath   string
+	keepVenv   bool
 	pipCommand string
 	cmdFactory ICmdFactory
 	fileWriter writer.IFileWriter
@@ -70,7 +72,7 @@ func (j *Job) Install() bool {
 func (j *Job) Run() {
 	if j.install {
 		defer func() {
-			if j.venvPath == "" {
+			if j.venvPath == "" || j.keepVenv {
 				return
 			}
 			status := "removing venv"
@@ -87,6 +89,9 @@ func (j *Job) Run() {
 		j.SendStatus(status)
 		_, cmdErr := j.runCreateVenvCmd()
 		if cmdErr != nil {
+			if j.resolveStatically(cmdErr) {
+				return
+			}
 			cmdErr.SetStatus(status)
 			j.handleError(cmdErr, "Error when trying to create python virtual environment")
 
@@ -96,6 +101,9 @@ func (j *Job) Run() {
 		j.SendStatus(status)
 		_, cmdErr = j.runInstallCmd()
 		if cmdErr != nil {
+			if j.resolveStatically(cmdErr) {
+				return
+			}
 			cmdErr.SetStatus(status)
 			j.handleError(cmdErr, cmdErr.Documentation())
 
@@ -105,12 +113,40 @@ func (j *Job) Run() {
 
 	err := j.writeLockContent()
 	if err != nil {
+		if j.resolveStatically(err) {
+			return
+		}
 		j.Errors().Critical(err)
 
 		return
 	}
 }
 
+// resolveStatically parses the requirements file without Python if Python or pip isn't installed, reporting whether it succeeded
+func (j *Job) resolveStatically(cmdError job.IError) bool {
+	executables := map[string]string{
+		pythonExecutableNotFoundErrRegex:  "Python",
+		python3ExecutableNotFoundErrRegex: "Python3",
+		pipExecutableNotFoundErrRegex:     "Pip",
+	}
+	for expression, executable := range executables {
+		if !regexp.MustCompile(expression).MatchString(cmdError.Error()) {
+			continue
+		}
+		j.SendStatus("resolving statically")
+		lockFile, warning, err := static.Resolve(j.GetFile(), executable, cmdError.Error(), static.ParseRequirements, j.fileWriter)
+		if err != nil {
+			return false
+		}
+		j.AddLockFile(lockFile)
+		j.Errors().Warning(warning)
+
+		return true
+	}
+
+	return false
+}
+
 func (j *Job) handleError(cmdError job.IError, defaultError string) {
 	expressions := []string{
 		pythonExecutableNotFoundErrRegex,
@@ -239,7 +275,8 @@ func (j *Job) writeLockContent() job.IError {
 	}
 
 	lockFileName := fmt.Sprintf("%s%s", filepath.Base(j.GetFile()), lockFileExtension)
-	lockFile, err := j.fileWriter.Create(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
+	lockFilePath := util.MakePathFromManifestFile(j.GetFile(), lockFileName)
+	lockFile, err := j.fileWriter.Create(lockFilePath)
 	if err != nil {
 		cmdErr = util.NewPMJobError(err.Error())
 		cmdErr.SetStatus(status)
@@ -269,6 +306,7 @@ func (j *Job) writeLockContent() job.IError {
 
 		return cmdErr
 	}
+	j.AddLockFile(lockFilePath)
 
 	return nil
 }
@@ -289,7 +327,7 @@ func (j *Job) runCreateVenvCmd() ([]byte, job.IError) {
 		return nil, cmdErr
 	}
 
-	createVenvCmdOutput, err := createVenvCmd.Output()
+	createVenvCmdOutput, err := j.Sandboxed(createVenvCmd).Output()
 	if err != nil {
 		cmdErr := util.NewPMJobError(j.GetExitError(err, "").Error())
 		cmdErr.SetCommand(createVenvCmd.String())
@@ -320,7 +358,7 @@ func (j *Job) runInstallCmd() ([]byte, job.IError) {
 		return nil, cmdErr
 	}
 
-	installCmdOutput, err := installCmd.Output()
+	installCmdOutput, err := j.Sandboxed(installCmd).Output()
 	if err != nil {
 		cmdErr := util.NewPMJobError(j.GetExitError(err, "").Error())
 		cmdErr.SetCommand(installCmd.String())
@@ -340,7 +378,7 @@ func (j *Job) runCatCmd() ([]byte, job.IError) {
 		return nil, cmdErr
 	}
 
-	listCmdOutput, err := listCmd.Output()
+	listCmdOutput, err := j.Sandboxed(listCmd).Output()
 	if err != nil {
 		cmdErr := util.NewPMJobError(j.GetExitError(err, "").Error())
 		cmdErr.SetCommand(listCmd.String())
@@ -360,7 +398,7 @@ func (j *Job) runListCmd() ([]byte, job.IError) {
 		return nil, cmdErr
 	}
 
-	listCmdOutput, err := listCmd.Output()
+	listCmdOutput, err := j.Sandboxed(listCmd).Output()
 	if err != nil {
 		cmdErr := util.NewPMJobError(j.GetExitError(err, "").Error())
 		cmdErr.SetCommand(listCmd.String())
@@ -380,7 +418,7 @@ func (j *Job) runShowCmd(packages []string) ([]byte, job.IError) {
 		return nil, cmdErr
 	}
 
-	listCmdOutput, err := listCmd.Output()
+	listCmdOutput, err := j.Sandboxed(listCmd).Output()
 	if err != nil {
 		cmdErr := util.NewPMJobError(j.GetExitError(err, "").Error())
 		cmdErr.SetCommand(listCmd.String())
@@ -427,3 +427,37 @@ func TestErrorStillClean(t *testing.T) {
 	assert.Len(t, j.Errors().GetAll(), 1)
 	assert.True(t, wasCalled)
 }
+
+func TestRunResolvesStaticallyWithoutPip(t *testing.T) {
+	requirements := filepath.Join(t.TempDir(), "requirements.txt")
+	assert.NoError(t, os.WriteFile(requirements, []byte("requests==2.31.0\nflask>=2.0\n"), 0600))
+	cmdErr := errors.New("exec: \"pip\": executable file not found in $PATH")
+	cmdFactoryMock := testdata.NewEchoCmdFactory()
+	cmdFactoryMock.MakeInstallErr = cmdErr
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+	j := NewJob(requirements, true, cmdFactoryMock, fileWriterMock, pipCleaner{})
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.Empty(t, j.Errors().GetCriticalErrors())
+	warnings := j.Errors().GetWarningErrors()
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0].Documentation(), "Pip wasn't found")
+	assert.Contains(t, string(fileWriterMock.Contents), "requests==2.31.0")
+	assert.NotContains(t, string(fileWriterMock.Contents), "Name: flask")
+}
+
+func TestRunKeepVenv(t *testing.T) {
+	fileWriterMock := &writerTestdata.FileWriterMock{}
+	cmdMock := testdata.NewEchoCmdFactory()
+	j := NewJob("file", true, cmdMock, fileWriterMock, nil)
+	j.pipCleaner = &pipCleanerMock{CleanErr: errors.New("clean-error")}
+	j.keepVenv = true
+
+	go jobTestdata.WaitStatus(j)
+	j.Run()
+
+	assert.NotEmpty(t, j.venvPath)
+	assert.False(t, j.Errors().HasError())
+}
@@ -3,30 +3,37 @@ package pip
 import (
 	"github.com/debricked/cli/internal/resolution/job"
 	"github.com/debricked/cli/internal/resolution/pm/writer"
+	"github.com/debricked/cli/internal/resolution/registry"
 )
 
 type Strategy struct {
-	files []string
+	files           []string
+	registries      *registry.Config
+	keepVirtualEnvs bool
 }
 
 func (s Strategy) Invoke() ([]job.IJob, error) {
 	var jobs []job.IJob
 	for _, file := range s.files {
-		jobs = append(jobs, NewJob(
+		j := NewJob(
 			file,
 			true,
 			CmdFactory{
-				execPath: ExecPath{},
+				execPath:   ExecPath{},
+				registries: s.registries,
 			},
 			writer.FileWriter{},
 			pipCleaner{},
-		),
 		)
+		j.keepVenv = s.keepVirtualEnvs
+		jobs = append(jobs, j)
 	}
 
 	return jobs, nil
 }
 
-func NewStrategy(files []string) Strategy {
-	return Strategy{files}
+// NewStrategy makes jobs resolving files through registries, unless registries is nil. The virtual environments
+// dependencies are installed in are kept if keepVirtualEnvs is true.
+func NewStrategy(files []string, registries *registry.Config, keepVirtualEnvs bool) Strategy {
+	return Strategy{files, registries, keepVirtualEnvs}
 }
@@ -14,6 +14,11 @@ func TestPms(t *testing.T) {
 		"gradle",
 		"composer",
 		"pub",
+		"cargo",
+		"spm",
+		"cocoapods",
+		"bundler",
+		"mix",
 	}
 
 	for _, pmName := range pmNames {
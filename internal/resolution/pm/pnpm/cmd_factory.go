@@ -3,6 +3,8 @@ package pnpm
 import (
 	"os/exec"
 	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
 )
 
 type ICmdFactory interface {
@@ -16,7 +18,7 @@ type IExecPath interface {
 type ExecPath struct{}
 
 func (ExecPath) LookPath(file string) (string, error) {
-	return exec.LookPath(file)
+	return util.LookPath(file)
 }
 
 type CmdFactory struct {
@@ -11,6 +11,7 @@ import (
 const (
 	pnpm                       = "pnpm"
 	executableNotFoundErrRegex = `executable file not found`
+	lockFileName               = "pnpm-lock.yaml"
 )
 
 type Job struct {
@@ -50,12 +51,13 @@ func (j *Job) Run() {
 			return
 		}
 
-		if output, err := installCmd.Output(); err != nil {
+		if output, err := j.Sandboxed(installCmd).Output(); err != nil {
 			joined := strings.Join([]string{string(output), j.GetExitError(err, "").Error()}, "")
 			j.handleError(j.createError(joined, installCmd.String(), status))
 
 			return
 		}
+		j.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
 	}
 }
 
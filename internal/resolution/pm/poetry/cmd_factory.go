@@ -4,6 +4,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
 )
 
 type ICmdFactory interface {
@@ -17,7 +19,7 @@ type IExecPath interface {
 type ExecPath struct{}
 
 func (_ ExecPath) LookPath(file string) (string, error) {
-	return exec.LookPath(file)
+	return util.LookPath(file)
 }
 
 type CmdFactory struct {
@@ -10,6 +10,7 @@ import (
 
 const (
 	executableNotFoundErrRegex = `executable file not found`
+	lockFileName               = "poetry.lock"
 )
 
 type Job struct {
@@ -35,13 +36,14 @@ func (j *Job) Run() {
 		return
 	}
 
-	if output, err := lockCmd.Output(); err != nil {
+	if output, err := j.Sandboxed(lockCmd).Output(); err != nil {
 		exitErr := j.GetExitError(err, string(output))
 		errorMessage := strings.Join([]string{string(output), exitErr.Error()}, "")
 		j.handleError(j.createError(errorMessage, lockCmd.String(), status))
 
 		return
 	}
+	j.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
 }
 
 func (j *Job) createError(errorStr string, cmd string, status string) job.IError {
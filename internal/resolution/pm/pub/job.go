@@ -12,6 +12,7 @@ import (
 const (
 	executableNotFoundErrRegex = `executable file not found`
 	depsFileName               = "pubspec.deps.json"
+	lockFileName               = "pubspec.lock"
 )
 
 // Job runs `dart pub get` and `dart pub deps --json` for a given pubspec.yaml.
@@ -40,7 +41,7 @@ func (j *Job) Run() {
 		return
 	}
 
-	if output, err := lockCmd.Output(); err != nil {
+	if output, err := j.Sandboxed(lockCmd).Output(); err != nil {
 		exitErr := j.GetExitError(err, string(output))
 		errorMessage := strings.Join([]string{string(output), exitErr.Error()}, "")
 		j.handleError(j.createError(errorMessage, lockCmd.String(), status))
@@ -58,7 +59,7 @@ func (j *Job) Run() {
 		return
 	}
 
-	depsOutput, err := depsCmd.Output()
+	depsOutput, err := j.Sandboxed(depsCmd).Output()
 	if err != nil {
 		exitErr := j.GetExitError(err, string(depsOutput))
 		errorMessage := strings.Join([]string{string(depsOutput), exitErr.Error()}, "")
@@ -70,12 +71,15 @@ func (j *Job) Run() {
 	status = "writing pubspec.deps.json"
 	j.SendStatus(status)
 
-	err = os.WriteFile(util.MakePathFromManifestFile(j.GetFile(), depsFileName), depsOutput, 0600)
+	depsFile := util.MakePathFromManifestFile(j.GetFile(), depsFileName)
+	err = os.WriteFile(depsFile, depsOutput, 0600)
 	if err != nil {
 		j.handleError(j.createError(err.Error(), "", status))
 
 		return
 	}
+	j.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
+	j.AddLockFile(depsFile)
 }
 
 func (j *Job) createError(errorStr string, cmd string, status string) job.IError {
@@ -1,6 +1,10 @@
 package sbt
 
-import "os/exec"
+import (
+	"os/exec"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
 
 type ICmdFactory interface {
 	MakePomCmd(workingDirectory string) (*exec.Cmd, error)
@@ -9,7 +13,7 @@ type ICmdFactory interface {
 type CmdFactory struct{}
 
 func (CmdFactory) MakePomCmd(workingDirectory string) (*exec.Cmd, error) {
-	path, err := exec.LookPath("sbt")
+	path, err := util.LookPath("sbt")
 
 	return &exec.Cmd{
 		Path: path,
@@ -17,6 +17,7 @@ const (
 	sbtFileNotFoundErrRegex    = `not found: .*build\.sbt`
 	nonParseableBuildErrRegex  = `Illegal character in build file`
 	networkUnreachableErrRegex = `Connection timed out`
+	pomFilePattern             = "target/scala-*/*.pom"
 )
 
 type Job struct {
@@ -97,7 +98,7 @@ func (j *Job) generatePomFile() error {
 	status := "generating Maven POM file"
 	j.SendStatus(status)
 
-	output, err := cmd.CombinedOutput()
+	output, err := j.Sandboxed(cmd).CombinedOutput()
 	if err != nil {
 		errContent := err.Error()
 		if output != nil {
@@ -111,6 +112,16 @@ func (j *Job) generatePomFile() error {
 		return err
 	}
 
+	// makePom writes below the target directory, which isn't copied out of containers with the lock file
+	err = j.Retrieve(pomFilePattern)
+	if err != nil {
+		cmdErr := util.NewPMJobError(err.Error())
+		cmdErr.SetStatus("copying generated POM file")
+		j.handleError(cmdErr)
+
+		return err
+	}
+
 	return nil
 }
 
@@ -168,6 +179,7 @@ func (j *Job) parseAndProcessWithMaven(pomXml string) error {
 	if err := j.createMavenDependencyGraph(workingDirectory, pomXml); err != nil {
 		return err
 	}
+	j.AddLockFile(filepath.Join(workingDirectory, maven.LockFile))
 
 	status = fmt.Sprintf("processing dependencies with Maven resolver using %s", pomXml)
 	j.SendStatus(status)
@@ -208,7 +220,7 @@ func (j *Job) createMavenDependencyGraph(workingDirectory string, pomXml string)
 		return err
 	}
 
-	output, err := cmd.Output()
+	output, err := j.Sandboxed(cmd).Output()
 	if err != nil {
 		errContent := err.Error()
 		if output != nil {
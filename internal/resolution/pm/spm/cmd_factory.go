@@ -0,0 +1,46 @@
+package spm
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+type ICmdFactory interface {
+	MakeResolveCmd(manifestFile string) (*exec.Cmd, error)
+}
+
+type IExecPath interface {
+	LookPath(file string) (string, error)
+}
+
+type ExecPath struct{}
+
+func (_ ExecPath) LookPath(file string) (string, error) {
+	return util.LookPath(file)
+}
+
+type CmdFactory struct {
+	execPath IExecPath
+}
+
+// MakeResolveCmd creates an exec.Cmd that runs `swift package resolve` in the directory
+// of the given manifest file. This resolves all dependencies and writes a Package.resolved
+// file next to Package.swift, without building the package.
+func (cmdf CmdFactory) MakeResolveCmd(manifestFile string) (*exec.Cmd, error) {
+	swiftPath, err := cmdf.execPath.LookPath("swift")
+	if err != nil {
+		return nil, err
+	}
+
+	workingDir := filepath.Dir(filepath.Clean(manifestFile))
+
+	return &exec.Cmd{
+		Path: swiftPath,
+		Args: []string{"swift", "package", "resolve"},
+		Dir:  workingDir,
+		Env:  os.Environ(),
+	}, nil
+}
@@ -0,0 +1,25 @@
+package spm
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type execPathMock struct{}
+
+func (execPathMock) LookPath(file string) (string, error) {
+	return "/usr/bin/" + file, nil
+}
+
+func TestMakeResolveCmd(t *testing.T) {
+	factory := CmdFactory{execPath: execPathMock{}}
+	manifest := filepath.Join("some", "path", "Package.swift")
+
+	cmd, err := factory.MakeResolveCmd(manifest)
+	assert.NoError(t, err)
+	assert.Equal(t, "/usr/bin/swift", cmd.Path)
+	assert.Equal(t, []string{"swift", "package", "resolve"}, cmd.Args)
+	assert.Equal(t, filepath.Dir(manifest), cmd.Dir)
+}
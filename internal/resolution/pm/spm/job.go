@@ -0,0 +1,162 @@
+package spm
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+)
+
+const (
+	executableNotFoundErrRegex = `executable file not found`
+	unresolvableErrRegex       = `Dependencies could not be resolved because ([^\n]+)`
+	repositoryNotFoundErrRegex = `Failed to clone repository (\S+?):?\s`
+	invalidManifestErrRegex    = `Invalid manifest[^\n]*\n\s*([^\s:]+):\d+`
+	noInternetErrRegex         = `Could not resolve host: ([^\s)'"]+)`
+	lockFileName               = "Package.resolved"
+)
+
+// Job runs `swift package resolve` for a Package.swift, producing a Package.resolved.
+type Job struct {
+	job.BaseJob
+	cmdFactory ICmdFactory
+}
+
+func NewJob(file string, cmdFactory ICmdFactory) *Job {
+	return &Job{
+		BaseJob:    job.NewBaseJob(file),
+		cmdFactory: cmdFactory,
+	}
+}
+
+func (j *Job) Run() {
+	status := "generating Package.resolved"
+	j.SendStatus(status)
+
+	resolveCmd, err := j.cmdFactory.MakeResolveCmd(j.GetFile())
+	if err != nil {
+		j.handleError(j.createError(err.Error(), "", status))
+
+		return
+	}
+
+	if output, err := j.Sandboxed(resolveCmd).Output(); err != nil {
+		exitErr := j.GetExitError(err, string(output))
+		errorMessage := strings.Join([]string{string(output), exitErr.Error()}, "")
+		j.handleError(j.createError(errorMessage, resolveCmd.String(), status))
+
+		return
+	}
+	j.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
+}
+
+func (j *Job) createError(errorStr string, cmd string, status string) job.IError {
+	cmdError := util.NewPMJobError(errorStr)
+	cmdError.SetCommand(cmd)
+	cmdError.SetStatus(status)
+
+	return cmdError
+}
+
+func (j *Job) handleError(cmdError job.IError) {
+	expressions := []string{
+		executableNotFoundErrRegex,
+		unresolvableErrRegex,
+		repositoryNotFoundErrRegex,
+		invalidManifestErrRegex,
+		noInternetErrRegex,
+	}
+
+	for _, expression := range expressions {
+		regex := regexp.MustCompile(expression)
+		matches := regex.FindAllStringSubmatch(cmdError.Error(), -1)
+
+		if len(matches) > 0 {
+			cmdError = j.addDocumentation(expression, matches, cmdError)
+			j.Errors().Append(cmdError)
+
+			return
+		}
+	}
+
+	j.Errors().Append(cmdError)
+}
+
+func (j *Job) addDocumentation(expr string, matches [][]string, cmdError job.IError) job.IError {
+	documentation := cmdError.Documentation()
+
+	switch expr {
+	case executableNotFoundErrRegex:
+		documentation = j.GetExecutableNotFoundErrorDocumentation("Swift")
+	case unresolvableErrRegex:
+		documentation = j.getUnresolvableErrorDocumentation(matches)
+	case repositoryNotFoundErrRegex:
+		documentation = j.getRepositoryNotFoundErrorDocumentation(matches)
+	case invalidManifestErrRegex:
+		documentation = j.getInvalidManifestErrorDocumentation(matches)
+	case noInternetErrRegex:
+		documentation = j.getNoInternetErrorDocumentation(matches)
+	}
+
+	cmdError.SetDocumentation(documentation)
+
+	return cmdError
+}
+
+func (j *Job) getUnresolvableErrorDocumentation(matches [][]string) string {
+	reason := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		reason = strings.TrimSuffix(matches[0][1], ".")
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to resolve dependencies because " + reason + ".",
+			"Please check that package versions are correct in the manifest file.",
+		}, " ")
+}
+
+func (j *Job) getRepositoryNotFoundErrorDocumentation(matches [][]string) string {
+	repository := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		repository = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to clone package repository",
+			"\"" + repository + "\".",
+			"Please check that the package URL is correct in the manifest file.",
+			"\n" + util.InstallPrivateDependencyMessage,
+		}, " ")
+}
+
+func (j *Job) getInvalidManifestErrorDocumentation(matches [][]string) string {
+	manifest := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		manifest = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Failed to compile",
+			"\"" + manifest + "\".",
+			"Please check that the manifest file is valid, for example by running `swift package describe`.",
+		}, " ")
+}
+
+func (j *Job) getNoInternetErrorDocumentation(matches [][]string) string {
+	registry := ""
+	if len(matches) > 0 && len(matches[0]) > 1 {
+		registry = matches[0][1]
+	}
+
+	return strings.Join(
+		[]string{
+			"Registry",
+			"\"" + registry + "\"",
+			"is not available at the moment.",
+			"There might be a trouble with your network connection.",
+		}, " ")
+}
@@ -0,0 +1,95 @@
+package spm
+
+import (
+	"errors"
+	"testing"
+
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/spm/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJob(t *testing.T) {
+	j := NewJob("file", testdata.CmdFactoryMock{})
+	assert.Equal(t, "file", j.GetFile())
+	assert.False(t, j.Errors().HasError())
+}
+
+func TestRunResolveCmdErr(t *testing.T) {
+	cases := []struct {
+		name  string
+		error string
+		doc   string
+	}{
+		{
+			name:  "General error",
+			error: "cmd-error",
+			doc:   util.UnknownError,
+		},
+		{
+			name:  "Swift not found",
+			error: "exec: \"swift\": executable file not found in $PATH",
+			doc:   "Swift wasn't found. Please check if it is installed and accessible by the CLI.",
+		},
+		{
+			name:  "Unresolvable versions",
+			error: "error: Dependencies could not be resolved because root depends on 'swift-log' 99.0.0..<100.0.0.\n'swift-log' 99.0.0..<100.0.0 cannot be used because no versions of 'swift-log' match the requirement",
+			doc:   "Failed to resolve dependencies because root depends on 'swift-log' 99.0.0..<100.0.0. Please check that package versions are correct in the manifest file.",
+		},
+		{
+			name:  "Repository not found",
+			error: "error: Failed to clone repository https://github.com/org/private-kit.git:\n    Cloning into bare repository...\n    fatal: could not read Username for 'https://github.com': terminal prompts disabled",
+			doc:   "Failed to clone package repository \"https://github.com/org/private-kit.git\". Please check that the package URL is correct in the manifest file. \n" + util.InstallPrivateDependencyMessage,
+		},
+		{
+			name:  "Invalid manifest",
+			error: "error: Invalid manifest (compiled with: [\"/usr/bin/swiftc\"])\n/app/Package.swift:8:9: error: cannot find 'pakage' in scope",
+			doc:   "Failed to compile \"/app/Package.swift\". Please check that the manifest file is valid, for example by running `swift package describe`.",
+		},
+		{
+			name:  "No internet connection",
+			error: "error: Failed to resolve dependencies: Could not resolve host: github.com",
+			doc:   "Registry \"github.com\" is not available at the moment. There might be a trouble with your network connection.",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			j := NewJob("file", testdata.CmdFactoryMock{ResolveErr: errors.New(c.error), Name: "echo"})
+
+			go jobTestdata.WaitStatus(j)
+
+			j.Run()
+
+			errs := j.Errors().GetAll()
+			assert.Len(t, errs, 1)
+			assert.Equal(t, c.error, errs[0].Error())
+			assert.Equal(t, c.doc+"\n", errs[0].Documentation())
+			assert.Equal(t, "generating Package.resolved", errs[0].Status())
+		})
+	}
+}
+
+func TestRunResolveCmdOutputErr(t *testing.T) {
+	j := NewJob("file", testdata.CmdFactoryMock{Name: "bad-name"})
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	errs := j.Errors().GetAll()
+	assert.Len(t, errs, 1)
+	assert.Contains(t, errs[0].Error(), "executable file not found")
+	assert.Contains(t, errs[0].Documentation(), "Swift wasn't found")
+}
+
+func TestRunSuccess(t *testing.T) {
+	j := NewJob("file", testdata.CmdFactoryMock{Name: "echo"})
+
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+}
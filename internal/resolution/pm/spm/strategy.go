@@ -0,0 +1,23 @@
+package spm
+
+import "github.com/debricked/cli/internal/resolution/job"
+
+type Strategy struct {
+	files []string
+}
+
+func NewStrategy(files []string) Strategy {
+	return Strategy{files: files}
+}
+
+func (s Strategy) Invoke() ([]job.IJob, error) {
+	var jobs []job.IJob
+	for _, file := range s.files {
+		jobs = append(jobs, NewJob(
+			file,
+			CmdFactory{execPath: ExecPath{}},
+		))
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,24 @@
+package testdata
+
+import (
+	"os/exec"
+	"runtime"
+)
+
+type CmdFactoryMock struct {
+	ResolveErr error
+	Name       string
+	Arg        string
+}
+
+func (f CmdFactoryMock) MakeResolveCmd(_ string) (*exec.Cmd, error) {
+	if len(f.Arg) == 0 {
+		f.Arg = `"MakeResolveCmd"`
+	}
+
+	if runtime.GOOS == "windows" && f.Name == "echo" {
+		return exec.Command("cmd", "/C", f.Name, f.Arg), f.ResolveErr
+	}
+
+	return exec.Command(f.Name, f.Arg), f.ResolveErr
+}
@@ -0,0 +1,198 @@
+package static
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/vifraa/gopom"
+)
+
+// MavenLockFile is the name of lock files parsed from pom.xml. Trivial Graph Format has no room for a notice, so
+// the name tells them apart from the maven.debricked.lock written by Maven, while still being found as a Maven lock file.
+const MavenLockFile = "static.maven.debricked.lock"
+
+const (
+	maxParentDepth  = 10
+	maxInterpolated = 10
+)
+
+var mavenPropertyRegex = regexp.MustCompile(`\$\{([^}]+)}`)
+
+// ParsePom creates a static.maven.debricked.lock in Trivial Graph Format from a pom.xml. Properties, managed versions and
+// dependencies are inherited from parent poms found in the local tree through relativePath.
+func ParsePom(pomFile string) (Lock, error) {
+	project, err := gopom.Parse(pomFile)
+	if err != nil {
+		return Lock{}, err
+	}
+	chain := append([]*gopom.Project{project}, parentPoms(pomFile, project)...)
+
+	properties := pomProperties(chain)
+	interpolate := func(value string) string {
+		return interpolatePom(value, properties)
+	}
+
+	managedVersions := map[string]string{}
+	var inherited []gopom.Dependency
+	for i := len(chain) - 1; i >= 0; i-- {
+		for _, dependency := range chain[i].DependencyManagement.Dependencies {
+			managedVersions[interpolate(dependency.GroupID)+":"+interpolate(dependency.ArtifactID)] = dependency.Version
+		}
+		inherited = append(inherited, chain[i].Dependencies...)
+	}
+
+	packaging := project.Packaging
+	if packaging == "" {
+		packaging = "jar"
+	}
+	nodes := []string{fmt.Sprintf(
+		"%s:%s:%s:%s",
+		interpolate(properties["project.groupId"]),
+		interpolate(properties["project.artifactId"]),
+		packaging,
+		interpolate(properties["project.version"]),
+	)}
+	var scopes []string
+	seen := map[string]int{}
+	for _, dependency := range inherited {
+		node, scope, ok := pomDependencyNode(dependency, managedVersions, interpolate)
+		if !ok {
+			continue
+		}
+		key := interpolate(dependency.GroupID) + ":" + interpolate(dependency.ArtifactID)
+		// Dependencies declared closer to the child override inherited ones
+		if i, declared := seen[key]; declared {
+			nodes[i] = node
+			scopes[i-1] = scope
+
+			continue
+		}
+		seen[key] = len(nodes)
+		nodes = append(nodes, node)
+		scopes = append(scopes, scope)
+	}
+
+	var content strings.Builder
+	for i, node := range nodes {
+		content.WriteString(fmt.Sprintf("%d %s\n", i+1, node))
+	}
+	content.WriteString("#\n")
+	for i, scope := range scopes {
+		content.WriteString(fmt.Sprintf("1 %d %s\n", i+2, scope))
+	}
+
+	return Lock{
+		File:    filepath.Join(filepath.Dir(pomFile), MavenLockFile),
+		Content: []byte(content.String()),
+	}, nil
+}
+
+// parentPoms returns the parent poms of project available in the local tree, closest first
+func parentPoms(pomFile string, project *gopom.Project) []*gopom.Project {
+	var parents []*gopom.Project
+	for depth := 0; depth < maxParentDepth && project.Parent.ArtifactID != ""; depth++ {
+		relativePath := project.Parent.RelativePath
+		if relativePath == "" {
+			relativePath = filepath.Join("..", "pom.xml")
+		}
+		parentFile := filepath.Join(filepath.Dir(pomFile), relativePath)
+		if info, err := os.Stat(parentFile); err == nil && info.IsDir() {
+			parentFile = filepath.Join(parentFile, "pom.xml")
+		}
+		parent, err := gopom.Parse(parentFile)
+		if err != nil || parent.ArtifactID != project.Parent.ArtifactID {
+			break
+		}
+		parents = append(parents, parent)
+		pomFile, project = parentFile, parent
+	}
+
+	return parents
+}
+
+// pomProperties merges the properties of chain, letting children override parents, and adds the project properties
+func pomProperties(chain []*gopom.Project) map[string]string {
+	properties := map[string]string{}
+	for i := len(chain) - 1; i >= 0; i-- {
+		for key, value := range chain[i].Properties.Entries {
+			properties[key] = strings.TrimSpace(value)
+		}
+	}
+
+	project := chain[0]
+	groupId, version := project.GroupID, project.Version
+	if groupId == "" {
+		groupId = project.Parent.GroupID
+	}
+	if version == "" {
+		version = project.Parent.Version
+	}
+	for _, prefix := range []string{"project.", "pom."} {
+		properties[prefix+"groupId"] = groupId
+		properties[prefix+"artifactId"] = project.ArtifactID
+		properties[prefix+"version"] = version
+		properties[prefix+"parent.groupId"] = project.Parent.GroupID
+		properties[prefix+"parent.artifactId"] = project.Parent.ArtifactID
+		properties[prefix+"parent.version"] = project.Parent.Version
+	}
+
+	return properties
+}
+
+func interpolatePom(value string, properties map[string]string) string {
+	value = strings.TrimSpace(value)
+	for i := 0; i < maxInterpolated && strings.Contains(value, "${"); i++ {
+		value = mavenPropertyRegex.ReplaceAllStringFunc(value, func(match string) string {
+			if property, ok := properties[match[2:len(match)-1]]; ok {
+				return property
+			}
+
+			return match
+		})
+	}
+
+	return value
+}
+
+// pomDependencyNode formats dependency as groupId:artifactId:type[:classifier]:version:scope. Dependencies without
+// an exact version, or with properties that can't be resolved statically, are skipped.
+func pomDependencyNode(
+	dependency gopom.Dependency,
+	managedVersions map[string]string,
+	interpolate func(string) string,
+) (string, string, bool) {
+	groupId := interpolate(dependency.GroupID)
+	artifactId := interpolate(dependency.ArtifactID)
+	version := dependency.Version
+	if version == "" {
+		version = managedVersions[groupId+":"+artifactId]
+	}
+	version = interpolate(version)
+	if groupId == "" || artifactId == "" || !isExactMavenVersion(version) ||
+		strings.Contains(groupId+artifactId, "${") {
+		return "", "", false
+	}
+
+	dependencyType := interpolate(dependency.Type)
+	if dependencyType == "" {
+		dependencyType = "jar"
+	}
+	scope := interpolate(dependency.Scope)
+	if scope == "" {
+		scope = "compile"
+	}
+	coordinates := []string{groupId, artifactId, dependencyType}
+	if classifier := interpolate(dependency.Classifier); classifier != "" {
+		coordinates = append(coordinates, classifier)
+	}
+	coordinates = append(coordinates, version, scope)
+
+	return strings.Join(coordinates, ":"), scope, true
+}
+
+func isExactMavenVersion(version string) bool {
+	return version != "" && !strings.ContainsAny(version, "[](),$ ")
+}
@@ -0,0 +1,54 @@
+package static
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePom(t *testing.T) {
+	pomFile := filepath.Join("testdata", "maven", "app", "pom.xml")
+
+	lock, err := ParsePom(pomFile)
+
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join("testdata", "maven", "app", MavenLockFile), lock.File)
+	assert.Equal(t, "1 com.example:app:jar:1.2.0\n"+
+		"2 org.slf4j:slf4j-api:jar:2.0.9:compile\n"+
+		"3 com.fasterxml.jackson.core:jackson-databind:jar:2.15.2:compile\n"+
+		"4 com.example:core:jar:tests:1.2.0:test\n"+
+		"#\n"+
+		"1 2 compile\n"+
+		"1 3 compile\n"+
+		"1 4 test\n", string(lock.Content))
+}
+
+func TestParsePomParent(t *testing.T) {
+	lock, err := ParsePom(filepath.Join("testdata", "maven", "pom.xml"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1 com.example:parent:pom:1.2.0\n2 org.slf4j:slf4j-api:jar:1.7.36:compile\n#\n1 2 compile\n", string(lock.Content))
+}
+
+func TestParsePomErr(t *testing.T) {
+	_, err := ParsePom(filepath.Join("testdata", "maven", "missing.xml"))
+
+	assert.Error(t, err)
+}
+
+func TestInterpolatePom(t *testing.T) {
+	properties := map[string]string{"a": "${b}", "b": "1.0", "loop": "${loop}"}
+
+	assert.Equal(t, "1.0", interpolatePom("${a}", properties))
+	assert.Equal(t, "1.0-${c}", interpolatePom(" ${b}-${c} ", properties))
+	assert.Equal(t, "${loop}", interpolatePom("${loop}", properties))
+}
+
+func TestIsExactMavenVersion(t *testing.T) {
+	assert.True(t, isExactMavenVersion("1.0.0"))
+	assert.True(t, isExactMavenVersion("2.0.0-SNAPSHOT"))
+	assert.False(t, isExactMavenVersion(""))
+	assert.False(t, isExactMavenVersion("[1.0,2.0)"))
+	assert.False(t, isExactMavenVersion("${version}"))
+}
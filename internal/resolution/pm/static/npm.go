@@ -0,0 +1,84 @@
+package static
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const npmLockFileName = "package-lock.json"
+
+var exactSemverRegex = regexp.MustCompile(`^[=v]?(\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?)$`)
+
+type packageJson struct {
+	Name                 string            `json:"name,omitempty"`
+	Version              string            `json:"version,omitempty"`
+	Dependencies         map[string]string `json:"dependencies,omitempty"`
+	DevDependencies      map[string]string `json:"devDependencies,omitempty"`
+	OptionalDependencies map[string]string `json:"optionalDependencies,omitempty"`
+}
+
+type npmLockPackage struct {
+	Version  string `json:"version"`
+	Dev      bool   `json:"dev,omitempty"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+type npmLockFile struct {
+	Name            string                 `json:"name,omitempty"`
+	Version         string                 `json:"version,omitempty"`
+	LockfileVersion int                    `json:"lockfileVersion"`
+	Requires        bool                   `json:"requires"`
+	DebrickedNotice string                 `json:"debrickedNotice"`
+	Packages        map[string]interface{} `json:"packages"`
+}
+
+// ParsePackageJson creates a package-lock.json, in lockfile version 3, from a package.json. Only dependencies with
+// exact versions, such as 1.2.3, are resolved.
+func ParsePackageJson(packageJsonFile string) (Lock, error) {
+	content, err := os.ReadFile(packageJsonFile)
+	if err != nil {
+		return Lock{}, err
+	}
+	var manifest packageJson
+	err = json.Unmarshal(content, &manifest)
+	if err != nil {
+		return Lock{}, err
+	}
+
+	lock := npmLockFile{
+		Name:            manifest.Name,
+		Version:         manifest.Version,
+		LockfileVersion: 3,
+		Requires:        true,
+		DebrickedNotice: Notice,
+		Packages:        map[string]interface{}{"": manifest},
+	}
+	addNpmPackages(lock.Packages, manifest.Dependencies, npmLockPackage{})
+	addNpmPackages(lock.Packages, manifest.OptionalDependencies, npmLockPackage{Optional: true})
+	addNpmPackages(lock.Packages, manifest.DevDependencies, npmLockPackage{Dev: true})
+
+	lockContent, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return Lock{}, err
+	}
+
+	return Lock{
+		File:    filepath.Join(filepath.Dir(packageJsonFile), npmLockFileName),
+		Content: append(lockContent, '\n'),
+	}, nil
+}
+
+func addNpmPackages(packages map[string]interface{}, dependencies map[string]string, flags npmLockPackage) {
+	for name, version := range dependencies {
+		match := exactSemverRegex.FindStringSubmatch(strings.TrimSpace(version))
+		key := "node_modules/" + name
+		if _, declared := packages[key]; match == nil || declared {
+			continue
+		}
+		flags.Version = match[1]
+		packages[key] = flags
+	}
+}
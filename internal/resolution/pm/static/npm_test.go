@@ -0,0 +1,39 @@
+package static
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePackageJson(t *testing.T) {
+	lock, err := ParsePackageJson(filepath.Join("testdata", "npm", "package.json"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join("testdata", "npm", "package-lock.json"), lock.File)
+	var lockFile struct {
+		LockfileVersion int                       `json:"lockfileVersion"`
+		DebrickedNotice string                    `json:"debrickedNotice"`
+		Packages        map[string]npmLockPackage `json:"packages"`
+	}
+	assert.NoError(t, json.Unmarshal(lock.Content, &lockFile))
+	assert.Equal(t, 3, lockFile.LockfileVersion)
+	assert.Equal(t, Notice, lockFile.DebrickedNotice)
+	assert.Equal(t, map[string]npmLockPackage{
+		"":                      {Version: "1.0.0"},
+		"node_modules/express":  {Version: "4.18.2"},
+		"node_modules/react":    {Version: "18.2.0"},
+		"node_modules/jest":     {Version: "29.7.0", Dev: true},
+		"node_modules/fsevents": {Version: "2.3.3", Optional: true},
+	}, lockFile.Packages)
+}
+
+func TestParsePackageJsonErr(t *testing.T) {
+	_, err := ParsePackageJson(filepath.Join("testdata", "npm", "missing.json"))
+	assert.Error(t, err)
+
+	_, err = ParsePackageJson(filepath.Join("testdata", "nuget", "packages.config"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,82 @@
+package static
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	packagesConfigLockFile = "packages.config.nuget.debricked.lock"
+	anyTargetFramework     = "any"
+)
+
+type packagesConfig struct {
+	Packages []struct {
+		ID              string `xml:"id,attr"`
+		Version         string `xml:"version,attr"`
+		TargetFramework string `xml:"targetFramework,attr"`
+	} `xml:"package"`
+}
+
+type nugetLockPackage struct {
+	Type      string `json:"type"`
+	Requested string `json:"requested"`
+	Resolved  string `json:"resolved"`
+}
+
+type nugetLockFile struct {
+	Version         int                                    `json:"version"`
+	DebrickedNotice string                                 `json:"debrickedNotice"`
+	Dependencies    map[string]map[string]nugetLockPackage `json:"dependencies"`
+}
+
+// ParsePackagesConfig creates a packages.config.nuget.debricked.lock, in packages.lock.json format, from a
+// packages.config. Packages are grouped on their target framework.
+func ParsePackagesConfig(packagesConfigFile string) (Lock, error) {
+	content, err := os.ReadFile(packagesConfigFile)
+	if err != nil {
+		return Lock{}, err
+	}
+	var config packagesConfig
+	err = xml.Unmarshal(content, &config)
+	if err != nil {
+		return Lock{}, err
+	}
+
+	lock := nugetLockFile{
+		Version:         1,
+		DebrickedNotice: Notice,
+		Dependencies:    map[string]map[string]nugetLockPackage{},
+	}
+	for _, pkg := range config.Packages {
+		version := strings.TrimSpace(pkg.Version)
+		if pkg.ID == "" || version == "" {
+			continue
+		}
+		framework := pkg.TargetFramework
+		if framework == "" {
+			framework = anyTargetFramework
+		}
+		if lock.Dependencies[framework] == nil {
+			lock.Dependencies[framework] = map[string]nugetLockPackage{}
+		}
+		lock.Dependencies[framework][pkg.ID] = nugetLockPackage{
+			Type:      "Direct",
+			Requested: "[" + version + ", )",
+			Resolved:  version,
+		}
+	}
+
+	lockContent, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return Lock{}, err
+	}
+
+	return Lock{
+		File:    filepath.Join(filepath.Dir(packagesConfigFile), packagesConfigLockFile),
+		Content: append(lockContent, '\n'),
+	}, nil
+}
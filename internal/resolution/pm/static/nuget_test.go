@@ -0,0 +1,36 @@
+package static
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePackagesConfig(t *testing.T) {
+	lock, err := ParsePackagesConfig(filepath.Join("testdata", "nuget", "packages.config"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join("testdata", "nuget", "packages.config.nuget.debricked.lock"), lock.File)
+	var lockFile nugetLockFile
+	assert.NoError(t, json.Unmarshal(lock.Content, &lockFile))
+	assert.Equal(t, Notice, lockFile.DebrickedNotice)
+	assert.Equal(t, map[string]map[string]nugetLockPackage{
+		"net48": {
+			"Newtonsoft.Json": {Type: "Direct", Requested: "[13.0.3, )", Resolved: "13.0.3"},
+			"NUnit":           {Type: "Direct", Requested: "[3.14.0, )", Resolved: "3.14.0"},
+		},
+		"any": {
+			"Serilog": {Type: "Direct", Requested: "[3.1.1, )", Resolved: "3.1.1"},
+		},
+	}, lockFile.Dependencies)
+}
+
+func TestParsePackagesConfigErr(t *testing.T) {
+	_, err := ParsePackagesConfig(filepath.Join("testdata", "nuget", "missing.config"))
+	assert.Error(t, err)
+
+	_, err = ParsePackagesConfig(filepath.Join("testdata", "npm", "package.json"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,130 @@
+package static
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	pipLockFileExtension = ".pip.debricked.lock"
+	pipLockFileDelimiter = "***"
+	maxIncludeDepth      = 10
+)
+
+var pinnedRequirementRegex = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9._-]*)\s*(?:\[[^\]]*])?\s*===?\s*([^\s;,*]+)$`)
+
+type pipRequirement struct {
+	name    string
+	version string
+}
+
+// ParseRequirements creates a .pip.debricked.lock from a requirements file, including requirement files it refers
+// to with -r. Only requirements pinned with == or === are resolved.
+func ParseRequirements(requirementsFile string) (Lock, error) {
+	var contents [][]byte
+	var requirements []pipRequirement
+	err := readRequirements(requirementsFile, 0, map[string]bool{}, &contents, &requirements)
+	if err != nil {
+		return Lock{}, err
+	}
+
+	var content bytes.Buffer
+	content.WriteString("# " + Notice + "\n")
+	content.Write(bytes.Join(contents, []byte("\n")))
+	content.WriteString("\n" + pipLockFileDelimiter + "\n")
+	content.WriteString("Package Version\n------- -------\n")
+	for _, requirement := range requirements {
+		content.WriteString(fmt.Sprintf("%s %s\n", requirement.name, requirement.version))
+	}
+	content.WriteString(pipLockFileDelimiter + "\n")
+	for _, requirement := range requirements {
+		content.WriteString(fmt.Sprintf("Name: %s\nVersion: %s\nRequires: \n---\n", requirement.name, requirement.version))
+	}
+
+	return Lock{
+		File:    filepath.Join(filepath.Dir(requirementsFile), filepath.Base(requirementsFile)+pipLockFileExtension),
+		Content: content.Bytes(),
+	}, nil
+}
+
+func readRequirements(
+	requirementsFile string,
+	depth int,
+	visited map[string]bool,
+	contents *[][]byte,
+	requirements *[]pipRequirement,
+) error {
+	if depth > maxIncludeDepth || visited[filepath.Clean(requirementsFile)] {
+		return nil
+	}
+	visited[filepath.Clean(requirementsFile)] = true
+
+	content, err := os.ReadFile(requirementsFile)
+	if err != nil {
+		return err
+	}
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	*contents = append(*contents, bytes.TrimRight(content, "\n"))
+
+	scanner := bufio.NewScanner(bytes.NewReader(bytes.ReplaceAll(content, []byte("\\\n"), []byte(" "))))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if include, ok := requirementsInclude(line); ok {
+			if !filepath.IsAbs(include) {
+				include = filepath.Join(filepath.Dir(requirementsFile), include)
+			}
+			err = readRequirements(include, depth+1, visited, contents, requirements)
+			if err != nil {
+				return err
+			}
+
+			continue
+		}
+		if requirement, ok := parsePinnedRequirement(line); ok {
+			*requirements = append(*requirements, requirement)
+		}
+	}
+
+	return scanner.Err()
+}
+
+func requirementsInclude(line string) (string, bool) {
+	for _, option := range []string{"-r", "--requirement"} {
+		if rest, found := strings.CutPrefix(line, option); found && (rest == "" || rest[0] == ' ' || rest[0] == '=') {
+			return strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(rest), "=")), true
+		}
+	}
+
+	return "", false
+}
+
+// parsePinnedRequirement parses requirements such as `requests[security]==2.31.0 ; python_version >= "3.8"`
+func parsePinnedRequirement(line string) (pipRequirement, bool) {
+	if strings.HasPrefix(line, "-") {
+		return pipRequirement{}, false
+	}
+	if i := strings.Index(line, ";"); i >= 0 {
+		line = line[:i]
+	}
+	// Per-requirement options, such as --hash, follow the specifier
+	if i := strings.Index(line, " --"); i >= 0 {
+		line = line[:i]
+	}
+	match := pinnedRequirementRegex.FindStringSubmatch(strings.TrimSpace(line))
+	if match == nil {
+		return pipRequirement{}, false
+	}
+
+	return pipRequirement{name: match[1], version: match[2]}, true
+}
@@ -0,0 +1,50 @@
+package static
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRequirements(t *testing.T) {
+	lock, err := ParseRequirements(filepath.Join("testdata", "pip", "requirements.txt"))
+
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join("testdata", "pip", "requirements.txt.pip.debricked.lock"), lock.File)
+	sections := strings.Split(string(lock.Content), "\n***\n")
+	assert.Len(t, sections, 3)
+	assert.True(t, strings.HasPrefix(sections[0], "# "+Notice+"\n# Web\nFlask==2.0.3"))
+	assert.Contains(t, sections[0], "pytest==7.4.3  # tests")
+	assert.Equal(t, "Package Version\n------- -------\nFlask 2.0.3\nrequests 2.31.0\npytest 7.4.3\ndjango 4.2.7", sections[1])
+	assert.Contains(t, sections[2], "Name: pytest\nVersion: 7.4.3\nRequires: \n---\n")
+}
+
+func TestParseRequirementsErr(t *testing.T) {
+	_, err := ParseRequirements(filepath.Join("testdata", "pip", "missing.txt"))
+
+	assert.Error(t, err)
+}
+
+func TestParsePinnedRequirement(t *testing.T) {
+	cases := map[string]*pipRequirement{
+		"Flask==2.0.3":                  {name: "Flask", version: "2.0.3"},
+		"zope.interface===5.5.2":        {name: "zope.interface", version: "5.5.2"},
+		"uvicorn[standard] == 0.24.0":   {name: "uvicorn", version: "0.24.0"},
+		"attrs==23.1.0 --hash=sha256:1": {name: "attrs", version: "23.1.0"},
+		"numpy>=1.23":                   nil,
+		"pandas==1.*":                   nil,
+		"--index-url https://pypi.org":  nil,
+	}
+	for line, expected := range cases {
+		requirement, ok := parsePinnedRequirement(line)
+		if expected == nil {
+			assert.False(t, ok, line)
+
+			continue
+		}
+		assert.True(t, ok, line)
+		assert.Equal(t, *expected, requirement, line)
+	}
+}
@@ -0,0 +1,67 @@
+// Package static parses manifest files without their package manager. It is a best-effort fallback for when the
+// package manager isn't installed: only dependencies declared with exact versions are resolved, and transitive
+// dependencies are missing. The lock files are written in the formats the package manager resolvers write.
+package static
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+)
+
+// Notice flags lock files generated by static parsing, in the formats that allow it
+const Notice = "Best-effort lock file generated by the debricked CLI without the package manager. " +
+	"Only dependencies declared with exact versions are included, transitive dependencies are missing."
+
+const status = "resolving statically"
+
+// Lock is a lock file generated from a manifest file
+type Lock struct {
+	File    string
+	Content []byte
+}
+
+func (lock Lock) Write(fileWriter writer.IFileWriter) error {
+	file, err := fileWriter.Create(lock.File)
+	if err != nil {
+		return err
+	}
+	err = fileWriter.Write(file, lock.Content)
+	closeErr := fileWriter.Close(file)
+	if err != nil {
+		return err
+	}
+
+	return closeErr
+}
+
+// Parser generates a Lock from manifestFile
+type Parser func(manifestFile string) (Lock, error)
+
+// Resolve writes the lock file parsed from manifestFile, returning its path. The returned warning flags the lock file as best-effort,
+// keeping cause, the reason the package manager couldn't be used, as its error.
+func Resolve(manifestFile string, pm string, cause string, parse Parser, fileWriter writer.IFileWriter) (string, job.IError, error) {
+	lock, err := parse(manifestFile)
+	if err != nil {
+		return "", nil, err
+	}
+	err = lock.Write(fileWriter)
+	if err != nil {
+		return "", nil, err
+	}
+
+	warning := util.NewPMJobError(cause)
+	warning.SetStatus(status)
+	warning.SetIsCritical(false)
+	warning.SetDocumentation(strings.Join(
+		[]string{
+			pm + " wasn't found, so " + filepath.Base(lock.File) + " was generated by parsing " + filepath.Base(manifestFile) + " without it.",
+			"Only dependencies declared with exact versions are included and transitive dependencies are missing.",
+			"Please install " + pm + " for a complete resolution.",
+		}, " "))
+
+	return lock.File, warning, nil
+}
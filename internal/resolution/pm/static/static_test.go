@@ -0,0 +1,80 @@
+package static
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/inventory"
+	"github.com/debricked/cli/internal/resolution/pm/writer"
+	writerTestdata "github.com/debricked/cli/internal/resolution/pm/writer/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve(t *testing.T) {
+	dir := t.TempDir()
+	manifest := filepath.Join(dir, "pom.xml")
+	parse := func(manifestFile string) (Lock, error) {
+		return Lock{File: filepath.Join(filepath.Dir(manifestFile), "maven.debricked.lock"), Content: []byte("content")}, nil
+	}
+
+	lockFile, warning, err := Resolve(manifest, "Maven", "exec: \"mvn\": executable file not found in $PATH", parse, writer.FileWriter{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "maven.debricked.lock"), lockFile)
+	assert.False(t, warning.IsCritical())
+	assert.Equal(t, "exec: \"mvn\": executable file not found in $PATH", warning.Error())
+	assert.Equal(t, "resolving statically", warning.Status())
+	assert.Equal(t, "Maven wasn't found, so maven.debricked.lock was generated by parsing pom.xml without it. "+
+		"Only dependencies declared with exact versions are included and transitive dependencies are missing. "+
+		"Please install Maven for a complete resolution.\n", warning.Documentation())
+	content, err := os.ReadFile(filepath.Join(dir, "maven.debricked.lock"))
+	assert.NoError(t, err)
+	assert.Equal(t, "content", string(content))
+}
+
+func TestResolveErr(t *testing.T) {
+	parseErr := errors.New("parse-error")
+	parse := func(string) (Lock, error) { return Lock{}, parseErr }
+
+	_, warning, err := Resolve("pom.xml", "Maven", "cause", parse, writer.FileWriter{})
+	assert.Nil(t, warning)
+	assert.ErrorIs(t, err, parseErr)
+
+	writeErr := errors.New("write-error")
+	parse = func(string) (Lock, error) { return Lock{File: "maven.debricked.lock"}, nil }
+	_, warning, err = Resolve("pom.xml", "Maven", "cause", parse, &writerTestdata.FileWriterMock{WriteErr: writeErr})
+	assert.Nil(t, warning)
+	assert.ErrorIs(t, err, writeErr)
+}
+
+// The lock files must be readable by the parsers for the lock files written by the package managers
+func TestLocksAreParseable(t *testing.T) {
+	cases := map[string]struct {
+		parse    Parser
+		manifest string
+		expected int
+	}{
+		"maven": {ParsePom, filepath.Join("testdata", "maven", "app", "pom.xml"), 3},
+		"pip":   {ParseRequirements, filepath.Join("testdata", "pip", "requirements.txt"), 4},
+		"npm":   {ParsePackageJson, filepath.Join("testdata", "npm", "package.json"), 4},
+		"nuget": {ParsePackagesConfig, filepath.Join("testdata", "nuget", "packages.config"), 3},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			lock, err := c.parse(c.manifest)
+			assert.NoError(t, err)
+			lock.File = filepath.Join(t.TempDir(), filepath.Base(lock.File))
+			assert.NoError(t, lock.Write(writer.FileWriter{}))
+
+			dependencies, err := inventory.ParseLockFile(lock.File, c.manifest)
+
+			assert.NoError(t, err)
+			assert.Len(t, dependencies, c.expected)
+			for _, dependency := range dependencies {
+				assert.True(t, dependency.Direct, dependency.Name)
+			}
+		})
+	}
+}
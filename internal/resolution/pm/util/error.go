@@ -1,5 +1,11 @@
 package util
 
+import (
+	"errors"
+
+	"github.com/debricked/cli/internal/resolution/job"
+)
+
 type PMJobError struct {
 	err        string
 	cmd        string
@@ -9,10 +15,15 @@ type PMJobError struct {
 }
 
 var InstallPrivateDependencyMessage = "If this is a private dependency, please make sure that the debricked CLI has access to install it or pre-install it before running the debricked CLI."
+var UntrustedProjectCodeMessage = "Please resolve with `--isolation docker` or `--isolation podman` to run it in a container, or with `--trust-project-code` to run it on the host if you trust the project."
 var UnknownError = "No specific documentation for this problem yet. If you would like this message to more informative for this error, please create an issue here: https://github.com/debricked/cli/issues"
 
+// ErrUntrustedProjectCode is returned for package managers that run code of the project to resolve it, which is only
+// done on the host for trusted projects
+var ErrUntrustedProjectCode = errors.New("project code is only run in containers unless the project is trusted")
+
 func (e PMJobError) Error() string {
-	return e.err
+	return job.Redact(e.err)
 }
 
 func (e PMJobError) Command() string {
@@ -20,7 +31,7 @@ func (e PMJobError) Command() string {
 		return ""
 	}
 
-	return "`" + e.cmd + "`\n"
+	return "`" + job.Redact(e.cmd) + "`\n"
 }
 
 func (e PMJobError) Documentation() string {
@@ -0,0 +1,29 @@
+package util
+
+import (
+	"os/exec"
+	"sync/atomic"
+)
+
+var isolated atomic.Bool
+
+// SetIsolated toggles whether package managers run in containers, in which case executables are looked up in the
+// container rather than on the host
+func SetIsolated(isIsolated bool) {
+	isolated.Store(isIsolated)
+}
+
+// IsIsolated reports whether package managers run in containers
+func IsIsolated() bool {
+	return isolated.Load()
+}
+
+// LookPath searches for the executable file on the host. When isolated the file is returned as is, since it is
+// resolved by the container it runs in.
+func LookPath(file string) (string, error) {
+	if IsIsolated() {
+		return file, nil
+	}
+
+	return exec.LookPath(file)
+}
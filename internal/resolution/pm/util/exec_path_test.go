@@ -0,0 +1,21 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookPath(t *testing.T) {
+	_, err := LookPath("debricked-non-existing-executable")
+	assert.Error(t, err)
+}
+
+func TestLookPathIsolated(t *testing.T) {
+	SetIsolated(true)
+	defer SetIsolated(false)
+
+	path, err := LookPath("debricked-non-existing-executable")
+	assert.NoError(t, err)
+	assert.Equal(t, "debricked-non-existing-executable", path)
+}
@@ -15,6 +15,7 @@ const (
 	dependencyNotFoundErrRegex  = `error.*? "?(https?://[^"\s:]+)?: Not found`
 	registryUnavailableErrRegex = "error Error: getaddrinfo ENOTFOUND ([\\w\\.]+)"
 	permissionDeniedErrRegex    = "Error: (.*): Request failed \"404 Not Found\""
+	lockFileName                = "yarn.lock"
 )
 
 type Job struct {
@@ -54,12 +55,13 @@ func (j *Job) Run() {
 			return
 		}
 
-		if output, err := installCmd.Output(); err != nil {
+		if output, err := j.Sandboxed(installCmd).Output(); err != nil {
 			error := strings.Join([]string{string(output), j.GetExitError(err, "").Error()}, "")
 			j.handleError(j.createError(error, installCmd.String(), status))
 
 			return
 		}
+		j.AddLockFile(util.MakePathFromManifestFile(j.GetFile(), lockFileName))
 	}
 }
 
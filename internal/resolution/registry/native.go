@@ -0,0 +1,365 @@
+package registry
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// passwordEnv and tokenEnv pass secrets to package managers, so that they aren't written to configuration files
+	passwordEnv = "DEBRICKED_REGISTRY_PASSWORD"
+	tokenEnv    = "DEBRICKED_REGISTRY_TOKEN"
+	// sourceId identifies the registry in Maven and NuGet configuration
+	sourceId = "debricked-registry"
+
+	npmrcFile          = "npmrc"
+	mavenSettingsFile  = "settings.xml"
+	nugetConfigFile    = "NuGet.Config"
+	composerHomeDir    = "composer"
+	composerConfigFile = "config.json"
+)
+
+// Write writes the native configuration files of the registries to dir, which is referenced by the commands of package
+// managers until the resolution is done
+func (config *Config) Write(dir string) error {
+	writers := map[string]func() ([]byte, error){}
+	if config.Npm != nil {
+		writers[npmrcFile] = config.npmrc
+	}
+	if config.Maven != nil || len(config.Proxy) > 0 {
+		writers[mavenSettingsFile] = config.mavenSettings
+	}
+	if config.Nuget != nil {
+		writers[nugetConfigFile] = config.nugetConfig
+	}
+	if config.Composer != nil {
+		writers[filepath.Join(composerHomeDir, composerConfigFile)] = config.composerConfig
+	}
+	for name, write := range writers {
+		content, err := write()
+		if err != nil {
+			return err
+		}
+		file := filepath.Join(dir, name)
+		if err = os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			return err
+		}
+		if err = os.WriteFile(file, content, 0o600); err != nil {
+			return err
+		}
+	}
+	config.dir = dir
+
+	return nil
+}
+
+// ApplyPip makes cmd, a pip install command, use the pip registry as index
+func (config *Config) ApplyPip(cmd *exec.Cmd) {
+	if config == nil {
+		return
+	}
+	config.applyProxy(cmd)
+	if len(config.Proxy) > 0 {
+		cmd.Args = append(cmd.Args, "--proxy", config.Proxy)
+	}
+	if config.Pip != nil {
+		cmd.Args = append(cmd.Args, "--index-url", config.Pip.urlWithCredentials())
+	}
+}
+
+// ApplyNpm makes cmd, an npm command, use the npm registry and its credentials
+func (config *Config) ApplyNpm(cmd *exec.Cmd) {
+	if config == nil {
+		return
+	}
+	config.applyProxy(cmd)
+	if config.Npm == nil {
+		return
+	}
+	cmd.Args = append(cmd.Args, "--registry="+config.Npm.Url, "--userconfig="+filepath.Join(config.dir, npmrcFile))
+	if len(config.Npm.token) > 0 {
+		setEnv(cmd, tokenEnv+"="+config.Npm.token)
+	} else if len(config.Npm.password) > 0 {
+		// npm expects passwords base64 encoded
+		setEnv(cmd, passwordEnv+"="+base64.StdEncoding.EncodeToString([]byte(config.Npm.password)))
+	}
+}
+
+// ApplyMaven makes cmd, an mvn command, use the Maven registry as mirror of every repository
+func (config *Config) ApplyMaven(cmd *exec.Cmd) {
+	if config == nil || (config.Maven == nil && len(config.Proxy) == 0) {
+		return
+	}
+	cmd.Args = append(cmd.Args, "--settings", filepath.Join(config.dir, mavenSettingsFile))
+	if config.Maven != nil {
+		config.Maven.applyCredentials(cmd)
+	}
+}
+
+// ApplyNuget makes cmd, a dotnet restore command, use the NuGet registry as only package source
+func (config *Config) ApplyNuget(cmd *exec.Cmd) {
+	if config == nil {
+		return
+	}
+	config.applyProxy(cmd)
+	if config.Nuget == nil {
+		return
+	}
+	cmd.Args = append(cmd.Args, "--configfile", filepath.Join(config.dir, nugetConfigFile))
+	config.Nuget.applyCredentials(cmd)
+}
+
+// ApplyComposer makes cmd, a composer command, use the Composer registry in place of Packagist
+func (config *Config) ApplyComposer(cmd *exec.Cmd) {
+	if config == nil {
+		return
+	}
+	config.applyProxy(cmd)
+	if config.Composer == nil {
+		return
+	}
+	setEnv(cmd, "COMPOSER_HOME="+filepath.Join(config.dir, composerHomeDir))
+	auth, err := config.Composer.composerAuth()
+	if err == nil && auth != nil {
+		setEnv(cmd, "COMPOSER_AUTH="+string(auth))
+	}
+}
+
+func (config *Config) applyProxy(cmd *exec.Cmd) {
+	if len(config.Proxy) == 0 {
+		return
+	}
+	setEnv(cmd, "HTTP_PROXY="+config.Proxy, "HTTPS_PROXY="+config.Proxy, "http_proxy="+config.Proxy, "https_proxy="+config.Proxy)
+	if len(config.NoProxy) > 0 {
+		setEnv(cmd, "NO_PROXY="+config.NoProxy, "no_proxy="+config.NoProxy)
+	}
+}
+
+// applyCredentials passes the secret of registry to cmd through the environment
+func (registry *Registry) applyCredentials(cmd *exec.Cmd) {
+	if len(registry.token) > 0 {
+		setEnv(cmd, tokenEnv+"="+registry.token)
+	} else if len(registry.password) > 0 {
+		setEnv(cmd, passwordEnv+"="+registry.password)
+	}
+}
+
+func setEnv(cmd *exec.Cmd, env ...string) {
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, env...)
+}
+
+// urlWithCredentials returns the URL of registry with its credentials, where tokens are passwords of __token__ unless
+// there is a username
+func (registry *Registry) urlWithCredentials() string {
+	u, err := url.Parse(registry.Url)
+	if err != nil {
+		return registry.Url
+	}
+	switch {
+	case len(registry.token) > 0:
+		username := registry.username
+		if len(username) == 0 {
+			username = "__token__"
+		}
+		u.User = url.UserPassword(username, registry.token)
+	case len(registry.password) > 0:
+		u.User = url.UserPassword(registry.username, registry.password)
+	case len(registry.username) > 0:
+		u.User = url.User(registry.username)
+	}
+
+	return u.String()
+}
+
+// npmrc writes the credentials of the npm registry, referencing the environment for secrets, along with the proxy
+func (config *Config) npmrc() ([]byte, error) {
+	registry := config.Npm
+	lines := []string{"registry=" + registry.Url}
+	u, err := url.Parse(registry.Url)
+	if err != nil {
+		return nil, err
+	}
+	prefix := "//" + u.Host + strings.TrimSuffix(u.Path, "/") + "/:"
+	switch {
+	case len(registry.token) > 0:
+		lines = append(lines, prefix+"_authToken=${"+tokenEnv+"}")
+	case len(registry.password) > 0:
+		lines = append(lines, prefix+"username="+registry.username, prefix+"_password=${"+passwordEnv+"}")
+	}
+	lines = append(lines, prefix+"always-auth=true")
+	if len(config.Proxy) > 0 {
+		lines = append(lines, "proxy="+config.Proxy, "https-proxy="+config.Proxy)
+	}
+	if len(config.NoProxy) > 0 {
+		lines = append(lines, "noproxy="+config.NoProxy)
+	}
+
+	return []byte(strings.Join(lines, "\n") + "\n"), nil
+}
+
+type mavenProperty struct {
+	Name  string `xml:"name"`
+	Value string `xml:"value"`
+}
+
+type mavenServer struct {
+	Id          string          `xml:"id"`
+	Username    string          `xml:"username,omitempty"`
+	Password    string          `xml:"password,omitempty"`
+	HttpHeaders []mavenProperty `xml:"configuration>httpHeaders>property,omitempty"`
+}
+
+type mavenMirror struct {
+	Id       string `xml:"id"`
+	MirrorOf string `xml:"mirrorOf"`
+	Url      string `xml:"url"`
+}
+
+type mavenProxy struct {
+	Id            string `xml:"id"`
+	Active        bool   `xml:"active"`
+	Protocol      string `xml:"protocol"`
+	Host          string `xml:"host"`
+	Port          string `xml:"port,omitempty"`
+	NonProxyHosts string `xml:"nonProxyHosts,omitempty"`
+}
+
+type mavenSettings struct {
+	XMLName xml.Name      `xml:"settings"`
+	Proxies []mavenProxy  `xml:"proxies>proxy,omitempty"`
+	Servers []mavenServer `xml:"servers>server,omitempty"`
+	Mirrors []mavenMirror `xml:"mirrors>mirror,omitempty"`
+}
+
+// mavenSettings writes a settings.xml mirroring every repository, using ${env.*} references for secrets
+func (config *Config) mavenSettings() ([]byte, error) {
+	var settings mavenSettings
+	if len(config.Proxy) > 0 {
+		u, err := url.Parse(config.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		nonProxyHosts := strings.ReplaceAll(strings.ReplaceAll(config.NoProxy, " ", ""), ",", "|")
+		for _, protocol := range []string{"http", "https"} {
+			settings.Proxies = append(settings.Proxies, mavenProxy{
+				Id:            sourceId + "-" + protocol,
+				Active:        true,
+				Protocol:      protocol,
+				Host:          u.Hostname(),
+				Port:          u.Port(),
+				NonProxyHosts: nonProxyHosts,
+			})
+		}
+	}
+	if registry := config.Maven; registry != nil {
+		server := mavenServer{Id: sourceId, Username: registry.username}
+		switch {
+		case len(registry.token) > 0:
+			server.HttpHeaders = []mavenProperty{{Name: "Authorization", Value: "Bearer ${env." + tokenEnv + "}"}}
+		case len(registry.password) > 0:
+			server.Password = "${env." + passwordEnv + "}"
+		}
+		if len(server.Username) > 0 || len(server.Password) > 0 || len(server.HttpHeaders) > 0 {
+			settings.Servers = append(settings.Servers, server)
+		}
+		settings.Mirrors = append(settings.Mirrors, mavenMirror{Id: sourceId, MirrorOf: "*", Url: registry.Url})
+	}
+
+	return marshalXml(settings)
+}
+
+type nugetAdd struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type nugetCredentials struct {
+	XMLName xml.Name
+	Adds    []nugetAdd `xml:"add"`
+}
+
+type nugetConfig struct {
+	XMLName        xml.Name `xml:"configuration"`
+	PackageSources struct {
+		Clear struct{}   `xml:"clear"`
+		Adds  []nugetAdd `xml:"add"`
+	} `xml:"packageSources"`
+	Credentials []nugetCredentials `xml:"packageSourceCredentials>x,omitempty"`
+}
+
+// nugetConfig writes a NuGet.Config with the registry as only package source, using %*% references for secrets
+func (config *Config) nugetConfig() ([]byte, error) {
+	registry := config.Nuget
+	var nuget nugetConfig
+	nuget.PackageSources.Adds = []nugetAdd{{Key: sourceId, Value: registry.Url}}
+	username, secretEnv := registry.username, ""
+	switch {
+	case len(registry.token) > 0:
+		secretEnv = tokenEnv
+		if len(username) == 0 {
+			// Package sources taking tokens, such as Azure Artifacts, accept any username
+			username = sourceId
+		}
+	case len(registry.password) > 0:
+		secretEnv = passwordEnv
+	}
+	if len(secretEnv) > 0 {
+		nuget.Credentials = []nugetCredentials{{
+			XMLName: xml.Name{Local: sourceId},
+			Adds: []nugetAdd{
+				{Key: "Username", Value: username},
+				{Key: "ClearTextPassword", Value: "%" + secretEnv + "%"},
+			},
+		}}
+	}
+
+	return marshalXml(nuget)
+}
+
+func marshalXml(v interface{}) ([]byte, error) {
+	content, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), append(content, '\n')...), nil
+}
+
+// composerConfig writes the global Composer configuration, with the registry in place of Packagist
+func (config *Config) composerConfig() ([]byte, error) {
+	return json.MarshalIndent(map[string]interface{}{
+		"repositories": []interface{}{
+			map[string]interface{}{"type": "composer", "url": config.Composer.Url},
+			map[string]interface{}{"packagist.org": false},
+		},
+	}, "", "  ")
+}
+
+// composerAuth returns the COMPOSER_AUTH of the registry, or nil if it takes no credentials
+func (registry *Registry) composerAuth() ([]byte, error) {
+	u, err := url.Parse(registry.Url)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case len(registry.token) > 0:
+		return json.Marshal(map[string]interface{}{"bearer": map[string]string{u.Hostname(): registry.token}})
+	case len(registry.password) > 0:
+		return json.Marshal(map[string]interface{}{
+			"http-basic": map[string]interface{}{
+				u.Hostname(): map[string]string{"username": registry.username, "password": registry.password},
+			},
+		})
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,201 @@
+// Package registry configures package managers to resolve dependencies through private registries, mirrors and
+// proxies. The registries section of .debricked.yaml is translated into the native configuration of each package
+// manager, with credentials read from environment variables or the keyring rather than from the file itself.
+package registry
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/debricked/cli/internal/auth"
+	"github.com/debricked/cli/internal/config"
+	"github.com/debricked/cli/internal/resolution/job"
+	"gopkg.in/yaml.v3"
+)
+
+// Key is the key of the registries section of .debricked.yaml
+const Key = "registries"
+
+// KeyringUser is the keyring account that registry secrets are stored under
+const KeyringUser = "DebrickedCLI"
+
+// Credentials of a registry, taken from environment variables or the keyring. Secrets can't be set in the file.
+type Credentials struct {
+	Username        string `yaml:"username"`
+	UsernameEnv     string `yaml:"username-env"`
+	PasswordEnv     string `yaml:"password-env"`
+	PasswordKeyring string `yaml:"password-keyring"`
+	TokenEnv        string `yaml:"token-env"`
+	TokenKeyring    string `yaml:"token-keyring"`
+}
+
+type Registry struct {
+	Url         string `yaml:"url"`
+	Credentials `yaml:",inline"`
+	username    string
+	password    string
+	token       string
+}
+
+// Config holds the registries of package managers, by package manager name, along with the proxy to reach them through
+type Config struct {
+	Proxy    string    `yaml:"proxy"`
+	NoProxy  string    `yaml:"no-proxy"`
+	Pip      *Registry `yaml:"pip"`
+	Npm      *Registry `yaml:"npm"`
+	Maven    *Registry `yaml:"mvn"`
+	Nuget    *Registry `yaml:"nuget"`
+	Composer *Registry `yaml:"composer"`
+	// dir holds the native configuration files written by Write
+	dir string
+}
+
+// Parse parses the registries section of .debricked.yaml
+func Parse(section interface{}) (*Config, error) {
+	content, err := yaml.Marshal(section)
+	if err != nil {
+		return nil, err
+	}
+	decoder := yaml.NewDecoder(strings.NewReader(string(content)))
+	decoder.KnownFields(true)
+	var config Config
+	if err = decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", Key, err)
+	}
+
+	return &config, nil
+}
+
+// registries returns the configured registries by package manager name
+func (config *Config) registries() map[string]*Registry {
+	registries := map[string]*Registry{}
+	for name, registry := range map[string]*Registry{
+		"pip":      config.Pip,
+		"npm":      config.Npm,
+		"mvn":      config.Maven,
+		"nuget":    config.Nuget,
+		"composer": config.Composer,
+	} {
+		if registry != nil {
+			registries[name] = registry
+		}
+	}
+
+	return registries
+}
+
+// Resolve validates config and looks up the credentials of its registries in the environment and secrets. The
+// secrets are redacted from the commands and errors of jobs.
+func (config *Config) Resolve(secrets auth.ISecretClient) error {
+	if len(config.Proxy) > 0 {
+		if err := validateUrl(config.Proxy); err != nil {
+			return fmt.Errorf("invalid %s proxy: %w", Key, err)
+		}
+	}
+	for name, registry := range config.registries() {
+		if err := registry.resolve(secrets); err != nil {
+			return fmt.Errorf("invalid %s registry: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (registry *Registry) resolve(secrets auth.ISecretClient) error {
+	if err := validateUrl(registry.Url); err != nil {
+		return err
+	}
+	var err error
+	registry.username = registry.Username
+	if len(registry.UsernameEnv) > 0 {
+		if registry.username, err = lookupEnv(registry.UsernameEnv); err != nil {
+			return err
+		}
+	}
+	if registry.password, err = lookupSecret(registry.PasswordEnv, registry.PasswordKeyring, secrets); err != nil {
+		return err
+	}
+	if registry.token, err = lookupSecret(registry.TokenEnv, registry.TokenKeyring, secrets); err != nil {
+		return err
+	}
+	if len(registry.password) > 0 && len(registry.username) == 0 {
+		return fmt.Errorf("a username is required along with the password of %s", registry.Url)
+	}
+	job.AddSecrets(registry.password, registry.token, base64.StdEncoding.EncodeToString([]byte(registry.password)))
+
+	return nil
+}
+
+func validateUrl(value string) error {
+	u, err := url.Parse(value)
+	if err != nil {
+		return err
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || len(u.Host) == 0 {
+		return fmt.Errorf("%q is not an http or https URL", value)
+	}
+	if u.User != nil {
+		return fmt.Errorf("%q has credentials, which have to be set by username, password-env or token-env instead", u.Redacted())
+	}
+
+	return nil
+}
+
+func lookupEnv(name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok || len(value) == 0 {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+
+	return value, nil
+}
+
+// lookupSecret returns the secret in the environment variable env, or else the keyring entry, which are optional
+func lookupSecret(env string, keyringService string, secrets auth.ISecretClient) (string, error) {
+	if len(env) > 0 {
+		return lookupEnv(env)
+	}
+	if len(keyringService) == 0 {
+		return "", nil
+	}
+	secret, err := secrets.Get(keyringService)
+	if err != nil {
+		return "", fmt.Errorf("failed to get %s from the keyring: %w", keyringService, err)
+	}
+
+	return secret, nil
+}
+
+// NewSecretClient returns the keyring that registry secrets are stored in
+func NewSecretClient() auth.ISecretClient {
+	return auth.DebrickedSecretClient{User: KeyringUser}
+}
+
+// Load loads and resolves the registries section of the .debricked.yaml found from rootPath. Nil is returned if there
+// is no such section.
+func Load(rootPath string, secrets auth.ISecretClient) (*Config, error) {
+	path := config.FindProjectConfig(rootPath)
+	if path == "" {
+		return nil, nil //nolint:nilnil
+	}
+	projectConfig, err := config.LoadProjectConfig(path)
+	if err != nil {
+		return nil, err
+	}
+	section := projectConfig.Value(Key)
+	if section == nil {
+		return nil, nil //nolint:nilnil
+	}
+	registries, err := Parse(section)
+	if err != nil {
+		return nil, err
+	}
+	if err = registries.Resolve(secrets); err != nil {
+		return nil, err
+	}
+
+	return registries, nil
+}
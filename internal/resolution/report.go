@@ -0,0 +1,103 @@
+package resolution
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/debricked/cli/internal/resolution/job"
+)
+
+const (
+	ReportStatusDone     = "done"
+	ReportStatusWarning  = "done with warnings"
+	ReportStatusFailed   = "failed"
+	ReportStatusTimedOut = job.StatusTimedOut
+	ReportStatusCanceled = job.StatusCanceled
+	ReportStatusRestored = "restored from cache"
+)
+
+// Report describes every job of a resolution, for tracking down failing and slow resolutions
+type Report struct {
+	Jobs []JobReport `json:"jobs"`
+}
+
+type JobReport struct {
+	ManifestFile   string        `json:"manifestFile"`
+	PackageManager string        `json:"packageManager"`
+	Status         string        `json:"status"`
+	Start          time.Time     `json:"start"`
+	DurationMs     int64         `json:"durationMs"`
+	Commands       []string      `json:"commands"`
+	LockFiles      []string      `json:"lockFiles"`
+	Errors         []ErrorReport `json:"errors"`
+}
+
+type ErrorReport struct {
+	Message       string `json:"message"`
+	Status        string `json:"status"`
+	Command       string `json:"command"`
+	Documentation string `json:"documentation"`
+	Critical      bool   `json:"critical"`
+}
+
+// NewReport makes the report of resolution, where pms holds the package manager name of each job
+func NewReport(resolution IResolution, pms map[job.IJob]string) Report {
+	report := Report{Jobs: []JobReport{}}
+	for _, j := range resolution.Jobs() {
+		timing := resolution.Timing(j)
+		jobReport := JobReport{
+			ManifestFile:   j.GetFile(),
+			PackageManager: pms[j],
+			Status:         reportStatus(j),
+			Start:          timing.Start,
+			DurationMs:     timing.Duration.Milliseconds(),
+			Commands:       []string{},
+			LockFiles:      []string{},
+			Errors:         []ErrorReport{},
+		}
+		if history, ok := j.(job.ICommandHistory); ok && history.Commands() != nil {
+			jobReport.Commands = history.Commands()
+		}
+		if writer, ok := j.(job.ILockFileWriter); ok && writer.LockFiles() != nil {
+			jobReport.LockFiles = writer.LockFiles()
+		}
+		for _, jobErr := range j.Errors().GetAll() {
+			jobReport.Errors = append(jobReport.Errors, ErrorReport{
+				Message:       jobErr.Error(),
+				Status:        jobErr.Status(),
+				Command:       strings.Trim(jobErr.Command(), "`\n"),
+				Documentation: strings.TrimSpace(jobErr.Documentation()),
+				Critical:      jobErr.IsCritical(),
+			})
+		}
+		report.Jobs = append(report.Jobs, jobReport)
+	}
+
+	return report
+}
+
+// Write writes the report as JSON to file
+func (report Report) Write(file string) error {
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, append(content, '\n'), 0o600)
+}
+
+func reportStatus(j job.IJob) string {
+	errs := j.Errors()
+	if len(errs.GetCriticalErrors()) > 0 {
+		return failedStatus(j)
+	} else if errs.HasError() {
+		return ReportStatusWarning
+	}
+	if cached, ok := j.(interface{ Restored() bool }); ok && cached.Restored() {
+		return ReportStatusRestored
+	}
+
+	return ReportStatusDone
+}
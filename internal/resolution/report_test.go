@@ -0,0 +1,149 @@
+package resolution
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/stretchr/testify/assert"
+)
+
+type commandJob struct {
+	job.BaseJob
+	restored bool
+}
+
+func (j *commandJob) Run() {
+	_ = j.Sandboxed(exec.Command("mvn", "dependency:tree"))
+	j.AddLockFile(filepath.Join(filepath.Dir(j.GetFile()), "maven.debricked.lock"))
+}
+
+func (j *commandJob) Restored() bool {
+	return j.restored
+}
+
+func TestNewReport(t *testing.T) {
+	dir := t.TempDir()
+	manifestFile := filepath.Join(dir, "pom.xml")
+	lockFile := filepath.Join(dir, "maven.debricked.lock")
+	// Files written next to the manifest file aren't taken for lock files unless the job reports them
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "pom.xml.bak"), []byte("<project/>"), 0o600))
+	start := time.Now()
+
+	resolved := &commandJob{BaseJob: job.NewBaseJob(manifestFile)}
+	resolved.Run()
+	failed := testdata.NewJobMock("package.json")
+	jobErr := util.NewPMJobError("npm failed")
+	jobErr.SetStatus("installing")
+	jobErr.SetCommand("npm install")
+	jobErr.SetDocumentation("Please check package.json")
+	failed.SetErr(jobErr)
+	resolution := Resolution{
+		jobs: []job.IJob{resolved, failed},
+		timings: map[job.IJob]Timing{
+			resolved: {Start: start, Duration: 1500 * time.Millisecond},
+		},
+	}
+
+	report := NewReport(resolution, map[job.IJob]string{resolved: "mvn", failed: "npm"})
+
+	assert.Len(t, report.Jobs, 2)
+	resolvedReport := report.Jobs[0]
+	assert.Equal(t, manifestFile, resolvedReport.ManifestFile)
+	assert.Equal(t, "mvn", resolvedReport.PackageManager)
+	assert.Equal(t, ReportStatusDone, resolvedReport.Status)
+	assert.Equal(t, int64(1500), resolvedReport.DurationMs)
+	assert.Len(t, resolvedReport.Commands, 1)
+	assert.Contains(t, resolvedReport.Commands[0], "mvn dependency:tree")
+	assert.Equal(t, []string{lockFile}, resolvedReport.LockFiles)
+	assert.Empty(t, resolvedReport.Errors)
+
+	failedReport := report.Jobs[1]
+	assert.Equal(t, "npm", failedReport.PackageManager)
+	assert.Equal(t, ReportStatusFailed, failedReport.Status)
+	assert.Empty(t, failedReport.Commands)
+	assert.Empty(t, failedReport.LockFiles)
+	assert.Equal(t, []ErrorReport{{
+		Message:       "npm failed",
+		Status:        "installing",
+		Command:       "npm install",
+		Documentation: "Please check package.json",
+		Critical:      true,
+	}}, failedReport.Errors)
+}
+
+func TestNewReportStatus(t *testing.T) {
+	warned := testdata.NewJobMock("pom.xml")
+	warning := util.NewPMJobError("cache failed")
+	warning.SetIsCritical(false)
+	warned.Errors().Warning(warning)
+	restored := &commandJob{BaseJob: job.NewBaseJob("go.mod"), restored: true}
+	timedOut := testdata.NewJobMock("build.gradle")
+	timedOut.SetErr(job.NewTimeoutError(time.Minute))
+
+	report := NewReport(NewResolution([]job.IJob{warned, restored, timedOut}), nil)
+
+	assert.Equal(t, ReportStatusWarning, report.Jobs[0].Status)
+	assert.Equal(t, ReportStatusRestored, report.Jobs[1].Status)
+	assert.Equal(t, ReportStatusTimedOut, report.Jobs[2].Status)
+}
+
+// writingJob keeps running commands and writing lock files until stopped, ignoring its context
+type writingJob struct {
+	job.BaseJob
+	stop    chan struct{}
+	stopped chan struct{}
+}
+
+func (j *writingJob) Run() {
+	defer close(j.stopped)
+	for {
+		select {
+		case <-j.stop:
+			return
+		default:
+			_ = j.Sandboxed(exec.Command("gradle", "dependencies"))
+			j.AddLockFile(filepath.Join(filepath.Dir(j.GetFile()), "gradle.debricked.lock"))
+		}
+	}
+}
+
+func TestNewReportAbandonedJob(t *testing.T) {
+	s := NewScheduler(10)
+	s.stopDelay = 10 * time.Millisecond
+	writing := &writingJob{BaseJob: job.NewBaseJob("build.gradle"), stop: make(chan struct{}), stopped: make(chan struct{})}
+	timeouts := map[job.IJob]time.Duration{writing: 10 * time.Millisecond}
+
+	res, err := s.Schedule(context.Background(), []job.IJob{writing}, timeouts)
+	assert.NoError(t, err)
+	var report Report
+	for i := 0; i < 10; i++ {
+		report = NewReport(res, nil)
+	}
+	close(writing.stop)
+	<-writing.stopped
+
+	assert.Equal(t, ReportStatusTimedOut, report.Jobs[0].Status)
+	assert.NotEmpty(t, report.Jobs[0].Commands)
+	assert.NotEmpty(t, report.Jobs[0].LockFiles)
+}
+
+func TestReportWrite(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "report.json")
+	report := NewReport(NewResolution([]job.IJob{testdata.NewJobMock("go.mod")}), nil)
+
+	assert.NoError(t, report.Write(file))
+
+	content, err := os.ReadFile(file)
+	assert.NoError(t, err)
+	var written Report
+	assert.NoError(t, json.Unmarshal(content, &written))
+	assert.Equal(t, report, written)
+}
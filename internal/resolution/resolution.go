@@ -1,19 +1,31 @@
 package resolution
 
-import "github.com/debricked/cli/internal/resolution/job"
+import (
+	"time"
+
+	"github.com/debricked/cli/internal/resolution/job"
+)
 
 type IResolution interface {
 	Jobs() []job.IJob
 	HasErr() bool
 	GetJobErrorCount() int
+	Timing(j job.IJob) Timing
+}
+
+// Timing is when a job started running, and for how long it ran
+type Timing struct {
+	Start    time.Time
+	Duration time.Duration
 }
 
 type Resolution struct {
-	jobs []job.IJob
+	jobs    []job.IJob
+	timings map[job.IJob]Timing
 }
 
 func NewResolution(jobs []job.IJob) Resolution {
-	return Resolution{jobs}
+	return Resolution{jobs: jobs}
 }
 
 func (r Resolution) Jobs() []job.IJob {
@@ -40,3 +52,8 @@ func (r Resolution) GetJobErrorCount() int {
 
 	return count
 }
+
+// Timing returns the timing of j, which is zero if j wasn't timed
+func (r Resolution) Timing(j job.IJob) Timing {
+	return r.timings[j]
+}
@@ -1,19 +1,30 @@
 package resolution
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"os/signal"
 	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/debricked/cli/internal/cmd/cmderror"
 	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/resolution/cache"
 	resolutionFile "github.com/debricked/cli/internal/resolution/file"
+	"github.com/debricked/cli/internal/resolution/isolation"
 	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/static"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/registry"
+	"github.com/debricked/cli/internal/resolution/scoped"
 	"github.com/debricked/cli/internal/resolution/strategy"
+	"github.com/debricked/cli/internal/scope"
 	"github.com/debricked/cli/internal/tui"
 )
 
@@ -67,6 +78,25 @@ type DebrickedOptions struct {
 	Regenerate           int
 	NpmPreferred         bool
 	ResolutionStrictness StrictnessLevel
+	Offline              bool
+	// Isolation runs package managers in containers of IsolationImages, by package manager name, unless it is None
+	Isolation       isolation.Mode
+	IsolationImages map[string]string
+	// CacheDir is where lock files are cached to skip resolving unchanged manifest files. Caching is off if empty.
+	CacheDir string
+	// Timeouts stop jobs running for too long, by package manager name
+	Timeouts Timeouts
+	// ReportFile is where a JSON report of the resolution is written, unless it is empty
+	ReportFile string
+	// Scopes filters the dependencies of the lock files written, keeping every scope if empty
+	Scopes scope.Filter
+	// Registries are the private registries and proxy to resolve through, unless nil
+	Registries *registry.Config
+	// KeepVirtualEnvs keeps the virtual environments pip installs dependencies in, for call graphs of Python projects
+	KeepVirtualEnvs bool
+	// TrustProjectCode lets package managers that run code of the project to resolve it, such as Bundler and Mix, run
+	// on the host. Otherwise they only run in containers.
+	TrustProjectCode bool
 }
 
 func NewResolver(
@@ -155,8 +185,35 @@ func (r Resolver) Resolve(paths []string, options IOptions) (IResolution, error)
 	}
 	r.setNpmPreferred(dOptions.NpmPreferred)
 	pmBatches := r.batchFactory.Make(files)
+	if dOptions.Registries != nil {
+		registriesDir, err := writeRegistries(dOptions.Registries)
+		if err != nil {
+			return nil, err
+		}
+		defer os.RemoveAll(registriesDir)
+	}
+	// Package managers are stopped on interrupts, since those running in process groups of their own don't get them
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	r.strategyFactory.SetRegistries(dOptions.Registries)
+	r.strategyFactory.SetKeepVirtualEnvs(dOptions.KeepVirtualEnvs)
+	r.strategyFactory.SetTrustProjectCode(dOptions.TrustProjectCode)
+	r.strategyFactory.SetContext(ctx)
+
+	var isolator *isolation.Isolator
+	if dOptions.Isolation != "" && dOptions.Isolation != isolation.None {
+		isolator, err = makeIsolator(dOptions)
+		if err != nil {
+			return nil, err
+		}
+		util.SetIsolated(true)
+		defer util.SetIsolated(false)
+	}
+	resolutionCache := makeCache(dOptions, isolator != nil)
 
 	var jobs []job.IJob
+	pms := map[job.IJob]string{}
+	timeouts := map[job.IJob]time.Duration{}
 	for _, pmBatch := range pmBatches {
 		s, strategyErr := r.strategyFactory.Make(pmBatch, paths)
 		if strategyErr == nil {
@@ -164,11 +221,29 @@ func (r Resolver) Resolve(paths []string, options IOptions) (IResolution, error)
 			if err != nil {
 				return nil, err
 			}
+			if isolator != nil {
+				newJobs = isolator.Isolate(newJobs, pmBatch.Pm().Name())
+			}
+			if resolutionCache != nil {
+				newJobs = cache.Wrap(newJobs, pmBatch.Pm().Name(), resolutionCache)
+			}
+			newJobs = scoped.Wrap(newJobs, pmBatch.Pm().Name(), dOptions.Scopes)
+			for _, j := range newJobs {
+				pms[j] = pmBatch.Pm().Name()
+				timeouts[j] = dOptions.Timeouts.For(pmBatch.Pm().Name())
+			}
 			jobs = append(jobs, newJobs...)
 		}
 	}
 
-	resolution, err := r.scheduler.Schedule(jobs)
+	resolution, err := r.scheduler.Schedule(ctx, jobs, timeouts)
+	// Canceled resolutions are reported as well, to tell the jobs that were canceled apart from those that finished
+	if (err == nil || errors.Is(err, ErrCanceled)) && len(dOptions.ReportFile) > 0 {
+		reportErr := NewReport(resolution, pms).Write(dOptions.ReportFile)
+		if reportErr != nil {
+			return resolution, reportErr
+		}
+	}
 
 	if resolution.HasErr() {
 		jobErrList := tui.NewJobsErrorList(os.Stdout, resolution.Jobs())
@@ -194,6 +269,46 @@ func (r Resolver) Resolve(paths []string, options IOptions) (IResolution, error)
 	return resolution, err
 }
 
+// writeRegistries writes the native configuration of registries to a temporary directory, which is mounted in
+// containers as well, returning the directory
+func writeRegistries(registries *registry.Config) (string, error) {
+	dir, err := os.MkdirTemp("", "debricked-registries-")
+	if err != nil {
+		return "", err
+	}
+	err = registries.Write(dir)
+	if err != nil {
+		_ = os.RemoveAll(dir)
+
+		return "", err
+	}
+
+	return dir, nil
+}
+
+func makeIsolator(options DebrickedOptions) (*isolation.Isolator, error) {
+	root, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	runtime := isolation.NewCliRuntime(string(options.Isolation))
+	isolator := isolation.NewIsolator(runtime, isolation.Images(options.IsolationImages), root)
+
+	return &isolator, nil
+}
+
+func makeCache(options DebrickedOptions, isolated bool) *cache.Cache {
+	if len(options.CacheDir) == 0 {
+		return nil
+	}
+	var images map[string]string
+	if isolated {
+		images = isolation.Images(options.IsolationImages)
+	}
+
+	return cache.NewCache(options.CacheDir, images)
+}
+
 func (r Resolver) refinePaths(paths []string, options DebrickedOptions) ([]string, error) {
 	var fileSet = map[string]bool{}
 	var dirs []string
@@ -249,6 +364,7 @@ func (r Resolver) processDir(fileSet map[string]bool, dir string, options Debric
 			Inclusions:   options.Inclusions,
 			LockFileOnly: false,
 			Strictness:   file.StrictAll,
+			Offline:      options.Offline,
 		},
 	)
 	if err != nil {
@@ -273,7 +389,8 @@ func shouldGenerateLock(fileGroup file.Group, regenerate int) bool {
 	}
 	switch regenerate {
 	case 0:
-		return !fileGroup.HasLockFiles() || shouldGeneratePubDepsFile(fileGroup)
+		return !fileGroup.HasLockFiles() || onlyStaticLockFiles(fileGroup.LockFiles) || anyScopedLockFiles(fileGroup.LockFiles) ||
+			shouldGeneratePubDepsFile(fileGroup)
 	case 1:
 		return onlyNonNativeLockFiles(fileGroup.LockFiles) || shouldGeneratePubDepsFile(fileGroup)
 	case 2:
@@ -295,6 +412,29 @@ func onlyNonNativeLockFiles(lockFiles []string) bool {
 
 }
 
+// onlyStaticLockFiles reports whether lockFiles were all parsed without the package manager, which is retried
+func onlyStaticLockFiles(lockFiles []string) bool {
+	for _, lockFile := range lockFiles {
+		if filepath.Base(lockFile) != static.MavenLockFile {
+			return false
+		}
+	}
+
+	return len(lockFiles) > 0
+}
+
+// anyScopedLockFiles reports whether any of lockFiles lacks the dependencies of some scopes, which is regenerated since
+// the scopes kept may differ
+func anyScopedLockFiles(lockFiles []string) bool {
+	for _, lockFile := range lockFiles {
+		if scoped.IsScoped(lockFile) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func shouldGeneratePubDepsFile(fileGroup file.Group) bool {
 	if !strings.EqualFold(filepath.Base(fileGroup.ManifestFile), "pubspec.yaml") {
 		return false
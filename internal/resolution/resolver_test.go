@@ -1,21 +1,33 @@
 package resolution
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	clientTestdata "github.com/debricked/cli/internal/client/testdata"
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/file/testdata"
+	ioFs "github.com/debricked/cli/internal/io"
+	"github.com/debricked/cli/internal/resolution/cache"
 	resolutionFile "github.com/debricked/cli/internal/resolution/file"
 	fileTestdata "github.com/debricked/cli/internal/resolution/file/testdata"
+	"github.com/debricked/cli/internal/resolution/isolation"
 	"github.com/debricked/cli/internal/resolution/job"
 	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/cargo"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/resolution/registry"
+	"github.com/debricked/cli/internal/resolution/scoped"
 
 	"github.com/debricked/cli/internal/resolution/strategy"
 	strategyTestdata "github.com/debricked/cli/internal/resolution/strategy/testdata"
+	"github.com/debricked/cli/internal/scope"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -51,6 +63,168 @@ func TestResolve(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestResolveIsolated(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+	options := DebrickedOptions{
+		Verbose:         true,
+		Isolation:       isolation.Docker,
+		IsolationImages: map[string]string{"go": "golang:1.23"},
+	}
+	res, err := r.Resolve([]string{"../../go.mod"}, options)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.Jobs())
+	for _, j := range res.Jobs() {
+		assert.IsType(t, &isolation.Job{}, j)
+	}
+
+	_, err = util.LookPath("debricked-non-existing-executable")
+	assert.Error(t, err, "isolation should be reset after resolution")
+}
+
+func TestResolveCached(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+	options := DebrickedOptions{
+		Verbose:  true,
+		CacheDir: t.TempDir(),
+	}
+	res, err := r.Resolve([]string{"../../go.mod"}, options)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, res.Jobs())
+	for _, j := range res.Jobs() {
+		assert.IsType(t, &cache.Job{}, j)
+	}
+}
+
+func TestResolveScoped(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+	pom := filepath.Join(t.TempDir(), "pom.xml")
+	assert.NoError(t, os.WriteFile(pom, []byte("<project/>"), 0o600))
+	options := DebrickedOptions{
+		Verbose: true,
+		Scopes:  scope.Filter{scope.Prod: true},
+	}
+	res, err := r.Resolve([]string{pom, "../../go.mod"}, options)
+	assert.NoError(t, err)
+	assert.Len(t, res.Jobs(), 2)
+	for _, j := range res.Jobs() {
+		_, ok := j.(*scoped.Job)
+		assert.Equal(t, filepath.Base(j.GetFile()) == "pom.xml", ok)
+	}
+}
+
+func TestResolveRegistries(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("TMPDIR", tempDir)
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+	options := DebrickedOptions{
+		Verbose:    true,
+		Registries: &registry.Config{Npm: &registry.Registry{Url: "https://npm.example.com/"}},
+	}
+	res, err := r.Resolve([]string{"../../go.mod"}, options)
+	assert.NoError(t, err)
+	assert.Len(t, res.Jobs(), 1)
+	entries, err := os.ReadDir(tempDir)
+	assert.NoError(t, err)
+	assert.Empty(t, entries, "registry configuration should be removed once resolved")
+}
+
+func TestResolveReport(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+	options := DebrickedOptions{
+		Verbose:    true,
+		ReportFile: reportFile,
+	}
+	res, err := r.Resolve([]string{"../../go.mod"}, options)
+	assert.NoError(t, err)
+
+	content, err := os.ReadFile(reportFile)
+	assert.NoError(t, err)
+	var report Report
+	assert.NoError(t, json.Unmarshal(content, &report))
+	assert.Len(t, report.Jobs, len(res.Jobs()))
+	for _, jobReport := range report.Jobs {
+		assert.Equal(t, "go", jobReport.PackageManager)
+		assert.Equal(t, ReportStatusDone, jobReport.Status)
+		assert.False(t, jobReport.Start.IsZero())
+	}
+}
+
+// canceledScheduler schedules jobs in a resolution canceled before they run
+type canceledScheduler struct{}
+
+func (canceledScheduler) Schedule(_ context.Context, jobs []job.IJob, timeouts map[job.IJob]time.Duration) (IResolution, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	return NewScheduler(workers).Schedule(ctx, jobs, timeouts)
+}
+
+func TestResolveReportCanceled(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		canceledScheduler{},
+	)
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+	options := DebrickedOptions{
+		Verbose:    true,
+		ReportFile: reportFile,
+	}
+	_, err := r.Resolve([]string{"../../go.mod"}, options)
+	assert.Error(t, err)
+
+	content, err := os.ReadFile(reportFile)
+	assert.NoError(t, err)
+	var report Report
+	assert.NoError(t, json.Unmarshal(content, &report))
+	assert.NotEmpty(t, report.Jobs)
+	for _, jobReport := range report.Jobs {
+		assert.Equal(t, ReportStatusCanceled, jobReport.Status)
+	}
+}
+
+func TestResolveReportWriteErr(t *testing.T) {
+	r := NewResolver(
+		&testdata.FinderMock{},
+		resolutionFile.NewBatchFactory(),
+		strategyTestdata.NewStrategyFactoryMock(),
+		NewScheduler(workers),
+	)
+	options := DebrickedOptions{
+		Verbose:    true,
+		ReportFile: filepath.Join(t.TempDir(), "missing", "report.json"),
+	}
+	_, err := r.Resolve([]string{"../../go.mod"}, options)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
 func TestResolveInvokeError(t *testing.T) {
 	r := NewResolver(
 		&testdata.FinderMock{},
@@ -415,3 +589,49 @@ func TestShouldGenerateLockPubDepsExists(t *testing.T) {
 	assert.False(t, shouldGenerateLock(group, 0))
 	assert.False(t, shouldGenerateLock(group, 1))
 }
+
+func TestShouldGenerateLockStaticLockFile(t *testing.T) {
+	group := file.Group{ManifestFile: "pom.xml", LockFiles: []string{filepath.Join("app", "static.maven.debricked.lock")}}
+
+	assert.True(t, shouldGenerateLock(group, 0))
+
+	group.LockFiles = append(group.LockFiles, filepath.Join("app", "maven.debricked.lock"))
+	assert.False(t, shouldGenerateLock(group, 0))
+}
+
+func TestShouldGenerateLockScopedLockFile(t *testing.T) {
+	group := file.Group{ManifestFile: "build.gradle", LockFiles: []string{filepath.Join("app", "gradle.debricked.lock")}}
+
+	assert.False(t, shouldGenerateLock(group, 0))
+
+	group.LockFiles = append(group.LockFiles, filepath.Join("app", "lib", "scoped.gradle.debricked.lock"))
+	assert.True(t, shouldGenerateLock(group, 0))
+}
+
+// pendingScheduler schedules jobs in a resolution without running them
+type pendingScheduler struct{}
+
+func (pendingScheduler) Schedule(_ context.Context, jobs []job.IJob, _ map[job.IJob]time.Duration) (IResolution, error) {
+	return NewResolution(jobs), nil
+}
+
+func TestResolveCargoProject(t *testing.T) {
+	dir := t.TempDir()
+	manifestFile := filepath.Join(dir, "Cargo.toml")
+	assert.NoError(t, os.WriteFile(manifestFile, []byte("[package]\nname = \"app\"\nversion = \"0.1.0\"\n"), 0600))
+	finder, err := file.NewFinder(clientTestdata.NewDebClientMock(), ioFs.FileSystem{})
+	assert.NoError(t, err)
+	r := NewResolver(
+		finder,
+		resolutionFile.NewBatchFactory(),
+		strategy.NewStrategyFactory(),
+		pendingScheduler{},
+	)
+
+	res, err := r.Resolve([]string{dir}, DebrickedOptions{Offline: true})
+
+	assert.NoError(t, err)
+	assert.Len(t, res.Jobs(), 1)
+	assert.IsType(t, &cargo.Job{}, res.Jobs()[0])
+	assert.Equal(t, manifestFile, res.Jobs()[0].GetFile())
+}
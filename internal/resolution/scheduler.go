@@ -1,16 +1,26 @@
 package resolution
 
 import (
+	"context"
+	"errors"
 	"sort"
 	"sync"
+	"time"
 
 	"github.com/chelnak/ysmrr"
 	"github.com/debricked/cli/internal/resolution/job"
 	"github.com/debricked/cli/internal/tui"
 )
 
+var ErrCanceled = errors.New("resolution canceled")
+
+// stopDelay is how long jobs get to stop once their timeout is reached or the resolution is canceled, before they are
+// left running in the background
+const stopDelay = 15 * time.Second
+
 type IScheduler interface {
-	Schedule(jobs []job.IJob) (IResolution, error)
+	// Schedule runs jobs until done or ctx is done, stopping jobs running longer than their timeouts
+	Schedule(ctx context.Context, jobs []job.IJob, timeouts map[job.IJob]time.Duration) (IResolution, error)
 }
 
 type queueItem struct {
@@ -23,20 +33,32 @@ type Scheduler struct {
 	queue          chan queueItem
 	waitGroup      sync.WaitGroup
 	spinnerManager tui.ISpinnerManager
+	ctx            context.Context
+	timeouts       map[job.IJob]time.Duration
+	timings        map[job.IJob]Timing
+	timingsMu      sync.Mutex
+	stopDelay      time.Duration
 }
 
 func NewScheduler(workers int) *Scheduler {
-	return &Scheduler{workers: workers, waitGroup: sync.WaitGroup{}}
+	return &Scheduler{workers: workers, waitGroup: sync.WaitGroup{}, stopDelay: stopDelay}
 }
 
-func (scheduler *Scheduler) Schedule(jobs []job.IJob) (IResolution, error) {
+func (scheduler *Scheduler) Schedule(
+	ctx context.Context,
+	jobs []job.IJob,
+	timeouts map[job.IJob]time.Duration,
+) (IResolution, error) {
+	scheduler.ctx = ctx
+	scheduler.timeouts = timeouts
 	scheduler.queue = make(chan queueItem, len(jobs))
 	scheduler.waitGroup.Add(len(jobs))
+	scheduler.timings = make(map[job.IJob]Timing, len(jobs))
 
 	scheduler.spinnerManager = tui.NewSpinnerManager("Resolving", "waiting for worker")
 
 	for w := 1; w <= scheduler.workers; w++ {
-		go scheduler.worker()
+		go scheduler.worker(scheduler.queue)
 	}
 
 	sort.Slice(jobs, func(i, j int) bool {
@@ -58,20 +80,74 @@ func (scheduler *Scheduler) Schedule(jobs []job.IJob) (IResolution, error) {
 
 	close(scheduler.queue)
 
-	return NewResolution(jobs), nil
+	resolution := Resolution{jobs: jobs, timings: scheduler.timings}
+	if ctx.Err() != nil {
+		return resolution, ErrCanceled
+	}
+
+	return resolution, nil
 }
 
-func (scheduler *Scheduler) worker() {
-	for item := range scheduler.queue {
+// worker runs the jobs of queue, which is passed on since workers of earlier schedules may still be starting
+func (scheduler *Scheduler) worker(queue <-chan queueItem) {
+	for item := range queue {
 		go scheduler.updateStatus(item)
 
-		item.job.Run()
+		scheduler.run(item.job)
 
 		scheduler.finish(item)
 
 		scheduler.waitGroup.Done()
 	}
 }
+
+// run runs j until done, or until its timeout is reached or the resolution is canceled. Jobs still running stopDelay
+// after that, such as jobs running commands that can't be stopped, are no longer waited for.
+func (scheduler *Scheduler) run(j job.IJob) {
+	if scheduler.ctx.Err() != nil {
+		j.Errors().Critical(job.NewCanceledError())
+
+		return
+	}
+	ctx, cancel := scheduler.ctx, context.CancelFunc(func() {})
+	timeout := scheduler.timeouts[j]
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(scheduler.ctx, timeout)
+	}
+	defer cancel()
+	if cancelable, ok := j.(job.ICancelableJob); ok {
+		cancelable.SetContext(ctx)
+	}
+
+	start := time.Now()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		j.Run()
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		select {
+		case <-done:
+		case <-time.After(scheduler.stopDelay):
+		}
+	}
+	scheduler.time(j, Timing{Start: start, Duration: time.Since(start)})
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		j.Errors().Critical(job.NewTimeoutError(timeout))
+	} else if ctx.Err() != nil {
+		j.Errors().Critical(job.NewCanceledError())
+	}
+}
+
+func (scheduler *Scheduler) time(j job.IJob, timing Timing) {
+	scheduler.timingsMu.Lock()
+	defer scheduler.timingsMu.Unlock()
+	scheduler.timings[j] = timing
+}
+
 func (scheduler *Scheduler) updateStatus(item queueItem) {
 	for {
 		msg := <-item.job.ReceiveStatus()
@@ -81,7 +157,7 @@ func (scheduler *Scheduler) updateStatus(item queueItem) {
 
 func (scheduler *Scheduler) finish(item queueItem) {
 	if item.job.Errors().HasError() {
-		scheduler.spinnerManager.SetSpinnerMessage(item.spinner, item.job.GetFile(), "failed")
+		scheduler.spinnerManager.SetSpinnerMessage(item.spinner, item.job.GetFile(), failedStatus(item.job))
 		item.spinner.Error()
 	} else {
 		scheduler.spinnerManager.SetSpinnerMessage(item.spinner, item.job.GetFile(), "done")
@@ -89,3 +165,14 @@ func (scheduler *Scheduler) finish(item queueItem) {
 		item.spinner.Complete()
 	}
 }
+
+// failedStatus tells timed out and canceled jobs apart from jobs failing on their own
+func failedStatus(j job.IJob) string {
+	for _, jobErr := range j.Errors().GetCriticalErrors() {
+		if job.IsTimeout(jobErr) || job.IsCanceled(jobErr) {
+			return jobErr.Status()
+		}
+	}
+
+	return "failed"
+}
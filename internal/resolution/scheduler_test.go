@@ -1,8 +1,11 @@
 package resolution
 
 import (
+	"context"
+	"os/exec"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/debricked/cli/internal/resolution/job"
 	"github.com/debricked/cli/internal/resolution/job/testdata"
@@ -14,7 +17,11 @@ type SchedulerMock struct {
 	JobsMock []job.IJob
 }
 
-func (s SchedulerMock) Schedule(jobs []job.IJob) (IResolution, error) {
+func (s SchedulerMock) Schedule(
+	_ context.Context,
+	jobs []job.IJob,
+	_ map[job.IJob]time.Duration,
+) (IResolution, error) {
 	if s.JobsMock != nil {
 		jobs = s.JobsMock
 	}
@@ -32,25 +39,26 @@ func TestNewScheduler(t *testing.T) {
 
 func TestSchedule(t *testing.T) {
 	s := NewScheduler(10)
-	res, err := s.Schedule([]job.IJob{testdata.NewJobMock("")})
+	res, err := s.Schedule(context.Background(), []job.IJob{testdata.NewJobMock("")}, nil)
 	assert.NoError(t, err)
 	assert.Len(t, res.Jobs(), 1)
+	assert.False(t, res.Timing(res.Jobs()[0]).Start.IsZero())
 
-	res, err = s.Schedule([]job.IJob{})
+	res, err = s.Schedule(context.Background(), []job.IJob{}, nil)
 	assert.NoError(t, err)
 	assert.Len(t, res.Jobs(), 0)
 
-	res, err = s.Schedule(nil)
+	res, err = s.Schedule(context.Background(), nil, nil)
 	assert.NoError(t, err)
 	assert.Len(t, res.Jobs(), 0)
 
-	res, err = s.Schedule([]job.IJob{
+	res, err = s.Schedule(context.Background(), []job.IJob{
 		testdata.NewJobMock("b/b_file.json"),
 		testdata.NewJobMock("a/b_file.json"),
 		testdata.NewJobMock("b/a_file.json"),
 		testdata.NewJobMock("a/a_file.json"),
 		testdata.NewJobMock("a/a_file.json"),
-	})
+	}, nil)
 	assert.NoError(t, err)
 	jobs := res.Jobs()
 
@@ -71,10 +79,80 @@ func TestScheduleJobErr(t *testing.T) {
 	jobMock := testdata.NewJobMock("")
 	jobErr := job.NewBaseJobError("job-error")
 	jobMock.SetErr(jobErr)
-	res, err := s.Schedule([]job.IJob{jobMock})
+	res, err := s.Schedule(context.Background(), []job.IJob{jobMock}, nil)
 	assert.NoError(t, err)
 	assert.Len(t, res.Jobs(), 1)
 	j := res.Jobs()[0]
 	assert.Len(t, j.Errors().GetAll(), 1)
 	assert.Contains(t, j.Errors().GetAll(), jobErr)
 }
+
+type sleepJob struct {
+	job.BaseJob
+}
+
+func (j *sleepJob) Run() {
+	_, err := j.Sandboxed(exec.Command("sleep", "30")).Output()
+	if err != nil {
+		j.Errors().Critical(job.NewBaseJobError(err.Error()))
+	}
+}
+
+func TestScheduleTimeout(t *testing.T) {
+	s := NewScheduler(10)
+	sleeping := &sleepJob{BaseJob: job.NewBaseJob("build.gradle")}
+	fast := testdata.NewJobMock("pom.xml")
+	timeouts := map[job.IJob]time.Duration{sleeping: 100 * time.Millisecond, fast: 100 * time.Millisecond}
+
+	start := time.Now()
+	res, err := s.Schedule(context.Background(), []job.IJob{sleeping, fast}, timeouts)
+
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 10*time.Second)
+	assert.Equal(t, 1, res.GetJobErrorCount())
+	assert.False(t, fast.Errors().HasError())
+	errs := sleeping.Errors().GetCriticalErrors()
+	assert.Len(t, errs, 2)
+	assert.True(t, job.IsTimeout(errs[1]))
+	assert.Equal(t, job.StatusTimedOut, failedStatus(sleeping))
+}
+
+// blockedJob runs until released, ignoring its context
+type blockedJob struct {
+	job.BaseJob
+	release chan struct{}
+}
+
+func (j *blockedJob) Run() {
+	<-j.release
+}
+
+func TestScheduleStopsWaitingForJobsIgnoringTimeout(t *testing.T) {
+	s := NewScheduler(10)
+	s.stopDelay = 10 * time.Millisecond
+	blocked := &blockedJob{BaseJob: job.NewBaseJob("build.gradle"), release: make(chan struct{})}
+	defer close(blocked.release)
+	timeouts := map[job.IJob]time.Duration{blocked: 50 * time.Millisecond}
+
+	start := time.Now()
+	res, err := s.Schedule(context.Background(), []job.IJob{blocked}, timeouts)
+
+	assert.NoError(t, err)
+	assert.Less(t, time.Since(start), 10*time.Second)
+	assert.Equal(t, 1, res.GetJobErrorCount())
+	assert.Equal(t, job.StatusTimedOut, failedStatus(blocked))
+}
+
+func TestScheduleCanceled(t *testing.T) {
+	s := NewScheduler(10)
+	jobMock := testdata.NewJobMock("pom.xml")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	res, err := s.Schedule(ctx, []job.IJob{jobMock}, nil)
+
+	assert.ErrorIs(t, err, ErrCanceled)
+	assert.Equal(t, 1, res.GetJobErrorCount())
+	assert.True(t, job.IsCanceled(jobMock.Errors().GetCriticalErrors()[0]))
+	assert.Equal(t, job.StatusCanceled, failedStatus(jobMock))
+}
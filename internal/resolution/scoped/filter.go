@@ -0,0 +1,136 @@
+package scoped
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/debricked/cli/internal/scope"
+)
+
+// gradleConfigurationRegex matches the headers of configurations, such as `compileClasspath - Compile classpath.`
+var gradleConfigurationRegex = regexp.MustCompile(`^([A-Za-z]\w*)(?: \(n\))?(?: - .*)?$`)
+
+type tgfGraph struct {
+	nodes []string
+	edges []string
+}
+
+// filterTgf filters lock files in Trivial Graph Format, written by `mvn dependency:tree`, where edges are labeled with
+// Maven scopes. Dependencies only reachable through edges of scopes not kept are removed.
+func filterTgf(content []byte, filter scope.Filter) []byte {
+	var filtered bytes.Buffer
+	for _, graph := range parseTgf(string(content)) {
+		graph.filter(filter)
+		for _, node := range graph.nodes {
+			filtered.WriteString(node + "\n")
+		}
+		filtered.WriteString("#\n")
+		for _, edge := range graph.edges {
+			filtered.WriteString(edge + "\n")
+		}
+	}
+
+	return filtered.Bytes()
+}
+
+// parseTgf parses the graphs of content, which are one per module of multi-module builds
+func parseTgf(content string) []tgfGraph {
+	var graphs []tgfGraph
+	inEdges := false
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if line == "#" {
+			inEdges = true
+
+			continue
+		}
+		fields := strings.Fields(line)
+		if inEdges && len(fields) > 1 && !strings.Contains(fields[1], ":") {
+			graphs[len(graphs)-1].edges = append(graphs[len(graphs)-1].edges, line)
+
+			continue
+		}
+		if inEdges || len(graphs) == 0 {
+			graphs = append(graphs, tgfGraph{})
+			inEdges = false
+		}
+		graphs[len(graphs)-1].nodes = append(graphs[len(graphs)-1].nodes, line)
+	}
+
+	return graphs
+}
+
+// filter removes the edges of scopes not kept, along with the nodes no longer reachable from the module
+func (graph *tgfGraph) filter(filter scope.Filter) {
+	if len(graph.nodes) == 0 {
+		return
+	}
+	children := map[string][]string{}
+	var edges []string
+	for _, edge := range graph.edges {
+		fields := strings.Fields(edge)
+		if len(fields) > 2 && !filter.Keeps(scope.Maven(fields[2])) {
+			continue
+		}
+		children[fields[0]] = append(children[fields[0]], fields[1])
+		edges = append(edges, edge)
+	}
+
+	root := strings.Fields(graph.nodes[0])[0]
+	reachable := map[string]bool{root: true}
+	queue := []string{root}
+	for len(queue) > 0 {
+		for _, child := range children[queue[0]] {
+			if !reachable[child] {
+				reachable[child] = true
+				queue = append(queue, child)
+			}
+		}
+		queue = queue[1:]
+	}
+
+	graph.edges = graph.edges[:0]
+	for _, edge := range edges {
+		if reachable[strings.Fields(edge)[0]] {
+			graph.edges = append(graph.edges, edge)
+		}
+	}
+	nodes := graph.nodes[:0]
+	for _, node := range graph.nodes {
+		if reachable[strings.Fields(node)[0]] {
+			nodes = append(nodes, node)
+		}
+	}
+	graph.nodes = nodes
+}
+
+// filterGradleReport filters lock files written by the Gradle dependency report, removing the configurations of scopes
+// not kept. Configurations are listed one after another, each followed by a blank line.
+func filterGradleReport(content []byte, filter scope.Filter) []byte {
+	lines := strings.SplitAfter(string(content), "\n")
+	var filtered strings.Builder
+	skipping := false
+	previousBlank := true
+	for _, line := range lines {
+		text := strings.TrimRight(line, "\r\n")
+		blank := strings.TrimSpace(text) == ""
+		if previousBlank && !blank {
+			if match := gradleConfigurationRegex.FindStringSubmatch(text); match != nil {
+				skipping = !filter.Keeps(scope.Gradle(match[1]))
+			}
+		}
+		if !skipping {
+			filtered.WriteString(line)
+		} else if blank {
+			// The blank line after a skipped configuration is dropped along with it
+			skipping = false
+		}
+		previousBlank = blank
+	}
+
+	return []byte(filtered.String())
+}
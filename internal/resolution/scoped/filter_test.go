@@ -0,0 +1,89 @@
+package scoped
+
+import (
+	"testing"
+
+	"github.com/debricked/cli/internal/scope"
+	"github.com/stretchr/testify/assert"
+)
+
+const tgf = `1 com.example:app:jar:1.0-SNAPSHOT
+2 com.google.guava:guava:jar:32.1.2-jre:compile
+3 com.google.guava:failureaccess:jar:1.0.1:compile
+4 junit:junit:jar:4.13.2:test
+5 org.hamcrest:hamcrest-core:jar:1.3:test
+#
+1 2 compile
+2 3 compile
+1 4 test
+4 5 test
+6 com.example:lib:jar:1.0-SNAPSHOT
+7 org.mockito:mockito-core:jar:5.6.0:test
+#
+6 7 test
+`
+
+const gradleReport = `
+------------------------------------------------------------
+Root project 'app'
+------------------------------------------------------------
+
+annotationProcessor - Annotation processors and their dependencies for source set 'main'.
+\--- org.projectlombok:lombok:1.18.30
+
+compileClasspath - Compile classpath for source set 'main'.
++--- com.google.guava:guava:32.1.2-jre
+|    \--- com.google.guava:failureaccess:1.0.1
+\--- project :lib
+
+testCompileClasspath - Compile classpath for source set 'test'.
+\--- junit:junit:4.13.2
+
+(*) - Indicates repeated occurrences of a transitive dependency subtree.
+`
+
+func TestFilterTgf(t *testing.T) {
+	filtered := filterTgf([]byte(tgf), scope.Filter{scope.Prod: true})
+
+	assert.Equal(t, `1 com.example:app:jar:1.0-SNAPSHOT
+2 com.google.guava:guava:jar:32.1.2-jre:compile
+3 com.google.guava:failureaccess:jar:1.0.1:compile
+#
+1 2 compile
+2 3 compile
+6 com.example:lib:jar:1.0-SNAPSHOT
+#
+`, string(filtered))
+}
+
+func TestFilterTgfKeepsEveryScope(t *testing.T) {
+	filtered := filterTgf([]byte(tgf), scope.Filter{scope.Prod: true, scope.Test: true})
+
+	assert.Equal(t, tgf, string(filtered))
+}
+
+func TestFilterGradleReport(t *testing.T) {
+	filtered := filterGradleReport([]byte(gradleReport), scope.Filter{scope.Prod: true})
+
+	assert.Equal(t, `
+------------------------------------------------------------
+Root project 'app'
+------------------------------------------------------------
+
+compileClasspath - Compile classpath for source set 'main'.
++--- com.google.guava:guava:32.1.2-jre
+|    \--- com.google.guava:failureaccess:1.0.1
+\--- project :lib
+
+(*) - Indicates repeated occurrences of a transitive dependency subtree.
+`, string(filtered))
+}
+
+func TestFilterGradleReportTestScope(t *testing.T) {
+	filtered := filterGradleReport([]byte(gradleReport), scope.Filter{scope.Test: true})
+
+	assert.NotContains(t, string(filtered), "compileClasspath - ")
+	assert.NotContains(t, string(filtered), "lombok")
+	assert.Contains(t, string(filtered), "testCompileClasspath - ")
+	assert.Contains(t, string(filtered), "junit:junit:4.13.2")
+}
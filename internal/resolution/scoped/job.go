@@ -0,0 +1,205 @@
+package scoped
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	"github.com/debricked/cli/internal/resolution/pm/gradle"
+	"github.com/debricked/cli/internal/resolution/pm/maven"
+	"github.com/debricked/cli/internal/resolution/pm/sbt"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/scope"
+)
+
+// Prefix tells the lock files written by Job apart from those written by package managers, since they lack the
+// dependencies of some scopes and are therefore not to be reused as if they held every dependency
+const Prefix = "scoped."
+
+type spec struct {
+	// lockFile is the name of the lock files written, which is a suffix of those written when resolving statically
+	lockFile string
+	// filter returns the content of a lock file without the dependencies of scopes not kept
+	filter func(content []byte, filter scope.Filter) []byte
+}
+
+// specs holds the package managers writing lock files that record scopes. Native lock files, such as
+// package-lock.json, are filtered when uploaded instead.
+var specs = map[string]spec{
+	maven.Name:  {lockFile: maven.LockFile, filter: filterTgf},
+	sbt.Name:    {lockFile: maven.LockFile, filter: filterTgf},
+	gradle.Name: {lockFile: gradle.LockFile, filter: filterGradleReport},
+}
+
+// Job moves the lock files written by a job to lock files named with Prefix, without the dependencies of scopes not
+// kept by the filter. Lock files named with Prefix that were written earlier are removed if every scope is kept.
+type Job struct {
+	inner     job.IJob
+	spec      spec
+	filter    scope.Filter
+	lockFiles []string
+	mutex     sync.Mutex
+}
+
+func NewJob(inner job.IJob, pm string, filter scope.Filter) *Job {
+	return &Job{
+		inner:  inner,
+		spec:   specs[pm],
+		filter: filter,
+	}
+}
+
+func (j *Job) GetFile() string {
+	return j.inner.GetFile()
+}
+
+func (j *Job) Errors() job.IErrors {
+	return j.inner.Errors()
+}
+
+func (j *Job) ReceiveStatus() chan string {
+	return j.inner.ReceiveStatus()
+}
+
+func (j *Job) SetContext(ctx context.Context) {
+	if cancelable, ok := j.inner.(job.ICancelableJob); ok {
+		cancelable.SetContext(ctx)
+	}
+}
+
+func (j *Job) Commands() []string {
+	if history, ok := j.inner.(job.ICommandHistory); ok {
+		return history.Commands()
+	}
+
+	return nil
+}
+
+func (j *Job) LockFiles() []string {
+	j.mutex.Lock()
+	lockFiles := j.lockFiles
+	j.mutex.Unlock()
+	if lockFiles != nil {
+		return append([]string{}, lockFiles...)
+	}
+	if writer, ok := j.inner.(job.ILockFileWriter); ok {
+		return writer.LockFiles()
+	}
+
+	return nil
+}
+
+// Restored reports whether the inner job restored the lock files from the cache
+func (j *Job) Restored() bool {
+	cached, ok := j.inner.(interface{ Restored() bool })
+
+	return ok && cached.Restored()
+}
+
+func (j *Job) Run() {
+	j.inner.Run()
+	if len(j.Errors().GetCriticalErrors()) > 0 {
+		return
+	}
+	lockFiles := j.innerLockFiles()
+	if len(j.filter) == 0 {
+		j.removeScopedLockFiles(lockFiles)
+
+		return
+	}
+
+	status := "filtering scopes"
+	j.inner.ReceiveStatus() <- status
+	scopedLockFiles := make([]string, 0, len(lockFiles))
+	for _, lockFile := range lockFiles {
+		if !strings.HasSuffix(filepath.Base(lockFile), j.spec.lockFile) {
+			scopedLockFiles = append(scopedLockFiles, lockFile)
+
+			continue
+		}
+		scopedLockFile, err := j.filterLockFile(lockFile)
+		if err != nil {
+			j.warn(err, status, "Failed to filter the scopes of the lock file, so it includes dependencies of every scope.")
+			scopedLockFiles = append(scopedLockFiles, lockFile)
+
+			continue
+		}
+		scopedLockFiles = append(scopedLockFiles, scopedLockFile)
+	}
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.lockFiles = scopedLockFiles
+}
+
+func (j *Job) innerLockFiles() []string {
+	if writer, ok := j.inner.(job.ILockFileWriter); ok {
+		return writer.LockFiles()
+	}
+
+	return nil
+}
+
+// filterLockFile writes the filtered lockFile to the lock file named with Prefix next to it, which it is replaced by
+func (j *Job) filterLockFile(lockFile string) (string, error) {
+	info, err := os.Stat(lockFile)
+	if err != nil {
+		return "", err
+	}
+	content, err := os.ReadFile(lockFile)
+	if err != nil {
+		return "", err
+	}
+	scopedLockFile := ScopedLockFile(lockFile)
+	err = os.WriteFile(scopedLockFile, j.spec.filter(content, j.filter), info.Mode().Perm())
+	if err != nil {
+		return "", err
+	}
+
+	return scopedLockFile, os.Remove(lockFile)
+}
+
+// removeScopedLockFiles removes the lock files named with Prefix written next to lockFiles by earlier resolutions,
+// which would otherwise be found along with them
+func (j *Job) removeScopedLockFiles(lockFiles []string) {
+	for _, lockFile := range lockFiles {
+		err := os.Remove(ScopedLockFile(lockFile))
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			j.warn(err, "removing filtered lock file", "Failed to remove a lock file of some scopes, so it is found along with the lock file of every scope.")
+		}
+	}
+}
+
+func (j *Job) warn(err error, status string, documentation string) {
+	jobErr := util.NewPMJobError(err.Error())
+	jobErr.SetStatus(status)
+	jobErr.SetDocumentation(documentation)
+	jobErr.SetIsCritical(false)
+	j.Errors().Warning(jobErr)
+}
+
+// ScopedLockFile returns the name of the lock file Job writes for lockFile
+func ScopedLockFile(lockFile string) string {
+	return filepath.Join(filepath.Dir(lockFile), Prefix+filepath.Base(lockFile))
+}
+
+// IsScoped reports whether lockFile was written by Job, i.e. lacks the dependencies of some scopes
+func IsScoped(lockFile string) bool {
+	return strings.HasPrefix(filepath.Base(lockFile), Prefix)
+}
+
+// Wrap makes the jobs of pm filter the scopes of their lock files, unless the lock files of pm don't record scopes
+func Wrap(jobs []job.IJob, pm string, filter scope.Filter) []job.IJob {
+	if _, ok := specs[pm]; !ok {
+		return jobs
+	}
+	scopedJobs := make([]job.IJob, 0, len(jobs))
+	for _, j := range jobs {
+		scopedJobs = append(scopedJobs, NewJob(j, pm, filter))
+	}
+
+	return scopedJobs
+}
@@ -0,0 +1,148 @@
+package scoped
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/resolution/job"
+	jobTestdata "github.com/debricked/cli/internal/resolution/job/testdata"
+	"github.com/debricked/cli/internal/resolution/pm/gradle"
+	"github.com/debricked/cli/internal/resolution/pm/maven"
+	"github.com/debricked/cli/internal/resolution/pm/util"
+	"github.com/debricked/cli/internal/scope"
+	"github.com/stretchr/testify/assert"
+)
+
+type lockJob struct {
+	job.BaseJob
+	lockFiles map[string]string
+	fail      bool
+}
+
+func (j *lockJob) Run() {
+	if j.fail {
+		j.Errors().Critical(util.NewPMJobError("mvn failed"))
+
+		return
+	}
+	for lockFile, content := range j.lockFiles {
+		_ = os.MkdirAll(filepath.Dir(lockFile), 0o755)
+		_ = os.WriteFile(lockFile, []byte(content), 0o600)
+		j.AddLockFile(lockFile)
+	}
+}
+
+func (j *lockJob) Restored() bool {
+	return true
+}
+
+func TestRunFiltersMavenLockFile(t *testing.T) {
+	dir := t.TempDir()
+	lockFile := filepath.Join(dir, maven.LockFile)
+	inner := &lockJob{BaseJob: job.NewBaseJob(filepath.Join(dir, "pom.xml")), lockFiles: map[string]string{lockFile: tgf}}
+	j := NewJob(inner, maven.Name, scope.Filter{scope.Test: true})
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.True(t, j.Restored())
+	scopedLockFile := filepath.Join(dir, "scoped.maven.debricked.lock")
+	assert.Equal(t, []string{scopedLockFile}, j.LockFiles())
+	content, err := os.ReadFile(scopedLockFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "junit:junit")
+	assert.NotContains(t, string(content), "guava")
+	assert.NoFileExists(t, lockFile)
+}
+
+func TestRunFiltersGradleSubprojectLockFiles(t *testing.T) {
+	dir := t.TempDir()
+	lockFile := filepath.Join(dir, gradle.LockFile)
+	subProjectLockFile := filepath.Join(dir, "lib", gradle.LockFile)
+	inner := &lockJob{
+		BaseJob:   job.NewBaseJob(filepath.Join(dir, "build.gradle")),
+		lockFiles: map[string]string{lockFile: gradleReport, subProjectLockFile: gradleReport},
+	}
+	j := NewJob(inner, gradle.Name, scope.Filter{scope.Prod: true})
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.ElementsMatch(t, []string{ScopedLockFile(lockFile), ScopedLockFile(subProjectLockFile)}, j.LockFiles())
+	for _, scopedLockFile := range j.LockFiles() {
+		content, err := os.ReadFile(scopedLockFile)
+		assert.NoError(t, err)
+		assert.NotContains(t, string(content), "junit:junit")
+	}
+}
+
+func TestRunSkipsOtherLockFiles(t *testing.T) {
+	dir := t.TempDir()
+	lockFile := filepath.Join(dir, "pom.xml.json")
+	inner := &lockJob{BaseJob: job.NewBaseJob(filepath.Join(dir, "pom.xml")), lockFiles: map[string]string{lockFile: tgf}}
+	j := NewJob(inner, maven.Name, scope.Filter{scope.Prod: true})
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.Equal(t, []string{lockFile}, j.LockFiles())
+	content, err := os.ReadFile(lockFile)
+	assert.NoError(t, err)
+	assert.Equal(t, tgf, string(content))
+}
+
+func TestRunRemovesScopedLockFilesKeepingEveryScope(t *testing.T) {
+	dir := t.TempDir()
+	lockFile := filepath.Join(dir, maven.LockFile)
+	assert.NoError(t, os.WriteFile(ScopedLockFile(lockFile), []byte(tgf), 0o600))
+	inner := &lockJob{BaseJob: job.NewBaseJob(filepath.Join(dir, "pom.xml")), lockFiles: map[string]string{lockFile: tgf}}
+	j := NewJob(inner, maven.Name, scope.Filter{})
+
+	j.Run()
+
+	assert.False(t, j.Errors().HasError())
+	assert.Equal(t, []string{lockFile}, j.LockFiles())
+	assert.FileExists(t, lockFile)
+	assert.NoFileExists(t, ScopedLockFile(lockFile))
+}
+
+func TestRunFailing(t *testing.T) {
+	inner := &lockJob{BaseJob: job.NewBaseJob(filepath.Join(t.TempDir(), "pom.xml")), fail: true}
+	j := NewJob(inner, maven.Name, scope.Filter{scope.Prod: true})
+
+	j.Run()
+
+	assert.Len(t, j.Errors().GetCriticalErrors(), 1)
+}
+
+func TestRunWarnsOnMissingLockFile(t *testing.T) {
+	inner := &lockJob{BaseJob: job.NewBaseJob(filepath.Join(t.TempDir(), "build.gradle"))}
+	lockFile := filepath.Join(t.TempDir(), "missing", gradle.LockFile)
+	inner.AddLockFile(lockFile)
+	j := NewJob(inner, gradle.Name, scope.Filter{scope.Prod: true})
+	go jobTestdata.WaitStatus(j)
+
+	j.Run()
+
+	assert.Empty(t, j.Errors().GetCriticalErrors())
+	assert.True(t, j.Errors().HasError())
+	assert.Equal(t, "filtering scopes", j.Errors().GetAll()[0].Status())
+	assert.Equal(t, []string{lockFile}, j.LockFiles())
+}
+
+func TestIsScoped(t *testing.T) {
+	assert.True(t, IsScoped(filepath.Join("lib", "scoped.gradle.debricked.lock")))
+	assert.True(t, IsScoped(ScopedLockFile(filepath.Join("lib", "static.maven.debricked.lock"))))
+	assert.False(t, IsScoped(filepath.Join("scoped.lib", "gradle.debricked.lock")))
+}
+
+func TestWrap(t *testing.T) {
+	jobs := []job.IJob{jobTestdata.NewJobMock("pom.xml")}
+
+	assert.IsType(t, &Job{}, Wrap(jobs, maven.Name, scope.Filter{scope.Prod: true})[0])
+	assert.IsType(t, &Job{}, Wrap(jobs, maven.Name, scope.Filter{})[0])
+	assert.Equal(t, jobs, Wrap(jobs, "npm", scope.Filter{scope.Prod: true}))
+}
@@ -1,14 +1,19 @@
 package strategy
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/debricked/cli/internal/resolution/file"
 	"github.com/debricked/cli/internal/resolution/pm/bower"
+	"github.com/debricked/cli/internal/resolution/pm/bundler"
+	"github.com/debricked/cli/internal/resolution/pm/cargo"
+	"github.com/debricked/cli/internal/resolution/pm/cocoapods"
 	"github.com/debricked/cli/internal/resolution/pm/composer"
 	"github.com/debricked/cli/internal/resolution/pm/gomod"
 	"github.com/debricked/cli/internal/resolution/pm/gradle"
 	"github.com/debricked/cli/internal/resolution/pm/maven"
+	"github.com/debricked/cli/internal/resolution/pm/mix"
 	"github.com/debricked/cli/internal/resolution/pm/npm"
 	"github.com/debricked/cli/internal/resolution/pm/nuget"
 	"github.com/debricked/cli/internal/resolution/pm/pip"
@@ -16,32 +21,65 @@ import (
 	"github.com/debricked/cli/internal/resolution/pm/poetry"
 	"github.com/debricked/cli/internal/resolution/pm/pub"
 	"github.com/debricked/cli/internal/resolution/pm/sbt"
+	"github.com/debricked/cli/internal/resolution/pm/spm"
 	"github.com/debricked/cli/internal/resolution/pm/uv"
 	"github.com/debricked/cli/internal/resolution/pm/yarn"
+	"github.com/debricked/cli/internal/resolution/registry"
 )
 
 type IFactory interface {
 	Make(pmBatch file.IBatch, paths []string) (IStrategy, error)
+	SetRegistries(registries *registry.Config)
+	SetKeepVirtualEnvs(keep bool)
+	SetTrustProjectCode(trust bool)
+	SetContext(ctx context.Context)
 }
 
-type Factory struct{}
+type Factory struct {
+	registries       *registry.Config
+	keepVirtualEnvs  bool
+	trustProjectCode bool
+	ctx              context.Context
+}
+
+func NewStrategyFactory() *Factory {
+	return &Factory{ctx: context.Background()}
+}
+
+// SetRegistries makes package managers that support registries resolve through registries, unless it is nil
+func (sf *Factory) SetRegistries(registries *registry.Config) {
+	sf.registries = registries
+}
+
+// SetKeepVirtualEnvs makes pip keep the virtual environments it installs dependencies in, if keep is true
+func (sf *Factory) SetKeepVirtualEnvs(keep bool) {
+	sf.keepVirtualEnvs = keep
+}
+
+// SetTrustProjectCode lets package managers that run code of the project to resolve it, such as Bundler and Mix,
+// run on the host if trust is true. Otherwise they only run in containers.
+func (sf *Factory) SetTrustProjectCode(trust bool) {
+	sf.trustProjectCode = trust
+}
 
-func NewStrategyFactory() Factory {
-	return Factory{}
+// SetContext makes strategies stop the commands they run to set up jobs, such as finding Gradle subprojects, once ctx
+// is done
+func (sf *Factory) SetContext(ctx context.Context) {
+	sf.ctx = ctx
 }
 
 //nolint:all
-func (sf Factory) Make(pmFileBatch file.IBatch, paths []string) (IStrategy, error) {
+func (sf *Factory) Make(pmFileBatch file.IBatch, paths []string) (IStrategy, error) {
 	name := pmFileBatch.Pm().Name()
 	switch name {
 	case maven.Name:
-		return maven.NewStrategy(pmFileBatch.Files()), nil
+		return maven.NewStrategy(pmFileBatch.Files(), sf.registries), nil
 	case gradle.Name:
-		return gradle.NewStrategy(pmFileBatch.Files(), paths), nil
+		return gradle.NewStrategy(sf.ctx, pmFileBatch.Files(), paths), nil
 	case gomod.Name:
 		return gomod.NewStrategy(pmFileBatch.Files()), nil
 	case pip.Name:
-		return pip.NewStrategy(pmFileBatch.Files()), nil
+		return pip.NewStrategy(pmFileBatch.Files(), sf.registries, sf.keepVirtualEnvs), nil
 	case poetry.Name:
 		return poetry.NewStrategy(pmFileBatch.Files()), nil
 	case uv.Name:
@@ -51,17 +89,27 @@ func (sf Factory) Make(pmFileBatch file.IBatch, paths []string) (IStrategy, erro
 	case pnpm.Name:
 		return pnpm.NewStrategy(pmFileBatch.Files()), nil
 	case npm.Name:
-		return npm.NewStrategy(pmFileBatch.Files()), nil
+		return npm.NewStrategy(pmFileBatch.Files(), sf.registries), nil
 	case bower.Name:
 		return bower.NewStrategy(pmFileBatch.Files()), nil
 	case nuget.Name:
-		return nuget.NewStrategy(pmFileBatch.Files()), nil
+		return nuget.NewStrategy(pmFileBatch.Files(), sf.registries), nil
 	case composer.Name:
-		return composer.NewStrategy(pmFileBatch.Files()), nil
+		return composer.NewStrategy(pmFileBatch.Files(), sf.registries), nil
 	case sbt.Name:
 		return sbt.NewStrategy(pmFileBatch.Files()), nil
 	case pub.Name:
 		return pub.NewStrategy(pmFileBatch.Files()), nil
+	case cargo.Name:
+		return cargo.NewStrategy(pmFileBatch.Files()), nil
+	case spm.Name:
+		return spm.NewStrategy(pmFileBatch.Files()), nil
+	case cocoapods.Name:
+		return cocoapods.NewStrategy(pmFileBatch.Files()), nil
+	case bundler.Name:
+		return bundler.NewStrategy(pmFileBatch.Files(), sf.trustProjectCode), nil
+	case mix.Name:
+		return mix.NewStrategy(pmFileBatch.Files(), sf.trustProjectCode), nil
 	default:
 		return nil, fmt.Errorf("failed to make strategy from %s", name)
 	}
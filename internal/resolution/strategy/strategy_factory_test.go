@@ -1,20 +1,27 @@
 package strategy
 
 import (
+	"context"
 	"testing"
 
 	"github.com/debricked/cli/internal/resolution/file"
+	"github.com/debricked/cli/internal/resolution/pm/bundler"
+	"github.com/debricked/cli/internal/resolution/pm/cargo"
+	"github.com/debricked/cli/internal/resolution/pm/cocoapods"
 	"github.com/debricked/cli/internal/resolution/pm/composer"
 	"github.com/debricked/cli/internal/resolution/pm/gomod"
 	"github.com/debricked/cli/internal/resolution/pm/gradle"
 	"github.com/debricked/cli/internal/resolution/pm/maven"
+	"github.com/debricked/cli/internal/resolution/pm/mix"
 	"github.com/debricked/cli/internal/resolution/pm/nuget"
 	"github.com/debricked/cli/internal/resolution/pm/pip"
 	"github.com/debricked/cli/internal/resolution/pm/poetry"
 	"github.com/debricked/cli/internal/resolution/pm/pub"
 	"github.com/debricked/cli/internal/resolution/pm/sbt"
+	"github.com/debricked/cli/internal/resolution/pm/spm"
 	"github.com/debricked/cli/internal/resolution/pm/testdata"
 	"github.com/debricked/cli/internal/resolution/pm/yarn"
+	"github.com/debricked/cli/internal/resolution/registry"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,6 +30,41 @@ func TestNewStrategyFactory(t *testing.T) {
 	assert.NotNil(t, f)
 }
 
+func TestSetRegistries(t *testing.T) {
+	f := NewStrategyFactory()
+	registries := &registry.Config{Pip: &registry.Registry{Url: "https://pypi.example.com/simple"}}
+	f.SetRegistries(registries)
+	assert.Same(t, registries, f.registries)
+
+	s, err := f.Make(file.NewBatch(testdata.PmMock{N: pip.Name}), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, pip.NewStrategy(nil, registries, false), s)
+}
+
+func TestSetKeepVirtualEnvs(t *testing.T) {
+	f := NewStrategyFactory()
+	f.SetKeepVirtualEnvs(true)
+	assert.True(t, f.keepVirtualEnvs)
+
+	s, err := f.Make(file.NewBatch(testdata.PmMock{N: pip.Name}), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, pip.NewStrategy(nil, nil, true), s)
+}
+
+func TestSetTrustProjectCode(t *testing.T) {
+	f := NewStrategyFactory()
+	f.SetTrustProjectCode(true)
+	assert.True(t, f.trustProjectCode)
+
+	s, err := f.Make(file.NewBatch(testdata.PmMock{N: bundler.Name}), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, bundler.NewStrategy(nil, true), s)
+
+	s, err = f.Make(file.NewBatch(testdata.PmMock{N: mix.Name}), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, mix.NewStrategy(nil, true), s)
+}
+
 func TestMakeErr(t *testing.T) {
 	f := NewStrategyFactory()
 	batch := file.NewBatch(testdata.PmMock{N: "test"})
@@ -33,16 +75,21 @@ func TestMakeErr(t *testing.T) {
 
 func TestMake(t *testing.T) {
 	cases := map[string]IStrategy{
-		maven.Name:    maven.NewStrategy(nil),
-		gradle.Name:   gradle.NewStrategy(nil, nil),
-		gomod.Name:    gomod.NewStrategy(nil),
-		pip.Name:      pip.NewStrategy(nil),
-		poetry.Name:   poetry.NewStrategy(nil),
-		yarn.Name:     yarn.NewStrategy(nil),
-		nuget.Name:    nuget.NewStrategy(nil),
-		composer.Name: composer.NewStrategy(nil),
-		sbt.Name:      sbt.NewStrategy(nil),
-		pub.Name:      pub.NewStrategy(nil),
+		maven.Name:     maven.NewStrategy(nil, nil),
+		gradle.Name:    gradle.NewStrategy(context.Background(), nil, nil),
+		gomod.Name:     gomod.NewStrategy(nil),
+		pip.Name:       pip.NewStrategy(nil, nil, false),
+		poetry.Name:    poetry.NewStrategy(nil),
+		yarn.Name:      yarn.NewStrategy(nil),
+		nuget.Name:     nuget.NewStrategy(nil, nil),
+		composer.Name:  composer.NewStrategy(nil, nil),
+		sbt.Name:       sbt.NewStrategy(nil),
+		pub.Name:       pub.NewStrategy(nil),
+		cargo.Name:     cargo.NewStrategy(nil),
+		spm.Name:       spm.NewStrategy(nil),
+		cocoapods.Name: cocoapods.NewStrategy(nil),
+		bundler.Name:   bundler.NewStrategy(nil, false),
+		mix.Name:       mix.NewStrategy(nil, false),
 	}
 	f := NewStrategyFactory()
 	var batch file.IBatch
@@ -55,3 +102,15 @@ func TestMake(t *testing.T) {
 		})
 	}
 }
+
+func TestSetContext(t *testing.T) {
+	f := NewStrategyFactory()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	f.SetContext(ctx)
+
+	s, err := f.Make(file.NewBatch(testdata.PmMock{N: gradle.Name}), nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, gradle.NewStrategy(ctx, nil, nil), s)
+}
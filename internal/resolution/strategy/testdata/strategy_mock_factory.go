@@ -1,7 +1,10 @@
 package testdata
 
 import (
+	"context"
+
 	"github.com/debricked/cli/internal/resolution/file"
+	"github.com/debricked/cli/internal/resolution/registry"
 	"github.com/debricked/cli/internal/resolution/strategy"
 )
 
@@ -18,6 +21,14 @@ func (sf FactoryMock) Make(pmFileBatch file.IBatch, paths []string) (strategy.IS
 
 type FactoryErrorMock struct{}
 
+func (sf FactoryMock) SetRegistries(_ *registry.Config) {}
+
+func (sf FactoryMock) SetKeepVirtualEnvs(_ bool) {}
+
+func (sf FactoryMock) SetTrustProjectCode(_ bool) {}
+
+func (sf FactoryMock) SetContext(_ context.Context) {}
+
 func NewStrategyFactoryErrorMock() FactoryErrorMock {
 	return FactoryErrorMock{}
 }
@@ -26,3 +37,11 @@ func (sf FactoryErrorMock) Make(pmFileBatch file.IBatch, paths []string) (strate
 
 	return NewStrategyErrorMock(pmFileBatch.Files()), nil
 }
+
+func (sf FactoryErrorMock) SetRegistries(_ *registry.Config) {}
+
+func (sf FactoryErrorMock) SetKeepVirtualEnvs(_ bool) {}
+
+func (sf FactoryErrorMock) SetTrustProjectCode(_ bool) {}
+
+func (sf FactoryErrorMock) SetContext(_ context.Context) {}
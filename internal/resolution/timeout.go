@@ -0,0 +1,44 @@
+package resolution
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Timeouts limit how long jobs run, by package manager name. A zero timeout means that jobs run until done.
+type Timeouts struct {
+	// Default applies to package managers without a timeout of their own
+	Default time.Duration
+	Pms     map[string]time.Duration
+}
+
+// For returns the timeout of jobs of pm
+func (timeouts Timeouts) For(pm string) time.Duration {
+	if timeout, ok := timeouts.Pms[pm]; ok {
+		return timeout
+	}
+
+	return timeouts.Default
+}
+
+// ParseTimeouts parses the default timeout and package manager timeouts on the form <package manager>=<seconds>,
+// such as gradle=600, all in seconds
+func ParseTimeouts(seconds int, pmTimeouts []string) (Timeouts, error) {
+	if seconds < 0 {
+		return Timeouts{}, fmt.Errorf("invalid resolution timeout: %d, expected seconds", seconds)
+	}
+	timeouts := Timeouts{Default: time.Duration(seconds) * time.Second, Pms: map[string]time.Duration{}}
+	for _, value := range pmTimeouts {
+		pm, timeout, found := strings.Cut(value, "=")
+		pm = strings.TrimSpace(pm)
+		pmSeconds, err := strconv.Atoi(strings.TrimSpace(timeout))
+		if !found || len(pm) == 0 || err != nil || pmSeconds < 0 {
+			return Timeouts{}, fmt.Errorf("invalid resolution timeout: %s, expected <package manager>=<seconds>", value)
+		}
+		timeouts.Pms[pm] = time.Duration(pmSeconds) * time.Second
+	}
+
+	return timeouts, nil
+}
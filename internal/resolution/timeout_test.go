@@ -0,0 +1,27 @@
+package resolution
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeouts(t *testing.T) {
+	timeouts, err := ParseTimeouts(60, []string{"gradle=600", " sbt = 0 "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, timeouts.For("mvn"))
+	assert.Equal(t, 10*time.Minute, timeouts.For("gradle"))
+	assert.Equal(t, time.Duration(0), timeouts.For("sbt"))
+}
+
+func TestParseTimeoutsErr(t *testing.T) {
+	_, err := ParseTimeouts(-1, nil)
+	assert.ErrorContains(t, err, "invalid resolution timeout: -1")
+
+	for _, value := range []string{"gradle", "=600", "gradle=ten", "gradle=-1"} {
+		_, err = ParseTimeouts(0, []string{value})
+		assert.ErrorContains(t, err, "invalid resolution timeout: "+value)
+	}
+}
@@ -0,0 +1,244 @@
+package scan
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/git"
+	"github.com/debricked/cli/internal/inventory"
+	"github.com/debricked/cli/internal/upload"
+	"github.com/fatih/color"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// DependencyDiff holds the dependency files and dependency versions that changed between a base revision and HEAD
+type DependencyDiff struct {
+	Base         string
+	ChangedFiles []string
+	// UncomparedFiles are changed dependency files without a lock file that can be compared offline
+	UncomparedFiles []string
+	Delta           inventory.Delta
+}
+
+// Complete reports whether the delta covers every changed dependency file. Automation rules and local policies are
+// only narrowed down to introduced dependencies if it does.
+func (diff *DependencyDiff) Complete() bool {
+	return len(diff.UncomparedFiles) == 0
+}
+
+// scanDiff compares the dependency files committed at options.DiffBase with those committed at HEAD, within the scan
+// path. Versions are only compared for lock files that are committed and can be parsed offline.
+func (dScanner *DebrickedScanner) scanDiff(options DebrickedOptions) (*DependencyDiff, error) {
+	repository, err := git.FindEnclosingRepository(options.Path)
+	if err != nil {
+		return nil, err
+	}
+	scanRoot, err := git.FindWorktreePath(repository, options.Path)
+	if err != nil {
+		return nil, err
+	}
+	base, err := git.FindRevisionCommit(repository, options.DiffBase)
+	if err != nil {
+		return nil, err
+	}
+	head, err := git.FindCommit(repository)
+	if err != nil {
+		return nil, err
+	}
+	paths, err := git.FindChangedFiles(base, head)
+	if err != nil {
+		return nil, err
+	}
+	formats, err := dScanner.finder.GetSupportedFormats()
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &DependencyDiff{Base: options.DiffBase, ChangedFiles: []string{}, UncomparedFiles: []string{}}
+	var baseDependencies, headDependencies []inventory.Dependency
+	for _, changedPath := range paths {
+		relativePath, inScanPath := scanRelativePath(scanRoot, changedPath)
+		if !inScanPath || file.Excluded(options.Exclusions, options.Inclusions, relativePath) || !isDependencyFile(formats, changedPath) {
+			continue
+		}
+		diff.ChangedFiles = append(diff.ChangedFiles, relativePath)
+		compared, baseFileDependencies, headFileDependencies, err := compareFile(formats, base, head, changedPath, relativePath)
+		if err != nil {
+			return nil, err
+		}
+		if !compared {
+			diff.UncomparedFiles = append(diff.UncomparedFiles, relativePath)
+		}
+		baseDependencies = append(baseDependencies, baseFileDependencies...)
+		headDependencies = append(headDependencies, headFileDependencies...)
+	}
+	diff.Delta = inventory.NewDelta(baseDependencies, headDependencies)
+
+	return diff, nil
+}
+
+// scanRelativePath returns worktreePath relative to scanRoot, and false if it is outside of scanRoot
+func scanRelativePath(scanRoot string, worktreePath string) (string, bool) {
+	if scanRoot == "." {
+		return worktreePath, true
+	}
+
+	return strings.CutPrefix(worktreePath, scanRoot+"/")
+}
+
+func isDependencyFile(formats []*file.CompiledFormat, filePath string) bool {
+	if inventory.IsLockFile(filePath) {
+		return true
+	}
+	name := path.Base(filePath)
+	for _, format := range formats {
+		if format.MatchFile(name) || format.MatchLockFile(name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compareFile parses the changed dependency file changedPath at base and head, with sourceFile as source file of the
+// dependencies. False is returned if the versions of the file cannot be compared.
+func compareFile(
+	formats []*file.CompiledFormat,
+	base *object.Commit,
+	head *object.Commit,
+	changedPath string,
+	sourceFile string,
+) (bool, []inventory.Dependency, []inventory.Dependency, error) {
+	if !inventory.IsLockFile(changedPath) {
+		comparable, err := hasComparableLockFile(formats, changedPath, base, head)
+
+		return comparable, nil, nil, err
+	}
+	baseDependencies, err := parseCommittedLockFile(base, changedPath, sourceFile)
+	if err != nil {
+		return false, nil, nil, err
+	}
+	headDependencies, err := parseCommittedLockFile(head, changedPath, sourceFile)
+	if err != nil {
+		return false, nil, nil, err
+	}
+
+	return true, baseDependencies, headDependencies, nil
+}
+
+// hasComparableLockFile reports whether manifestFile is a manifest file with a lock file next to it, at base or head,
+// that can be parsed offline. Changes to manifest files without one, such as pom.xml, cannot be compared.
+func hasComparableLockFile(formats []*file.CompiledFormat, manifestFile string, commits ...*object.Commit) (bool, error) {
+	name := path.Base(manifestFile)
+	for _, commit := range commits {
+		siblings, err := git.ListFiles(commit, path.Dir(manifestFile))
+		if err != nil {
+			return false, err
+		}
+		for _, format := range formats {
+			if !format.MatchFile(name) {
+				continue
+			}
+			for _, sibling := range siblings {
+				if format.MatchLockFile(path.Base(sibling)) && inventory.IsLockFile(sibling) {
+					return true, nil
+				}
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// parseCommittedLockFile parses the lock file at lockFile in commit, returning no dependencies if it does not exist.
+// The source file of the dependencies is set to sourceFile.
+func parseCommittedLockFile(commit *object.Commit, lockFile string, sourceFile string) ([]inventory.Dependency, error) {
+	content, found, err := git.ReadFile(commit, lockFile)
+	if err != nil || !found {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "debricked-diff-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	// Parsers recognise lock files by name, so the original file name is kept
+	tmpFile := filepath.Join(dir, path.Base(lockFile))
+	if err = os.WriteFile(tmpFile, content, 0600); err != nil {
+		return nil, err
+	}
+	dependencies, err := inventory.ParseLockFile(tmpFile, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s at %s: %w", lockFile, commit.Hash.String()[:7], err)
+	}
+	for i := range dependencies {
+		dependencies[i].SourceFile = sourceFile
+	}
+
+	return dependencies, nil
+}
+
+// Print writes the changed dependency files and the dependency delta to stdout
+func (diff *DependencyDiff) Print() {
+	fmt.Printf("\nDependency changes compared to %s\n", color.BlueString(diff.Base))
+	if len(diff.ChangedFiles) == 0 {
+		fmt.Printf("No dependency files changed\n\n")
+
+		return
+	}
+	fmt.Println("Changed dependency files:")
+	for _, changedFile := range diff.ChangedFiles {
+		fmt.Printf("  %s\n", changedFile)
+	}
+	if !diff.Complete() {
+		fmt.Printf(
+			"%s No lock file to compare versions of %s with, so all automation rules and policies apply\n",
+			color.YellowString("⚠️"),
+			strings.Join(diff.UncomparedFiles, ", "),
+		)
+	}
+	for _, dependency := range diff.Delta.Added {
+		fmt.Printf("  %s %s %s (%s)\n", color.GreenString("+"), dependency.Name, dependency.Version, dependency.Ecosystem)
+	}
+	for _, change := range diff.Delta.Upgraded {
+		fmt.Printf("  %s %s %s -> %s (%s)\n", color.YellowString("~"), change.Name, change.FromVersion, change.ToVersion, change.Ecosystem)
+	}
+	for _, dependency := range diff.Delta.Removed {
+		fmt.Printf("  %s %s %s (%s)\n", color.RedString("-"), dependency.Name, dependency.Version, dependency.Ecosystem)
+	}
+	fmt.Printf(
+		"%d added, %d upgraded and %d removed dependencies\n\n",
+		len(diff.Delta.Added),
+		len(diff.Delta.Upgraded),
+		len(diff.Delta.Removed),
+	)
+}
+
+// FilterIntroducedRules returns a copy of result where trigger events only concern dependencies added or upgraded
+// in delta, or dependencies of unknown ecosystems. Rules that lose all their trigger events are no longer triggered.
+func FilterIntroducedRules(result *upload.UploadResult, delta inventory.Delta) *upload.UploadResult {
+	introduced := delta.Introduced()
+	filtered := *result
+	filtered.AutomationRules = make([]automation.Rule, 0, len(result.AutomationRules))
+	for _, rule := range result.AutomationRules {
+		if len(rule.TriggerEvents) > 0 {
+			events := []automation.TriggerEvent{}
+			for _, event := range rule.TriggerEvents {
+				name, ecosystem := inventory.SplitEventDependency(event.Dependency)
+				if ecosystem == "" || introduced[inventory.DependencyKey(ecosystem, name)] {
+					events = append(events, event)
+				}
+			}
+			rule.TriggerEvents = events
+			rule.Triggered = rule.Triggered && len(events) > 0
+		}
+		filtered.AutomationRules = append(filtered.AutomationRules, rule)
+	}
+
+	return &filtered
+}
@@ -0,0 +1,295 @@
+package scan
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/file"
+	fileTestdata "github.com/debricked/cli/internal/file/testdata"
+	"github.com/debricked/cli/internal/inventory"
+	"github.com/debricked/cli/internal/upload"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+const cargoLockBase = `version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = ["itoa", "libc", "ryu"]
+
+[[package]]
+name = "itoa"
+version = "1.0.9"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "libc"
+version = "0.2.148"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "ryu"
+version = "1.0.15"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`
+
+const cargoLockHead = `version = 3
+
+[[package]]
+name = "app"
+version = "0.1.0"
+dependencies = ["itoa", "ryu", "serde"]
+
+[[package]]
+name = "itoa"
+version = "1.0.9"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "ryu"
+version = "1.0.16"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+
+[[package]]
+name = "serde"
+version = "1.0.188"
+source = "registry+https://github.com/rust-lang/crates.io-index"
+`
+
+func commitDiffFiles(t *testing.T, worktree *git.Worktree, dir string, files map[string]string) {
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0600))
+		_, err := worktree.Add(name)
+		assert.NoError(t, err)
+	}
+	_, err := worktree.Commit("commit", &git.CommitOptions{Author: &object.Signature{Name: "author"}})
+	assert.NoError(t, err)
+}
+
+func makeDiffRepository(t *testing.T) string {
+	dir := t.TempDir()
+	repository, err := git.PlainInit(dir, false)
+	assert.NoError(t, err)
+	worktree, err := repository.Worktree()
+	assert.NoError(t, err)
+	commitDiffFiles(t, worktree, dir, map[string]string{
+		"Cargo.toml": "[package]",
+		"Cargo.lock": cargoLockBase,
+		"README.md":  "readme",
+	})
+	head, err := repository.Head()
+	assert.NoError(t, err)
+	_, err = repository.CreateTag("base", head.Hash(), nil)
+	assert.NoError(t, err)
+	commitDiffFiles(t, worktree, dir, map[string]string{
+		"Cargo.toml":              "[package]\nversion = \"0.2.0\"",
+		"Cargo.lock":              cargoLockHead,
+		"README.md":               "updated readme",
+		"vendor/other/Cargo.lock": cargoLockHead,
+	})
+
+	return dir
+}
+
+func makeDiffScanner(t *testing.T) *DebrickedScanner {
+	var formats []*file.CompiledFormat
+	for _, f := range []file.Format{
+		{ManifestFileRegex: `^Cargo\.toml$`, LockFileRegexes: []string{`^Cargo\.lock$`}},
+		{ManifestFileRegex: `^pom\.xml$`, LockFileRegexes: []string{`^maven\.debricked\.lock$`}},
+	} {
+		format, err := file.NewCompiledFormat(&f)
+		assert.NoError(t, err)
+		formats = append(formats, format)
+	}
+	finder := fileTestdata.NewFinderMock()
+	finder.SetGetSupportedFormatsReturnMock(formats, nil)
+
+	return NewDebrickedScanner(nil, finder, nil, nil, nil, nil, nil)
+}
+
+func TestScanDiff(t *testing.T) {
+	scanner := makeDiffScanner(t)
+
+	diff, err := scanner.scanDiff(DebrickedOptions{
+		Path:       makeDiffRepository(t),
+		DiffBase:   "base",
+		Exclusions: []string{"**/vendor/**"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "base", diff.Base)
+	assert.Equal(t, []string{"Cargo.lock", "Cargo.toml"}, diff.ChangedFiles)
+	assert.Len(t, diff.Delta.Added, 1)
+	assert.Equal(t, "serde", diff.Delta.Added[0].Name)
+	assert.True(t, diff.Delta.Added[0].Direct)
+	assert.Equal(t, "Cargo.lock", diff.Delta.Added[0].SourceFile)
+	assert.Len(t, diff.Delta.Removed, 1)
+	assert.Equal(t, "libc", diff.Delta.Removed[0].Name)
+	assert.Equal(t, []inventory.VersionChange{
+		{Name: "ryu", Ecosystem: inventory.EcosystemCargo, FromVersion: "1.0.15", ToVersion: "1.0.16", Direct: true},
+	}, diff.Delta.Upgraded)
+	assert.True(t, diff.Complete())
+}
+
+func TestScanDiffScanPath(t *testing.T) {
+	dir := t.TempDir()
+	repository, err := git.PlainInit(dir, false)
+	assert.NoError(t, err)
+	worktree, err := repository.Worktree()
+	assert.NoError(t, err)
+	commitDiffFiles(t, worktree, dir, map[string]string{"services/api/Cargo.lock": cargoLockBase})
+	head, err := repository.Head()
+	assert.NoError(t, err)
+	_, err = repository.CreateTag("base", head.Hash(), nil)
+	assert.NoError(t, err)
+	commitDiffFiles(t, worktree, dir, map[string]string{
+		"Cargo.lock":              cargoLockHead,
+		"services/api/Cargo.lock": cargoLockHead,
+		"services/api/pom.xml":    "<project/>",
+	})
+	scanner := makeDiffScanner(t)
+
+	diff, err := scanner.scanDiff(DebrickedOptions{Path: filepath.Join(dir, "services", "api"), DiffBase: "base"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Cargo.lock", "pom.xml"}, diff.ChangedFiles)
+	assert.Equal(t, []string{"pom.xml"}, diff.UncomparedFiles)
+	assert.False(t, diff.Complete())
+	assert.Len(t, diff.Delta.Added, 1)
+	assert.Equal(t, "Cargo.lock", diff.Delta.Added[0].SourceFile)
+}
+
+func TestScanDiffUnknownBase(t *testing.T) {
+	scanner := makeDiffScanner(t)
+
+	diff, err := scanner.scanDiff(DebrickedOptions{Path: makeDiffRepository(t), DiffBase: "does-not-exist"})
+
+	assert.Nil(t, diff)
+	assert.ErrorContains(t, err, "failed to resolve does-not-exist")
+}
+
+func TestScanDiffNoRepository(t *testing.T) {
+	scanner := makeDiffScanner(t)
+
+	diff, err := scanner.scanDiff(DebrickedOptions{Path: t.TempDir(), DiffBase: "main"})
+
+	assert.Nil(t, diff)
+	assert.ErrorIs(t, err, git.ErrRepositoryNotExists)
+}
+
+func TestDependencyDiffPrint(t *testing.T) {
+	diff := DependencyDiff{
+		Base:         "origin/main",
+		ChangedFiles: []string{"Cargo.lock"},
+		Delta: inventory.Delta{
+			Added:    []inventory.Dependency{{Name: "serde", Version: "1.0.188", Ecosystem: inventory.EcosystemCargo}},
+			Removed:  []inventory.Dependency{{Name: "libc", Version: "0.2.148", Ecosystem: inventory.EcosystemCargo}},
+			Upgraded: []inventory.VersionChange{{Name: "ryu", Ecosystem: inventory.EcosystemCargo, FromVersion: "1.0.15", ToVersion: "1.0.16"}},
+		},
+	}
+
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	diff.Print()
+
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	assert.Contains(t, string(output), "Dependency changes compared to origin/main")
+	assert.Contains(t, string(output), "serde 1.0.188 (cargo)")
+	assert.Contains(t, string(output), "ryu 1.0.15 -> 1.0.16 (cargo)")
+	assert.Contains(t, string(output), "libc 0.2.148 (cargo)")
+	assert.Contains(t, string(output), "1 added, 1 upgraded and 1 removed dependencies")
+	assert.NotContains(t, string(output), "No lock file to compare")
+}
+
+func TestDependencyDiffPrintIncomplete(t *testing.T) {
+	diff := DependencyDiff{Base: "origin/main", ChangedFiles: []string{"pom.xml"}, UncomparedFiles: []string{"pom.xml"}}
+
+	output, err := captureStdout(t, func() error {
+		diff.Print()
+
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, "No lock file to compare versions of pom.xml with")
+}
+
+func TestFilterIntroducedRules(t *testing.T) {
+	result := &upload.UploadResult{
+		VulnerabilitiesFound: 3,
+		AutomationRules: []automation.Rule{
+			{
+				RuleDescription: "Fail on critical vulnerabilities",
+				Triggered:       true,
+				TriggerEvents: []automation.TriggerEvent{
+					{Dependency: "serde (Cargo)", Cve: "CVE-2023-0001"},
+					{Dependency: "itoa (Cargo)", Cve: "CVE-2023-0002"},
+				},
+			},
+			{
+				RuleDescription: "Notify on GPL licenses",
+				Triggered:       true,
+				TriggerEvents:   []automation.TriggerEvent{{Dependency: "itoa (Cargo)", Licenses: []string{"GPL-3.0"}}},
+			},
+			{RuleDescription: "Rule without events", Triggered: true},
+		},
+	}
+	delta := inventory.Delta{
+		Added:    []inventory.Dependency{{Name: "Serde", Version: "1.0.188", Ecosystem: inventory.EcosystemCargo}},
+		Upgraded: []inventory.VersionChange{{Name: "ryu", Ecosystem: inventory.EcosystemCargo}},
+	}
+
+	filtered := FilterIntroducedRules(result, delta)
+
+	assert.Len(t, filtered.AutomationRules, 3)
+	assert.True(t, filtered.AutomationRules[0].Triggered)
+	assert.Equal(t, []automation.TriggerEvent{{Dependency: "serde (Cargo)", Cve: "CVE-2023-0001"}}, filtered.AutomationRules[0].TriggerEvents)
+	assert.False(t, filtered.AutomationRules[1].Triggered)
+	assert.Empty(t, filtered.AutomationRules[1].TriggerEvents)
+	assert.True(t, filtered.AutomationRules[2].Triggered)
+	// The original result is left untouched, since it is written as is to the JSON file
+	assert.Len(t, result.AutomationRules[0].TriggerEvents, 2)
+	assert.True(t, result.AutomationRules[1].Triggered)
+}
+
+func TestFilterIntroducedRulesEcosystems(t *testing.T) {
+	result := &upload.UploadResult{
+		AutomationRules: []automation.Rule{
+			{
+				RuleDescription: "Fail on critical vulnerabilities",
+				Triggered:       true,
+				TriggerEvents: []automation.TriggerEvent{
+					{Dependency: "foo (PyPI)", Cve: "CVE-2023-0001"},
+					{Dependency: "foo-bar (PyPI)", Cve: "CVE-2023-0002"},
+					{Dependency: "baz", Cve: "CVE-2023-0003"},
+				},
+			},
+		},
+	}
+	delta := inventory.Delta{
+		Added: []inventory.Dependency{
+			{Name: "foo", Version: "1.0.0", Ecosystem: inventory.EcosystemNpm},
+			{Name: "Foo_Bar", Version: "1.0.0", Ecosystem: inventory.EcosystemPypi},
+		},
+	}
+
+	filtered := FilterIntroducedRules(result, delta)
+
+	assert.Equal(t, []automation.TriggerEvent{
+		{Dependency: "foo-bar (PyPI)", Cve: "CVE-2023-0002"},
+		{Dependency: "baz", Cve: "CVE-2023-0003"},
+	}, filtered.AutomationRules[0].TriggerEvents)
+}
@@ -0,0 +1,76 @@
+package scan
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/inventory"
+	"github.com/debricked/cli/internal/policy"
+	"github.com/debricked/cli/internal/scope"
+	"github.com/debricked/cli/internal/upload"
+	"github.com/fatih/color"
+)
+
+// policyCheck evaluates the policies of debricked-config.yaml locally, in addition to the evaluation done by Debricked
+type policyCheck struct {
+	engine    *policy.Engine
+	inventory *inventory.Inventory
+}
+
+// localInventory parses the lock files of fileGroups offline and drops the dependencies outside scopes. Lock files
+// that cannot be parsed are warned about, and nil is returned, with a warning, if the inventory cannot be generated.
+func localInventory(fileGroups file.Groups, scopes scope.Filter) *inventory.Inventory {
+	inv, err := inventory.NewGenerator().Generate(fileGroups)
+	if err != nil {
+		fmt.Printf("%s Dependencies are only known from the scan result: %s\n", color.YellowString("⚠️"), err.Error())
+
+		return nil
+	}
+	for _, unparsedFile := range inv.UnparsedFiles {
+		if inventory.IsLockFile(unparsedFile) {
+			fmt.Printf("%s Dependencies of %s are only known from the scan result\n", color.YellowString("⚠️"), unparsedFile)
+		}
+	}
+	inv.Filter(scopes)
+
+	return inv
+}
+
+// newPolicyCheck returns nil if config has no policies. If inv is nil, dependencies are only checked through the
+// trigger events returned by Debricked.
+func newPolicyCheck(config *upload.DebrickedConfig, inv *inventory.Inventory) (*policyCheck, error) {
+	if !hasPolicies(config) {
+		return nil, nil
+	}
+	engine, err := policy.NewEngine(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &policyCheck{engine: engine, inventory: inv}, nil
+}
+
+func hasPolicies(config *upload.DebrickedConfig) bool {
+	return config != nil && config.Policies != nil
+}
+
+// evaluate renders the verdict for result and the inventory. If diff is set and complete, only introduced
+// dependencies of the inventory are checked.
+func (check *policyCheck) evaluate(result *upload.UploadResult, diff *DependencyDiff) policy.Verdict {
+	inv := check.inventory
+	if diff != nil && diff.Complete() && inv != nil {
+		introduced := diff.Delta.Introduced()
+		filtered := &inventory.Inventory{}
+		for _, dependency := range inv.Dependencies {
+			if introduced[inventory.DependencyKey(dependency.Ecosystem, dependency.Name)] {
+				filtered.Dependencies = append(filtered.Dependencies, dependency)
+			}
+		}
+		inv = filtered
+	}
+	verdict := check.engine.Evaluate(result, inv)
+	verdict.Render(os.Stdout)
+
+	return verdict
+}
@@ -0,0 +1,187 @@
+package scan
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/automation"
+	"github.com/debricked/cli/internal/client/testdata"
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/inventory"
+	"github.com/debricked/cli/internal/policy"
+	"github.com/debricked/cli/internal/upload"
+	"github.com/stretchr/testify/assert"
+)
+
+func makePolicyDirectory(t *testing.T, config string) string {
+	dir := t.TempDir()
+	for _, name := range []string{"package.json", "package-lock.json"} {
+		content, err := os.ReadFile(filepath.Join("testdata", "offline", name))
+		assert.NoError(t, err)
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), content, 0600))
+	}
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "debricked-config.yaml"), []byte(config), 0600))
+
+	return dir
+}
+
+func scanOfflineWithPolicies(t *testing.T, config string) (string, error) {
+	clientMock := testdata.NewDebClientMock()
+	clientMock.SetServiceUp(false)
+	scanner := makeScanner(clientMock, nil, nil)
+	cwd, _ := os.Getwd()
+	// reset working directory that has been manipulated in scanner.Scan
+	defer resetWd(t, cwd)
+	dir := makePolicyDirectory(t, config)
+
+	return captureStdout(t, func() error {
+		return scanner.Scan(DebrickedOptions{
+			Path:            dir,
+			Offline:         true,
+			InventoryOutput: filepath.Join(t.TempDir(), "inventory.json"),
+		})
+	})
+}
+
+func TestScanOfflineLocalPoliciesFailed(t *testing.T) {
+	output, err := scanOfflineWithPolicies(t, "policies:\n  deny:\n    packages:\n      - \"lodash@<=4.17.21\"\n")
+
+	assert.ErrorIs(t, err, FailPipelineErr)
+	assert.Contains(t, output, "lodash 4.17.21 (npm)")
+	assert.Contains(t, output, "denied by lodash@<=4.17.21")
+	assert.Contains(t, output, "Local policy check failed")
+}
+
+func TestScanOfflineLocalPoliciesPassed(t *testing.T) {
+	output, err := scanOfflineWithPolicies(t, "policies:\n  deny:\n    packages:\n      - \"lodash@<4.17.21\"\n")
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Local policy check passed")
+}
+
+func TestScanOfflineInvalidLocalPolicies(t *testing.T) {
+	_, err := scanOfflineWithPolicies(t, "policies:\n  deny:\n    packages:\n      - \"lodash@\"\n")
+
+	assert.ErrorContains(t, err, "invalid policy package \"lodash@\"")
+}
+
+func TestNewPolicyCheckWithoutPolicies(t *testing.T) {
+	check, err := newPolicyCheck(&upload.DebrickedConfig{Ignore: &upload.IgnoreConfig{}}, &inventory.Inventory{})
+	assert.NoError(t, err)
+	assert.Nil(t, check)
+
+	check, err = newPolicyCheck(nil, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, check)
+}
+
+func TestLocalInventory(t *testing.T) {
+	var groups file.Groups
+	groups.Add(*file.NewGroup(
+		filepath.Join("testdata", "offline", "package.json"),
+		nil,
+		[]string{filepath.Join("testdata", "offline", "package-lock.json")},
+	))
+
+	inv := localInventory(groups, nil)
+
+	assert.NotNil(t, inv)
+	assert.NotEmpty(t, inv.Dependencies)
+
+	groups = file.Groups{}
+	groups.Add(*file.NewGroup("", nil, []string{filepath.Join("testdata", "missing", "package-lock.json")}))
+	output, _ := captureStdout(t, func() error {
+		inv = localInventory(groups, nil)
+
+		return nil
+	})
+	assert.NotNil(t, inv)
+	assert.Empty(t, inv.Dependencies)
+	assert.Contains(t, output, "Dependencies of "+filepath.Join("testdata", "missing", "package-lock.json")+" are only known from the scan result")
+}
+
+func TestPolicyCheckEvaluateDiff(t *testing.T) {
+	engine, err := policy.NewEngine(&upload.DebrickedConfig{
+		Policies: &upload.PoliciesConfig{
+			Deny:    &upload.PolicyPackages{Packages: []string{"pkg:cargo/serde", "pkg:cargo/libc"}},
+			MaxCvss: 5,
+		},
+	})
+	assert.NoError(t, err)
+	check := policyCheck{
+		engine: engine,
+		inventory: &inventory.Inventory{Dependencies: []inventory.Dependency{
+			{Name: "libc", Version: "0.2.148", Ecosystem: inventory.EcosystemCargo},
+			{Name: "serde", Version: "1.0.188", Ecosystem: inventory.EcosystemCargo},
+		}},
+	}
+	diff := &DependencyDiff{Delta: inventory.Delta{
+		Added: []inventory.Dependency{{Name: "serde", Version: "1.0.188", Ecosystem: inventory.EcosystemCargo}},
+	}}
+	result := &upload.UploadResult{AutomationRules: []automation.Rule{
+		{TriggerEvents: []automation.TriggerEvent{{Dependency: "serde (Cargo)", Cve: "CVE-2023-0001", Cvss3: 9.1}}},
+	}}
+
+	var verdict policy.Verdict
+	output, _ := captureStdout(t, func() error {
+		verdict = check.evaluate(result, diff)
+
+		return nil
+	})
+
+	assert.Equal(t, []policy.Violation{
+		{Policy: policy.PolicyDeny, Dependency: "serde", Version: "1.0.188", Ecosystem: "cargo", Reason: "denied by pkg:cargo/serde"},
+		{Policy: policy.PolicyMaxCvss, Dependency: "serde", Version: "1.0.188", Ecosystem: "cargo", Reason: "CVE-2023-0001 has CVSS 9.1, above the maximum of 5.0"},
+	}, verdict.Violations)
+	assert.Contains(t, output, "Local policy check failed")
+
+	// Dependencies of an incomplete diff may have been introduced without showing up in the delta
+	diff.UncomparedFiles = []string{"pom.xml"}
+	_, _ = captureStdout(t, func() error {
+		verdict = check.evaluate(result, diff)
+
+		return nil
+	})
+	assert.Len(t, verdict.Violations, 3)
+}
+
+func TestPolicyCheckEvaluateDiffEcosystems(t *testing.T) {
+	engine, err := policy.NewEngine(&upload.DebrickedConfig{
+		Policies: &upload.PoliciesConfig{
+			Deny: &upload.PolicyPackages{Packages: []string{"pkg:npm/foo", "pkg:pypi/foo-bar"}},
+		},
+	})
+	assert.NoError(t, err)
+	check := policyCheck{
+		engine: engine,
+		inventory: &inventory.Inventory{Dependencies: []inventory.Dependency{
+			{Name: "foo", Version: "1.0.0", Ecosystem: inventory.EcosystemNpm},
+			{Name: "Foo_Bar", Version: "1.0.0", Ecosystem: inventory.EcosystemPypi},
+		}},
+	}
+	diff := &DependencyDiff{Delta: inventory.Delta{
+		Added: []inventory.Dependency{
+			{Name: "foo", Version: "1.0.0", Ecosystem: inventory.EcosystemPypi},
+			{Name: "foo-bar", Version: "1.0.0", Ecosystem: inventory.EcosystemPypi},
+		},
+	}}
+
+	var verdict policy.Verdict
+	_, _ = captureStdout(t, func() error {
+		verdict = check.evaluate(nil, diff)
+
+		return nil
+	})
+
+	assert.Equal(t, []policy.Violation{
+		{Policy: policy.PolicyDeny, Dependency: "Foo_Bar", Version: "1.0.0", Ecosystem: "pypi", Reason: "denied by pkg:pypi/foo-bar"},
+	}, verdict.Violations)
+}
+
+func TestScanOfflineReportsConfigIssues(t *testing.T) {
+	output, err := scanOfflineWithPolicies(t, "policies:\n  deny:\n    package:\n      - \"lodash\"\n")
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, "debricked-config.yaml:3:5: error: unknown key \"package\" in deny, did you mean \"packages\"?")
+}
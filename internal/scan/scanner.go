@@ -13,13 +13,20 @@ import (
 	"github.com/debricked/cli/internal/ci"
 	"github.com/debricked/cli/internal/ci/env"
 	"github.com/debricked/cli/internal/client"
+	"github.com/debricked/cli/internal/comment"
+	schema "github.com/debricked/cli/internal/config"
 	"github.com/debricked/cli/internal/debug"
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/fingerprint"
+	"github.com/debricked/cli/internal/format"
 	"github.com/debricked/cli/internal/git"
+	"github.com/debricked/cli/internal/inventory"
 	"github.com/debricked/cli/internal/io"
+	"github.com/debricked/cli/internal/policy"
 	"github.com/debricked/cli/internal/report/sbom"
 	"github.com/debricked/cli/internal/resolution"
+	"github.com/debricked/cli/internal/resolution/registry"
+	"github.com/debricked/cli/internal/scope"
 	"github.com/debricked/cli/internal/tui"
 	"github.com/debricked/cli/internal/upload"
 	"github.com/fatih/color"
@@ -58,6 +65,9 @@ type DebrickedOptions struct {
 	Verbose                     bool
 	Debug                       bool
 	Regenerate                  int
+	ResolutionTimeouts          resolution.Timeouts
+	Scopes                      scope.Filter
+	Registries                  *registry.Config
 	VersionHint                 bool
 	RepositoryName              string
 	CommitName                  string
@@ -75,6 +85,19 @@ type DebrickedOptions struct {
 	TagCommitAsRelease          bool
 	Experimental                bool
 	Version                     string
+	Offline                     bool
+	InventoryOutput             string
+	Format                      string
+	FormatOutput                string
+	PRComment                   bool
+	DiffBase                    string
+	FailOnUploadError           bool
+	PollInterval                int
+	MaxWait                     int
+	Detach                      bool
+	TrustProjectCode            bool
+	// ProgressFile stores the upload progress to resume interrupted scans, debricked.upload.json if empty
+	ProgressFile string
 }
 
 func NewDebrickedScanner(
@@ -102,6 +125,11 @@ func (dScanner *DebrickedScanner) Scan(o IOptions) error {
 	if !ok {
 		return BadOptsErr
 	}
+	if dOptions.Format != "" {
+		if _, err := format.NewWriter(dOptions.Format, format.Context{}); err != nil {
+			return err
+		}
+	}
 	debug.Log("Options initialized, finding CI service...", dOptions.Debug)
 
 	e, _ := dScanner.ciService.Find()
@@ -110,10 +138,25 @@ func (dScanner *DebrickedScanner) Scan(o IOptions) error {
 	MapEnvToOptions(&dOptions, e)
 	UpdatedEmptyCommitName(&dOptions)
 
-	if err := SetWorkingDirectory(&dOptions); err != nil {
+	// The detached scan is stored where the scan was started, so that status finds it there
+	detachedScanFile, err := filepath.Abs(OutputFileNameDetachedScan)
+	if err != nil {
+		return err
+	}
+	scanPath, err := filepath.Abs(dOptions.Path)
+	if err != nil {
+		return err
+	}
+	if err = SetWorkingDirectory(&dOptions); err != nil {
 		return err
 	}
 
+	if dOptions.Offline {
+		debug.Log("Running offline scan...", dOptions.Debug)
+
+		return dScanner.scanOffline(dOptions)
+	}
+
 	debug.Log("Setting up git objects...", dOptions.Debug)
 	gitMetaObject, err := git.NewMetaObject(
 		dOptions.Path,
@@ -127,20 +170,82 @@ func (dScanner *DebrickedScanner) Scan(o IOptions) error {
 		return err
 	}
 
+	var diff *DependencyDiff
+	if dOptions.DiffBase != "" {
+		debug.Log("Comparing dependency files with diff base...", dOptions.Debug)
+		diff, err = dScanner.scanDiff(dOptions)
+		if err != nil {
+			return err
+		}
+	}
+
 	debug.Log("Running scan with initialized scanner...", dOptions.Debug)
-	result, err := dScanner.scan(dOptions, *gitMetaObject)
+	result, local, err := dScanner.scan(dOptions, *gitMetaObject)
 	if err != nil {
 		return dScanner.handleScanError(err, dOptions.PassOnTimeOut)
 	}
 
+	if dOptions.Detach {
+		return writeDetachedScan(detachedScanFile, newDetachedScan(result.CiUploadId, *gitMetaObject, scanPath, dOptions))
+	}
+
+	return reportResult(dOptions, result, e.Integration, diff, local)
+}
+
+// newLocalResult parses the inventory of fileGroups, if needed, and the local policies of config
+func newLocalResult(options DebrickedOptions, config *upload.DebrickedConfig, fileGroups file.Groups) (localResult, error) {
+	var local localResult
+	if needsInventory(options, config) {
+		local.inventory = localInventory(fileGroups, options.Scopes)
+	}
+	var err error
+	local.check, err = newPolicyCheck(config, local.inventory)
+
+	return local, err
+}
+
+// needsInventory reports whether the result is formatted, or local policies are evaluated, against the inventory
+func needsInventory(options DebrickedOptions, config *upload.DebrickedConfig) bool {
+	return options.Format != "" || hasPolicies(config)
+}
+
+// localResult holds what the scan found out locally, to report alongside the result returned by Debricked
+type localResult struct {
+	// inventory is nil unless a format or local policies need it
+	inventory *inventory.Inventory
+	check     *policyCheck
+}
+
+// reportResult writes and prints result. FailPipelineErr is returned if a triggered automation rule, or a local
+// policy check if set, fails the pipeline.
+// If diff is set and complete, automation rules are filtered down to the dependencies introduced since the diff base.
+func reportResult(
+	options DebrickedOptions,
+	result *upload.UploadResult,
+	integration string,
+	diff *DependencyDiff,
+	local localResult,
+) error {
 	if result.LongQueue {
 		fmt.Println("Progress polling terminated due to long scan times. Please try again later")
+		if result.CiUploadId > 0 {
+			fmt.Printf("To resume, run: %s\n", color.YellowString("debricked scan status %d", result.CiUploadId))
+		}
 		fmt.Printf("For full details, visit: %s\n\n", color.BlueString(result.DetailsUrl))
 
 		return nil
 	}
 
-	WriteApiReplyToJsonFile(dOptions, result)
+	WriteApiReplyToJsonFile(options, result)
+	if diff != nil {
+		diff.Print()
+		if diff.Complete() {
+			result = FilterIntroducedRules(result, diff.Delta)
+		}
+	}
+	if err := WriteFormattedResult(options, result, local.inventory); err != nil {
+		return err
+	}
 
 	fmt.Printf("\n%d vulnerabilities found\n", result.VulnerabilitiesFound)
 	fmt.Println("")
@@ -149,7 +254,13 @@ func (dScanner *DebrickedScanner) Scan(o IOptions) error {
 		tui.NewRuleCard(os.Stdout, rule).Render()
 		failPipeline = failPipeline || (rule.Triggered && rule.FailPipeline())
 	}
+	if local.check != nil {
+		failPipeline = !local.check.evaluate(result, diff).Passed() || failPipeline
+	}
 	fmt.Printf("For full details, visit: %s\n\n", color.BlueString(result.DetailsUrl))
+	if options.PRComment {
+		publishPullRequestComment(integration, result)
+	}
 	if failPipeline {
 		return FailPipelineErr
 	}
@@ -187,6 +298,13 @@ func (dScanner *DebrickedScanner) scanResolve(options DebrickedOptions) error {
 		Exclusions:   options.Exclusions,
 		Inclusions:   options.Inclusions,
 		NpmPreferred: options.NpmPreferred,
+		Offline:      options.Offline,
+		Timeouts:     options.ResolutionTimeouts,
+		Scopes:       options.Scopes,
+		Registries:   options.Registries,
+		// Call graphs of Python projects are generated from the packages installed in the virtual environments
+		KeepVirtualEnvs:  options.CallGraph,
+		TrustProjectCode: options.TrustProjectCode,
 	}
 	if options.Resolve {
 		_, resErr := dScanner.resolver.Resolve([]string{options.Path}, resolveOptions)
@@ -200,7 +318,7 @@ func (dScanner *DebrickedScanner) scanResolve(options DebrickedOptions) error {
 
 func (dScanner *DebrickedScanner) scanFingerprint(options DebrickedOptions) error {
 	if options.Fingerprint {
-		if !(*dScanner.client).IsEnterpriseCustomer(false) {
+		if !options.Offline && !(*dScanner.client).IsEnterpriseCustomer(false) {
 
 			return nil
 		}
@@ -225,18 +343,22 @@ func (dScanner *DebrickedScanner) scanFingerprint(options DebrickedOptions) erro
 	return nil
 }
 
-func (dScanner *DebrickedScanner) scan(options DebrickedOptions, gitMetaObject git.MetaObject) (*upload.UploadResult, error) {
+func (dScanner *DebrickedScanner) scan(
+	options DebrickedOptions,
+	gitMetaObject git.MetaObject,
+) (*upload.UploadResult, localResult, error) {
+	var local localResult
 
 	debug.Log("Running scanResolve...", options.Debug)
 	err := dScanner.scanResolve(options)
 	if err != nil {
-		return nil, err
+		return nil, local, err
 	}
 
 	debug.Log("Running scanFingerprint...", options.Debug)
 	err = dScanner.scanFingerprint(options)
 	if err != nil {
-		return nil, err
+		return nil, local, err
 	}
 
 	if options.CallGraph {
@@ -244,6 +366,8 @@ func (dScanner *DebrickedScanner) scan(options DebrickedOptions, gitMetaObject g
 		configs := []config.IConfig{
 			config.NewConfig("java", []string{}, map[string]string{"pm": "maven"}, true, "maven", options.Version),
 			config.NewConfig("golang", []string{}, map[string]string{"pm": "go"}, true, "go", options.Version),
+			config.NewConfig("javascript", []string{}, map[string]string{"pm": "npm"}, true, "npm", options.Version),
+			config.NewConfig("python", []string{}, map[string]string{"pm": "pip"}, true, "pip", options.Version),
 		}
 		timeout := options.CallGraphGenerateTimeout
 		path := options.Path
@@ -260,22 +384,19 @@ func (dScanner *DebrickedScanner) scan(options DebrickedOptions, gitMetaObject g
 			},
 		)
 		if resErr != nil {
-			return nil, resErr
+			return nil, local, resErr
 		}
 	}
 
 	debug.Log("Matching groups...", options.Debug)
-	fileGroups, err := dScanner.finder.GetGroups(
-		file.DebrickedOptions{
-			RootPath:     options.Path,
-			Exclusions:   options.Exclusions,
-			Inclusions:   options.Inclusions,
-			LockFileOnly: false,
-			Strictness:   file.StrictAll,
-		},
-	)
+	fileGroups, err := dScanner.getGroups(options)
 	if err != nil {
-		return nil, err
+		return nil, local, err
+	}
+	debrickedConfig := dScanner.getDebrickedConfig(options.Path, options.Exclusions, options.Inclusions)
+	local, err = newLocalResult(options, debrickedConfig, fileGroups)
+	if err != nil {
+		return nil, local, err
 	}
 
 	debug.Log("Starting upload...", options.Debug)
@@ -285,23 +406,148 @@ func (dScanner *DebrickedScanner) scan(options DebrickedOptions, gitMetaObject g
 		IntegrationsName:       options.IntegrationName,
 		CallGraphUploadTimeout: options.CallGraphUploadTimeout,
 		VersionHint:            options.VersionHint,
-		DebrickedConfig:        dScanner.getDebrickedConfig(options.Path, options.Exclusions, options.Inclusions),
+		DebrickedConfig:        debrickedConfig,
 		TagCommitAsRelease:     options.TagCommitAsRelease,
 		Experimental:           options.Experimental,
+		FailOnUploadError:      options.FailOnUploadError,
+		PollOptions:            upload.NewPollOptions(options.PollInterval, options.MaxWait),
+		Scopes:                 options.Scopes,
+		Detach:                 options.Detach,
+		ProgressFile:           options.ProgressFile,
 	}
 	result, err := (*dScanner.uploader).Upload(uploaderOptions)
 	if err != nil {
-		return nil, err
+		return nil, local, err
+	}
+	if options.Detach {
+		return result, local, nil
 	}
 	err = dScanner.scanReportSBOM(
 		options,
 		result.DetailsUrl,
 	)
 	if err != nil {
-		return nil, err
+		return nil, local, err
+	}
+
+	return result, local, nil
+}
+
+// scanOffline resolves, fingerprints and parses dependency files into a local inventory without contacting Debricked
+func (dScanner *DebrickedScanner) scanOffline(options DebrickedOptions) error {
+	debug.Log("Running scanResolve...", options.Debug)
+	err := dScanner.scanResolve(options)
+	if err != nil {
+		return err
+	}
+
+	debug.Log("Running scanFingerprint...", options.Debug)
+	err = dScanner.scanFingerprint(options)
+	if err != nil {
+		return err
+	}
+
+	debug.Log("Matching groups...", options.Debug)
+	fileGroups, err := dScanner.finder.GetGroups(
+		file.DebrickedOptions{
+			RootPath:     options.Path,
+			Exclusions:   options.Exclusions,
+			Inclusions:   options.Inclusions,
+			LockFileOnly: false,
+			Strictness:   file.StrictAll,
+			Offline:      true,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	debug.Log("Generating dependency inventory...", options.Debug)
+	inv, err := inventory.NewGenerator().Generate(fileGroups)
+	if err != nil {
+		return err
+	}
+	inv.Filter(options.Scopes)
+	output := options.InventoryOutput
+	if output == "" {
+		output = inventory.OutputFileNameInventory
+	}
+	err = inv.ToFile(output)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n%d dependencies found (%d direct) in %d files\n", inv.Len(), inv.DirectCount(), len(inv.ParsedFiles))
+	for _, unparsedFile := range inv.UnparsedFiles {
+		fmt.Printf("%s Unable to parse dependencies of %s offline\n", color.YellowString("⚠️"), unparsedFile)
+	}
+	fmt.Printf("Dependency inventory written to: %s\n\n", color.YellowString(output))
+
+	err = dScanner.scanLocalSBOM(options, inv)
+	if err != nil {
+		return err
+	}
+
+	return dScanner.scanLocalPolicies(options, inv)
+}
+
+// scanLocalPolicies evaluates the policies of debricked-config.yaml against inv, as there is no scan result offline
+func (dScanner *DebrickedScanner) scanLocalPolicies(options DebrickedOptions, inv *inventory.Inventory) error {
+	debrickedConfig := dScanner.getDebrickedConfig(options.Path, options.Exclusions, options.Inclusions)
+	if !hasPolicies(debrickedConfig) {
+		return nil
+	}
+	engine, err := policy.NewEngine(debrickedConfig)
+	if err != nil {
+		return err
+	}
+	check := policyCheck{engine: engine, inventory: inv}
+	if !check.evaluate(nil, nil).Passed() {
+		return FailPipelineErr
+	}
+
+	return nil
+}
+
+func (dScanner *DebrickedScanner) scanLocalSBOM(options DebrickedOptions, inv *inventory.Inventory) error {
+	if options.SBOM == "" {
+		return nil
+	}
+	name := options.RepositoryName
+	if name == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		name = filepath.Base(cwd)
+	}
+
+	debug.Log("Generating SBOM from dependency inventory...", options.Debug)
+	reporter := sbom.LocalReporter{FileWriter: io.FileWriter{}}
+	err := reporter.Order(sbom.LocalOrderArgs{
+		Inventory: inv,
+		Name:      name,
+		Format:    options.SBOM,
+		Output:    options.SBOMOutput,
+	})
+	if err != nil {
+		return err
 	}
+	fmt.Printf("%s SBOM generated from local lock files\n\n", color.GreenString("✔"))
+
+	return nil
+}
 
-	return result, nil
+func (dScanner *DebrickedScanner) getGroups(options DebrickedOptions) (file.Groups, error) {
+	return dScanner.finder.GetGroups(
+		file.DebrickedOptions{
+			RootPath:     options.Path,
+			Exclusions:   options.Exclusions,
+			Inclusions:   options.Inclusions,
+			LockFileOnly: false,
+			Strictness:   file.StrictAll,
+		},
+	)
 }
 
 func (dScanner *DebrickedScanner) getDebrickedConfig(path string, exclusions []string, inclusions []string) *upload.DebrickedConfig {
@@ -309,6 +555,12 @@ func (dScanner *DebrickedScanner) getDebrickedConfig(path string, exclusions []s
 	if configPath == "" {
 		return nil
 	}
+	// Problems are only reported, since the config is parsed leniently for backwards compatibility
+	if content, err := os.ReadFile(configPath); err == nil {
+		for _, issue := range schema.ValidateSchema(content) {
+			fmt.Printf("%s %s:%s\n", color.YellowString("⚠️"), configPath, issue.String())
+		}
+	}
 
 	return upload.GetDebrickedConfig(configPath)
 }
@@ -379,3 +631,31 @@ func WriteApiReplyToJsonFile(options DebrickedOptions, result *upload.UploadResu
 		_ = os.WriteFile(options.JsonFilePath, file, 0600)
 	}
 }
+
+// publishPullRequestComment posts the scan summary on the pull request. Failures are reported but do not fail the scan.
+func publishPullRequestComment(integration string, result *upload.UploadResult) {
+	publisher, err := comment.NewPublisher(integration)
+	if err == nil {
+		err = publisher.Publish(comment.NewSummary(result))
+	}
+	if err != nil {
+		fmt.Printf("%s Failed to publish pull request comment: %s\n\n", color.YellowString("⚠️"), err.Error())
+
+		return
+	}
+	fmt.Printf("%s Published scan summary on pull request\n\n", color.GreenString("✔"))
+}
+
+// WriteFormattedResult writes result in the format of options. Dependencies are located in inv, if set.
+func WriteFormattedResult(options DebrickedOptions, result *upload.UploadResult, inv *inventory.Inventory) error {
+	if options.Format == "" {
+		return nil
+	}
+	output, err := format.ToFile(options.Format, options.FormatOutput, result, format.Context{Inventory: inv, Version: options.Version})
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Scan result written to: %s\n", color.YellowString(output))
+
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/debricked/cli/internal/automation"
 	"github.com/debricked/cli/internal/callgraph"
 	callgraphTestdata "github.com/debricked/cli/internal/callgraph/testdata"
 	"github.com/debricked/cli/internal/ci"
@@ -30,6 +31,7 @@ import (
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/fingerprint"
 	"github.com/debricked/cli/internal/git"
+	"github.com/debricked/cli/internal/inventory"
 	ioFs "github.com/debricked/cli/internal/io"
 	"github.com/debricked/cli/internal/resolution"
 	resolveTestdata "github.com/debricked/cli/internal/resolution/testdata"
@@ -195,6 +197,65 @@ func TestScanWithJsonPath(t *testing.T) {
 	assert.FileExists(t, filepath.Join(cwd, path, "result.json"))
 }
 
+func TestScanUnsupportedFormat(t *testing.T) {
+	scanner := makeScanner(testdata.NewDebClientMock(), nil, nil)
+
+	err := scanner.Scan(DebrickedOptions{Format: "xml"})
+
+	assert.ErrorContains(t, err, "unsupported format \"xml\"")
+}
+
+func TestWriteFormattedResult(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "junit.xml")
+	result := &upload.UploadResult{
+		AutomationRules: []automation.Rule{{RuleDescription: "rule", Triggered: true}},
+	}
+
+	err := WriteFormattedResult(DebrickedOptions{Format: "junit", FormatOutput: outputFile}, result, nil)
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `<testsuites name="Debricked" tests="1" failures="1">`)
+	assert.NoError(t, WriteFormattedResult(DebrickedOptions{}, result, nil))
+}
+
+func TestWriteFormattedResultLocatesDependencies(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "gl-dependency-scanning-report.json")
+	result := &upload.UploadResult{
+		AutomationRules: []automation.Rule{{
+			Triggered:     true,
+			TriggerEvents: []automation.TriggerEvent{{Dependency: "serde (Cargo)", Cve: "CVE-2023-0001"}},
+		}},
+	}
+	inv := &inventory.Inventory{Dependencies: []inventory.Dependency{
+		{Name: "serde", Version: "1.0.188", Ecosystem: inventory.EcosystemCargo, SourceFile: "Cargo.lock"},
+	}}
+
+	err := WriteFormattedResult(DebrickedOptions{Format: "gitlab", FormatOutput: outputFile, Version: "v2.0.0"}, result, inv)
+
+	assert.NoError(t, err)
+	content, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"file": "Cargo.lock"`)
+	assert.Contains(t, string(content), `"version": "1.0.188"`)
+	assert.Contains(t, string(content), `"version": "v2.0.0"`)
+}
+
+func TestPublishPullRequestCommentUnsupportedIntegration(t *testing.T) {
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	publishPullRequestComment("travis", &upload.UploadResult{})
+
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	assert.Contains(t, string(output), "Failed to publish pull request comment: pull request comments are supported on")
+}
+
 func TestScanFailingMetaObject(t *testing.T) {
 	var debClient client.IDebClient = testdata.NewDebClientMock()
 	scanner := NewDebrickedScanner(&debClient, nil, nil, ciService, nil, nil, nil)
@@ -351,6 +412,7 @@ func TestScanWithResolve(t *testing.T) {
 	repositoryName := path
 	commitName := "testdata/npm-commit"
 	opts := DebrickedOptions{
+		ProgressFile:    filepath.Join(t.TempDir(), upload.OutputFileNameUploadProgress),
 		Path:            path,
 		Resolve:         true,
 		Exclusions:      nil,
@@ -666,6 +728,85 @@ func TestScanServiceDowntime(t *testing.T) {
 	assert.ErrorContains(t, err, client.NoResErr.Error())
 }
 
+func TestScanOffline(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	clientMock.SetServiceUp(false)
+
+	scanner := makeScanner(clientMock, nil, nil)
+	cwd, _ := os.Getwd()
+	// reset working directory that has been manipulated in scanner.Scan
+	defer resetWd(t, cwd)
+	inventoryOutput := filepath.Join(t.TempDir(), "inventory.json")
+	opts := DebrickedOptions{
+		Path:            filepath.Join("testdata", "offline"),
+		Exclusions:      nil,
+		Fingerprint:     false,
+		Offline:         true,
+		InventoryOutput: inventoryOutput,
+	}
+
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := scanner.Scan(opts)
+
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	assert.NoError(t, err)
+	assert.NotContains(t, string(output), client.SupportedFormatsFallbackError.Error())
+	assert.Contains(t, string(output), "5 dependencies found (3 direct) in 1 files")
+	assert.Contains(t, string(output), "Dependency inventory written to")
+
+	content, err := os.ReadFile(inventoryOutput)
+	assert.NoError(t, err)
+	var inv inventory.Inventory
+	assert.NoError(t, json.Unmarshal(content, &inv))
+	assert.Equal(t, 5, inv.Len())
+	assert.Equal(t, []string{"package-lock.json"}, inv.ParsedFiles)
+}
+
+func TestScanOfflineWithSBOM(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	clientMock.SetServiceUp(false)
+
+	scanner := makeScanner(clientMock, nil, nil)
+	cwd, _ := os.Getwd()
+	// reset working directory that has been manipulated in scanner.Scan
+	defer resetWd(t, cwd)
+	dir := t.TempDir()
+	sbomOutput := filepath.Join(dir, "app.cdx.json")
+	opts := DebrickedOptions{
+		Path:            filepath.Join("testdata", "offline"),
+		Offline:         true,
+		InventoryOutput: filepath.Join(dir, "inventory.json"),
+		SBOM:            "CycloneDX",
+		SBOMOutput:      sbomOutput,
+	}
+
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := scanner.Scan(opts)
+
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), "SBOM generated from local lock files")
+
+	content, err := os.ReadFile(sbomOutput)
+	assert.NoError(t, err)
+	var document map[string]interface{}
+	assert.NoError(t, json.Unmarshal(content, &document))
+	assert.Equal(t, "CycloneDX", document["bomFormat"])
+	assert.Len(t, document["components"], 5)
+}
+
 func TestScanWithFingerprint(t *testing.T) {
 	if runtime.GOOS == windowsOS {
 		t.Skipf("TestScan is skipped due to Windows env")
@@ -692,6 +833,7 @@ func TestScanWithFingerprint(t *testing.T) {
 	repositoryName := path
 	commitName := "testdata/npm-commit-fingerprint"
 	opts := DebrickedOptions{
+		ProgressFile:    filepath.Join(t.TempDir(), upload.OutputFileNameUploadProgress),
 		Path:            path,
 		Resolve:         true,
 		Fingerprint:     true,
@@ -734,6 +876,7 @@ func TestScanWithFingerprintNoEnterprise(t *testing.T) {
 	repositoryName := path
 	commitName := "testdata/npm-commit-fingerprint"
 	opts := DebrickedOptions{
+		ProgressFile:    filepath.Join(t.TempDir(), upload.OutputFileNameUploadProgress),
 		Path:            path,
 		Resolve:         true,
 		Fingerprint:     true,
@@ -767,6 +910,7 @@ func TestScanWithGeneratedCommitName(t *testing.T) {
 	path := testdataNpm
 	repositoryName := path
 	opts := DebrickedOptions{
+		ProgressFile:       filepath.Join(t.TempDir(), upload.OutputFileNameUploadProgress),
 		Path:               path,
 		Resolve:            false,
 		Fingerprint:        false,
@@ -854,6 +998,7 @@ func TestScanWithSBOMReport(t *testing.T) {
 	// reset working directory that has been manipulated in scanner.Scan
 	defer resetWd(t, cwd)
 	opts := DebrickedOptions{
+		ProgressFile:             filepath.Join(t.TempDir(), upload.OutputFileNameUploadProgress),
 		Path:                     path,
 		Exclusions:               nil,
 		RepositoryName:           repositoryName,
@@ -0,0 +1,195 @@
+package scan
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/debricked/cli/internal/debug"
+	"github.com/debricked/cli/internal/file"
+	"github.com/debricked/cli/internal/format"
+	"github.com/debricked/cli/internal/git"
+	"github.com/debricked/cli/internal/scope"
+	"github.com/debricked/cli/internal/upload"
+	"github.com/fatih/color"
+)
+
+const OutputFileNameDetachedScan = "debricked.scan.json"
+
+var NoCiUploadIdErr = errors.New("no scan to resume. Specify the scan id or run a detached scan first")
+
+type IStatusChecker interface {
+	Status(o IOptions) error
+}
+
+type StatusOptions struct {
+	// CiUploadId identifies the scan. If zero, the scan stored by the last detached scan in the working directory is used
+	CiUploadId    int
+	PollInterval  int
+	MaxWait       int
+	PassOnTimeOut bool
+	JsonFilePath  string
+	Format        string
+	FormatOutput  string
+	PRComment     bool
+	Debug         bool
+	// Version is the version of the CLI
+	Version string
+}
+
+// DetachedScan is stored by detached scans, so that the scan can be resumed by `debricked scan status`. The options
+// of the scan are stored as well, so that its result is reported against the same files.
+type DetachedScan struct {
+	CiUploadId     int    `json:"ciUploadId"`
+	RepositoryName string `json:"repositoryName"`
+	CommitName     string `json:"commitName"`
+	// Path is the absolute path of the scanned directory
+	Path       string       `json:"path,omitempty"`
+	Exclusions []string     `json:"exclusions,omitempty"`
+	Inclusions []string     `json:"inclusions,omitempty"`
+	Scopes     scope.Filter `json:"scopes,omitempty"`
+	DiffBase   string       `json:"diffBase,omitempty"`
+}
+
+func newDetachedScan(ciUploadId int, gitMetaObject git.MetaObject, path string, options DebrickedOptions) DetachedScan {
+	return DetachedScan{
+		CiUploadId:     ciUploadId,
+		RepositoryName: gitMetaObject.RepositoryName,
+		CommitName:     gitMetaObject.CommitName,
+		Path:           path,
+		Exclusions:     options.Exclusions,
+		Inclusions:     options.Inclusions,
+		Scopes:         options.Scopes,
+		DiffBase:       options.DiffBase,
+	}
+}
+
+// Status resumes polling of a scan started earlier, handling the result the same way as Scan does
+func (dScanner *DebrickedScanner) Status(o IOptions) error {
+	sOptions, ok := o.(StatusOptions)
+	if !ok {
+		return BadOptsErr
+	}
+	if sOptions.Format != "" {
+		if _, err := format.NewWriter(sOptions.Format, format.Context{}); err != nil {
+			return err
+		}
+	}
+
+	detachedScan, err := findDetachedScan(sOptions.CiUploadId)
+	if err != nil {
+		return err
+	}
+	e, _ := dScanner.ciService.Find()
+
+	debug.Log(fmt.Sprintf("Polling status of scan %d...", detachedScan.CiUploadId), sOptions.Debug)
+	poller := upload.NewStatusPoller(*dScanner.client)
+	result, err := poller.Poll(detachedScan.CiUploadId, upload.NewPollOptions(sOptions.PollInterval, sOptions.MaxWait))
+	if err != nil && !errors.Is(err, upload.PollingTerminatedErr) {
+		return dScanner.handleScanError(err, sOptions.PassOnTimeOut)
+	}
+
+	options := detachedScan.options(sOptions)
+	if err = SetWorkingDirectory(&options); err != nil {
+		return err
+	}
+	diff, local, err := dScanner.statusLocalResult(options)
+	if err != nil {
+		return err
+	}
+
+	return reportResult(options, result, e.Integration, diff, local)
+}
+
+// findDetachedScan returns the scan stored by the last detached scan if ciUploadId is zero or identifies it. Other
+// scans are reported against the working directory.
+func findDetachedScan(ciUploadId int) (*DetachedScan, error) {
+	detachedScan, err := ReadDetachedScan(OutputFileNameDetachedScan)
+	if ciUploadId == 0 {
+		return detachedScan, err
+	}
+	if err == nil && detachedScan.CiUploadId == ciUploadId {
+		return detachedScan, nil
+	}
+
+	return &DetachedScan{CiUploadId: ciUploadId, Exclusions: file.DefaultExclusions()}, nil
+}
+
+func (detachedScan *DetachedScan) options(sOptions StatusOptions) DebrickedOptions {
+	return DebrickedOptions{
+		Path:         detachedScan.Path,
+		Exclusions:   detachedScan.Exclusions,
+		Inclusions:   detachedScan.Inclusions,
+		Scopes:       detachedScan.Scopes,
+		DiffBase:     detachedScan.DiffBase,
+		JsonFilePath: sOptions.JsonFilePath,
+		Format:       sOptions.Format,
+		FormatOutput: sOptions.FormatOutput,
+		PRComment:    sOptions.PRComment,
+		Debug:        sOptions.Debug,
+		Version:      sOptions.Version,
+	}
+}
+
+// statusLocalResult repeats the local parts of a scan, the diff and the local policies, for a resumed scan
+func (dScanner *DebrickedScanner) statusLocalResult(options DebrickedOptions) (*DependencyDiff, localResult, error) {
+	var diff *DependencyDiff
+	var err error
+	if options.DiffBase != "" {
+		debug.Log("Comparing dependency files with diff base...", options.Debug)
+		diff, err = dScanner.scanDiff(options)
+		if err != nil {
+			return nil, localResult{}, err
+		}
+	}
+	debrickedConfig := dScanner.getDebrickedConfig(options.Path, options.Exclusions, options.Inclusions)
+	var fileGroups file.Groups
+	if needsInventory(options, debrickedConfig) {
+		debug.Log("Matching groups...", options.Debug)
+		fileGroups, err = dScanner.getGroups(options)
+		if err != nil {
+			return nil, localResult{}, err
+		}
+	}
+	local, err := newLocalResult(options, debrickedConfig, fileGroups)
+
+	return diff, local, err
+}
+
+func writeDetachedScan(path string, detachedScan DetachedScan) error {
+	content, err := json.MarshalIndent(detachedScan, "", "  ")
+	if err != nil {
+		return err
+	}
+	err = os.WriteFile(path, content, 0600)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Scan %d started in detached mode, stored in %s\n", detachedScan.CiUploadId, color.YellowString(path))
+	fmt.Printf("To get the result, run: %s\n\n", color.YellowString("debricked scan status %d", detachedScan.CiUploadId))
+
+	return nil
+}
+
+// ReadDetachedScan reads the scan stored by a detached scan
+func ReadDetachedScan(path string) (*DetachedScan, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, NoCiUploadIdErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	var detachedScan DetachedScan
+	err = json.Unmarshal(content, &detachedScan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if detachedScan.CiUploadId == 0 {
+		return nil, NoCiUploadIdErr
+	}
+
+	return &detachedScan, nil
+}
@@ -0,0 +1,214 @@
+package scan
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/debricked/cli/internal/client/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func captureStdout(t *testing.T, run func() error) (string, error) {
+	t.Helper()
+	rescueStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := run()
+
+	_ = w.Close()
+	output, _ := io.ReadAll(r)
+	os.Stdout = rescueStdout
+
+	return string(output), err
+}
+
+func TestScanDetach(t *testing.T) {
+	if runtime.GOOS == windowsOS {
+		t.Skipf("TestScanDetach is skipped due to Windows env")
+	}
+	clientMock := testdata.NewDebClientMock()
+	addMockedFormatsResponse(clientMock, "package\\.json")
+	addMockedFileUploadResponse(clientMock)
+	addMockedFinishResponse(clientMock, http.StatusNoContent)
+	scanner := makeScanner(clientMock, nil, nil)
+	cwd, _ := os.Getwd()
+	defer resetWd(t, cwd)
+	defer os.Remove(filepath.Join(cwd, OutputFileNameDetachedScan))
+	opts := DebrickedOptions{
+		Path:           testdataNpm,
+		RepositoryName: testdataNpm,
+		CommitName:     "commit",
+		Exclusions:     []string{"**/node_modules/**"},
+		Detach:         true,
+	}
+
+	output, err := captureStdout(t, func() error { return scanner.Scan(opts) })
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Scan 1 started in detached mode")
+	assert.Contains(t, output, "debricked scan status 1")
+	assert.NotContains(t, output, "Scanning...")
+	// The detached scan is stored where the scan was started, rather than in the scanned directory
+	assert.NoFileExists(t, OutputFileNameDetachedScan)
+	detachedScan, err := ReadDetachedScan(filepath.Join(cwd, OutputFileNameDetachedScan))
+	assert.NoError(t, err)
+	assert.Equal(t, DetachedScan{
+		CiUploadId:     1,
+		RepositoryName: testdataNpm,
+		CommitName:     "commit",
+		Path:           filepath.Join(cwd, testdataNpm),
+		Exclusions:     []string{"**/node_modules/**"},
+	}, *detachedScan)
+}
+
+func TestStatus(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	addMockedStatusResponse(clientMock, http.StatusOK, 50)
+	addMockedStatusResponse(clientMock, http.StatusOK, 100)
+	scanner := makeScanner(clientMock, nil, nil)
+
+	output, err := captureStdout(t, func() error {
+		return scanner.Status(StatusOptions{CiUploadId: 1, PollInterval: 0})
+	})
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, "100% |")
+	assert.Contains(t, output, "0 vulnerabilities found")
+}
+
+func TestStatusFailPipeline(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	clientMock.AddMockUriResponse("/api/1.0/open/ci/upload/status", testdata.MockResponse{
+		StatusCode: http.StatusOK,
+		ResponseBody: io.NopCloser(strings.NewReader(`{"progress": 100, "automationRules": [
+			{"ruleDescription": "Fail on vulnerabilities", "ruleActions": ["failPipeline"], "triggered": true}
+		]}`)),
+	})
+	scanner := makeScanner(clientMock, nil, nil)
+
+	_, err := captureStdout(t, func() error { return scanner.Status(StatusOptions{CiUploadId: 1}) })
+
+	assert.ErrorIs(t, err, FailPipelineErr)
+}
+
+func TestStatusLongQueue(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	addMockedStatusResponse(clientMock, http.StatusCreated, 0)
+	scanner := makeScanner(clientMock, nil, nil)
+
+	output, err := captureStdout(t, func() error { return scanner.Status(StatusOptions{CiUploadId: 3}) })
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, "Progress polling terminated due to long scan times")
+	assert.Contains(t, output, "debricked scan status 3")
+}
+
+func TestStatusFromDetachedScan(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	addMockedStatusResponse(clientMock, http.StatusOK, 100)
+	scanner := makeScanner(clientMock, nil, nil)
+	cwd, _ := os.Getwd()
+	defer resetWd(t, cwd)
+	dir := t.TempDir()
+	content, _ := json.Marshal(DetachedScan{CiUploadId: 5})
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, OutputFileNameDetachedScan), content, 0600))
+	assert.NoError(t, os.Chdir(dir))
+
+	output, err := captureStdout(t, func() error { return scanner.Status(StatusOptions{}) })
+
+	assert.NoError(t, err)
+	assert.Contains(t, output, "0 vulnerabilities found")
+}
+
+func TestStatusLocalPolicies(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	addMockedStatusResponse(clientMock, http.StatusOK, 100)
+	addMockedFormatsResponse(clientMock, "package-lock\\.json")
+	scanner := makeScanner(clientMock, nil, nil)
+	cwd, _ := os.Getwd()
+	defer resetWd(t, cwd)
+	scanDir := makePolicyDirectory(t, "policies:\n  deny:\n    packages:\n      - \"lodash\"\n")
+	dir := t.TempDir()
+	content, _ := json.Marshal(DetachedScan{CiUploadId: 5, Path: scanDir})
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, OutputFileNameDetachedScan), content, 0600))
+	assert.NoError(t, os.Chdir(dir))
+
+	output, err := captureStdout(t, func() error { return scanner.Status(StatusOptions{CiUploadId: 5}) })
+
+	assert.ErrorIs(t, err, FailPipelineErr)
+	assert.Contains(t, output, "denied by lodash")
+	assert.Contains(t, output, "Local policy check failed")
+}
+
+func TestFindDetachedScan(t *testing.T) {
+	cwd, _ := os.Getwd()
+	defer resetWd(t, cwd)
+	dir := t.TempDir()
+	content, _ := json.Marshal(DetachedScan{CiUploadId: 5, Path: dir})
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, OutputFileNameDetachedScan), content, 0600))
+	assert.NoError(t, os.Chdir(dir))
+
+	detachedScan, err := findDetachedScan(0)
+	assert.NoError(t, err)
+	assert.Equal(t, dir, detachedScan.Path)
+	detachedScan, err = findDetachedScan(5)
+	assert.NoError(t, err)
+	assert.Equal(t, dir, detachedScan.Path)
+
+	// Other scans are reported against the working directory
+	detachedScan, err = findDetachedScan(6)
+	assert.NoError(t, err)
+	assert.Equal(t, 6, detachedScan.CiUploadId)
+	assert.Empty(t, detachedScan.Path)
+	assert.NotEmpty(t, detachedScan.Exclusions)
+}
+
+func TestStatusWithoutDetachedScan(t *testing.T) {
+	scanner := makeScanner(testdata.NewDebClientMock(), nil, nil)
+	cwd, _ := os.Getwd()
+	defer resetWd(t, cwd)
+	assert.NoError(t, os.Chdir(t.TempDir()))
+
+	err := scanner.Status(StatusOptions{})
+
+	assert.ErrorIs(t, err, NoCiUploadIdErr)
+}
+
+func TestStatusBadOpts(t *testing.T) {
+	scanner := makeScanner(testdata.NewDebClientMock(), nil, nil)
+
+	err := scanner.Status(DebrickedOptions{})
+
+	assert.ErrorIs(t, err, BadOptsErr)
+}
+
+func TestStatusUnsupportedFormat(t *testing.T) {
+	scanner := makeScanner(testdata.NewDebClientMock(), nil, nil)
+
+	err := scanner.Status(StatusOptions{CiUploadId: 1, Format: "xml"})
+
+	assert.ErrorContains(t, err, "xml")
+}
+
+func TestReadDetachedScanInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), OutputFileNameDetachedScan)
+	assert.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+
+	detachedScan, err := ReadDetachedScan(path)
+
+	assert.Nil(t, detachedScan)
+	assert.ErrorContains(t, err, "failed to read")
+
+	assert.NoError(t, os.WriteFile(path, []byte("{}"), 0600))
+	detachedScan, err = ReadDetachedScan(path)
+
+	assert.Nil(t, detachedScan)
+	assert.ErrorIs(t, err, NoCiUploadIdErr)
+}
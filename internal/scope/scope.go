@@ -0,0 +1,132 @@
+// Package scope classifies dependency scopes, such as Maven scopes, Gradle configurations and npm devDependencies,
+// into production, development and test dependencies, so that dependencies can be filtered on what they are used for.
+package scope
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// Prod dependencies are needed to build or run the project
+	Prod = "prod"
+	// Dev dependencies are only needed when developing the project, such as linters and build tooling
+	Dev = "dev"
+	// Test dependencies are only needed to test the project
+	Test = "test"
+)
+
+var Scopes = []string{Prod, Dev, Test}
+
+// Filter is the set of scopes to keep. The empty filter keeps every scope.
+type Filter map[string]bool
+
+// ParseFilter parses scopes to keep, such as prod and test
+func ParseFilter(values []string) (Filter, error) {
+	filter := Filter{}
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if !isScope(value) {
+			return nil, fmt.Errorf("invalid scope: %s, expected %s", value, strings.Join(Scopes, ", "))
+		}
+		filter[value] = true
+	}
+
+	return filter, nil
+}
+
+// Keeps reports whether a dependency of scopes is kept. Dependencies of unknown scope, i.e. without scopes, are kept.
+func (filter Filter) Keeps(scopes ...string) bool {
+	if len(filter) == 0 || len(scopes) == 0 {
+		return true
+	}
+	for _, s := range scopes {
+		if filter[s] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (filter Filter) String() string {
+	scopes := make([]string, 0, len(filter))
+	for s := range filter {
+		scopes = append(scopes, s)
+	}
+	sort.Strings(scopes)
+
+	return strings.Join(scopes, ",")
+}
+
+func isScope(value string) bool {
+	for _, s := range Scopes {
+		if s == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Maven classifies Maven scopes, where compile, provided, runtime, system and import are production scopes
+func Maven(mavenScope string) string {
+	if strings.EqualFold(mavenScope, "test") {
+		return Test
+	}
+
+	return Prod
+}
+
+// devConfigurations are prefixes of Gradle configurations of build tooling, which doesn't end up in the project
+var devConfigurations = []string{
+	"annotationprocessor",
+	"kapt",
+	"checkstyle",
+	"detekt",
+	"errorprone",
+	"jacoco",
+	"ktlint",
+	"lint",
+	"pmd",
+	"spotbugs",
+}
+
+// Gradle classifies Gradle configurations, such as testRuntimeClasspath which is a test configuration
+func Gradle(configuration string) string {
+	name := strings.ToLower(configuration)
+	if strings.Contains(name, "test") {
+		return Test
+	}
+	for _, prefix := range devConfigurations {
+		if strings.HasPrefix(name, prefix) {
+			return Dev
+		}
+	}
+
+	return Prod
+}
+
+// Group classifies dependency groups, such as Poetry groups and PEP 735 dependency groups, where main and default
+// are production groups
+func Group(group string) string {
+	name := strings.ToLower(group)
+	switch {
+	case name == "main" || name == "default" || name == "":
+		return Prod
+	case strings.Contains(name, "test"):
+		return Test
+	default:
+		return Dev
+	}
+}
+
+// DevIf returns Dev if isDev, and Prod otherwise, for package managers only telling dev dependencies apart
+func DevIf(isDev bool) string {
+	if isDev {
+		return Dev
+	}
+
+	return Prod
+}
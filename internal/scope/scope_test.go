@@ -0,0 +1,78 @@
+package scope
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFilter(t *testing.T) {
+	filter, err := ParseFilter([]string{"prod", " Test "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, Filter{Prod: true, Test: true}, filter)
+	assert.Equal(t, "prod,test", filter.String())
+}
+
+func TestParseFilterErr(t *testing.T) {
+	_, err := ParseFilter([]string{"prod", "compile"})
+
+	assert.EqualError(t, err, "invalid scope: compile, expected prod, dev, test")
+}
+
+func TestKeeps(t *testing.T) {
+	filter := Filter{Prod: true}
+
+	assert.True(t, filter.Keeps(Prod))
+	assert.True(t, filter.Keeps(Test, Prod))
+	assert.False(t, filter.Keeps(Test))
+	assert.False(t, filter.Keeps(Dev, Test))
+	assert.True(t, filter.Keeps(), "dependencies of unknown scope are kept")
+	assert.True(t, Filter{}.Keeps(Test))
+	assert.True(t, Filter(nil).Keeps(Dev))
+}
+
+func TestMaven(t *testing.T) {
+	for _, mavenScope := range []string{"compile", "provided", "runtime", "system", "import", ""} {
+		assert.Equal(t, Prod, Maven(mavenScope), mavenScope)
+	}
+	assert.Equal(t, Test, Maven("test"))
+}
+
+func TestGradle(t *testing.T) {
+	cases := map[string]string{
+		"compileClasspath":              Prod,
+		"runtimeClasspath":              Prod,
+		"releaseRuntimeClasspath":       Prod,
+		"testCompileClasspath":          Test,
+		"androidTestRuntimeClasspath":   Test,
+		"integrationTestImplementation": Test,
+		"annotationProcessor":           Dev,
+		"kapt":                          Dev,
+		"detekt":                        Dev,
+		"jacocoAgent":                   Dev,
+	}
+	for configuration, expected := range cases {
+		assert.Equal(t, expected, Gradle(configuration), configuration)
+	}
+}
+
+func TestGroup(t *testing.T) {
+	cases := map[string]string{
+		"main":    Prod,
+		"default": Prod,
+		"dev":     Dev,
+		"docs":    Dev,
+		"lint":    Dev,
+		"test":    Test,
+		"tests":   Test,
+	}
+	for group, expected := range cases {
+		assert.Equal(t, expected, Group(group), group)
+	}
+}
+
+func TestDevIf(t *testing.T) {
+	assert.Equal(t, Dev, DevIf(true))
+	assert.Equal(t, Prod, DevIf(false))
+}
@@ -2,6 +2,8 @@ package upload
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -13,21 +15,23 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
-	"time"
 
 	"github.com/debricked/cli/internal/client"
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/git"
-	"github.com/debricked/cli/internal/tui"
+	"github.com/debricked/cli/internal/inventory"
+	"github.com/debricked/cli/internal/scope"
 	"github.com/fatih/color"
 	"gopkg.in/yaml.v3"
 )
 
 var (
-	NoFilesErr           = errors.New("failed to find dependency files")
-	PollingTerminatedErr = errors.New("progress polling terminated due to long queue times")
-	EmptyFileErr         = errors.New("tried to upload empty file")
-	InitScanErr          = errors.New("failed to initialize a scan")
+	NoFilesErr                   = errors.New("failed to find dependency files")
+	PollingTerminatedErr         = errors.New("progress polling terminated due to long queue times")
+	EmptyFileErr                 = errors.New("tried to upload empty file")
+	InitScanErr                  = errors.New("failed to initialize a scan")
+	UploadFailedErr              = errors.New("failed to upload dependency files")
+	NonEnterpriseFingerprintsErr = errors.New("non-enterprise customer trying to upload fingerprints")
 )
 
 const callgraphName = "debricked-call-graph"
@@ -43,6 +47,13 @@ type uploadBatch struct {
 	debrickedConfig    *DebrickedConfig // JSON Config
 	tagCommitAsRelease bool
 	experimental       bool
+	failOnUploadError  bool
+	pollOptions        PollOptions
+	summary            *Summary
+	progress           *uploadProgress
+	progressFile       string
+	// scopes filters the dependencies of native lock files before they are uploaded, keeping every scope if empty
+	scopes scope.Filter
 }
 
 func newUploadBatch(
@@ -61,37 +72,38 @@ func newUploadBatch(
 		debrickedConfig:    debrickedConfig,
 		tagCommitAsRelease: tagCommitAsRelease,
 		experimental:       experimental,
+		summary:            &Summary{},
+		progress:           &uploadProgress{Files: map[string]string{}},
+		progressFile:       OutputFileNameUploadProgress,
 	}
 }
 
-// upload concurrently posts all file groups to Debricked
+// upload concurrently posts all file groups to Debricked. If an earlier upload of the same commit was interrupted,
+// its scan is resumed and files that are unchanged since are skipped.
 func (uploadBatch *uploadBatch) upload() error {
-	uploadWorker := func(fileQueue <-chan string, fileResults chan<- int) {
-		const ok = 0
-		const fail = 1
-
+	uploadWorker := func(fileQueue <-chan string, fileResults chan<- FileUpload) {
 		for f := range fileQueue {
-			fileName := filepath.Base(f)
-			var err error
 			timeout := 0
-			if strings.HasSuffix(fileName, callgraphName) {
+			if strings.HasSuffix(filepath.Base(f), callgraphName) {
 				timeout = uploadBatch.callGraphTimeout
 			}
-			err = uploadBatch.uploadFile(f, timeout)
-
-			if err != nil {
+			result := uploadBatch.uploadFileResult(f, timeout)
+			if result.Err != nil {
 				log.Println("Failed to upload:", f)
-				if err != nil {
-					log.Println(err.Error())
-					fileResults <- fail
-				}
+				log.Println(result.Err.Error())
 			} else {
 				printSuccessfulUpload(f)
-				fileResults <- ok
 			}
+			fileResults <- result
 		}
 	}
 
+	uploadBatch.progress = readUploadProgress(uploadBatch.progressFile, uploadBatch.gitMetaObject)
+	resumed := uploadBatch.progress.CiUploadId > 0
+	if resumed {
+		uploadBatch.ciUploadId = uploadBatch.progress.CiUploadId
+		fmt.Printf("Resuming scan %d, skipping files uploaded earlier\n", uploadBatch.ciUploadId)
+	}
 	files, err := uploadBatch.initUpload()
 	if err != nil {
 
@@ -99,32 +111,100 @@ func (uploadBatch *uploadBatch) upload() error {
 	}
 
 	fileQueue := make(chan string, len(files))
-	fileResults := make(chan int, len(files))
+	fileResults := make(chan FileUpload, len(files))
 
 	// Spawn workers
 	for w := 1; w <= 20; w++ {
 		go uploadWorker(fileQueue, fileResults)
 	}
 
-	// Append file jobs on queue
+	// Append file jobs on queue, skipping files shared by several groups and files already uploaded for this scan
+	queued := 0
+	queuedFiles := map[string]bool{}
 	for _, f := range files {
+		if queuedFiles[filepath.Clean(f)] || uploadBatch.progress.uploaded(f) {
+			uploadBatch.summary.add(FileUpload{File: f, Status: FileSkipped})
+
+			continue
+		}
+		queuedFiles[filepath.Clean(f)] = true
 		fileQueue <- f
+		queued++
 	}
 
 	// Await completion
-	for range files {
-		<-fileResults
+	for i := 0; i < queued; i++ {
+		uploadBatch.summary.add(<-fileResults)
 	}
 
 	close(fileQueue)
 
+	uploadBatch.summary.Render(os.Stdout)
+	failed := uploadBatch.summary.Count(FileFailed)
+	err = uploadBatch.storeProgress(resumed, failed)
+	if err != nil {
+		log.Println("Failed to store upload progress:", err.Error())
+	}
+	if failed > 0 && uploadBatch.failOnUploadError {
+		return fmt.Errorf("%w: %d of %d files failed", UploadFailedErr, failed, len(uploadBatch.summary.Uploads()))
+	}
+
 	return nil
 }
 
+// storeProgress stores the upload progress, so that an interrupted scan can be resumed, once a scan has been started
+// and files have been uploaded to it
+func (uploadBatch *uploadBatch) storeProgress(resumed bool, failed int) error {
+	uploaded := uploadBatch.summary.Count(FileUploaded)
+	if resumed && failed > 0 && uploaded == 0 {
+		// The resumed scan may no longer accept files, so the next scan starts over
+		return removeUploadProgress(uploadBatch.progressFile)
+	}
+	if uploadBatch.ciUploadId == 0 || uploaded == 0 {
+		return nil
+	}
+
+	return uploadBatch.progress.toFile(uploadBatch.progressFile)
+}
+
+// uploadFileResult uploads filePath and records it in the upload progress. Transient errors are retried by the client.
+func (uploadBatch *uploadBatch) uploadFileResult(filePath string, timeout int) FileUpload {
+	err := uploadBatch.uploadFile(filePath, timeout)
+	if err != nil {
+		return FileUpload{File: filePath, Status: FileFailed, Err: err}
+	}
+	uploadBatch.progress.add(filePath)
+
+	return FileUpload{File: filePath, Status: FileUploaded}
+}
+
+func hashFile(filePath string) (string, error) {
+	f, err := os.Open(filepath.Clean(filePath))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// statusError is returned when Debricked responds to an upload with an error status code
+type statusError struct {
+	StatusCode int
+}
+
+func (err statusError) Error() string {
+	return fmt.Sprintf("upload failed due to status code %d", err.StatusCode)
+}
+
 // uploadFile Reads file content from filepath and uploads it to Debricked. Returns HTTP status code or 0 if other error occur
 func (uploadBatch *uploadBatch) uploadFile(filePath string, timeout int) error {
 	if strings.HasSuffix(filePath, "debricked.fingerprints.txt") && !(*uploadBatch.client).IsEnterpriseCustomer(true) {
-		return errors.New("non-enterprise customer trying to upload fingerprints")
+		return NonEnterpriseFingerprintsErr
 	}
 
 	body := &bytes.Buffer{}
@@ -132,16 +212,11 @@ func (uploadBatch *uploadBatch) uploadFile(filePath string, timeout int) error {
 	defer writer.Close()
 
 	fileData, _ := writer.CreateFormFile("fileData", filepath.Base(filePath))
-	f, err := os.Open(filepath.Clean(filePath))
+	content, err := uploadBatch.fileContent(filepath.Clean(filePath))
 	if err != nil {
 		return err
 	}
-
-	defer func(f *os.File) {
-		_ = f.Close()
-	}(f)
-
-	_, _ = io.Copy(fileData, f)
+	_, _ = fileData.Write(content)
 
 	_ = writer.WriteField("fileRelativePath", getRelativeFilePath(filePath))
 	_ = writer.WriteField("repositoryName", uploadBatch.gitMetaObject.RepositoryName)
@@ -160,9 +235,14 @@ func (uploadBatch *uploadBatch) uploadFile(filePath string, timeout int) error {
 	if err != nil {
 		return err
 	}
+	if response.Body != nil {
+		defer response.Body.Close()
+	}
+	if response.StatusCode >= http.StatusBadRequest {
+		return statusError{StatusCode: response.StatusCode}
+	}
 	if !uploadBatch.initialized() {
 		data, _ := io.ReadAll(response.Body)
-		defer response.Body.Close()
 		uFile := uploadedFile{}
 		_ = json.Unmarshal(data, &uFile)
 		if uFile.CiUploadId == 0 {
@@ -174,6 +254,35 @@ func (uploadBatch *uploadBatch) uploadFile(filePath string, timeout int) error {
 	return nil
 }
 
+// fileContent reads filePath, without the dependencies outside the scopes kept if it is a native lock file recording
+// scopes. Lock files that fail to be filtered are uploaded as they are.
+func (uploadBatch *uploadBatch) fileContent(filePath string) ([]byte, error) {
+	if len(uploadBatch.scopes) == 0 {
+		return os.ReadFile(filePath)
+	}
+	content, err := inventory.FilterLockFile(filePath, uploadBatch.manifestFile(filePath), uploadBatch.scopes)
+	if err != nil {
+		log.Printf("Failed to filter the scopes of %s, so it includes dependencies of every scope: %s\n", filePath, err.Error())
+
+		return os.ReadFile(filePath)
+	}
+
+	return content, nil
+}
+
+// manifestFile returns the manifest file of the group lockFile belongs to, which is empty if it lacks one
+func (uploadBatch *uploadBatch) manifestFile(lockFile string) string {
+	for _, group := range uploadBatch.fileGroups.ToSlice() {
+		for _, f := range group.LockFiles {
+			if filepath.Clean(f) == lockFile {
+				return group.ManifestFile
+			}
+		}
+	}
+
+	return ""
+}
+
 // initAnalysis send the finish request that starts the analysis
 func (uploadBatch *uploadBatch) initAnalysis() error {
 	if uploadBatch.ciUploadId == 0 {
@@ -212,7 +321,7 @@ func (uploadBatch *uploadBatch) initAnalysis() error {
 		fmt.Println("Successfully initialized scan")
 	}
 
-	return nil
+	return removeUploadProgress(uploadBatch.progressFile)
 }
 
 func (uploadBatch *uploadBatch) initialized() bool {
@@ -221,53 +330,14 @@ func (uploadBatch *uploadBatch) initialized() bool {
 
 // wait track scan progress and return uploadStatus upon completion
 func (uploadBatch *uploadBatch) wait() (*UploadResult, error) {
-	bar := tui.NewProgressBar()
-	_ = bar.RenderBlank()
-	// poll scan status until completion
-	var resultStatus *UploadResult
-	uri := fmt.Sprintf("/api/1.0/open/ci/upload/status?ciUploadId=%s", strconv.Itoa(uploadBatch.ciUploadId))
-	for !bar.IsFinished() {
-		res, err := (*uploadBatch.client).Get(uri, "application/json")
-		if err != nil {
-			return nil, err
-		}
-		status, err := newUploadStatus(res)
-		if err != nil {
-			return nil, err
-		}
-		if res.StatusCode == http.StatusCreated {
-			err := bar.Finish()
-			if err != nil {
-				return resultStatus, err
-			}
-
-			resultStatus = &UploadResult{
-				DetailsUrl: status.DetailsUrl,
-				LongQueue:  true,
-			}
-
-			return resultStatus, PollingTerminatedErr
-		}
-		err = bar.Set(status.Progress)
-		if err != nil {
-			return nil, err
-		}
-
-		if bar.IsFinished() {
-			resultStatus = newUploadResult(status)
-		} else {
-			time.Sleep(1000 * time.Millisecond)
-		}
-	}
-
-	return resultStatus, nil
+	return NewStatusPoller(*uploadBatch.client).Poll(uploadBatch.ciUploadId, uploadBatch.pollOptions)
 }
 
 // initUpload initialises a scan by uploading one file. This enables the scan to
 // get assigned a `ciUploadId`
 func (uploadBatch *uploadBatch) initUpload() ([]string, error) {
 	files := uploadBatch.fileGroups.GetFiles()
-	if len(files) == 0 {
+	if len(files) == 0 || uploadBatch.initialized() {
 		return files, nil
 	}
 
@@ -280,8 +350,11 @@ func (uploadBatch *uploadBatch) initUpload() ([]string, error) {
 		if strings.HasSuffix(filepath.Base(entryFile), callgraphName) {
 			timeout = 30
 		}
-		err = uploadBatch.uploadFile(entryFile, timeout)
+		result := uploadBatch.uploadFileResult(entryFile, timeout)
+		uploadBatch.summary.add(result)
+		err = result.Err
 		if err == nil {
+			uploadBatch.progress.CiUploadId = uploadBatch.ciUploadId
 			printSuccessfulUpload(entryFile)
 
 			return files, nil
@@ -341,11 +414,17 @@ type IgnorePackage struct {
 type PoliciesConfig struct {
 	Allow *PolicyPackages `json:"allow,omitempty" yaml:"allow,omitempty"`
 	Deny  *PolicyPackages `json:"deny,omitempty" yaml:"deny,omitempty"`
+	// MaxCvss is the highest CVSS score allowed for vulnerabilities. Zero means no limit.
+	// It is only checked by the CLI, so it is not uploaded.
+	MaxCvss float64 `json:"-" yaml:"maxCvss,omitempty"`
 }
 
 // PolicyPackages contains a list of package identifiers.
 type PolicyPackages struct {
 	Packages []string `json:"packages" yaml:"packages"`
+	// Licenses are only used in deny, where they list banned licenses.
+	// They are only checked by the CLI, so they are not uploaded.
+	Licenses []string `json:"-" yaml:"licenses,omitempty"`
 }
 
 type uploadFinish struct {
@@ -16,6 +16,7 @@ import (
 	"github.com/debricked/cli/internal/client/testdata"
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/git"
+	"github.com/debricked/cli/internal/scope"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -39,6 +40,7 @@ func TestUploadWithBadFiles(t *testing.T) {
 	clientMock.AddMockResponse(mockRes)
 	c = clientMock
 	batch := newUploadBatch(&c, groups, metaObj, "CLI", 10*60, true, &DebrickedConfig{}, true, false)
+	batch.progressFile = filepath.Join(t.TempDir(), OutputFileNameUploadProgress)
 	var buf bytes.Buffer
 	log.SetOutput(&buf)
 	err = batch.upload()
@@ -420,3 +422,232 @@ func TestGetDebrickedConfigPoliciesOnly(t *testing.T) {
 	assert.Nil(t, err)
 	assert.JSONEq(t, string(configJSON), string(expectedJSON))
 }
+
+func newTestBatch(t *testing.T, clientMock *testdata.DebClientMock, files ...string) *uploadBatch {
+	var groups file.Groups
+	for _, f := range files {
+		groups.Add(*file.NewGroup(f, nil, nil))
+	}
+	metaObj, err := git.NewMetaObject("", "repository-name", "commit-name", "", "", "")
+	assert.NoError(t, err)
+	var c client.IDebClient = clientMock
+	batch := newUploadBatch(&c, groups, metaObj, "CLI", 10*60, true, &DebrickedConfig{}, true, false)
+	batch.progressFile = filepath.Join(t.TempDir(), OutputFileNameUploadProgress)
+	batch.progress = readUploadProgress(batch.progressFile, metaObj)
+
+	return batch
+}
+
+func initializedResponse() testdata.MockResponse {
+	return testdata.MockResponse{
+		StatusCode:   http.StatusOK,
+		ResponseBody: io.NopCloser(strings.NewReader(`{"ciUploadId": 1}`)),
+	}
+}
+
+func TestUploadFileResult(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	clientMock.AddMockResponse(initializedResponse())
+	batch := newTestBatch(t, clientMock, "testdata/yarn/package.json")
+
+	result := batch.uploadFileResult("testdata/yarn/package.json", 0)
+
+	assert.NoError(t, result.Err)
+	assert.Equal(t, FileUploaded, result.Status)
+	assert.Equal(t, 1, batch.ciUploadId)
+	assert.True(t, batch.progress.uploaded("testdata/yarn/package.json"))
+}
+
+func TestUploadFileResultFailed(t *testing.T) {
+	cases := map[string]testdata.MockResponse{
+		"bad request":  {StatusCode: http.StatusBadRequest},
+		"empty file":   {StatusCode: http.StatusOK, ResponseBody: io.NopCloser(strings.NewReader(`{}`))},
+		"no response":  {Error: client.NoResErr},
+		"server error": {StatusCode: http.StatusServiceUnavailable},
+	}
+	for name, response := range cases {
+		t.Run(name, func(t *testing.T) {
+			clientMock := testdata.NewDebClientMock()
+			clientMock.AddMockResponse(response)
+			batch := newTestBatch(t, clientMock, "testdata/yarn/package.json")
+
+			result := batch.uploadFileResult("testdata/yarn/package.json", 0)
+
+			assert.Equal(t, FileFailed, result.Status)
+			assert.Error(t, result.Err)
+			assert.False(t, batch.progress.uploaded("testdata/yarn/package.json"))
+		})
+	}
+}
+
+func TestUploadFileResultMissingFile(t *testing.T) {
+	batch := newTestBatch(t, testdata.NewDebClientMock())
+
+	result := batch.uploadFileResult("testdata/does-not-exist.lock", 0)
+
+	assert.Equal(t, FileFailed, result.Status)
+	assert.ErrorIs(t, result.Err, os.ErrNotExist)
+}
+
+func TestUploadSkipsDuplicateFiles(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	clientMock.AddMockResponse(initializedResponse())
+	clientMock.AddMockResponse(testdata.MockResponse{StatusCode: http.StatusOK})
+	batch := newTestBatch(t, clientMock, "testdata/yarn/package.json", "testdata/yarn/yarn.lock", "testdata/yarn/package.json", "testdata/yarn/yarn.lock")
+
+	err := batch.upload()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, batch.summary.Count(FileUploaded))
+	assert.Equal(t, 2, batch.summary.Count(FileSkipped))
+	assert.Equal(t, 0, batch.summary.Count(FileFailed))
+	progress := readUploadProgress(batch.progressFile, batch.gitMetaObject)
+	assert.Equal(t, 1, progress.CiUploadId)
+	assert.Len(t, progress.Files, 2)
+}
+
+func TestUploadStoresNoProgressWithoutUploads(t *testing.T) {
+	batch := newTestBatch(t, testdata.NewDebClientMock())
+
+	err := batch.upload()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, batch.ciUploadId)
+	assert.NoFileExists(t, batch.progressFile)
+}
+
+func TestUploadResumesScan(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	clientMock.AddMockResponse(testdata.MockResponse{StatusCode: http.StatusOK})
+	batch := newTestBatch(t, clientMock, "testdata/yarn/package.json", "testdata/yarn/yarn.lock")
+	batch.progress.CiUploadId = 7
+	batch.progress.add("testdata/yarn/package.json")
+	assert.NoError(t, batch.progress.toFile(batch.progressFile))
+
+	err := batch.upload()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 7, batch.ciUploadId)
+	assert.Equal(t, []FileUpload{
+		{File: "testdata/yarn/package.json", Status: FileSkipped},
+		{File: "testdata/yarn/yarn.lock", Status: FileUploaded},
+	}, batch.summary.Uploads())
+	assert.Len(t, readUploadProgress(batch.progressFile, batch.gitMetaObject).Files, 2)
+}
+
+func TestUploadResumedScanRejected(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	clientMock.AddMockResponse(testdata.MockResponse{StatusCode: http.StatusBadRequest})
+	batch := newTestBatch(t, clientMock, "testdata/yarn/package.json")
+	batch.progress.CiUploadId = 7
+	assert.NoError(t, batch.progress.toFile(batch.progressFile))
+	log.SetOutput(io.Discard)
+
+	err := batch.upload()
+	log.SetOutput(os.Stderr)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, batch.summary.Count(FileFailed))
+	assert.NoFileExists(t, batch.progressFile)
+}
+
+func TestInitAnalysisRemovesUploadProgress(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	clientMock.AddMockResponse(testdata.MockResponse{StatusCode: http.StatusNoContent})
+	batch := newTestBatch(t, clientMock)
+	batch.ciUploadId = 1
+	batch.progress.CiUploadId = 1
+	assert.NoError(t, batch.progress.toFile(batch.progressFile))
+
+	err := batch.initAnalysis()
+
+	assert.NoError(t, err)
+	assert.NoFileExists(t, batch.progressFile)
+}
+
+func TestUploadFailOnUploadError(t *testing.T) {
+	for _, failOnUploadError := range []bool{false, true} {
+		clientMock := testdata.NewDebClientMock()
+		clientMock.AddMockResponse(initializedResponse())
+		clientMock.AddMockResponse(testdata.MockResponse{StatusCode: http.StatusBadRequest})
+		batch := newTestBatch(t, clientMock, "testdata/yarn/package.json", "testdata/yarn/yarn.lock")
+		batch.failOnUploadError = failOnUploadError
+		log.SetOutput(io.Discard)
+
+		err := batch.upload()
+		log.SetOutput(os.Stderr)
+
+		assert.Equal(t, 1, batch.summary.Count(FileUploaded))
+		assert.Equal(t, 1, batch.summary.Count(FileFailed))
+		if failOnUploadError {
+			assert.ErrorIs(t, err, UploadFailedErr)
+			assert.ErrorContains(t, err, "1 of 2 files failed")
+		} else {
+			assert.NoError(t, err)
+		}
+	}
+}
+
+func TestGetDebrickedConfigLocalPolicies(t *testing.T) {
+	config := GetDebrickedConfig(filepath.Join("testdata", "debricked-config-policies-local.yaml"))
+
+	assert.Equal(t, &PoliciesConfig{
+		Deny: &PolicyPackages{
+			Packages: []string{"pkg:npm/request"},
+			Licenses: []string{"GPL-3.0", "AGPL-3.0"},
+		},
+		MaxCvss: 7.5,
+	}, config.Policies)
+}
+
+func TestMarshalJSONDebrickedConfigLocalPolicies(t *testing.T) {
+	config := GetDebrickedConfig(filepath.Join("testdata", "debricked-config-policies-local.yaml"))
+
+	content, err := json.Marshal(config)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"policies": {"deny": {"packages": ["pkg:npm/request"]}}}`, string(content))
+}
+
+func TestFileContentFiltersScopes(t *testing.T) {
+	batch := newTestBatch(t, testdata.NewDebClientMock())
+	batch.fileGroups.Add(*file.NewGroup("testdata/yarn/package.json", nil, []string{"testdata/yarn/yarn.lock"}))
+	batch.scopes = scope.Filter{scope.Dev: true}
+
+	content, err := batch.fileContent("testdata/yarn/yarn.lock")
+
+	assert.NoError(t, err)
+	// express is a production dependency, along with every dependency of it
+	assert.NotContains(t, string(content), "express@")
+	assert.NotContains(t, string(content), "accepts@")
+	assert.Contains(t, string(content), "# yarn lockfile v1")
+}
+
+func TestFileContentKeepsScopes(t *testing.T) {
+	expected, err := os.ReadFile("testdata/yarn/yarn.lock")
+	assert.NoError(t, err)
+	for name, filter := range map[string]scope.Filter{"every scope": {}, "prod": {scope.Prod: true}} {
+		t.Run(name, func(t *testing.T) {
+			batch := newTestBatch(t, testdata.NewDebClientMock())
+			batch.fileGroups.Add(*file.NewGroup("testdata/yarn/package.json", nil, []string{"testdata/yarn/yarn.lock"}))
+			batch.scopes = filter
+
+			content, err := batch.fileContent("testdata/yarn/yarn.lock")
+
+			assert.NoError(t, err)
+			assert.Equal(t, expected, content)
+		})
+	}
+}
+
+func TestFileContentUnfilteredOnParseError(t *testing.T) {
+	lockFile := filepath.Join(t.TempDir(), "package-lock.json")
+	assert.NoError(t, os.WriteFile(lockFile, []byte("{"), 0600))
+	batch := newTestBatch(t, testdata.NewDebClientMock())
+	batch.scopes = scope.Filter{scope.Prod: true}
+
+	content, err := batch.fileContent(lockFile)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "{", string(content))
+}
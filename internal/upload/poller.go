@@ -0,0 +1,98 @@
+package upload
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/debricked/cli/internal/client"
+	"github.com/debricked/cli/internal/tui"
+)
+
+const DefaultPollInterval = time.Second
+
+type PollOptions struct {
+	// Interval is the time between status requests, DefaultPollInterval is used if it is not positive
+	Interval time.Duration
+	// MaxWait terminates polling once exceeded. Zero polls until the scan completes or Debricked reports a long queue.
+	MaxWait time.Duration
+}
+
+// NewPollOptions converts intervals and max waits given in seconds
+func NewPollOptions(intervalSeconds int, maxWaitSeconds int) PollOptions {
+	return PollOptions{
+		Interval: time.Duration(intervalSeconds) * time.Second,
+		MaxWait:  time.Duration(maxWaitSeconds) * time.Second,
+	}
+}
+
+type IStatusPoller interface {
+	Poll(ciUploadId int, options PollOptions) (*UploadResult, error)
+}
+
+// StatusPoller tracks the progress of a scan that has already been uploaded
+type StatusPoller struct {
+	client *client.IDebClient
+}
+
+func NewStatusPoller(c client.IDebClient) *StatusPoller {
+	return &StatusPoller{client: &c}
+}
+
+// Poll renders scan progress until the scan with ciUploadId completes. PollingTerminatedErr is returned, together with
+// a long queue result, if Debricked reports a long queue or options.MaxWait is exceeded.
+func (poller *StatusPoller) Poll(ciUploadId int, options PollOptions) (*UploadResult, error) {
+	interval := options.Interval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	bar := tui.NewProgressBar()
+	_ = bar.RenderBlank()
+	start := time.Now()
+	uri := fmt.Sprintf("/api/1.0/open/ci/upload/status?ciUploadId=%s", strconv.Itoa(ciUploadId))
+	for {
+		res, err := (*poller.client).Get(uri, "application/json")
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode >= http.StatusBadRequest {
+			_ = res.Body.Close()
+
+			return nil, fmt.Errorf("failed to get status of scan %d due to status code %d", ciUploadId, res.StatusCode)
+		}
+		status, err := newUploadStatus(res)
+		if err != nil {
+			return nil, err
+		}
+		if res.StatusCode == http.StatusCreated {
+			err := bar.Finish()
+			if err != nil {
+				return nil, err
+			}
+
+			return newLongQueueResult(status, ciUploadId), PollingTerminatedErr
+		}
+		err = bar.Set(status.Progress)
+		if err != nil {
+			return nil, err
+		}
+		if bar.IsFinished() {
+			return newUploadResult(status, ciUploadId), nil
+		}
+		if options.MaxWait > 0 && time.Since(start)+interval > options.MaxWait {
+			fmt.Println()
+
+			return newLongQueueResult(status, ciUploadId), PollingTerminatedErr
+		}
+		time.Sleep(interval)
+	}
+}
+
+func newLongQueueResult(status *uploadStatus, ciUploadId int) *UploadResult {
+	return &UploadResult{
+		DetailsUrl: status.DetailsUrl,
+		LongQueue:  true,
+		CiUploadId: ciUploadId,
+	}
+}
@@ -0,0 +1,78 @@
+package upload
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/debricked/cli/internal/client/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+const statusUri = "/api/1.0/open/ci/upload/status"
+
+func statusResponse(statusCode int, body string) testdata.MockResponse {
+	return testdata.MockResponse{
+		StatusCode:   statusCode,
+		ResponseBody: io.NopCloser(strings.NewReader(body)),
+	}
+}
+
+func TestNewPollOptions(t *testing.T) {
+	options := NewPollOptions(5, 60)
+
+	assert.Equal(t, 5*time.Second, options.Interval)
+	assert.Equal(t, time.Minute, options.MaxWait)
+}
+
+func TestPoll(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	clientMock.AddMockUriResponse(statusUri, statusResponse(http.StatusOK, `{"progress": 10}`))
+	clientMock.AddMockUriResponse(statusUri, statusResponse(http.StatusOK, `{"progress": 60}`))
+	clientMock.AddMockUriResponse(statusUri, statusResponse(http.StatusOK, `{"progress": 100, "vulnerabilitiesFound": 3, "detailsUrl": "https://debricked.com/details"}`))
+
+	result, err := NewStatusPoller(clientMock).Poll(7, PollOptions{Interval: time.Millisecond})
+
+	assert.NoError(t, err)
+	assert.False(t, result.LongQueue)
+	assert.Equal(t, 3, result.VulnerabilitiesFound)
+	assert.Equal(t, "https://debricked.com/details", result.DetailsUrl)
+	assert.Equal(t, 7, result.CiUploadId)
+}
+
+func TestPollMaxWait(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	for i := 0; i < 10; i++ {
+		clientMock.AddMockUriResponse(statusUri, statusResponse(http.StatusOK, `{"progress": 10, "detailsUrl": "https://debricked.com/details"}`))
+	}
+
+	result, err := NewStatusPoller(clientMock).Poll(7, PollOptions{Interval: 20 * time.Millisecond, MaxWait: 50 * time.Millisecond})
+
+	assert.ErrorIs(t, err, PollingTerminatedErr)
+	assert.True(t, result.LongQueue)
+	assert.Equal(t, "https://debricked.com/details", result.DetailsUrl)
+	assert.Equal(t, 7, result.CiUploadId)
+}
+
+func TestPollLongQueue(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	clientMock.AddMockUriResponse(statusUri, statusResponse(http.StatusCreated, `{"detailsUrl": "https://debricked.com/details"}`))
+
+	result, err := NewStatusPoller(clientMock).Poll(7, PollOptions{})
+
+	assert.ErrorIs(t, err, PollingTerminatedErr)
+	assert.True(t, result.LongQueue)
+	assert.Equal(t, 7, result.CiUploadId)
+}
+
+func TestPollErrorStatus(t *testing.T) {
+	clientMock := testdata.NewDebClientMock()
+	clientMock.AddMockUriResponse(statusUri, statusResponse(http.StatusNotFound, `{}`))
+
+	result, err := NewStatusPoller(clientMock).Poll(7, PollOptions{})
+
+	assert.Nil(t, result)
+	assert.ErrorContains(t, err, "failed to get status of scan 7 due to status code 404")
+}
@@ -0,0 +1,90 @@
+package upload
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/debricked/cli/internal/git"
+)
+
+const OutputFileNameUploadProgress = "debricked.upload.json"
+
+// uploadProgress is stored while files are uploaded, and removed once the analysis has started. A later scan of the
+// same commit resumes the scan it belongs to, without uploading the files that are unchanged since.
+type uploadProgress struct {
+	CiUploadId     int    `json:"ciUploadId"`
+	RepositoryName string `json:"repositoryName"`
+	CommitName     string `json:"commitName"`
+	// Files maps the files uploaded for ciUploadId to the SHA-256 of their content
+	Files map[string]string `json:"files"`
+	mutex sync.Mutex
+}
+
+// readUploadProgress reads the progress stored at path. Progress of other commits, or progress that cannot be read,
+// is discarded.
+func readUploadProgress(path string, gitMetaObject *git.MetaObject) *uploadProgress {
+	progress := &uploadProgress{
+		RepositoryName: gitMetaObject.RepositoryName,
+		CommitName:     gitMetaObject.CommitName,
+		Files:          map[string]string{},
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return progress
+	}
+	var stored uploadProgress
+	if json.Unmarshal(content, &stored) != nil || stored.CiUploadId == 0 || stored.Files == nil {
+		return progress
+	}
+	if stored.RepositoryName != progress.RepositoryName || stored.CommitName != progress.CommitName {
+		return progress
+	}
+
+	return &stored
+}
+
+// uploaded reports whether filePath was uploaded with its current content
+func (progress *uploadProgress) uploaded(filePath string) bool {
+	hash, err := hashFile(filePath)
+	if err != nil {
+		return false
+	}
+	progress.mutex.Lock()
+	defer progress.mutex.Unlock()
+
+	return progress.Files[filepath.Clean(filePath)] == hash
+}
+
+// add records that filePath has been uploaded
+func (progress *uploadProgress) add(filePath string) {
+	hash, err := hashFile(filePath)
+	if err != nil {
+		return
+	}
+	progress.mutex.Lock()
+	defer progress.mutex.Unlock()
+	progress.Files[filepath.Clean(filePath)] = hash
+}
+
+func (progress *uploadProgress) toFile(path string) error {
+	progress.mutex.Lock()
+	defer progress.mutex.Unlock()
+	content, err := json.MarshalIndent(progress, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0600)
+}
+
+func removeUploadProgress(path string) error {
+	err := os.Remove(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
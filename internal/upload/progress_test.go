@@ -0,0 +1,53 @@
+package upload
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/debricked/cli/internal/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadUploadProgress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), OutputFileNameUploadProgress)
+	metaObject := &git.MetaObject{RepositoryName: "repository-name", CommitName: "commit-name"}
+	progress := readUploadProgress(path, metaObject)
+	assert.Zero(t, progress.CiUploadId)
+	assert.False(t, progress.uploaded("testdata/yarn/yarn.lock"))
+
+	progress.CiUploadId = 7
+	progress.add("testdata/yarn/yarn.lock")
+	progress.add("testdata/does-not-exist.lock")
+	assert.NoError(t, progress.toFile(path))
+
+	stored := readUploadProgress(path, metaObject)
+	assert.Equal(t, 7, stored.CiUploadId)
+	assert.True(t, stored.uploaded("testdata/yarn/yarn.lock"))
+	assert.False(t, stored.uploaded("testdata/yarn/package.json"))
+	assert.False(t, stored.uploaded("testdata/does-not-exist.lock"))
+
+	// Progress of another commit belongs to another scan
+	other := readUploadProgress(path, &git.MetaObject{RepositoryName: "repository-name", CommitName: "other"})
+	assert.Zero(t, other.CiUploadId)
+	assert.Empty(t, other.Files)
+}
+
+func TestReadUploadProgressInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), OutputFileNameUploadProgress)
+	assert.NoError(t, os.WriteFile(path, []byte("{"), 0600))
+
+	progress := readUploadProgress(path, &git.MetaObject{})
+
+	assert.Zero(t, progress.CiUploadId)
+	assert.NotNil(t, progress.Files)
+}
+
+func TestRemoveUploadProgress(t *testing.T) {
+	path := filepath.Join(t.TempDir(), OutputFileNameUploadProgress)
+	assert.NoError(t, os.WriteFile(path, []byte("{}"), 0600))
+
+	assert.NoError(t, removeUploadProgress(path))
+	assert.NoFileExists(t, path)
+	assert.NoError(t, removeUploadProgress(path))
+}
@@ -11,9 +11,11 @@ type UploadResult struct {
 	AutomationRules                []automation.Rule `json:"automationRules"`
 	DetailsUrl                     string            `json:"detailsUrl"`
 	LongQueue                      bool
+	// CiUploadId identifies the scan, so that its status can be polled later on
+	CiUploadId int `json:"ciUploadId,omitempty"`
 }
 
-func newUploadResult(status *uploadStatus) *UploadResult {
+func newUploadResult(status *uploadStatus, ciUploadId int) *UploadResult {
 	return &UploadResult{
 		status.VulnerabilitiesFound,
 		status.UnaffectedVulnerabilitiesFound,
@@ -21,5 +23,6 @@ func newUploadResult(status *uploadStatus) *UploadResult {
 		status.AutomationRules,
 		status.DetailsUrl,
 		false,
+		ciUploadId,
 	}
 }
@@ -15,7 +15,8 @@ func TestNewUploadResult(t *testing.T) {
 		AutomationRules:                nil,
 		DetailsUrl:                     "",
 	}
-	result := newUploadResult(status)
+	result := newUploadResult(status, 1)
 
 	assert.NotNil(t, result)
+	assert.Equal(t, 1, result.CiUploadId)
 }
@@ -0,0 +1,106 @@
+package upload
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/jedib0t/go-pretty/v6/text"
+)
+
+const (
+	FileUploaded = "uploaded"
+	FileSkipped  = "skipped"
+	FileFailed   = "failed"
+)
+
+// FileUpload is the outcome of uploading a single dependency file
+type FileUpload struct {
+	File   string
+	Status string
+	Err    error
+}
+
+// Summary collects the outcome of every file in an upload batch. It is safe for concurrent use.
+type Summary struct {
+	mutex   sync.Mutex
+	uploads []FileUpload
+}
+
+func (summary *Summary) add(upload FileUpload) {
+	summary.mutex.Lock()
+	defer summary.mutex.Unlock()
+	summary.uploads = append(summary.uploads, upload)
+}
+
+// Uploads returns the file outcomes sorted on file name
+func (summary *Summary) Uploads() []FileUpload {
+	summary.mutex.Lock()
+	defer summary.mutex.Unlock()
+	uploads := append([]FileUpload(nil), summary.uploads...)
+	sort.SliceStable(uploads, func(i, j int) bool {
+		return uploads[i].File < uploads[j].File
+	})
+
+	return uploads
+}
+
+// Count returns the number of files with status
+func (summary *Summary) Count(status string) int {
+	count := 0
+	for _, upload := range summary.Uploads() {
+		if upload.Status == status {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Render writes a table with the status of every file, followed by the reasons the failed files failed
+func (summary *Summary) Render(mirror io.Writer) {
+	uploads := summary.Uploads()
+	if len(uploads) == 0 {
+		return
+	}
+
+	t := table.NewWriter()
+	t.SetOutputMirror(mirror)
+	style := table.StyleRounded
+	style.Format.Footer = text.FormatDefault
+	t.SetStyle(style)
+	t.AppendHeader(table.Row{"File", "Status"})
+	for _, upload := range uploads {
+		t.AppendRow(table.Row{upload.File, colorStatus(upload.Status)})
+	}
+	t.AppendFooter(table.Row{
+		fmt.Sprintf(
+			"%d uploaded, %d skipped, %d failed",
+			summary.Count(FileUploaded),
+			summary.Count(FileSkipped),
+			summary.Count(FileFailed),
+		),
+	})
+	t.Render()
+
+	for _, upload := range uploads {
+		if upload.Status == FileFailed && upload.Err != nil {
+			_, _ = fmt.Fprintf(mirror, "%s %s: %s\n", color.RedString("✖"), upload.File, upload.Err.Error())
+		}
+	}
+	_, _ = fmt.Fprintln(mirror)
+}
+
+func colorStatus(status string) string {
+	switch status {
+	case FileUploaded:
+		return color.GreenString(status)
+	case FileFailed:
+		return color.RedString(status)
+	default:
+		return color.YellowString(status)
+	}
+}
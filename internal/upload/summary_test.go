@@ -0,0 +1,46 @@
+package upload
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSummary(t *testing.T) {
+	summary := &Summary{}
+	summary.add(FileUpload{File: "yarn.lock", Status: FileUploaded})
+	summary.add(FileUpload{File: "composer.lock", Status: FileFailed, Err: errors.New("upload failed due to status code 503")})
+	summary.add(FileUpload{File: "package.json", Status: FileSkipped})
+
+	uploads := summary.Uploads()
+
+	assert.Equal(t, []string{"composer.lock", "package.json", "yarn.lock"}, []string{uploads[0].File, uploads[1].File, uploads[2].File})
+	assert.Equal(t, 1, summary.Count(FileUploaded))
+	assert.Equal(t, 1, summary.Count(FileSkipped))
+	assert.Equal(t, 1, summary.Count(FileFailed))
+}
+
+func TestSummaryRender(t *testing.T) {
+	summary := &Summary{}
+	summary.add(FileUpload{File: "yarn.lock", Status: FileUploaded})
+	summary.add(FileUpload{File: "composer.lock", Status: FileFailed, Err: errors.New("upload failed due to status code 503")})
+	var buf bytes.Buffer
+
+	summary.Render(&buf)
+
+	output := buf.String()
+	assert.Contains(t, output, "FILE")
+	assert.Contains(t, output, "yarn.lock")
+	assert.Contains(t, output, "1 uploaded, 0 skipped, 1 failed")
+	assert.Contains(t, output, "composer.lock: upload failed due to status code 503")
+}
+
+func TestSummaryRenderEmpty(t *testing.T) {
+	var buf bytes.Buffer
+
+	(&Summary{}).Render(&buf)
+
+	assert.Empty(t, buf.String())
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/debricked/cli/internal/client"
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/git"
+	"github.com/debricked/cli/internal/scope"
 )
 
 type IOptions interface{}
@@ -19,6 +20,15 @@ type DebrickedOptions struct {
 	DebrickedConfig        *DebrickedConfig
 	TagCommitAsRelease     bool
 	Experimental           bool
+	// FailOnUploadError fails the upload if any dependency file failed to upload, even after retries
+	FailOnUploadError bool
+	PollOptions       PollOptions
+	// Scopes filters the dependencies of native lock files, such as package-lock.json, keeping every scope if empty
+	Scopes scope.Filter
+	// Detach returns as soon as the scan has been started, without waiting for the result
+	Detach bool
+	// ProgressFile stores the upload progress to resume interrupted scans, OutputFileNameUploadProgress if empty
+	ProgressFile string
 }
 
 type IUploader interface {
@@ -50,6 +60,12 @@ func (uploader *Uploader) Upload(o IOptions) (*UploadResult, error) {
 		dOptions.TagCommitAsRelease,
 		dOptions.Experimental,
 	)
+	batch.failOnUploadError = dOptions.FailOnUploadError
+	batch.pollOptions = dOptions.PollOptions
+	batch.scopes = dOptions.Scopes
+	if len(dOptions.ProgressFile) > 0 {
+		batch.progressFile = dOptions.ProgressFile
+	}
 
 	err := batch.upload()
 	if err != nil {
@@ -60,6 +76,9 @@ func (uploader *Uploader) Upload(o IOptions) (*UploadResult, error) {
 	if err != nil {
 		return nil, err
 	}
+	if dOptions.Detach {
+		return &UploadResult{CiUploadId: batch.ciUploadId}, nil
+	}
 
 	result, err := batch.wait()
 	if err != nil {
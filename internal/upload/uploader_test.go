@@ -48,6 +48,29 @@ func TestUpload(t *testing.T) {
 	assert.NotNil(t, result)
 }
 
+func TestUploadDetach(t *testing.T) {
+	debClientMock := testdata.NewDebClientMock()
+	debClientMock.AddMockUriResponse("/api/1.0/open/uploads/dependencies/files", testdata.MockResponse{
+		StatusCode:   http.StatusOK,
+		ResponseBody: io.NopCloser(strings.NewReader("{\"ciUploadId\": 42}")),
+	})
+	debClientMock.AddMockUriResponse("/api/1.0/open/finishes/dependencies/files/uploads", testdata.MockResponse{
+		StatusCode:   http.StatusNoContent,
+		ResponseBody: io.NopCloser(strings.NewReader("{}")),
+	})
+	uploader, _ := NewUploader(debClientMock)
+	metaObject, _ := git.NewMetaObject("testdata/npm", "testdata/npm", "testdata/npm-commit", "", "", "")
+	groups := file.Groups{}
+	groups.Add(*file.NewGroup("testdata/yarn/package.json", nil, nil))
+
+	result, err := uploader.Upload(DebrickedOptions{FileGroups: groups, GitMetaObject: *metaObject, Detach: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, result.CiUploadId)
+	assert.False(t, result.LongQueue)
+	assert.Empty(t, result.DetailsUrl)
+}
+
 func TestUploadPollingError(t *testing.T) {
 	debClientMock := testdata.NewDebClientMock()
 	// Create mocked file upload response
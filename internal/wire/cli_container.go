@@ -5,9 +5,11 @@ import (
 
 	"github.com/debricked/cli/internal/auth"
 	"github.com/debricked/cli/internal/callgraph"
+	"github.com/debricked/cli/internal/callgraph/query"
 	callgraphStrategy "github.com/debricked/cli/internal/callgraph/strategy"
 	"github.com/debricked/cli/internal/ci"
 	"github.com/debricked/cli/internal/client"
+	"github.com/debricked/cli/internal/config"
 	"github.com/debricked/cli/internal/file"
 	"github.com/debricked/cli/internal/fingerprint"
 	"github.com/debricked/cli/internal/io"
@@ -80,8 +82,9 @@ func (cc *CliContainer) wire() error {
 		cc.cgStrategyFactory,
 		cc.cgScheduler,
 	)
+	cc.callgraphQuerier = query.NewQuerier()
 
-	cc.scanner = scan.NewDebrickedScanner(
+	scanner := scan.NewDebrickedScanner(
 		&cc.debClient,
 		cc.finder,
 		cc.uploader,
@@ -90,11 +93,14 @@ func (cc *CliContainer) wire() error {
 		cc.fingerprinter,
 		cc.callgraph,
 	)
+	cc.scanner = scanner
+	cc.statusChecker = scanner
 
 	cc.licenseReporter = licenseReport.Reporter{DebClient: cc.debClient}
 	cc.vulnerabilityReporter = vulnerabilityReport.Reporter{DebClient: cc.debClient}
 	cc.sbomReporter = sbomReport.Reporter{DebClient: cc.debClient, FileWriter: io.FileWriter{}}
 	cc.authenticator = cc.debClient.Authenticator()
+	cc.configValidator = config.NewValidator(cc.finder)
 
 	return nil
 }
@@ -107,6 +113,7 @@ type CliContainer struct {
 	uploader              upload.IUploader
 	ciService             ci.IService
 	scanner               scan.IScanner
+	statusChecker         scan.IStatusChecker
 	resolver              resolution.IResolver
 	scheduler             resolution.IScheduler
 	strategyFactory       strategy.IFactory
@@ -117,7 +124,9 @@ type CliContainer struct {
 	callgraph             callgraph.IGenerator
 	cgScheduler           callgraph.IScheduler
 	cgStrategyFactory     callgraphStrategy.IFactory
+	callgraphQuerier      query.IQuerier
 	authenticator         auth.IAuthenticator
+	configValidator       config.IValidator
 }
 
 func (cc *CliContainer) DebClient() client.IDebClient {
@@ -132,6 +141,10 @@ func (cc *CliContainer) Scanner() scan.IScanner {
 	return cc.scanner
 }
 
+func (cc *CliContainer) StatusChecker() scan.IStatusChecker {
+	return cc.statusChecker
+}
+
 func (cc *CliContainer) Resolver() resolution.IResolver {
 	return cc.resolver
 }
@@ -140,6 +153,10 @@ func (cc *CliContainer) CallgraphGenerator() callgraph.IGenerator {
 	return cc.callgraph
 }
 
+func (cc *CliContainer) CallgraphQuerier() query.IQuerier {
+	return cc.callgraphQuerier
+}
+
 func (cc *CliContainer) LicenseReporter() licenseReport.Reporter {
 	return cc.licenseReporter
 }
@@ -160,6 +177,10 @@ func (cc *CliContainer) Authenticator() auth.IAuthenticator {
 	return cc.authenticator
 }
 
+func (cc *CliContainer) ConfigValidator() config.IValidator {
+	return cc.configValidator
+}
+
 func wireErr(err error) error {
 	return fmt.Errorf("failed to wire with cli-container. Error %s", err)
 }
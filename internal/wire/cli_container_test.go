@@ -37,9 +37,11 @@ func assertCliContainer(t *testing.T, cc *CliContainer) {
 	assert.NotNil(t, cc.Scanner())
 	assert.NotNil(t, cc.Resolver())
 	assert.NotNil(t, cc.CallgraphGenerator())
+	assert.NotNil(t, cc.CallgraphQuerier())
 	assert.NotNil(t, cc.LicenseReporter())
 	assert.NotNil(t, cc.VulnerabilityReporter())
 	assert.NotNil(t, cc.Fingerprinter())
 	assert.NotNil(t, cc.Authenticator())
+	assert.NotNil(t, cc.ConfigValidator())
 	assert.NotNil(t, cc.SBOMReporter())
 }